@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/nodeagent"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type options struct {
+	listenAddress       string
+	trivyPath           string
+	cacheDir            string
+	containerdAddress   string
+	containerdNamespace string
+	maxConcurrentScans  int
+	dbUpdateInterval    time.Duration
+	tlsCertFile         string
+	tlsKeyFile          string
+	tokenAudience       string
+	allowedUsers        []string
+}
+
+// main is the entrypoint of the node agent, which scans images present in
+// the container runtime of the node on request of the operator.
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("error: %s", err.Error())
+	}
+}
+
+func run() error {
+	opt := options{}
+
+	rootCmd := &cobra.Command{
+		Use:           "starboard-node-agent",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+			return serve(ctx, opt)
+		},
+	}
+
+	rootCmd.Flags().StringVar(&opt.listenAddress, "listen-address", ":8090", "Address of scan requests")
+	rootCmd.Flags().StringVar(&opt.trivyPath, "trivy-path", "trivy", "Path of the Trivy executable")
+	rootCmd.Flags().StringVar(&opt.cacheDir, "cache-dir", "/var/lib/trivy", "Cache directory of Trivy")
+	rootCmd.Flags().StringVar(&opt.containerdAddress, "containerd-address", "/run/containerd/containerd.sock", "Address of the containerd socket of the node")
+	rootCmd.Flags().StringVar(&opt.containerdNamespace, "containerd-namespace", "k8s.io", "Containerd namespace of images pulled by the kubelet")
+	rootCmd.Flags().IntVar(&opt.maxConcurrentScans, "max-concurrent-scans", 2, "Maximum number of concurrent scans")
+	rootCmd.Flags().DurationVar(&opt.dbUpdateInterval, "db-update-interval", 6*time.Hour, "Interval of updating the vulnerability database")
+	rootCmd.Flags().StringVar(&opt.tlsCertFile, "tls-cert-file", "", "Path of the TLS certificate")
+	rootCmd.Flags().StringVar(&opt.tlsKeyFile, "tls-key-file", "", "Path of the TLS key")
+	rootCmd.Flags().StringVar(&opt.tokenAudience, "token-audience", nodeagent.TokenAudience, "Audience of ServiceAccount tokens of callers")
+	rootCmd.Flags().StringSliceVar(&opt.allowedUsers, "allowed-users", nil, "Users allowed to send scan requests, e.g. system:serviceaccount:starboard-system:starboard-operator")
+	_ = rootCmd.MarkFlagRequired("tls-cert-file")
+	_ = rootCmd.MarkFlagRequired("tls-key-file")
+	_ = rootCmd.MarkFlagRequired("allowed-users")
+
+	return rootCmd.Execute()
+}
+
+// serve downloads the vulnerability database, and then serves scan requests
+// over HTTPS until the context is cancelled, while updating the database every
+// update interval. Tokens of callers are reviewed with the in-cluster config.
+func serve(ctx context.Context, opt options) error {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	auth := nodeagent.NewKubeAuth(clientset, opt.tokenAudience)
+
+	scanner := nodeagent.NewTrivyScanner(opt.trivyPath, opt.cacheDir, opt.containerdAddress,
+		opt.containerdNamespace, opt.maxConcurrentScans)
+	log.Println("Downloading vulnerability database")
+	if err := scanner.UpdateDB(ctx); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(opt.dbUpdateInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := scanner.UpdateDB(ctx); err != nil {
+					log.Printf("Unable to update vulnerability database: %s", err)
+				}
+			}
+		}
+	}()
+
+	server := &http.Server{
+		Addr:    opt.listenAddress,
+		Handler: nodeagent.NewHandler(scanner, auth, opt.allowedUsers),
+		TLSConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	log.Printf("Listening on %s", opt.listenAddress)
+	if err := server.ListenAndServeTLS(opt.tlsCertFile, opt.tlsKeyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
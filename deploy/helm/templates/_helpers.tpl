@@ -63,3 +63,11 @@ Create the name of the service account to use.
 {{- default "default" .Values.serviceAccount.name }}
 {{- end }}
 {{- end }}
+
+{{/*
+Create the name of the namespace where scan jobs and configuration of plugins
+are created.
+*/}}
+{{- define "starboard-operator.scanJobsNamespace" -}}
+{{- default .Release.Namespace .Values.operator.scanJobsNamespace }}
+{{- end }}
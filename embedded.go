@@ -20,6 +20,8 @@ var (
 	kubeBenchReportsCRD []byte
 	//go:embed deploy/crd/kubehunterreports.crd.yaml
 	kubeHunterReportsCRD []byte
+	//go:embed deploy/crd/imagesignaturereports.crd.yaml
+	imageSignatureReportsCRD []byte
 )
 
 func GetVulnerabilityReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
@@ -46,6 +48,10 @@ func GetKubeHunterReportsCRD() (apiextensionsv1.CustomResourceDefinition, error)
 	return getCRDFromBytes(kubeHunterReportsCRD)
 }
 
+func GetImageSignatureReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(imageSignatureReportsCRD)
+}
+
 func getCRDFromBytes(bytes []byte) (apiextensionsv1.CustomResourceDefinition, error) {
 	var crd apiextensionsv1.CustomResourceDefinition
 	_, _, err := scheme.Codecs.UniversalDecoder().Decode(bytes, nil, &crd)
@@ -145,6 +145,21 @@ var _ = Describe("Starboard CLI", func() {
 						}),
 					}),
 				}),
+				"imagesignaturereports.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Group":   Equal("aquasecurity.github.io"),
+						"Version": Equal("v1alpha1"),
+						"Scope":   Equal(apiextensionsv1beta1.NamespaceScoped),
+						"Names": Equal(apiextensionsv1beta1.CustomResourceDefinitionNames{
+							Plural:     "imagesignaturereports",
+							Singular:   "imagesignaturereport",
+							ShortNames: []string{"imagesig", "imagesigs"},
+							Kind:       "ImageSignatureReport",
+							ListKind:   "ImageSignatureReportList",
+							Categories: []string{"all"},
+						}),
+					}),
+				}),
 			}))
 
 			err = kubeClient.Get(context.TODO(), types.NamespacedName{
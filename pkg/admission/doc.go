@@ -0,0 +1,10 @@
+// Package admission provides a validating admission webhook of custom
+// resources that configure the operator, such as ScanPolicies and the
+// OperatorConfig, and of ClusterVulnerabilityReports submitted for image
+// digests.
+//
+// The webhook is registered with the Kubernetes API server by a
+// ValidatingWebhookConfiguration, so that malformed durations, unknown
+// severities, or invalid settings of plugins are rejected when the resources
+// are created or updated, instead of failing later inside reconcilers.
+package admission
@@ -0,0 +1,107 @@
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type handler struct {
+	logger    logr.Logger
+	validator *Validator
+}
+
+// NewHandler constructs a new http.Handler that serves AdmissionReviews of
+// the Kubernetes API server. Creates and updates of ScanPolicies,
+// OperatorConfigs, and submitted ClusterVulnerabilityReports are allowed if
+// the validator finds no problems, whereas other requests are always allowed.
+func NewHandler(logger logr.Logger, validator *Validator) http.Handler {
+	return &handler{logger: logger, validator: validator}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(req.Body).Decode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("decoding admission review: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "admission review without request", http.StatusBadRequest)
+		return
+	}
+
+	response := h.review(req, review.Request)
+	response.UID = review.Request.UID
+	review.Request = nil
+	review.Response = response
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&review)
+}
+
+func (h *handler) review(req *http.Request, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	log := h.logger.WithValues("kind", request.Kind.Kind, "name", request.Name, "namespace", request.Namespace)
+
+	var warnings []string
+	var problems []error
+	switch request.Kind.Kind {
+	case v1alpha1.ScanPolicyKind:
+		var policy v1alpha1.ScanPolicy
+		if err := json.Unmarshal(request.Object.Raw, &policy); err != nil {
+			return denied(request.Kind.Kind, request.Name, []error{err})
+		}
+		warnings, problems = h.validator.ValidateScanPolicy(policy)
+	case v1alpha1.OperatorConfigKind:
+		var config v1alpha1.OperatorConfig
+		if err := json.Unmarshal(request.Object.Raw, &config); err != nil {
+			return denied(request.Kind.Kind, request.Name, []error{err})
+		}
+		warnings, problems = h.validator.ValidateOperatorConfig(req.Context(), config)
+	case v1alpha1.ClusterVulnerabilityReportKind:
+		var report v1alpha1.ClusterVulnerabilityReport
+		if err := json.Unmarshal(request.Object.Raw, &report); err != nil {
+			return denied(request.Kind.Kind, request.Name, []error{err})
+		}
+		problems = h.validator.ValidateSubmittedReport(report)
+	default:
+		log.V(1).Info("Allowing resource of unsupported kind")
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	if len(problems) > 0 {
+		log.Info("Denying invalid resource", "problems", len(problems))
+		response := denied(request.Kind.Kind, request.Name, problems)
+		response.Warnings = warnings
+		return response
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true, Warnings: warnings}
+}
+
+// denied returns the response that denies the resource of the specified kind
+// and name because of the specified problems.
+func denied(kind, name string, problems []error) *admissionv1.AdmissionResponse {
+	messages := make([]string, len(problems))
+	for i, problem := range problems {
+		messages[i] = problem.Error()
+	}
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: fmt.Sprintf("%s %s is invalid: %s", kind, name, strings.Join(messages, "; ")),
+			Reason:  metav1.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package admission_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/admission"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestHandler(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+	handler := admission.NewHandler(log.Log, admission.NewValidator(c, etc.Config{Namespace: "starboard-system"}))
+
+	review := func(t *testing.T, operation admissionv1.Operation, kind, name, object string) *admissionv1.AdmissionResponse {
+		t.Helper()
+		body, err := json.Marshal(&admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+			Request: &admissionv1.AdmissionRequest{
+				UID:       "705ab4f5-6393-11e8-b7cc-42010a800002",
+				Kind:      metav1.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: kind},
+				Name:      name,
+				Namespace: "team-a",
+				Operation: operation,
+				Object:    runtime.RawExtension{Raw: []byte(object)},
+			},
+		})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(string(body))))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var response admissionv1.AdmissionReview
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		require.NotNil(t, response.Response)
+		assert.Equal(t, "705ab4f5-6393-11e8-b7cc-42010a800002", string(response.Response.UID))
+		return response.Response
+	}
+
+	t.Run("Should allow valid ScanPolicy", func(t *testing.T) {
+		response := review(t, admissionv1.Create, "ScanPolicy", "starboard",
+			`{"metadata":{"name":"starboard","namespace":"team-a"},"spec":{"severities":["CRITICAL"],"vulnerabilityReportTTL":"24h"}}`)
+		assert.True(t, response.Allowed)
+		assert.Empty(t, response.Warnings)
+	})
+
+	t.Run("Should deny ScanPolicy with malformed duration", func(t *testing.T) {
+		response := review(t, admissionv1.Update, "ScanPolicy", "starboard",
+			`{"metadata":{"name":"starboard","namespace":"team-a"},"spec":{"vulnerabilityReportTTL":"3 days"}}`)
+		assert.False(t, response.Allowed)
+		require.NotNil(t, response.Result)
+		assert.Equal(t, metav1.StatusReasonInvalid, response.Result.Reason)
+		assert.Equal(t, int32(http.StatusUnprocessableEntity), response.Result.Code)
+		assert.Equal(t, `ScanPolicy starboard is invalid: time: unknown unit " days" in duration "3 days"`, response.Result.Message)
+	})
+
+	t.Run("Should deny invalid ScanPolicy with warnings", func(t *testing.T) {
+		response := review(t, admissionv1.Create, "ScanPolicy", "team-a",
+			`{"metadata":{"name":"team-a","namespace":"team-a"},"spec":{"vulnerabilitySLA":{"URGENT":1}}}`)
+		assert.False(t, response.Allowed)
+		assert.Equal(t, "ScanPolicy team-a is invalid: invalid severity (URGENT) in vulnerabilitySLA; allowed values (CRITICAL, HIGH, MEDIUM, LOW, UNKNOWN)",
+			response.Result.Message)
+		assert.Equal(t, []string{"ScanPolicy team-a is ignored by the operator, which only applies the ScanPolicy named starboard"},
+			response.Warnings)
+	})
+
+	t.Run("Should deny OperatorConfig with malformed duration", func(t *testing.T) {
+		response := review(t, admissionv1.Create, "OperatorConfig", "starboard",
+			`{"metadata":{"name":"starboard","namespace":"starboard-system"},"spec":{"scanJobTimeout":"ten minutes"}}`)
+		assert.False(t, response.Allowed)
+		assert.Equal(t, `OperatorConfig starboard is invalid: time: invalid duration "ten minutes"`, response.Result.Message)
+	})
+
+	t.Run("Should deny submitted ClusterVulnerabilityReport with malformed digest", func(t *testing.T) {
+		response := review(t, admissionv1.Create, "ClusterVulnerabilityReport", "acme-app",
+			`{"metadata":{"name":"acme-app","labels":{"starboard.report.submitted":"true","starboard.container.image-digest":"4ed1d8ba4b1d"}},`+
+				`"report":{"updateTimestamp":"2022-03-01T12:00:00Z","scanner":{"name":"Trivy"},"artifact":{"repository":"acme/app","digest":"sha256:4ed1d8ba4b1d"}}}`)
+		assert.False(t, response.Allowed)
+		assert.Contains(t, response.Result.Message, "ClusterVulnerabilityReport acme-app is invalid: invalid report.artifact.digest (sha256:4ed1d8ba4b1d)")
+	})
+
+	t.Run("Should allow ClusterVulnerabilityReport that is not submitted", func(t *testing.T) {
+		response := review(t, admissionv1.Create, "ClusterVulnerabilityReport", "acme-app",
+			`{"metadata":{"name":"acme-app"},"report":{}}`)
+		assert.True(t, response.Allowed)
+	})
+
+	t.Run("Should allow deletes", func(t *testing.T) {
+		response := review(t, admissionv1.Delete, "ScanPolicy", "starboard", "null")
+		assert.True(t, response.Allowed)
+	})
+
+	t.Run("Should allow unsupported kinds", func(t *testing.T) {
+		response := review(t, admissionv1.Create, "VulnerabilityReport", "replicaset-nginx", `{}`)
+		assert.True(t, response.Allowed)
+	})
+
+	t.Run("Should reject requests other than POST", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/validate", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
@@ -0,0 +1,57 @@
+package admission
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Server serves the webhook over HTTPS until the context passed to Start is
+// cancelled. It implements manager.Runnable, so that it's started by the
+// manager of controllers.
+type Server struct {
+	// Addr is the TCP address that the server listens on.
+	Addr string
+	// CertFile and KeyFile are paths of the TLS certificate and key, which
+	// are required because the Kubernetes API server only calls webhooks over
+	// HTTPS.
+	CertFile string
+	KeyFile  string
+	Handler  http.Handler
+}
+
+// Start serves the webhook until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:    s.Addr,
+		Handler: s.Handler,
+		TLSConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errs := make(chan error, 1)
+	go func() {
+		err := server.ListenAndServeTLS(s.CertFile, s.KeyFile)
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		errs <- err
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so that the
+// webhook is served by every replica of the operator.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
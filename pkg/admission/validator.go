@@ -0,0 +1,191 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operatorconfig"
+	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Validator validates custom resources that configure the operator with the
+// specified configuration.
+type Validator struct {
+	client client.Client
+	config etc.Config
+}
+
+// NewValidator constructs a new Validator, which reads ConfigMaps of plugins
+// and the etc.ConfigMapName ConfigMap with the specified client.
+func NewValidator(client client.Client, config etc.Config) *Validator {
+	return &Validator{client: client, config: config}
+}
+
+// ValidateScanPolicy returns warnings and problems of the specified
+// ScanPolicy, or nil if the policy is valid.
+func (v *Validator) ValidateScanPolicy(policy v1alpha1.ScanPolicy) ([]string, []error) {
+	var warnings []string
+	if policy.Name != v1alpha1.ScanPolicyName {
+		warnings = append(warnings, fmt.Sprintf("ScanPolicy %s is ignored by the operator, which only applies the ScanPolicy named %s",
+			policy.Name, v1alpha1.ScanPolicyName))
+	}
+
+	var problems []error
+	seen := make(map[v1alpha1.Severity]bool)
+	for _, severity := range policy.Spec.Severities {
+		if !isSeverity(severity) {
+			problems = append(problems, invalidSeverity(severity, "severities"))
+			continue
+		}
+		if seen[severity] {
+			problems = append(problems, fmt.Errorf("duplicate severity (%s) in severities", severity))
+		}
+		seen[severity] = true
+	}
+	for i, id := range policy.Spec.IgnoredVulnerabilities {
+		if strings.TrimSpace(id) == "" {
+			problems = append(problems, fmt.Errorf("empty vulnerability ID at index %d of ignoredVulnerabilities", i))
+		}
+	}
+	if ttl := policy.Spec.VulnerabilityReportTTL; ttl != nil && ttl.Duration <= 0 {
+		problems = append(problems, fmt.Errorf("invalid value (%s) of vulnerabilityReportTTL; expected a positive duration", ttl.Duration))
+	}
+	var severities []string
+	for severity := range policy.Spec.VulnerabilitySLA {
+		severities = append(severities, string(severity))
+	}
+	sort.Strings(severities)
+	for _, severity := range severities {
+		if !isSeverity(v1alpha1.Severity(severity)) {
+			problems = append(problems, invalidSeverity(v1alpha1.Severity(severity), "vulnerabilitySLA"))
+			continue
+		}
+		if days := policy.Spec.VulnerabilitySLA[v1alpha1.Severity(severity)]; days < 0 {
+			problems = append(problems, fmt.Errorf("invalid value (%d) of vulnerabilitySLA.%s; expected non-negative number of days", days, severity))
+		}
+	}
+	return warnings, problems
+}
+
+// ValidateSubmittedReport returns problems of the specified
+// ClusterVulnerabilityReport if it's submitted for an image digest, so that
+// pipelines learn about malformed submissions when they submit them, rather
+// than from scans that the operator runs instead. Other reports are valid.
+func (v *Validator) ValidateSubmittedReport(report v1alpha1.ClusterVulnerabilityReport) []error {
+	if !vulnerabilityreport.IsSubmittedReport(report) {
+		return nil
+	}
+	return vulnerabilityreport.ValidateSubmittedReport(report)
+}
+
+// ValidateOperatorConfig returns warnings and problems of the specified
+// OperatorConfig, or nil if the config is valid.
+//
+// Settings of plugins are merged with the current settings in ConfigMaps of
+// plugins and validated by plugins that implement
+// starboard.PluginConfigValidator. Settings of plugins whose ConfigMaps do not
+// exist yet are validated by the operator when the ConfigMaps are created.
+func (v *Validator) ValidateOperatorConfig(ctx context.Context, config v1alpha1.OperatorConfig) ([]string, []error) {
+	var warnings []string
+	if config.Name != v1alpha1.OperatorConfigName || config.Namespace != v.config.Namespace {
+		warnings = append(warnings, fmt.Sprintf("OperatorConfig %s/%s is ignored by the operator, which only applies the OperatorConfig %s/%s",
+			config.Namespace, config.Name, v.config.Namespace, v1alpha1.OperatorConfigName))
+	}
+
+	var problems []error
+	if ttl := config.Spec.VulnerabilityReportTTL; ttl != nil && ttl.Duration <= 0 {
+		problems = append(problems, fmt.Errorf("invalid value (%s) of vulnerabilityReportTTL; expected a positive duration", ttl.Duration))
+	}
+	if timeout := config.Spec.ScanJobTimeout; timeout != nil && timeout.Duration <= 0 {
+		problems = append(problems, fmt.Errorf("invalid value (%s) of scanJobTimeout; expected a positive duration", timeout.Duration))
+	}
+	overrides, err := operatorconfig.GetOverrides(ctx, v.client, v.config)
+	if err != nil {
+		return warnings, append(problems, err)
+	}
+	// Validate checks image references of plugins too, therefore settings of
+	// plugins are only validated by plugins if it succeeds.
+	if err := operatorconfig.Validate(config.Spec, overrides); err != nil {
+		return warnings, append(problems, err)
+	}
+
+	var plugins []string
+	for name := range config.Spec.Plugins {
+		plugins = append(plugins, name)
+	}
+	sort.Strings(plugins)
+	for _, name := range plugins {
+		for _, problem := range v.validatePluginSettings(name, config.Spec.Plugins[name]) {
+			problems = append(problems, fmt.Errorf("settings of %s plugin: %w", name, problem))
+		}
+	}
+	return warnings, problems
+}
+
+// validatePluginSettings returns problems of the specified settings of the
+// plugin with the specified name.
+func (v *Validator) validatePluginSettings(name string, settings map[string]string) []error {
+	resolver := plugin.NewResolver().
+		WithNamespace(v.config.GetScanJobsNamespace()).
+		WithServiceAccountName(v.config.ServiceAccount).
+		WithClient(v.client)
+	var p interface{}
+	var pluginContext starboard.PluginContext
+	var err error
+	switch starboard.Scanner(name) {
+	case starboard.Polaris, starboard.Conftest:
+		p, pluginContext, err = resolver.WithConfig(starboard.ConfigData{
+			"configAuditReports.scanner": name,
+		}).GetConfigAuditPlugin()
+	default:
+		p, pluginContext, err = resolver.WithConfig(starboard.ConfigData{
+			"vulnerabilityReports.scanner": name,
+		}).GetVulnerabilityPlugin()
+	}
+	if err != nil {
+		return []error{fmt.Errorf("unknown plugin; allowed values (%s, %s, %s, %s, %s, %s, %s)", starboard.Trivy,
+			starboard.Aqua, starboard.Harbor, starboard.Quay, starboard.External, starboard.Polaris, starboard.Conftest)}
+	}
+	validator, ok := p.(starboard.PluginConfigValidator)
+	if !ok {
+		return nil
+	}
+
+	pluginConfig, err := pluginContext.GetConfig()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return []error{err}
+	}
+	if pluginConfig.Data == nil {
+		pluginConfig.Data = make(map[string]string)
+	}
+	for key, value := range settings {
+		pluginConfig.Data[key] = value
+	}
+	return validator.ValidateConfig(pluginConfig)
+}
+
+func isSeverity(severity v1alpha1.Severity) bool {
+	switch severity {
+	case v1alpha1.SeverityCritical, v1alpha1.SeverityHigh, v1alpha1.SeverityMedium, v1alpha1.SeverityLow,
+		v1alpha1.SeverityUnknown:
+		return true
+	}
+	return false
+}
+
+func invalidSeverity(severity v1alpha1.Severity, field string) error {
+	return fmt.Errorf("invalid severity (%s) in %s; allowed values (%s, %s, %s, %s, %s)", severity, field,
+		v1alpha1.SeverityCritical, v1alpha1.SeverityHigh, v1alpha1.SeverityMedium, v1alpha1.SeverityLow,
+		v1alpha1.SeverityUnknown)
+}
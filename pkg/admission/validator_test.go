@@ -0,0 +1,194 @@
+package admission_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/admission"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidator_ValidateScanPolicy(t *testing.T) {
+	testCases := []struct {
+		name             string
+		policy           v1alpha1.ScanPolicy
+		expectedWarnings []string
+		expectedProblems []string
+	}{
+		{
+			name: "Should accept valid policy",
+			policy: v1alpha1.ScanPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "starboard"},
+				Spec: v1alpha1.ScanPolicySpec{
+					Severities:             []v1alpha1.Severity{v1alpha1.SeverityCritical, v1alpha1.SeverityHigh},
+					IgnoredVulnerabilities: []string{"CVE-2021-44228"},
+					VulnerabilityReportTTL: &metav1.Duration{Duration: 24 * time.Hour},
+					VulnerabilitySLA:       map[v1alpha1.Severity]int{v1alpha1.SeverityCritical: 7},
+				},
+			},
+		},
+		{
+			name: "Should warn about policy that is ignored",
+			policy: v1alpha1.ScanPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "default"},
+			},
+			expectedWarnings: []string{
+				"ScanPolicy default is ignored by the operator, which only applies the ScanPolicy named starboard",
+			},
+		},
+		{
+			name: "Should reject invalid policy",
+			policy: v1alpha1.ScanPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "starboard"},
+				Spec: v1alpha1.ScanPolicySpec{
+					Severities:             []v1alpha1.Severity{"SEVERE", v1alpha1.SeverityHigh, v1alpha1.SeverityHigh},
+					IgnoredVulnerabilities: []string{"CVE-2021-44228", " "},
+					VulnerabilityReportTTL: &metav1.Duration{Duration: -time.Hour},
+					VulnerabilitySLA: map[v1alpha1.Severity]int{
+						v1alpha1.SeverityHigh: -1,
+						"Critical":            7,
+					},
+				},
+			},
+			expectedProblems: []string{
+				"invalid severity (SEVERE) in severities; allowed values (CRITICAL, HIGH, MEDIUM, LOW, UNKNOWN)",
+				"duplicate severity (HIGH) in severities",
+				"empty vulnerability ID at index 1 of ignoredVulnerabilities",
+				"invalid value (-1h0m0s) of vulnerabilityReportTTL; expected a positive duration",
+				"invalid severity (Critical) in vulnerabilitySLA; allowed values (CRITICAL, HIGH, MEDIUM, LOW, UNKNOWN)",
+				"invalid value (-1) of vulnerabilitySLA.HIGH; expected non-negative number of days",
+			},
+		},
+	}
+
+	validator := admission.NewValidator(fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build(), etc.Config{})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings, problems := validator.ValidateScanPolicy(tc.policy)
+			assert.Equal(t, tc.expectedWarnings, warnings)
+			assert.Equal(t, tc.expectedProblems, messages(problems))
+		})
+	}
+}
+
+func TestValidator_ValidateOperatorConfig(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "starboard-system")
+
+	trivyConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "starboard-trivy-config"},
+		Data: map[string]string{
+			"trivy.imageRef": "docker.io/aquasec/trivy:0.25.2",
+			"trivy.mode":     "Standalone",
+		},
+	}
+	overrides := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: etc.ConfigMapName},
+		Data:       map[string]string{"OPERATOR_TARGET_NAMESPACE_SELECTOR": "env in (prod"},
+	}
+	newConfig := func(spec v1alpha1.OperatorConfigSpec) v1alpha1.OperatorConfig {
+		return v1alpha1.OperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "starboard"},
+			Spec:       spec,
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		config           v1alpha1.OperatorConfig
+		reloadEnabled    bool
+		expectedWarnings []string
+		expectedProblems []string
+	}{
+		{
+			name: "Should accept valid config",
+			config: newConfig(v1alpha1.OperatorConfigSpec{
+				VulnerabilityScanner:    &v1alpha1.OperatorConfigScanner{Enabled: pointer.BoolPtr(true), Plugin: "Trivy"},
+				ScanJobTimeout:          &metav1.Duration{Duration: 10 * time.Minute},
+				ConcurrentScanJobsLimit: pointer.IntPtr(5),
+				Plugins: map[string]map[string]string{
+					"Trivy":   {"trivy.severity": "CRITICAL,HIGH"},
+					"Polaris": {"polaris.imageRef": "quay.io/fairwinds/polaris:4.2"},
+				},
+			}),
+		},
+		{
+			name: "Should warn about config that is ignored",
+			config: v1alpha1.OperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "starboard"},
+			},
+			expectedWarnings: []string{
+				"OperatorConfig default/starboard is ignored by the operator, which only applies the OperatorConfig starboard-system/starboard",
+			},
+		},
+		{
+			name: "Should reject non-positive durations",
+			config: newConfig(v1alpha1.OperatorConfigSpec{
+				VulnerabilityReportTTL: &metav1.Duration{Duration: 0},
+				ScanJobTimeout:         &metav1.Duration{Duration: -time.Minute},
+			}),
+			expectedProblems: []string{
+				"invalid value (0s) of vulnerabilityReportTTL; expected a positive duration",
+				"invalid value (-1m0s) of scanJobTimeout; expected a positive duration",
+			},
+		},
+		{
+			name: "Should reject unknown scanner",
+			config: newConfig(v1alpha1.OperatorConfigSpec{
+				VulnerabilityScanner: &v1alpha1.OperatorConfigScanner{Plugin: "Clair"},
+			}),
+			expectedProblems: []string{
+				"invalid value (Clair) of vulnerabilityReports.scanner; allowed values (Trivy, Aqua, Harbor, Quay, External)",
+			},
+		},
+		{
+			name:          "Should reject config with invalid overrides",
+			config:        newConfig(v1alpha1.OperatorConfigSpec{}),
+			reloadEnabled: true,
+			expectedProblems: []string{
+				"parsing OPERATOR_TARGET_NAMESPACE_SELECTOR: unable to parse requirement: found '', expected: ',' or ')'",
+			},
+		},
+		{
+			name: "Should reject invalid settings of plugins",
+			config: newConfig(v1alpha1.OperatorConfigSpec{
+				Plugins: map[string]map[string]string{
+					"Trivy": {"trivy.severity": "CRITICAL,SEVERE"},
+					"Clair": {"clair.imageRef": "quay.io/coreos/clair:v4.3.6"},
+				},
+			}),
+			expectedProblems: []string{
+				"settings of Clair plugin: unknown plugin; allowed values (Trivy, Aqua, Harbor, Quay, External, Polaris, Conftest)",
+				"settings of Trivy plugin: invalid severity (SEVERE) in trivy.severity; allowed values (CRITICAL, HIGH, MEDIUM, LOW, UNKNOWN)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(trivyConfig, overrides).Build()
+			validator := admission.NewValidator(c, etc.Config{
+				Namespace:           "starboard-system",
+				ConfigReloadEnabled: tc.reloadEnabled,
+			})
+			warnings, problems := validator.ValidateOperatorConfig(context.TODO(), tc.config)
+			assert.Equal(t, tc.expectedWarnings, warnings)
+			assert.Equal(t, tc.expectedProblems, messages(problems))
+		})
+	}
+}
+
+func messages(problems []error) []string {
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.Error())
+	}
+	return messages
+}
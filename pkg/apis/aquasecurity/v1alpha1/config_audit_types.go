@@ -10,7 +10,9 @@ const (
 	ConfigAuditReportKind      = "ConfigAuditReport"
 	ConfigAuditReportListKind  = "ConfigAuditReportList"
 
-	ClusterConfigAuditReportCRName = "clusterconfigauditreports.aquasecurity.github.io"
+	ClusterConfigAuditReportCRName   = "clusterconfigauditreports.aquasecurity.github.io"
+	ClusterConfigAuditReportKind     = "ClusterConfigAuditReport"
+	ClusterConfigAuditReportListKind = "ClusterConfigAuditReportList"
 )
 
 const (
@@ -0,0 +1,78 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ExposureReportCRName    = "exposurereports.aquasecurity.github.io"
+	ExposureReportCRVersion = "v1alpha1"
+	ExposureReportKind      = "ExposureReport"
+	ExposureReportListKind  = "ExposureReportList"
+)
+
+// ExposureRoute is a resource through which a workload is reachable from
+// outside the cluster.
+type ExposureRoute struct {
+	// Kind is the kind of the resource, i.e. Service, Ingress, or a Gateway
+	// API route such as HTTPRoute.
+	Kind string `json:"kind"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// Service is the name of the Service that selects pods of the workload
+	// and that the resource routes traffic to. It's the same as Name for
+	// LoadBalancer and NodePort Services.
+	Service string `json:"service"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ExposureReport is a specification for the ExposureReport resource, which
+// records how a workload is reachable from outside the cluster and whether
+// it also carries critical findings.
+type ExposureReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Report ExposureReportData `json:"report"`
+}
+
+// ExposureReportData is the exposure assessment of a workload.
+type ExposureReportData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when this report was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// InternetReachable indicates whether the workload is reachable from
+	// outside the cluster through any of the routes.
+	InternetReachable bool `json:"internetReachable"`
+
+	// Routes is the list of resources through which the workload is
+	// reachable from outside the cluster.
+	Routes []ExposureRoute `json:"routes"`
+
+	// CriticalCount is the number of critical vulnerabilities in
+	// VulnerabilityReports of the workload.
+	CriticalCount int `json:"criticalCount"`
+
+	// DangerCount is the number of failed checks of danger severity in the
+	// ConfigAuditReport of the workload.
+	DangerCount int `json:"dangerCount"`
+
+	// Flagged indicates whether the workload is reachable from outside the
+	// cluster and also carries critical findings, i.e. critical
+	// vulnerabilities or failed checks of danger severity.
+	Flagged bool `json:"flagged"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ExposureReportList is a list of ExposureReport resources.
+type ExposureReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ExposureReport `json:"items"`
+}
@@ -0,0 +1,106 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ImageSignatureReportCRName    = "imagesignaturereports.aquasecurity.github.io"
+	ImageSignatureReportCRVersion = "v1alpha1"
+	ImageSignatureReportKind      = "ImageSignatureReport"
+	ImageSignatureReportListKind  = "ImageSignatureReportList"
+)
+
+// ImageSignature is a signature of an Artifact that was successfully verified.
+type ImageSignature struct {
+	// Digest is the digest of the signed image manifest.
+	Digest string `json:"digest"`
+
+	// Issuer is the OIDC issuer of the signing certificate. It's empty for
+	// signatures verified with a public key.
+	Issuer string `json:"issuer,omitempty"`
+
+	// Subject is the identity, e.g. email address or workflow URI, of the
+	// signing certificate. It's empty for signatures verified with a public key.
+	Subject string `json:"subject,omitempty"`
+}
+
+// ImageProvenance is the result of verifying the SLSA provenance attestation
+// of an Artifact.
+type ImageProvenance struct {
+	// Verified indicates whether the Artifact has a valid provenance
+	// attestation that satisfies the provenance policy.
+	Verified bool `json:"verified"`
+
+	// Message describes why the verification failed.
+	Message string `json:"message,omitempty"`
+
+	// PredicateType is the in-toto predicate type of the attestation,
+	// e.g. `https://slsa.dev/provenance/v0.2`.
+	PredicateType string `json:"predicateType,omitempty"`
+
+	// BuilderID is the identity of the builder that produced the Artifact.
+	BuilderID string `json:"builderID,omitempty"`
+
+	// BuildType is the URI describing how the Artifact was built.
+	BuildType string `json:"buildType,omitempty"`
+
+	// SLSALevel is the SLSA build level attained by the Artifact. It's 0 if
+	// there's no valid provenance attestation.
+	SLSALevel int `json:"slsaLevel"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ImageSignatureReport is a specification for the ImageSignatureReport resource.
+type ImageSignatureReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Report ImageSignatureReportData `json:"report"`
+}
+
+// ImageSignatureReportData is the result of verifying signatures of a
+// container image against an image signature policy.
+type ImageSignatureReportData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when this report was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// Scanner is the tool that verified signatures.
+	Scanner Scanner `json:"scanner"`
+
+	// Registry is the registry the Artifact was pulled from.
+	Registry Registry `json:"registry"`
+
+	// Artifact is a container image which signatures were verified.
+	Artifact Artifact `json:"artifact"`
+
+	// Policy is the name of the image signature policy that the Artifact
+	// was verified against.
+	Policy string `json:"policy"`
+
+	// Verified indicates whether the Artifact has at least one valid
+	// signature that satisfies the Policy.
+	Verified bool `json:"verified"`
+
+	// Message describes why the verification failed.
+	Message string `json:"message,omitempty"`
+
+	// Signatures is a list of verified signatures.
+	Signatures []ImageSignature `json:"signatures"`
+
+	// Provenance is the result of verifying the provenance attestation. It's
+	// nil unless the Policy requires provenance verification.
+	Provenance *ImageProvenance `json:"provenance,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ImageSignatureReportList is a list of ImageSignatureReport resources.
+type ImageSignatureReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ImageSignatureReport `json:"items"`
+}
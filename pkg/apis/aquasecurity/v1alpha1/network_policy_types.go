@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ClusterNetworkPolicyReportCRName    = "clusternetworkpolicyreports.aquasecurity.github.io"
+	ClusterNetworkPolicyReportCRVersion = "v1alpha1"
+	ClusterNetworkPolicyReportKind      = "ClusterNetworkPolicyReport"
+	ClusterNetworkPolicyReportListKind  = "ClusterNetworkPolicyReportList"
+)
+
+// NetworkPolicySummary is the summary of NetworkPolicy coverage of a cluster.
+type NetworkPolicySummary struct {
+	// NamespaceCount is the number of assessed namespaces, i.e. namespaces
+	// with workloads.
+	NamespaceCount int `json:"namespaceCount"`
+
+	// UncoveredNamespaceCount is the number of assessed namespaces without
+	// any NetworkPolicy.
+	UncoveredNamespaceCount int `json:"uncoveredNamespaceCount"`
+
+	// WorkloadCount is the number of assessed workloads.
+	WorkloadCount int `json:"workloadCount"`
+
+	// UncoveredWorkloadCount is the number of assessed workloads whose pods
+	// are not selected by any NetworkPolicy.
+	UncoveredWorkloadCount int `json:"uncoveredWorkloadCount"`
+}
+
+// NetworkPolicyWorkload is a workload whose pods are not selected by any
+// NetworkPolicy.
+type NetworkPolicyWorkload struct {
+	// Kind is the kind of the workload, e.g. Deployment.
+	Kind string `json:"kind"`
+
+	// Name is the name of the workload.
+	Name string `json:"name"`
+}
+
+// NetworkPolicyNamespace is the NetworkPolicy coverage of a namespace with
+// uncovered workloads.
+type NetworkPolicyNamespace struct {
+	// Name is the name of the namespace.
+	Name string `json:"name"`
+
+	// NetworkPolicyCount is the number of NetworkPolicies in the namespace.
+	// It's 0 if the namespace lacks any NetworkPolicy.
+	NetworkPolicyCount int `json:"networkPolicyCount"`
+
+	// UncoveredWorkloads is the list of workloads in the namespace whose pods
+	// are not selected by any NetworkPolicy.
+	UncoveredWorkloads []NetworkPolicyWorkload `json:"uncoveredWorkloads"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterNetworkPolicyReport is a specification for the
+// ClusterNetworkPolicyReport resource, which records namespaces and workloads
+// that lack any applicable NetworkPolicy.
+type ClusterNetworkPolicyReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Report ClusterNetworkPolicyReportData `json:"report"`
+}
+
+// ClusterNetworkPolicyReportData is the NetworkPolicy coverage assessment of
+// a cluster.
+type ClusterNetworkPolicyReportData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when this report was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	Summary NetworkPolicySummary `json:"summary"`
+
+	// Namespaces is the list of namespaces with uncovered workloads, sorted
+	// by name.
+	Namespaces []NetworkPolicyNamespace `json:"namespaces"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterNetworkPolicyReportList is a list of ClusterNetworkPolicyReport
+// resources.
+type ClusterNetworkPolicyReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterNetworkPolicyReport `json:"items"`
+}
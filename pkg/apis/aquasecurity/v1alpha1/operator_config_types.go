@@ -0,0 +1,121 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	OperatorConfigCRName    = "operatorconfigs.aquasecurity.github.io"
+	OperatorConfigCRVersion = "v1alpha1"
+	OperatorConfigKind      = "OperatorConfig"
+	OperatorConfigListKind  = "OperatorConfigList"
+
+	// OperatorConfigName is the name of the OperatorConfig in the namespace
+	// of the operator that configures the operator. OperatorConfigs with other
+	// names or in other namespaces are ignored.
+	OperatorConfigName = "starboard"
+
+	// OperatorConfigConditionValid is the type of the condition that is true
+	// if the spec of the OperatorConfig is valid and applied by the operator.
+	OperatorConfigConditionValid = "Valid"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OperatorConfig is a specification for the OperatorConfig resource, which
+// configures the operator with a validated schema instead of environment
+// variables of its Deployment and keys of its ConfigMaps.
+type OperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OperatorConfigSpec   `json:"spec"`
+	Status OperatorConfigStatus `json:"status,omitempty"`
+}
+
+// OperatorConfigSpec is the configuration of the operator. Settings that are
+// not set fall back to environment variables of the operator and keys of the
+// starboard ConfigMap.
+type OperatorConfigSpec struct {
+	// VulnerabilityScanner configures scanning of workloads for
+	// vulnerabilities. It overrides OPERATOR_VULNERABILITY_SCANNER_ENABLED and
+	// the vulnerabilityReports.scanner key of the starboard ConfigMap.
+	VulnerabilityScanner *OperatorConfigScanner `json:"vulnerabilityScanner,omitempty"`
+
+	// ConfigAuditScanner configures scanning of workloads for configuration
+	// issues. It overrides OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED and the
+	// configAuditReports.scanner key of the starboard ConfigMap.
+	ConfigAuditScanner *OperatorConfigScanner `json:"configAuditScanner,omitempty"`
+
+	// VulnerabilityReportTTL is the time to live of VulnerabilityReports, after
+	// which they are deleted and workloads are rescanned. It overrides
+	// OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL.
+	VulnerabilityReportTTL *metav1.Duration `json:"vulnerabilityReportTTL,omitempty"`
+
+	// ScanJobTimeout is the time to wait for scan jobs to complete. It
+	// overrides OPERATOR_SCAN_JOB_TIMEOUT.
+	ScanJobTimeout *metav1.Duration `json:"scanJobTimeout,omitempty"`
+
+	// ConcurrentScanJobsLimit is the maximum number of scan jobs run at the
+	// same time. It overrides OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT.
+	ConcurrentScanJobsLimit *int `json:"concurrentScanJobsLimit,omitempty"`
+
+	// Namespaces configures the namespaces whose workloads are scanned and
+	// where scan jobs are run.
+	Namespaces *OperatorConfigNamespaces `json:"namespaces,omitempty"`
+
+	// Plugins are settings of plugins by the name of the plugin, e.g. Trivy,
+	// which are set in the ConfigMaps of plugins, e.g. starboard-trivy-config.
+	Plugins map[string]map[string]string `json:"plugins,omitempty"`
+}
+
+// OperatorConfigScanner configures a scanner of the operator.
+type OperatorConfigScanner struct {
+	// Enabled is the flag to enable or disable the scanner.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Plugin is the name of the plugin of the scanner, e.g. Trivy.
+	Plugin string `json:"plugin,omitempty"`
+}
+
+// OperatorConfigNamespaces configures namespaces of the operator.
+type OperatorConfigNamespaces struct {
+	// Targets are the namespaces whose workloads are scanned. If empty, all
+	// namespaces are scanned. It overrides OPERATOR_TARGET_NAMESPACES.
+	Targets []string `json:"targets,omitempty"`
+
+	// Selector is the label selector of namespaces whose workloads are
+	// scanned. It overrides OPERATOR_TARGET_NAMESPACE_SELECTOR.
+	Selector string `json:"selector,omitempty"`
+
+	// ExcludeSelector is the label selector of namespaces whose workloads are
+	// not scanned. It overrides OPERATOR_EXCLUDE_NAMESPACE_SELECTOR.
+	ExcludeSelector string `json:"excludeSelector,omitempty"`
+
+	// ScanJobs is the namespace where scan jobs are run. It overrides
+	// OPERATOR_SCAN_JOBS_NAMESPACE.
+	ScanJobs string `json:"scanJobs,omitempty"`
+}
+
+// OperatorConfigStatus is the status of the OperatorConfig reported by the
+// operator.
+type OperatorConfigStatus struct {
+	// ObservedGeneration is the generation of the spec that the conditions
+	// refer to.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions are the conditions of the OperatorConfig, e.g. the Valid
+	// condition which reports why the spec is invalid.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OperatorConfigList is a list of OperatorConfig resources.
+type OperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []OperatorConfig `json:"items"`
+}
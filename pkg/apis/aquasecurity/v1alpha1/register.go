@@ -42,6 +42,20 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ConfigAuditReportList{},
 		&ClusterConfigAuditReport{},
 		&ClusterConfigAuditReportList{},
+		&ImageSignatureReport{},
+		&ImageSignatureReportList{},
+		&ScanFailureReport{},
+		&ScanFailureReportList{},
+		&ScanPolicy{},
+		&ScanPolicyList{},
+		&OperatorConfig{},
+		&OperatorConfigList{},
+		&VulnerabilityHistory{},
+		&VulnerabilityHistoryList{},
+		&ExposureReport{},
+		&ExposureReportList{},
+		&ClusterNetworkPolicyReport{},
+		&ClusterNetworkPolicyReportList{},
 	)
 	meta.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
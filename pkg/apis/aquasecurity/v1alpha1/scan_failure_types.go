@@ -0,0 +1,91 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ScanFailureReportCRName    = "scanfailurereports.aquasecurity.github.io"
+	ScanFailureReportCRVersion = "v1alpha1"
+	ScanFailureReportKind      = "ScanFailureReport"
+	ScanFailureReportListKind  = "ScanFailureReportList"
+)
+
+// ScanFailureContainer describes a container of a failed scan job.
+type ScanFailureContainer struct {
+	// Name is the name of the container.
+	Name string `json:"name"`
+
+	// Image is the container image scanned by the container. It's empty if
+	// the container does not scan an image, e.g. for configuration audits.
+	Image string `json:"image,omitempty"`
+
+	// ExitCode is the exit code of the container.
+	ExitCode int32 `json:"exitCode"`
+
+	// Reason is the reason of the container termination, e.g. `Error` or
+	// `OOMKilled`.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the termination message of the container.
+	Message string `json:"message,omitempty"`
+
+	// Logs is the tail of the container logs.
+	Logs string `json:"logs,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScanFailureReport is a specification for the ScanFailureReport resource.
+type ScanFailureReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Report ScanFailureReportData `json:"report"`
+}
+
+// ScanFailureReportData is the record of the last failed scan job of a
+// Kubernetes object.
+type ScanFailureReportData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when this report was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// Scanner is the name of the plugin that ran the scan job.
+	Scanner string `json:"scanner"`
+
+	// ReportKind is the kind of the report that the scan job was supposed to
+	// produce, e.g. VulnerabilityReport or ConfigAuditReport.
+	ReportKind string `json:"reportKind"`
+
+	// Job is the name of the failed scan job in the operator namespace.
+	Job string `json:"job"`
+
+	// Attempt is the attempt number of the failed scan job.
+	Attempt int `json:"attempt"`
+
+	// GaveUp indicates whether the operator gave up scanning the object until
+	// its spec changes.
+	GaveUp bool `json:"gaveUp"`
+
+	// Reason is the reason of the job failure, e.g. `BackoffLimitExceeded`
+	// or `DeadlineExceeded`.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the message of the job failure.
+	Message string `json:"message,omitempty"`
+
+	// Containers is the list of containers of the failed scan job that
+	// terminated with a non-zero exit code.
+	Containers []ScanFailureContainer `json:"containers"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScanFailureReportList is a list of ScanFailureReport resources.
+type ScanFailureReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ScanFailureReport `json:"items"`
+}
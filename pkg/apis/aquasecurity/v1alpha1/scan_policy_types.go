@@ -0,0 +1,67 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ScanPolicyCRName    = "scanpolicies.aquasecurity.github.io"
+	ScanPolicyCRVersion = "v1alpha1"
+	ScanPolicyKind      = "ScanPolicy"
+	ScanPolicyListKind  = "ScanPolicyList"
+
+	// ScanPolicyName is the name of the ScanPolicy that the operator applies
+	// to workloads in the namespace of the policy. Policies with other names
+	// are ignored.
+	ScanPolicyName = "starboard"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScanPolicy is a specification for the ScanPolicy resource, which lets owners
+// of a namespace override a subset of the cluster-wide configuration of the
+// operator for workloads in that namespace.
+type ScanPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScanPolicySpec `json:"spec"`
+}
+
+// ScanPolicySpec is the configuration that overrides the cluster-wide
+// configuration. Settings that are not set fall back to the cluster-wide
+// configuration.
+type ScanPolicySpec struct {
+	// Severities is the list of severities of vulnerabilities that are kept in
+	// VulnerabilityReports, e.g. CRITICAL and HIGH. Vulnerabilities with other
+	// severities are dropped. If empty, vulnerabilities of all severities are
+	// kept.
+	Severities []Severity `json:"severities,omitempty"`
+
+	// IgnoredVulnerabilities is the list of IDs of vulnerabilities that are
+	// dropped from VulnerabilityReports, like the entries of an ignore file of
+	// a scanner.
+	IgnoredVulnerabilities []string `json:"ignoredVulnerabilities,omitempty"`
+
+	// VulnerabilityReportTTL is the time to live of VulnerabilityReports, after
+	// which they are deleted and workloads are rescanned. It overrides
+	// OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL.
+	VulnerabilityReportTTL *metav1.Duration `json:"vulnerabilityReportTTL,omitempty"`
+
+	// VulnerabilitySLA is the maximum number of days that open vulnerabilities
+	// of each severity may remain in workloads, e.g. 7 days for CRITICAL
+	// vulnerabilities. It overrides the severities set by
+	// OPERATOR_VULNERABILITY_SLA.
+	VulnerabilitySLA map[Severity]int `json:"vulnerabilitySLA,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScanPolicyList is a list of ScanPolicy resources.
+type ScanPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ScanPolicy `json:"items"`
+}
@@ -0,0 +1,74 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	VulnerabilityHistoryCRName    = "vulnerabilityhistories.aquasecurity.github.io"
+	VulnerabilityHistoryCRVersion = "v1alpha1"
+	VulnerabilityHistoryKind      = "VulnerabilityHistory"
+	VulnerabilityHistoryListKind  = "VulnerabilityHistoryList"
+)
+
+// VulnerabilityRecord is the record of a vulnerability found in a container
+// of a workload.
+type VulnerabilityRecord struct {
+	// Container is the name of the container.
+	Container string `json:"container"`
+
+	// VulnerabilityID is the vulnerability identifier.
+	VulnerabilityID string `json:"vulnerabilityID"`
+
+	// Resource is the vulnerable package, application, or library.
+	Resource string `json:"resource"`
+
+	// Severity is the last reported severity of the vulnerability.
+	Severity Severity `json:"severity"`
+
+	// FirstSeen is the time when the vulnerability first appeared in reports
+	// of the container.
+	FirstSeen metav1.Time `json:"firstSeen"`
+
+	// ResolvedAt is the time when the vulnerability disappeared from reports
+	// of the container. It's not set for open vulnerabilities.
+	ResolvedAt *metav1.Time `json:"resolvedAt,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VulnerabilityHistory is a specification for the VulnerabilityHistory
+// resource, which records when vulnerabilities appeared in and disappeared
+// from VulnerabilityReports of a workload across its revisions.
+type VulnerabilityHistory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Report VulnerabilityHistoryData `json:"report"`
+}
+
+// VulnerabilityHistoryData is the rolling history of vulnerabilities of a
+// workload.
+type VulnerabilityHistoryData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when this report was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// Open is the list of vulnerabilities currently found in containers of
+	// the workload.
+	Open []VulnerabilityRecord `json:"open"`
+
+	// Resolved is the list of the most recently resolved vulnerabilities, the
+	// most recent first. Older records are dropped.
+	Resolved []VulnerabilityRecord `json:"resolved"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VulnerabilityHistoryList is a list of VulnerabilityHistory resources.
+type VulnerabilityHistoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VulnerabilityHistory `json:"items"`
+}
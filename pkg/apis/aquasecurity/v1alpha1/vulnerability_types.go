@@ -11,6 +11,7 @@ const (
 	VulnerabilityReportListKind   = "VulnerabilityReportList"
 
 	ClusterVulnerabilityReportsCRName = "clustervulnerabilityreports.aquasecurity.github.io"
+	ClusterVulnerabilityReportKind    = "ClusterVulnerabilityReport"
 )
 
 type Severity string
@@ -67,6 +68,41 @@ type Artifact struct {
 	MimeType string `json:"mimeType,omitempty"`
 }
 
+// Sources of the BaseImage.
+const (
+	// BaseImageSourceLabel means the base image is recorded in the
+	// org.opencontainers.image.base.name label of the image.
+	BaseImageSourceLabel = "Label"
+	// BaseImageSourceOS means the base image is derived from the OS
+	// distribution of the image.
+	BaseImageSourceOS = "OS"
+)
+
+// BaseImage is the probable base image of an Artifact.
+type BaseImage struct {
+	// Name is the reference of the base image, e.g. debian:10.10.
+	Name string `json:"name"`
+
+	// Digest is the digest of the base image, if known.
+	Digest string `json:"digest,omitempty"`
+
+	// Source is how the base image was detected, either Label or OS.
+	Source string `json:"source"`
+
+	// EndOfServiceLife indicates whether the OS distribution of the base image
+	// is no longer supported, so it doesn't get security updates.
+	EndOfServiceLife bool `json:"endOfServiceLife,omitempty"`
+
+	// FixableCount is the number of vulnerabilities of OS packages fixed in
+	// newer versions of the packages.
+	FixableCount int `json:"fixableCount"`
+
+	// UpdateAvailable indicates whether a newer, patched base image probably
+	// exists, because vulnerabilities of OS packages have been fixed, or the
+	// OS distribution reached the end of service life.
+	UpdateAvailable bool `json:"updateAvailable"`
+}
+
 // Vulnerability is the spec for a vulnerability record.
 type Vulnerability struct {
 	// VulnerabilityID the vulnerability identifier.
@@ -87,6 +123,26 @@ type Vulnerability struct {
 	PrimaryLink string   `json:"primaryLink,omitempty"`
 	Links       []string `json:"links"`
 	Score       *float64 `json:"score,omitempty"`
+
+	// Ecosystem is the package ecosystem of the Resource, e.g. os for OS
+	// packages, or jar, npm, pip, or gobinary for application dependencies.
+	Ecosystem string `json:"ecosystem,omitempty"`
+
+	// Layer is the image layer that introduced the vulnerable Resource, if known.
+	Layer *Layer `json:"layer,omitempty"`
+}
+
+// Layer is a layer of a container image.
+type Layer struct {
+	// Digest is the digest of the compressed layer.
+	Digest string `json:"digest,omitempty"`
+
+	// DiffID is the digest of the uncompressed layer.
+	DiffID string `json:"diffID,omitempty"`
+
+	// CreatedBy is the command which created the layer, e.g. a Dockerfile
+	// instruction, if recorded in the history of the image.
+	CreatedBy string `json:"createdBy,omitempty"`
 }
 
 // +genclient
@@ -101,6 +157,30 @@ type VulnerabilityReport struct {
 	Report VulnerabilityReportData `json:"report"`
 }
 
+// ScanMetadata is the metadata of the scan of an Artifact.
+type ScanMetadata struct {
+	// StartTimestamp is the time when the scan started.
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Duration is the duration of the scan.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// DB is the vulnerability database used by the scan.
+	DB *VulnerabilityDB `json:"db,omitempty"`
+}
+
+// VulnerabilityDB describes the vulnerability database used by a scan.
+type VulnerabilityDB struct {
+	// Version is the schema version of the database.
+	Version int `json:"version,omitempty"`
+
+	// UpdatedAt is the time when the database was built.
+	UpdatedAt metav1.Time `json:"updatedAt"`
+
+	// NextUpdate is the time when the next build of the database is expected.
+	NextUpdate *metav1.Time `json:"nextUpdate,omitempty"`
+}
+
 // VulnerabilityReportData is the spec for the vulnerability scan result.
 //
 // The spec follows the Pluggable Scanners API defined for Harbor.
@@ -112,12 +192,19 @@ type VulnerabilityReportData struct {
 	// Scanner is the scanner that generated this report.
 	Scanner Scanner `json:"scanner"`
 
+	// Scan is the metadata of the scan that generated this report, if it was
+	// recorded by the scanner.
+	Scan *ScanMetadata `json:"scan,omitempty"`
+
 	// Registry is the registry the Artifact was pulled from.
 	Registry Registry `json:"registry"`
 
 	// Artifact is a container image scanned for Vulnerabilities.
 	Artifact Artifact `json:"artifact"`
 
+	// BaseImage is the probable base image of the Artifact, if detected.
+	BaseImage *BaseImage `json:"baseImage,omitempty"`
+
 	// Summary is a summary of Vulnerability counts grouped by Severity.
 	Summary VulnerabilitySummary `json:"summary"`
 
@@ -141,7 +228,7 @@ type VulnerabilityReportList struct {
 
 // ClusterVulnerabilityReport is a specification for the ClusterVulnerabilityReport resource.
 type ClusterVulnerabilityReport struct {
-	metav1.TypeMeta   `json:",incline"`
+	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Report VulnerabilityReportData `json:"report"`
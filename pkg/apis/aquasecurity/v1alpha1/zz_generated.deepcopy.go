@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -25,6 +26,22 @@ func (in *Artifact) DeepCopy() *Artifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseImage) DeepCopyInto(out *BaseImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseImage.
+func (in *BaseImage) DeepCopy() *BaseImage {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CISKubeBenchReport) DeepCopyInto(out *CISKubeBenchReport) {
 	*out = *in
@@ -284,6 +301,91 @@ func (in *ClusterConfigAuditReportList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetworkPolicyReport) DeepCopyInto(out *ClusterNetworkPolicyReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworkPolicyReport.
+func (in *ClusterNetworkPolicyReport) DeepCopy() *ClusterNetworkPolicyReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetworkPolicyReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterNetworkPolicyReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetworkPolicyReportData) DeepCopyInto(out *ClusterNetworkPolicyReportData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Summary = in.Summary
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NetworkPolicyNamespace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworkPolicyReportData.
+func (in *ClusterNetworkPolicyReportData) DeepCopy() *ClusterNetworkPolicyReportData {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetworkPolicyReportData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetworkPolicyReportList) DeepCopyInto(out *ClusterNetworkPolicyReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterNetworkPolicyReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworkPolicyReportList.
+func (in *ClusterNetworkPolicyReportList) DeepCopy() *ClusterNetworkPolicyReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetworkPolicyReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterNetworkPolicyReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterVulnerabilityReport) DeepCopyInto(out *ClusterVulnerabilityReport) {
 	*out = *in
@@ -471,7 +573,7 @@ func (in *ConfigAuditSummary) DeepCopy() *ConfigAuditSummary {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeHunterReport) DeepCopyInto(out *KubeHunterReport) {
+func (in *ExposureReport) DeepCopyInto(out *ExposureReport) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -479,18 +581,18 @@ func (in *KubeHunterReport) DeepCopyInto(out *KubeHunterReport) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterReport.
-func (in *KubeHunterReport) DeepCopy() *KubeHunterReport {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposureReport.
+func (in *ExposureReport) DeepCopy() *ExposureReport {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeHunterReport)
+	out := new(ExposureReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KubeHunterReport) DeepCopyObject() runtime.Object {
+func (in *ExposureReport) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -498,37 +600,35 @@ func (in *KubeHunterReport) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeHunterReportData) DeepCopyInto(out *KubeHunterReportData) {
+func (in *ExposureReportData) DeepCopyInto(out *ExposureReportData) {
 	*out = *in
 	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
-	out.Scanner = in.Scanner
-	out.Summary = in.Summary
-	if in.Vulnerabilities != nil {
-		in, out := &in.Vulnerabilities, &out.Vulnerabilities
-		*out = make([]KubeHunterVulnerability, len(*in))
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]ExposureRoute, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterReportData.
-func (in *KubeHunterReportData) DeepCopy() *KubeHunterReportData {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposureReportData.
+func (in *ExposureReportData) DeepCopy() *ExposureReportData {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeHunterReportData)
+	out := new(ExposureReportData)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeHunterReportList) DeepCopyInto(out *KubeHunterReportList) {
+func (in *ExposureReportList) DeepCopyInto(out *ExposureReportList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KubeHunterReport, len(*in))
+		*out = make([]ExposureReport, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -536,18 +636,18 @@ func (in *KubeHunterReportList) DeepCopyInto(out *KubeHunterReportList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterReportList.
-func (in *KubeHunterReportList) DeepCopy() *KubeHunterReportList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposureReportList.
+func (in *ExposureReportList) DeepCopy() *ExposureReportList {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeHunterReportList)
+	out := new(ExposureReportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KubeHunterReportList) DeepCopyObject() runtime.Object {
+func (in *ExposureReportList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -555,97 +655,145 @@ func (in *KubeHunterReportList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeHunterSummary) DeepCopyInto(out *KubeHunterSummary) {
+func (in *ExposureRoute) DeepCopyInto(out *ExposureRoute) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterSummary.
-func (in *KubeHunterSummary) DeepCopy() *KubeHunterSummary {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposureRoute.
+func (in *ExposureRoute) DeepCopy() *ExposureRoute {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeHunterSummary)
+	out := new(ExposureRoute)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeHunterVulnerability) DeepCopyInto(out *KubeHunterVulnerability) {
+func (in *ImageProvenance) DeepCopyInto(out *ImageProvenance) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterVulnerability.
-func (in *KubeHunterVulnerability) DeepCopy() *KubeHunterVulnerability {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageProvenance.
+func (in *ImageProvenance) DeepCopy() *ImageProvenance {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeHunterVulnerability)
+	out := new(ImageProvenance)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Registry) DeepCopyInto(out *Registry) {
+func (in *ImageSignature) DeepCopyInto(out *ImageSignature) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Registry.
-func (in *Registry) DeepCopy() *Registry {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSignature.
+func (in *ImageSignature) DeepCopy() *ImageSignature {
 	if in == nil {
 		return nil
 	}
-	out := new(Registry)
+	out := new(ImageSignature)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Scanner) DeepCopyInto(out *Scanner) {
+func (in *ImageSignatureReport) DeepCopyInto(out *ImageSignatureReport) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scanner.
-func (in *Scanner) DeepCopy() *Scanner {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSignatureReport.
+func (in *ImageSignatureReport) DeepCopy() *ImageSignatureReport {
 	if in == nil {
 		return nil
 	}
-	out := new(Scanner)
+	out := new(ImageSignatureReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageSignatureReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Vulnerability) DeepCopyInto(out *Vulnerability) {
+func (in *ImageSignatureReportData) DeepCopyInto(out *ImageSignatureReportData) {
 	*out = *in
-	if in.Links != nil {
-		in, out := &in.Links, &out.Links
-		*out = make([]string, len(*in))
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Scanner = in.Scanner
+	out.Registry = in.Registry
+	out.Artifact = in.Artifact
+	if in.Signatures != nil {
+		in, out := &in.Signatures, &out.Signatures
+		*out = make([]ImageSignature, len(*in))
 		copy(*out, *in)
 	}
-	if in.Score != nil {
-		in, out := &in.Score, &out.Score
-		*out = new(float64)
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(ImageProvenance)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vulnerability.
-func (in *Vulnerability) DeepCopy() *Vulnerability {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSignatureReportData.
+func (in *ImageSignatureReportData) DeepCopy() *ImageSignatureReportData {
 	if in == nil {
 		return nil
 	}
-	out := new(Vulnerability)
+	out := new(ImageSignatureReportData)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VulnerabilityReport) DeepCopyInto(out *VulnerabilityReport) {
+func (in *ImageSignatureReportList) DeepCopyInto(out *ImageSignatureReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImageSignatureReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSignatureReportList.
+func (in *ImageSignatureReportList) DeepCopy() *ImageSignatureReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSignatureReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageSignatureReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeHunterReport) DeepCopyInto(out *KubeHunterReport) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -653,18 +801,18 @@ func (in *VulnerabilityReport) DeepCopyInto(out *VulnerabilityReport) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityReport.
-func (in *VulnerabilityReport) DeepCopy() *VulnerabilityReport {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterReport.
+func (in *KubeHunterReport) DeepCopy() *KubeHunterReport {
 	if in == nil {
 		return nil
 	}
-	out := new(VulnerabilityReport)
+	out := new(KubeHunterReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VulnerabilityReport) DeepCopyObject() runtime.Object {
+func (in *KubeHunterReport) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -672,12 +820,818 @@ func (in *VulnerabilityReport) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VulnerabilityReportData) DeepCopyInto(out *VulnerabilityReportData) {
+func (in *KubeHunterReportData) DeepCopyInto(out *KubeHunterReportData) {
 	*out = *in
 	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
 	out.Scanner = in.Scanner
-	out.Registry = in.Registry
-	out.Artifact = in.Artifact
+	out.Summary = in.Summary
+	if in.Vulnerabilities != nil {
+		in, out := &in.Vulnerabilities, &out.Vulnerabilities
+		*out = make([]KubeHunterVulnerability, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterReportData.
+func (in *KubeHunterReportData) DeepCopy() *KubeHunterReportData {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeHunterReportData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeHunterReportList) DeepCopyInto(out *KubeHunterReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KubeHunterReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterReportList.
+func (in *KubeHunterReportList) DeepCopy() *KubeHunterReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeHunterReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeHunterReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeHunterSummary) DeepCopyInto(out *KubeHunterSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterSummary.
+func (in *KubeHunterSummary) DeepCopy() *KubeHunterSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeHunterSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeHunterVulnerability) DeepCopyInto(out *KubeHunterVulnerability) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterVulnerability.
+func (in *KubeHunterVulnerability) DeepCopy() *KubeHunterVulnerability {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeHunterVulnerability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Layer) DeepCopyInto(out *Layer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Layer.
+func (in *Layer) DeepCopy() *Layer {
+	if in == nil {
+		return nil
+	}
+	out := new(Layer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyNamespace) DeepCopyInto(out *NetworkPolicyNamespace) {
+	*out = *in
+	if in.UncoveredWorkloads != nil {
+		in, out := &in.UncoveredWorkloads, &out.UncoveredWorkloads
+		*out = make([]NetworkPolicyWorkload, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyNamespace.
+func (in *NetworkPolicyNamespace) DeepCopy() *NetworkPolicyNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySummary) DeepCopyInto(out *NetworkPolicySummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySummary.
+func (in *NetworkPolicySummary) DeepCopy() *NetworkPolicySummary {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyWorkload) DeepCopyInto(out *NetworkPolicyWorkload) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyWorkload.
+func (in *NetworkPolicyWorkload) DeepCopy() *NetworkPolicyWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfig) DeepCopyInto(out *OperatorConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfig.
+func (in *OperatorConfig) DeepCopy() *OperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigList) DeepCopyInto(out *OperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigList.
+func (in *OperatorConfigList) DeepCopy() *OperatorConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigNamespaces) DeepCopyInto(out *OperatorConfigNamespaces) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigNamespaces.
+func (in *OperatorConfigNamespaces) DeepCopy() *OperatorConfigNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigScanner) DeepCopyInto(out *OperatorConfigScanner) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigScanner.
+func (in *OperatorConfigScanner) DeepCopy() *OperatorConfigScanner {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigScanner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigSpec) DeepCopyInto(out *OperatorConfigSpec) {
+	*out = *in
+	if in.VulnerabilityScanner != nil {
+		in, out := &in.VulnerabilityScanner, &out.VulnerabilityScanner
+		*out = new(OperatorConfigScanner)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigAuditScanner != nil {
+		in, out := &in.ConfigAuditScanner, &out.ConfigAuditScanner
+		*out = new(OperatorConfigScanner)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VulnerabilityReportTTL != nil {
+		in, out := &in.VulnerabilityReportTTL, &out.VulnerabilityReportTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ScanJobTimeout != nil {
+		in, out := &in.ScanJobTimeout, &out.ScanJobTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ConcurrentScanJobsLimit != nil {
+		in, out := &in.ConcurrentScanJobsLimit, &out.ConcurrentScanJobsLimit
+		*out = new(int)
+		**out = **in
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(OperatorConfigNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigSpec.
+func (in *OperatorConfigSpec) DeepCopy() *OperatorConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigStatus) DeepCopyInto(out *OperatorConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigStatus.
+func (in *OperatorConfigStatus) DeepCopy() *OperatorConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Registry) DeepCopyInto(out *Registry) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Registry.
+func (in *Registry) DeepCopy() *Registry {
+	if in == nil {
+		return nil
+	}
+	out := new(Registry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanFailureContainer) DeepCopyInto(out *ScanFailureContainer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanFailureContainer.
+func (in *ScanFailureContainer) DeepCopy() *ScanFailureContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanFailureContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanFailureReport) DeepCopyInto(out *ScanFailureReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanFailureReport.
+func (in *ScanFailureReport) DeepCopy() *ScanFailureReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanFailureReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScanFailureReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanFailureReportData) DeepCopyInto(out *ScanFailureReportData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ScanFailureContainer, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanFailureReportData.
+func (in *ScanFailureReportData) DeepCopy() *ScanFailureReportData {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanFailureReportData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanFailureReportList) DeepCopyInto(out *ScanFailureReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScanFailureReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanFailureReportList.
+func (in *ScanFailureReportList) DeepCopy() *ScanFailureReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanFailureReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScanFailureReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanMetadata) DeepCopyInto(out *ScanMetadata) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DB != nil {
+		in, out := &in.DB, &out.DB
+		*out = new(VulnerabilityDB)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanMetadata.
+func (in *ScanMetadata) DeepCopy() *ScanMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanPolicy) DeepCopyInto(out *ScanPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanPolicy.
+func (in *ScanPolicy) DeepCopy() *ScanPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScanPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanPolicyList) DeepCopyInto(out *ScanPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScanPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanPolicyList.
+func (in *ScanPolicyList) DeepCopy() *ScanPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScanPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanPolicySpec) DeepCopyInto(out *ScanPolicySpec) {
+	*out = *in
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]Severity, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoredVulnerabilities != nil {
+		in, out := &in.IgnoredVulnerabilities, &out.IgnoredVulnerabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VulnerabilityReportTTL != nil {
+		in, out := &in.VulnerabilityReportTTL, &out.VulnerabilityReportTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.VulnerabilitySLA != nil {
+		in, out := &in.VulnerabilitySLA, &out.VulnerabilitySLA
+		*out = make(map[Severity]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanPolicySpec.
+func (in *ScanPolicySpec) DeepCopy() *ScanPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scanner) DeepCopyInto(out *Scanner) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scanner.
+func (in *Scanner) DeepCopy() *Scanner {
+	if in == nil {
+		return nil
+	}
+	out := new(Scanner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Vulnerability) DeepCopyInto(out *Vulnerability) {
+	*out = *in
+	if in.Links != nil {
+		in, out := &in.Links, &out.Links
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Layer != nil {
+		in, out := &in.Layer, &out.Layer
+		*out = new(Layer)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vulnerability.
+func (in *Vulnerability) DeepCopy() *Vulnerability {
+	if in == nil {
+		return nil
+	}
+	out := new(Vulnerability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityDB) DeepCopyInto(out *VulnerabilityDB) {
+	*out = *in
+	in.UpdatedAt.DeepCopyInto(&out.UpdatedAt)
+	if in.NextUpdate != nil {
+		in, out := &in.NextUpdate, &out.NextUpdate
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityDB.
+func (in *VulnerabilityDB) DeepCopy() *VulnerabilityDB {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityHistory) DeepCopyInto(out *VulnerabilityHistory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityHistory.
+func (in *VulnerabilityHistory) DeepCopy() *VulnerabilityHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VulnerabilityHistory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityHistoryData) DeepCopyInto(out *VulnerabilityHistoryData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	if in.Open != nil {
+		in, out := &in.Open, &out.Open
+		*out = make([]VulnerabilityRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resolved != nil {
+		in, out := &in.Resolved, &out.Resolved
+		*out = make([]VulnerabilityRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityHistoryData.
+func (in *VulnerabilityHistoryData) DeepCopy() *VulnerabilityHistoryData {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityHistoryData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityHistoryList) DeepCopyInto(out *VulnerabilityHistoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VulnerabilityHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityHistoryList.
+func (in *VulnerabilityHistoryList) DeepCopy() *VulnerabilityHistoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityHistoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VulnerabilityHistoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityRecord) DeepCopyInto(out *VulnerabilityRecord) {
+	*out = *in
+	in.FirstSeen.DeepCopyInto(&out.FirstSeen)
+	if in.ResolvedAt != nil {
+		in, out := &in.ResolvedAt, &out.ResolvedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityRecord.
+func (in *VulnerabilityRecord) DeepCopy() *VulnerabilityRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityReport) DeepCopyInto(out *VulnerabilityReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityReport.
+func (in *VulnerabilityReport) DeepCopy() *VulnerabilityReport {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VulnerabilityReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityReportData) DeepCopyInto(out *VulnerabilityReportData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Scanner = in.Scanner
+	if in.Scan != nil {
+		in, out := &in.Scan, &out.Scan
+		*out = new(ScanMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Registry = in.Registry
+	out.Artifact = in.Artifact
+	if in.BaseImage != nil {
+		in, out := &in.BaseImage, &out.BaseImage
+		*out = new(BaseImage)
+		**out = **in
+	}
 	out.Summary = in.Summary
 	if in.Vulnerabilities != nil {
 		in, out := &in.Vulnerabilities, &out.Vulnerabilities
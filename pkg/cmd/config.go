@@ -6,10 +6,13 @@ import (
 	"io"
 	"strings"
 
+	"github.com/aquasecurity/starboard/pkg/plugin"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type LocalFlags struct {
@@ -17,7 +20,7 @@ type LocalFlags struct {
 	nameOnly bool
 }
 
-func NewConfigCmd(cf *genericclioptions.ConfigFlags, outWriter io.Writer) *cobra.Command {
+func NewConfigCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, outWriter io.Writer) *cobra.Command {
 	var localFlags LocalFlags
 	cmd := &cobra.Command{
 		Use:   "config",
@@ -45,9 +48,116 @@ func NewConfigCmd(cf *genericclioptions.ConfigFlags, outWriter io.Writer) *cobra
 		},
 	}
 	setLocalFlags(cmd, &localFlags)
+	cmd.AddCommand(NewConfigValidateCmd(buildInfo, cf, outWriter))
+	cmd.AddCommand(NewConfigConvertCmd(buildInfo, cf, outWriter))
 	return cmd
 }
 
+func NewConfigValidateCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the configuration of Starboard and its scanner plugins",
+		Long: `Validate settings in the Starboard ConfigMap and in the ConfigMaps and Secrets of the configured vulnerability
+and configuration audit scanner plugins, e.g. Trivy settings, Conftest policies, or the Polaris configuration file
+
+Problems are printed for each source of settings, so typos can be fixed before they fail scan jobs.
+`,
+		Example: fmt.Sprintf(`  # Validate the configuration
+  %[1]s config validate`, buildInfo.Executable),
+		Args: cobra.NoArgs,
+		RunE: ValidateConfig(buildInfo, cf, out),
+	}
+}
+
+func ValidateConfig(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		kubeConfig, err := cf.ToRESTConfig()
+		if err != nil {
+			return err
+		}
+		kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+		if err != nil {
+			return err
+		}
+		kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+		if err != nil {
+			return err
+		}
+		config, err := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName).Read(ctx)
+		if err != nil {
+			return err
+		}
+		problems := printProblems(out, fmt.Sprintf("ConfigMap %s/%s", starboard.NamespaceName, starboard.ConfigMapName),
+			config.Validate())
+
+		resolver := plugin.NewResolver().
+			WithBuildInfo(buildInfo).
+			WithNamespace(starboard.NamespaceName).
+			WithServiceAccountName(starboard.ServiceAccountName).
+			WithConfig(config).
+			WithClient(kubeClient)
+		// Unsupported scanners are reported as problems of the Starboard ConfigMap.
+		var plugins []interface{}
+		var pluginContexts []starboard.PluginContext
+		if vulnerabilityPlugin, pluginContext, err := resolver.GetVulnerabilityPlugin(); err == nil {
+			plugins = append(plugins, vulnerabilityPlugin)
+			pluginContexts = append(pluginContexts, pluginContext)
+		}
+		if configAuditPlugin, pluginContext, err := resolver.GetConfigAuditPlugin(); err == nil {
+			plugins = append(plugins, configAuditPlugin)
+			pluginContexts = append(pluginContexts, pluginContext)
+		}
+		for i, pluginContext := range pluginContexts {
+			source := fmt.Sprintf("%s plugin config %s/%s", pluginContext.GetName(), starboard.NamespaceName,
+				starboard.GetPluginConfigMapName(pluginContext.GetName()))
+			problems += printProblems(out, source, validatePluginConfig(buildInfo, plugins[i], pluginContext))
+		}
+		if problems > 0 {
+			return fmt.Errorf("found %d configuration problem(s)", problems)
+		}
+		return nil
+	}
+}
+
+// validatePluginConfig returns problems with configuration settings of the
+// specified plugin. Plugins that implement starboard.PluginConfigValidator
+// validate their own settings.
+func validatePluginConfig(buildInfo starboard.BuildInfo, p interface{}, pluginContext starboard.PluginContext) []error {
+	pluginConfig, err := pluginContext.GetConfig()
+	if errors.IsNotFound(err) {
+		return []error{fmt.Errorf("ConfigMap not found, run '%s init' to create it", buildInfo.Executable)}
+	}
+	if err != nil {
+		return []error{err}
+	}
+	var problems []error
+	if _, err := pluginConfig.GetScanJobOverride(""); err != nil {
+		problems = append(problems, err)
+	}
+	if _, err := pluginConfig.GetEcosystems(""); err != nil {
+		problems = append(problems, err)
+	}
+	if validator, ok := p.(starboard.PluginConfigValidator); ok {
+		problems = append(problems, validator.ValidateConfig(pluginConfig)...)
+	}
+	return problems
+}
+
+// printProblems prints problems with settings of the specified source and
+// returns the number of printed problems.
+func printProblems(out io.Writer, source string, problems []error) int {
+	if len(problems) == 0 {
+		_, _ = fmt.Fprintf(out, "%s: OK\n", source)
+		return 0
+	}
+	_, _ = fmt.Fprintf(out, "%s: %d problem(s)\n", source, len(problems))
+	for i, problem := range problems {
+		_, _ = fmt.Fprintf(out, "  %d. %v\n", i+1, problem)
+	}
+	return len(problems)
+}
+
 func getFilteredValues(data starboard.ConfigData, localFlags *LocalFlags) (string, error) {
 	if localFlags.get != "" {
 		value := data[localFlags.get]
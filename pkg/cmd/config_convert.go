@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operatorconfig"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	operatorNamespaceFlagName  = "operator-namespace"
+	operatorDeploymentFlagName = "operator-deployment"
+)
+
+func NewConfigConvertCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Convert the configuration of the operator to an OperatorConfig",
+		Long: `Convert the configuration of the operator, i.e. environment variables of its Deployment, keys of the
+starboard-operator ConfigMap, the scanners selected in the starboard ConfigMap, and settings of the selected scanner
+plugins, to the OperatorConfig that configures the operator in the same way
+
+Values of environment variables and settings that are read from Secrets are not converted.
+`,
+		Example: fmt.Sprintf(`  # Convert the configuration of the operator installed in the starboard-system namespace
+  %[1]s config convert
+
+  # Convert the configuration of the operator and apply the OperatorConfig
+  %[1]s config convert --operator-namespace starboard-operator | kubectl apply -f -`, buildInfo.Executable),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			format := cmd.Flag("output").Value.String()
+			switch format {
+			case "yaml", "json":
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json", format)
+			}
+			namespace, err := cmd.Flags().GetString(operatorNamespaceFlagName)
+			if err != nil {
+				return err
+			}
+			name, err := cmd.Flags().GetString(operatorDeploymentFlagName)
+			if err != nil {
+				return err
+			}
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
+			operatorConfig, err := convertOperatorConfig(ctx, kubeClient, namespace, name)
+			if err != nil {
+				return err
+			}
+			printer, err := genericclioptions.NewPrintFlags("").
+				WithTypeSetter(starboard.NewScheme()).
+				WithDefaultOutput(format).
+				ToPrinter()
+			if err != nil {
+				return err
+			}
+			return printer.PrintObj(operatorConfig, out)
+		},
+	}
+	cmd.Flags().StringP("output", "o", "yaml", "Output format. One of yaml|json")
+	cmd.Flags().String(operatorNamespaceFlagName, "starboard-system", "The namespace the operator is installed in")
+	cmd.Flags().String(operatorDeploymentFlagName, "starboard-operator", "The name of the Deployment of the operator")
+	return cmd
+}
+
+// convertOperatorConfig converts the configuration of the operator that is
+// run by the specified Deployment to the OperatorConfig.
+func convertOperatorConfig(ctx context.Context, c client.Client, namespace, name string) (*v1alpha1.OperatorConfig, error) {
+	var deploy appsv1.Deployment
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &deploy)
+	if err != nil {
+		return nil, fmt.Errorf("getting operator deployment: %w", err)
+	}
+	if len(deploy.Spec.Template.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("operator deployment %s/%s has no containers", namespace, name)
+	}
+	environment := operatorconfig.GetContainerEnv(deploy.Spec.Template.Spec.Containers[0], namespace)
+
+	if enabled, _ := strconv.ParseBool(environment["OPERATOR_CONFIG_RELOAD_ENABLED"]); enabled {
+		overrides, err := getConfigMapData(ctx, c, namespace, etc.ConfigMapName)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range overrides {
+			environment[key] = value
+		}
+	}
+	config, err := etc.ParseOperatorConfig(environment)
+	if err != nil {
+		return nil, fmt.Errorf("parsing environment variables of operator: %w", err)
+	}
+
+	data, err := getConfigMapData(ctx, c, namespace, starboard.ConfigMapName)
+	if err != nil {
+		return nil, err
+	}
+	var scanners []starboard.Scanner
+	if scanner, err := starboard.ConfigData(data).GetVulnerabilityReportsScanner(); err == nil {
+		scanners = append(scanners, scanner)
+	}
+	if scanner, err := starboard.ConfigData(data).GetConfigAuditReportsScanner(); err == nil {
+		scanners = append(scanners, scanner)
+	}
+	plugins := make(map[string]map[string]string)
+	for _, scanner := range scanners {
+		settings, err := getConfigMapData(ctx, c, config.GetScanJobsNamespace(), starboard.GetPluginConfigMapName(string(scanner)))
+		if err != nil {
+			return nil, err
+		}
+		if len(settings) > 0 {
+			plugins[string(scanner)] = settings
+		}
+	}
+	if len(plugins) == 0 {
+		plugins = nil
+	}
+
+	operatorConfig := operatorconfig.FromConfig(config, data, plugins)
+	return &operatorConfig, nil
+}
+
+// getConfigMapData returns data of the specified ConfigMap, or nil if there's
+// no such ConfigMap.
+func getConfigMapData(ctx context.Context, c client.Client, namespace, name string) (map[string]string, error) {
+	var cm corev1.ConfigMap
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &cm)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting %s ConfigMap: %w", name, err)
+	}
+	return cm.Data, nil
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	fromFlagName = "from"
+	toFlagName   = "to"
+)
+
+func NewDiffCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show differences between security reports",
+	}
+	diffCmd.AddCommand(NewDiffVulnerabilityReportsCmd(buildInfo.Executable, cf, out))
+
+	return diffCmd
+}
+
+func NewDiffVulnerabilityReportsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "vulnerabilityreports (NAME | TYPE/NAME) --from FILENAME",
+		Aliases: []string{"vulns", "vuln", "vulnerabilities"},
+		Short:   "Show vulnerabilities added, removed, and changed since a previous scan",
+		Long: fmt.Sprintf(`Show vulnerabilities added, removed, and changed in vulnerability reports of the specified workload
+since a previous scan
+
+Vulnerability reports are overwritten by each scan, so reports of the previous scan must be saved beforehand,
+e.g. with the "%[1]s get vulnerabilityreports -o json" command, and specified with the --from flag.
+By default they're compared with reports currently stored in the cluster.
+
+TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
+NAME is the name of a particular Kubernetes workload.
+`, executable),
+		Example: fmt.Sprintf(`  # Save reports of a Deployment before redeploying a fix
+  %[1]s get vulns deploy/app -o json > app.before.json
+
+  # Rescan the Deployment after redeploying and show what changed
+  %[1]s scan vulnerabilityreports deploy/app
+  %[1]s diff vulnerabilities deploy/app --from app.before.json
+
+  # Compare two saved reports without connecting to the cluster
+  %[1]s diff vulns deploy/app --from app.before.json --to app.after.json
+
+  # Show changes in JSON output format
+  %[1]s diff vulns deploy/app --from app.before.json -o json`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			from, err := cmd.Flags().GetString(fromFlagName)
+			if err != nil {
+				return err
+			}
+			if from == "" {
+				return fmt.Errorf("required --%s flag not specified", fromFlagName)
+			}
+			to, err := cmd.Flags().GetString(toFlagName)
+			if err != nil {
+				return err
+			}
+			if from == "-" && to == "-" {
+				return fmt.Errorf("only one of --%s and --%s flags can read from stdin", fromFlagName, toFlagName)
+			}
+			format := cmd.Flag("output").Value.String()
+			switch format {
+			case "", "table", "json":
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: table,json", format)
+			}
+
+			fromReports, err := readVulnerabilityReports(cmd.InOrStdin(), from)
+			if err != nil {
+				return err
+			}
+			var toReports []v1alpha1.VulnerabilityReport
+			if to != "" {
+				toReports, err = readVulnerabilityReports(cmd.InOrStdin(), to)
+				if err != nil {
+					return err
+				}
+			} else {
+				toReports, err = findVulnerabilityReports(ctx, cf, args)
+				if err != nil {
+					return err
+				}
+			}
+
+			diff := vulnerabilityreport.Compare(fromReports, toReports)
+			if format == "json" {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(diff)
+			}
+			if diff.Empty() {
+				_, _ = fmt.Fprintln(out, "No differences found.")
+				return nil
+			}
+			return diff.WriteTable(out)
+		},
+	}
+
+	cmd.Flags().String(fromFlagName, "",
+		"File with vulnerability reports of the previous scan in YAML or JSON output format, or - to read them from stdin")
+	cmd.Flags().String(toFlagName, "",
+		"File with vulnerability reports to compare with instead of reports stored in the cluster, or - to read them from stdin")
+	cmd.Flags().StringP("output", "o", "", "Output format. One of table|json")
+
+	return cmd
+}
+
+// findVulnerabilityReports returns vulnerability reports of the workload
+// specified by args stored in the cluster.
+func findVulnerabilityReports(ctx context.Context, cf *genericclioptions.ConfigFlags, args []string) ([]v1alpha1.VulnerabilityReport, error) {
+	kubeConfig, err := cf.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+	if err != nil {
+		return nil, err
+	}
+	ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := cf.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	workload, _, err := WorkloadFromArgs(mapper, ns, args)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := vulnerabilityreport.NewReadWriter(kubeClient).FindByOwnerInHierarchy(ctx, workload)
+	if err != nil {
+		return nil, fmt.Errorf("list vulnerability reports: %w", err)
+	}
+	return reports, nil
+}
+
+// readVulnerabilityReports reads vulnerability reports from the specified
+// file, or stdin if the filename is -. Other objects are skipped.
+func readVulnerabilityReports(stdin io.Reader, filename string) ([]v1alpha1.VulnerabilityReport, error) {
+	r := stdin
+	if filename != "-" {
+		file, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		r = file
+	}
+	objects, err := kube.ReadManifests(starboard.NewScheme(), r)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filename, err)
+	}
+	var reports []v1alpha1.VulnerabilityReport
+	for _, obj := range objects {
+		if report, ok := obj.(*v1alpha1.VulnerabilityReport); ok {
+			reports = append(reports, *report)
+		}
+	}
+	if len(reports) == 0 {
+		return nil, errors.New("no vulnerability reports found in " + filename)
+	}
+	return reports, nil
+}
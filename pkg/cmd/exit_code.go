@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	exitCodeFlagName = "exit-code"
+	severityFlagName = "severity"
+)
+
+var (
+	// vulnerabilitySeverities are severities of vulnerabilities from the
+	// highest to the lowest.
+	vulnerabilitySeverities = []string{
+		string(v1alpha1.SeverityCritical),
+		string(v1alpha1.SeverityHigh),
+		string(v1alpha1.SeverityMedium),
+		string(v1alpha1.SeverityLow),
+		string(v1alpha1.SeverityUnknown),
+	}
+	// configAuditSeverities are severities of failed checks from the highest
+	// to the lowest.
+	configAuditSeverities = []string{
+		v1alpha1.ConfigAuditSeverityDanger,
+		v1alpha1.ConfigAuditSeverityWarning,
+	}
+)
+
+// ExitError is returned by commands when reports contain findings at or above
+// the severity threshold, so that the executable exits with the exit code
+// requested by the --exit-code flag, e.g. to fail a CI pipeline.
+type ExitError struct {
+	Code    int
+	Message string
+}
+
+func (e *ExitError) Error() string {
+	return e.Message
+}
+
+func registerExitCodeOpts(cmd *cobra.Command, severities []string) {
+	cmd.Flags().Int(exitCodeFlagName, 0,
+		"The exit code when reports contain findings at or above the severity threshold."+
+			" A value of zero means don't fail regardless of findings.")
+	cmd.Flags().String(severityFlagName, "",
+		fmt.Sprintf("The severity threshold of findings, one of %s. Defaults to the lowest severity.",
+			strings.Join(severities, ", ")))
+}
+
+// exitCodeOpts determines whether a command fails because of findings in
+// reports.
+type exitCodeOpts struct {
+	code      int
+	threshold string
+	// severities are severities at or above the threshold.
+	severities []string
+}
+
+func getExitCodeOpts(cmd *cobra.Command, severities []string) (opts exitCodeOpts, err error) {
+	opts.code, err = cmd.Flags().GetInt(exitCodeFlagName)
+	if err != nil {
+		return
+	}
+	threshold, err := cmd.Flags().GetString(severityFlagName)
+	if err != nil {
+		return
+	}
+	if threshold == "" {
+		opts.threshold = severities[len(severities)-1]
+		opts.severities = severities
+		return
+	}
+	for i, severity := range severities {
+		if strings.EqualFold(threshold, severity) {
+			opts.threshold = severity
+			opts.severities = severities[:i+1]
+			return
+		}
+	}
+	err = fmt.Errorf("invalid value (%s) of --%s flag; allowed values (%s)",
+		threshold, severityFlagName, strings.Join(severities, ", "))
+	return
+}
+
+// check returns the ExitError if the specified counts of findings by severity
+// include findings at or above the threshold.
+func (o exitCodeOpts) check(counts map[string]int, findings string) error {
+	if o.code == 0 {
+		return nil
+	}
+	var total int
+	for _, severity := range o.severities {
+		total += counts[severity]
+	}
+	if total == 0 {
+		return nil
+	}
+	return &ExitError{
+		Code:    o.code,
+		Message: fmt.Sprintf("found %d %s with severity %s or higher", total, findings, o.threshold),
+	}
+}
+
+// countVulnerabilities returns counts of vulnerabilities by severity in the
+// specified reports.
+func countVulnerabilities(reports []v1alpha1.VulnerabilityReport) map[string]int {
+	counts := make(map[string]int)
+	for _, report := range reports {
+		summary := report.Report.Summary
+		counts[string(v1alpha1.SeverityCritical)] += summary.CriticalCount
+		counts[string(v1alpha1.SeverityHigh)] += summary.HighCount
+		counts[string(v1alpha1.SeverityMedium)] += summary.MediumCount
+		counts[string(v1alpha1.SeverityLow)] += summary.LowCount
+		counts[string(v1alpha1.SeverityUnknown)] += summary.UnknownCount
+	}
+	return counts
+}
+
+// countFailedChecks returns counts of failed checks by severity in the
+// specified report data.
+func countFailedChecks(data v1alpha1.ConfigAuditReportData) map[string]int {
+	counts := make(map[string]int)
+	for _, check := range data.Checks {
+		if !check.Success {
+			counts[strings.ToLower(check.Severity)]++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewFixCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fix (NAME | TYPE/NAME)",
+		Short: "Suggest package upgrades that remediate vulnerabilities of a workload",
+		Long: `Suggest the minimal set of package upgrades that remediate vulnerabilities of a workload at or above the
+severity threshold, grouped by container
+
+Upgrades are derived from fixed versions in vulnerability reports of the workload. Vulnerabilities of the same
+package are remediated by a single upgrade to the highest of versions that fix each of them.
+
+TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
+NAME is the name of a particular Kubernetes workload.
+`,
+		Example: fmt.Sprintf(`  # Suggest upgrades that remediate HIGH and CRITICAL vulnerabilities of a deployment
+  %[1]s fix deploy/nginx
+
+  # Suggest upgrades that remediate vulnerabilities of all severities of a container of a deployment
+  %[1]s fix deploy/nginx -c sidecar --severity UNKNOWN
+
+  # Suggest upgrades in JSON output format
+  %[1]s fix deploy/nginx -o json`, buildInfo.Executable),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			format := cmd.Flag("output").Value.String()
+			switch format {
+			case "", "table", "json":
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: table,json", format)
+			}
+			severities, err := getSeveritiesAtOrAbove(cmd)
+			if err != nil {
+				return err
+			}
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
+			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return err
+			}
+			mapper, err := cf.ToRESTMapper()
+			if err != nil {
+				return err
+			}
+			workload, _, err := WorkloadFromArgs(mapper, ns, args)
+			if err != nil {
+				return err
+			}
+			reports, err := vulnerabilityreport.NewReadWriter(kubeClient).FindByOwnerInHierarchy(ctx, workload)
+			if err != nil {
+				return fmt.Errorf("list vulnerability reports: %w", err)
+			}
+			if len(reports) == 0 {
+				_, _ = fmt.Fprintf(out, "No reports found in %s namespace.\n", workload.Namespace)
+				return nil
+			}
+			container := cmd.Flag("container").Value.String()
+			var remediations []vulnerabilityreport.Remediation
+			for _, report := range reports {
+				if container != "" && report.Labels[starboard.LabelContainerName] != container {
+					continue
+				}
+				remediations = append(remediations, vulnerabilityreport.NewRemediation(report, severities))
+			}
+			if len(remediations) == 0 {
+				return fmt.Errorf("container %s is not valid for %s %s", container, strings.ToLower(string(workload.Kind)), workload.Name)
+			}
+			if format == "json" {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(remediations)
+			}
+			return vulnerabilityreport.WriteRemediations(remediations, out)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Output format. One of table|json")
+	cmd.Flags().StringP("container", "c", "", "Suggest upgrades of this container only")
+	cmd.Flags().String(severityFlagName, string(v1alpha1.SeverityHigh),
+		fmt.Sprintf("The severity threshold of remediated vulnerabilities, one of %s",
+			strings.Join(vulnerabilitySeverities, ", ")))
+
+	return cmd
+}
+
+// getSeveritiesAtOrAbove returns severities of vulnerabilities at or above
+// the threshold specified with the --severity flag.
+func getSeveritiesAtOrAbove(cmd *cobra.Command) ([]v1alpha1.Severity, error) {
+	threshold, err := cmd.Flags().GetString(severityFlagName)
+	if err != nil {
+		return nil, err
+	}
+	var severities []v1alpha1.Severity
+	for _, severity := range vulnerabilitySeverities {
+		severities = append(severities, v1alpha1.Severity(severity))
+		if strings.EqualFold(threshold, severity) {
+			return severities, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid value (%s) of --%s flag; allowed values (%s)",
+		threshold, severityFlagName, strings.Join(vulnerabilitySeverities, ", "))
+}
@@ -15,7 +15,10 @@ func NewGetCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags,
 	}
 	getCmd.AddCommand(NewGetVulnerabilityReportsCmd(buildInfo.Executable, cf, outWriter))
 	getCmd.AddCommand(NewGetConfigAuditReportsCmd(buildInfo.Executable, cf, outWriter))
-	getCmd.PersistentFlags().StringP("output", "o", "", "Output format. One of yaml|json")
+	getCmd.AddCommand(NewGetNodeReportsCmd(buildInfo.Executable, cf, outWriter))
+	getCmd.AddCommand(NewGetAffectedCmd(buildInfo.Executable, cf, outWriter))
+	getCmd.AddCommand(NewGetExpiringCmd(buildInfo.Executable, cf, outWriter))
+	getCmd.PersistentFlags().StringP("output", "o", "", "Output format. One of table|wide|yaml|json|sarif|cyclonedx|junit|csv|markdown")
 
 	return getCmd
 }
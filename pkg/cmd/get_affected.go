@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// affectedColumns are columns of vulnerabilities printed by the get affected
+// command by default.
+var affectedColumns = []vulnerabilityreport.Column{
+	vulnerabilityreport.ColumnNamespace,
+	vulnerabilityreport.ColumnWorkload,
+	vulnerabilityreport.ColumnContainer,
+	vulnerabilityreport.ColumnImage,
+	vulnerabilityreport.ColumnSeverity,
+	vulnerabilityreport.ColumnPackage,
+	vulnerabilityreport.ColumnInstalled,
+	vulnerabilityreport.ColumnFixed,
+}
+
+func NewGetAffectedCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "affected VULNERABILITY_ID",
+		Short: "Get workloads affected by the specified vulnerability",
+		Long: `Get containers of workloads whose vulnerability reports contain the specified vulnerability, e.g. CVE-2021-44228
+
+Reports are looked up in all namespaces, unless the namespace is specified with the --namespace flag, by labels
+indexing vulnerabilities of reports, so that lookups are fast even in large clusters.
+`,
+		Example: fmt.Sprintf(`  # Get workloads affected by the specified CVE in all namespaces
+  %[1]s get affected CVE-2021-44228
+
+  # Get workloads affected by the specified CVE in the specified namespace
+  %[1]s get affected CVE-2021-44228 -n staging
+
+  # Get workloads affected by the specified CVE in the CSV output format with the specified columns
+  %[1]s get affected CVE-2021-44228 -o csv --columns namespace,workload,image,fixed`, executable),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			id := args[0]
+			if strings.HasPrefix(strings.ToUpper(id), "CVE-") {
+				id = strings.ToUpper(id)
+			}
+			if vulnerabilityreport.GetVulnerabilityIDLabel(id) == "" {
+				return fmt.Errorf("invalid vulnerability ID: %s", args[0])
+			}
+			var namespace string
+			if flag := cmd.Flag("namespace"); flag != nil && flag.Changed {
+				namespace = flag.Value.String()
+			}
+			format := cmd.Flag("output").Value.String()
+			var columns []vulnerabilityreport.Column
+			var err error
+			switch format {
+			case "", "table", "csv":
+				columns, err = vulnerabilityreport.ParseColumns(cmd.Flag("columns").Value.String(), affectedColumns)
+			case "yaml", "json":
+			default:
+				err = fmt.Errorf("invalid output format %q, allowed formats are: table,csv,yaml,json", format)
+			}
+			if err != nil {
+				return err
+			}
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
+			reports, err := vulnerabilityreport.NewReadWriter(kubeClient).FindByVulnerabilityID(ctx, namespace, id)
+			if err != nil {
+				return err
+			}
+			reports = vulnerabilityreport.FilterByVulnerabilityID(reports, id)
+			switch format {
+			case "csv":
+				return vulnerabilityreport.WriteCSV(reports, columns, out)
+			case "yaml", "json":
+				printer, err := genericclioptions.NewPrintFlags("").
+					WithTypeSetter(starboard.NewScheme()).
+					WithDefaultOutput(format).
+					ToPrinter()
+				if err != nil {
+					return err
+				}
+				return printer.PrintObj(&v1alpha1.VulnerabilityReportList{Items: reports}, out)
+			}
+			if len(reports) == 0 {
+				_, _ = fmt.Fprintf(out, "No workloads affected by %s found.\n", id)
+				return nil
+			}
+			return vulnerabilityreport.WriteTable(reports, columns, out)
+		},
+	}
+
+	registerColumnsOpts(cmd)
+
+	return cmd
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/junit"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/markdown"
+	"github.com/aquasecurity/starboard/pkg/sarif"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -32,10 +37,27 @@ NAME is the name of a particular Kubernetes resource.
   %[1]s get configaudit replicaset/nginx
 
   # Get configuration audit report for a CronJob with the specified name in JSON output format
-  %[1]s get configaudit cj/my-job -o json`, executable),
+  %[1]s get configaudit cj/my-job -o json
+
+  # Get configuration audit report for a Deployment in SARIF output format
+  %[1]s get configaudit deploy/nginx -o sarif > nginx.sarif
+
+  # Get configuration audit report for a Deployment in JUnit XML output format, e.g. to render failed checks in CI
+  %[1]s get configaudit deploy/nginx -o junit > nginx.xml
+
+  # Get a summary of configuration audit report for a Deployment in Markdown, e.g. to comment on a pull request
+  %[1]s get configaudit deploy/nginx -o markdown > nginx.md
+
+  # Get configuration audit report for a Deployment and exit with code 1 if there are failed danger checks
+  %[1]s get configaudit deploy/nginx --exit-code 1 --severity danger`, executable),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
+			exitCodeOpts, err := getExitCodeOpts(cmd, configAuditSeverities)
+			if err != nil {
+				return err
+			}
+
 			kubeConfig, err := cf.ToRESTConfig()
 			if err != nil {
 				return err
@@ -69,6 +91,21 @@ NAME is the name of a particular Kubernetes resource.
 			}
 
 			format := cmd.Flag("output").Value.String()
+			if format == "sarif" || format == "junit" || format == "markdown" {
+				uri := resourceName(workload)
+				switch format {
+				case "sarif":
+					err = sarif.Write(sarif.FromConfigAuditReport(report.Report, uri), out)
+				case "junit":
+					err = junit.Write(junit.FromConfigAuditReport(report.Report, uri), out)
+				default:
+					err = markdown.WriteConfigAuditReport(uri, report.Report, markdown.DefaultTop, out)
+				}
+				if err != nil {
+					return fmt.Errorf("print configuration audit report: %w", err)
+				}
+				return exitCodeOpts.check(countFailedChecks(report.Report), "failed checks")
+			}
 			printer, err := genericclioptions.NewPrintFlags("").
 				WithTypeSetter(scheme).
 				WithDefaultOutput(format).
@@ -81,9 +118,21 @@ NAME is the name of a particular Kubernetes resource.
 				return fmt.Errorf("print vulnerability reports: %w", err)
 			}
 
-			return nil
+			return exitCodeOpts.check(countFailedChecks(report.Report), "failed checks")
 		},
 	}
 
+	registerExitCodeOpts(cmd, configAuditSeverities)
+
 	return cmd
 }
+
+// resourceName returns the name of the specified resource qualified with its
+// kind and namespace, e.g. default/deployment/nginx.
+func resourceName(resource kube.ObjectRef) string {
+	name := strings.ToLower(string(resource.Kind)) + "/" + resource.Name
+	if resource.Namespace != "" {
+		name = resource.Namespace + "/" + name
+	}
+	return name
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ttlFlagName    = "ttl"
+	withinFlagName = "within"
+)
+
+func NewGetExpiringCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "expiring",
+		Short: "Preview vulnerability reports that the operator deletes when their TTL expires",
+		Long: `Preview vulnerability reports whose TTL set in the starboard.aquasecurity.github.io/report-ttl annotation
+expires, and when, sorted by the time of expiration
+
+Reports are looked up in all namespaces, unless the namespace is specified with the --namespace flag. Reports whose
+TTL has already expired are deleted by the next sweep of the operator. Set the --ttl flag to simulate a change of the
+TTL, e.g. of OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL, which then applies to all reports, including reports without
+TTL. Nothing is deleted by this command.
+`,
+		Example: fmt.Sprintf(`  # Preview all vulnerability reports with TTL
+  %[1]s get expiring
+
+  # Preview vulnerability reports that expire within the next 24 hours in the specified namespace
+  %[1]s get expiring --within 24h -n staging
+
+  # Preview vulnerability reports that would expire within the next week if the TTL was 72h
+  %[1]s get expiring --ttl 72h --within 168h`, executable),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			format := cmd.Flag("output").Value.String()
+			switch format {
+			case "", "table", "json":
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: table,json", format)
+			}
+			var ttl *time.Duration
+			if cmd.Flag(ttlFlagName).Changed {
+				value, err := cmd.Flags().GetDuration(ttlFlagName)
+				if err != nil {
+					return err
+				}
+				ttl = &value
+			}
+			within, err := cmd.Flags().GetDuration(withinFlagName)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			var before time.Time
+			if within > 0 {
+				before = now.Add(within)
+			}
+			var namespace string
+			if flag := cmd.Flag("namespace"); flag != nil && flag.Changed {
+				namespace = flag.Value.String()
+			}
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
+			var list v1alpha1.VulnerabilityReportList
+			if err := kubeClient.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+				return fmt.Errorf("listing vulnerability reports: %w", err)
+			}
+			expirations, err := vulnerabilityreport.PreviewExpirations(list.Items, ttl, before)
+			if err != nil {
+				return err
+			}
+			if format == "json" {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(expirations)
+			}
+			if len(expirations) == 0 {
+				_, _ = fmt.Fprintln(out, "No expiring vulnerability reports found.")
+				return nil
+			}
+			return vulnerabilityreport.WriteExpirationsTable(expirations, now, out)
+		},
+	}
+
+	cmd.Flags().Duration(ttlFlagName, 0, "The TTL of all reports to simulate, e.g. 72h. If not set, the TTL of each report is used")
+	cmd.Flags().Duration(withinFlagName, 0, "Only preview reports that expire within the duration, e.g. 24h. If not set, all reports with TTL are previewed")
+
+	return cmd
+}
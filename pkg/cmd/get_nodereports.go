@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"github.com/aquasecurity/starboard/pkg/report"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const statusFlagName = "status"
+
+func NewGetNodeReportsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "nodereports [NODE...]",
+		Aliases: []string{"nodereport", "ciskubebenchreports", "kubebench"},
+		Short:   "Get node reports",
+		Long: `Get CIS Kubernetes Benchmark reports of the specified nodes, or of all nodes
+
+NODE is the name of a particular node. Nodes can also be selected by labels with the --selector flag.
+
+In the table output format, reports are summarized with counts of results by status, unless
+the --status flag is specified, in which case results with the specified statuses are listed.
+`,
+		Example: fmt.Sprintf(`  # Get summaries of reports of all nodes
+  %[1]s get nodereports
+
+  # Get failed and warned results of the specified node
+  %[1]s get nodereports kind-control-plane --status fail,warn
+
+  # Get reports of worker nodes in JSON output format
+  %[1]s get nodereports -l '!node-role.kubernetes.io/control-plane' -o json
+
+  # Get the report of the specified node as an HTML page
+  %[1]s get nodereports kind-control-plane -o html > kind-control-plane.node.html`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			format := cmd.Flag("output").Value.String()
+			switch format {
+			case "", "table", "yaml", "json", "html":
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: table,yaml,json,html", format)
+			}
+			statuses, err := cmd.Flags().GetStringSlice(statusFlagName)
+			if err != nil {
+				return err
+			}
+			for _, status := range statuses {
+				if !ext.SliceContainsString(kubebench.Statuses, strings.ToUpper(status)) {
+					return fmt.Errorf("invalid status %q, allowed statuses are: %s", status,
+						strings.ToLower(strings.Join(kubebench.Statuses, ",")))
+				}
+			}
+			selector, err := cmd.Flags().GetString(selectorFlagName)
+			if err != nil {
+				return err
+			}
+			nodeSelector, err := labels.Parse(selector)
+			if err != nil {
+				return fmt.Errorf("invalid selector %q: %w", selector, err)
+			}
+
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			scheme := starboard.NewScheme()
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+			if err != nil {
+				return err
+			}
+			reports, err := findNodeReports(ctx, kubeClient, args, nodeSelector)
+			if err != nil {
+				return err
+			}
+			if len(reports) == 0 {
+				_, _ = fmt.Fprintln(out, "No reports found.")
+				return nil
+			}
+
+			switch format {
+			case "yaml", "json":
+				printer, err := genericclioptions.NewPrintFlags("").
+					WithTypeSetter(scheme).
+					WithDefaultOutput(format).
+					ToPrinter()
+				if err != nil {
+					return fmt.Errorf("create printer: %w", err)
+				}
+				return printer.PrintObj(&v1alpha1.CISKubeBenchReportList{Items: reports}, out)
+			case "html":
+				if len(reports) != 1 {
+					return fmt.Errorf("the html output format requires exactly one node, but reports of %d nodes were found", len(reports))
+				}
+				reporter := report.NewNodeReporter(ext.NewSystemClock(), kubeClient)
+				return reporter.Generate(kube.ObjectRef{Kind: kube.KindNode, Name: reports[0].Name}, out)
+			default:
+				if len(statuses) > 0 {
+					return kubebench.WriteResultsTable(reports, statuses, out)
+				}
+				return kubebench.WriteTable(reports, time.Now(), out)
+			}
+		},
+	}
+
+	cmd.Flags().StringP(selectorFlagName, "l", "", "Get reports only of nodes matching the specified label selector")
+	cmd.Flags().StringSlice(statusFlagName, nil, "Comma-separated list of statuses of results listed in the table output format, any of "+
+		strings.ToLower(strings.Join(kubebench.Statuses, ",")))
+
+	return cmd
+}
+
+// findNodeReports returns CIS Kubernetes Benchmark reports, sorted by name, of
+// nodes with the specified names and labels matching the specified selector.
+// All nodes are matched if no names are specified. It's an error if a report of a
+// node with the specified name is not found.
+func findNodeReports(ctx context.Context, kubeClient client.Client, names []string, selector labels.Selector) ([]v1alpha1.CISKubeBenchReport, error) {
+	var list v1alpha1.CISKubeBenchReportList
+	err := kubeClient.List(ctx, &list)
+	if err != nil {
+		return nil, fmt.Errorf("list node reports: %w", err)
+	}
+	var matching map[string]bool
+	if !selector.Empty() {
+		var nodes corev1.NodeList
+		err = kubeClient.List(ctx, &nodes, client.MatchingLabelsSelector{Selector: selector})
+		if err != nil {
+			return nil, fmt.Errorf("list nodes: %w", err)
+		}
+		matching = make(map[string]bool)
+		for _, node := range nodes.Items {
+			matching[node.Name] = true
+		}
+	}
+	found := make(map[string]bool)
+	var reports []v1alpha1.CISKubeBenchReport
+	for _, report := range list.Items {
+		if len(names) > 0 && !ext.SliceContainsString(names, report.Name) {
+			continue
+		}
+		found[report.Name] = true
+		if matching != nil && !matching[report.Name] {
+			continue
+		}
+		reports = append(reports, report)
+	}
+	for _, name := range names {
+		if !found[name] {
+			return nil, fmt.Errorf("report of node %s not found", name)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Name < reports[j].Name
+	})
+	return reports, nil
+}
@@ -7,6 +7,12 @@ import (
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/cyclonedx"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/junit"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/markdown"
+	"github.com/aquasecurity/starboard/pkg/sarif"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/spf13/cobra"
@@ -17,7 +23,7 @@ import (
 
 func NewGetVulnerabilityReportsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "vulnerabilityreports (NAME | TYPE/NAME)",
+		Use:     "vulnerabilityreports (NAME | TYPE/NAME | --watch)",
 		Aliases: []string{"vulns", "vuln", "vulnerabilities"},
 		Short:   "Get vulnerability reports",
 		Long: `Get vulnerability reports for the specified workload
@@ -39,10 +45,64 @@ NAME is the name of a particular Kubernetes workload.
   %[1]s get vulns replicaset/nginx --container nginx
 
   # Get vulnerability reports for a CronJob with the specified name in JSON output format
-  %[1]s get vuln cj/my-job -o json`, executable),
+  %[1]s get vuln cj/my-job -o json
+
+  # Get vulnerability reports for a Deployment in SARIF output format, e.g. to upload to GitHub Code Scanning
+  %[1]s get vulns deploy/nginx -o sarif > nginx.sarif
+
+  # Get vulnerability reports for a Deployment as a CycloneDX BOM with embedded vulnerabilities
+  %[1]s get vulns deploy/nginx -o cyclonedx > nginx.cdx.json
+
+  # Get vulnerability reports for a Deployment in JUnit XML output format, e.g. to render vulnerabilities in CI
+  %[1]s get vulns deploy/nginx -o junit > nginx.xml
+
+  # Get vulnerability reports for a Deployment in CSV output format with the specified columns
+  %[1]s get vulns deploy/nginx -o csv --columns container,cve,severity,installed,fixed > nginx.csv
+
+  # Get a summary of vulnerability reports for a Deployment in Markdown, e.g. to comment on a pull request
+  %[1]s get vulns deploy/nginx -o markdown > nginx.md
+
+  # Get vulnerability reports for a Deployment in the wide table output format
+  %[1]s get vulns deploy/nginx -o wide
+
+  # Get vulnerability reports for a Deployment in the table output format with the specified columns
+  %[1]s get vulns deploy/nginx --columns cve,severity,package,title
+
+  # Get vulnerability reports for a Deployment and exit with code 1 if there are CRITICAL vulnerabilities
+  %[1]s get vulns deploy/nginx --exit-code 1 --severity CRITICAL
+
+  # Stream summaries of vulnerability reports in all namespaces as they're created or updated, e.g. during a rollout
+  %[1]s get vulns --watch --all-namespaces`, executable),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
+			watch, err := cmd.Flags().GetBool(watchFlagName)
+			if err != nil {
+				return err
+			}
+			if watch {
+				if len(args) > 0 {
+					return fmt.Errorf("workload %s cannot be specified with the --%s flag", args[0], watchFlagName)
+				}
+				allNamespaces, err := cmd.Flags().GetBool(allNamespacesFlagName)
+				if err != nil {
+					return err
+				}
+				ns := ""
+				if !allNamespaces {
+					ns, _, err = cf.ToRawKubeConfigLoader().Namespace()
+					if err != nil {
+						return err
+					}
+				}
+				return watchVulnerabilityReports(ctx, cf, ns, out)
+			}
+
+			exitCodeOpts, err := getExitCodeOpts(cmd, vulnerabilitySeverities)
+			if err != nil {
+				return err
+			}
+
 			kubeConfig, err := cf.ToRESTConfig()
 			if err != nil {
 				return err
@@ -75,28 +135,11 @@ NAME is the name of a particular Kubernetes workload.
 				return nil
 			}
 
-			format := cmd.Flag("output").Value.String()
-			container := cmd.Flag("container").Value.String()
-
-			var printer printers.ResourcePrinter
-
-			switch format {
-			case "yaml", "json":
-				printer, err = genericclioptions.NewPrintFlags("").
-					WithTypeSetter(starboard.NewScheme()).
-					WithDefaultOutput(format).
-					ToPrinter()
-				if err != nil {
-					return err
-				}
-			case "":
-				printer = printers.NewTablePrinter(printers.PrintOptions{})
-				if err != nil {
-					return err
-				}
-			default:
-				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json", format)
+			printer, err := newVulnerabilityReportsPrinter(cmd)
+			if err != nil {
+				return err
 			}
+			container := cmd.Flag("container").Value.String()
 
 			list := &v1alpha1.VulnerabilityReportList{
 				Items: []v1alpha1.VulnerabilityReport{},
@@ -112,11 +155,81 @@ NAME is the name of a particular Kubernetes workload.
 				return fmt.Errorf("container %s is not valid for %s %s", container, strings.ToLower(string(workload.Kind)), workload.Name)
 			}
 
-			return printer.PrintObj(list, out)
+			err = printer.print(workload, list, out)
+			if err != nil {
+				return err
+			}
+			return exitCodeOpts.check(countVulnerabilities(list.Items), "vulnerabilities")
 		},
 	}
 
 	cmd.PersistentFlags().StringP("container", "c", "", "Get vulnerability report of this container")
+	cmd.Flags().BoolP(watchFlagName, "w", false,
+		"After listing summaries of reports, watch for reports created, updated, or deleted and print their summaries")
+	cmd.Flags().BoolP(allNamespacesFlagName, "A", false, "If true, watch reports in all namespaces. Requires the --watch flag")
+	registerColumnsOpts(cmd)
+	registerExitCodeOpts(cmd, vulnerabilitySeverities)
 
 	return cmd
 }
+
+func registerColumnsOpts(cmd *cobra.Command) {
+	cmd.Flags().String("columns", "", "Comma-separated list of columns in the table, wide, and csv output formats, any of "+
+		strings.Join(vulnerabilityreport.ColumnNames(), ",")+". Defaults to columns of the output format.")
+}
+
+// vulnerabilityReportsPrinter prints vulnerability reports in the output
+// format and with the columns specified by flags.
+type vulnerabilityReportsPrinter struct {
+	format  string
+	columns []vulnerabilityreport.Column
+	printer printers.ResourcePrinter
+}
+
+func newVulnerabilityReportsPrinter(cmd *cobra.Command) (p vulnerabilityReportsPrinter, err error) {
+	p.format = cmd.Flag("output").Value.String()
+	columns := cmd.Flag("columns").Value.String()
+	switch p.format {
+	case "yaml", "json":
+		p.printer, err = genericclioptions.NewPrintFlags("").
+			WithTypeSetter(starboard.NewScheme()).
+			WithDefaultOutput(p.format).
+			ToPrinter()
+	case "", "table":
+		p.format = "table"
+		p.columns, err = vulnerabilityreport.ParseColumns(columns, vulnerabilityreport.TableColumns)
+	case "wide":
+		p.format = "table"
+		p.columns, err = vulnerabilityreport.ParseColumns(columns, vulnerabilityreport.WideTableColumns)
+	case "csv":
+		p.columns, err = vulnerabilityreport.ParseColumns(columns, vulnerabilityreport.Columns)
+	case "sarif", "cyclonedx", "junit", "markdown":
+	default:
+		err = fmt.Errorf("invalid output format %q, allowed formats are: table,wide,yaml,json,sarif,cyclonedx,junit,csv,markdown", p.format)
+	}
+	return
+}
+
+func (p vulnerabilityReportsPrinter) print(workload kube.ObjectRef, list *v1alpha1.VulnerabilityReportList, out io.Writer) error {
+	switch p.format {
+	case "sarif":
+		return sarif.Write(sarif.FromVulnerabilityReports(list.Items), out)
+	case "cyclonedx":
+		converter := cyclonedx.NewConverter(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator())
+		return cyclonedx.Write(converter.FromVulnerabilityReports(workload, list.Items), out)
+	case "junit":
+		return junit.Write(junit.FromVulnerabilityReports(list.Items), out)
+	case "csv":
+		return vulnerabilityreport.WriteCSV(list.Items, p.columns, out)
+	case "markdown":
+		name := resourceName(workload)
+		if workload.Kind == "Image" {
+			name = workload.Name
+		}
+		return markdown.WriteVulnerabilityReports(name, list.Items, markdown.DefaultTop, out)
+	case "table":
+		return vulnerabilityreport.WriteTable(list.Items, p.columns, out)
+	default:
+		return p.printer.PrintObj(list, out)
+	}
+}
@@ -28,6 +28,7 @@ Kubernetes cluster:
    - "clusterconfigauditreports.aquasecurity.github.io"
    - "ciskubebenchreports.aquasecurity.github.io"
    - "kubehunterreports.aquasecurity.github.io"
+   - "imagesignaturereports.aquasecurity.github.io"
  - RBAC objects:
    - The "starboard" ClusterRole
    - The "starboard" ClusterRoleBinding
@@ -198,6 +198,14 @@ func (m *Installer) Install(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	imageSignatureReportsCRD, err := embedded.GetImageSignatureReportsCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &imageSignatureReportsCRD)
+	if err != nil {
+		return err
+	}
 
 	// TODO We should wait for CRD statuses and make sure that the names were accepted
 
@@ -421,6 +429,10 @@ func (m *Installer) Uninstall(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	err = m.deleteCRD(ctx, v1alpha1.ImageSignatureReportCRName)
+	if err != nil {
+		return err
+	}
 	err = m.cleanupRBAC(ctx)
 	if err != nil {
 		return err
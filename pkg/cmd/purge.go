@@ -0,0 +1,339 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	kindFlagName              = "kind"
+	olderThanFlagName         = "older-than"
+	selectorFlagName          = "selector"
+	scanJobsFlagName          = "scan-jobs"
+	scanJobsNamespaceFlagName = "scan-jobs-namespace"
+	dryRunFlagName            = "dry-run"
+)
+
+// purgeKind is a kind of reports deleted by the purge command.
+type purgeKind struct {
+	// name is the plural lower case name of the kind, as accepted by the
+	// --kind flag.
+	name string
+	// singular is the singular lower case name of the kind, which is also
+	// accepted by the --kind flag.
+	singular      string
+	clusterScoped bool
+	newList       func() client.ObjectList
+}
+
+var purgeKinds = []purgeKind{
+	{name: "vulnerabilityreports", singular: "vulnerabilityreport",
+		newList: func() client.ObjectList { return &v1alpha1.VulnerabilityReportList{} }},
+	{name: "configauditreports", singular: "configauditreport",
+		newList: func() client.ObjectList { return &v1alpha1.ConfigAuditReportList{} }},
+	{name: "imagesignaturereports", singular: "imagesignaturereport",
+		newList: func() client.ObjectList { return &v1alpha1.ImageSignatureReportList{} }},
+	{name: "scanfailurereports", singular: "scanfailurereport",
+		newList: func() client.ObjectList { return &v1alpha1.ScanFailureReportList{} }},
+	{name: "vulnerabilityhistories", singular: "vulnerabilityhistory",
+		newList: func() client.ObjectList { return &v1alpha1.VulnerabilityHistoryList{} }},
+	{name: "exposurereports", singular: "exposurereport",
+		newList: func() client.ObjectList { return &v1alpha1.ExposureReportList{} }},
+	{name: "clustervulnerabilityreports", singular: "clustervulnerabilityreport", clusterScoped: true,
+		newList: func() client.ObjectList { return &v1alpha1.ClusterVulnerabilityReportList{} }},
+	{name: "clusterconfigauditreports", singular: "clusterconfigauditreport", clusterScoped: true,
+		newList: func() client.ObjectList { return &v1alpha1.ClusterConfigAuditReportList{} }},
+	{name: "ciskubebenchreports", singular: "ciskubebenchreport", clusterScoped: true,
+		newList: func() client.ObjectList { return &v1alpha1.CISKubeBenchReportList{} }},
+	{name: "kubehunterreports", singular: "kubehunterreport", clusterScoped: true,
+		newList: func() client.ObjectList { return &v1alpha1.KubeHunterReportList{} }},
+	{name: "clusternetworkpolicyreports", singular: "clusternetworkpolicyreport", clusterScoped: true,
+		newList: func() client.ObjectList { return &v1alpha1.ClusterNetworkPolicyReportList{} }},
+}
+
+// scanJobsKind is the kind of scan jobs deleted with the --scan-jobs flag.
+var scanJobsKind = purgeKind{name: "jobs", singular: "job", newList: func() client.ObjectList { return &batchv1.JobList{} }}
+
+func purgeKindNames() []string {
+	var names []string
+	for _, kind := range purgeKinds {
+		names = append(names, kind.name)
+	}
+	return names
+}
+
+// purgeOpts are filters of objects deleted by the purge command.
+type purgeOpts struct {
+	kinds         []purgeKind
+	namespace     string
+	allNamespaces bool
+	olderThan     time.Duration
+	selector      labels.Selector
+	dryRun        bool
+}
+
+func NewPurgeCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Delete security reports and leftover scan jobs matching the specified filters",
+		Long: `Delete security reports and leftover scan jobs matching the specified filters
+
+By default, reports of all kinds in the current namespace are deleted. Cluster-scoped reports, such as
+ClusterConfigAuditReports, are deleted with the --all-namespaces flag or when their kind is specified with
+the --kind flag.
+
+The age of an object is the time elapsed since it was created.
+`,
+		Example: fmt.Sprintf(`  # Delete all reports in the current namespace
+  %[1]s purge
+
+  # List vulnerability reports in all namespaces older than 7 days without deleting them
+  %[1]s purge --kind vulnerabilityreports --all-namespaces --older-than 168h --dry-run
+
+  # Delete reports labeled with the specified cluster name in the specified namespace
+  %[1]s purge -n staging -l starboard.cluster.name=staging
+
+  # Delete reports in all namespaces and scan jobs left behind in the specified namespace, i.e. finished scan jobs
+  # and scan jobs running for longer than 10 minutes
+  %[1]s purge -A --scan-jobs --scan-jobs-namespace starboard-system --scan-job-timeout 10m`, buildInfo.Executable),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			opts, err := getPurgeOpts(cmd, cf)
+			if err != nil {
+				return err
+			}
+			scanJobs, err := cmd.Flags().GetBool(scanJobsFlagName)
+			if err != nil {
+				return err
+			}
+			scanJobsNamespace, err := cmd.Flags().GetString(scanJobsNamespaceFlagName)
+			if err != nil {
+				return err
+			}
+			scanJobTimeout, err := cmd.Flags().GetDuration(scanJobTimeoutFlagName)
+			if err != nil {
+				return err
+			}
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
+			purger := &purger{client: kubeClient, clock: ext.NewSystemClock(), opts: opts, out: out}
+			for _, kind := range opts.kinds {
+				err = purger.purge(ctx, kind, opts.selector, nil)
+				if err != nil {
+					return err
+				}
+			}
+			if !scanJobs {
+				return nil
+			}
+			purger.opts.namespace = scanJobsNamespace
+			purger.opts.allNamespaces = false
+			return purger.purgeScanJobs(ctx, scanJobTimeout)
+		},
+	}
+
+	cmd.Flags().StringSlice(kindFlagName, nil,
+		"Kinds of reports to delete, any of "+strings.Join(purgeKindNames(), ",")+". Defaults to all kinds.")
+	cmd.Flags().BoolP(allNamespacesFlagName, "A", false, "If true, delete reports in all namespaces")
+	cmd.Flags().Duration(olderThanFlagName, 0,
+		"Delete only reports older than the specified duration, e.g. 24h. A value of zero means reports of any age.")
+	cmd.Flags().StringP(selectorFlagName, "l", "", "Delete only reports matching the specified label selector")
+	cmd.Flags().Bool(scanJobsFlagName, false,
+		"If true, also delete scan jobs left behind, e.g. by interrupted scans, matching the --older-than and --selector flags")
+	cmd.Flags().String(scanJobsNamespaceFlagName, starboard.NamespaceName, "The namespace of scan jobs")
+	cmd.Flags().Duration(scanJobTimeoutFlagName, 5*time.Minute,
+		"Delete scan jobs that are complete or failed, or running for longer than the specified duration."+
+			" A value of zero means only complete or failed scan jobs.")
+	cmd.Flags().Bool(dryRunFlagName, false, "If true, only print objects that would be deleted")
+
+	return cmd
+}
+
+func getPurgeOpts(cmd *cobra.Command, cf *genericclioptions.ConfigFlags) (opts purgeOpts, err error) {
+	names, err := cmd.Flags().GetStringSlice(kindFlagName)
+	if err != nil {
+		return
+	}
+	opts.allNamespaces, err = cmd.Flags().GetBool(allNamespacesFlagName)
+	if err != nil {
+		return
+	}
+	opts.kinds, err = parsePurgeKinds(names, opts.allNamespaces)
+	if err != nil {
+		return
+	}
+	if !opts.allNamespaces {
+		opts.namespace, _, err = cf.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return
+		}
+	}
+	opts.olderThan, err = cmd.Flags().GetDuration(olderThanFlagName)
+	if err != nil {
+		return
+	}
+	if opts.olderThan < 0 {
+		err = fmt.Errorf("invalid duration %s, must not be negative", opts.olderThan)
+		return
+	}
+	selector, err := cmd.Flags().GetString(selectorFlagName)
+	if err != nil {
+		return
+	}
+	opts.selector, err = labels.Parse(selector)
+	if err != nil {
+		err = fmt.Errorf("invalid selector %q: %w", selector, err)
+		return
+	}
+	opts.dryRun, err = cmd.Flags().GetBool(dryRunFlagName)
+	return
+}
+
+// parsePurgeKinds returns kinds with the specified names, which are matched case
+// insensitively in the plural or singular form. If no names are specified, it
+// returns namespaced kinds, as well as cluster-scoped kinds if allNamespaces
+// is true.
+func parsePurgeKinds(names []string, allNamespaces bool) ([]purgeKind, error) {
+	if len(names) == 0 {
+		var kinds []purgeKind
+		for _, kind := range purgeKinds {
+			if !kind.clusterScoped || allNamespaces {
+				kinds = append(kinds, kind)
+			}
+		}
+		return kinds, nil
+	}
+	var kinds []purgeKind
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for _, kind := range purgeKinds {
+			if kind.name == name || kind.singular == name {
+				kinds = append(kinds, kind)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid kind %q, allowed kinds are: %s", name, strings.Join(purgeKindNames(), ","))
+		}
+	}
+	return kinds, nil
+}
+
+// purger deletes objects matching purgeOpts.
+type purger struct {
+	client client.Client
+	clock  ext.Clock
+	opts   purgeOpts
+	out    io.Writer
+}
+
+// purgeScanJobs deletes scan jobs matching the selector and options, which are
+// complete or failed, or have been running for longer than the specified
+// timeout. Scan jobs still watched by the operator or the CLI are kept.
+func (p *purger) purgeScanJobs(ctx context.Context, timeout time.Duration) error {
+	managedBy, err := labels.NewRequirement(starboard.LabelK8SAppManagedBy, selection.Equals, []string{starboard.AppStarboard})
+	if err != nil {
+		return err
+	}
+	return p.purge(ctx, scanJobsKind, p.opts.selector.Add(*managedBy), func(obj client.Object) bool {
+		job, ok := obj.(*batchv1.Job)
+		return ok && isLeftoverScanJob(job, timeout, p.clock.Now())
+	})
+}
+
+// isLeftoverScanJob returns true if the specified job is complete or failed,
+// or it has been running for longer than the specified timeout, unless the
+// timeout is zero.
+func isLeftoverScanJob(job *batchv1.Job, timeout time.Duration, now time.Time) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) &&
+			condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return timeout > 0 && now.Sub(job.CreationTimestamp.Time) > timeout
+}
+
+// purge deletes objects of the specified kind matching the specified selector,
+// options and filter, and prints each deleted object. The filter may be nil.
+// Kinds whose CRDs are not installed are skipped.
+func (p *purger) purge(ctx context.Context, kind purgeKind, selector labels.Selector, filter func(client.Object) bool) error {
+	listOpts := &client.ListOptions{LabelSelector: selector}
+	if !kind.clusterScoped {
+		listOpts.Namespace = p.opts.namespace
+	}
+	list := kind.newList()
+	err := p.client.List(ctx, list, listOpts)
+	if meta.IsNoMatchError(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("listing %s: %w", kind.name, err)
+	}
+	var objects []client.Object
+	err = meta.EachListItem(list, func(item runtime.Object) error {
+		obj, ok := item.(client.Object)
+		if !ok {
+			return fmt.Errorf("unexpected object: %T", item)
+		}
+		if p.opts.olderThan > 0 && p.clock.Now().Sub(obj.GetCreationTimestamp().Time) < p.opts.olderThan {
+			return nil
+		}
+		if filter != nil && !filter(obj) {
+			return nil
+		}
+		objects = append(objects, obj)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].GetNamespace()+"/"+objects[i].GetName() < objects[j].GetNamespace()+"/"+objects[j].GetName()
+	})
+
+	for _, obj := range objects {
+		ref := kind.singular + "/" + obj.GetName()
+		if obj.GetNamespace() != "" {
+			ref = obj.GetNamespace() + "/" + ref
+		}
+		if p.opts.dryRun {
+			_, _ = fmt.Fprintf(p.out, "%s deleted (dry run)\n", ref)
+			continue
+		}
+		err = p.client.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("deleting %s: %w", ref, err)
+		}
+		_, _ = fmt.Fprintf(p.out, "%s deleted\n", ref)
+	}
+	return nil
+}
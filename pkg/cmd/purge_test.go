@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParsePurgeKinds(t *testing.T) {
+	kindNames := func(kinds []purgeKind) []string {
+		var names []string
+		for _, kind := range kinds {
+			names = append(names, kind.name)
+		}
+		return names
+	}
+
+	t.Run("Should accept plural and singular names", func(t *testing.T) {
+		kinds, err := parsePurgeKinds([]string{"VulnerabilityReports", "vulnerabilityhistory", " vulnerabilityhistories "}, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"vulnerabilityreports", "vulnerabilityhistories", "vulnerabilityhistories"}, kindNames(kinds))
+	})
+
+	t.Run("Should reject unknown name", func(t *testing.T) {
+		_, err := parsePurgeKinds([]string{"vulnerabilityhistorie"}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid kind "vulnerabilityhistorie"`)
+	})
+
+	t.Run("Should return namespaced kinds by default", func(t *testing.T) {
+		kinds, err := parsePurgeKinds(nil, false)
+		require.NoError(t, err)
+		assert.Contains(t, kindNames(kinds), "vulnerabilityhistories")
+		assert.NotContains(t, kindNames(kinds), "clustervulnerabilityreports")
+	})
+}
+
+func TestPurger_Purge(t *testing.T) {
+	kinds, err := parsePurgeKinds([]string{"vulnerabilityhistory"}, false)
+	require.NoError(t, err)
+	kubeClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.VulnerabilityHistory{ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "pod-nginx"}},
+		&v1alpha1.VulnerabilityHistory{ObjectMeta: metav1.ObjectMeta{Namespace: "staging", Name: "pod-nginx"}},
+	).Build()
+
+	out := &bytes.Buffer{}
+	purger := &purger{
+		client: kubeClient,
+		clock:  ext.NewSystemClock(),
+		opts:   purgeOpts{namespace: "prod", selector: labels.Everything()},
+		out:    out,
+	}
+	require.NoError(t, purger.purge(context.TODO(), kinds[0], purger.opts.selector, nil))
+	assert.Equal(t, "prod/vulnerabilityhistory/pod-nginx deleted\n", out.String())
+
+	var histories v1alpha1.VulnerabilityHistoryList
+	require.NoError(t, kubeClient.List(context.TODO(), &histories))
+	require.Len(t, histories.Items, 1)
+	assert.Equal(t, "staging", histories.Items[0].Namespace)
+}
+
+func TestPurger_PurgeScanJobs(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	newJob := func(name string, age time.Duration, jobLabels map[string]string, conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         starboard.NamespaceName,
+				Name:              name,
+				Labels:            jobLabels,
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Status: batchv1.JobStatus{Conditions: conditions},
+		}
+	}
+	scanLabels := map[string]string{
+		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+		starboard.LabelResourceKind:    "Pod",
+	}
+	complete := batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}
+
+	kubeClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		newJob("scan-active", time.Minute, scanLabels),
+		newJob("scan-complete", time.Minute, scanLabels, complete),
+		newJob("scan-failed", time.Minute, scanLabels,
+			batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}),
+		newJob("scan-timed-out", time.Hour, scanLabels),
+		newJob("not-a-scan", time.Hour, map[string]string{starboard.LabelResourceKind: "Pod"}, complete),
+		newJob("scan-of-deployment", time.Hour, map[string]string{
+			starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+			starboard.LabelResourceKind:    "Deployment",
+		}, complete),
+	).Build()
+
+	out := &bytes.Buffer{}
+	purger := &purger{
+		client: kubeClient,
+		clock:  ext.NewFixedClock(now),
+		opts: purgeOpts{
+			namespace: starboard.NamespaceName,
+			selector:  labels.SelectorFromSet(labels.Set{starboard.LabelResourceKind: "Pod"}),
+		},
+		out: out,
+	}
+	err := purger.purgeScanJobs(context.TODO(), 5*time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, "starboard/job/scan-complete deleted\n"+
+		"starboard/job/scan-failed deleted\n"+
+		"starboard/job/scan-timed-out deleted\n", out.String())
+
+	var jobs batchv1.JobList
+	require.NoError(t, kubeClient.List(context.TODO(), &jobs, client.InNamespace(starboard.NamespaceName)))
+	var names []string
+	for _, job := range jobs.Items {
+		names = append(names, job.Name)
+	}
+	assert.ElementsMatch(t, []string{"not-a-scan", "scan-active", "scan-of-deployment"}, names)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kube"
@@ -13,8 +14,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const outputFileFlagName = "output"
+
 func NewReportCmd(info starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "report (NAME | TYPE/NAME)",
 		Short: "Generate an HTML security report for a specified Kubernetes object",
 		Long: fmt.Sprintf(`Generate an HTML security report for a specified Kubernetes object.
@@ -36,11 +39,15 @@ pitfalls. You can run "%[1]s scan vulnerabilityreports -h" and
 If the specified object is a Kubernetes node, the report will contain configuration
 checks based on CIS Kubernetes Benchmark guides.
 
+Reports are self-contained pages, which can be opened without network access.
+Vulnerabilities of a workload can be filtered by severity and searched, and
+printing a report, e.g. to a PDF file, prints vulnerabilities of all containers.
+
 TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
 NAME is the name of a particular Kubernetes workload.
 `, info.Executable),
 		Example: fmt.Sprintf(`  # Generate an HTML report for a deployment with the specified name and save it to a file.
-  %[1]s report deployment/nginx > nginx.deploy.html
+  %[1]s report deployment/nginx --output nginx.deploy.html
 
   # Generate an HTML report for a namespace with the specified name and save it to a file.
   %[1]s report namespace/kube-system > kube-system.ns.html
@@ -54,6 +61,9 @@ NAME is the name of a particular Kubernetes workload.
 				return err
 			}
 			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
 			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
 			if err != nil {
 				return err
@@ -66,6 +76,21 @@ NAME is the name of a particular Kubernetes workload.
 			if err != nil {
 				return err
 			}
+			filename, err := cmd.Flags().GetString(outputFileFlagName)
+			if err != nil {
+				return err
+			}
+			writer := out
+			if filename != "" {
+				file, err := os.Create(filename)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					_ = file.Close()
+				}()
+				writer = file
+			}
 			clock := ext.NewSystemClock()
 			switch workload.Kind {
 			case kube.KindDeployment,
@@ -77,16 +102,20 @@ NAME is the name of a particular Kubernetes workload.
 				kube.KindJob,
 				kube.KindPod:
 				reporter := report.NewWorkloadReporter(clock, kubeClient)
-				return reporter.Generate(workload, out)
+				return reporter.Generate(workload, writer)
 			case kube.KindNamespace:
 				reporter := report.NewNamespaceReporter(clock, kubeClient)
-				return reporter.Generate(workload, out)
+				return reporter.Generate(workload, writer)
 			case kube.KindNode:
 				reporter := report.NewNodeReporter(clock, kubeClient)
-				return reporter.Generate(workload, out)
+				return reporter.Generate(workload, writer)
 			default:
 				return fmt.Errorf("HTML report is not supported for %q", workload.Kind)
 			}
 		},
 	}
+
+	cmd.Flags().String(outputFileFlagName, "", "The file to write the report to. Defaults to stdout")
+
+	return cmd
 }
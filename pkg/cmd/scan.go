@@ -1,21 +1,26 @@
 package cmd
 
 import (
+	"io"
+
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
-func NewScanCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
+func NewScanCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, outWriter io.Writer) *cobra.Command {
 	scanCmd := &cobra.Command{
 		Use:     "scan",
 		Aliases: []string{"generate"},
 		Short:   "Manage security weakness identification tools",
 	}
-	scanCmd.AddCommand(NewScanConfigAuditReportsCmd(buildInfo, cf))
+	scanCmd.AddCommand(NewScanConfigAuditReportsCmd(buildInfo, cf, outWriter))
+	scanCmd.AddCommand(NewScanChartCmd(buildInfo, cf, outWriter))
+	scanCmd.AddCommand(NewScanImageCmd(buildInfo, cf, outWriter))
+	scanCmd.AddCommand(NewScanK8sCmd(buildInfo, cf, outWriter))
 	scanCmd.AddCommand(NewScanKubeBenchReportsCmd(cf))
 	scanCmd.AddCommand(NewScanKubeHunterReportsCmd(cf))
-	scanCmd.AddCommand(NewScanVulnerabilityReportsCmd(buildInfo, cf))
+	scanCmd.AddCommand(NewScanVulnerabilityReportsCmd(buildInfo, cf, outWriter))
 
 	return scanCmd
 }
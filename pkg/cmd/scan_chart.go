@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const (
+	valuesFlagName       = "values"
+	chartVersionFlagName = "version"
+	releaseNameFlagName  = "release-name"
+)
+
+func NewScanChartCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chart CHART",
+		Short: "Audit the configuration of a Helm chart and scan its images for vulnerabilities",
+		Long: `Render a Helm chart, audit the configuration of rendered resources, and scan images of rendered workloads for vulnerabilities
+
+CHART is a chart reference, a path to a packaged chart, a path to an unpacked chart directory, or a URL, as accepted by
+the 'helm template' command. The helm executable must be on the PATH.
+
+Reports are printed rather than stored, because rendered resources don't exist in the cluster.
+`,
+		Example: fmt.Sprintf(`  # Scan a chart in a repository added with the helm repo add command
+  %[1]s scan chart bitnami/nginx
+
+  # Scan the specified version of a chart with the specified values
+  %[1]s scan chart bitnami/nginx --version 9.5.0 --values values.yaml
+
+  # Scan a local chart directory without scanning images for vulnerabilities
+  %[1]s scan chart ./my-chart --skip-vulnerabilities`, buildInfo.Executable),
+		Args: cobra.ExactArgs(1),
+		RunE: ScanChart(buildInfo, cf, out),
+	}
+
+	cmd.Flags().StringSlice(valuesFlagName, nil, "Values files passed to helm template")
+	cmd.Flags().String(chartVersionFlagName, "", "The version of the chart. Defaults to the latest version")
+	cmd.Flags().String(releaseNameFlagName, "release", "The name of the release used to render the chart")
+	registerManifestScanOpts(cmd)
+	registerScannerOpts(cmd)
+
+	return cmd
+}
+
+func ScanChart(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return err
+		}
+		values, err := cmd.Flags().GetStringSlice(valuesFlagName)
+		if err != nil {
+			return err
+		}
+		version, err := cmd.Flags().GetString(chartVersionFlagName)
+		if err != nil {
+			return err
+		}
+		releaseName, err := cmd.Flags().GetString(releaseNameFlagName)
+		if err != nil {
+			return err
+		}
+		manifest, err := renderChart(args[0], version, releaseName, ns, values)
+		if err != nil {
+			return err
+		}
+		manifests, err := kube.ReadManifests(starboard.NewScheme(), bytes.NewReader(manifest))
+		if err != nil {
+			return fmt.Errorf("reading rendered chart: %w", err)
+		}
+		setDefaultNamespace(manifests, ns)
+
+		return scanManifestsAndImages(ctx, cmd, buildInfo, cf, manifests, ns, out)
+	}
+}
+
+// renderChart renders the specified chart with the helm template command.
+func renderChart(chart, version, releaseName, namespace string, values []string) ([]byte, error) {
+	args := []string{"template", releaseName, chart, "--namespace", namespace}
+	if version != "" {
+		args = append(args, "--version", version)
+	}
+	for _, file := range values {
+		args = append(args, "--values", file)
+	}
+	command := exec.Command("helm", args...)
+
+	var stderr bytes.Buffer
+	command.Stderr = &stderr
+	out, err := command.Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, errors.New("rendering chart: helm executable not found in PATH")
+	}
+	if err != nil {
+		_, _ = fmt.Fprint(os.Stderr, stderr.String())
+		return nil, fmt.Errorf("rendering chart: %w", err)
+	}
+	return out, nil
+}
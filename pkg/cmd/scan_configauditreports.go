@@ -2,48 +2,115 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/junit"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/markdown"
 	"github.com/aquasecurity/starboard/pkg/plugin"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	configAuditCmdShort = "Run a variety of checks to ensure that a given workload is configured using best practices"
+	filenameFlagName    = "filename"
 )
 
-func NewScanConfigAuditReportsCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
+func NewScanConfigAuditReportsCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "configauditreports",
-		Short: configAuditCmdShort,
-		Args:  cobra.MaximumNArgs(1),
-		RunE:  ScanConfigAuditReports(buildInfo, cf),
+		Use:     "configauditreports (NAME | TYPE/NAME | -f FILENAME)",
+		Aliases: []string{"configaudit"},
+		Short:   configAuditCmdShort,
+		Example: fmt.Sprintf(`  # Scan a deployment with the specified name
+  %[1]s scan configauditreports deploy/nginx
+
+  # Scan manifests in the specified directory before applying them and print failed checks
+  %[1]s scan configaudit -f manifests/
+
+  # Scan manifests read from stdin and print results in JUnit XML output format
+  helm template my-chart | %[1]s scan configaudit -f - -o junit
+
+  # Scan manifests and print a summary of failed checks in Markdown, e.g. to comment on a pull request
+  %[1]s scan configaudit -f manifests/ -o markdown
+
+  # Scan manifests and exit with code 1 if there are failed danger checks
+  %[1]s scan configaudit -f deployment.yaml --exit-code 1 --severity danger
+
+  # Wait for the Starboard operator to audit a deployment after it's been updated
+  %[1]s scan configauditreports deploy/nginx --wait`, buildInfo.Executable),
+		Args: cobra.MaximumNArgs(1),
+		RunE: ScanConfigAuditReports(buildInfo, cf, out),
 	}
 
+	cmd.Flags().StringSliceP(filenameFlagName, "f", nil,
+		"Files or directories with manifests to audit instead of objects in the cluster, or - to read manifests from stdin."+
+			" Reports of manifests are printed rather than stored.")
+	cmd.Flags().StringP("output", "o", "", "Output format of reports of manifests. One of table|junit|markdown")
 	registerScannerOpts(cmd)
+	registerWaitOpts(cmd)
+	registerExitCodeOpts(cmd, configAuditSeverities)
 
 	return cmd
 }
 
-func ScanConfigAuditReports(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) func(cmd *cobra.Command, args []string) error {
+func ScanConfigAuditReports(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 		ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
 		if err != nil {
 			return err
 		}
-		mapper, err := cf.ToRESTMapper()
+		filenames, err := cmd.Flags().GetStringSlice(filenameFlagName)
 		if err != nil {
 			return err
 		}
-		workload, _, err := WorkloadFromArgs(mapper, ns, args)
+		format := cmd.Flag("output").Value.String()
+		switch format {
+		case "", "table", "junit", "markdown":
+		default:
+			return fmt.Errorf("invalid output format %q, allowed formats are: table,junit,markdown", format)
+		}
+		waitOpts, err := getWaitOpts(cmd)
 		if err != nil {
 			return err
 		}
+		var manifests []client.Object
+		var workload kube.ObjectRef
+		if len(filenames) > 0 {
+			if len(args) > 0 {
+				return fmt.Errorf("workload %s cannot be specified with the --%s flag", args[0], filenameFlagName)
+			}
+			if waitOpts.wait {
+				return fmt.Errorf("the --%s flag cannot be specified with the --%s flag", waitFlagName, filenameFlagName)
+			}
+			manifests, err = readManifests(cmd.InOrStdin(), filenames)
+			if err != nil {
+				return err
+			}
+			setDefaultNamespace(manifests, ns)
+		} else {
+			mapper, err := cf.ToRESTMapper()
+			if err != nil {
+				return err
+			}
+			workload, _, err = WorkloadFromArgs(mapper, ns, args)
+			if err != nil {
+				return err
+			}
+		}
 		kubeConfig, err := cf.ToRESTConfig()
 		if err != nil {
 			return err
@@ -54,10 +121,24 @@ func ScanConfigAuditReports(buildInfo starboard.BuildInfo, cf *genericclioptions
 		}
 		scheme := starboard.NewScheme()
 		kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			return err
+		}
 		opts, err := getScannerOpts(cmd)
 		if err != nil {
 			return err
 		}
+		exitCodeOpts, err := getExitCodeOpts(cmd, configAuditSeverities)
+		if err != nil {
+			return err
+		}
+		if waitOpts.wait {
+			data, err := waitForConfigAuditReport(ctx, kubeClient, workload, waitOpts)
+			if err != nil {
+				return err
+			}
+			return exitCodeOpts.check(countFailedChecks(data), "failed checks")
+		}
 		config, err := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName).Read(ctx)
 		if err != nil {
 			return err
@@ -73,11 +154,192 @@ func ScanConfigAuditReports(buildInfo starboard.BuildInfo, cf *genericclioptions
 			return err
 		}
 		scanner := configauditreport.NewScanner(kubeClientset, kubeClient, plugin, pluginContext, config, opts)
+		if len(filenames) > 0 {
+			return scanManifests(ctx, scanner, manifests, format, exitCodeOpts, out)
+		}
 		reportBuilder, err := scanner.Scan(ctx, workload)
 		if err != nil {
 			return err
 		}
 		writer := configauditreport.NewReadWriter(kubeClient)
-		return reportBuilder.Write(ctx, writer)
+		err = reportBuilder.Write(ctx, writer)
+		if err != nil {
+			return err
+		}
+		data, err := getConfigAuditReportData(reportBuilder, string(workload.Kind))
+		if err != nil {
+			return err
+		}
+		return exitCodeOpts.check(countFailedChecks(data), "failed checks")
+	}
+}
+
+func getConfigAuditReportData(reportBuilder *configauditreport.ReportBuilder, kind string) (v1alpha1.ConfigAuditReportData, error) {
+	if kube.IsClusterScopedKind(kind) {
+		report, err := reportBuilder.GetClusterReport()
+		if err != nil {
+			return v1alpha1.ConfigAuditReportData{}, err
+		}
+		return report.Report, nil
+	}
+	report, err := reportBuilder.GetReport()
+	if err != nil {
+		return v1alpha1.ConfigAuditReportData{}, err
+	}
+	return report.Report, nil
+}
+
+// readManifests reads objects from the specified files, directories, which
+// are walked recursively for YAML and JSON files, or stdin.
+func readManifests(stdin io.Reader, filenames []string) ([]client.Object, error) {
+	scheme := starboard.NewScheme()
+	var objects []client.Object
+	read := func(name string, r io.Reader) error {
+		objs, err := kube.ReadManifests(scheme, r)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", name, err)
+		}
+		objects = append(objects, objs...)
+		return nil
+	}
+	for _, filename := range filenames {
+		if filename == "-" {
+			if err := read("stdin", stdin); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		err := filepath.WalkDir(filename, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".yaml", ".yml", ".json":
+			default:
+				if path != filename {
+					return nil
+				}
+			}
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = file.Close()
+			}()
+			return read(path, file)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+// setDefaultNamespace puts objects without namespaces in the specified
+// namespace unless they're cluster-scoped.
+func setDefaultNamespace(objects []client.Object, namespace string) {
+	for _, obj := range objects {
+		if obj.GetNamespace() == "" && !kube.IsClusterScopedKind(obj.GetObjectKind().GroupVersionKind().Kind) {
+			obj.SetNamespace(namespace)
+		}
+	}
+}
+
+func scanManifests(ctx context.Context, scanner *configauditreport.Scanner, manifests []client.Object, format string, exitCodeOpts exitCodeOpts, out io.Writer) error {
+	counts := make(map[string]int)
+	suites := junit.TestSuites{Name: "configuration audit"}
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	if format != "junit" && format != "markdown" {
+		_, _ = fmt.Fprintln(w, "RESOURCE\tCHECK\tSEVERITY\tMESSAGE")
+	}
+	for _, obj := range manifests {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		resource := strings.ToLower(kind) + "/" + obj.GetName()
+		if obj.GetNamespace() != "" {
+			resource = obj.GetNamespace() + "/" + resource
+		}
+		if !scanner.SupportsKind(kube.Kind(kind)) {
+			klog.V(3).Infof("Skipping manifest of unsupported kind: %s", resource)
+			continue
+		}
+		reportBuilder, err := scanner.ScanManifest(ctx, obj)
+		if err != nil {
+			return fmt.Errorf("scanning %s: %w", resource, err)
+		}
+		data, err := getConfigAuditReportData(reportBuilder, kind)
+		if err != nil {
+			return err
+		}
+		for severity, count := range countFailedChecks(data) {
+			counts[severity] += count
+		}
+		if format == "junit" {
+			s := junit.FromConfigAuditReport(data, resource)
+			suites.Suites = append(suites.Suites, s.Suites...)
+			suites.Tests += s.Tests
+			suites.Failures += s.Failures
+			continue
+		}
+		if format == "markdown" {
+			if err := markdown.WriteConfigAuditReport(resource, data, markdown.DefaultTop, out); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(out)
+			continue
+		}
+		for _, check := range data.Checks {
+			if check.Success {
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", resource, check.ID, check.Severity, check.Message)
+		}
+	}
+	var err error
+	if format == "junit" {
+		err = junit.Write(suites, out)
+	} else {
+		err = w.Flush()
+	}
+	if err != nil {
+		return err
+	}
+	return exitCodeOpts.check(counts, "failed checks")
+}
+
+// waitForConfigAuditReport waits for the Starboard operator to write the
+// config audit report of the current spec of the specified workload.
+func waitForConfigAuditReport(ctx context.Context, kubeClient client.Client, workload kube.ObjectRef, opts waitOpts) (v1alpha1.ConfigAuditReportData, error) {
+	reader := configauditreport.NewReadWriter(kubeClient)
+	reportKind := v1alpha1.ConfigAuditReportKind
+	if kube.IsClusterScopedKind(string(workload.Kind)) {
+		reportKind = v1alpha1.ClusterConfigAuditReportKind
 	}
+	var data v1alpha1.ConfigAuditReportData
+	err := waitForReport(ctx, kubeClient, workload, reportKind, opts,
+		func(ctx context.Context, owner client.Object, ownerRef kube.ObjectRef) (bool, error) {
+			hash, err := kube.ComputeSpecHash(owner)
+			if err != nil {
+				return false, err
+			}
+			var labels map[string]string
+			if reportKind == v1alpha1.ClusterConfigAuditReportKind {
+				report, err := reader.FindClusterReportByOwner(ctx, ownerRef)
+				if err != nil || report == nil {
+					return false, err
+				}
+				labels, data = report.Labels, report.Report
+			} else {
+				report, err := reader.FindReportByOwner(ctx, ownerRef)
+				if err != nil || report == nil {
+					return false, err
+				}
+				labels, data = report.Labels, report.Report
+			}
+			return labels[starboard.LabelResourceSpecHash] == hash, nil
+		})
+	return data, err
 }
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const imagePullSecretFlagName = "image-pull-secret"
+
+func NewScanImageCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "image REF",
+		Short: "Run static vulnerability scanner for a container image",
+		Long: `Scan a container image, which doesn't have to be deployed in the cluster, for vulnerabilities
+
+REF is a reference of the image, e.g. 'nginx:1.16' or 'quay.io/org/app@sha256:...'.
+
+The image is scanned by a scan job in the same way as images of workloads, and the
+report is printed rather than stored, because there's no workload to own it.
+`,
+		Example: fmt.Sprintf(`  # Scan an image
+  %[1]s scan image nginx:1.16
+
+  # Scan an image in a private registry with credentials from the specified image pull Secret
+  %[1]s scan image registry.example.com/app:1.0 -n staging --image-pull-secret regcred
+
+  # Scan an image and print the report in JSON output format
+  %[1]s scan image nginx:1.16 -o json
+
+  # Scan an image and exit with code 1 if there are HIGH or CRITICAL vulnerabilities
+  %[1]s scan image nginx:1.16 --exit-code 1 --severity HIGH`, buildInfo.Executable),
+		RunE: ScanImage(buildInfo, cf, out),
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Output format. One of table|wide|yaml|json|sarif|cyclonedx|junit|csv|markdown")
+	cmd.Flags().StringSlice(imagePullSecretFlagName, nil,
+		"Names of image pull Secrets in the namespace used to pull the image from a private registry")
+	registerColumnsOpts(cmd)
+	registerScannerOpts(cmd)
+	registerExitCodeOpts(cmd, vulnerabilitySeverities)
+
+	return cmd
+}
+
+func ScanImage(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		if len(args) < 1 || args[0] == "" {
+			return errors.New("required image reference not specified")
+		}
+		image := args[0]
+		printer, err := newVulnerabilityReportsPrinter(cmd)
+		if err != nil {
+			return err
+		}
+		imagePullSecrets, err := cmd.Flags().GetStringSlice(imagePullSecretFlagName)
+		if err != nil {
+			return err
+		}
+		ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return err
+		}
+		kubeConfig, err := cf.ToRESTConfig()
+		if err != nil {
+			return err
+		}
+		kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+		if err != nil {
+			return err
+		}
+		scheme := starboard.NewScheme()
+		kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			return err
+		}
+		config, err := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName).Read(ctx)
+		if err != nil {
+			return err
+		}
+		opts, err := getScannerOpts(cmd)
+		if err != nil {
+			return err
+		}
+		exitCodeOpts, err := getExitCodeOpts(cmd, vulnerabilitySeverities)
+		if err != nil {
+			return err
+		}
+		plugin, pluginContext, err := plugin.NewResolver().
+			WithBuildInfo(buildInfo).
+			WithNamespace(starboard.NamespaceName).
+			WithServiceAccountName(starboard.ServiceAccountName).
+			WithConfig(config).
+			WithClient(kubeClient).
+			GetVulnerabilityPlugin()
+		if err != nil {
+			return err
+		}
+		scanner := vulnerabilityreport.NewScanner(kubeClientset, kubeClient, plugin, pluginContext, config, opts)
+		reports, err := scanner.ScanImage(ctx, image, ns, imagePullSecrets)
+		if err != nil {
+			return err
+		}
+		err = printer.print(kube.ObjectRef{Kind: "Image", Name: image}, &v1alpha1.VulnerabilityReportList{Items: reports}, out)
+		if err != nil {
+			return err
+		}
+		return exitCodeOpts.check(countVulnerabilities(reports), "vulnerabilities")
+	}
+}
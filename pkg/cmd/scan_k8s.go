@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	kustomizeFlagName           = "kustomize"
+	skipVulnerabilitiesFlagName = "skip-vulnerabilities"
+)
+
+// manifestVulnerabilityColumns are columns of vulnerabilities in images of
+// manifests printed by default.
+var manifestVulnerabilityColumns = []vulnerabilityreport.Column{
+	vulnerabilityreport.ColumnImage,
+	vulnerabilityreport.ColumnCVE,
+	vulnerabilityreport.ColumnSeverity,
+	vulnerabilityreport.ColumnPackage,
+	vulnerabilityreport.ColumnInstalled,
+	vulnerabilityreport.ColumnFixed,
+}
+
+func NewScanK8sCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "k8s (-k DIRECTORY | -f FILENAME)",
+		Short: "Audit the configuration of manifests and scan their images for vulnerabilities",
+		Long: `Audit the configuration of manifests, or of resources built from a kustomization, and scan images of workloads for vulnerabilities
+
+Reports are printed rather than stored, because scanned resources don't have to exist in the cluster.
+`,
+		Example: fmt.Sprintf(`  # Scan resources built from the kustomization in the specified directory
+  %[1]s scan k8s --kustomize overlays/production
+
+  # Scan manifests in the specified directory
+  %[1]s scan k8s -f manifests/
+
+  # Scan a kustomization without scanning images for vulnerabilities
+  %[1]s scan k8s -k overlays/staging --skip-vulnerabilities`, buildInfo.Executable),
+		Args: cobra.NoArgs,
+		RunE: ScanK8s(buildInfo, cf, out),
+	}
+
+	cmd.Flags().StringSliceP(kustomizeFlagName, "k", nil, "Directories with kustomizations to build and scan")
+	cmd.Flags().StringSliceP(filenameFlagName, "f", nil,
+		"Files or directories with manifests to scan, or - to read manifests from stdin")
+	registerManifestScanOpts(cmd)
+	registerScannerOpts(cmd)
+
+	return cmd
+}
+
+func ScanK8s(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return err
+		}
+		kustomizations, err := cmd.Flags().GetStringSlice(kustomizeFlagName)
+		if err != nil {
+			return err
+		}
+		filenames, err := cmd.Flags().GetStringSlice(filenameFlagName)
+		if err != nil {
+			return err
+		}
+		if len(kustomizations) == 0 && len(filenames) == 0 {
+			return fmt.Errorf("required --%s or --%s flag not specified", kustomizeFlagName, filenameFlagName)
+		}
+		manifests, err := readManifests(cmd.InOrStdin(), filenames)
+		if err != nil {
+			return err
+		}
+		for _, dir := range kustomizations {
+			manifest, err := kube.BuildKustomization(dir)
+			if err != nil {
+				return err
+			}
+			objects, err := kube.ReadManifests(starboard.NewScheme(), bytes.NewReader(manifest))
+			if err != nil {
+				return fmt.Errorf("reading kustomization %s: %w", dir, err)
+			}
+			manifests = append(manifests, objects...)
+		}
+		setDefaultNamespace(manifests, ns)
+
+		return scanManifestsAndImages(ctx, cmd, buildInfo, cf, manifests, ns, out)
+	}
+}
+
+func registerManifestScanOpts(cmd *cobra.Command) {
+	cmd.Flags().Bool(skipVulnerabilitiesFlagName, false, "If true, don't scan images of workloads for vulnerabilities")
+	cmd.Flags().String("columns", "", "Comma-separated list of columns of vulnerabilities, any of "+
+		strings.Join(vulnerabilityreport.ColumnNames(), ",")+". Defaults to image,cve,severity,package,installed,fixed.")
+}
+
+// scanManifestsAndImages audits the configuration of the specified manifests
+// and scans images of workloads among them for vulnerabilities, as requested
+// by flags registered with registerManifestScanOpts, and prints failed checks
+// followed by vulnerabilities.
+func scanManifestsAndImages(ctx context.Context, cmd *cobra.Command, buildInfo starboard.BuildInfo,
+	cf *genericclioptions.ConfigFlags, manifests []client.Object, namespace string, out io.Writer) error {
+	skipVulnerabilities, err := cmd.Flags().GetBool(skipVulnerabilitiesFlagName)
+	if err != nil {
+		return err
+	}
+	columns, err := vulnerabilityreport.ParseColumns(cmd.Flag("columns").Value.String(), manifestVulnerabilityColumns)
+	if err != nil {
+		return err
+	}
+	opts, err := getScannerOpts(cmd)
+	if err != nil {
+		return err
+	}
+	if len(manifests) == 0 {
+		return errors.New("no manifests found")
+	}
+
+	kubeConfig, err := cf.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+	kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+	if err != nil {
+		return err
+	}
+	config, err := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName).Read(ctx)
+	if err != nil {
+		return err
+	}
+	resolver := plugin.NewResolver().
+		WithBuildInfo(buildInfo).
+		WithNamespace(starboard.NamespaceName).
+		WithServiceAccountName(starboard.ServiceAccountName).
+		WithConfig(config).
+		WithClient(kubeClient)
+
+	configAuditPlugin, configAuditPluginContext, err := resolver.GetConfigAuditPlugin()
+	if err != nil {
+		return err
+	}
+	configAuditScanner := configauditreport.NewScanner(kubeClientset, kubeClient, configAuditPlugin,
+		configAuditPluginContext, config, opts)
+	err = scanManifests(ctx, configAuditScanner, manifests, "table", exitCodeOpts{}, out)
+	if err != nil {
+		return err
+	}
+	if skipVulnerabilities {
+		return nil
+	}
+
+	vulnerabilityPlugin, vulnerabilityPluginContext, err := resolver.GetVulnerabilityPlugin()
+	if err != nil {
+		return err
+	}
+	vulnerabilityScanner := vulnerabilityreport.NewScanner(kubeClientset, kubeClient, vulnerabilityPlugin,
+		vulnerabilityPluginContext, config, opts)
+	var reports []v1alpha1.VulnerabilityReport
+	for _, image := range imagesOf(manifests) {
+		imageReports, err := vulnerabilityScanner.ScanImage(ctx, image, namespace, nil)
+		if err != nil {
+			return fmt.Errorf("scanning image %s: %w", image, err)
+		}
+		reports = append(reports, imageReports...)
+	}
+	_, _ = fmt.Fprintln(out)
+	return vulnerabilityreport.WriteTable(reports, columns, out)
+}
+
+// imagesOf returns sorted unique images of containers of the specified
+// workloads. Objects that are not workloads are skipped.
+func imagesOf(objects []client.Object) []string {
+	unique := make(map[string]bool)
+	for _, obj := range objects {
+		spec, err := kube.GetPodSpec(obj)
+		if err != nil {
+			continue
+		}
+		for _, image := range kube.GetContainerImagesFromPodSpec(spec) {
+			unique[image] = true
+		}
+	}
+	var images []string
+	for image := range unique {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return images
+}
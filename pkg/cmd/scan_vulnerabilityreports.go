@@ -3,7 +3,10 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/plugin"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
@@ -22,10 +25,10 @@ NAME is the name of a particular Kubernetes workload.
 `
 )
 
-func NewScanVulnerabilityReportsCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
+func NewScanVulnerabilityReportsCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Aliases: []string{"vulns", "vuln"},
-		Use:     "vulnerabilityreports (NAME | TYPE/NAME)",
+		Use:     "vulnerabilityreports (NAME | TYPE/NAME | --all-namespaces)",
 		Short:   vulnerabilitiesCmdShort,
 		Long:    vulnerabilitiesCmdLong,
 		Example: fmt.Sprintf(`  # Scan a pod with the specified name
@@ -53,30 +56,69 @@ func NewScanVulnerabilityReportsCmd(buildInfo starboard.BuildInfo, cf *genericcl
   %[1]s scan vulnerabilityreports job/my-job
 
   # Scan a cronjob with the specified name and the specified scan job timeout
-  %[1]s scan vulnerabilityreports cj/my-cronjob --scan-job-timeout 2m`, buildInfo.Executable),
-		RunE: ScanVulnerabilityReports(buildInfo, cf),
+  %[1]s scan vulnerabilityreports cj/my-cronjob --scan-job-timeout 2m
+
+  # Scan a deployment and exit with code 1 if there are HIGH or CRITICAL vulnerabilities
+  %[1]s scan vulnerabilityreports deploy/nginx --exit-code 1 --severity HIGH
+
+  # Scan all workloads in all namespaces with 10 scan jobs running in parallel
+  %[1]s scan vulnerabilityreports --all-namespaces --workers 10
+
+  # Wait up to 10 minutes for the Starboard operator to scan a deployment after it's been updated
+  %[1]s scan vulnerabilityreports deploy/nginx --wait --timeout 10m`, buildInfo.Executable),
+		RunE: ScanVulnerabilityReports(buildInfo, cf, out),
 	}
 
+	cmd.Flags().BoolP(allNamespacesFlagName, "A", false,
+		"If true, scan all workloads in all namespaces and print a summary instead of scanning the specified workload")
+	cmd.Flags().Int(workersFlagName, 5, "The number of workloads scanned in parallel with the --all-namespaces flag")
 	registerScannerOpts(cmd)
+	registerWaitOpts(cmd)
+	registerExitCodeOpts(cmd, vulnerabilitySeverities)
 
 	return cmd
 }
 
-func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) func(cmd *cobra.Command, args []string) error {
+func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+		allNamespaces, err := cmd.Flags().GetBool(allNamespacesFlagName)
 		if err != nil {
 			return err
 		}
-		mapper, err := cf.ToRESTMapper()
+		workers, err := cmd.Flags().GetInt(workersFlagName)
 		if err != nil {
 			return err
 		}
-		workload, _, err := WorkloadFromArgs(mapper, ns, args)
+		if workers < 1 {
+			return fmt.Errorf("invalid number of workers %d, must be at least 1", workers)
+		}
+		waitOpts, err := getWaitOpts(cmd)
 		if err != nil {
 			return err
 		}
+		if waitOpts.wait && allNamespaces {
+			return fmt.Errorf("the --%s flag cannot be specified with the --%s flag", waitFlagName, allNamespacesFlagName)
+		}
+		var workload kube.ObjectRef
+		if allNamespaces {
+			if len(args) > 0 {
+				return fmt.Errorf("workload %s cannot be specified with the --%s flag", args[0], allNamespacesFlagName)
+			}
+		} else {
+			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return err
+			}
+			mapper, err := cf.ToRESTMapper()
+			if err != nil {
+				return err
+			}
+			workload, _, err = WorkloadFromArgs(mapper, ns, args)
+			if err != nil {
+				return err
+			}
+		}
 		kubeConfig, err := cf.ToRESTConfig()
 		if err != nil {
 			return err
@@ -90,6 +132,17 @@ func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptio
 		if err != nil {
 			return err
 		}
+		exitCodeOpts, err := getExitCodeOpts(cmd, vulnerabilitySeverities)
+		if err != nil {
+			return err
+		}
+		if waitOpts.wait {
+			reports, err := waitForVulnerabilityReports(ctx, kubeClient, workload, waitOpts)
+			if err != nil {
+				return err
+			}
+			return exitCodeOpts.check(countVulnerabilities(reports), "vulnerabilities")
+		}
 		config, err := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName).Read(ctx)
 		if err != nil {
 			return err
@@ -109,11 +162,52 @@ func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptio
 			return err
 		}
 		scanner := vulnerabilityreport.NewScanner(kubeClientset, kubeClient, plugin, pluginContext, config, opts)
+		writer := vulnerabilityreport.NewReadWriter(kubeClient)
+		if allNamespaces {
+			workloads, err := (&kube.ObjectResolver{Client: kubeClient}).ListWorkloads(ctx, "")
+			if err != nil {
+				return err
+			}
+			counts, err := scanWorkloads(ctx, scanner, writer, workloads, workers, cmd.ErrOrStderr(), out)
+			if err != nil {
+				return err
+			}
+			return exitCodeOpts.check(counts, "vulnerabilities")
+		}
 		reports, err := scanner.Scan(ctx, workload)
 		if err != nil {
 			return err
 		}
-		writer := vulnerabilityreport.NewReadWriter(kubeClient)
-		return writer.Write(ctx, reports)
+		err = writer.Write(ctx, reports)
+		if err != nil {
+			return err
+		}
+		return exitCodeOpts.check(countVulnerabilities(reports), "vulnerabilities")
 	}
 }
+
+// waitForVulnerabilityReports waits for the Starboard operator to write
+// vulnerability reports of the current pod spec of the specified workload.
+func waitForVulnerabilityReports(ctx context.Context, kubeClient client.Client, workload kube.ObjectRef, opts waitOpts) ([]v1alpha1.VulnerabilityReport, error) {
+	reader := vulnerabilityreport.NewReadWriter(kubeClient)
+	var reports []v1alpha1.VulnerabilityReport
+	err := waitForReport(ctx, kubeClient, workload, v1alpha1.VulnerabilityReportKind, opts,
+		func(ctx context.Context, owner client.Object, ownerRef kube.ObjectRef) (bool, error) {
+			spec, err := kube.GetPodSpec(owner)
+			if err != nil {
+				return false, err
+			}
+			hash := kube.ComputeHash(spec)
+			reports, err = reader.FindByOwner(ctx, ownerRef)
+			if err != nil {
+				return false, err
+			}
+			for _, report := range reports {
+				if report.Labels[starboard.LabelResourceSpecHash] != hash {
+					return false, nil
+				}
+			}
+			return len(reports) > 0, nil
+		})
+	return reports, err
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+)
+
+const (
+	workersFlagName  = "workers"
+	progressBarWidth = 20
+)
+
+// workloadScanResult is the result of scanning a workload with scanWorkloads.
+type workloadScanResult struct {
+	workload kube.ObjectRef
+	counts   map[string]int
+	err      error
+}
+
+// skipped checks whether the workload was skipped rather than failed, because
+// there is nothing to scan, e.g. a Deployment scaled down to zero replicas.
+func (r workloadScanResult) skipped() bool {
+	return errors.Is(r.err, kube.ErrNoRunningPods) || errors.Is(r.err, kube.ErrReplicaSetNotFound)
+}
+
+// scanWorkloads scans the specified workloads with the specified number of
+// workers and writes their reports. A progress bar and the status of each
+// scanned workload are printed to progress, and the summary of all scans is
+// printed to out. It returns counts of vulnerabilities by severity found in
+// all workloads, and an error if any workload failed to be scanned.
+func scanWorkloads(ctx context.Context, scanner *vulnerabilityreport.Scanner, writer vulnerabilityreport.Writer,
+	workloads []kube.ObjectRef, workers int, progress, out io.Writer) (map[string]int, error) {
+	start := time.Now()
+	queue := make(chan kube.ObjectRef)
+	results := make(chan workloadScanResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for workload := range queue {
+				results <- scanWorkload(ctx, scanner, writer, workload)
+			}
+		}()
+	}
+	go func() {
+		for _, workload := range workloads {
+			queue <- workload
+		}
+		close(queue)
+		wg.Wait()
+		close(results)
+	}()
+
+	counts := make(map[string]int)
+	var succeeded, skipped int
+	var failed []workloadScanResult
+	done := 0
+	for result := range results {
+		done++
+		status := "OK"
+		detail := formatCounts(result.counts)
+		switch {
+		case result.skipped():
+			skipped++
+			status = "SKIPPED"
+			detail = result.err.Error()
+		case result.err != nil:
+			failed = append(failed, result)
+			status = "FAILED"
+			detail = result.err.Error()
+		default:
+			succeeded++
+			for severity, count := range result.counts {
+				counts[severity] += count
+			}
+		}
+		_, _ = fmt.Fprintf(progress, "%s %*d/%d  %-7s  %s: %s\n", progressBar(done, len(workloads)),
+			len(fmt.Sprint(len(workloads))), done, len(workloads), status, workloadName(result.workload), detail)
+	}
+
+	_, _ = fmt.Fprintf(out, "Scanned %d workloads in %s: %d succeeded, %d skipped, %d failed\n",
+		len(workloads), time.Since(start).Round(time.Second), succeeded, skipped, len(failed))
+	_, _ = fmt.Fprintf(out, "Vulnerabilities: %s\n", formatCounts(counts))
+	if len(failed) == 0 {
+		return counts, nil
+	}
+	_, _ = fmt.Fprintln(out, "Failed workloads:")
+	for _, result := range failed {
+		_, _ = fmt.Fprintf(out, "  %s: %v\n", workloadName(result.workload), result.err)
+	}
+	return counts, fmt.Errorf("failed to scan %d of %d workloads", len(failed), len(workloads))
+}
+
+func scanWorkload(ctx context.Context, scanner *vulnerabilityreport.Scanner, writer vulnerabilityreport.Writer, workload kube.ObjectRef) workloadScanResult {
+	reports, err := scanner.Scan(ctx, workload)
+	if err != nil {
+		return workloadScanResult{workload: workload, err: err}
+	}
+	err = writer.Write(ctx, reports)
+	if err != nil {
+		return workloadScanResult{workload: workload, err: err}
+	}
+	return workloadScanResult{workload: workload, counts: countVulnerabilities(reports)}
+}
+
+// progressBar returns the progress bar of the specified number of done items,
+// e.g. [=========>          ].
+func progressBar(done, total int) string {
+	filled := progressBarWidth
+	if total > 0 {
+		filled = done * progressBarWidth / total
+	}
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+	return "[" + bar + "]"
+}
+
+func formatCounts(counts map[string]int) string {
+	var parts []string
+	for _, severity := range []v1alpha1.Severity{
+		v1alpha1.SeverityCritical,
+		v1alpha1.SeverityHigh,
+		v1alpha1.SeverityMedium,
+		v1alpha1.SeverityLow,
+		v1alpha1.SeverityUnknown,
+	} {
+		parts = append(parts, fmt.Sprintf("%s %d", severity, counts[string(severity)]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func workloadName(workload kube.ObjectRef) string {
+	return workload.Namespace + "/" + strings.ToLower(string(workload.Kind)) + "/" + workload.Name
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/posture"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const topFlagName = "top"
+
+func NewSummaryCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "Print an overview of the security posture of the cluster",
+		Long: `Print an overview of the security posture of the cluster based on reports in all namespaces
+
+The overview shows the number of workloads with and without vulnerability reports, counts of vulnerabilities
+and failed configuration checks, namespaces with most critical and high vulnerabilities, CIS Kubernetes Benchmark
+controls failed on most nodes, and scanners that generated vulnerability reports with ages of scans.
+`,
+		Example: fmt.Sprintf(`  # Print an overview of the cluster
+  %[1]s summary
+
+  # Print an overview listing at most 10 namespaces, controls, and workloads not scanned
+  %[1]s summary --top 10
+
+  # Print an overview in JSON output format
+  %[1]s summary -o json`, buildInfo.Executable),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			format := cmd.Flag("output").Value.String()
+			switch format {
+			case "", "table", "json":
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: table,json", format)
+			}
+			top, err := cmd.Flags().GetInt(topFlagName)
+			if err != nil {
+				return err
+			}
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
+			summary, err := summarize(ctx, kubeClient)
+			if err != nil {
+				return err
+			}
+			if format == "json" {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(summary)
+			}
+			return posture.Write(summary, top, time.Now(), out)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Output format. One of table|json")
+	cmd.Flags().Int(topFlagName, 5, "The maximum number of namespaces, controls, and workloads not scanned to list")
+
+	return cmd
+}
+
+// summarize summarizes reports in all namespaces and finds workloads without
+// vulnerability reports.
+func summarize(ctx context.Context, kubeClient client.Client) (posture.Summary, error) {
+	var vulnerabilityReports v1alpha1.VulnerabilityReportList
+	if err := kubeClient.List(ctx, &vulnerabilityReports); err != nil {
+		return posture.Summary{}, fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	var configAuditReports v1alpha1.ConfigAuditReportList
+	if err := kubeClient.List(ctx, &configAuditReports); err != nil {
+		return posture.Summary{}, fmt.Errorf("listing config audit reports: %w", err)
+	}
+	var nodeReports v1alpha1.CISKubeBenchReportList
+	if err := kubeClient.List(ctx, &nodeReports); err != nil {
+		return posture.Summary{}, fmt.Errorf("listing node reports: %w", err)
+	}
+	summary := posture.NewSummary(vulnerabilityReports.Items, configAuditReports.Items, nodeReports.Items)
+
+	workloads, err := (&kube.ObjectResolver{Client: kubeClient}).ListWorkloads(ctx, "")
+	if err != nil {
+		return posture.Summary{}, err
+	}
+	unscanned, err := unscannedWorkloads(ctx, kubeClient, workloads, vulnerabilityReports.Items)
+	if err != nil {
+		return posture.Summary{}, err
+	}
+	summary.Workloads = len(workloads)
+	summary.UnscannedWorkloads = unscanned
+	return summary, nil
+}
+
+// unscannedWorkloads returns the specified workloads without vulnerability
+// reports. Deployments are scanned if they own reports, as reports written by
+// the scan command do, or if any of their ReplicaSets owns reports, as reports
+// written by the operator do.
+func unscannedWorkloads(ctx context.Context, kubeClient client.Client, workloads []kube.ObjectRef,
+	reports []v1alpha1.VulnerabilityReport) ([]kube.ObjectRef, error) {
+	owners := make(map[kube.ObjectRef]bool)
+	for _, report := range reports {
+		owner, err := kube.ObjectRefFromObjectMeta(report.ObjectMeta)
+		if err != nil {
+			continue
+		}
+		owners[owner] = true
+	}
+	var replicaSets appsv1.ReplicaSetList
+	if err := kubeClient.List(ctx, &replicaSets); err != nil {
+		return nil, fmt.Errorf("listing replicasets: %w", err)
+	}
+	for _, rs := range replicaSets.Items {
+		controller := metav1.GetControllerOf(&rs)
+		if controller == nil || controller.Kind != string(kube.KindDeployment) {
+			continue
+		}
+		if owners[kube.ObjectRef{Kind: kube.KindReplicaSet, Name: rs.Name, Namespace: rs.Namespace}] {
+			owners[kube.ObjectRef{Kind: kube.KindDeployment, Name: controller.Name, Namespace: rs.Namespace}] = true
+		}
+	}
+	var unscanned []kube.ObjectRef
+	for _, workload := range workloads {
+		if !owners[workload] {
+			unscanned = append(unscanned, workload)
+		}
+	}
+	return unscanned, nil
+}
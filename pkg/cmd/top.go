@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const byFlagName = "by"
+
+func NewTopCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Rank resources by their security findings",
+	}
+	cmd.AddCommand(NewTopImagesCmd(buildInfo, cf, out))
+	cmd.AddCommand(NewTopPackagesCmd(buildInfo, cf, out))
+	return cmd
+}
+
+func NewTopImagesCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	var rankings []string
+	for _, ranking := range vulnerabilityreport.ImageRankings {
+		rankings = append(rankings, string(ranking))
+	}
+	cmd := &cobra.Command{
+		Use:     "images",
+		Aliases: []string{"image"},
+		Short:   "Rank images run in the cluster by their vulnerabilities",
+		Long: `Rank images run by workloads in all namespaces by their vulnerabilities
+
+Vulnerability reports are grouped by digests of scanned images, so vulnerabilities of an image are counted
+once regardless of how many containers run it. Workloads that run each image are listed with the image.
+`,
+		Example: fmt.Sprintf(`  # List the 10 images with most critical vulnerabilities
+  %[1]s top images
+
+  # List the 20 images with the highest CVSS scores
+  %[1]s top images --by cvss --top 20
+
+  # List images by the total number of vulnerabilities in JSON output format
+  %[1]s top images --by count -o json`, buildInfo.Executable),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			format := cmd.Flag("output").Value.String()
+			switch format {
+			case "", "table", "json":
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: table,json", format)
+			}
+			by, err := cmd.Flags().GetString(byFlagName)
+			if err != nil {
+				return err
+			}
+			ranking, err := vulnerabilityreport.ParseImageRanking(by)
+			if err != nil {
+				return err
+			}
+			top, err := cmd.Flags().GetInt(topFlagName)
+			if err != nil {
+				return err
+			}
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
+			var list v1alpha1.VulnerabilityReportList
+			if err := kubeClient.List(ctx, &list); err != nil {
+				return fmt.Errorf("listing vulnerability reports: %w", err)
+			}
+			images := vulnerabilityreport.SummarizeImages(list.Items)
+			vulnerabilityreport.SortImages(images, ranking)
+			if format == "json" {
+				if top > 0 && len(images) > top {
+					images = images[:top]
+				}
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(images)
+			}
+			return vulnerabilityreport.WriteImagesTable(images, top, out)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Output format. One of table|json")
+	cmd.Flags().String(byFlagName, string(vulnerabilityreport.RankByCritical),
+		"The ranking of images. One of "+strings.Join(rankings, "|"))
+	cmd.Flags().Int(topFlagName, 10, "The maximum number of images to list, or 0 to list all images")
+
+	return cmd
+}
+
+func NewTopPackagesCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "packages",
+		Aliases: []string{"package", "pkgs"},
+		Short:   "Rank vulnerable packages by the number of images in the cluster that contain them",
+		Long: `Rank vulnerable versions of packages by the number of images run by workloads in all namespaces that contain them
+
+Vulnerability reports are grouped by digests of scanned images, so each image is counted once regardless of how many
+containers run it. Widespread packages, such as OS packages inherited from a common base image, are listed first,
+which helps to plan fleet-wide base image refreshes.
+`,
+		Example: fmt.Sprintf(`  # List the 10 vulnerable packages found in most images
+  %[1]s top packages
+
+  # List all vulnerable packages in JSON output format
+  %[1]s top packages --top 0 -o json`, buildInfo.Executable),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			format := cmd.Flag("output").Value.String()
+			switch format {
+			case "", "table", "json":
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: table,json", format)
+			}
+			top, err := cmd.Flags().GetInt(topFlagName)
+			if err != nil {
+				return err
+			}
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
+			var list v1alpha1.VulnerabilityReportList
+			if err := kubeClient.List(ctx, &list); err != nil {
+				return fmt.Errorf("listing vulnerability reports: %w", err)
+			}
+			packages := vulnerabilityreport.SummarizePackages(list.Items)
+			vulnerabilityreport.SortPackages(packages)
+			if format == "json" {
+				if top > 0 && len(packages) > top {
+					packages = packages[:top]
+				}
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(packages)
+			}
+			return vulnerabilityreport.WritePackagesTable(packages, top, out)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Output format. One of table|json")
+	cmd.Flags().Int(topFlagName, 10, "The maximum number of packages to list, or 0 to list all packages")
+
+	return cmd
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/scanfailurereport"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	waitFlagName    = "wait"
+	timeoutFlagName = "timeout"
+
+	// ExitCodeScanFailed is the exit code when waiting with the --wait flag
+	// ends because a scan job failed.
+	ExitCodeScanFailed = 2
+	// ExitCodeTimeout is the exit code when waiting with the --wait flag
+	// ends because the timeout elapsed.
+	ExitCodeTimeout = 3
+)
+
+// waitPollInterval is how often reports are checked while waiting.
+const waitPollInterval = 2 * time.Second
+
+func registerWaitOpts(cmd *cobra.Command) {
+	cmd.Flags().Bool(waitFlagName, false,
+		"If true, don't run a scan job, but wait until the Starboard operator creates or updates the report"+
+			" of the current spec of the workload, or its scan job fails")
+	cmd.Flags().Duration(timeoutFlagName, 5*time.Minute,
+		"The length of time to wait for the report with the --wait flag. A value of zero means wait forever.")
+}
+
+// waitOpts determines whether and how long a command waits for a report
+// produced by the Starboard operator.
+type waitOpts struct {
+	wait    bool
+	timeout time.Duration
+}
+
+func getWaitOpts(cmd *cobra.Command) (opts waitOpts, err error) {
+	opts.wait, err = cmd.Flags().GetBool(waitFlagName)
+	if err != nil {
+		return
+	}
+	opts.timeout, err = cmd.Flags().GetDuration(timeoutFlagName)
+	if err != nil {
+		return
+	}
+	if opts.timeout < 0 {
+		err = fmt.Errorf("invalid timeout %s, must not be negative", opts.timeout)
+	}
+	return
+}
+
+// reportFinder returns true if the report of the current spec of the
+// specified owner exists.
+type reportFinder func(ctx context.Context, owner client.Object, ownerRef kube.ObjectRef) (bool, error)
+
+// waitForReport waits until the report of the specified kind of the specified
+// workload is found by the specified finder. It returns the ExitError with the
+// ExitCodeScanFailed code if the ScanFailureReport of the workload is created
+// or updated while waiting, and the ExitError with the ExitCodeTimeout code if
+// the report isn't found within the timeout.
+func waitForReport(ctx context.Context, kubeClient client.Client, workload kube.ObjectRef, reportKind string,
+	opts waitOpts, find reportFinder) error {
+	resolver := &kube.ObjectResolver{Client: kubeClient}
+	obj, err := resolver.ObjectFromObjectRef(ctx, workload)
+	if err != nil {
+		return fmt.Errorf("resolving object: %w", err)
+	}
+	owner, err := resolver.ReportOwner(ctx, obj)
+	if err != nil {
+		return err
+	}
+	ownerRef := kube.ObjectRefFromKindAndNamespacedName(kube.Kind(owner.GetObjectKind().GroupVersionKind().Kind),
+		client.ObjectKeyFromObject(owner))
+
+	failures := scanfailurereport.NewReadWriter(kubeClient)
+	previousFailures, err := findScanFailures(ctx, failures, ownerRef, reportKind)
+	if err != nil {
+		return err
+	}
+
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+	var scanErr error
+	err = wait.PollImmediateUntil(waitPollInterval, func() (bool, error) {
+		found, err := find(ctx, owner, ownerRef)
+		if err != nil || found {
+			return found, err
+		}
+		current, err := findScanFailures(ctx, failures, ownerRef, reportKind)
+		if err != nil {
+			return false, err
+		}
+		for name, failure := range current {
+			if previous, ok := previousFailures[name]; ok && previous.Report.UpdateTimestamp.Equal(&failure.Report.UpdateTimestamp) {
+				continue
+			}
+			scanErr = &ExitError{
+				Code:    ExitCodeScanFailed,
+				Message: formatScanFailure(failure.Report),
+			}
+			return true, nil
+		}
+		return false, nil
+	}, ctx.Done())
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return &ExitError{
+			Code: ExitCodeTimeout,
+			Message: fmt.Sprintf("timed out waiting for %s of %s %s", reportKind,
+				strings.ToLower(string(ownerRef.Kind)), ownerRef.Name),
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return scanErr
+}
+
+// findScanFailures returns ScanFailureReports of the specified report kind of
+// the specified owner by name. It returns no reports if the ScanFailureReport
+// CRD is not installed.
+func findScanFailures(ctx context.Context, reader scanfailurereport.Reader, owner kube.ObjectRef,
+	reportKind string) (map[string]v1alpha1.ScanFailureReport, error) {
+	reports, err := reader.FindByOwner(ctx, owner)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing scan failure reports: %w", err)
+	}
+	failures := make(map[string]v1alpha1.ScanFailureReport)
+	for _, report := range reports {
+		if report.Report.ReportKind == reportKind {
+			failures[report.Name] = report
+		}
+	}
+	return failures, nil
+}
+
+func formatScanFailure(data v1alpha1.ScanFailureReportData) string {
+	message := fmt.Sprintf("scan job %s failed", data.Job)
+	if data.Reason != "" {
+		message += ": " + data.Reason
+	}
+	if data.Message != "" {
+		message += ": " + data.Message
+	}
+	if data.GaveUp {
+		message += " (the operator gave up scanning until the spec changes)"
+	}
+	return message
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	watchFlagName         = "watch"
+	allNamespacesFlagName = "all-namespaces"
+)
+
+// watchVulnerabilityReports prints a summary of each vulnerability report in
+// the specified namespace, or all namespaces if the namespace is empty, and
+// then a summary of each report created, updated, or deleted until
+// interrupted.
+func watchVulnerabilityReports(ctx context.Context, cf *genericclioptions.ConfigFlags, namespace string, out io.Writer) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	kubeConfig, err := cf.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	kubeClient, err := client.NewWithWatch(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+	if err != nil {
+		return err
+	}
+
+	printer := newVulnerabilityReportEventPrinter(out)
+	printer.printHeader()
+
+	var resourceVersion string
+	for {
+		// The watch without the resource version starts with synthetic ADDED
+		// events of all existing reports. When the watch is closed by the API
+		// server it's resumed from the last seen resource version.
+		watcher, err := kubeClient.Watch(ctx, &v1alpha1.VulnerabilityReportList{}, &client.ListOptions{
+			Namespace: namespace,
+			Raw:       &metav1.ListOptions{ResourceVersion: resourceVersion},
+		})
+		if err != nil {
+			return fmt.Errorf("watch vulnerability reports: %w", err)
+		}
+		resourceVersion, err = printEvents(ctx, watcher, resourceVersion, printer)
+		watcher.Stop()
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// printEvents prints events received from the specified watcher until it's
+// closed or the context is done, and returns the last seen resource version.
+func printEvents(ctx context.Context, watcher watch.Interface, resourceVersion string, printer *vulnerabilityReportEventPrinter) (string, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion, nil
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified, watch.Deleted:
+				report, ok := event.Object.(*v1alpha1.VulnerabilityReport)
+				if !ok {
+					continue
+				}
+				resourceVersion = report.ResourceVersion
+				printer.print(event.Type, report)
+			case watch.Error:
+				err := apierrors.FromObject(event.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					klog.V(3).Infof("Restarting watch of vulnerability reports: %v", err)
+					return "", nil
+				}
+				return resourceVersion, fmt.Errorf("watch vulnerability reports: %w", err)
+			}
+		}
+	}
+}
+
+// vulnerabilityReportEventFormat is the format of rows printed for watch
+// events. Columns have fixed widths, because rows are printed one at a time
+// and the widths of values to come are unknown.
+const vulnerabilityReportEventFormat = "%-9s  %8s  %5s  %6s  %5s  %7s  %-45s  %-15s  %s\n"
+
+// vulnerabilityReportEventPrinter prints a row with the summary of a
+// vulnerability report for each watch event.
+type vulnerabilityReportEventPrinter struct {
+	out io.Writer
+}
+
+func newVulnerabilityReportEventPrinter(out io.Writer) *vulnerabilityReportEventPrinter {
+	return &vulnerabilityReportEventPrinter{
+		out: out,
+	}
+}
+
+func (p *vulnerabilityReportEventPrinter) printHeader() {
+	_, _ = fmt.Fprintf(p.out, vulnerabilityReportEventFormat,
+		"EVENT", "CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN", "WORKLOAD", "CONTAINER", "IMAGE")
+}
+
+func (p *vulnerabilityReportEventPrinter) print(eventType watch.EventType, report *v1alpha1.VulnerabilityReport) {
+	workload := report.Labels[starboard.LabelResourceKind] + "/" + report.Labels[starboard.LabelResourceName]
+	if report.Namespace != "" {
+		workload = report.Namespace + "/" + workload
+	}
+	summary := report.Report.Summary
+	_, _ = fmt.Fprintf(p.out, vulnerabilityReportEventFormat,
+		eventType,
+		strconv.Itoa(summary.CriticalCount),
+		strconv.Itoa(summary.HighCount),
+		strconv.Itoa(summary.MediumCount),
+		strconv.Itoa(summary.LowCount),
+		strconv.Itoa(summary.UnknownCount),
+		workload,
+		report.Labels[starboard.LabelContainerName],
+		vulnerabilityreport.GetImageRef(report.Report),
+	)
+}
@@ -3,7 +3,6 @@ package configauditreport
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -14,7 +13,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -26,8 +24,14 @@ type ScanJobBuilder struct {
 	timeout           time.Duration
 	object            client.Object
 	tolerations       []corev1.Toleration
+	scheduling        starboard.ScanJobScheduling
+	sandbox           starboard.ScanJobSandbox
+	images            starboard.ScanJobImages
+	override          starboard.ScanJobOverride
 	annotations       map[string]string
 	podTemplateLabels labels.Set
+	manifest          bool
+	commonMetadata    starboard.CommonMetadata
 }
 
 func NewScanJobBuilder() *ScanJobBuilder {
@@ -59,6 +63,26 @@ func (s *ScanJobBuilder) WithTolerations(tolerations []corev1.Toleration) *ScanJ
 	return s
 }
 
+func (s *ScanJobBuilder) WithScheduling(scheduling starboard.ScanJobScheduling) *ScanJobBuilder {
+	s.scheduling = scheduling
+	return s
+}
+
+func (s *ScanJobBuilder) WithOverride(override starboard.ScanJobOverride) *ScanJobBuilder {
+	s.override = override
+	return s
+}
+
+func (s *ScanJobBuilder) WithSandbox(sandbox starboard.ScanJobSandbox) *ScanJobBuilder {
+	s.sandbox = sandbox
+	return s
+}
+
+func (s *ScanJobBuilder) WithImages(images starboard.ScanJobImages) *ScanJobBuilder {
+	s.images = images
+	return s
+}
+
 func (s *ScanJobBuilder) WithAnnotations(annotations map[string]string) *ScanJobBuilder {
 	s.annotations = annotations
 	return s
@@ -69,8 +93,27 @@ func (s *ScanJobBuilder) WithPodTemplateLabels(podTemplateLabels labels.Set) *Sc
 	return s
 }
 
+// WithManifest specifies whether the object is a manifest that doesn't exist in
+// the cluster. If true, the job is described by ManifestPlugin, if implemented
+// by the plugin.
+func (s *ScanJobBuilder) WithManifest(manifest bool) *ScanJobBuilder {
+	s.manifest = manifest
+	return s
+}
+
+// WithCommonMetadata sets labels and annotations that are added to the scan
+// job and to the template of its pods unless they are already set.
+func (s *ScanJobBuilder) WithCommonMetadata(metadata starboard.CommonMetadata) *ScanJobBuilder {
+	s.commonMetadata = metadata
+	return s
+}
+
 func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
-	jobSpec, secrets, err := s.plugin.GetScanJobSpec(s.pluginContext, s.object)
+	getScanJobSpec := s.plugin.GetScanJobSpec
+	if manifestPlugin, ok := s.plugin.(ManifestPlugin); ok && s.manifest {
+		getScanJobSpec = manifestPlugin.GetManifestScanJobSpec
+	}
+	jobSpec, secrets, err := getScanJobSpec(s.pluginContext, s.object)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -81,6 +124,10 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 	}
 
 	jobSpec.Tolerations = append(jobSpec.Tolerations, s.tolerations...)
+	s.scheduling.ApplyTo(&jobSpec)
+	s.sandbox.ApplyTo(&jobSpec)
+	s.override.ApplyTo(&jobSpec)
+	s.images.ApplyTo(&jobSpec)
 
 	pluginConfigHash, err := s.plugin.ConfigHash(s.pluginContext, kube.Kind(s.object.GetObjectKind().GroupVersionKind().Kind))
 	if err != nil {
@@ -112,7 +159,7 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 		Spec: batchv1.JobSpec{
 			BackoffLimit:          pointer.Int32Ptr(0),
 			Completions:           pointer.Int32Ptr(1),
-			ActiveDeadlineSeconds: kube.GetActiveDeadlineSeconds(s.timeout),
+			ActiveDeadlineSeconds: kube.GetActiveDeadlineSeconds(s.override.GetTimeout(s.timeout)),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      podTemplateLabelsSet,
@@ -143,6 +190,8 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 		err = kube.ObjectToObjectMetadata(s.object, &secret.ObjectMeta)
 	}
 
+	s.commonMetadata.ApplyToJob(job)
+
 	return job, secrets, nil
 }
 
@@ -160,6 +209,10 @@ type ReportBuilder struct {
 	resourceSpecHash string
 	pluginConfigHash string
 	data             v1alpha1.ConfigAuditReportData
+	labels           map[string]string
+	nameFormat       starboard.ReportNameFormat
+	annotations      map[string]string
+	orphaned         bool
 }
 
 func NewReportBuilder(scheme *runtime.Scheme) *ReportBuilder {
@@ -188,18 +241,46 @@ func (b *ReportBuilder) Data(data v1alpha1.ConfigAuditReportData) *ReportBuilder
 	return b
 }
 
+// Labels sets additional labels of the report, such as the labels that
+// identify the cluster. Labels set by the builder take precedence.
+func (b *ReportBuilder) Labels(labels map[string]string) *ReportBuilder {
+	b.labels = labels
+	return b
+}
+
+// Annotations sets additional annotations of the report, such as the
+// annotations copied from the workload. Annotations set by the builder take
+// precedence.
+func (b *ReportBuilder) Annotations(annotations map[string]string) *ReportBuilder {
+	b.annotations = annotations
+	return b
+}
+
+// NameFormat sets the starboard.ReportNameFormat of the name of the report,
+// which defaults to starboard.ReportNameReadable.
+func (b *ReportBuilder) NameFormat(format starboard.ReportNameFormat) *ReportBuilder {
+	b.nameFormat = format
+	return b
+}
+
+// Orphaned sets whether the report is built without the owner reference to its
+// controller, so that it's not garbage collected by Kubernetes when the
+// controller is deleted.
+func (b *ReportBuilder) Orphaned(orphaned bool) *ReportBuilder {
+	b.orphaned = orphaned
+	return b
+}
+
 func (b *ReportBuilder) reportName() string {
 	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
-	name := b.controller.GetName()
-	reportName := fmt.Sprintf("%s-%s", strings.ToLower(kind), name)
-	if len(validation.IsValidLabelValue(reportName)) == 0 {
-		return reportName
-	}
-	return fmt.Sprintf("%s-%s", strings.ToLower(kind), kube.ComputeHash(name))
+	return kube.GetReportName(b.nameFormat, kind, b.controller.GetName())
 }
 
 func (b *ReportBuilder) GetClusterReport() (v1alpha1.ClusterConfigAuditReport, error) {
 	labelsSet := make(labels.Set)
+	for key, value := range b.labels {
+		labelsSet[key] = value
+	}
 	if b.resourceSpecHash != "" {
 		labelsSet[starboard.LabelResourceSpecHash] = b.resourceSpecHash
 	}
@@ -209,8 +290,9 @@ func (b *ReportBuilder) GetClusterReport() (v1alpha1.ClusterConfigAuditReport, e
 
 	report := v1alpha1.ClusterConfigAuditReport{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   b.reportName(),
-			Labels: labelsSet,
+			Name:        b.reportName(),
+			Labels:      labelsSet,
+			Annotations: copyAnnotations(b.annotations),
 		},
 		Report: b.data,
 	}
@@ -218,6 +300,9 @@ func (b *ReportBuilder) GetClusterReport() (v1alpha1.ClusterConfigAuditReport, e
 	if err != nil {
 		return v1alpha1.ClusterConfigAuditReport{}, err
 	}
+	if b.orphaned {
+		return report, nil
+	}
 	err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
 	if err != nil {
 		return v1alpha1.ClusterConfigAuditReport{}, fmt.Errorf("setting controller reference: %w", err)
@@ -236,6 +321,9 @@ func (b *ReportBuilder) GetClusterReport() (v1alpha1.ClusterConfigAuditReport, e
 
 func (b *ReportBuilder) GetReport() (v1alpha1.ConfigAuditReport, error) {
 	labelsSet := make(labels.Set)
+	for key, value := range b.labels {
+		labelsSet[key] = value
+	}
 	if b.resourceSpecHash != "" {
 		labelsSet[starboard.LabelResourceSpecHash] = b.resourceSpecHash
 	}
@@ -245,9 +333,10 @@ func (b *ReportBuilder) GetReport() (v1alpha1.ConfigAuditReport, error) {
 
 	report := v1alpha1.ConfigAuditReport{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      b.reportName(),
-			Namespace: b.controller.GetNamespace(),
-			Labels:    labelsSet,
+			Name:        b.reportName(),
+			Namespace:   b.controller.GetNamespace(),
+			Labels:      labelsSet,
+			Annotations: copyAnnotations(b.annotations),
 		},
 		Report: b.data,
 	}
@@ -255,6 +344,9 @@ func (b *ReportBuilder) GetReport() (v1alpha1.ConfigAuditReport, error) {
 	if err != nil {
 		return v1alpha1.ConfigAuditReport{}, err
 	}
+	if b.orphaned {
+		return report, nil
+	}
 	err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
 	if err != nil {
 		return v1alpha1.ConfigAuditReport{}, fmt.Errorf("setting controller reference: %w", err)
@@ -286,3 +378,14 @@ func (b *ReportBuilder) Write(ctx context.Context, writer Writer) error {
 		return writer.WriteReport(ctx, report)
 	}
 }
+
+func copyAnnotations(annotations map[string]string) map[string]string {
+	if len(annotations) == 0 {
+		return nil
+	}
+	copied := make(map[string]string)
+	for key, value := range annotations {
+		copied[key] = value
+	}
+	return copied
+}
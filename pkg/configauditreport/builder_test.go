@@ -89,7 +89,7 @@ func TestReportBuilder(t *testing.T) {
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(report).To(Equal(v1alpha1.ClusterConfigAuditReport{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "clusterrole-6f69bb5b79",
+				Name: "clusterrole-system-controller-node-controller-6f69bb5b79",
 				OwnerReferences: []metav1.OwnerReference{
 					{
 						APIVersion:         "rbac.authorization.k8s.io/v1",
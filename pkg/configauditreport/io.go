@@ -7,6 +7,8 @@ import (
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -71,12 +73,19 @@ func (r *readWriter) WriteReport(ctx context.Context, report v1alpha1.ConfigAudi
 		copied := existing.DeepCopy()
 		copied.Labels = report.Labels
 		copied.Report = report.Report
+		for key, value := range report.Annotations {
+			metav1.SetMetaDataAnnotation(&copied.ObjectMeta, key, value)
+		}
 
 		return r.Update(ctx, copied)
 	}
 
 	if errors.IsNotFound(err) {
-		return r.Create(ctx, &report)
+		err = r.Create(ctx, &report)
+		if err != nil {
+			return err
+		}
+		return r.deleteRenamed(ctx, &v1alpha1.ConfigAuditReportList{}, &report)
 	}
 
 	return err
@@ -92,17 +101,57 @@ func (r *readWriter) WriteClusterReport(ctx context.Context, report v1alpha1.Clu
 		copied := existing.DeepCopy()
 		copied.Labels = report.Labels
 		copied.Report = report.Report
+		for key, value := range report.Annotations {
+			metav1.SetMetaDataAnnotation(&copied.ObjectMeta, key, value)
+		}
 
 		return r.Update(ctx, copied)
 	}
 
 	if errors.IsNotFound(err) {
-		return r.Create(ctx, &report)
+		err = r.Create(ctx, &report)
+		if err != nil {
+			return err
+		}
+		return r.deleteRenamed(ctx, &v1alpha1.ClusterConfigAuditReportList{}, &report)
 	}
 
 	return err
 }
 
+// deleteRenamed deletes reports, listed into the specified list, of the
+// workload of the specified report that have different names, e.g. because
+// the format of names of reports was changed.
+func (r *readWriter) deleteRenamed(ctx context.Context, list client.ObjectList, report client.Object) error {
+	owner := kube.GetReportOwnerLabels(report)
+	if len(owner) == 0 {
+		return nil
+	}
+	opts := []client.ListOption{client.MatchingLabels(owner)}
+	if report.GetNamespace() != "" {
+		opts = append(opts, client.InNamespace(report.GetNamespace()))
+	}
+	err := r.List(ctx, list, opts...)
+	if err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		obj := item.(client.Object)
+		if obj.GetName() == report.GetName() {
+			continue
+		}
+		err = r.Delete(ctx, obj)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *readWriter) FindReportByOwner(ctx context.Context, owner kube.ObjectRef) (*v1alpha1.ConfigAuditReport, error) {
 	var list v1alpha1.ConfigAuditReportList
 
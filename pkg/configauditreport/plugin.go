@@ -44,3 +44,13 @@ type Plugin interface {
 	// plugin, false otherwise.
 	IsApplicable(ctx starboard.PluginContext, obj client.Object) (bool, string, error)
 }
+
+// ManifestPlugin is implemented by plugins whose scan jobs read the scanned
+// object from the cluster, and therefore need a different scan job to audit
+// manifests of objects that don't exist in the cluster, e.g. local files.
+type ManifestPlugin interface {
+
+	// GetManifestScanJobSpec describes the pod that audits the manifest of the
+	// specified client.Object instead of reading it from the cluster.
+	GetManifestScanJobSpec(ctx starboard.PluginContext, obj client.Object) (corev1.PodSpec, []*corev1.Secret, error)
+}
@@ -46,7 +46,7 @@ func NewScanner(
 }
 
 func (s *Scanner) Scan(ctx context.Context, partial kube.ObjectRef) (*ReportBuilder, error) {
-	if !s.supportsKind(partial.Kind) {
+	if !s.SupportsKind(partial.Kind) {
 		return nil, fmt.Errorf("kind %s is not supported by %s plugin", partial.Kind, s.pluginContext.GetName())
 	}
 	obj, err := s.objectResolver.ObjectFromObjectRef(ctx, partial)
@@ -67,11 +67,62 @@ func (s *Scanner) Scan(ctx context.Context, partial kube.ObjectRef) (*ReportBuil
 		return nil, err
 	}
 
+	return s.scan(ctx, owner, false)
+}
+
+// ScanManifest creates a Kubernetes job to audit the specified manifest of
+// the object, which doesn't have to exist in the cluster, e.g. a local file
+// applied after the audit. The returned ReportBuilder is meant to get the
+// report rather than to write it, because the report is not controlled by
+// an object stored in the cluster.
+func (s *Scanner) ScanManifest(ctx context.Context, obj client.Object) (*ReportBuilder, error) {
+	kind := kube.Kind(obj.GetObjectKind().GroupVersionKind().Kind)
+	if !s.SupportsKind(kind) {
+		return nil, fmt.Errorf("kind %s is not supported by %s plugin", kind, s.pluginContext.GetName())
+	}
+
+	applicable, reason, err := s.plugin.IsApplicable(s.pluginContext, obj)
+	if err != nil {
+		return nil, err
+	}
+	if !applicable {
+		return nil, fmt.Errorf("not applicable: %s", reason)
+	}
+
+	return s.scan(ctx, obj, true)
+}
+
+func (s *Scanner) scan(ctx context.Context, owner client.Object, manifest bool) (*ReportBuilder, error) {
 	scanJobTolerations, err := s.config.GetScanJobTolerations()
 	if err != nil {
 		return nil, fmt.Errorf("getting scan job tolerations: %w", err)
 	}
 
+	scanJobScheduling, err := s.config.GetScanJobScheduling()
+	if err != nil {
+		return nil, fmt.Errorf("getting scan job scheduling: %w", err)
+	}
+
+	scanJobSandbox, err := s.config.GetScanJobSandbox()
+	if err != nil {
+		return nil, fmt.Errorf("getting scan job sandbox: %w", err)
+	}
+
+	scanJobImages, err := s.config.GetScanJobImages()
+	if err != nil {
+		return nil, fmt.Errorf("getting scan job images: %w", err)
+	}
+
+	pluginConfig, err := s.pluginContext.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("getting plugin config: %w", err)
+	}
+
+	scanJobOverride, err := pluginConfig.GetScanJobOverride(owner.GetNamespace())
+	if err != nil {
+		return nil, fmt.Errorf("getting scan job override: %w", err)
+	}
+
 	scanJobAnnotations, err := s.config.GetScanJobAnnotations()
 	if err != nil {
 		return nil, fmt.Errorf("getting scan job annotations: %w", err)
@@ -82,6 +133,11 @@ func (s *Scanner) Scan(ctx context.Context, partial kube.ObjectRef) (*ReportBuil
 		return nil, fmt.Errorf("getting scan job template labels: %w", err)
 	}
 
+	commonMetadata, err := s.config.GetCommonMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("getting common metadata: %w", err)
+	}
+
 	klog.V(3).Infof("Scanning with options: %+v", s.opts)
 	job, secrets, err := NewScanJobBuilder().
 		WithPlugin(s.plugin).
@@ -89,8 +145,14 @@ func (s *Scanner) Scan(ctx context.Context, partial kube.ObjectRef) (*ReportBuil
 		WithTimeout(s.opts.ScanJobTimeout).
 		WithObject(owner).
 		WithTolerations(scanJobTolerations).
+		WithScheduling(scanJobScheduling).
+		WithSandbox(scanJobSandbox).
+		WithImages(scanJobImages).
+		WithOverride(scanJobOverride).
 		WithAnnotations(scanJobAnnotations).
 		WithPodTemplateLabels(scanJobPodTemplateLabels).
+		WithCommonMetadata(commonMetadata).
+		WithManifest(manifest).
 		Get()
 	if err != nil {
 		return nil, fmt.Errorf("constructing scan job: %w", err)
@@ -135,14 +197,24 @@ func (s *Scanner) Scan(ctx context.Context, partial kube.ObjectRef) (*ReportBuil
 		return nil, fmt.Errorf("expected label %s not set", starboard.LabelPluginConfigHash)
 	}
 
+	nameFormat, err := s.config.GetReportNameFormat()
+	if err != nil {
+		return nil, err
+	}
+
 	return NewReportBuilder(s.scheme).
 		Controller(owner).
 		ResourceSpecHash(resourceSpecHash).
 		PluginConfigHash(pluginConfigHash).
-		Data(result), nil
+		Data(result).
+		NameFormat(nameFormat).
+		Labels(commonMetadata.Labels).
+		Annotations(commonMetadata.Annotations), nil
 }
 
-func (s *Scanner) supportsKind(kind kube.Kind) bool {
+// SupportsKind checks whether objects of the specified kind can be scanned by
+// the plugin.
+func (s *Scanner) SupportsKind(kind kube.Kind) bool {
 	for _, k := range s.plugin.SupportedKinds() {
 		if k == kind {
 			return true
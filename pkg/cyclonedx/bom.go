@@ -0,0 +1,232 @@
+package cyclonedx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+)
+
+const (
+	BOMFormat   = "CycloneDX"
+	SpecVersion = "1.4"
+)
+
+// Types of components.
+const (
+	ComponentTypeApplication = "application"
+	ComponentTypeContainer   = "container"
+	ComponentTypeLibrary     = "library"
+)
+
+// BOM is the CycloneDX bill of materials. Only the properties used by
+// Starboard are modeled.
+type BOM struct {
+	BOMFormat       string          `json:"bomFormat"`
+	SpecVersion     string          `json:"specVersion"`
+	SerialNumber    string          `json:"serialNumber"`
+	Version         int             `json:"version"`
+	Metadata        Metadata        `json:"metadata"`
+	Components      []Component     `json:"components"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+type Metadata struct {
+	Timestamp string     `json:"timestamp"`
+	Tools     []Tool     `json:"tools,omitempty"`
+	Component *Component `json:"component,omitempty"`
+}
+
+type Tool struct {
+	Vendor  string `json:"vendor,omitempty"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type Component struct {
+	BOMRef     string      `json:"bom-ref"`
+	Type       string      `json:"type"`
+	Name       string      `json:"name"`
+	Version    string      `json:"version,omitempty"`
+	Hashes     []Hash      `json:"hashes,omitempty"`
+	Components []Component `json:"components,omitempty"`
+}
+
+type Hash struct {
+	Algorithm string `json:"alg"`
+	Content   string `json:"content"`
+}
+
+type Vulnerability struct {
+	ID             string     `json:"id"`
+	Source         *Source    `json:"source,omitempty"`
+	Ratings        []Rating   `json:"ratings,omitempty"`
+	Description    string     `json:"description,omitempty"`
+	Recommendation string     `json:"recommendation,omitempty"`
+	Advisories     []Advisory `json:"advisories,omitempty"`
+	Affects        []Affect   `json:"affects"`
+}
+
+type Source struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type Rating struct {
+	Score    *float64 `json:"score,omitempty"`
+	Severity string   `json:"severity"`
+}
+
+type Advisory struct {
+	URL string `json:"url"`
+}
+
+type Affect struct {
+	Ref string `json:"ref"`
+}
+
+// Write writes the specified BOM as indented JSON.
+func Write(bom BOM, out io.Writer) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(bom)
+}
+
+// Converter converts vulnerability reports to BOMs.
+type Converter struct {
+	clock       ext.Clock
+	idGenerator ext.IDGenerator
+}
+
+func NewConverter(clock ext.Clock, idGenerator ext.IDGenerator) *Converter {
+	return &Converter{
+		clock:       clock,
+		idGenerator: idGenerator,
+	}
+}
+
+// FromVulnerabilityReports converts reports of containers of the specified
+// workload to the BOM whose component is the workload. Images of containers
+// are components of the BOM, and vulnerable packages are components of images.
+// Each vulnerability is listed once and affects all components in which it's
+// found.
+//
+// Reports list vulnerable packages only, so the BOM is not a complete
+// inventory of software in images.
+func (c *Converter) FromVulnerabilityReports(workload kube.ObjectRef, reports []v1alpha1.VulnerabilityReport) BOM {
+	workloadRef := strings.ToLower(string(workload.Kind)) + "/" + workload.Name
+	if workload.Namespace != "" {
+		workloadRef = workload.Namespace + "/" + workloadRef
+	}
+	bom := BOM{
+		BOMFormat:    BOMFormat,
+		SpecVersion:  SpecVersion,
+		SerialNumber: "urn:uuid:" + c.idGenerator.GenerateID(),
+		Version:      1,
+		Metadata: Metadata{
+			Timestamp: c.clock.Now().UTC().Format(time.RFC3339),
+			Component: &Component{
+				BOMRef: workloadRef,
+				Type:   ComponentTypeApplication,
+				Name:   workloadRef,
+			},
+		},
+		Components:      []Component{},
+		Vulnerabilities: []Vulnerability{},
+	}
+
+	tools := make(map[v1alpha1.Scanner]bool)
+	vulnerabilities := make(map[string]int)
+	for _, report := range reports {
+		if scanner := report.Report.Scanner; !tools[scanner] {
+			tools[scanner] = true
+			bom.Metadata.Tools = append(bom.Metadata.Tools, Tool{
+				Vendor:  scanner.Vendor,
+				Name:    scanner.Name,
+				Version: scanner.Version,
+			})
+		}
+
+		image := imageComponent(report.Report)
+		packages := make(map[string]bool)
+		for _, vulnerability := range report.Report.Vulnerabilities {
+			ref := fmt.Sprintf("%s|%s@%s", image.BOMRef, vulnerability.Resource, vulnerability.InstalledVersion)
+			if !packages[ref] {
+				packages[ref] = true
+				image.Components = append(image.Components, Component{
+					BOMRef:  ref,
+					Type:    ComponentTypeLibrary,
+					Name:    vulnerability.Resource,
+					Version: vulnerability.InstalledVersion,
+				})
+			}
+
+			index, ok := vulnerabilities[vulnerability.VulnerabilityID]
+			if !ok {
+				index = len(bom.Vulnerabilities)
+				vulnerabilities[vulnerability.VulnerabilityID] = index
+				bom.Vulnerabilities = append(bom.Vulnerabilities, newVulnerability(vulnerability))
+			}
+			bom.Vulnerabilities[index].Affects = append(bom.Vulnerabilities[index].Affects, Affect{Ref: ref})
+		}
+		bom.Components = append(bom.Components, image)
+	}
+	return bom
+}
+
+func imageComponent(data v1alpha1.VulnerabilityReportData) Component {
+	ref := vulnerabilityreport.GetImageRef(data)
+	component := Component{
+		BOMRef:  ref,
+		Type:    ComponentTypeContainer,
+		Name:    strings.TrimSuffix(ref, ":"+data.Artifact.Tag),
+		Version: data.Artifact.Tag,
+	}
+	if strings.HasPrefix(data.Artifact.Digest, "sha256:") {
+		component.BOMRef = component.Name + "@" + data.Artifact.Digest
+		component.Hashes = []Hash{{Algorithm: "SHA-256", Content: strings.TrimPrefix(data.Artifact.Digest, "sha256:")}}
+	}
+	return component
+}
+
+func newVulnerability(vulnerability v1alpha1.Vulnerability) Vulnerability {
+	v := Vulnerability{
+		ID:          vulnerability.VulnerabilityID,
+		Description: vulnerability.Description,
+		Ratings: []Rating{{
+			Score:    vulnerability.Score,
+			Severity: severity(vulnerability.Severity),
+		}},
+		Affects: []Affect{},
+	}
+	if v.Description == "" {
+		v.Description = vulnerability.Title
+	}
+	if vulnerability.FixedVersion != "" {
+		v.Recommendation = fmt.Sprintf("Upgrade %s to version %s", vulnerability.Resource, vulnerability.FixedVersion)
+	}
+	if vulnerability.PrimaryLink != "" {
+		v.Source = &Source{URL: vulnerability.PrimaryLink}
+	}
+	for _, link := range vulnerability.Links {
+		v.Advisories = append(v.Advisories, Advisory{URL: link})
+	}
+	return v
+}
+
+// severity returns the CycloneDX severity of the specified severity.
+func severity(severity v1alpha1.Severity) string {
+	switch severity {
+	case v1alpha1.SeverityCritical, v1alpha1.SeverityHigh, v1alpha1.SeverityMedium, v1alpha1.SeverityLow,
+		v1alpha1.SeverityNone:
+		return strings.ToLower(string(severity))
+	default:
+		return "unknown"
+	}
+}
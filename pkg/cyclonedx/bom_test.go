@@ -0,0 +1,114 @@
+package cyclonedx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/cyclonedx"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+)
+
+func TestConverter_FromVulnerabilityReports(t *testing.T) {
+	scanner := v1alpha1.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.24.0"}
+	vulnerability := v1alpha1.Vulnerability{
+		VulnerabilityID:  "CVE-2021-44228",
+		Resource:         "org.apache.logging.log4j:log4j-core",
+		InstalledVersion: "2.14.1",
+		FixedVersion:     "2.15.0",
+		Severity:         v1alpha1.SeverityCritical,
+		Title:            "Remote code injection in Log4j",
+		PrimaryLink:      "https://avd.aquasec.com/nvd/cve-2021-44228",
+		Links:            []string{"https://logging.apache.org/log4j/2.x/security.html"},
+		Score:            pointer.Float64(10),
+	}
+	reports := []v1alpha1.VulnerabilityReport{
+		{
+			Report: v1alpha1.VulnerabilityReportData{
+				Scanner:  scanner,
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact: v1alpha1.Artifact{Repository: "library/app", Tag: "1.0"},
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					vulnerability,
+					{VulnerabilityID: "CVE-2022-0778", Resource: "openssl", InstalledVersion: "1.1.1k", Severity: v1alpha1.SeverityHigh},
+				},
+			},
+		},
+		{
+			Report: v1alpha1.VulnerabilityReportData{
+				Scanner: scanner,
+				Artifact: v1alpha1.Artifact{
+					Repository: "sidecar",
+					Tag:        "2.0",
+					Digest:     "sha256:2c4269d573d9fc6e9e95d5e8f3de2dd0b07c19912551f25e848415b5dd783acf",
+				},
+				Vulnerabilities: []v1alpha1.Vulnerability{vulnerability},
+			},
+		},
+	}
+
+	converter := cyclonedx.NewConverter(ext.NewFixedClock(time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)),
+		ext.NewSimpleIDGenerator())
+	bom := converter.FromVulnerabilityReports(kube.ObjectRef{
+		Kind:      kube.KindDeployment,
+		Name:      "app",
+		Namespace: "default",
+	}, reports)
+
+	assert.Equal(t, cyclonedx.BOMFormat, bom.BOMFormat)
+	assert.Equal(t, cyclonedx.SpecVersion, bom.SpecVersion)
+	assert.Equal(t, "urn:uuid:00000000-0000-0000-0000-000000000001", bom.SerialNumber)
+	assert.Equal(t, "2022-03-01T12:00:00Z", bom.Metadata.Timestamp)
+	assert.Equal(t, []cyclonedx.Tool{{Vendor: "Aqua Security", Name: "Trivy", Version: "0.24.0"}}, bom.Metadata.Tools)
+	require.NotNil(t, bom.Metadata.Component)
+	assert.Equal(t, "default/deployment/app", bom.Metadata.Component.Name)
+
+	require.Len(t, bom.Components, 2)
+	assert.Equal(t, "index.docker.io/library/app:1.0", bom.Components[0].BOMRef)
+	assert.Equal(t, "index.docker.io/library/app", bom.Components[0].Name)
+	assert.Equal(t, "1.0", bom.Components[0].Version)
+	require.Len(t, bom.Components[0].Components, 2)
+	assert.Equal(t, "index.docker.io/library/app:1.0|org.apache.logging.log4j:log4j-core@2.14.1",
+		bom.Components[0].Components[0].BOMRef)
+	assert.Equal(t, "sidecar@sha256:2c4269d573d9fc6e9e95d5e8f3de2dd0b07c19912551f25e848415b5dd783acf",
+		bom.Components[1].BOMRef)
+	assert.Equal(t, []cyclonedx.Hash{{
+		Algorithm: "SHA-256",
+		Content:   "2c4269d573d9fc6e9e95d5e8f3de2dd0b07c19912551f25e848415b5dd783acf",
+	}}, bom.Components[1].Hashes)
+
+	require.Len(t, bom.Vulnerabilities, 2, "vulnerabilities must be listed once")
+	log4shell := bom.Vulnerabilities[0]
+	assert.Equal(t, "CVE-2021-44228", log4shell.ID)
+	assert.Equal(t, "Remote code injection in Log4j", log4shell.Description)
+	assert.Equal(t, "Upgrade org.apache.logging.log4j:log4j-core to version 2.15.0", log4shell.Recommendation)
+	assert.Equal(t, &cyclonedx.Source{URL: "https://avd.aquasec.com/nvd/cve-2021-44228"}, log4shell.Source)
+	assert.Equal(t, []cyclonedx.Rating{{Score: pointer.Float64(10), Severity: "critical"}}, log4shell.Ratings)
+	assert.Equal(t, []cyclonedx.Advisory{{URL: "https://logging.apache.org/log4j/2.x/security.html"}}, log4shell.Advisories)
+	assert.Equal(t, []cyclonedx.Affect{
+		{Ref: "index.docker.io/library/app:1.0|org.apache.logging.log4j:log4j-core@2.14.1"},
+		{Ref: "sidecar@sha256:2c4269d573d9fc6e9e95d5e8f3de2dd0b07c19912551f25e848415b5dd783acf|org.apache.logging.log4j:log4j-core@2.14.1"},
+	}, log4shell.Affects)
+	assert.Equal(t, "high", bom.Vulnerabilities[1].Ratings[0].Severity)
+	assert.Empty(t, bom.Vulnerabilities[1].Recommendation)
+}
+
+func TestWrite(t *testing.T) {
+	converter := cyclonedx.NewConverter(ext.NewSystemClock(), ext.NewSimpleIDGenerator())
+	var out bytes.Buffer
+	err := cyclonedx.Write(converter.FromVulnerabilityReports(kube.ObjectRef{Kind: kube.KindPod, Name: "nginx"}, nil), &out)
+	require.NoError(t, err)
+
+	var bom map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &bom))
+	assert.Equal(t, "CycloneDX", bom["bomFormat"])
+	assert.Equal(t, "1.4", bom["specVersion"])
+	assert.Equal(t, []interface{}{}, bom["components"])
+	assert.Equal(t, []interface{}{}, bom["vulnerabilities"])
+}
@@ -0,0 +1,3 @@
+// Package cyclonedx provides primitives for converting vulnerability reports
+// to CycloneDX bills of materials (BOMs) with embedded vulnerabilities.
+package cyclonedx
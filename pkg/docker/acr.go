@@ -0,0 +1,164 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+)
+
+const (
+	// azureClientIDEnv is the environment variable used by Azure SDKs to
+	// select a user-assigned managed identity, e.g. the kubelet identity of
+	// an AKS cluster.
+	azureClientIDEnv = "AZURE_CLIENT_ID"
+	azureIMDSURL     = "http://169.254.169.254/metadata/identity/oauth2/token"
+	azureResource    = "https://management.azure.com/"
+
+	// acrUsername is the username accepted by ACR when authenticating with
+	// a refresh token obtained from the token exchange endpoint.
+	acrUsername = "00000000-0000-0000-0000-000000000000"
+
+	// acrRefreshTokenLifetime is the lifetime of ACR refresh tokens.
+	acrRefreshTokenLifetime = 3 * time.Hour
+)
+
+var acrServerSuffixes = []string{".azurecr.io", ".azurecr.cn", ".azurecr.de", ".azurecr.us"}
+
+// IsACRServer returns true if the specified registry server is hosted by
+// Azure Container Registry.
+func IsACRServer(server string) bool {
+	for _, suffix := range acrServerSuffixes {
+		if strings.HasSuffix(server, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+type acrProvider struct {
+	cache      *authCache
+	clock      ext.Clock
+	httpClient *http.Client
+	imdsURL    string
+	clientID   string
+	scheme     string
+}
+
+// NewACRCredentialsProvider constructs a CredentialsProvider that exchanges
+// the Azure managed identity available to the current process for ACR
+// refresh tokens.
+//
+// The AAD access token is requested from the Azure Instance Metadata Service
+// (IMDS). By default the system-assigned identity is used. Set the
+// AZURE_CLIENT_ID environment variable to use a user-assigned identity, such
+// as the kubelet identity of an AKS cluster. With AAD Pod Identity, requests
+// to IMDS are served with the identity assigned to the Pod.
+func NewACRCredentialsProvider(clock ext.Clock) CredentialsProvider {
+	return &acrProvider{
+		cache:      newAuthCache(clock),
+		clock:      clock,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		imdsURL:    azureIMDSURL,
+		clientID:   os.Getenv(azureClientIDEnv),
+		scheme:     "https",
+	}
+}
+
+func (p *acrProvider) Accepts(server string) bool {
+	return IsACRServer(server)
+}
+
+func (p *acrProvider) GetAuth(ctx context.Context, server string) (Auth, error) {
+	if auth, ok := p.cache.get(server); ok {
+		return auth, nil
+	}
+	accessToken, err := p.getAADAccessToken(ctx)
+	if err != nil {
+		return Auth{}, fmt.Errorf("getting AAD access token: %w", err)
+	}
+	refreshToken, err := p.exchangeAccessToken(ctx, server, accessToken)
+	if err != nil {
+		return Auth{}, fmt.Errorf("exchanging AAD access token for ACR refresh token: %w", err)
+	}
+	auth := Auth{
+		Auth:     NewBasicAuth(acrUsername, refreshToken),
+		Username: acrUsername,
+		Password: refreshToken,
+	}
+	p.cache.put(server, auth, p.clock.Now().Add(acrRefreshTokenLifetime))
+	return auth, nil
+}
+
+func (p *acrProvider) getAADAccessToken(ctx context.Context) (string, error) {
+	query := url.Values{
+		"api-version": {"2018-02-01"},
+		"resource":    {azureResource},
+	}
+	if p.clientID != "" {
+		query.Set("client_id", p.clientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.imdsURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata", "true")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	err = p.doJSON(req, &token)
+	if err != nil {
+		return "", err
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("empty access token")
+	}
+	return token.AccessToken, nil
+}
+
+func (p *acrProvider) exchangeAccessToken(ctx context.Context, server, accessToken string) (string, error) {
+	form := url.Values{
+		"grant_type":   {"access_token"},
+		"service":      {server},
+		"access_token": {accessToken},
+	}
+	exchangeURL := fmt.Sprintf("%s://%s/oauth2/exchange", p.scheme, server)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exchangeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var token struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	err = p.doJSON(req, &token)
+	if err != nil {
+		return "", err
+	}
+	if token.RefreshToken == "" {
+		return "", fmt.Errorf("empty refresh token")
+	}
+	return token.RefreshToken, nil
+}
+
+func (p *acrProvider) doJSON(req *http.Request, v interface{}) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
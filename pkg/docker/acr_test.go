@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsACRServer(t *testing.T) {
+	testCases := []struct {
+		server   string
+		expected bool
+	}{
+		{server: "myregistry.azurecr.io", expected: true},
+		{server: "myregistry.azurecr.cn", expected: true},
+		{server: "azurecr.io", expected: false},
+		{server: "index.docker.io", expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.server, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsACRServer(tc.server))
+		})
+	}
+}
+
+func TestACRProvider_GetAuth(t *testing.T) {
+	var imdsCalls int
+	imds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		imdsCalls++
+		assert.Equal(t, "true", r.Header.Get("Metadata"))
+		assert.Equal(t, azureResource, r.URL.Query().Get("resource"))
+		assert.Equal(t, "kubelet-client-id", r.URL.Query().Get("client_id"))
+		_, _ = w.Write([]byte(`{"access_token":"aad-t0ken"}`))
+	}))
+	defer imds.Close()
+
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/oauth2/exchange", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "access_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "aad-t0ken", r.PostForm.Get("access_token"))
+		_, _ = w.Write([]byte(`{"refresh_token":"acr-refresh-t0ken"}`))
+	}))
+	defer registry.Close()
+	registryURL, err := url.Parse(registry.URL)
+	require.NoError(t, err)
+
+	clock := ext.NewFixedClock(time.Date(2022, time.February, 1, 10, 0, 0, 0, time.UTC))
+	provider := &acrProvider{
+		cache:      newAuthCache(clock),
+		clock:      clock,
+		httpClient: http.DefaultClient,
+		imdsURL:    imds.URL,
+		clientID:   "kubelet-client-id",
+		scheme:     "http",
+	}
+
+	auth, err := provider.GetAuth(context.TODO(), registryURL.Host)
+	require.NoError(t, err)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000000", auth.Username)
+	assert.Equal(t, "acr-refresh-t0ken", auth.Password)
+
+	_, err = provider.GetAuth(context.TODO(), registryURL.Host)
+	require.NoError(t, err)
+	assert.Equal(t, 1, imdsCalls, "expected token to be served from cache")
+}
@@ -0,0 +1,70 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+)
+
+// CredentialsProvider obtains Docker registry credentials from a source other
+// than image pull Secrets, for example an identity assigned by a cloud
+// provider to the Kubernetes Pod or Node.
+type CredentialsProvider interface {
+	// Accepts returns true if this provider can issue credentials for the
+	// specified registry server.
+	Accepts(server string) bool
+
+	// GetAuth returns short-lived credentials for the specified registry server.
+	// It returns ErrNoCredentials if, after all, it holds no credentials for
+	// the server.
+	GetAuth(ctx context.Context, server string) (Auth, error)
+}
+
+// ErrNoCredentials is returned by a CredentialsProvider that accepted a
+// registry server but has no credentials for it. Callers should consult the
+// next CredentialsProvider.
+var ErrNoCredentials = errors.New("no credentials")
+
+// expiryMargin is subtracted from the expiration time of cached credentials
+// so that a scan job is never handed a token that expires while it's pulling
+// image layers.
+const expiryMargin = 5 * time.Minute
+
+// authCache holds short-lived credentials keyed by registry server until
+// they're about to expire.
+type authCache struct {
+	clock   ext.Clock
+	mu      sync.Mutex
+	entries map[string]cachedAuth
+}
+
+type cachedAuth struct {
+	auth      Auth
+	expiresAt time.Time
+}
+
+func newAuthCache(clock ext.Clock) *authCache {
+	return &authCache{
+		clock:   clock,
+		entries: make(map[string]cachedAuth),
+	}
+}
+
+func (c *authCache) get(server string) (Auth, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[server]
+	if !ok || !c.clock.Now().Before(entry.expiresAt.Add(-expiryMargin)) {
+		return Auth{}, false
+	}
+	return entry.auth, true
+}
+
+func (c *authCache) put(server string, auth Auth, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[server] = cachedAuth{auth: auth, expiresAt: expiresAt}
+}
@@ -0,0 +1,120 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecr"
+)
+
+// ecrServerPattern matches private ECR registry servers, e.g.
+// 123456789012.dkr.ecr.us-east-1.amazonaws.com, and captures the AWS account
+// ID and region.
+var ecrServerPattern = regexp.MustCompile(`^(\d{12})\.dkr\.ecr(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?$`)
+
+// ParseECRServer returns the AWS account ID and region of the specified ECR
+// registry server. The last return value is false if the server does not
+// belong to ECR.
+func ParseECRServer(server string) (string, string, bool) {
+	matches := ecrServerPattern.FindStringSubmatch(server)
+	if matches == nil {
+		return "", "", false
+	}
+	return matches[1], matches[2], true
+}
+
+type ecrClient interface {
+	GetAuthorizationTokenWithContext(ctx aws.Context, input *ecr.GetAuthorizationTokenInput, opts ...request.Option) (*ecr.GetAuthorizationTokenOutput, error)
+}
+
+type ecrProvider struct {
+	cache     *authCache
+	newClient func(region string) (ecrClient, error)
+
+	mu      sync.Mutex
+	clients map[string]ecrClient
+}
+
+// NewECRCredentialsProvider constructs a CredentialsProvider that exchanges
+// the AWS identity available to the current process for ECR authorization
+// tokens.
+//
+// The identity is resolved with the default AWS credentials chain, which
+// covers IAM Roles for Service Accounts (IRSA), where the EKS Pod Identity
+// Webhook injects the AWS_ROLE_ARN and AWS_WEB_IDENTITY_TOKEN_FILE
+// environment variables, as well as the Node's instance profile.
+func NewECRCredentialsProvider(clock ext.Clock) CredentialsProvider {
+	return &ecrProvider{
+		cache: newAuthCache(clock),
+		newClient: func(region string) (ecrClient, error) {
+			sess, err := session.NewSession(aws.NewConfig().WithRegion(region))
+			if err != nil {
+				return nil, err
+			}
+			return ecr.New(sess), nil
+		},
+		clients: make(map[string]ecrClient),
+	}
+}
+
+func (p *ecrProvider) Accepts(server string) bool {
+	_, _, ok := ParseECRServer(server)
+	return ok
+}
+
+func (p *ecrProvider) GetAuth(ctx context.Context, server string) (Auth, error) {
+	if auth, ok := p.cache.get(server); ok {
+		return auth, nil
+	}
+	accountID, region, ok := ParseECRServer(server)
+	if !ok {
+		return Auth{}, fmt.Errorf("not an ECR registry: %s", server)
+	}
+	client, err := p.clientFor(region)
+	if err != nil {
+		return Auth{}, fmt.Errorf("constructing ECR client: %w", err)
+	}
+	output, err := client.GetAuthorizationTokenWithContext(ctx, &ecr.GetAuthorizationTokenInput{
+		RegistryIds: []*string{aws.String(accountID)},
+	})
+	if err != nil {
+		return Auth{}, fmt.Errorf("getting ECR authorization token: %w", err)
+	}
+	if len(output.AuthorizationData) == 0 || output.AuthorizationData[0].AuthorizationToken == nil {
+		return Auth{}, fmt.Errorf("getting ECR authorization token: empty authorization data")
+	}
+	data := output.AuthorizationData[0]
+
+	basicAuth := BasicAuth(aws.StringValue(data.AuthorizationToken))
+	username, password, err := basicAuth.Decode()
+	if err != nil {
+		return Auth{}, fmt.Errorf("decoding ECR authorization token: %w", err)
+	}
+	auth := Auth{
+		Auth:     basicAuth,
+		Username: username,
+		Password: password,
+	}
+	p.cache.put(server, auth, aws.TimeValue(data.ExpiresAt))
+	return auth, nil
+}
+
+func (p *ecrProvider) clientFor(region string) (ecrClient, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if client, ok := p.clients[region]; ok {
+		return client, nil
+	}
+	client, err := p.newClient(region)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[region] = client
+	return client, nil
+}
@@ -0,0 +1,109 @@
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseECRServer(t *testing.T) {
+	testCases := []struct {
+		server            string
+		expectedAccountID string
+		expectedRegion    string
+		expectedOK        bool
+	}{
+		{
+			server:            "123456789012.dkr.ecr.us-east-1.amazonaws.com",
+			expectedAccountID: "123456789012",
+			expectedRegion:    "us-east-1",
+			expectedOK:        true,
+		},
+		{
+			server:            "123456789012.dkr.ecr-fips.us-gov-west-1.amazonaws.com",
+			expectedAccountID: "123456789012",
+			expectedRegion:    "us-gov-west-1",
+			expectedOK:        true,
+		},
+		{
+			server:            "123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn",
+			expectedAccountID: "123456789012",
+			expectedRegion:    "cn-north-1",
+			expectedOK:        true,
+		},
+		{
+			server:     "public.ecr.aws",
+			expectedOK: false,
+		},
+		{
+			server:     "index.docker.io",
+			expectedOK: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.server, func(t *testing.T) {
+			accountID, region, ok := ParseECRServer(tc.server)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedAccountID, accountID)
+			assert.Equal(t, tc.expectedRegion, region)
+		})
+	}
+}
+
+type fakeECRClient struct {
+	calls  int
+	input  *ecr.GetAuthorizationTokenInput
+	output *ecr.GetAuthorizationTokenOutput
+}
+
+func (c *fakeECRClient) GetAuthorizationTokenWithContext(_ aws.Context, input *ecr.GetAuthorizationTokenInput, _ ...request.Option) (*ecr.GetAuthorizationTokenOutput, error) {
+	c.calls++
+	c.input = input
+	return c.output, nil
+}
+
+func TestECRProvider_GetAuth(t *testing.T) {
+	now := time.Date(2022, time.February, 1, 10, 0, 0, 0, time.UTC)
+	client := &fakeECRClient{
+		output: &ecr.GetAuthorizationTokenOutput{
+			AuthorizationData: []*ecr.AuthorizationData{
+				{
+					// base64(AWS:s3cret)
+					AuthorizationToken: aws.String("QVdTOnMzY3JldA=="),
+					ExpiresAt:          aws.Time(now.Add(12 * time.Hour)),
+				},
+			},
+		},
+	}
+	var requestedRegion string
+	provider := &ecrProvider{
+		cache: newAuthCache(ext.NewFixedClock(now)),
+		newClient: func(region string) (ecrClient, error) {
+			requestedRegion = region
+			return client, nil
+		},
+		clients: make(map[string]ecrClient),
+	}
+
+	server := "123456789012.dkr.ecr.eu-west-1.amazonaws.com"
+	require.True(t, provider.Accepts(server))
+	require.False(t, provider.Accepts("quay.io"))
+
+	auth, err := provider.GetAuth(context.TODO(), server)
+	require.NoError(t, err)
+	assert.Equal(t, "AWS", auth.Username)
+	assert.Equal(t, "s3cret", auth.Password)
+	assert.Equal(t, "eu-west-1", requestedRegion)
+	assert.Equal(t, []*string{aws.String("123456789012")}, client.input.RegistryIds)
+
+	_, err = provider.GetAuth(context.TODO(), server)
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.calls, "expected token to be served from cache")
+}
@@ -0,0 +1,106 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+)
+
+const (
+	// gcpMetadataHostEnv is the environment variable used by Google client
+	// libraries to override the address of the metadata server.
+	gcpMetadataHostEnv = "GCE_METADATA_HOST"
+	gcpMetadataHost    = "metadata.google.internal"
+	gcpTokenPath       = "/computeMetadata/v1/instance/service-accounts/default/token"
+
+	// gcrUsername is the username accepted by GCR and Artifact Registry
+	// when authenticating with an OAuth 2.0 access token.
+	gcrUsername = "oauth2accesstoken"
+)
+
+// IsGCRServer returns true if the specified registry server is hosted by
+// Google Container Registry or Artifact Registry.
+func IsGCRServer(server string) bool {
+	return server == "gcr.io" ||
+		strings.HasSuffix(server, ".gcr.io") ||
+		strings.HasSuffix(server, "-docker.pkg.dev")
+}
+
+type gcrProvider struct {
+	cache       *authCache
+	clock       ext.Clock
+	httpClient  *http.Client
+	metadataURL string
+}
+
+// NewGCRCredentialsProvider constructs a CredentialsProvider that exchanges
+// the Google service account available to the current process for OAuth 2.0
+// access tokens accepted by GCR and Artifact Registry.
+//
+// The access token is requested from the GKE metadata server, which, with
+// Workload Identity enabled, impersonates the Google service account bound
+// to the Kubernetes service account of the Pod.
+func NewGCRCredentialsProvider(clock ext.Clock) CredentialsProvider {
+	host := os.Getenv(gcpMetadataHostEnv)
+	if host == "" {
+		host = gcpMetadataHost
+	}
+	return &gcrProvider{
+		cache:       newAuthCache(clock),
+		clock:       clock,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		metadataURL: "http://" + host + gcpTokenPath,
+	}
+}
+
+func (p *gcrProvider) Accepts(server string) bool {
+	return IsGCRServer(server)
+}
+
+type gcpAccessToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+}
+
+func (p *gcrProvider) GetAuth(ctx context.Context, server string) (Auth, error) {
+	if auth, ok := p.cache.get(server); ok {
+		return auth, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.metadataURL, nil)
+	if err != nil {
+		return Auth{}, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return Auth{}, fmt.Errorf("requesting access token from metadata server: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return Auth{}, fmt.Errorf("requesting access token from metadata server: unexpected status code: %d", resp.StatusCode)
+	}
+	var token gcpAccessToken
+	err = json.NewDecoder(resp.Body).Decode(&token)
+	if err != nil {
+		return Auth{}, fmt.Errorf("decoding access token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return Auth{}, fmt.Errorf("decoding access token: empty access token")
+	}
+	auth := Auth{
+		Auth:     NewBasicAuth(gcrUsername, token.AccessToken),
+		Username: gcrUsername,
+		Password: token.AccessToken,
+	}
+	p.cache.put(server, auth, p.clock.Now().Add(time.Duration(token.ExpiresIn)*time.Second))
+	return auth, nil
+}
@@ -0,0 +1,60 @@
+package docker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsGCRServer(t *testing.T) {
+	testCases := []struct {
+		server   string
+		expected bool
+	}{
+		{server: "gcr.io", expected: true},
+		{server: "eu.gcr.io", expected: true},
+		{server: "europe-west1-docker.pkg.dev", expected: true},
+		{server: "index.docker.io", expected: false},
+		{server: "gcr.io.example.com", expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.server, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsGCRServer(tc.server))
+		})
+	}
+}
+
+func TestGCRProvider_GetAuth(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, gcpTokenPath, r.URL.Path)
+		assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"ya29.t0ken","expires_in":3599,"token_type":"Bearer"}`))
+	}))
+	defer server.Close()
+
+	clock := ext.NewFixedClock(time.Date(2022, time.February, 1, 10, 0, 0, 0, time.UTC))
+	provider := &gcrProvider{
+		cache:       newAuthCache(clock),
+		clock:       clock,
+		httpClient:  server.Client(),
+		metadataURL: server.URL + gcpTokenPath,
+	}
+
+	auth, err := provider.GetAuth(context.TODO(), "europe-west1-docker.pkg.dev")
+	require.NoError(t, err)
+	assert.Equal(t, "oauth2accesstoken", auth.Username)
+	assert.Equal(t, "ya29.t0ken", auth.Password)
+
+	_, err = provider.GetAuth(context.TODO(), "europe-west1-docker.pkg.dev")
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls, "expected token to be served from cache")
+}
@@ -0,0 +1,4 @@
+// Package export provides primitives for exporting security reports to
+// systems outside of the cluster, e.g. compliance archives, before they are
+// deleted from the cluster.
+package export
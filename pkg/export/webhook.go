@@ -0,0 +1,77 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Event is the reason why a report is exported.
+type Event string
+
+const (
+	// EventDeleted means that the report is being deleted from the cluster,
+	// e.g. because its TTL has expired or because its owner was deleted.
+	EventDeleted Event = "Deleted"
+)
+
+// Payload is the JSON document sent to the webhook for each exported report.
+type Payload struct {
+	Event Event `json:"event"`
+	// Cluster is the name of the cluster of the report, if it's configured.
+	Cluster string `json:"cluster,omitempty"`
+	// Report is the report with its apiVersion and kind set.
+	Report client.Object `json:"report"`
+}
+
+// Exporter exports reports.
+type Exporter interface {
+	// Export exports the specified report. A report must be considered
+	// exported only if nil is returned.
+	Export(ctx context.Context, payload Payload) error
+}
+
+type webhookExporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookExporter constructs a new Exporter, which sends each report as
+// Payload in the body of a POST request to the specified URL. Any response
+// status other than 2xx is an error.
+func NewWebhookExporter(url string, timeout time.Duration) Exporter {
+	return &webhookExporter{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (e *webhookExporter) Export(ctx context.Context, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending report to webhook: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("sending report to webhook: unexpected response status: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package export_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWebhookExporter_Export(t *testing.T) {
+	report := &v1alpha1.VulnerabilityReport{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "aquasecurity.github.io/v1alpha1",
+			Kind:       "VulnerabilityReport",
+		},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "replicaset-nginx-nginx"},
+	}
+
+	t.Run("Should post payload as JSON", func(t *testing.T) {
+		var received map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		err := export.NewWebhookExporter(server.URL, time.Second).Export(context.TODO(), export.Payload{
+			Event:   export.EventDeleted,
+			Cluster: "prod",
+			Report:  report,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Deleted", received["event"])
+		assert.Equal(t, "prod", received["cluster"])
+		assert.Equal(t, "VulnerabilityReport", received["report"].(map[string]interface{})["kind"])
+	})
+
+	t.Run("Should return error when response status is not 2xx", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := export.NewWebhookExporter(server.URL, time.Second).Export(context.TODO(), export.Payload{
+			Event:  export.EventDeleted,
+			Report: report,
+		})
+		assert.EqualError(t, err, "sending report to webhook: unexpected response status: 503 Service Unavailable")
+	})
+}
@@ -0,0 +1,87 @@
+package exposurereport
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Assess returns the exposure assessment of a workload that is reachable
+// through the specified routes and whose reports have the specified
+// summaries. The workload is flagged if it's reachable from outside the
+// cluster and has critical vulnerabilities or failed checks of danger
+// severity.
+func Assess(routes []v1alpha1.ExposureRoute, vulnerabilities v1alpha1.VulnerabilitySummary, configAudit v1alpha1.ConfigAuditSummary) v1alpha1.ExposureReportData {
+	data := v1alpha1.ExposureReportData{
+		UpdateTimestamp:   metav1.Now(),
+		InternetReachable: len(routes) > 0,
+		Routes:            routes,
+		CriticalCount:     vulnerabilities.CriticalCount,
+		DangerCount:       configAudit.DangerCount,
+	}
+	data.Flagged = data.InternetReachable && (data.CriticalCount > 0 || data.DangerCount > 0)
+	return data
+}
+
+// GetReportName returns the name of the ExposureReport of the given workload.
+func GetReportName(workload client.Object) string {
+	kind := strings.ToLower(workload.GetObjectKind().GroupVersionKind().Kind)
+	name := fmt.Sprintf("%s-%s", kind, workload.GetName())
+	if len(validation.IsValidLabelValue(name)) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s-%s", kind, kube.ComputeHash(workload.GetName()))
+}
+
+type ReportBuilder struct {
+	scheme     *runtime.Scheme
+	controller client.Object
+	data       v1alpha1.ExposureReportData
+}
+
+func NewReportBuilder(scheme *runtime.Scheme) *ReportBuilder {
+	return &ReportBuilder{
+		scheme: scheme,
+	}
+}
+
+func (b *ReportBuilder) Controller(controller client.Object) *ReportBuilder {
+	b.controller = controller
+	return b
+}
+
+func (b *ReportBuilder) Data(data v1alpha1.ExposureReportData) *ReportBuilder {
+	b.data = data
+	return b
+}
+
+func (b *ReportBuilder) Get() (v1alpha1.ExposureReport, error) {
+	report := v1alpha1.ExposureReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetReportName(b.controller),
+			Namespace: b.controller.GetNamespace(),
+		},
+		Report: b.data,
+	}
+	err := kube.ObjectToObjectMetadata(b.controller, &report.ObjectMeta)
+	if err != nil {
+		return v1alpha1.ExposureReport{}, err
+	}
+	err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
+	if err != nil {
+		return v1alpha1.ExposureReport{}, fmt.Errorf("setting controller reference: %w", err)
+	}
+	// We set metadata.ownerReferences[x].blockOwnerDeletion to false so that
+	// additional RBAC permissions are not required when the
+	// OwnerReferencesPermissionsEnforcement admission controller is enabled.
+	report.OwnerReferences[0].BlockOwnerDeletion = pointer.BoolPtr(false)
+	return report, nil
+}
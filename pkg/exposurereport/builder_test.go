@@ -0,0 +1,97 @@
+package exposurereport_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/exposurereport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+)
+
+func TestAssess(t *testing.T) {
+	routes := []v1alpha1.ExposureRoute{{Kind: "Service", Name: "nginx", Service: "nginx"}}
+	testCases := []struct {
+		name            string
+		routes          []v1alpha1.ExposureRoute
+		vulnerabilities v1alpha1.VulnerabilitySummary
+		configAudit     v1alpha1.ConfigAuditSummary
+		expectedFlagged bool
+	}{
+		{
+			name:            "Should flag reachable workload with critical vulnerabilities",
+			routes:          routes,
+			vulnerabilities: v1alpha1.VulnerabilitySummary{CriticalCount: 1},
+			expectedFlagged: true,
+		},
+		{
+			name:            "Should flag reachable workload with failed checks of danger severity",
+			routes:          routes,
+			configAudit:     v1alpha1.ConfigAuditSummary{DangerCount: 1},
+			expectedFlagged: true,
+		},
+		{
+			name:            "Should not flag reachable workload without critical findings",
+			routes:          routes,
+			vulnerabilities: v1alpha1.VulnerabilitySummary{HighCount: 3},
+			configAudit:     v1alpha1.ConfigAuditSummary{WarningCount: 2},
+		},
+		{
+			name:            "Should not flag unreachable workload with critical findings",
+			routes:          []v1alpha1.ExposureRoute{},
+			vulnerabilities: v1alpha1.VulnerabilitySummary{CriticalCount: 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := exposurereport.Assess(tc.routes, tc.vulnerabilities, tc.configAudit)
+			assert.Equal(t, len(tc.routes) > 0, data.InternetReachable)
+			assert.Equal(t, tc.expectedFlagged, data.Flagged)
+			assert.Equal(t, tc.vulnerabilities.CriticalCount, data.CriticalCount)
+			assert.Equal(t, tc.configAudit.DangerCount, data.DangerCount)
+		})
+	}
+}
+
+func TestReportBuilder(t *testing.T) {
+	data := v1alpha1.ExposureReportData{InternetReachable: true}
+	report, err := exposurereport.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nginx-6d4cf56db6",
+				Namespace: "default",
+			},
+		}).
+		Data(data).
+		Get()
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ExposureReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replicaset-nginx-6d4cf56db6",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         "apps/v1",
+					Kind:               "ReplicaSet",
+					Name:               "nginx-6d4cf56db6",
+					Controller:         pointer.BoolPtr(true),
+					BlockOwnerDeletion: pointer.BoolPtr(false),
+				},
+			},
+			Labels: map[string]string{
+				"starboard.resource.kind":      "ReplicaSet",
+				"starboard.resource.name":      "nginx-6d4cf56db6",
+				"starboard.resource.namespace": "default",
+			},
+		},
+		Report: data,
+	}, report)
+}
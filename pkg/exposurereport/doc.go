@@ -0,0 +1,5 @@
+// Package exposurereport provides primitives for assessing how workloads are
+// reachable from outside the cluster, through LoadBalancer and NodePort
+// Services, Ingresses, and Gateway API routes, and for recording the
+// assessment as ExposureReport resources.
+package exposurereport
@@ -0,0 +1,145 @@
+package exposurereport
+
+import (
+	"sort"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	KindService = "Service"
+	KindIngress = "Ingress"
+)
+
+// GatewayAPIGroup is the API group of Gateway API routes.
+const GatewayAPIGroup = "gateway.networking.k8s.io"
+
+// GatewayRouteKinds are kinds of Gateway API routes whose backends are
+// correlated with workloads. Routes are handled as unstructured objects, so
+// that they're supported regardless of the version of the Gateway API.
+var GatewayRouteKinds = []string{"HTTPRoute", "GRPCRoute", "TLSRoute", "TCPRoute", "UDPRoute"}
+
+// GetGatewayRouteKinds returns group version kinds of Gateway API routes that
+// are served by the cluster, in preferred versions. Kinds that are not served,
+// e.g. because the Gateway API is not installed, are skipped.
+func GetGatewayRouteKinds(mapper meta.RESTMapper) ([]schema.GroupVersionKind, error) {
+	var kinds []schema.GroupVersionKind
+	for _, kind := range GatewayRouteKinds {
+		mapping, err := mapper.RESTMapping(schema.GroupKind{Group: GatewayAPIGroup, Kind: kind})
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		kinds = append(kinds, mapping.GroupVersionKind)
+	}
+	return kinds, nil
+}
+
+// GetRoutes returns resources through which a workload with the specified
+// labels of its pod template is reachable from outside the cluster. These are
+// LoadBalancer and NodePort Services that select pods of the workload, and
+// Ingresses and Gateway API routes whose backends are Services that select
+// pods of the workload. Services, Ingresses, and routes must be in the
+// namespace of the workload.
+func GetRoutes(podLabels map[string]string, services []corev1.Service, ingresses []networkingv1.Ingress, gatewayRoutes []unstructured.Unstructured) []v1alpha1.ExposureRoute {
+	routes := make([]v1alpha1.ExposureRoute, 0)
+	selected := make(map[string]bool)
+	for _, service := range services {
+		if len(service.Spec.Selector) == 0 ||
+			!labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(podLabels)) {
+			continue
+		}
+		selected[service.Name] = true
+		if service.Spec.Type == corev1.ServiceTypeLoadBalancer || service.Spec.Type == corev1.ServiceTypeNodePort {
+			routes = append(routes, v1alpha1.ExposureRoute{Kind: KindService, Name: service.Name, Service: service.Name})
+		}
+	}
+	if len(selected) == 0 {
+		return routes
+	}
+
+	addRoutes := func(kind, name string, backends map[string]bool) {
+		for _, service := range sortedKeys(backends) {
+			if selected[service] {
+				routes = append(routes, v1alpha1.ExposureRoute{Kind: kind, Name: name, Service: service})
+			}
+		}
+	}
+	for _, ingress := range ingresses {
+		addRoutes(KindIngress, ingress.Name, getIngressBackends(ingress))
+	}
+	for _, route := range gatewayRoutes {
+		addRoutes(route.GetKind(), route.GetName(), getGatewayRouteBackends(route))
+	}
+	return routes
+}
+
+// getIngressBackends returns names of Services that are backends of the
+// Ingress.
+func getIngressBackends(ingress networkingv1.Ingress) map[string]bool {
+	backends := make(map[string]bool)
+	addBackend := func(backend *networkingv1.IngressBackend) {
+		if backend != nil && backend.Service != nil {
+			backends[backend.Service.Name] = true
+		}
+	}
+	addBackend(ingress.Spec.DefaultBackend)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			addBackend(&path.Backend)
+		}
+	}
+	return backends
+}
+
+// getGatewayRouteBackends returns names of Services in the namespace of the
+// Gateway API route that are referenced by backendRefs of its rules.
+func getGatewayRouteBackends(route unstructured.Unstructured) map[string]bool {
+	backends := make(map[string]bool)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	for _, rule := range rules {
+		rule, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		refs, _, _ := unstructured.NestedSlice(rule, "backendRefs")
+		for _, ref := range refs {
+			ref, ok := ref.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			group, _, _ := unstructured.NestedString(ref, "group")
+			kind, _, _ := unstructured.NestedString(ref, "kind")
+			namespace, _, _ := unstructured.NestedString(ref, "namespace")
+			name, _, _ := unstructured.NestedString(ref, "name")
+			// The group and kind of backends default to the core group and
+			// Service.
+			if group != "" || (kind != "" && kind != KindService) ||
+				(namespace != "" && namespace != route.GetNamespace()) || name == "" {
+				continue
+			}
+			backends[name] = true
+		}
+	}
+	return backends
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
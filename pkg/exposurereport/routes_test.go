@@ -0,0 +1,110 @@
+package exposurereport_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/exposurereport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGetRoutes(t *testing.T) {
+	podLabels := map[string]string{"app": "nginx", "tier": "web"}
+	newService := func(name string, serviceType corev1.ServiceType, selector map[string]string) corev1.Service {
+		return corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       corev1.ServiceSpec{Type: serviceType, Selector: selector},
+		}
+	}
+	services := []corev1.Service{
+		newService("nginx", corev1.ServiceTypeClusterIP, map[string]string{"app": "nginx"}),
+		newService("nginx-lb", corev1.ServiceTypeLoadBalancer, map[string]string{"app": "nginx"}),
+		newService("redis", corev1.ServiceTypeNodePort, map[string]string{"app": "redis"}),
+		newService("external", corev1.ServiceTypeLoadBalancer, nil),
+	}
+	ingresses := []networkingv1.Ingress{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec: networkingv1.IngressSpec{
+				DefaultBackend: &networkingv1.IngressBackend{
+					Service: &networkingv1.IngressServiceBackend{Name: "redis"},
+				},
+				Rules: []networkingv1.IngressRule{
+					{
+						IngressRuleValue: networkingv1.IngressRuleValue{
+							HTTP: &networkingv1.HTTPIngressRuleValue{
+								Paths: []networkingv1.HTTPIngressPath{
+									{
+										Path: "/",
+										Backend: networkingv1.IngressBackend{
+											Service: &networkingv1.IngressServiceBackend{Name: "nginx"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	newGatewayRoute := func(kind, name string, backendRefs ...interface{}) unstructured.Unstructured {
+		route := unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{"backendRefs": backendRefs},
+				},
+			},
+		}}
+		route.SetAPIVersion("gateway.networking.k8s.io/v1beta1")
+		route.SetKind(kind)
+		route.SetNamespace("default")
+		route.SetName(name)
+		return route
+	}
+	gatewayRoutes := []unstructured.Unstructured{
+		newGatewayRoute("HTTPRoute", "web",
+			map[string]interface{}{"name": "nginx", "port": int64(80)}),
+		newGatewayRoute("TLSRoute", "other-namespace",
+			map[string]interface{}{"name": "nginx", "namespace": "prod"}),
+		newGatewayRoute("TCPRoute", "other-kind",
+			map[string]interface{}{"group": "multicluster.x-k8s.io", "kind": "ServiceImport", "name": "nginx"}),
+	}
+
+	t.Run("Should return routes of Services selecting the workload", func(t *testing.T) {
+		routes := exposurereport.GetRoutes(podLabels, services, ingresses, gatewayRoutes)
+		assert.Equal(t, []v1alpha1.ExposureRoute{
+			{Kind: "Service", Name: "nginx-lb", Service: "nginx-lb"},
+			{Kind: "Ingress", Name: "web", Service: "nginx"},
+			{Kind: "HTTPRoute", Name: "web", Service: "nginx"},
+		}, routes)
+	})
+
+	t.Run("Should return empty routes when no Service selects the workload", func(t *testing.T) {
+		routes := exposurereport.GetRoutes(map[string]string{"app": "mysql"}, services, ingresses, gatewayRoutes)
+		assert.Equal(t, []v1alpha1.ExposureRoute{}, routes)
+	})
+}
+
+func TestGetGatewayRouteKinds(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{
+		{Group: "gateway.networking.k8s.io", Version: "v1beta1"},
+		{Group: "gateway.networking.k8s.io", Version: "v1alpha2"},
+	})
+	mapper.Add(schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1beta1", Kind: "HTTPRoute"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "TLSRoute"}, meta.RESTScopeNamespace)
+
+	kinds, err := exposurereport.GetGatewayRouteKinds(mapper)
+	require.NoError(t, err)
+	assert.Equal(t, []schema.GroupVersionKind{
+		{Group: "gateway.networking.k8s.io", Version: "v1beta1", Kind: "HTTPRoute"},
+		{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "TLSRoute"},
+	}, kinds)
+}
@@ -1,6 +1,9 @@
 package ext
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 // Clock wraps the Now method. Introduced to allow replacing the global state with fixed clocks to facilitate testing.
 // Now returns the current time.
@@ -32,3 +35,28 @@ func NewFixedClock(fixedTime time.Time) Clock {
 		fixedTime: fixedTime,
 	}
 }
+
+// FakeClock is a Clock whose current time only changes when it's stepped.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Step advances the current time of the clock by the specified duration.
+func (c *FakeClock) Step(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now: now,
+	}
+}
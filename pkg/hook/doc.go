@@ -0,0 +1,4 @@
+// Package hook provides primitives for calling hooks of the scan lifecycle,
+// which let users enrich reports, create tickets or warm caches without
+// modifying controllers.
+package hook
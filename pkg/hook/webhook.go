@@ -0,0 +1,92 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Stage is the point of the scan lifecycle at which a hook is called.
+type Stage string
+
+const (
+	// StagePreScan hooks are called before a scan job is created.
+	StagePreScan Stage = "PreScan"
+	// StagePostReport hooks are called after reports produced by a scan job
+	// are persisted.
+	StagePostReport Stage = "PostReport"
+)
+
+// Workload identifies the scanned workload.
+type Workload struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Payload is the JSON document sent to the webhook at each Stage.
+type Payload struct {
+	Stage Stage `json:"stage"`
+	// Cluster is the name of the cluster of the workload, if it's configured.
+	Cluster  string   `json:"cluster,omitempty"`
+	Workload Workload `json:"workload"`
+	// ScanJob is the scan job that is about to be created. It's only set at
+	// StagePreScan.
+	ScanJob *batchv1.Job `json:"scanJob,omitempty"`
+	// Reports are the persisted reports with their apiVersion and kind set.
+	// They're only set at StagePostReport.
+	Reports []client.Object `json:"reports,omitempty"`
+}
+
+// Hook is called at a Stage of the scan lifecycle.
+type Hook interface {
+	// Call calls the hook with the specified payload. The hook must be
+	// considered failed unless nil is returned.
+	Call(ctx context.Context, payload Payload) error
+}
+
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook constructs a new Hook, which sends Payload in the body of a POST
+// request to the specified URL. Any response status other than 2xx is an
+// error.
+func NewWebhook(url string, timeout time.Duration) Hook {
+	return &webhook{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (h *webhook) Call(ctx context.Context, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling %s hook: %w", payload.Stage, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("calling %s hook: unexpected response status: %s", payload.Stage, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package hook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/hook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestWebhook_Call(t *testing.T) {
+	workload := hook.Workload{Kind: "ReplicaSet", Name: "nginx-6d4cf56db6", Namespace: "default"}
+
+	t.Run("Should post payload as JSON", func(t *testing.T) {
+		var received map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		err := hook.NewWebhook(server.URL, time.Second).Call(context.TODO(), hook.Payload{
+			Stage:    hook.StagePostReport,
+			Cluster:  "prod",
+			Workload: workload,
+			Reports: []client.Object{
+				&v1alpha1.VulnerabilityReport{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "aquasecurity.github.io/v1alpha1",
+						Kind:       "VulnerabilityReport",
+					},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "replicaset-nginx-6d4cf56db6-nginx"},
+				},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "PostReport", received["stage"])
+		assert.Equal(t, "prod", received["cluster"])
+		assert.Equal(t, map[string]interface{}{
+			"kind":      "ReplicaSet",
+			"name":      "nginx-6d4cf56db6",
+			"namespace": "default",
+		}, received["workload"])
+		assert.NotContains(t, received, "scanJob")
+		require.Len(t, received["reports"], 1)
+		assert.Equal(t, "VulnerabilityReport", received["reports"].([]interface{})[0].(map[string]interface{})["kind"])
+	})
+
+	t.Run("Should return error when response status is not 2xx", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		err := hook.NewWebhook(server.URL, time.Second).Call(context.TODO(), hook.Payload{
+			Stage:    hook.StagePreScan,
+			Workload: workload,
+		})
+		assert.EqualError(t, err, "calling PreScan hook: unexpected response status: 403 Forbidden")
+	})
+}
@@ -0,0 +1,298 @@
+package imagesignature
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type ScanJobBuilder struct {
+	plugin            Plugin
+	pluginContext     starboard.PluginContext
+	timeout           time.Duration
+	object            client.Object
+	credentials       map[string]docker.Auth
+	tolerations       []corev1.Toleration
+	scheduling        starboard.ScanJobScheduling
+	sandbox           starboard.ScanJobSandbox
+	images            starboard.ScanJobImages
+	annotations       map[string]string
+	podTemplateLabels labels.Set
+	commonMetadata    starboard.CommonMetadata
+}
+
+func NewScanJobBuilder() *ScanJobBuilder {
+	return &ScanJobBuilder{}
+}
+
+func (s *ScanJobBuilder) WithPlugin(plugin Plugin) *ScanJobBuilder {
+	s.plugin = plugin
+	return s
+}
+
+func (s *ScanJobBuilder) WithPluginContext(pluginContext starboard.PluginContext) *ScanJobBuilder {
+	s.pluginContext = pluginContext
+	return s
+}
+
+func (s *ScanJobBuilder) WithTimeout(timeout time.Duration) *ScanJobBuilder {
+	s.timeout = timeout
+	return s
+}
+
+func (s *ScanJobBuilder) WithObject(object client.Object) *ScanJobBuilder {
+	s.object = object
+	return s
+}
+
+func (s *ScanJobBuilder) WithTolerations(tolerations []corev1.Toleration) *ScanJobBuilder {
+	s.tolerations = tolerations
+	return s
+}
+
+func (s *ScanJobBuilder) WithScheduling(scheduling starboard.ScanJobScheduling) *ScanJobBuilder {
+	s.scheduling = scheduling
+	return s
+}
+
+func (s *ScanJobBuilder) WithSandbox(sandbox starboard.ScanJobSandbox) *ScanJobBuilder {
+	s.sandbox = sandbox
+	return s
+}
+
+func (s *ScanJobBuilder) WithImages(images starboard.ScanJobImages) *ScanJobBuilder {
+	s.images = images
+	return s
+}
+
+func (s *ScanJobBuilder) WithAnnotations(annotations map[string]string) *ScanJobBuilder {
+	s.annotations = annotations
+	return s
+}
+
+func (s *ScanJobBuilder) WithPodTemplateLabels(podTemplateLabels labels.Set) *ScanJobBuilder {
+	s.podTemplateLabels = podTemplateLabels
+	return s
+}
+
+// WithCommonMetadata sets labels and annotations that are added to the scan
+// job and to the template of its pods unless they are already set.
+func (s *ScanJobBuilder) WithCommonMetadata(metadata starboard.CommonMetadata) *ScanJobBuilder {
+	s.commonMetadata = metadata
+	return s
+}
+
+func (s *ScanJobBuilder) WithCredentials(credentials map[string]docker.Auth) *ScanJobBuilder {
+	s.credentials = credentials
+	return s
+}
+
+func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
+	spec, err := kube.GetPodSpec(s.object)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	templateSpec, secrets, err := s.plugin.GetScanJobSpec(s.pluginContext, s.object, s.credentials)
+	if err != nil {
+		return nil, nil, err
+	}
+	templateSpec.Tolerations = append(templateSpec.Tolerations, s.tolerations...)
+	s.scheduling.ApplyTo(&templateSpec)
+	s.sandbox.ApplyTo(&templateSpec)
+	s.images.ApplyTo(&templateSpec)
+
+	// Only images subject to image signature policies are verified. Record
+	// them, rather than all images of the workload, so that reports are
+	// created for containers that were actually verified.
+	images, err := s.plugin.GetContainerImages(s.object)
+	if err != nil {
+		return nil, nil, err
+	}
+	containerImagesAsJSON, err := images.AsJSON()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	podSpecHash := kube.ComputeHash(spec)
+
+	labelsSet := map[string]string{
+		starboard.LabelResourceSpecHash:            podSpecHash,
+		starboard.LabelK8SAppManagedBy:             starboard.AppStarboard,
+		starboard.LabelImageSignatureReportScanner: s.pluginContext.GetName(),
+	}
+	podTemplateLabelsSet := make(labels.Set)
+	for index, element := range labelsSet {
+		podTemplateLabelsSet[index] = element
+	}
+	for index, element := range s.podTemplateLabels {
+		podTemplateLabelsSet[index] = element
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetScanJobName(s.object),
+			Namespace: s.pluginContext.GetNamespace(),
+			Labels:    labelsSet,
+			Annotations: map[string]string{
+				starboard.AnnotationContainerImages: containerImagesAsJSON,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          pointer.Int32Ptr(0),
+			Completions:           pointer.Int32Ptr(1),
+			ActiveDeadlineSeconds: kube.GetActiveDeadlineSeconds(s.timeout),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podTemplateLabelsSet,
+					Annotations: s.annotations,
+				},
+				Spec: templateSpec,
+			},
+		},
+	}
+
+	err = kube.ObjectToObjectMetadata(s.object, &job.ObjectMeta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = kube.ObjectToObjectMetadata(s.object, &job.Spec.Template.ObjectMeta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.commonMetadata.ApplyToJob(job)
+
+	return job, secrets, nil
+}
+
+func GetScanJobName(obj client.Object) string {
+	return fmt.Sprintf("scan-imagesignaturereport-%s", kube.ComputeHash(kube.ObjectRef{
+		Kind:      kube.Kind(obj.GetObjectKind().GroupVersionKind().Kind),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}))
+}
+
+type ReportBuilder struct {
+	scheme      *runtime.Scheme
+	controller  client.Object
+	container   string
+	hash        string
+	data        v1alpha1.ImageSignatureReportData
+	labels      map[string]string
+	nameFormat  starboard.ReportNameFormat
+	annotations map[string]string
+}
+
+func NewReportBuilder(scheme *runtime.Scheme) *ReportBuilder {
+	return &ReportBuilder{
+		scheme: scheme,
+	}
+}
+
+func (b *ReportBuilder) Controller(controller client.Object) *ReportBuilder {
+	b.controller = controller
+	return b
+}
+
+func (b *ReportBuilder) Container(name string) *ReportBuilder {
+	b.container = name
+	return b
+}
+
+func (b *ReportBuilder) PodSpecHash(hash string) *ReportBuilder {
+	b.hash = hash
+	return b
+}
+
+func (b *ReportBuilder) Data(data v1alpha1.ImageSignatureReportData) *ReportBuilder {
+	b.data = data
+	return b
+}
+
+// Labels sets additional labels of the report, such as the labels that
+// identify the cluster. Labels set by the builder take precedence.
+func (b *ReportBuilder) Labels(labels map[string]string) *ReportBuilder {
+	b.labels = labels
+	return b
+}
+
+// Annotations sets additional annotations of the report, such as the
+// annotations copied from the workload. Annotations set by the builder take
+// precedence.
+func (b *ReportBuilder) Annotations(annotations map[string]string) *ReportBuilder {
+	b.annotations = annotations
+	return b
+}
+
+// NameFormat sets the starboard.ReportNameFormat of the name of the report,
+// which defaults to starboard.ReportNameReadable.
+func (b *ReportBuilder) NameFormat(format starboard.ReportNameFormat) *ReportBuilder {
+	b.nameFormat = format
+	return b
+}
+
+func (b *ReportBuilder) reportName() string {
+	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
+	return kube.GetReportName(b.nameFormat, kind, b.controller.GetName(), b.container)
+}
+
+func (b *ReportBuilder) Get() (v1alpha1.ImageSignatureReport, error) {
+	labels := make(map[string]string)
+	for key, value := range b.labels {
+		labels[key] = value
+	}
+	labels[starboard.LabelContainerName] = b.container
+
+	if b.hash != "" {
+		labels[starboard.LabelResourceSpecHash] = b.hash
+	}
+
+	report := v1alpha1.ImageSignatureReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.reportName(),
+			Namespace:   b.controller.GetNamespace(),
+			Labels:      labels,
+			Annotations: copyAnnotations(b.annotations),
+		},
+		Report: b.data,
+	}
+	err := kube.ObjectToObjectMetadata(b.controller, &report.ObjectMeta)
+	if err != nil {
+		return v1alpha1.ImageSignatureReport{}, err
+	}
+	err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
+	if err != nil {
+		return v1alpha1.ImageSignatureReport{}, fmt.Errorf("setting controller reference: %w", err)
+	}
+	// We set metadata.ownerReferences[x].blockOwnerDeletion to false so that
+	// additional RBAC permissions are not required when the
+	// OwnerReferencesPermissionsEnforcement admission controller is enabled.
+	report.OwnerReferences[0].BlockOwnerDeletion = pointer.BoolPtr(false)
+	return report, nil
+}
+
+func copyAnnotations(annotations map[string]string) map[string]string {
+	if len(annotations) == 0 {
+		return nil
+	}
+	copied := make(map[string]string)
+	for key, value := range annotations {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,174 @@
+package imagesignature_test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/imagesignature"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReportBuilder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := imagesignature.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-owner",
+				Namespace: "qa",
+			},
+		}).
+		Container("my-container").
+		PodSpecHash("xyz").
+		Data(v1alpha1.ImageSignatureReportData{}).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report).To(gomega.Equal(v1alpha1.ImageSignatureReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replicaset-some-owner-my-container",
+			Namespace: "qa",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         "apps/v1",
+					Kind:               "ReplicaSet",
+					Name:               "some-owner",
+					Controller:         pointer.BoolPtr(true),
+					BlockOwnerDeletion: pointer.BoolPtr(false),
+				},
+			},
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      "ReplicaSet",
+				starboard.LabelResourceName:      "some-owner",
+				starboard.LabelResourceNamespace: "qa",
+				starboard.LabelContainerName:     "my-container",
+				starboard.LabelResourceSpecHash:  "xyz",
+			},
+		},
+		Report: v1alpha1.ImageSignatureReportData{},
+	}))
+}
+
+func TestScanJobBuilder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	job, _, err := imagesignature.NewScanJobBuilder().
+		WithPlugin(&testPlugin{
+			images: kube.ContainerImages{
+				"nginx": "nginx:1.16",
+			},
+		}).
+		WithPluginContext(starboard.NewPluginContext().
+			WithName("test-plugin").
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			Get()).
+		WithTimeout(3 * time.Second).
+		WithObject(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nginx-6799fc88d8",
+				Namespace: "prod-ns",
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "nginx",
+								Image: "nginx:1.16",
+							},
+							{
+								Name:  "sidecar",
+								Image: "busybox:1.34",
+							},
+						},
+					},
+				},
+				Selector: &metav1.LabelSelector{},
+			},
+		}).
+		Get()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(job).ToNot(gomega.BeNil())
+	g.Expect(job).To(gomega.Equal(&batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "scan-imagesignaturereport-64d65c457",
+			Namespace: "starboard-ns",
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy:             "starboard",
+				starboard.LabelImageSignatureReportScanner: "test-plugin",
+				starboard.LabelResourceKind:                "ReplicaSet",
+				starboard.LabelResourceName:                "nginx-6799fc88d8",
+				starboard.LabelResourceNamespace:           "prod-ns",
+				starboard.LabelResourceSpecHash:            "7785466d8b",
+			},
+			Annotations: map[string]string{
+				starboard.AnnotationContainerImages: `{"nginx":"nginx:1.16"}`,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          pointer.Int32Ptr(0),
+			Completions:           pointer.Int32Ptr(1),
+			ActiveDeadlineSeconds: pointer.Int64Ptr(3),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						starboard.LabelK8SAppManagedBy:             "starboard",
+						starboard.LabelImageSignatureReportScanner: "test-plugin",
+						starboard.LabelResourceKind:                "ReplicaSet",
+						starboard.LabelResourceName:                "nginx-6799fc88d8",
+						starboard.LabelResourceNamespace:           "prod-ns",
+						starboard.LabelResourceSpecHash:            "7785466d8b",
+					},
+				},
+				Spec: corev1.PodSpec{},
+			},
+		},
+	}))
+}
+
+type testPlugin struct {
+	images kube.ContainerImages
+}
+
+func (p *testPlugin) GetContainerImages(_ client.Object) (kube.ContainerImages, error) {
+	return p.images, nil
+}
+
+func (p *testPlugin) GetScanJobSpec(_ starboard.PluginContext, _ client.Object, _ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	return corev1.PodSpec{}, nil, nil
+}
+
+func (p *testPlugin) ParseImageSignatureReportData(_ string, _ io.Reader) (v1alpha1.ImageSignatureReportData, error) {
+	return v1alpha1.ImageSignatureReportData{}, nil
+}
+
+func (p *testPlugin) GetFailedImageSignatureReportData(_ string, _ string) (v1alpha1.ImageSignatureReportData, error) {
+	return v1alpha1.ImageSignatureReportData{}, nil
+}
+
+func (p *testPlugin) ParseImageProvenance(_ string, _ io.Reader) (*v1alpha1.ImageProvenance, error) {
+	return nil, nil
+}
+
+func (p *testPlugin) GetFailedImageProvenance(_ string, _ string) (*v1alpha1.ImageProvenance, error) {
+	return nil, nil
+}
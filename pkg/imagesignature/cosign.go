@@ -0,0 +1,547 @@
+package imagesignature
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// CosignPlugin the name of the Plugin that verifies signatures with Cosign.
+	CosignPlugin = "Cosign"
+
+	secretVolumeName    = "secret"
+	secretMountPath     = "/etc/starboard"
+	tmpVolumeName       = "tmp"
+	tmpMountPath        = "/tmp"
+	dockerConfigKey     = "config.json"
+	dockerConfigDirPath = "docker"
+	publicKeysDirPath   = "cosign"
+)
+
+// Config defines configuration settings of the Cosign Plugin.
+type Config interface {
+	GetCosignImageRef() (string, error)
+	GetImageSignaturePolicies() ([]starboard.ImageSignaturePolicy, error)
+}
+
+type cosignPlugin struct {
+	clock       ext.Clock
+	idGenerator ext.IDGenerator
+	config      Config
+}
+
+// NewCosignPlugin constructs a new Plugin, which is using the official Cosign
+// container image, with the specified Config.
+func NewCosignPlugin(clock ext.Clock, idGenerator ext.IDGenerator, config Config) Plugin {
+	return &cosignPlugin{
+		clock:       clock,
+		idGenerator: idGenerator,
+		config:      config,
+	}
+}
+
+func (p *cosignPlugin) GetContainerImages(workload client.Object) (kube.ContainerImages, error) {
+	spec, err := kube.GetPodSpec(workload)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := p.config.GetImageSignaturePolicies()
+	if err != nil {
+		return nil, err
+	}
+	images := kube.ContainerImages{}
+	for containerName, imageRef := range kube.GetContainerImagesFromPodSpec(spec) {
+		if _, ok := MatchPolicy(policies, imageRef); ok {
+			images[containerName] = imageRef
+		}
+	}
+	return images, nil
+}
+
+func (p *cosignPlugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	cosignImageRef, err := p.config.GetCosignImageRef()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	policies, err := p.config.GetImageSignaturePolicies()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	images, err := p.GetContainerImages(workload)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	secretData := make(map[string][]byte)
+	var secretItems []corev1.KeyToPath
+
+	dockerConfig, err := p.newDockerConfig(images, credentials)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	if dockerConfig != nil {
+		secretData[dockerConfigKey] = dockerConfig
+		secretItems = append(secretItems, corev1.KeyToPath{
+			Key:  dockerConfigKey,
+			Path: dockerConfigDirPath + "/" + dockerConfigKey,
+		})
+	}
+
+	var containers []corev1.Container
+	for _, containerName := range sortedContainerNames(images) {
+		imageRef := images[containerName]
+		policy, _ := MatchPolicy(policies, imageRef)
+
+		var verificationArgs []string
+		if policy.Keyless != nil {
+			verificationArgs = []string{
+				"--certificate-oidc-issuer", policy.Keyless.Issuer,
+				"--certificate-identity-regexp", policy.Keyless.Subject,
+			}
+		} else {
+			keyName := policy.Name + ".pub"
+			if _, ok := secretData[keyName]; !ok {
+				secretData[keyName] = []byte(policy.Key)
+				secretItems = append(secretItems, corev1.KeyToPath{
+					Key:  keyName,
+					Path: publicKeysDirPath + "/" + keyName,
+				})
+			}
+			verificationArgs = []string{"--key", secretMountPath + "/" + publicKeysDirPath + "/" + keyName}
+		}
+
+		args := append([]string{"verify", "--output", "json"}, verificationArgs...)
+		args = append(args, imageRef)
+		containers = append(containers, p.newContainer(containerName, cosignImageRef, args, dockerConfig != nil))
+
+		if policy.Provenance != nil {
+			args := append([]string{"verify-attestation", "--type", policy.Provenance.GetType()}, verificationArgs...)
+			args = append(args, imageRef)
+			containers = append(containers, p.newContainer(GetProvenanceContainerName(containerName), cosignImageRef, args, dockerConfig != nil))
+		}
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: tmpVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumDefault,
+				},
+			},
+		},
+	}
+
+	var secrets []*corev1.Secret
+	if len(secretData) > 0 {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: p.idGenerator.GenerateID(),
+			},
+			Data: secretData,
+		}
+		secrets = append(secrets, secret)
+		volumes = append(volumes, corev1.Volume{
+			Name: secretVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret.Name,
+					Items:      secretItems,
+				},
+			},
+		})
+		for i := range containers {
+			containers[i].VolumeMounts = append(containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      secretVolumeName,
+				MountPath: secretMountPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+
+	return corev1.PodSpec{
+		ServiceAccountName:           ctx.GetServiceAccountName(),
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		RestartPolicy:                corev1.RestartPolicyNever,
+		Volumes:                      volumes,
+		Containers:                   containers,
+	}, secrets, nil
+}
+
+func (p *cosignPlugin) newContainer(name, cosignImageRef string, args []string, withDockerConfig bool) corev1.Container {
+	env := []corev1.EnvVar{
+		{
+			// Cosign caches the Sigstore TUF root in the home directory.
+			Name:  "HOME",
+			Value: tmpMountPath,
+		},
+	}
+	if withDockerConfig {
+		env = append(env, corev1.EnvVar{
+			Name:  "DOCKER_CONFIG",
+			Value: secretMountPath + "/" + dockerConfigDirPath,
+		})
+	}
+	return corev1.Container{
+		Name:                     name,
+		Image:                    cosignImageRef,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Args:                     args,
+		Env:                      env,
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("256M"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+				corev1.ResourceMemory: resource.MustParse("64M"),
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			Privileged:               pointer.BoolPtr(false),
+			AllowPrivilegeEscalation: pointer.BoolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"all"},
+			},
+			ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      tmpVolumeName,
+				MountPath: tmpMountPath,
+				ReadOnly:  false,
+			},
+		},
+	}
+}
+
+// newDockerConfig returns the Docker config file with credentials of
+// registries that host the specified images, or nil if there are no
+// credentials.
+func (p *cosignPlugin) newDockerConfig(images kube.ContainerImages, credentials map[string]docker.Auth) ([]byte, error) {
+	auths := make(map[string]docker.Auth)
+	for containerName, imageRef := range images {
+		auth, ok := credentials[containerName]
+		if !ok {
+			continue
+		}
+		server, err := docker.GetServerFromImageRef(imageRef)
+		if err != nil {
+			return nil, err
+		}
+		auths[server] = docker.Auth{
+			Auth:     docker.NewBasicAuth(auth.Username, auth.Password),
+			Username: auth.Username,
+			Password: auth.Password,
+		}
+	}
+	if len(auths) == 0 {
+		return nil, nil
+	}
+	return docker.Config{Auths: auths}.Write()
+}
+
+// cosignPayload represents a simple signing payload printed by the cosign
+// verify command for each verified signature.
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+func (p *cosignPlugin) ParseImageSignatureReportData(imageRef string, logsStream io.Reader) (v1alpha1.ImageSignatureReportData, error) {
+	signatures := make([]v1alpha1.ImageSignature, 0)
+
+	// Logs contain both the JSON output printed to stdout and human-readable
+	// messages printed to stderr. Depending on the version, Cosign prints
+	// either a JSON array or one JSON object per signature.
+	scanner := bufio.NewScanner(logsStream)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		var payloads []cosignPayload
+		switch {
+		case strings.HasPrefix(line, "["):
+			err := json.Unmarshal([]byte(line), &payloads)
+			if err != nil {
+				return v1alpha1.ImageSignatureReportData{}, fmt.Errorf("decoding cosign output: %w", err)
+			}
+		case strings.HasPrefix(line, "{"):
+			var payload cosignPayload
+			err := json.Unmarshal([]byte(line), &payload)
+			if err != nil {
+				return v1alpha1.ImageSignatureReportData{}, fmt.Errorf("decoding cosign output: %w", err)
+			}
+			payloads = append(payloads, payload)
+		}
+		for _, payload := range payloads {
+			signatures = append(signatures, toImageSignature(payload))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return v1alpha1.ImageSignatureReportData{}, fmt.Errorf("reading cosign output: %w", err)
+	}
+
+	data, err := p.newReportData(imageRef)
+	if err != nil {
+		return v1alpha1.ImageSignatureReportData{}, err
+	}
+	data.Verified = len(signatures) > 0
+	if !data.Verified {
+		data.Message = "no signatures found in cosign output"
+	}
+	data.Signatures = signatures
+	return data, nil
+}
+
+func (p *cosignPlugin) GetFailedImageSignatureReportData(imageRef string, message string) (v1alpha1.ImageSignatureReportData, error) {
+	data, err := p.newReportData(imageRef)
+	if err != nil {
+		return v1alpha1.ImageSignatureReportData{}, err
+	}
+	data.Verified = false
+	data.Message = strings.TrimSpace(message)
+	data.Signatures = []v1alpha1.ImageSignature{}
+	return data, nil
+}
+
+// dsseEnvelope represents a signed in-toto attestation printed by the
+// cosign verify-attestation command for each verified attestation.
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+}
+
+// inTotoStatement represents an in-toto statement with a SLSA provenance
+// predicate. Both v0.2 and v1 predicates are supported.
+type inTotoStatement struct {
+	PredicateType string `json:"predicateType"`
+	Predicate     struct {
+		// v0.2
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		BuildType string `json:"buildType"`
+
+		// v1
+		BuildDefinition struct {
+			BuildType string `json:"buildType"`
+		} `json:"buildDefinition"`
+		RunDetails struct {
+			Builder struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+		} `json:"runDetails"`
+	} `json:"predicate"`
+}
+
+func (s inTotoStatement) builderID() string {
+	if s.Predicate.RunDetails.Builder.ID != "" {
+		return s.Predicate.RunDetails.Builder.ID
+	}
+	return s.Predicate.Builder.ID
+}
+
+func (s inTotoStatement) buildType() string {
+	if s.Predicate.BuildDefinition.BuildType != "" {
+		return s.Predicate.BuildDefinition.BuildType
+	}
+	return s.Predicate.BuildType
+}
+
+func (p *cosignPlugin) ParseImageProvenance(imageRef string, logsStream io.Reader) (*v1alpha1.ImageProvenance, error) {
+	provenancePolicy, err := p.getProvenancePolicy(imageRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []inTotoStatement
+	scanner := bufio.NewScanner(logsStream)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var envelope dsseEnvelope
+		err := json.Unmarshal([]byte(line), &envelope)
+		if err != nil {
+			return nil, fmt.Errorf("decoding cosign output: %w", err)
+		}
+		payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("decoding attestation payload: %w", err)
+		}
+		var statement inTotoStatement
+		err = json.Unmarshal(payload, &statement)
+		if err != nil {
+			return nil, fmt.Errorf("decoding in-toto statement: %w", err)
+		}
+		statements = append(statements, statement)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading cosign output: %w", err)
+	}
+
+	if len(statements) == 0 {
+		return &v1alpha1.ImageProvenance{
+			Verified: false,
+			Message:  "no attestations found in cosign output",
+		}, nil
+	}
+
+	// Report the attestation of the most trusted builder if there are many.
+	var provenance *v1alpha1.ImageProvenance
+	for _, statement := range statements {
+		candidate := &v1alpha1.ImageProvenance{
+			PredicateType: statement.PredicateType,
+			BuilderID:     statement.builderID(),
+			BuildType:     statement.buildType(),
+			SLSALevel:     getSLSALevel(provenancePolicy, statement.builderID()),
+		}
+		if provenance == nil || candidate.SLSALevel > provenance.SLSALevel {
+			provenance = candidate
+		}
+	}
+	provenance.Verified = provenance.SLSALevel >= provenancePolicy.MinSLSALevel
+	if !provenance.Verified {
+		provenance.Message = fmt.Sprintf("SLSA level %d of builder %s is lower than required level %d",
+			provenance.SLSALevel, provenance.BuilderID, provenancePolicy.MinSLSALevel)
+	}
+	return provenance, nil
+}
+
+func (p *cosignPlugin) GetFailedImageProvenance(imageRef string, message string) (*v1alpha1.ImageProvenance, error) {
+	_, err := p.getProvenancePolicy(imageRef)
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.ImageProvenance{
+		Verified: false,
+		Message:  strings.TrimSpace(message),
+	}, nil
+}
+
+func (p *cosignPlugin) getProvenancePolicy(imageRef string) (starboard.ProvenancePolicy, error) {
+	policies, err := p.config.GetImageSignaturePolicies()
+	if err != nil {
+		return starboard.ProvenancePolicy{}, err
+	}
+	policy, ok := MatchPolicy(policies, imageRef)
+	if !ok {
+		return starboard.ProvenancePolicy{}, fmt.Errorf("no image signature policy matches image: %s", imageRef)
+	}
+	if policy.Provenance == nil {
+		return starboard.ProvenancePolicy{}, fmt.Errorf("image signature policy %s does not verify provenance", policy.Name)
+	}
+	return *policy.Provenance, nil
+}
+
+// getSLSALevel returns the SLSA build level of the specified builder. Signed
+// provenance of a builder that is not trusted attains level 1.
+func getSLSALevel(policy starboard.ProvenancePolicy, builderID string) int {
+	for _, builder := range policy.Builders {
+		if builder.ID == builderID {
+			return builder.SLSALevel
+		}
+	}
+	return 1
+}
+
+func (p *cosignPlugin) newReportData(imageRef string) (v1alpha1.ImageSignatureReportData, error) {
+	policies, err := p.config.GetImageSignaturePolicies()
+	if err != nil {
+		return v1alpha1.ImageSignatureReportData{}, err
+	}
+	policy, ok := MatchPolicy(policies, imageRef)
+	if !ok {
+		return v1alpha1.ImageSignatureReportData{}, fmt.Errorf("no image signature policy matches image: %s", imageRef)
+	}
+	cosignImageRef, err := p.config.GetCosignImageRef()
+	if err != nil {
+		return v1alpha1.ImageSignatureReportData{}, err
+	}
+	version, err := starboard.GetVersionFromImageRef(cosignImageRef)
+	if err != nil {
+		return v1alpha1.ImageSignatureReportData{}, err
+	}
+	registry, artifact, err := parseImageRef(imageRef)
+	if err != nil {
+		return v1alpha1.ImageSignatureReportData{}, err
+	}
+	return v1alpha1.ImageSignatureReportData{
+		UpdateTimestamp: metav1.NewTime(p.clock.Now()),
+		Scanner: v1alpha1.Scanner{
+			Name:    CosignPlugin,
+			Vendor:  "Sigstore",
+			Version: version,
+		},
+		Registry: registry,
+		Artifact: artifact,
+		Policy:   policy.Name,
+	}, nil
+}
+
+func toImageSignature(payload cosignPayload) v1alpha1.ImageSignature {
+	signature := v1alpha1.ImageSignature{
+		Digest: payload.Critical.Image.DockerManifestDigest,
+	}
+	if issuer, ok := payload.Optional["Issuer"].(string); ok {
+		signature.Issuer = issuer
+	}
+	if subject, ok := payload.Optional["Subject"].(string); ok {
+		signature.Subject = subject
+	}
+	return signature
+}
+
+func parseImageRef(imageRef string) (v1alpha1.Registry, v1alpha1.Artifact, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return v1alpha1.Registry{}, v1alpha1.Artifact{}, err
+	}
+	registry := v1alpha1.Registry{
+		Server: ref.Context().RegistryStr(),
+	}
+	artifact := v1alpha1.Artifact{
+		Repository: ref.Context().RepositoryStr(),
+	}
+	switch t := ref.(type) {
+	case name.Tag:
+		artifact.Tag = t.TagStr()
+	case name.Digest:
+		artifact.Digest = t.DigestStr()
+	}
+	return registry, artifact, nil
+}
+
+func sortedContainerNames(images kube.ContainerImages) []string {
+	names := make([]string, 0, len(images))
+	for containerName := range images {
+		names = append(names, containerName)
+	}
+	sort.Strings(names)
+	return names
+}
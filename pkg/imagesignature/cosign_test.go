@@ -0,0 +1,260 @@
+package imagesignature_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/imagesignature"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	fixedTime  = time.Now()
+	fixedClock = ext.NewFixedClock(fixedTime)
+)
+
+const testPolicies = `[
+  {"name":"acme","images":["ghcr.io/acme/*"],"keyless":{"issuer":"https://token.actions.githubusercontent.com","subject":"^https://github.com/acme/"}},
+  {"name":"internal","images":["registry.internal/*"],"key":"PUBLIC KEY"}
+]`
+
+func newTestConfig() starboard.ConfigData {
+	return starboard.ConfigData{
+		"cosign.imageRef":          "gcr.io/projectsigstore/cosign:v2.0.0",
+		"imageSignatures.policies": testPolicies,
+	}
+}
+
+func newTestWorkload() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "app", Image: "ghcr.io/acme/app:1.2.0"},
+						{Name: "db", Image: "registry.internal/db:13"},
+						{Name: "sidecar", Image: "busybox:1.34"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCosignPlugin_GetContainerImages(t *testing.T) {
+	plugin := imagesignature.NewCosignPlugin(fixedClock, ext.NewSimpleIDGenerator(), newTestConfig())
+	images, err := plugin.GetContainerImages(newTestWorkload())
+	require.NoError(t, err)
+	assert.Equal(t, kube.ContainerImages{
+		"app": "ghcr.io/acme/app:1.2.0",
+		"db":  "registry.internal/db:13",
+	}, images)
+}
+
+func TestCosignPlugin_GetScanJobSpec(t *testing.T) {
+	plugin := imagesignature.NewCosignPlugin(fixedClock, ext.NewSimpleIDGenerator(), newTestConfig())
+	pluginContext := starboard.NewPluginContext().
+		WithName(imagesignature.CosignPlugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		Get()
+
+	spec, secrets, err := plugin.GetScanJobSpec(pluginContext, newTestWorkload(), map[string]docker.Auth{
+		"db": {Username: "user", Password: "pass"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, secrets, 1)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", secrets[0].Name)
+	assert.Equal(t, []byte("PUBLIC KEY"), secrets[0].Data["internal.pub"])
+	assert.JSONEq(t, `{"auths":{"registry.internal":{"auth":"dXNlcjpwYXNz","username":"user","password":"pass"}}}`,
+		string(secrets[0].Data["config.json"]))
+
+	assert.Equal(t, "starboard-sa", spec.ServiceAccountName)
+	assert.Equal(t, corev1.RestartPolicyNever, spec.RestartPolicy)
+	require.Len(t, spec.Containers, 2)
+
+	assert.Equal(t, "app", spec.Containers[0].Name)
+	assert.Equal(t, "gcr.io/projectsigstore/cosign:v2.0.0", spec.Containers[0].Image)
+	assert.Equal(t, []string{
+		"verify", "--output", "json",
+		"--certificate-oidc-issuer", "https://token.actions.githubusercontent.com",
+		"--certificate-identity-regexp", "^https://github.com/acme/",
+		"ghcr.io/acme/app:1.2.0",
+	}, spec.Containers[0].Args)
+
+	assert.Equal(t, "db", spec.Containers[1].Name)
+	assert.Equal(t, []string{
+		"verify", "--output", "json",
+		"--key", "/etc/starboard/cosign/internal.pub",
+		"registry.internal/db:13",
+	}, spec.Containers[1].Args)
+	assert.Contains(t, spec.Containers[1].Env, corev1.EnvVar{Name: "DOCKER_CONFIG", Value: "/etc/starboard/docker"})
+}
+
+func TestCosignPlugin_ParseImageSignatureReportData(t *testing.T) {
+	plugin := imagesignature.NewCosignPlugin(fixedClock, ext.NewSimpleIDGenerator(), newTestConfig())
+
+	t.Run("Should parse verified signatures", func(t *testing.T) {
+		logs := `Verification for ghcr.io/acme/app:1.2.0 --
+The following checks were performed on each of these signatures:
+  - The cosign claims were validated
+  - The code-signing certificate was verified using trusted certificate authority certificates
+[{"critical":{"identity":{"docker-reference":"ghcr.io/acme/app"},"image":{"docker-manifest-digest":"sha256:2cf6a0e9"},"type":"cosign container image signature"},"optional":{"Issuer":"https://token.actions.githubusercontent.com","Subject":"https://github.com/acme/app/.github/workflows/release.yaml@refs/tags/v1.2.0"}}]
+`
+		data, err := plugin.ParseImageSignatureReportData("ghcr.io/acme/app:1.2.0", strings.NewReader(logs))
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.ImageSignatureReportData{
+			UpdateTimestamp: metav1.NewTime(fixedTime),
+			Scanner: v1alpha1.Scanner{
+				Name:    "Cosign",
+				Vendor:  "Sigstore",
+				Version: "v2.0.0",
+			},
+			Registry: v1alpha1.Registry{Server: "ghcr.io"},
+			Artifact: v1alpha1.Artifact{Repository: "acme/app", Tag: "1.2.0"},
+			Policy:   "acme",
+			Verified: true,
+			Signatures: []v1alpha1.ImageSignature{
+				{
+					Digest:  "sha256:2cf6a0e9",
+					Issuer:  "https://token.actions.githubusercontent.com",
+					Subject: "https://github.com/acme/app/.github/workflows/release.yaml@refs/tags/v1.2.0",
+				},
+			},
+		}, data)
+	})
+
+	t.Run("Should return error when image does not match any policy", func(t *testing.T) {
+		_, err := plugin.ParseImageSignatureReportData("nginx:1.16", strings.NewReader(""))
+		require.EqualError(t, err, "no image signature policy matches image: nginx:1.16")
+	})
+}
+
+func TestCosignPlugin_GetFailedImageSignatureReportData(t *testing.T) {
+	plugin := imagesignature.NewCosignPlugin(fixedClock, ext.NewSimpleIDGenerator(), newTestConfig())
+	data, err := plugin.GetFailedImageSignatureReportData("registry.internal/db:13", "Error: no matching signatures\n")
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ImageSignatureReportData{
+		UpdateTimestamp: metav1.NewTime(fixedTime),
+		Scanner: v1alpha1.Scanner{
+			Name:    "Cosign",
+			Vendor:  "Sigstore",
+			Version: "v2.0.0",
+		},
+		Registry:   v1alpha1.Registry{Server: "registry.internal"},
+		Artifact:   v1alpha1.Artifact{Repository: "db", Tag: "13"},
+		Policy:     "internal",
+		Verified:   false,
+		Message:    "Error: no matching signatures",
+		Signatures: []v1alpha1.ImageSignature{},
+	}, data)
+}
+
+func newProvenanceTestConfig() starboard.ConfigData {
+	return starboard.ConfigData{
+		"cosign.imageRef": "gcr.io/projectsigstore/cosign:v2.0.0",
+		"imageSignatures.policies": `[{
+  "name": "acme",
+  "images": ["ghcr.io/acme/*"],
+  "keyless": {"issuer": "https://token.actions.githubusercontent.com", "subject": "^https://github.com/acme/"},
+  "provenance": {
+    "builders": [{"id": "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.5.0", "slsaLevel": 3}],
+    "minSLSALevel": 3
+  }
+}]`,
+	}
+}
+
+func TestCosignPlugin_GetScanJobSpec_WithProvenance(t *testing.T) {
+	plugin := imagesignature.NewCosignPlugin(fixedClock, ext.NewSimpleIDGenerator(), newProvenanceTestConfig())
+	pluginContext := starboard.NewPluginContext().
+		WithName(imagesignature.CosignPlugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		Get()
+
+	spec, secrets, err := plugin.GetScanJobSpec(pluginContext, newTestWorkload(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, secrets)
+	require.Len(t, spec.Containers, 2)
+
+	assert.Equal(t, "app", spec.Containers[0].Name)
+	assert.Equal(t, "app-provenance", spec.Containers[1].Name)
+	assert.Equal(t, []string{
+		"verify-attestation", "--type", "slsaprovenance",
+		"--certificate-oidc-issuer", "https://token.actions.githubusercontent.com",
+		"--certificate-identity-regexp", "^https://github.com/acme/",
+		"ghcr.io/acme/app:1.2.0",
+	}, spec.Containers[1].Args)
+}
+
+func TestCosignPlugin_ParseImageProvenance(t *testing.T) {
+	plugin := imagesignature.NewCosignPlugin(fixedClock, ext.NewSimpleIDGenerator(), newProvenanceTestConfig())
+
+	newLogs := func(builderID string) string {
+		statement := `{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://slsa.dev/provenance/v0.2","subject":[{"name":"ghcr.io/acme/app","digest":{"sha256":"2cf6a0e9"}}],"predicate":{"builder":{"id":"` + builderID + `"},"buildType":"https://github.com/slsa-framework/slsa-github-generator/container@v1"}}`
+		return "Verification for ghcr.io/acme/app:1.2.0 --\n" +
+			`{"payloadType":"application/vnd.in-toto+json","payload":"` + base64.StdEncoding.EncodeToString([]byte(statement)) + `","signatures":[{"keyid":"","sig":"MEUCIQ"}]}` + "\n"
+	}
+
+	t.Run("Should return provenance of trusted builder", func(t *testing.T) {
+		builderID := "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.5.0"
+		provenance, err := plugin.ParseImageProvenance("ghcr.io/acme/app:1.2.0", strings.NewReader(newLogs(builderID)))
+		require.NoError(t, err)
+		assert.Equal(t, &v1alpha1.ImageProvenance{
+			Verified:      true,
+			PredicateType: "https://slsa.dev/provenance/v0.2",
+			BuilderID:     builderID,
+			BuildType:     "https://github.com/slsa-framework/slsa-github-generator/container@v1",
+			SLSALevel:     3,
+		}, provenance)
+	})
+
+	t.Run("Should not verify provenance of untrusted builder", func(t *testing.T) {
+		provenance, err := plugin.ParseImageProvenance("ghcr.io/acme/app:1.2.0", strings.NewReader(newLogs("https://ci.acme.io")))
+		require.NoError(t, err)
+		assert.Equal(t, &v1alpha1.ImageProvenance{
+			Verified:      false,
+			Message:       "SLSA level 1 of builder https://ci.acme.io is lower than required level 3",
+			PredicateType: "https://slsa.dev/provenance/v0.2",
+			BuilderID:     "https://ci.acme.io",
+			BuildType:     "https://github.com/slsa-framework/slsa-github-generator/container@v1",
+			SLSALevel:     1,
+		}, provenance)
+	})
+
+	t.Run("Should return failed provenance", func(t *testing.T) {
+		provenance, err := plugin.GetFailedImageProvenance("ghcr.io/acme/app:1.2.0", "Error: none of the attestations matched the predicate type\n")
+		require.NoError(t, err)
+		assert.Equal(t, &v1alpha1.ImageProvenance{
+			Verified: false,
+			Message:  "Error: none of the attestations matched the predicate type",
+		}, provenance)
+	})
+}
+
+func TestGetProvenanceContainerName(t *testing.T) {
+	assert.Equal(t, "app-provenance", imagesignature.GetProvenanceContainerName("app"))
+	assert.Equal(t, "provenance-"+kube.ComputeHash(strings.Repeat("a", 60)),
+		imagesignature.GetProvenanceContainerName(strings.Repeat("a", 60)))
+}
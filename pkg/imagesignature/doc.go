@@ -0,0 +1,3 @@
+// Package imagesignature provides primitives for verifying signatures of
+// container images against image signature policies.
+package imagesignature
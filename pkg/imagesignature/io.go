@@ -0,0 +1,132 @@
+package imagesignature
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Writer is the interface that wraps the basic Write method.
+//
+// Write creates or updates the given slice of v1alpha1.ImageSignatureReport
+// instances.
+type Writer interface {
+	Write(context.Context, []v1alpha1.ImageSignatureReport) error
+}
+
+// Reader is the interface that wraps methods for finding
+// v1alpha1.ImageSignatureReport objects.
+//
+// FindByOwner returns the slice of v1alpha1.ImageSignatureReport instances
+// owned by the given kube.ObjectRef or an empty slice if the reports are not found.
+type Reader interface {
+	FindByOwner(context.Context, kube.ObjectRef) ([]v1alpha1.ImageSignatureReport, error)
+}
+
+type ReadWriter interface {
+	Reader
+	Writer
+}
+
+type readWriter struct {
+	client.Client
+}
+
+// NewReadWriter constructs a new ReadWriter which is using the client package
+// provided by the controller-runtime libraries for interacting with the
+// Kubernetes API server.
+func NewReadWriter(client client.Client) ReadWriter {
+	return &readWriter{
+		Client: client,
+	}
+}
+
+func (r *readWriter) Write(ctx context.Context, reports []v1alpha1.ImageSignatureReport) error {
+	for _, report := range reports {
+		err := r.createOrUpdate(ctx, report)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *readWriter) createOrUpdate(ctx context.Context, report v1alpha1.ImageSignatureReport) error {
+	var existing v1alpha1.ImageSignatureReport
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      report.Name,
+		Namespace: report.Namespace,
+	}, &existing)
+
+	if err == nil && kube.IsNameCollision(&existing, &report) {
+		report.Name = kube.GetCollisionFreeName(&report)
+		err = r.Get(ctx, types.NamespacedName{
+			Name:      report.Name,
+			Namespace: report.Namespace,
+		}, &existing)
+	}
+
+	if err == nil {
+		copied := existing.DeepCopy()
+		copied.Labels = report.Labels
+		copied.Report = report.Report
+		for key, value := range report.Annotations {
+			metav1.SetMetaDataAnnotation(&copied.ObjectMeta, key, value)
+		}
+
+		return r.Update(ctx, copied)
+	}
+
+	if errors.IsNotFound(err) {
+		err = r.Create(ctx, &report)
+		if err != nil {
+			return err
+		}
+		return r.deleteRenamed(ctx, report)
+	}
+
+	return err
+}
+
+// deleteRenamed deletes reports of the workload and the container of the
+// specified report that have different names, e.g. because the format of
+// names of reports was changed.
+func (r *readWriter) deleteRenamed(ctx context.Context, report v1alpha1.ImageSignatureReport) error {
+	owner := kube.GetReportOwnerLabels(&report)
+	if len(owner) == 0 {
+		return nil
+	}
+	var list v1alpha1.ImageSignatureReportList
+	err := r.List(ctx, &list, client.InNamespace(report.Namespace), client.MatchingLabels(owner))
+	if err != nil {
+		return err
+	}
+	for i := range list.Items {
+		if list.Items[i].Name == report.Name {
+			continue
+		}
+		err = r.Delete(ctx, &list.Items[i])
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *readWriter) FindByOwner(ctx context.Context, owner kube.ObjectRef) ([]v1alpha1.ImageSignatureReport, error) {
+	var list v1alpha1.ImageSignatureReportList
+
+	labels := client.MatchingLabels(kube.ObjectRefToLabels(owner))
+
+	err := r.List(ctx, &list, labels, client.InNamespace(owner.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	return list.DeepCopy().Items, nil
+}
@@ -0,0 +1,140 @@
+package imagesignature_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/imagesignature"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewReadWriter(t *testing.T) {
+
+	kubernetesScheme := starboard.NewScheme()
+
+	t.Run("Should create ImageSignatureReports", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		readWriter := imagesignature.NewReadWriter(client)
+		err := readWriter.Write(context.TODO(), []v1alpha1.ImageSignatureReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app1-container1",
+					Namespace: "qa",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "Deployment",
+						starboard.LabelResourceName:      "app1",
+						starboard.LabelResourceNamespace: "qa",
+						starboard.LabelContainerName:     "container1",
+						starboard.LabelResourceSpecHash:  "h1",
+					},
+				},
+				Report: v1alpha1.ImageSignatureReportData{
+					Policy:   "acme",
+					Verified: true,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var found v1alpha1.ImageSignatureReport
+		err = client.Get(context.TODO(), types.NamespacedName{Namespace: "qa", Name: "deployment-app1-container1"}, &found)
+		require.NoError(t, err)
+		assert.Equal(t, "acme", found.Report.Policy)
+		assert.True(t, found.Report.Verified)
+	})
+
+	t.Run("Should update ImageSignatureReports", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+			&v1alpha1.ImageSignatureReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "deployment-app1-container1",
+					Namespace:       "qa",
+					ResourceVersion: "0",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "Deployment",
+						starboard.LabelResourceName:      "app1",
+						starboard.LabelResourceNamespace: "qa",
+						starboard.LabelContainerName:     "container1",
+						starboard.LabelResourceSpecHash:  "h1",
+					},
+				},
+				Report: v1alpha1.ImageSignatureReportData{
+					Policy:   "acme",
+					Verified: true,
+				},
+			}).Build()
+		readWriter := imagesignature.NewReadWriter(client)
+		err := readWriter.Write(context.TODO(), []v1alpha1.ImageSignatureReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app1-container1",
+					Namespace: "qa",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "Deployment",
+						starboard.LabelResourceName:      "app1",
+						starboard.LabelResourceNamespace: "qa",
+						starboard.LabelContainerName:     "container1",
+						starboard.LabelResourceSpecHash:  "h2",
+					},
+				},
+				Report: v1alpha1.ImageSignatureReportData{
+					Policy:   "acme",
+					Verified: false,
+					Message:  "no matching signatures",
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var found v1alpha1.ImageSignatureReport
+		err = client.Get(context.TODO(), types.NamespacedName{Namespace: "qa", Name: "deployment-app1-container1"}, &found)
+		require.NoError(t, err)
+		assert.Equal(t, "h2", found.Labels[starboard.LabelResourceSpecHash])
+		assert.False(t, found.Report.Verified)
+		assert.Equal(t, "no matching signatures", found.Report.Message)
+	})
+
+	t.Run("Should find ImageSignatureReports by owner", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+			&v1alpha1.ImageSignatureReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app1-container1",
+					Namespace: "qa",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "Deployment",
+						starboard.LabelResourceName:      "app1",
+						starboard.LabelResourceNamespace: "qa",
+						starboard.LabelContainerName:     "container1",
+					},
+				},
+			},
+			&v1alpha1.ImageSignatureReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app2-container1",
+					Namespace: "qa",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "Deployment",
+						starboard.LabelResourceName:      "app2",
+						starboard.LabelResourceNamespace: "qa",
+						starboard.LabelContainerName:     "container1",
+					},
+				},
+			}).Build()
+		readWriter := imagesignature.NewReadWriter(client)
+		reports, err := readWriter.FindByOwner(context.TODO(), kube.ObjectRef{
+			Kind:      kube.KindDeployment,
+			Name:      "app1",
+			Namespace: "qa",
+		})
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, "deployment-app1-container1", reports[0].Name)
+	})
+}
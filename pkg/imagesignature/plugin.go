@@ -0,0 +1,60 @@
+package imagesignature
+
+import (
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Plugin defines the interface between Starboard and tools that verify
+// signatures of container images.
+type Plugin interface {
+	// GetContainerImages returns container images of the specified workload
+	// that are subject to image signature policies, keyed by container name.
+	// Images that do not match any policy are not verified.
+	GetContainerImages(workload client.Object) (kube.ContainerImages, error)
+
+	// GetScanJobSpec describes the pod that will be created by Starboard when
+	// it schedules a Kubernetes job to verify signatures of container images
+	// of the specified workload. Each container of the pod verifies the image
+	// of the workload container with the same name.
+	GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error)
+
+	// ParseImageSignatureReportData is a callback to parse and convert logs of
+	// the container that successfully verified signatures of the specified
+	// image to v1alpha1.ImageSignatureReportData.
+	ParseImageSignatureReportData(imageRef string, logsStream io.Reader) (v1alpha1.ImageSignatureReportData, error)
+
+	// GetFailedImageSignatureReportData returns v1alpha1.ImageSignatureReportData
+	// for the specified image which signatures could not be verified. The
+	// message explains the reason, typically it's the termination message of
+	// the container that verified signatures.
+	GetFailedImageSignatureReportData(imageRef string, message string) (v1alpha1.ImageSignatureReportData, error)
+
+	// ParseImageProvenance is a callback to parse and convert logs of the
+	// container that successfully verified provenance attestations of the
+	// specified image to v1alpha1.ImageProvenance.
+	ParseImageProvenance(imageRef string, logsStream io.Reader) (*v1alpha1.ImageProvenance, error)
+
+	// GetFailedImageProvenance returns v1alpha1.ImageProvenance for the
+	// specified image which provenance attestations could not be verified.
+	GetFailedImageProvenance(imageRef string, message string) (*v1alpha1.ImageProvenance, error)
+}
+
+// GetProvenanceContainerName returns the name of the scan job container that
+// verifies provenance attestations of the image of the specified workload
+// container. Plugins must use this name so that the operator can tell
+// provenance results apart from signature verification results.
+func GetProvenanceContainerName(containerName string) string {
+	name := containerName + "-provenance"
+	if len(validation.IsDNS1123Label(name)) == 0 {
+		return name
+	}
+	return "provenance-" + kube.ComputeHash(containerName)
+}
@@ -0,0 +1,32 @@
+package imagesignature
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// MatchPolicy returns the first starboard.ImageSignaturePolicy with an image
+// pattern that matches the specified image reference. The last return value
+// is false if none of the policies applies to the image.
+func MatchPolicy(policies []starboard.ImageSignaturePolicy, imageRef string) (starboard.ImageSignaturePolicy, bool) {
+	for _, policy := range policies {
+		for _, pattern := range policy.Images {
+			if matchImagePattern(pattern, imageRef) {
+				return policy, true
+			}
+		}
+	}
+	return starboard.ImageSignaturePolicy{}, false
+}
+
+// matchImagePattern returns true if the specified image reference matches
+// the pattern, where the `*` wildcard matches any sequence of characters.
+func matchImagePattern(pattern, imageRef string) bool {
+	quoted := strings.Split(pattern, "*")
+	for i := range quoted {
+		quoted[i] = regexp.QuoteMeta(quoted[i])
+	}
+	return regexp.MustCompile("^" + strings.Join(quoted, ".*") + "$").MatchString(imageRef)
+}
@@ -0,0 +1,37 @@
+package imagesignature_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/imagesignature"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPolicy(t *testing.T) {
+	policies := []starboard.ImageSignaturePolicy{
+		{Name: "acme", Images: []string{"ghcr.io/acme/*"}},
+		{Name: "internal", Images: []string{"registry.internal/*", "*.internal.acme.io/*"}},
+	}
+
+	testCases := []struct {
+		imageRef       string
+		expectedPolicy string
+		expectedMatch  bool
+	}{
+		{imageRef: "ghcr.io/acme/app:1.2.0", expectedPolicy: "acme", expectedMatch: true},
+		{imageRef: "ghcr.io/acme/tools/cli@sha256:2cf6a0e91f2b6a6fa5b4e1d7f7b3c1d6e4b5a8c9d0e1f2a3b4c5d6e7f8a9b0c1", expectedPolicy: "acme", expectedMatch: true},
+		{imageRef: "registry.internal/db:13", expectedPolicy: "internal", expectedMatch: true},
+		{imageRef: "eu.internal.acme.io/db:13", expectedPolicy: "internal", expectedMatch: true},
+		{imageRef: "ghcr.io/other/app:1.2.0", expectedMatch: false},
+		{imageRef: "nginx:1.16", expectedMatch: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.imageRef, func(t *testing.T) {
+			policy, ok := imagesignature.MatchPolicy(policies, tc.imageRef)
+			assert.Equal(t, tc.expectedMatch, ok)
+			assert.Equal(t, tc.expectedPolicy, policy.Name)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package junit provides primitives for converting security reports to the
+// JUnit XML format, so that findings are rendered as failed tests by CI
+// servers such as Jenkins and GitLab.
+package junit
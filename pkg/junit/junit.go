@@ -0,0 +1,144 @@
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+)
+
+// TestSuites is the root element of the JUnit XML document. Only the
+// elements and attributes understood by common CI servers are modeled.
+type TestSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr,omitempty"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Suites   []TestSuite `xml:"testsuite"`
+}
+
+type TestSuite struct {
+	Name      string     `xml:"name,attr"`
+	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
+	TestCases []TestCase `xml:"testcase"`
+}
+
+type TestCase struct {
+	ClassName string   `xml:"classname,attr"`
+	Name      string   `xml:"name,attr"`
+	Failure   *Failure `xml:"failure,omitempty"`
+}
+
+type Failure struct {
+	Type    string `xml:"type,attr,omitempty"`
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Write writes the specified TestSuites as indented XML.
+func Write(suites TestSuites, out io.Writer) error {
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
+
+func (s *TestSuite) add(testCase TestCase) {
+	s.Tests++
+	if testCase.Failure != nil {
+		s.Failures++
+	}
+	s.TestCases = append(s.TestCases, testCase)
+}
+
+func newTestSuites(name string, suites []TestSuite) TestSuites {
+	testSuites := TestSuites{Name: name, Suites: suites}
+	for _, suite := range suites {
+		testSuites.Tests += suite.Tests
+		testSuites.Failures += suite.Failures
+	}
+	return testSuites
+}
+
+// FromVulnerabilityReports converts the specified reports to TestSuites with
+// a suite for each container and a failed test case for each vulnerability.
+func FromVulnerabilityReports(reports []v1alpha1.VulnerabilityReport) TestSuites {
+	var suites []TestSuite
+	for _, report := range reports {
+		image := vulnerabilityreport.GetImageRef(report.Report)
+		name := image
+		if container, ok := report.Labels[starboard.LabelContainerName]; ok {
+			name = fmt.Sprintf("%s (%s)", container, image)
+		}
+		suite := TestSuite{Name: name, TestCases: []TestCase{}}
+		for _, vulnerability := range report.Report.Vulnerabilities {
+			message := vulnerability.Title
+			if message == "" {
+				message = vulnerability.VulnerabilityID
+			}
+			suite.add(TestCase{
+				ClassName: fmt.Sprintf("%s@%s", vulnerability.Resource, vulnerability.InstalledVersion),
+				Name:      fmt.Sprintf("[%s] %s", vulnerability.Severity, vulnerability.VulnerabilityID),
+				Failure: &Failure{
+					Type:    string(vulnerability.Severity),
+					Message: message,
+					Text: fmt.Sprintf("Package: %s\nInstalled Version: %s\nFixed Version: %s\nLink: %s",
+						vulnerability.Resource, vulnerability.InstalledVersion, vulnerability.FixedVersion,
+						vulnerability.PrimaryLink),
+				},
+			})
+		}
+		suites = append(suites, suite)
+	}
+	return newTestSuites("vulnerabilities", suites)
+}
+
+// FromConfigAuditReport converts the specified report data of the object with
+// the specified name, e.g. default/deployment/nginx, to TestSuites with a test
+// case for each check. Checks scoped to containers are grouped in a suite for
+// each container and the remaining checks in the suite of the object.
+func FromConfigAuditReport(data v1alpha1.ConfigAuditReportData, name string) TestSuites {
+	suites := []TestSuite{{Name: name, TestCases: []TestCase{}}}
+	indexes := make(map[string]int)
+	for _, check := range data.Checks {
+		index := 0
+		if check.Scope != nil && check.Scope.Type == "Container" {
+			var ok bool
+			index, ok = indexes[check.Scope.Value]
+			if !ok {
+				index = len(suites)
+				indexes[check.Scope.Value] = index
+				suites = append(suites, TestSuite{
+					Name:      fmt.Sprintf("%s (container %s)", name, check.Scope.Value),
+					TestCases: []TestCase{},
+				})
+			}
+		}
+		testCase := TestCase{
+			ClassName: check.Category,
+			Name:      check.ID,
+		}
+		if testCase.ClassName == "" {
+			testCase.ClassName = data.Scanner.Name
+		}
+		if !check.Success {
+			testCase.Failure = &Failure{
+				Type:    check.Severity,
+				Message: check.Message,
+				Text:    check.Remediation,
+			}
+		}
+		suites[index].add(testCase)
+	}
+	return newTestSuites("configuration audit", suites)
+}
@@ -0,0 +1,118 @@
+package junit_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/junit"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFromVulnerabilityReports(t *testing.T) {
+	suites := junit.FromVulnerabilityReports([]v1alpha1.VulnerabilityReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+				starboard.LabelContainerName: "app",
+			}},
+			Report: v1alpha1.VulnerabilityReportData{
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact: v1alpha1.Artifact{Repository: "library/app", Tag: "1.0"},
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{
+						VulnerabilityID:  "CVE-2021-44228",
+						Resource:         "org.apache.logging.log4j:log4j-core",
+						InstalledVersion: "2.14.1",
+						FixedVersion:     "2.15.0",
+						Severity:         v1alpha1.SeverityCritical,
+						Title:            "Remote code injection in Log4j",
+					},
+					{VulnerabilityID: "CVE-2022-0778", Resource: "openssl", InstalledVersion: "1.1.1k", Severity: v1alpha1.SeverityHigh},
+				},
+			},
+		},
+		{
+			Report: v1alpha1.VulnerabilityReportData{
+				Artifact: v1alpha1.Artifact{Repository: "sidecar", Tag: "2.0"},
+			},
+		},
+	})
+
+	assert.Equal(t, 2, suites.Tests)
+	assert.Equal(t, 2, suites.Failures)
+	require.Len(t, suites.Suites, 2)
+	assert.Equal(t, "app (index.docker.io/library/app:1.0)", suites.Suites[0].Name)
+	require.Len(t, suites.Suites[0].TestCases, 2)
+	assert.Equal(t, junit.TestCase{
+		ClassName: "org.apache.logging.log4j:log4j-core@2.14.1",
+		Name:      "[CRITICAL] CVE-2021-44228",
+		Failure: &junit.Failure{
+			Type:    "CRITICAL",
+			Message: "Remote code injection in Log4j",
+			Text:    "Package: org.apache.logging.log4j:log4j-core\nInstalled Version: 2.14.1\nFixed Version: 2.15.0\nLink: ",
+		},
+	}, suites.Suites[0].TestCases[0])
+	assert.Equal(t, "CVE-2022-0778", suites.Suites[0].TestCases[1].Failure.Message)
+	assert.Equal(t, "sidecar:2.0", suites.Suites[1].Name)
+	assert.Equal(t, 0, suites.Suites[1].Tests)
+}
+
+func TestFromConfigAuditReport(t *testing.T) {
+	suites := junit.FromConfigAuditReport(v1alpha1.ConfigAuditReportData{
+		Scanner: v1alpha1.Scanner{Name: "Polaris"},
+		Checks: []v1alpha1.Check{
+			{ID: "hostIPCSet", Success: true, Category: "Security"},
+			{
+				ID:       "runAsRootAllowed",
+				Message:  "Should not be allowed to run as root",
+				Severity: v1alpha1.ConfigAuditSeverityDanger,
+				Category: "Security",
+				Scope:    &v1alpha1.CheckScope{Type: "Container", Value: "nginx"},
+			},
+			{
+				ID:       "cpuLimitsMissing",
+				Message:  "CPU limits should be set",
+				Severity: v1alpha1.ConfigAuditSeverityWarning,
+				Scope:    &v1alpha1.CheckScope{Type: "Container", Value: "nginx"},
+			},
+		},
+	}, "default/deployment/nginx")
+
+	assert.Equal(t, 3, suites.Tests)
+	assert.Equal(t, 2, suites.Failures)
+	require.Len(t, suites.Suites, 2)
+	assert.Equal(t, junit.TestSuite{
+		Name:      "default/deployment/nginx",
+		Tests:     1,
+		TestCases: []junit.TestCase{{ClassName: "Security", Name: "hostIPCSet"}},
+	}, suites.Suites[0])
+	assert.Equal(t, "default/deployment/nginx (container nginx)", suites.Suites[1].Name)
+	assert.Equal(t, 2, suites.Suites[1].Failures)
+	assert.Equal(t, "Polaris", suites.Suites[1].TestCases[1].ClassName)
+	assert.Equal(t, &junit.Failure{
+		Type:    "warning",
+		Message: "CPU limits should be set",
+	}, suites.Suites[1].TestCases[1].Failure)
+}
+
+func TestWrite(t *testing.T) {
+	var out bytes.Buffer
+	err := junit.Write(junit.FromConfigAuditReport(v1alpha1.ConfigAuditReportData{
+		Checks: []v1alpha1.Check{
+			{ID: "runAsRootAllowed", Message: "Should not be allowed to run as root", Severity: "danger", Category: "Security"},
+		},
+	}, "pod/nginx"), &out)
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="configuration audit" tests="1" failures="1">
+  <testsuite name="pod/nginx" tests="1" failures="1">
+    <testcase classname="Security" name="runAsRootAllowed">
+      <failure type="danger" message="Should not be allowed to run as root"></failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, out.String())
+}
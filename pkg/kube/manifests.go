@@ -0,0 +1,88 @@
+package kube
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// ReadManifests decodes objects from YAML or JSON manifests, which may contain
+// many documents. Items of lists, such as v1.List, are decoded as separate
+// objects and empty documents are skipped. Objects of kinds registered in the
+// specified scheme are converted to typed objects, whereas objects of other
+// kinds are returned as unstructured.Unstructured.
+func ReadManifests(scheme *runtime.Scheme, r io.Reader) ([]client.Object, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	var objects []client.Object
+	for {
+		var document map[string]interface{}
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding manifest: %w", err)
+		}
+		if len(document) == 0 {
+			continue
+		}
+		u := &unstructured.Unstructured{Object: document}
+		if !u.IsList() {
+			obj, err := toTypedObject(scheme, u)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, obj)
+			continue
+		}
+		err = u.EachListItem(func(item runtime.Object) error {
+			obj, err := toTypedObject(scheme, item.(*unstructured.Unstructured))
+			if err != nil {
+				return err
+			}
+			objects = append(objects, obj)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func toTypedObject(scheme *runtime.Scheme, u *unstructured.Unstructured) (client.Object, error) {
+	gvk := u.GroupVersionKind()
+	if gvk.Kind == "" {
+		return nil, fmt.Errorf("decoding manifest: object %q has no kind", u.GetName())
+	}
+	typed, err := scheme.New(gvk)
+	if err != nil {
+		return u, nil
+	}
+	obj, ok := typed.(client.Object)
+	if !ok {
+		return u, nil
+	}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s %q: %w", gvk.Kind, u.GetName(), err)
+	}
+	return obj, nil
+}
+
+// BuildKustomization builds the kustomization in the specified directory, in
+// the same way as the kubectl kustomize command, and returns the YAML manifest
+// of built resources.
+func BuildKustomization(dir string) ([]byte, error) {
+	resources, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("building kustomization %s: %w", dir, err)
+	}
+	return resources.AsYaml()
+}
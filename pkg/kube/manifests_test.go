@@ -0,0 +1,115 @@
+package kube_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestReadManifests(t *testing.T) {
+	t.Run("Should read typed and unstructured objects from many documents", func(t *testing.T) {
+		objects, err := kube.ReadManifests(starboard.NewScheme(), strings.NewReader(`---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  namespace: staging
+spec:
+  template:
+    spec:
+      containers:
+        - name: nginx
+          image: nginx:1.16
+---
+# empty document
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: nginx
+`))
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+
+		deployment, ok := objects[0].(*appsv1.Deployment)
+		require.True(t, ok)
+		assert.Equal(t, "staging", deployment.Namespace)
+		assert.Equal(t, "Deployment", deployment.Kind)
+		assert.Equal(t, "nginx:1.16", deployment.Spec.Template.Spec.Containers[0].Image)
+
+		ingress, ok := objects[1].(*unstructured.Unstructured)
+		require.True(t, ok)
+		assert.Equal(t, "Ingress", ingress.GetKind())
+	})
+
+	t.Run("Should read items of lists in JSON", func(t *testing.T) {
+		objects, err := kube.ReadManifests(starboard.NewScheme(), strings.NewReader(`{
+  "apiVersion": "v1",
+  "kind": "List",
+  "items": [
+    {"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "nginx"}},
+    {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "settings"}}
+  ]
+}`))
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+		assert.IsType(t, &corev1.Pod{}, objects[0])
+		assert.IsType(t, &corev1.ConfigMap{}, objects[1])
+	})
+
+	t.Run("Should return error when object has no kind", func(t *testing.T) {
+		_, err := kube.ReadManifests(starboard.NewScheme(), strings.NewReader(`metadata:
+  name: nginx
+`))
+		assert.EqualError(t, err, `decoding manifest: object "nginx" has no kind`)
+	})
+}
+
+func TestBuildKustomization(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"kustomization.yaml": `resources:
+  - deployment.yaml
+namespace: production
+images:
+  - name: nginx
+    newTag: "1.21"
+`,
+		"deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+spec:
+  template:
+    spec:
+      containers:
+        - name: nginx
+          image: nginx:1.16
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	manifest, err := kube.BuildKustomization(dir)
+	require.NoError(t, err)
+	objects, err := kube.ReadManifests(starboard.NewScheme(), strings.NewReader(string(manifest)))
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	deployment, ok := objects[0].(*appsv1.Deployment)
+	require.True(t, ok)
+	assert.Equal(t, "production", deployment.Namespace)
+	assert.Equal(t, "nginx:1.21", deployment.Spec.Template.Spec.Containers[0].Image)
+
+	_, err = kube.BuildKustomization(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
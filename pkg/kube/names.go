@@ -0,0 +1,82 @@
+package kube
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// GetReportName returns the name of a report of the workload of the specified
+// kind, which consists of the lowercase kind and the specified names, e.g. the
+// name of the workload and the name of a container, separated with dashes.
+// Names that are not valid label values, e.g. because they are too long or
+// contain colons, are replaced as specified by the given
+// starboard.ReportNameFormat.
+func GetReportName(format starboard.ReportNameFormat, kind string, names ...string) string {
+	kind = strings.ToLower(kind)
+	joined := strings.Join(names, "-")
+	name := kind + "-" + joined
+	if len(validation.IsValidLabelValue(name)) == 0 {
+		return name
+	}
+	if format == starboard.ReportNameHashed {
+		return kind + "-" + ComputeHash(joined)
+	}
+	return AppendHash(invalidNameChars.ReplaceAllString(name, "-"), joined)
+}
+
+// invalidNameChars matches characters that are not allowed in names of
+// objects, such as colons in names of ClusterRoles.
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]`)
+
+// AppendHash returns the specified name followed by a dash and the hash of the
+// given object. The name is truncated so that the result is not longer than
+// the maximum length of a label value.
+func AppendHash(name string, obj interface{}) string {
+	hash := ComputeHash(obj)
+	if max := validation.LabelValueMaxLength - len(hash) - 1; len(name) > max {
+		name = name[:max]
+	}
+	return strings.TrimRight(name, "-.") + "-" + hash
+}
+
+// reportOwnerLabels are labels that identify the workload and the container
+// of a report.
+var reportOwnerLabels = []string{
+	starboard.LabelResourceKind,
+	starboard.LabelResourceName,
+	starboard.LabelResourceNameHash,
+	starboard.LabelResourceNamespace,
+	starboard.LabelContainerName,
+}
+
+// GetReportOwnerLabels returns labels of the specified report that identify
+// its workload and its container.
+func GetReportOwnerLabels(report metav1.Object) labels.Set {
+	owner := make(labels.Set)
+	for _, key := range reportOwnerLabels {
+		if value, ok := report.GetLabels()[key]; ok {
+			owner[key] = value
+		}
+	}
+	return owner
+}
+
+// IsNameCollision checks whether the specified reports, which have the same
+// name, belong to different workloads or containers. For example, reports of
+// the nginx-app container of the nginx Deployment and of the app container of
+// the nginx-nginx Deployment are both named deployment-nginx-nginx-app.
+func IsNameCollision(existing, report metav1.Object) bool {
+	return !labels.Equals(GetReportOwnerLabels(existing), GetReportOwnerLabels(report))
+}
+
+// GetCollisionFreeName returns the name of the specified report followed by a
+// hash of its workload and container, used instead of the name when it
+// collides with the name of a report of another workload or container.
+func GetCollisionFreeName(report metav1.Object) string {
+	return AppendHash(report.GetName(), GetReportOwnerLabels(report))
+}
@@ -0,0 +1,91 @@
+package kube_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestGetReportName(t *testing.T) {
+	longName := strings.Repeat("a", 60)
+
+	testCases := []struct {
+		name     string
+		format   starboard.ReportNameFormat
+		kind     string
+		names    []string
+		expected string
+	}{
+		{
+			name:     "Should return readable name",
+			format:   starboard.ReportNameReadable,
+			kind:     "ReplicaSet",
+			names:    []string{"nginx-6d4cf56db6", "nginx"},
+			expected: "replicaset-nginx-6d4cf56db6-nginx",
+		},
+		{
+			name:     "Should return readable name in hashed format",
+			format:   starboard.ReportNameHashed,
+			kind:     "ReplicaSet",
+			names:    []string{"nginx-6d4cf56db6", "nginx"},
+			expected: "replicaset-nginx-6d4cf56db6-nginx",
+		},
+		{
+			name:     "Should truncate long name",
+			format:   starboard.ReportNameReadable,
+			kind:     "Deployment",
+			names:    []string{longName, "nginx"},
+			expected: "deployment-" + longName[:41] + "-" + kube.ComputeHash(longName+"-nginx"),
+		},
+		{
+			name:     "Should replace long name with hash in hashed format",
+			format:   starboard.ReportNameHashed,
+			kind:     "Deployment",
+			names:    []string{longName, "nginx"},
+			expected: "deployment-" + kube.ComputeHash(longName+"-nginx"),
+		},
+		{
+			name:     "Should replace invalid characters",
+			format:   starboard.ReportNameReadable,
+			kind:     "ClusterRole",
+			names:    []string{"system:controller:node-controller"},
+			expected: "clusterrole-system-controller-node-controller-6f69bb5b79",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name := kube.GetReportName(tc.format, tc.kind, tc.names...)
+			assert.Equal(t, tc.expected, name)
+			assert.Empty(t, validation.IsDNS1123Subdomain(name))
+			assert.Empty(t, validation.IsValidLabelValue(name))
+		})
+	}
+}
+
+func TestIsNameCollision(t *testing.T) {
+	report := func(name, container string) *metav1.ObjectMeta {
+		return &metav1.ObjectMeta{
+			Name: "deployment-nginx-nginx-app",
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      "Deployment",
+				starboard.LabelResourceName:      name,
+				starboard.LabelResourceNamespace: "default",
+				starboard.LabelContainerName:     container,
+				starboard.LabelResourceSpecHash:  name,
+			},
+		}
+	}
+
+	assert.False(t, kube.IsNameCollision(report("nginx", "nginx-app"), report("nginx", "nginx-app")))
+	assert.True(t, kube.IsNameCollision(report("nginx", "nginx-app"), report("nginx-nginx", "app")))
+
+	name := kube.GetCollisionFreeName(report("nginx-nginx", "app"))
+	assert.True(t, strings.HasPrefix(name, "deployment-nginx-nginx-app-"))
+	assert.NotEqual(t, name, kube.GetCollisionFreeName(report("nginx", "nginx-app")))
+}
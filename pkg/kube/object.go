@@ -252,6 +252,35 @@ func GetPodSpec(obj client.Object) (corev1.PodSpec, error) {
 	}
 }
 
+// GetPodTemplateLabels returns labels of pods of the specified Kubernetes
+// workload, i.e. labels of its pod template or of the pod itself. Returns
+// error if the given client.Object is not a Kubernetes workload.
+func GetPodTemplateLabels(obj client.Object) (map[string]string, error) {
+	switch t := obj.(type) {
+	case *corev1.Pod:
+		return t.Labels, nil
+	case *appsv1.Deployment:
+		return t.Spec.Template.Labels, nil
+	case *appsv1.ReplicaSet:
+		return t.Spec.Template.Labels, nil
+	case *corev1.ReplicationController:
+		if t.Spec.Template == nil {
+			return nil, nil
+		}
+		return t.Spec.Template.Labels, nil
+	case *appsv1.StatefulSet:
+		return t.Spec.Template.Labels, nil
+	case *appsv1.DaemonSet:
+		return t.Spec.Template.Labels, nil
+	case *batchv1beta1.CronJob:
+		return t.Spec.JobTemplate.Spec.Template.Labels, nil
+	case *batchv1.Job:
+		return t.Spec.Template.Labels, nil
+	default:
+		return nil, fmt.Errorf("unsupported workload: %T", t)
+	}
+}
+
 type ObjectResolver struct {
 	client.Client
 }
@@ -277,6 +306,8 @@ func (o *ObjectResolver) ObjectFromObjectRef(ctx context.Context, workload Objec
 		obj = &batchv1.Job{}
 	case KindService:
 		obj = &corev1.Service{}
+	case KindNode:
+		obj = &corev1.Node{}
 	case KindConfigMap:
 		obj = &corev1.ConfigMap{}
 	case KindRole:
@@ -424,6 +455,49 @@ func (o *ObjectResolver) JobByPod(ctx context.Context, pod *corev1.Pod) (*batchv
 	return obj.(*batchv1.Job), err
 }
 
+// ListWorkloads returns workloads in the specified namespace, or all namespaces
+// if the namespace is empty, which are not controlled by other built-in
+// workloads. Workloads controlled by other workloads, such as ReplicaSets of
+// Deployments, are skipped, because they're resolved from their controllers.
+func (o *ObjectResolver) ListWorkloads(ctx context.Context, namespace string) ([]ObjectRef, error) {
+	lists := []struct {
+		kind Kind
+		list client.ObjectList
+	}{
+		{kind: KindDeployment, list: &appsv1.DeploymentList{}},
+		{kind: KindStatefulSet, list: &appsv1.StatefulSetList{}},
+		{kind: KindDaemonSet, list: &appsv1.DaemonSetList{}},
+		{kind: KindReplicaSet, list: &appsv1.ReplicaSetList{}},
+		{kind: KindReplicationController, list: &corev1.ReplicationControllerList{}},
+		{kind: KindCronJob, list: &batchv1beta1.CronJobList{}},
+		{kind: KindJob, list: &batchv1.JobList{}},
+		{kind: KindPod, list: &corev1.PodList{}},
+	}
+	var workloads []ObjectRef
+	for _, l := range lists {
+		err := o.Client.List(ctx, l.list, client.InNamespace(namespace))
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", l.kind, err)
+		}
+		err = meta.EachListItem(l.list, func(item runtime.Object) error {
+			obj, ok := item.(client.Object)
+			if !ok {
+				return fmt.Errorf("unexpected object: %T", item)
+			}
+			if controller := metav1.GetControllerOf(obj); IsBuiltInWorkload(controller) ||
+				controller != nil && (controller.Kind == string(KindDeployment) || controller.Kind == string(KindCronJob)) {
+				return nil
+			}
+			workloads = append(workloads, ObjectRef{Kind: l.kind, Name: obj.GetName(), Namespace: obj.GetNamespace()})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return workloads, nil
+}
+
 func (o *ObjectResolver) ensureGVK(obj client.Object) (client.Object, error) {
 	gvk, err := apiutil.GVKForObject(obj, o.Client.Scheme())
 	if err != nil {
@@ -586,3 +660,70 @@ func (o *ObjectResolver) getActivePodsByLabelSelector(ctx context.Context, names
 	}
 	return pods, nil
 }
+
+// GetContainerImageDigests returns the mapping between container names and
+// digests of images run by pods of the specified workload. Digests are
+// resolved from statuses of running containers, therefore a container is
+// omitted if none of the pods has reported the digest of its image yet.
+// If there are no running pods ErrNoRunningPods is returned.
+func (o *ObjectResolver) GetContainerImageDigests(ctx context.Context, obj client.Object) (ContainerImages, error) {
+	var pods []corev1.Pod
+	var err error
+	switch w := obj.(type) {
+	case *corev1.Pod:
+		pods = []corev1.Pod{*w}
+	case *appsv1.Deployment:
+		replicaSet, err := o.ReplicaSetByDeployment(ctx, w)
+		if err != nil {
+			return nil, err
+		}
+		pods, err = o.getActivePodsByLabelSelector(ctx, w.Namespace, replicaSet.Spec.Selector.MatchLabels)
+	case *appsv1.ReplicaSet:
+		pods, err = o.getActivePodsByLabelSelector(ctx, w.Namespace, w.Spec.Selector.MatchLabels)
+	case *corev1.ReplicationController:
+		pods, err = o.getActivePodsByLabelSelector(ctx, w.Namespace, w.Spec.Selector)
+	case *appsv1.StatefulSet:
+		pods, err = o.getActivePodsByLabelSelector(ctx, w.Namespace, w.Spec.Selector.MatchLabels)
+	case *appsv1.DaemonSet:
+		pods, err = o.getActivePodsByLabelSelector(ctx, w.Namespace, w.Spec.Selector.MatchLabels)
+	case *batchv1.Job:
+		pods, err = o.getActivePodsByLabelSelector(ctx, w.Namespace, w.Spec.Selector.MatchLabels)
+	default:
+		return nil, ErrUnSupportedKind
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	digests := ContainerImages{}
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if _, ok := digests[status.Name]; ok {
+				continue
+			}
+			if digest := GetImageDigestFromImageID(status.ImageID); digest != "" {
+				digests[status.Name] = digest
+			}
+		}
+	}
+	return digests, nil
+}
+
+// GetImageDigestFromImageID returns the digest, e.g. sha256:4ed1..., from
+// the image ID reported by a container runtime in the status of a container.
+// Depending on the runtime the image ID is either the repository digest,
+// e.g. docker-pullable://nginx@sha256:4ed1..., or the ID of the image
+// config, e.g. docker://sha256:2b6a.... An empty string is returned if the
+// image ID does not contain a digest.
+func GetImageDigestFromImageID(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		imageID = imageID[i+1:]
+	}
+	imageID = strings.TrimPrefix(imageID, "docker://")
+	for _, algorithm := range []string{"sha256:", "sha512:"} {
+		if strings.HasPrefix(imageID, algorithm) && len(imageID) > len(algorithm) {
+			return imageID
+		}
+	}
+	return ""
+}
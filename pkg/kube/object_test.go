@@ -1138,3 +1138,138 @@ func TestObjectResolver_IsActiveReplicaSet(t *testing.T) {
 		})
 	}
 }
+
+func TestGetImageDigestFromImageID(t *testing.T) {
+	testCases := []struct {
+		imageID        string
+		expectedDigest string
+	}{
+		{
+			imageID:        "docker-pullable://nginx@sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+			expectedDigest: "sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+		},
+		{
+			imageID:        "docker.io/library/nginx@sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+			expectedDigest: "sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+		},
+		{
+			imageID:        "docker://sha256:605c77e624ddb75e6110f997c58876baa13f8754486b461117934b24a9dc3a85",
+			expectedDigest: "sha256:605c77e624ddb75e6110f997c58876baa13f8754486b461117934b24a9dc3a85",
+		},
+		{
+			imageID:        "sha256:605c77e624ddb75e6110f997c58876baa13f8754486b461117934b24a9dc3a85",
+			expectedDigest: "sha256:605c77e624ddb75e6110f997c58876baa13f8754486b461117934b24a9dc3a85",
+		},
+		{
+			imageID:        "",
+			expectedDigest: "",
+		},
+		{
+			imageID:        "nginx:1.16",
+			expectedDigest: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.imageID, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDigest, kube.GetImageDigestFromImageID(tc.imageID))
+		})
+	}
+}
+
+func TestObjectResolver_GetContainerImageDigests(t *testing.T) {
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "nginx-6d4cf56db6",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app":               "nginx",
+					"pod-template-hash": "6d4cf56db6",
+				},
+			},
+		},
+	}
+
+	t.Run("Should return digests of images run by active pods", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: corev1.NamespaceDefault,
+					Name:      "nginx-6d4cf56db6-s7rpv",
+					Labels: map[string]string{
+						"app":               "nginx",
+						"pod-template-hash": "6d4cf56db6",
+					},
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name:    "nginx",
+							ImageID: "docker-pullable://nginx@sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+						},
+						{
+							Name:    "sidecar",
+							ImageID: "",
+						},
+					},
+				},
+			},
+		).Build()
+		resolver := kube.ObjectResolver{Client: client}
+
+		digests, err := resolver.GetContainerImageDigests(context.TODO(), replicaSet)
+		require.NoError(t, err)
+		assert.Equal(t, kube.ContainerImages{
+			"nginx": "sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+		}, digests)
+	})
+
+	t.Run("Should return error when there are no active pods", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+		resolver := kube.ObjectResolver{Client: client}
+
+		_, err := resolver.GetContainerImageDigests(context.TODO(), replicaSet)
+		assert.ErrorIs(t, err, kube.ErrNoRunningPods)
+	})
+}
+
+func TestObjectResolver_ListWorkloads(t *testing.T) {
+	controlledBy := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: pointer.BoolPtr(true)}}
+	}
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-6d4cf56db6",
+			OwnerReferences: controlledBy("Deployment", "nginx")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-6d4cf56db6-s7rpv",
+			OwnerReferences: controlledBy("ReplicaSet", "nginx-6d4cf56db6")}},
+		&batchv1beta1.CronJob{ObjectMeta: metav1.ObjectMeta{Namespace: "batch", Name: "backup"}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "batch", Name: "backup-27301",
+			OwnerReferences: controlledBy("CronJob", "backup")}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "batch", Name: "workflow-step",
+			OwnerReferences: controlledBy("Workflow", "workflow")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "etcd"}},
+	).Build()
+	resolver := kube.ObjectResolver{Client: client}
+
+	t.Run("Should return workloads in all namespaces", func(t *testing.T) {
+		workloads, err := resolver.ListWorkloads(context.TODO(), "")
+		require.NoError(t, err)
+		assert.Equal(t, []kube.ObjectRef{
+			{Kind: kube.KindDeployment, Name: "nginx", Namespace: "default"},
+			{Kind: kube.KindCronJob, Name: "backup", Namespace: "batch"},
+			{Kind: kube.KindJob, Name: "workflow-step", Namespace: "batch"},
+			{Kind: kube.KindPod, Name: "etcd", Namespace: "kube-system"},
+		}, workloads)
+	})
+
+	t.Run("Should return workloads in the specified namespace", func(t *testing.T) {
+		workloads, err := resolver.ListWorkloads(context.TODO(), "kube-system")
+		require.NoError(t, err)
+		assert.Equal(t, []kube.ObjectRef{
+			{Kind: kube.KindPod, Name: "etcd", Namespace: "kube-system"},
+		}, workloads)
+	})
+}
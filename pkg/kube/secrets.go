@@ -2,11 +2,16 @@ package kube
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -118,14 +123,53 @@ type SecretsReader interface {
 // NewSecretsReader constructs a new SecretsReader which is using the client
 // package provided by the controller-runtime libraries for interacting with
 // the Kubernetes API server.
-func NewSecretsReader(client client.Client) SecretsReader {
-	return &secretsReader{client: client}
+//
+// The specified docker.CredentialsProvider instances are consulted, in order,
+// for container images whose credentials cannot be found in image pull Secrets.
+func NewSecretsReader(client client.Client, providers ...docker.CredentialsProvider) SecretsReader {
+	return &secretsReader{
+		client:    client,
+		providers: providers,
+	}
+}
+
+// NewCredentialsProviders constructs docker.CredentialsProvider instances
+// enabled with the specified starboard.ConfigData.
+//
+// If the cluster-wide registry credentials Secret is configured, the provider
+// backed by that Secret, which is looked up in the specified namespace, comes
+// first so that credentials managed by the platform team take precedence over
+// cloud provider identities.
+func NewCredentialsProviders(client client.Client, namespace string, config starboard.ConfigData) ([]docker.CredentialsProvider, error) {
+	names, err := config.GetScanJobCredentialProviders()
+	if err != nil {
+		return nil, err
+	}
+	var providers []docker.CredentialsProvider
+	if secretName := config.GetScanJobRegistryCredentialsSecret(); secretName != "" {
+		providers = append(providers, NewSecretCredentialsProvider(client, namespace, secretName))
+	}
+	for _, name := range names {
+		switch name {
+		case starboard.ECR:
+			providers = append(providers, docker.NewECRCredentialsProvider(ext.NewSystemClock()))
+		case starboard.GCR:
+			providers = append(providers, docker.NewGCRCredentialsProvider(ext.NewSystemClock()))
+		case starboard.ACR:
+			providers = append(providers, docker.NewACRCredentialsProvider(ext.NewSystemClock()))
+		}
+	}
+	return providers, nil
 }
 
 type secretsReader struct {
-	client client.Client
+	client    client.Client
+	providers []docker.CredentialsProvider
 }
 
+// ListByLocalObjectReferences returns Secrets referenced by the specified
+// local object references. Secrets that do not exist are skipped, the same
+// way kubelet ignores missing image pull Secrets.
 func (r *secretsReader) ListByLocalObjectReferences(ctx context.Context, refs []corev1.LocalObjectReference, ns string) ([]corev1.Secret, error) {
 	secrets := make([]corev1.Secret, 0)
 
@@ -133,6 +177,9 @@ func (r *secretsReader) ListByLocalObjectReferences(ctx context.Context, refs []
 		var secret corev1.Secret
 		err := r.client.Get(ctx, client.ObjectKey{Name: secretRef.Name, Namespace: ns}, &secret)
 		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
 			return nil, fmt.Errorf("getting secret by name: %s/%s: %w", ns, secretRef.Name, err)
 		}
 		secrets = append(secrets, secret)
@@ -141,34 +188,59 @@ func (r *secretsReader) ListByLocalObjectReferences(ctx context.Context, refs []
 	return secrets, nil
 }
 
+// ListByServiceAccount returns image pull Secrets of the specified service
+// account. It returns an empty slice if the service account does not exist.
 func (r *secretsReader) ListByServiceAccount(ctx context.Context, name string, ns string) ([]corev1.Secret, error) {
 	var sa corev1.ServiceAccount
 
 	err := r.client.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, &sa)
 	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return []corev1.Secret{}, nil
+		}
 		return nil, fmt.Errorf("getting service account by name: %s/%s: %w", ns, name, err)
 	}
 
 	return r.ListByLocalObjectReferences(ctx, sa.ImagePullSecrets, ns)
 }
 
+// ListImagePullSecretsByPodSpec returns image pull Secrets listed in the
+// specified PodSpec and image pull Secrets of the Pod's service account. If
+// the Pod runs with a non-default service account, image pull Secrets of the
+// namespace's default service account are returned as well.
+//
+// Secrets are ordered from the lowest to the highest precedence, i.e. the
+// default service account first and the PodSpec last, so that
+// MapDockerRegistryServersToAuths picks credentials closest to the workload
+// for a registry server referenced by multiple Secrets.
 func (r *secretsReader) ListImagePullSecretsByPodSpec(ctx context.Context, spec corev1.PodSpec, ns string) ([]corev1.Secret, error) {
-	secrets, err := r.ListByLocalObjectReferences(ctx, spec.ImagePullSecrets, ns)
-	if err != nil {
-		return nil, err
-	}
+	var secrets []corev1.Secret
 
 	serviceAccountName := spec.ServiceAccountName
 	if serviceAccountName == "" {
 		serviceAccountName = serviceAccountDefault
 	}
 
+	if serviceAccountName != serviceAccountDefault {
+		defaultSecrets, err := r.ListByServiceAccount(ctx, serviceAccountDefault, ns)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, defaultSecrets...)
+	}
+
 	serviceAccountSecrets, err := r.ListByServiceAccount(ctx, serviceAccountName, ns)
 	if err != nil {
 		return nil, err
 	}
+	secrets = append(secrets, serviceAccountSecrets...)
+
+	podSecrets, err := r.ListByLocalObjectReferences(ctx, spec.ImagePullSecrets, ns)
+	if err != nil {
+		return nil, err
+	}
 
-	return append(secrets, serviceAccountSecrets...), nil
+	return append(secrets, podSecrets...), nil
 }
 
 func (r *secretsReader) CredentialsByWorkload(ctx context.Context, workload client.Object) (map[string]docker.Auth, error) {
@@ -180,5 +252,87 @@ func (r *secretsReader) CredentialsByWorkload(ctx context.Context, workload clie
 	if err != nil {
 		return nil, err
 	}
-	return MapContainerNamesToDockerAuths(GetContainerImagesFromPodSpec(spec), imagePullSecrets)
+	images := GetContainerImagesFromPodSpec(spec)
+	credentials, err := MapContainerNamesToDockerAuths(images, imagePullSecrets)
+	if err != nil {
+		return nil, err
+	}
+	err = r.fillCredentialsFromProviders(ctx, images, credentials)
+	if err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+// fillCredentialsFromProviders adds credentials issued by the configured
+// docker.CredentialsProvider instances for containers that do not have
+// credentials resolved from image pull Secrets.
+func (r *secretsReader) fillCredentialsFromProviders(ctx context.Context, images ContainerImages, credentials map[string]docker.Auth) error {
+	if len(r.providers) == 0 {
+		return nil
+	}
+	for containerName, imageRef := range images {
+		if _, ok := credentials[containerName]; ok {
+			continue
+		}
+		server, err := docker.GetServerFromImageRef(imageRef)
+		if err != nil {
+			return err
+		}
+		for _, provider := range r.providers {
+			if !provider.Accepts(server) {
+				continue
+			}
+			auth, err := provider.GetAuth(ctx, server)
+			if errors.Is(err, docker.ErrNoCredentials) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("getting credentials for registry %s: %w", server, err)
+			}
+			credentials[containerName] = auth
+			break
+		}
+	}
+	return nil
+}
+
+type secretCredentialsProvider struct {
+	client client.Client
+	key    client.ObjectKey
+}
+
+// NewSecretCredentialsProvider constructs a docker.CredentialsProvider that
+// reads registry credentials from the specified Secret of type
+// kubernetes.io/dockerconfigjson. The Secret is read on each call so that
+// rotated credentials are picked up without restarting Starboard.
+func NewSecretCredentialsProvider(client client.Client, namespace, name string) docker.CredentialsProvider {
+	return &secretCredentialsProvider{
+		client: client,
+		key:    types.NamespacedName{Namespace: namespace, Name: name},
+	}
+}
+
+func (p *secretCredentialsProvider) Accepts(_ string) bool {
+	return true
+}
+
+func (p *secretCredentialsProvider) GetAuth(ctx context.Context, server string) (docker.Auth, error) {
+	var secret corev1.Secret
+	err := p.client.Get(ctx, p.key, &secret)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return docker.Auth{}, docker.ErrNoCredentials
+		}
+		return docker.Auth{}, fmt.Errorf("getting registry credentials secret: %s: %w", p.key, err)
+	}
+	auths, err := MapDockerRegistryServersToAuths([]corev1.Secret{secret})
+	if err != nil {
+		return docker.Auth{}, err
+	}
+	auth, ok := auths[server]
+	if !ok {
+		return docker.Auth{}, docker.ErrNoCredentials
+	}
+	return auth, nil
 }
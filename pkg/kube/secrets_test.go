@@ -1,6 +1,7 @@
 package kube_test
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -8,8 +9,10 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/docker"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestNewImagePullSecret(t *testing.T) {
@@ -175,3 +178,185 @@ func TestMapContainerNamesToDockerAuths(t *testing.T) {
 		}))
 	})
 }
+
+type staticCredentialsProvider struct {
+	server string
+	auth   docker.Auth
+}
+
+func (p *staticCredentialsProvider) Accepts(server string) bool {
+	return server == p.server
+}
+
+func (p *staticCredentialsProvider) GetAuth(_ context.Context, _ string) (docker.Auth, error) {
+	return p.auth, nil
+}
+
+func TestSecretsReader_CredentialsByWorkload(t *testing.T) {
+	t.Run("should fall back to credentials providers for images without image pull secrets", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+			},
+		).Build()
+
+		reader := kube.NewSecretsReader(testClient, &staticCredentialsProvider{
+			server: "123456789012.dkr.ecr.us-east-1.amazonaws.com",
+			auth:   docker.Auth{Username: "AWS", Password: "t0ken"},
+		})
+
+		credentials, err := reader.CredentialsByWorkload(context.TODO(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-pod", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "backend", Image: "123456789012.dkr.ecr.us-east-1.amazonaws.com/backend:1.0"},
+					{Name: "sidecar", Image: "quay.io/my-company/sidecar:2.0"},
+				},
+			},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(credentials).To(MatchAllKeys(Keys{
+			"backend": Equal(docker.Auth{Username: "AWS", Password: "t0ken"}),
+		}))
+	})
+
+	t.Run("should fall back to cluster-wide registry credentials secret", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		registrySecret, err := kube.NewImagePullSecret(metav1.ObjectMeta{
+			Name:      "starboard-registry-credentials",
+			Namespace: "starboard",
+		}, "quay.io", "platform", "s3cret")
+		g.Expect(err).ToNot(HaveOccurred())
+		workloadSecret, err := kube.NewImagePullSecret(metav1.ObjectMeta{
+			Name:      "private-registry",
+			Namespace: "default",
+		}, "quay.io", "team", "t0ken")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+			},
+			registrySecret,
+			workloadSecret,
+		).Build()
+
+		providers, err := kube.NewCredentialsProviders(testClient, "starboard", starboard.ConfigData{
+			"scanJob.registryCredentialsSecret": "starboard-registry-credentials",
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		reader := kube.NewSecretsReader(testClient, providers...)
+
+		credentials, err := reader.CredentialsByWorkload(context.TODO(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-pod", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "backend", Image: "quay.io/my-company/backend:1.0"},
+					{Name: "public", Image: "nginx:1.16"},
+				},
+			},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(credentials).To(MatchAllKeys(Keys{
+			"backend": MatchFields(IgnoreExtras, Fields{
+				"Username": Equal("platform"),
+				"Password": Equal("s3cret"),
+			}),
+		}))
+
+		credentials, err = reader.CredentialsByWorkload(context.TODO(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-pod", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "private-registry"}},
+				Containers: []corev1.Container{
+					{Name: "backend", Image: "quay.io/my-company/backend:1.0"},
+				},
+			},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(credentials["backend"].Username).To(Equal("team"))
+	})
+
+	t.Run("should ignore missing cluster-wide registry credentials secret", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+		providers, err := kube.NewCredentialsProviders(testClient, "starboard", starboard.ConfigData{
+			"scanJob.registryCredentialsSecret": "starboard-registry-credentials",
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+
+		credentials, err := kube.NewSecretsReader(testClient, providers...).CredentialsByWorkload(context.TODO(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-pod", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "backend", Image: "quay.io/my-company/backend:1.0"},
+				},
+			},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(credentials).To(BeEmpty())
+	})
+}
+
+func TestSecretsReader_ListImagePullSecretsByPodSpec(t *testing.T) {
+	newSecret := func(name string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+		}
+	}
+
+	t.Run("should return secrets of named and default service accounts", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			&corev1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Name: "default", Namespace: "default"},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "default-registry"}},
+			},
+			&corev1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Name: "backend", Namespace: "default"},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "backend-registry"}},
+			},
+			newSecret("default-registry"),
+			newSecret("backend-registry"),
+			newSecret("pod-registry"),
+		).Build()
+
+		secrets, err := kube.NewSecretsReader(testClient).ListImagePullSecretsByPodSpec(context.TODO(), corev1.PodSpec{
+			ServiceAccountName: "backend",
+			ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "pod-registry"}},
+		}, "default")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		var names []string
+		for _, secret := range secrets {
+			names = append(names, secret.Name)
+		}
+		g.Expect(names).To(Equal([]string{"default-registry", "backend-registry", "pod-registry"}))
+	})
+
+	t.Run("should skip missing service accounts and secrets", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			&corev1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Name: "default", Namespace: "default"},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "deleted-registry"}},
+			},
+			newSecret("pod-registry"),
+		).Build()
+
+		secrets, err := kube.NewSecretsReader(testClient).ListImagePullSecretsByPodSpec(context.TODO(), corev1.PodSpec{
+			ServiceAccountName: "not-found",
+			ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "pod-registry"}},
+		}, "default")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(secrets).To(HaveLen(1))
+		g.Expect(secrets[0].Name).To(Equal("pod-registry"))
+	})
+}
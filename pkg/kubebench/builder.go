@@ -22,6 +22,7 @@ type Builder struct {
 	scheme     *runtime.Scheme
 	controller metav1.Object
 	data       v1alpha1.CISKubeBenchReportData
+	labels     map[string]string
 }
 
 func (b *Builder) Controller(controller metav1.Object) *Builder {
@@ -34,6 +35,13 @@ func (b *Builder) Data(data v1alpha1.CISKubeBenchReportData) *Builder {
 	return b
 }
 
+// Labels sets additional labels of the report, such as the labels that
+// identify the cluster. Labels set by the builder take precedence.
+func (b *Builder) Labels(labels map[string]string) *Builder {
+	b.labels = labels
+	return b
+}
+
 func (b *Builder) reportName() string {
 	return b.controller.GetName()
 }
@@ -44,10 +52,12 @@ func (b *Builder) Get() (v1alpha1.CISKubeBenchReport, error) {
 		return v1alpha1.CISKubeBenchReport{}, fmt.Errorf("getting kind for object: %w", err)
 	}
 
-	labels := map[string]string{
-		starboard.LabelResourceKind: kind,
-		starboard.LabelResourceName: b.controller.GetName(),
+	labels := make(map[string]string)
+	for key, value := range b.labels {
+		labels[key] = value
 	}
+	labels[starboard.LabelResourceKind] = kind
+	labels[starboard.LabelResourceName] = b.controller.GetName()
 
 	reportName := b.reportName()
 
@@ -22,6 +22,10 @@ func TestBuilder(t *testing.T) {
 				Name: "control-plane",
 			},
 		}).
+		Labels(map[string]string{
+			starboard.LabelClusterName:  "eu-west-1-prod",
+			starboard.LabelResourceName: "overridden",
+		}).
 		Data(v1alpha1.CISKubeBenchReportData{}).Get()
 
 	g.Expect(err).ToNot(gomega.HaveOccurred())
@@ -38,6 +42,7 @@ func TestBuilder(t *testing.T) {
 				},
 			},
 			Labels: map[string]string{
+				starboard.LabelClusterName:  "eu-west-1-prod",
 				starboard.LabelResourceKind: "Node",
 				starboard.LabelResourceName: "control-plane",
 			},
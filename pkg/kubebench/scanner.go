@@ -99,6 +99,11 @@ func (s *Scanner) Scan(ctx context.Context, node corev1.Node) (v1alpha1.CISKubeB
 	if err != nil {
 		return v1alpha1.CISKubeBenchReport{}, fmt.Errorf("building report: %w", err)
 	}
+	commonMetadata, err := s.config.GetCommonMetadata()
+	if err != nil {
+		return v1alpha1.CISKubeBenchReport{}, err
+	}
+	commonMetadata.ApplyTo(&report.ObjectMeta)
 
 	return report, nil
 }
@@ -115,6 +120,18 @@ func (s *Scanner) prepareKubeBenchJob(node corev1.Node) (*batchv1.Job, error) {
 	}
 	templateSpec.Tolerations = append(templateSpec.Tolerations, scanJobTolerations...)
 
+	scanJobScheduling, err := s.config.GetScanJobScheduling()
+	if err != nil {
+		return nil, err
+	}
+	scanJobScheduling.ApplyTo(&templateSpec)
+
+	scanJobImages, err := s.config.GetScanJobImages()
+	if err != nil {
+		return nil, err
+	}
+	scanJobImages.ApplyTo(&templateSpec)
+
 	scanJobAnnotations, err := s.config.GetScanJobAnnotations()
 	if err != nil {
 		return nil, err
@@ -125,6 +142,11 @@ func (s *Scanner) prepareKubeBenchJob(node corev1.Node) (*batchv1.Job, error) {
 		return nil, err
 	}
 
+	commonMetadata, err := s.config.GetCommonMetadata()
+	if err != nil {
+		return nil, err
+	}
+
 	labelsSet := labels.Set{
 		starboard.LabelResourceKind: string(kube.KindNode),
 		starboard.LabelResourceName: node.Name,
@@ -138,7 +160,7 @@ func (s *Scanner) prepareKubeBenchJob(node corev1.Node) (*batchv1.Job, error) {
 		podTemplateLabelsSet[index] = element
 	}
 
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "scan-cisbenchmark-" + kube.ComputeHash(node.Name),
 			Namespace: starboard.NamespaceName,
@@ -156,7 +178,9 @@ func (s *Scanner) prepareKubeBenchJob(node corev1.Node) (*batchv1.Job, error) {
 				Spec: templateSpec,
 			},
 		},
-	}, nil
+	}
+	commonMetadata.ApplyToJob(job)
+	return job, nil
 }
 
 const (
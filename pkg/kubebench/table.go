@@ -0,0 +1,76 @@
+package kubebench
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// Statuses of results of CIS Kubernetes Benchmark checks.
+const (
+	StatusPass = "PASS"
+	StatusFail = "FAIL"
+	StatusWarn = "WARN"
+	StatusInfo = "INFO"
+)
+
+// Statuses are all statuses of results in the order of severity.
+var Statuses = []string{StatusFail, StatusWarn, StatusInfo, StatusPass}
+
+// WriteTable writes the summary of each of the specified reports as a row of
+// the table with counts of results by status. The age of reports is relative
+// to the specified time.
+func WriteTable(reports []v1alpha1.CISKubeBenchReport, now time.Time, out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "NODE\tFAIL\tWARN\tINFO\tPASS\tAGE"); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		summary := report.Report.Summary
+		age := "<unknown>"
+		if updated := report.Report.UpdateTimestamp; !updated.IsZero() {
+			age = duration.HumanDuration(now.Sub(updated.Time))
+		}
+		_, err := fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\n", report.Name, summary.FailCount, summary.WarnCount,
+			summary.InfoCount, summary.PassCount, age)
+		if err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// WriteResultsTable writes results of checks with the specified statuses in
+// the specified reports as rows of the table.
+func WriteResultsTable(reports []v1alpha1.CISKubeBenchReport, statuses []string, out io.Writer) error {
+	include := make(map[string]bool)
+	for _, status := range statuses {
+		include[strings.ToUpper(status)] = true
+	}
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "NODE\tID\tSTATUS\tSCORED\tDESCRIPTION"); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		for _, section := range report.Report.Sections {
+			for _, test := range section.Tests {
+				for _, result := range test.Results {
+					if !include[result.Status] {
+						continue
+					}
+					_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", report.Name, result.TestNumber, result.Status,
+						result.Scored, result.TestDesc)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return w.Flush()
+}
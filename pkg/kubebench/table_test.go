@@ -0,0 +1,62 @@
+package kubebench_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var tableReports = []v1alpha1.CISKubeBenchReport{
+	{
+		ObjectMeta: metav1.ObjectMeta{Name: "kind-control-plane"},
+		Report: v1alpha1.CISKubeBenchReportData{
+			UpdateTimestamp: metav1.NewTime(time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)),
+			Summary:         v1alpha1.CISKubeBenchSummary{FailCount: 12, WarnCount: 40, InfoCount: 0, PassCount: 70},
+			Sections: []v1alpha1.CISKubeBenchSection{
+				{
+					Tests: []v1alpha1.CISKubeBenchTests{
+						{
+							Results: []v1alpha1.CISKubeBenchResult{
+								{TestNumber: "1.1.1", TestDesc: "Ensure that the API server pod specification file permissions are set", Status: "PASS", Scored: true},
+								{TestNumber: "1.1.12", TestDesc: "Ensure that the etcd data directory ownership is set to etcd:etcd", Status: "FAIL", Scored: true},
+								{TestNumber: "1.2.1", TestDesc: "Ensure that the --anonymous-auth argument is set to false", Status: "WARN", Scored: false},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		ObjectMeta: metav1.ObjectMeta{Name: "kind-worker"},
+		Report: v1alpha1.CISKubeBenchReportData{
+			Summary: v1alpha1.CISKubeBenchSummary{FailCount: 2, WarnCount: 13, PassCount: 11},
+		},
+	},
+}
+
+func TestWriteTable(t *testing.T) {
+	var out bytes.Buffer
+	err := kubebench.WriteTable(tableReports, time.Date(2022, 3, 3, 10, 0, 0, 0, time.UTC), &out)
+	require.NoError(t, err)
+	assert.Equal(t, `NODE                FAIL  WARN  INFO  PASS  AGE
+kind-control-plane  12    40    0     70    2d
+kind-worker         2     13    0     11    <unknown>
+`, out.String())
+}
+
+func TestWriteResultsTable(t *testing.T) {
+	var out bytes.Buffer
+	err := kubebench.WriteResultsTable(tableReports, []string{"fail", "WARN"}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, `NODE                ID      STATUS  SCORED  DESCRIPTION
+kind-control-plane  1.1.12  FAIL    true    Ensure that the etcd data directory ownership is set to etcd:etcd
+kind-control-plane  1.2.1   WARN    false   Ensure that the --anonymous-auth argument is set to false
+`, out.String())
+}
@@ -122,6 +122,21 @@ func (s *Scanner) prepareKubeHunterJob() (*batchv1.Job, error) {
 		return nil, err
 	}
 
+	scanJobScheduling, err := s.config.GetScanJobScheduling()
+	if err != nil {
+		return nil, err
+	}
+
+	scanJobImages, err := s.config.GetScanJobImages()
+	if err != nil {
+		return nil, err
+	}
+
+	commonMetadata, err := s.config.GetCommonMetadata()
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		podSecurityContext       *corev1.PodSecurityContext
 		containerSecurityContext *corev1.SecurityContext
@@ -149,7 +164,7 @@ func (s *Scanner) prepareKubeHunterJob() (*batchv1.Job, error) {
 		}
 	}
 
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("scan-kubehunterreports-%s", kube.ComputeHash("cluster")),
 			Namespace: starboard.NamespaceName,
@@ -193,7 +208,11 @@ func (s *Scanner) prepareKubeHunterJob() (*batchv1.Job, error) {
 				},
 			},
 		},
-	}, nil
+	}
+	scanJobScheduling.ApplyTo(&job.Spec.Template.Spec)
+	scanJobImages.ApplyTo(&job.Spec.Template.Spec)
+	commonMetadata.ApplyToJob(job)
+	return job, nil
 }
 
 func isAtLeast(ver string, targetVer string) bool {
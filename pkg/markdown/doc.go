@@ -0,0 +1,4 @@
+// Package markdown provides primitives for converting security reports to
+// compact summaries in the GitHub Flavored Markdown format, e.g. to post
+// findings as comments on pull requests from CI.
+package markdown
@@ -0,0 +1,188 @@
+package markdown
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+)
+
+// DefaultTop is the default number of top findings listed in summaries.
+const DefaultTop = 10
+
+var severityOrder = map[v1alpha1.Severity]int{
+	v1alpha1.SeverityCritical: 0,
+	v1alpha1.SeverityHigh:     1,
+	v1alpha1.SeverityMedium:   2,
+	v1alpha1.SeverityLow:      3,
+	v1alpha1.SeverityUnknown:  4,
+}
+
+type finding struct {
+	container     string
+	vulnerability v1alpha1.Vulnerability
+}
+
+// WriteVulnerabilityReports writes the summary of the specified reports of
+// the object with the specified name, e.g. default/deployment/nginx. The
+// summary lists counts of vulnerabilities of each container followed by at
+// most top vulnerabilities of all containers ordered by severity and score.
+func WriteVulnerabilityReports(name string, reports []v1alpha1.VulnerabilityReport, top int, out io.Writer) error {
+	w := &writer{out: out}
+	w.printf("### Vulnerabilities of %s\n\n", name)
+	if len(reports) == 0 {
+		w.printf("No vulnerability reports found.\n")
+		return w.err
+	}
+
+	w.printf("| Container | Image | Critical | High | Medium | Low | Unknown |\n")
+	w.printf("| --- | --- | ---: | ---: | ---: | ---: | ---: |\n")
+	var total v1alpha1.VulnerabilitySummary
+	var findings []finding
+	for _, report := range reports {
+		container := report.Labels[starboard.LabelContainerName]
+		summary := report.Report.Summary
+		w.printf("| %s | `%s` | %s | %s | %d | %d | %d |\n", cell(container),
+			vulnerabilityreport.GetImageRef(report.Report), emphasize(summary.CriticalCount),
+			emphasize(summary.HighCount), summary.MediumCount, summary.LowCount, summary.UnknownCount)
+		total.CriticalCount += summary.CriticalCount
+		total.HighCount += summary.HighCount
+		total.MediumCount += summary.MediumCount
+		total.LowCount += summary.LowCount
+		total.UnknownCount += summary.UnknownCount
+		for _, vulnerability := range report.Report.Vulnerabilities {
+			findings = append(findings, finding{container: container, vulnerability: vulnerability})
+		}
+	}
+	if len(reports) > 1 {
+		w.printf("| **Total** | | %s | %s | %d | %d | %d |\n", emphasize(total.CriticalCount),
+			emphasize(total.HighCount), total.MediumCount, total.LowCount, total.UnknownCount)
+	}
+	w.printf("\n")
+
+	if len(findings) == 0 {
+		w.printf("No vulnerabilities found.\n")
+		return w.err
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i].vulnerability, findings[j].vulnerability
+		if severityOrder[a.Severity] != severityOrder[b.Severity] {
+			return severityOrder[a.Severity] < severityOrder[b.Severity]
+		}
+		return score(a) > score(b)
+	})
+	if top > 0 && len(findings) > top {
+		w.printf("Top %d of %d vulnerabilities:\n\n", top, len(findings))
+		findings = findings[:top]
+	}
+	w.printf("| Severity | ID | Package | Installed | Fixed | Container |\n")
+	w.printf("| --- | --- | --- | --- | --- | --- |\n")
+	for _, f := range findings {
+		v := f.vulnerability
+		w.printf("| %s | %s | %s | %s | %s | %s |\n", v.Severity, link(v.VulnerabilityID, v.PrimaryLink),
+			cell(v.Resource), cell(v.InstalledVersion), cell(v.FixedVersion), cell(f.container))
+	}
+	return w.err
+}
+
+// WriteConfigAuditReport writes the summary of the specified report data of
+// the object with the specified name, e.g. default/deployment/nginx. The
+// summary lists counts of checks followed by at most top failed checks
+// ordered by severity.
+func WriteConfigAuditReport(name string, data v1alpha1.ConfigAuditReportData, top int, out io.Writer) error {
+	w := &writer{out: out}
+	w.printf("### Configuration audit of %s\n\n", name)
+
+	var failed []v1alpha1.Check
+	for _, check := range data.Checks {
+		if !check.Success {
+			failed = append(failed, check)
+		}
+	}
+	w.printf("| Danger | Warning | Pass |\n")
+	w.printf("| ---: | ---: | ---: |\n")
+	w.printf("| %s | %d | %d |\n\n", emphasize(data.Summary.DangerCount), data.Summary.WarningCount,
+		data.Summary.PassCount)
+
+	if len(failed) == 0 {
+		w.printf("No failed checks found.\n")
+		return w.err
+	}
+	sort.SliceStable(failed, func(i, j int) bool {
+		return checkSeverityOrder(failed[i].Severity) < checkSeverityOrder(failed[j].Severity)
+	})
+	if top > 0 && len(failed) > top {
+		w.printf("Top %d of %d failed checks:\n\n", top, len(failed))
+		failed = failed[:top]
+	}
+	w.printf("| Severity | Check | Category | Scope | Message |\n")
+	w.printf("| --- | --- | --- | --- | --- |\n")
+	for _, check := range failed {
+		scope := ""
+		if check.Scope != nil {
+			scope = check.Scope.Type + " " + check.Scope.Value
+		}
+		w.printf("| %s | %s | %s | %s | %s |\n", cell(check.Severity), cell(check.ID), cell(check.Category),
+			cell(scope), cell(check.Message))
+	}
+	return w.err
+}
+
+func checkSeverityOrder(severity string) int {
+	switch strings.ToLower(severity) {
+	case v1alpha1.ConfigAuditSeverityDanger:
+		return 0
+	case v1alpha1.ConfigAuditSeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func score(vulnerability v1alpha1.Vulnerability) float64 {
+	if vulnerability.Score == nil {
+		return 0
+	}
+	return *vulnerability.Score
+}
+
+// emphasize returns the specified count in bold unless it's zero.
+func emphasize(count int) string {
+	if count == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("**%d**", count)
+}
+
+// link returns the Markdown link with the specified text, or the text if the
+// URL is empty.
+func link(text, url string) string {
+	if url == "" {
+		return cell(text)
+	}
+	return fmt.Sprintf("[%s](%s)", cell(text), url)
+}
+
+// cell escapes the specified text so that it's rendered in a single table cell.
+func cell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// writer remembers the first error of writes, so that the error is checked
+// once all lines are written.
+type writer struct {
+	out io.Writer
+	err error
+}
+
+func (w *writer) printf(format string, args ...interface{}) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = fmt.Fprintf(w.out, format, args...)
+}
@@ -0,0 +1,118 @@
+package markdown_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/markdown"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestWriteVulnerabilityReports(t *testing.T) {
+	reports := []v1alpha1.VulnerabilityReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+				starboard.LabelContainerName: "app",
+			}},
+			Report: v1alpha1.VulnerabilityReportData{
+				Artifact: v1alpha1.Artifact{Repository: "library/app", Tag: "1.0"},
+				Summary:  v1alpha1.VulnerabilitySummary{HighCount: 2, MediumCount: 1},
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{VulnerabilityID: "CVE-2022-0001", Resource: "zlib", InstalledVersion: "1.2", Severity: v1alpha1.SeverityMedium},
+					{VulnerabilityID: "CVE-2022-0778", Resource: "openssl", InstalledVersion: "1.1.1k", FixedVersion: "1.1.1n",
+						Severity: v1alpha1.SeverityHigh, Score: pointer.Float64Ptr(7.5), PrimaryLink: "https://avd.aquasec.com/nvd/cve-2022-0778"},
+					{VulnerabilityID: "CVE-2022-0002", Resource: "a|b", InstalledVersion: "1.0", Severity: v1alpha1.SeverityHigh,
+						Score: pointer.Float64Ptr(8.1)},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+				starboard.LabelContainerName: "sidecar",
+			}},
+			Report: v1alpha1.VulnerabilityReportData{
+				Artifact: v1alpha1.Artifact{Repository: "sidecar", Tag: "2.0"},
+				Summary:  v1alpha1.VulnerabilitySummary{CriticalCount: 1},
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{VulnerabilityID: "CVE-2021-44228", Resource: "log4j-core", InstalledVersion: "2.14.1", FixedVersion: "2.15.0",
+						Severity: v1alpha1.SeverityCritical},
+				},
+			},
+		},
+	}
+
+	t.Run("Should write all vulnerabilities", func(t *testing.T) {
+		var out bytes.Buffer
+		err := markdown.WriteVulnerabilityReports("default/deployment/app", reports, markdown.DefaultTop, &out)
+		require.NoError(t, err)
+		assert.Equal(t, "### Vulnerabilities of default/deployment/app\n"+
+			"\n"+
+			"| Container | Image | Critical | High | Medium | Low | Unknown |\n"+
+			"| --- | --- | ---: | ---: | ---: | ---: | ---: |\n"+
+			"| app | `library/app:1.0` | 0 | **2** | 1 | 0 | 0 |\n"+
+			"| sidecar | `sidecar:2.0` | **1** | 0 | 0 | 0 | 0 |\n"+
+			"| **Total** | | **1** | **2** | 1 | 0 | 0 |\n"+
+			"\n"+
+			"| Severity | ID | Package | Installed | Fixed | Container |\n"+
+			"| --- | --- | --- | --- | --- | --- |\n"+
+			"| CRITICAL | CVE-2021-44228 | log4j-core | 2.14.1 | 2.15.0 | sidecar |\n"+
+			"| HIGH | CVE-2022-0002 | a\\|b | 1.0 |  | app |\n"+
+			"| HIGH | [CVE-2022-0778](https://avd.aquasec.com/nvd/cve-2022-0778) | openssl | 1.1.1k | 1.1.1n | app |\n"+
+			"| MEDIUM | CVE-2022-0001 | zlib | 1.2 |  | app |\n", out.String())
+	})
+
+	t.Run("Should write top vulnerabilities", func(t *testing.T) {
+		var out bytes.Buffer
+		err := markdown.WriteVulnerabilityReports("default/deployment/app", reports, 1, &out)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "Top 1 of 4 vulnerabilities:\n\n")
+		assert.Contains(t, out.String(), "| CRITICAL | CVE-2021-44228 |")
+		assert.NotContains(t, out.String(), "CVE-2022-0778")
+	})
+
+	t.Run("Should write note when there are no reports", func(t *testing.T) {
+		var out bytes.Buffer
+		err := markdown.WriteVulnerabilityReports("nginx:1.16", nil, markdown.DefaultTop, &out)
+		require.NoError(t, err)
+		assert.Equal(t, "### Vulnerabilities of nginx:1.16\n\nNo vulnerability reports found.\n", out.String())
+	})
+}
+
+func TestWriteConfigAuditReport(t *testing.T) {
+	var out bytes.Buffer
+	err := markdown.WriteConfigAuditReport("default/deployment/nginx", v1alpha1.ConfigAuditReportData{
+		Summary: v1alpha1.ConfigAuditSummary{DangerCount: 1, WarningCount: 1, PassCount: 1},
+		Checks: []v1alpha1.Check{
+			{ID: "hostIPCSet", Success: true, Category: "Security"},
+			{
+				ID:       "cpuLimitsMissing",
+				Message:  "CPU limits should be set",
+				Severity: v1alpha1.ConfigAuditSeverityWarning,
+				Category: "Resources",
+				Scope:    &v1alpha1.CheckScope{Type: "Container", Value: "nginx"},
+			},
+			{
+				ID:       "runAsRootAllowed",
+				Message:  "Should not be allowed to run as root",
+				Severity: v1alpha1.ConfigAuditSeverityDanger,
+				Category: "Security",
+			},
+		},
+	}, markdown.DefaultTop, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "### Configuration audit of default/deployment/nginx\n"+
+		"\n"+
+		"| Danger | Warning | Pass |\n"+
+		"| ---: | ---: | ---: |\n"+
+		"| **1** | 1 | 1 |\n"+
+		"\n"+
+		"| Severity | Check | Category | Scope | Message |\n"+
+		"| --- | --- | --- | --- | --- |\n"+
+		"| danger | runAsRootAllowed | Security |  | Should not be allowed to run as root |\n"+
+		"| warning | cpuLimitsMissing | Resources | Container nginx | CPU limits should be set |\n", out.String())
+}
@@ -0,0 +1,111 @@
+package networkpolicyreport
+
+import (
+	"sort"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ReportName is the name of the ClusterNetworkPolicyReport, which is a
+// singleton.
+const ReportName = "cluster"
+
+// Workload is a workload whose NetworkPolicy coverage is assessed.
+type Workload struct {
+	Kind      kube.Kind
+	Name      string
+	Namespace string
+	// PodLabels are labels of the pod template of the workload.
+	PodLabels map[string]string
+}
+
+// Assess returns the NetworkPolicy coverage assessment of the specified
+// workloads. A workload is covered if its pods are selected by any of the
+// NetworkPolicies in its namespace, where the empty pod selector selects all
+// pods. A namespace is covered if it has any NetworkPolicy. Only namespaces
+// with workloads are assessed.
+func Assess(workloads []Workload, policies []networkingv1.NetworkPolicy) v1alpha1.ClusterNetworkPolicyReportData {
+	selectors := make(map[string][]labels.Selector)
+	for _, policy := range policies {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil {
+			// An invalid pod selector is rejected by the API server, and
+			// wouldn't select any pod anyway.
+			selector = labels.Nothing()
+		}
+		selectors[policy.Namespace] = append(selectors[policy.Namespace], selector)
+	}
+
+	uncovered := make(map[string][]v1alpha1.NetworkPolicyWorkload)
+	namespaces := make(map[string]bool)
+	var summary v1alpha1.NetworkPolicySummary
+	for _, workload := range workloads {
+		namespaces[workload.Namespace] = true
+		summary.WorkloadCount++
+		if selects(selectors[workload.Namespace], workload.PodLabels) {
+			continue
+		}
+		summary.UncoveredWorkloadCount++
+		uncovered[workload.Namespace] = append(uncovered[workload.Namespace], v1alpha1.NetworkPolicyWorkload{
+			Kind: string(workload.Kind),
+			Name: workload.Name,
+		})
+	}
+	summary.NamespaceCount = len(namespaces)
+	for namespace := range namespaces {
+		if len(selectors[namespace]) == 0 {
+			summary.UncoveredNamespaceCount++
+		}
+	}
+
+	names := make([]string, 0, len(uncovered))
+	for name := range uncovered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	coverage := make([]v1alpha1.NetworkPolicyNamespace, 0, len(names))
+	for _, name := range names {
+		workloads := uncovered[name]
+		sort.Slice(workloads, func(i, j int) bool {
+			if workloads[i].Kind != workloads[j].Kind {
+				return workloads[i].Kind < workloads[j].Kind
+			}
+			return workloads[i].Name < workloads[j].Name
+		})
+		coverage = append(coverage, v1alpha1.NetworkPolicyNamespace{
+			Name:               name,
+			NetworkPolicyCount: len(selectors[name]),
+			UncoveredWorkloads: workloads,
+		})
+	}
+
+	return v1alpha1.ClusterNetworkPolicyReportData{
+		UpdateTimestamp: metav1.Now(),
+		Summary:         summary,
+		Namespaces:      coverage,
+	}
+}
+
+func selects(selectors []labels.Selector, podLabels map[string]string) bool {
+	for _, selector := range selectors {
+		if selector.Matches(labels.Set(podLabels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewReport returns the ClusterNetworkPolicyReport with the specified
+// assessment.
+func NewReport(data v1alpha1.ClusterNetworkPolicyReportData) v1alpha1.ClusterNetworkPolicyReport {
+	return v1alpha1.ClusterNetworkPolicyReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ReportName,
+		},
+		Report: data,
+	}
+}
@@ -0,0 +1,77 @@
+package networkpolicyreport_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/networkpolicyreport"
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAssess(t *testing.T) {
+	workloads := []networkpolicyreport.Workload{
+		{Kind: kube.KindDeployment, Name: "web", Namespace: "prod", PodLabels: map[string]string{"app": "web"}},
+		{Kind: kube.KindDeployment, Name: "api", Namespace: "prod", PodLabels: map[string]string{"app": "api"}},
+		{Kind: kube.KindStatefulSet, Name: "db", Namespace: "prod", PodLabels: map[string]string{"app": "db"}},
+		{Kind: kube.KindDeployment, Name: "web", Namespace: "staging", PodLabels: map[string]string{"app": "web"}},
+		{Kind: kube.KindPod, Name: "debug", Namespace: "dev"},
+		{Kind: kube.KindDaemonSet, Name: "agent", Namespace: "dev", PodLabels: map[string]string{"app": "agent"}},
+	}
+	policies := []networkingv1.NetworkPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "prod"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"db", "cache"}},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-deny", Namespace: "staging"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unused", Namespace: "empty"},
+		},
+	}
+
+	data := networkpolicyreport.Assess(workloads, policies)
+	assert.Equal(t, v1alpha1.NetworkPolicySummary{
+		NamespaceCount:          3,
+		UncoveredNamespaceCount: 1,
+		WorkloadCount:           6,
+		UncoveredWorkloadCount:  3,
+	}, data.Summary)
+	assert.Equal(t, []v1alpha1.NetworkPolicyNamespace{
+		{
+			Name:               "dev",
+			NetworkPolicyCount: 0,
+			UncoveredWorkloads: []v1alpha1.NetworkPolicyWorkload{
+				{Kind: "DaemonSet", Name: "agent"},
+				{Kind: "Pod", Name: "debug"},
+			},
+		},
+		{
+			Name:               "prod",
+			NetworkPolicyCount: 2,
+			UncoveredWorkloads: []v1alpha1.NetworkPolicyWorkload{
+				{Kind: "Deployment", Name: "api"},
+			},
+		},
+	}, data.Namespaces)
+	assert.False(t, data.UpdateTimestamp.IsZero())
+}
+
+func TestAssess_WithoutWorkloads(t *testing.T) {
+	data := networkpolicyreport.Assess(nil, nil)
+	assert.Equal(t, v1alpha1.NetworkPolicySummary{}, data.Summary)
+	assert.Equal(t, []v1alpha1.NetworkPolicyNamespace{}, data.Namespaces)
+}
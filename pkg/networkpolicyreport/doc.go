@@ -0,0 +1,4 @@
+// Package networkpolicyreport provides primitives for assessing which
+// namespaces and workloads lack any applicable NetworkPolicy, and for
+// recording the assessment as the ClusterNetworkPolicyReport resource.
+package networkpolicyreport
@@ -0,0 +1,51 @@
+package networkpolicyreport
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Writer is the interface that wraps the Write method.
+//
+// Write creates or updates the given v1alpha1.ClusterNetworkPolicyReport.
+type Writer interface {
+	Write(context.Context, v1alpha1.ClusterNetworkPolicyReport) error
+}
+
+type writer struct {
+	client.Client
+}
+
+// NewWriter constructs a new Writer which is using the client package
+// provided by the controller-runtime libraries for interacting with the
+// Kubernetes API server.
+func NewWriter(client client.Client) Writer {
+	return &writer{
+		Client: client,
+	}
+}
+
+func (w *writer) Write(ctx context.Context, report v1alpha1.ClusterNetworkPolicyReport) error {
+	var existing v1alpha1.ClusterNetworkPolicyReport
+	err := w.Get(ctx, types.NamespacedName{
+		Name: report.Name,
+	}, &existing)
+
+	if err == nil {
+		copied := existing.DeepCopy()
+		copied.Labels = report.Labels
+		copied.Report = report.Report
+
+		return w.Update(ctx, copied)
+	}
+
+	if errors.IsNotFound(err) {
+		return w.Create(ctx, &report)
+	}
+
+	return err
+}
@@ -0,0 +1,56 @@
+package nodeagent
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// TokenAudience is the audience of ServiceAccount tokens that the operator
+// sends to node agents. Tokens bound to it are rejected by the Kubernetes API
+// server, therefore a node agent cannot replay them against the cluster.
+const TokenAudience = "starboard-node-agent"
+
+// Auth authenticates callers of the node agent.
+type Auth interface {
+	// Authenticate returns the user identified by the bearer token, or false
+	// if the token is not valid.
+	Authenticate(ctx context.Context, token string) (authenticationv1.UserInfo, bool, error)
+}
+
+type kubeAuth struct {
+	clientset kubernetes.Interface
+	audience  string
+}
+
+// NewKubeAuth constructs a new Auth, which reviews tokens with TokenReviews
+// and accepts only tokens issued for the specified audience.
+func NewKubeAuth(clientset kubernetes.Interface, audience string) Auth {
+	return &kubeAuth{clientset: clientset, audience: audience}
+}
+
+func (a *kubeAuth) Authenticate(ctx context.Context, token string) (authenticationv1.UserInfo, bool, error) {
+	review, err := a.clientset.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: []string{a.audience},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, false, fmt.Errorf("reviewing token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, false, nil
+	}
+	// The API server returns audiences of the token that are compatible with
+	// the requested ones. Authenticators that ignore audiences return none.
+	for _, audience := range review.Status.Audiences {
+		if audience == a.audience {
+			return review.Status.User, true, nil
+		}
+	}
+	return authenticationv1.UserInfo{}, false, nil
+}
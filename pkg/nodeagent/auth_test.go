@@ -0,0 +1,46 @@
+package nodeagent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/nodeagent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestKubeAuth_Authenticate(t *testing.T) {
+	// newAuth returns Auth whose TokenReviews authenticate every token with
+	// the specified audiences.
+	newAuth := func(audiences ...string) nodeagent.Auth {
+		clientset := fake.NewSimpleClientset()
+		clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+			assert.Equal(t, []string{nodeagent.TokenAudience}, review.Spec.Audiences)
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authenticationv1.UserInfo{Username: operatorUser},
+				Audiences:     audiences,
+			}
+			return true, review, nil
+		})
+		return nodeagent.NewKubeAuth(clientset, nodeagent.TokenAudience)
+	}
+
+	t.Run("Should authenticate token of the audience", func(t *testing.T) {
+		user, authenticated, err := newAuth(nodeagent.TokenAudience).Authenticate(context.TODO(), "token")
+		require.NoError(t, err)
+		assert.True(t, authenticated)
+		assert.Equal(t, operatorUser, user.Username)
+	})
+
+	t.Run("Should reject token of another audience", func(t *testing.T) {
+		_, authenticated, err := newAuth("https://kubernetes.default.svc").Authenticate(context.TODO(), "token")
+		require.NoError(t, err)
+		assert.False(t, authenticated)
+	})
+}
@@ -0,0 +1,16 @@
+// Package nodeagent provides primitives of the Starboard node agent, which
+// runs on each node as a pod of a DaemonSet and scans images already present
+// in the container runtime of the node on request of the operator. Scanning
+// with node agents avoids creating a scan job, and pulling the image again,
+// for each scanned workload.
+//
+// The operator calls node agents with JSON over HTTPS rather than gRPC. The
+// protocol has a single method whose response is the JSON report printed by
+// Trivy, which the operator decodes anyway, so protobuf messages would only
+// wrap it. Plain net/http also keeps generated code and a gRPC dependency out
+// of the operator, and lets node agents be probed by the kubelet like any
+// other HTTPS server.
+//
+// Node agents serve TLS and accept scan requests only with a ServiceAccount
+// token of an allowed user, which is reviewed with a TokenReview.
+package nodeagent
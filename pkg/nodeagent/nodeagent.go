@@ -0,0 +1,123 @@
+package nodeagent
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/docker"
+)
+
+const (
+	// PathScan is the path of the method that scans images.
+	PathScan = "/v1alpha1/scan"
+)
+
+// ScanRequest is the body of a request sent to PathScan.
+type ScanRequest struct {
+	// Images are scanned images keyed by container names.
+	Images map[string]string `json:"images"`
+	// Credentials are image pull credentials keyed by container names, which
+	// are used if images are not present on the node.
+	Credentials map[string]docker.Auth `json:"credentials,omitempty"`
+}
+
+// Result is the result of scanning the image of a container.
+type Result struct {
+	// Output is the report printed by the scanner, e.g. Trivy JSON report.
+	Output string `json:"output,omitempty"`
+	// Error is set if the image couldn't be scanned.
+	Error string `json:"error,omitempty"`
+}
+
+// ScanResponse is the body of a successful response of PathScan.
+type ScanResponse struct {
+	// Results are results of scanned images keyed by container names.
+	Results map[string]Result `json:"results"`
+}
+
+// NewClientTLSConfig returns the tls.Config of a Client, which verifies that
+// the certificate of a node agent is signed by the CA in the specified file,
+// or by a system CA if the file is not set, and is issued for the specified
+// server name. Node agents are dialed by pod IPs, which change, therefore
+// their certificates are verified against a fixed name instead.
+func NewClientTLSConfig(caFile, serverName string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+	if caFile == "" {
+		return config, nil
+	}
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA certificate: %w", err)
+	}
+	config.RootCAs = x509.NewCertPool()
+	if !config.RootCAs.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("reading CA certificate: no PEM encoded certificates found")
+	}
+	return config, nil
+}
+
+// Client sends scan requests to node agents over HTTPS. Requests are
+// authenticated with the ServiceAccount token in the token file, which is
+// read for each request, because the kubelet rotates projected tokens.
+type Client struct {
+	client    *http.Client
+	tokenFile string
+}
+
+// NewClient constructs a new Client with the specified timeout of scan
+// requests, TLS config, and path of the token file.
+func NewClient(timeout time.Duration, tlsConfig *tls.Config, tokenFile string) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &Client{
+		client:    &http.Client{Timeout: timeout, Transport: transport},
+		tokenFile: tokenFile,
+	}
+}
+
+// Scan sends the scan request to the node agent listening on the specified
+// address, e.g. 10.0.1.23:8090, and waits for results of all images.
+func (c *Client) Scan(ctx context.Context, address string, request ScanRequest) (ScanResponse, error) {
+	token, err := os.ReadFile(c.tokenFile)
+	if err != nil {
+		return ScanResponse{}, fmt.Errorf("reading token: %w", err)
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return ScanResponse{}, fmt.Errorf("encoding request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+address+PathScan, bytes.NewReader(body))
+	if err != nil {
+		return ScanResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return ScanResponse{}, fmt.Errorf("calling node agent: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return ScanResponse{}, fmt.Errorf("calling node agent: unexpected response status: %s", resp.Status)
+	}
+	var scanResp ScanResponse
+	err = json.NewDecoder(resp.Body).Decode(&scanResp)
+	if err != nil {
+		return ScanResponse{}, fmt.Errorf("decoding response of node agent: %w", err)
+	}
+	return scanResp, nil
+}
@@ -0,0 +1,146 @@
+package nodeagent_test
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/nodeagent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+type scannerFunc func(ctx context.Context, image string, credentials *docker.Auth) ([]byte, error)
+
+func (f scannerFunc) Scan(ctx context.Context, image string, credentials *docker.Auth) ([]byte, error) {
+	return f(ctx, image, credentials)
+}
+
+// fakeAuth authenticates tokens as users with the same names.
+type fakeAuth struct{}
+
+func (a *fakeAuth) Authenticate(_ context.Context, token string) (authenticationv1.UserInfo, bool, error) {
+	if token == "invalid" {
+		return authenticationv1.UserInfo{}, false, nil
+	}
+	return authenticationv1.UserInfo{Username: token}, true, nil
+}
+
+const operatorUser = "system:serviceaccount:starboard-system:starboard-operator"
+
+// writeFile writes the file with the specified content to a temporary
+// directory and returns its path.
+func writeFile(t *testing.T, name string, content []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, content, 0600))
+	return path
+}
+
+// newClient returns the client of the TLS server, which trusts the
+// certificate of the server and sends the specified token.
+func newClient(t *testing.T, server *httptest.Server, token string) *nodeagent.Client {
+	caFile := writeFile(t, "ca.crt", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	tlsConfig, err := nodeagent.NewClientTLSConfig(caFile, "example.com")
+	require.NoError(t, err)
+	return nodeagent.NewClient(time.Second, tlsConfig, writeFile(t, "token", []byte(token+"\n")))
+}
+
+func TestClient_Scan(t *testing.T) {
+	var usernames []string
+	server := httptest.NewTLSServer(nodeagent.NewHandler(scannerFunc(func(_ context.Context, image string, credentials *docker.Auth) ([]byte, error) {
+		if credentials != nil {
+			usernames = append(usernames, credentials.Username)
+		}
+		if image == "private.registry/app:1.0" {
+			return nil, errors.New("image not found")
+		}
+		return []byte(`{"ArtifactName":"` + image + `"}`), nil
+	}), &fakeAuth{}, []string{operatorUser}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "https://")
+
+	resp, err := newClient(t, server, operatorUser).Scan(context.TODO(), address, nodeagent.ScanRequest{
+		Images: map[string]string{
+			"nginx": "nginx:1.16",
+			"app":   "private.registry/app:1.0",
+		},
+		Credentials: map[string]docker.Auth{
+			"app": {Username: "robot", Password: "s3cret"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]nodeagent.Result{
+		"nginx": {Output: `{"ArtifactName":"nginx:1.16"}`},
+		"app":   {Error: "image not found"},
+	}, resp.Results)
+	assert.Equal(t, []string{"robot"}, usernames)
+}
+
+func TestHandler(t *testing.T) {
+	var scanned []string
+	server := httptest.NewTLSServer(nodeagent.NewHandler(scannerFunc(func(_ context.Context, image string, _ *docker.Auth) ([]byte, error) {
+		scanned = append(scanned, image)
+		return []byte(image), nil
+	}), &fakeAuth{}, []string{operatorUser}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "https://")
+	request := nodeagent.ScanRequest{Images: map[string]string{"nginx": "nginx:1.16"}}
+
+	t.Run("Should reject request without token", func(t *testing.T) {
+		resp, err := server.Client().Post(server.URL+nodeagent.PathScan, "application/json", strings.NewReader(`{"images":{"nginx":"nginx:1.16"}}`))
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Should reject invalid token", func(t *testing.T) {
+		_, err := newClient(t, server, "invalid").Scan(context.TODO(), address, request)
+		assert.EqualError(t, err, "calling node agent: unexpected response status: 401 Unauthorized")
+	})
+
+	t.Run("Should reject user that is not allowed", func(t *testing.T) {
+		_, err := newClient(t, server, "system:serviceaccount:default:default").Scan(context.TODO(), address, request)
+		assert.EqualError(t, err, "calling node agent: unexpected response status: 403 Forbidden")
+	})
+
+	t.Run("Should serve health checks without token", func(t *testing.T) {
+		resp, err := server.Client().Get(server.URL + "/healthz")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	assert.Empty(t, scanned)
+}
+
+func TestNewClientTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(nodeagent.NewHandler(scannerFunc(func(_ context.Context, image string, _ *docker.Auth) ([]byte, error) {
+		return []byte(image), nil
+	}), &fakeAuth{}, []string{operatorUser}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "https://")
+
+	t.Run("Should reject certificate of another server name", func(t *testing.T) {
+		caFile := writeFile(t, "ca.crt", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+		tlsConfig, err := nodeagent.NewClientTLSConfig(caFile, "starboard-node-agent")
+		require.NoError(t, err)
+		client := nodeagent.NewClient(time.Second, tlsConfig, writeFile(t, "token", []byte(operatorUser)))
+		_, err = client.Scan(context.TODO(), address, nodeagent.ScanRequest{Images: map[string]string{"nginx": "nginx:1.16"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate is valid for example.com")
+	})
+
+	t.Run("Should reject CA file without certificates", func(t *testing.T) {
+		_, err := nodeagent.NewClientTLSConfig(writeFile(t, "ca.crt", []byte("not a certificate")), "starboard-node-agent")
+		assert.EqualError(t, err, "reading CA certificate: no PEM encoded certificates found")
+	})
+}
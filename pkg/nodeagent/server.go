@@ -0,0 +1,85 @@
+package nodeagent
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/docker"
+)
+
+// Scanner scans images present in the container runtime of the node.
+type Scanner interface {
+	// Scan scans the specified image and returns the report printed by the
+	// scanner. The optional credentials are used if the image must be pulled
+	// from its registry.
+	Scan(ctx context.Context, image string, credentials *docker.Auth) ([]byte, error)
+}
+
+// NewHandler returns the http.Handler, which serves PathScan by scanning
+// requested images one by one with the Scanner. Failures of individual images
+// are returned in results, so that the operator can tell them apart from
+// failures of the node agent.
+//
+// Scan requests carry image pull credentials and make the node agent run the
+// scanner, therefore callers must present a bearer token of one of the
+// specified users, e.g. the ServiceAccount of the operator.
+func NewHandler(scanner Scanner, auth Auth, users []string) http.Handler {
+	allowed := make(map[string]bool, len(users))
+	for _, user := range users {
+		allowed[user] = true
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathScan, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || token == r.Header.Get("Authorization") {
+			http.Error(w, "bearer token is required", http.StatusUnauthorized)
+			return
+		}
+		user, authenticated, err := auth.Authenticate(r.Context(), token)
+		if err != nil {
+			log.Printf("Unable to authenticate scan request: %s", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		if !authenticated {
+			http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+		if !allowed[user.Username] {
+			http.Error(w, "user "+user.Username+" is not allowed to scan images", http.StatusForbidden)
+			return
+		}
+
+		var req ScanRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp := ScanResponse{Results: make(map[string]Result)}
+		for container, image := range req.Images {
+			var credentials *docker.Auth
+			if auth, ok := req.Credentials[container]; ok {
+				credentials = &auth
+			}
+			output, err := scanner.Scan(r.Context(), image, credentials)
+			if err != nil {
+				resp.Results[container] = Result{Error: err.Error()}
+				continue
+			}
+			resp.Results[container] = Result{Output: string(output)}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
@@ -0,0 +1,103 @@
+package nodeagent
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// TrivyScanner is the Scanner that runs the Trivy executable. Trivy looks up
+// images in the Docker Engine and containerd of the node before pulling them
+// from registries.
+type TrivyScanner struct {
+	path                string
+	cacheDir            string
+	containerdAddress   string
+	containerdNamespace string
+
+	// mu prevents scans while the vulnerability database is updated.
+	mu sync.RWMutex
+	// sem limits the number of concurrent scans.
+	sem chan struct{}
+}
+
+// NewTrivyScanner constructs a new TrivyScanner, which runs the Trivy
+// executable at the specified path with the specified cache directory, and
+// runs at most maxConcurrentScans scans at a time. The containerd address and
+// namespace, which is k8s.io for images pulled by the kubelet, are passed to
+// Trivy to look up images in containerd.
+func NewTrivyScanner(path, cacheDir, containerdAddress, containerdNamespace string, maxConcurrentScans int) *TrivyScanner {
+	if maxConcurrentScans < 1 {
+		maxConcurrentScans = 1
+	}
+	return &TrivyScanner{
+		path:                path,
+		cacheDir:            cacheDir,
+		containerdAddress:   containerdAddress,
+		containerdNamespace: containerdNamespace,
+		sem:                 make(chan struct{}, maxConcurrentScans),
+	}
+}
+
+// UpdateDB downloads the vulnerability database. Scans wait until the
+// download completes, so that they never read a partially written database.
+func (s *TrivyScanner) UpdateDB(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.run(ctx, nil, "--cache-dir", s.cacheDir, "image", "--download-db-only")
+	if err != nil {
+		return fmt.Errorf("downloading vulnerability database: %w", err)
+	}
+	return nil
+}
+
+func (s *TrivyScanner) Scan(ctx context.Context, image string, credentials *docker.Auth) ([]byte, error) {
+	// The image is passed to Trivy as an argument, therefore it must not be
+	// mistaken for a flag.
+	if _, err := name.ParseReference(image); err != nil {
+		return nil, fmt.Errorf("invalid image reference: %w", err)
+	}
+	select {
+	case s.sem <- struct{}{}:
+		defer func() { <-s.sem }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var env []string
+	if credentials != nil {
+		env = append(env, "TRIVY_USERNAME="+credentials.Username, "TRIVY_PASSWORD="+credentials.Password)
+	}
+	return s.run(ctx, env, "--cache-dir", s.cacheDir, "--quiet", "image", "--skip-update", "--format", "json", "--", image)
+}
+
+func (s *TrivyScanner) run(ctx context.Context, env []string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, s.path, args...)
+	cmd.Env = append(os.Environ(), env...)
+	if s.containerdAddress != "" {
+		cmd.Env = append(cmd.Env, "CONTAINERD_ADDRESS="+s.containerdAddress)
+	}
+	if s.containerdNamespace != "" {
+		cmd.Env = append(cmd.Env, "CONTAINERD_NAMESPACE="+s.containerdNamespace)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,48 @@
+package nodeagent_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/nodeagent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeTrivy writes the executable that prints its environment variables
+// used by the scanner and its arguments instead of running Trivy.
+func newFakeTrivy(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "trivy")
+	script := `#!/bin/sh
+echo "$CONTAINERD_ADDRESS $CONTAINERD_NAMESPACE $TRIVY_USERNAME"
+echo "$@"
+for image; do :; done
+[ "$image" != "broken:1.0" ] || { echo "unable to inspect the image" >&2; exit 1; }
+`
+	require.NoError(t, os.WriteFile(path, []byte(script), 0700))
+	return path
+}
+
+func TestTrivyScanner_Scan(t *testing.T) {
+	scanner := nodeagent.NewTrivyScanner(newFakeTrivy(t), "/var/lib/trivy", "/run/containerd/containerd.sock", "k8s.io", 1)
+
+	output, err := scanner.Scan(context.TODO(), "nginx:1.16", &docker.Auth{Username: "robot", Password: "s3cret"})
+	require.NoError(t, err)
+	assert.Equal(t, "/run/containerd/containerd.sock k8s.io robot\n"+
+		"--cache-dir /var/lib/trivy --quiet image --skip-update --format json -- nginx:1.16\n", string(output))
+
+	_, err = scanner.Scan(context.TODO(), "broken:1.0", nil)
+	assert.EqualError(t, err, "exit status 1: unable to inspect the image")
+
+	_, err = scanner.Scan(context.TODO(), "--config=/etc/shadow", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid image reference")
+}
+
+func TestTrivyScanner_UpdateDB(t *testing.T) {
+	scanner := nodeagent.NewTrivyScanner(newFakeTrivy(t), "/var/lib/trivy", "", "", 1)
+	assert.NoError(t, scanner.UpdateDB(context.TODO()))
+}
@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -39,26 +40,39 @@ type CISKubeBenchReportReconciler struct {
 	client.Client
 	kube.LogsReader
 	LimitChecker
+	RateLimiter
 	kubebench.ReadWriter
 	kubebench.Plugin
 	starboard.ConfigData
+	// QuotaChecker is optional. If nil, scan jobs are created regardless of
+	// ResourceQuotas of the scan jobs namespace.
+	QuotaChecker QuotaChecker
+	// Sharder is optional. If nil, all nodes are reconciled.
+	Sharder Sharder
 }
 
 func (r *CISKubeBenchReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.Node{}, builder.WithPredicates(IsLinuxNode)).
-		Owns(&v1alpha1.CISKubeBenchReport{}).
+	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controllerOptions(r.Config, r.Config.CISKubernetesBenchmarkMaxConcurrentReconciles)).
+		For(&corev1.Node{}, builder.WithPredicates(IsLinuxNode, InShard(r.Sharder))).
+		Owns(&v1alpha1.CISKubeBenchReport{}, builder.OnlyMetadata, builder.WithPredicates(InShard(r.Sharder)))
+	err := watchGainedNamespaces(b, r.Sharder,
+		objectsInNamespace(r.Logger, mgr.GetClient(), &corev1.Node{}, true, IsLinuxNode)).
 		Complete(r.reconcileNodes())
 	if err != nil {
 		return err
 	}
-	return ctrl.NewControllerManagedBy(mgr).
+	b = ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controllerOptions(r.Config, r.Config.CISKubernetesBenchmarkMaxConcurrentReconciles)).
 		For(&batchv1.Job{}, builder.WithPredicates(
-			InNamespace(r.Config.Namespace),
+			InNamespace(r.Config.GetScanJobsNamespace()),
 			ManagedByStarboardOperator,
 			IsKubeBenchReportScan,
 			JobHasAnyCondition,
-		)).
+			ScanJobInShard(r.Sharder),
+		))
+	return watchGainedNamespaces(b, r.Sharder,
+		scanJobsOfNamespace(r.Logger, mgr.GetClient(), r.Config.GetScanJobsNamespace(), starboard.LabelKubeBenchReportScanner)).
 		Complete(r.reconcileJobs())
 }
 
@@ -116,6 +130,23 @@ func (r *CISKubeBenchReportReconciler) reconcileNodes() reconcile.Func {
 			return ctrl.Result{}, fmt.Errorf("preparing job: %w", err)
 		}
 
+		if r.QuotaChecker != nil {
+			quota, err := r.QuotaChecker.Check(ctx, job)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("checking resource quotas: %w", err)
+			}
+			if quota != "" {
+				log.V(1).Info("Pushing back scan job", "reason", "resource quota exceeded", "quota", quota,
+					"retryAfter", r.ScanJobRetryAfter)
+				return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+			}
+		}
+
+		if delay := r.RateLimiter.Reserve(); delay > 0 {
+			log.V(1).Info("Pushing back scan job", "reason", "scan jobs rate limit exceeded", "retryAfter", delay)
+			return ctrl.Result{RequeueAfter: delay}, nil
+		}
+
 		log.V(1).Info("Scheduling CIS Kubernetes Benchmark checks")
 		err = r.Client.Create(ctx, job)
 		if err != nil {
@@ -130,7 +161,7 @@ func (r *CISKubeBenchReportReconciler) reconcileNodes() reconcile.Func {
 }
 
 func (r *CISKubeBenchReportReconciler) hasReport(ctx context.Context, node *corev1.Node) (bool, error) {
-	report, err := r.ReadWriter.FindByOwner(ctx, kube.ObjectRef{Kind: kube.KindNode, Name: node.Name})
+	report, err := getReportMetadata(ctx, r.Client, v1alpha1.CISKubeBenchReportKind, types.NamespacedName{Name: node.Name})
 	if err != nil {
 		return false, err
 	}
@@ -140,7 +171,7 @@ func (r *CISKubeBenchReportReconciler) hasReport(ctx context.Context, node *core
 func (r *CISKubeBenchReportReconciler) hasScanJob(ctx context.Context, node *corev1.Node) (bool, *batchv1.Job, error) {
 	jobName := r.getScanJobName(node)
 	job := &batchv1.Job{}
-	err := r.Client.Get(ctx, client.ObjectKey{Namespace: r.Config.Namespace, Name: jobName}, job)
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: r.Config.GetScanJobsNamespace(), Name: jobName}, job)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return false, nil, nil
@@ -164,6 +195,18 @@ func (r *CISKubeBenchReportReconciler) newScanJob(node *corev1.Node) (*batchv1.J
 	}
 	templateSpec.Tolerations = append(templateSpec.Tolerations, scanJobTolerations...)
 
+	scanJobScheduling, err := r.ConfigData.GetScanJobScheduling()
+	if err != nil {
+		return nil, err
+	}
+	scanJobScheduling.ApplyTo(&templateSpec)
+
+	scanJobImages, err := r.ConfigData.GetScanJobImages()
+	if err != nil {
+		return nil, err
+	}
+	scanJobImages.ApplyTo(&templateSpec)
+
 	scanJobAnnotations, err := r.ConfigData.GetScanJobAnnotations()
 	if err != nil {
 		return nil, err
@@ -174,6 +217,11 @@ func (r *CISKubeBenchReportReconciler) newScanJob(node *corev1.Node) (*batchv1.J
 		return nil, err
 	}
 
+	commonMetadata, err := r.ConfigData.GetCommonMetadata()
+	if err != nil {
+		return nil, err
+	}
+
 	labelsSet := labels.Set{
 		starboard.LabelResourceKind:           string(kube.KindNode),
 		starboard.LabelResourceName:           node.Name,
@@ -189,10 +237,10 @@ func (r *CISKubeBenchReportReconciler) newScanJob(node *corev1.Node) (*batchv1.J
 		podTemplateLabelsSet[index] = element
 	}
 
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      r.getScanJobName(node),
-			Namespace: r.Config.Namespace,
+			Namespace: r.Config.GetScanJobsNamespace(),
 			Labels:    labelsSet,
 		},
 		Spec: batchv1.JobSpec{
@@ -207,7 +255,9 @@ func (r *CISKubeBenchReportReconciler) newScanJob(node *corev1.Node) (*batchv1.J
 				Spec: templateSpec,
 			},
 		},
-	}, nil
+	}
+	commonMetadata.ApplyToJob(job)
+	return job, nil
 }
 
 func (r *CISKubeBenchReportReconciler) getScanJobName(node *corev1.Node) string {
@@ -290,11 +340,17 @@ func (r *CISKubeBenchReportReconciler) processCompleteScanJob(ctx context.Contex
 
 	report, err := kubebench.NewBuilder(r.Client.Scheme()).
 		Controller(node).
+		Labels(r.Config.GetClusterLabels()).
 		Data(output).
 		Get()
 	if err != nil {
 		return fmt.Errorf("building report: %w", err)
 	}
+	commonMetadata, err := r.ConfigData.GetCommonMetadata()
+	if err != nil {
+		return fmt.Errorf("getting common metadata: %w", err)
+	}
+	commonMetadata.ApplyTo(&report.ObjectMeta)
 
 	log.V(1).Info("Writing CIS Kubernetes Benchmark report", "reportName", report.Name)
 	err = r.ReadWriter.Write(ctx, report)
@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operatorconfig"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigReloader watches the configuration of the operator, i.e. the
+// starboard ConfigMap and Secret, the etc.ConfigMapName ConfigMap whose keys
+// override environment variables, and the OperatorConfig, and calls Reload
+// once the configuration differs from the one the operator was started with.
+//
+// Reload is expected to stop the manager, so that all controllers are
+// started again with the reloaded configuration. Their caches are synced
+// from scratch, therefore all watched objects are reconciled again and
+// picked up by the reloaded settings, whereas running scan jobs are resumed
+// as after a restart of the operator.
+//
+// Changes that would prevent the operator from starting, such as invalid
+// values of overridden environment variables, are logged and ignored.
+// ConfigMaps and Secrets of plugins are not watched, because plugins read
+// them each time they're used.
+type ConfigReloader struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	// ConfigData is the configuration the operator was started with, i.e.
+	// the data of the starboard ConfigMap and Secret overridden by the
+	// OperatorConfig.
+	ConfigData starboard.ConfigData
+	// Overrides are the environment variables the operator was started with
+	// that are overridden by the etc.ConfigMapName ConfigMap and by the
+	// OperatorConfig.
+	Overrides map[string]string
+	// Reload is called once the configuration has changed.
+	Reload func()
+
+	informers cache.Informers
+	once      sync.Once
+}
+
+func (r *ConfigReloader) SetupWithManager(mgr ctrl.Manager) error {
+	r.informers = mgr.GetCache()
+	return mgr.Add(r)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so that each
+// replica reloads its configuration, including replicas that are not leaders
+// yet.
+func (r *ConfigReloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start checks the configuration on each change of watched ConfigMaps and
+// Secrets until the context is cancelled.
+func (r *ConfigReloader) Start(ctx context.Context) error {
+	changed := make(chan struct{}, 1)
+	handler := toolscache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			o, ok := obj.(client.Object)
+			return ok && r.watches(o)
+		},
+		Handler: toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    func(interface{}) { notify(changed) },
+			UpdateFunc: func(interface{}, interface{}) { notify(changed) },
+			DeleteFunc: func(interface{}) { notify(changed) },
+		},
+	}
+	for _, obj := range []client.Object{&corev1.ConfigMap{}, &corev1.Secret{}, &v1alpha1.OperatorConfig{}} {
+		informer, err := r.informers.GetInformer(ctx, obj)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				r.Logger.V(1).Info("Skipping watch of kind that is not installed", "kind", fmt.Sprintf("%T", obj))
+				continue
+			}
+			return fmt.Errorf("getting informer of %T: %w", obj, err)
+		}
+		informer.AddEventHandler(handler)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+			reload, err := r.check(ctx)
+			if err != nil {
+				r.Logger.Error(err, "Ignoring changed configuration")
+				continue
+			}
+			if reload {
+				r.once.Do(r.Reload)
+			}
+		}
+	}
+}
+
+func notify(ch chan<- struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+func (r *ConfigReloader) watches(obj client.Object) bool {
+	if obj.GetNamespace() != r.Config.Namespace {
+		return false
+	}
+	switch obj.(type) {
+	case *corev1.ConfigMap:
+		return obj.GetName() == starboard.ConfigMapName || obj.GetName() == etc.ConfigMapName
+	case *corev1.Secret:
+		return obj.GetName() == starboard.SecretName
+	case *v1alpha1.OperatorConfig:
+		return obj.GetName() == v1alpha1.OperatorConfigName
+	}
+	return false
+}
+
+// check returns true if the configuration differs from the one the operator
+// was started with, or an error if the changed configuration is invalid.
+func (r *ConfigReloader) check(ctx context.Context) (bool, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.get(ctx, starboard.ConfigMapName, cm)
+	if err != nil {
+		return false, err
+	}
+	secret := &corev1.Secret{}
+	err = r.get(ctx, starboard.SecretName, secret)
+	if err != nil {
+		return false, err
+	}
+	overrides := &corev1.ConfigMap{}
+	err = r.get(ctx, etc.ConfigMapName, overrides)
+	if err != nil {
+		return false, err
+	}
+
+	operatorConfig, err := operatorconfig.Get(ctx, r.Client, r.Config.Namespace)
+	if err != nil {
+		return false, err
+	}
+	var spec *v1alpha1.OperatorConfigSpec
+	if operatorConfig != nil {
+		err = operatorconfig.Validate(operatorConfig.Spec, overrides.Data)
+		if err != nil {
+			return false, fmt.Errorf("validating operator config: %w", err)
+		}
+		spec = &operatorConfig.Spec
+	}
+
+	configData := operatorconfig.GetConfigData(spec, starboard.NewConfigData(cm, secret))
+	env := operatorconfig.GetEnv(spec, overrides.Data)
+	if equalData(configData, r.ConfigData) && equalData(env, r.Overrides) {
+		return false, nil
+	}
+	err = starboard.ValidateImageRefs(configData)
+	if err != nil {
+		return false, fmt.Errorf("validating starboard config: %w", err)
+	}
+	_, err = etc.GetOperatorConfigWithOverrides(env)
+	if err != nil {
+		return false, fmt.Errorf("getting operator config: %w", err)
+	}
+	r.Logger.Info("Reloading changed configuration")
+	return true, nil
+}
+
+// get gets the object with the specified name in the operator namespace, or
+// leaves it empty if it doesn't exist.
+func (r *ConfigReloader) get(ctx context.Context, name string, obj client.Object) error {
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: r.Config.Namespace, Name: name}, obj)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("getting %T %s: %w", obj, name, err)
+	}
+	return nil
+}
+
+// equalData checks whether the specified maps have the same keys and values,
+// where nil maps equal empty maps.
+func equalData(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
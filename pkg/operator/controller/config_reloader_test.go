@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestConfigReloader_check(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: starboard.ConfigMapName},
+		Data:       map[string]string{"vulnerabilityReports.scanner": "Trivy"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: starboard.SecretName},
+		Data:       map[string][]byte{"trivy.githubToken": []byte("token")},
+	}
+	newReloader := func(objects ...client.Object) *ConfigReloader {
+		return &ConfigReloader{
+			Logger: log.Log,
+			Config: etc.Config{Namespace: "starboard-system"},
+			Client: fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build(),
+			ConfigData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": "Trivy",
+				"trivy.githubToken":            "token",
+			},
+		}
+	}
+
+	t.Run("Should not reload unchanged configuration", func(t *testing.T) {
+		reload, err := newReloader(cm, secret).check(context.TODO())
+		require.NoError(t, err)
+		assert.False(t, reload)
+	})
+
+	t.Run("Should reload when Secret is changed", func(t *testing.T) {
+		changed := secret.DeepCopy()
+		changed.Data["trivy.githubToken"] = []byte("rotated")
+		reload, err := newReloader(cm, changed).check(context.TODO())
+		require.NoError(t, err)
+		assert.True(t, reload)
+	})
+
+	t.Run("Should reload when Secret is deleted", func(t *testing.T) {
+		reload, err := newReloader(cm).check(context.TODO())
+		require.NoError(t, err)
+		assert.True(t, reload)
+	})
+
+	t.Run("Should reload when environment variables are overridden", func(t *testing.T) {
+		overrides := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: etc.ConfigMapName},
+			Data:       map[string]string{"OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT": "5"},
+		}
+		reload, err := newReloader(cm, secret, overrides).check(context.TODO())
+		require.NoError(t, err)
+		assert.True(t, reload)
+	})
+
+	t.Run("Should not reload invalid overrides", func(t *testing.T) {
+		overrides := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: etc.ConfigMapName},
+			Data:       map[string]string{"OPERATOR_NAMESPACE": "default"},
+		}
+		reload, err := newReloader(cm, secret, overrides).check(context.TODO())
+		assert.EqualError(t, err, "getting operator config: OPERATOR_NAMESPACE cannot be set in starboard-operator ConfigMap")
+		assert.False(t, reload)
+	})
+
+	t.Run("Should reload when OperatorConfig is changed", func(t *testing.T) {
+		t.Setenv("OPERATOR_NAMESPACE", "starboard-system")
+		operatorConfig := &v1alpha1.OperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: v1alpha1.OperatorConfigName},
+			Spec:       v1alpha1.OperatorConfigSpec{ConcurrentScanJobsLimit: pointer.IntPtr(5)},
+		}
+		r := newReloader(cm, secret, operatorConfig)
+		reload, err := r.check(context.TODO())
+		require.NoError(t, err)
+		assert.True(t, reload)
+
+		r.Overrides = map[string]string{"OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT": "5"}
+		reload, err = r.check(context.TODO())
+		require.NoError(t, err)
+		assert.False(t, reload)
+	})
+
+	t.Run("Should not reload invalid OperatorConfig", func(t *testing.T) {
+		t.Setenv("OPERATOR_NAMESPACE", "starboard-system")
+		operatorConfig := &v1alpha1.OperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: v1alpha1.OperatorConfigName},
+			Spec: v1alpha1.OperatorConfigSpec{
+				VulnerabilityScanner: &v1alpha1.OperatorConfigScanner{Plugin: "Clair"},
+			},
+		}
+		reload, err := newReloader(cm, secret, operatorConfig).check(context.TODO())
+		assert.EqualError(t, err, "validating operator config: invalid value (Clair) of vulnerabilityReports.scanner; allowed values (Trivy, Aqua, Harbor, Quay, External)")
+		assert.False(t, reload)
+	})
+
+	t.Run("Should only watch configuration of operator namespace", func(t *testing.T) {
+		r := newReloader()
+		assert.True(t, r.watches(cm))
+		assert.True(t, r.watches(secret))
+		assert.False(t, r.watches(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: starboard.ConfigMapName},
+		}))
+		assert.False(t, r.watches(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "starboard-trivy-config"},
+		}))
+	})
+}
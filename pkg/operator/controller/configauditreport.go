@@ -5,11 +5,14 @@ import (
 
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/scanfailurereport"
+	"github.com/aquasecurity/starboard/pkg/secrethygiene"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -20,11 +23,15 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 type ConfigAuditReportReconciler struct {
@@ -34,10 +41,25 @@ type ConfigAuditReportReconciler struct {
 	client.Client
 	kube.ObjectResolver
 	LimitChecker
+	RateLimiter
+	RetryPolicy
 	kube.LogsReader
 	configauditreport.Plugin
 	starboard.PluginContext
 	configauditreport.ReadWriter
+	ScanFailureReports scanfailurereport.ReadWriter
+	// QuotaChecker is optional. If nil, scan jobs are created regardless of
+	// ResourceQuotas of the scan jobs namespace.
+	QuotaChecker QuotaChecker
+	// Sharder is optional. If nil, resources in all namespaces and
+	// cluster-scoped resources are reconciled.
+	Sharder Sharder
+	// NamespaceSelector is optional. If nil, resources are reconciled regardless
+	// of labels of their namespaces.
+	NamespaceSelector NamespaceSelector
+	// Hooks are called before scan jobs are created and after reports are
+	// persisted. The zero value doesn't call any hooks.
+	Hooks ScanHooks
 }
 
 func (r *ConfigAuditReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -79,14 +101,31 @@ func (r *ConfigAuditReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			r.Logger.Info("Skipping unsupported kind", "pluginName", r.PluginContext.GetName(), "kind", resource.kind)
 			continue
 		}
-		err = ctrl.NewControllerManagedBy(mgr).
-			For(resource.forObject, builder.WithPredicates(
-				Not(ManagedByStarboardOperator),
-				Not(IsLeaderElectionResource),
-				Not(IsBeingTerminated),
-				installModePredicate,
-			)).
-			Owns(resource.ownsObject).
+		resourcePredicates := []predicate.Predicate{
+			Not(ManagedByStarboardOperator),
+			Not(IsLeaderElectionResource),
+			Not(IsBeingTerminated),
+			installModePredicate,
+			InSelectedNamespace(r.NamespaceSelector),
+			ScanEnabled(r.Config.ScanOptInEnabled),
+		}
+		b := ctrl.NewControllerManagedBy(mgr).
+			WithOptions(controllerOptions(r.Config, r.Config.ConfigAuditScannerMaxConcurrentReconciles)).
+			For(resource.forObject, builder.WithPredicates(append(resourcePredicates, InShard(r.Sharder))...)).
+			Owns(resource.ownsObject, builder.OnlyMetadata, builder.WithPredicates(InShard(r.Sharder))).
+			Watches(&source.Kind{Type: &batchv1.Job{}},
+				handler.EnqueueRequestsFromMapFunc(scanJobOwner(resource.kind)),
+				builder.WithPredicates(
+					InNamespace(r.Config.GetScanJobsNamespace()),
+					ManagedByStarboardOperator,
+					IsConfigAuditReportScan,
+					JobHasFailedCondition,
+					ScanJobInShard(r.Sharder),
+				))
+		b = watchSelectedNamespaces(b, r.NamespaceSelector,
+			objectsInNamespace(r.Logger, mgr.GetClient(), resource.forObject, false, resourcePredicates...))
+		err = watchGainedNamespaces(b, r.Sharder,
+			objectsInNamespace(r.Logger, mgr.GetClient(), resource.forObject, false, resourcePredicates...)).
 			Complete(r.reconcileResource(resource.kind))
 		if err != nil {
 			return fmt.Errorf("constructing controller for %s: %w", resource.kind, err)
@@ -98,25 +137,43 @@ func (r *ConfigAuditReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			r.Logger.Info("Skipping unsupported kind", "pluginName", r.PluginContext.GetName(), "kind", resource.kind)
 			continue
 		}
-		err = ctrl.NewControllerManagedBy(mgr).
-			For(resource.forObject, builder.WithPredicates(
-				Not(ManagedByStarboardOperator),
-				Not(IsBeingTerminated),
-			)).
-			Owns(resource.ownsObject).
+		resourcePredicates := []predicate.Predicate{
+			Not(ManagedByStarboardOperator),
+			Not(IsBeingTerminated),
+			ScanEnabled(r.Config.ScanOptInEnabled),
+		}
+		b := ctrl.NewControllerManagedBy(mgr).
+			WithOptions(controllerOptions(r.Config, r.Config.ConfigAuditScannerMaxConcurrentReconciles)).
+			For(resource.forObject, builder.WithPredicates(append(resourcePredicates, InShard(r.Sharder))...)).
+			Owns(resource.ownsObject, builder.OnlyMetadata, builder.WithPredicates(InShard(r.Sharder))).
+			Watches(&source.Kind{Type: &batchv1.Job{}},
+				handler.EnqueueRequestsFromMapFunc(scanJobOwner(resource.kind)),
+				builder.WithPredicates(
+					InNamespace(r.Config.GetScanJobsNamespace()),
+					ManagedByStarboardOperator,
+					IsConfigAuditReportScan,
+					JobHasFailedCondition,
+					ScanJobInShard(r.Sharder),
+				))
+		err = watchGainedNamespaces(b, r.Sharder,
+			objectsInNamespace(r.Logger, mgr.GetClient(), resource.forObject, true, resourcePredicates...)).
 			Complete(r.reconcileResource(resource.kind))
 		if err != nil {
 			return fmt.Errorf("constructing controller for %s: %w", resource.kind, err)
 		}
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controllerOptions(r.Config, r.Config.ConfigAuditScannerMaxConcurrentReconciles)).
 		For(&batchv1.Job{}, builder.WithPredicates(
-			InNamespace(r.Config.Namespace),
+			InNamespace(r.Config.GetScanJobsNamespace()),
 			ManagedByStarboardOperator,
 			IsConfigAuditReportScan,
 			JobHasAnyCondition,
-		)).
+			ScanJobInShard(r.Sharder),
+		))
+	return watchGainedNamespaces(b, r.Sharder,
+		scanJobsOfNamespace(r.Logger, mgr.GetClient(), r.Config.GetScanJobsNamespace(), starboard.LabelConfigAuditReportScanner)).
 		Complete(r.reconcileJobs())
 }
 
@@ -145,6 +202,13 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 			return ctrl.Result{}, fmt.Errorf("getting %s from cache: %w", resourceKind, err)
 		}
 
+		// Skip processing if auditing is disabled by the annotation of the
+		// resource. Events of owned reports pass the predicates of resources.
+		if !IsScanEnabled(resource, r.Config.ScanOptInEnabled) {
+			log.V(1).Info("Ignoring resource with auditing disabled", "annotation", starboard.AnnotationScan)
+			return ctrl.Result{}, nil
+		}
+
 		// Skip processing if a resource is a Pod controlled by a built-in K8s workload.
 		if resourceKind == kube.KindPod {
 			controller := metav1.GetControllerOf(resource)
@@ -203,14 +267,50 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 		}
 
 		log.V(1).Info("Checking whether configuration audit has been scheduled")
-		_, job, err := r.hasActiveScanJob(ctx, resource, resourceSpecHash)
+		hasScanJob, job, err := r.hasActiveScanJob(ctx, resource, resourceSpecHash)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
-		if job != nil {
+
+		var failedJob *batchv1.Job
+		attempt := 1
+		switch {
+		case job != nil && hasJobCondition(*job, batchv1.JobFailed):
+			failedJob = job
+			// If the resource has changed since its scan job failed, the
+			// failed scan job is replaced without waiting.
+			if !hasScanJob {
+				break
+			}
+			var retry bool
+			var retryAfter time.Duration
+			attempt, retryAfter, retry = r.RetryPolicy.Next(job)
+			if !retry {
+				log.V(1).Info("Giving up configuration audit after failed attempts", "attempts", attempt,
+					"failure", job.Annotations[starboard.AnnotationScanJobFailure])
+				return ctrl.Result{}, nil
+			}
+			if retryAfter > 0 {
+				log.V(1).Info("Pushing back reconcile key",
+					"reason", "waiting before retrying failed scan job",
+					"attempt", attempt,
+					"retryAfter", retryAfter)
+				return ctrl.Result{RequeueAfter: retryAfter}, nil
+			}
+		case hasScanJob:
 			log.V(1).Info("Configuration audit has been scheduled",
 				"job", fmt.Sprintf("%s/%s", job.Namespace, job.Name))
 			return ctrl.Result{}, nil
+		case job != nil:
+			// The scan job of the previous resource spec is still running,
+			// e.g. because the resource changed while the operator was down.
+			// It has the same name as the new scan job, which is created once
+			// the running one completes.
+			log.V(1).Info("Pushing back reconcile key",
+				"reason", "waiting for scan job of previous resource spec",
+				"job", fmt.Sprintf("%s/%s", job.Namespace, job.Name),
+				"retryAfter", r.ScanJobRetryAfter)
+			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
 		}
 
 		limitExceeded, scanJobsCount, err := r.LimitChecker.Check(ctx)
@@ -232,6 +332,31 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 			return ctrl.Result{}, fmt.Errorf("getting scan job tolerations: %w", err)
 		}
 
+		scanJobScheduling, err := r.ConfigData.GetScanJobScheduling()
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("getting scan job scheduling: %w", err)
+		}
+
+		scanJobSandbox, err := r.ConfigData.GetScanJobSandbox()
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("getting scan job sandbox: %w", err)
+		}
+
+		scanJobImages, err := r.ConfigData.GetScanJobImages()
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("getting scan job images: %w", err)
+		}
+
+		pluginConfig, err := r.PluginContext.GetConfig()
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("getting plugin config: %w", err)
+		}
+
+		scanJobOverride, err := pluginConfig.GetScanJobOverride(resource.GetNamespace())
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("getting scan job override: %w", err)
+		}
+
 		scanJobAnnotations, err := r.ConfigData.GetScanJobAnnotations()
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("getting scan job annotations: %w", err)
@@ -242,18 +367,63 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 			return ctrl.Result{}, fmt.Errorf("getting scan job template labels: %w", err)
 		}
 
+		commonMetadata, err := r.ConfigData.GetCommonMetadata()
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("getting common metadata: %w", err)
+		}
+
 		job, secrets, err := configauditreport.NewScanJobBuilder().
 			WithPlugin(r.Plugin).
 			WithPluginContext(r.PluginContext).
 			WithTimeout(r.Config.ScanJobTimeout).
 			WithObject(resource).
 			WithTolerations(scanJobTolerations).
+			WithScheduling(scanJobScheduling).
+			WithSandbox(scanJobSandbox).
+			WithImages(scanJobImages).
+			WithOverride(scanJobOverride).
 			WithAnnotations(scanJobAnnotations).
 			WithPodTemplateLabels(scanJobPodTemplateLabels).
+			WithCommonMetadata(commonMetadata).
 			Get()
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("constructing scan job: %w", err)
 		}
+		setScanJobAttempt(job, attempt)
+
+		if r.QuotaChecker != nil {
+			quota, err := r.QuotaChecker.Check(ctx, job)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("checking resource quotas: %w", err)
+			}
+			if quota != "" {
+				log.V(1).Info("Pushing back reconcile key",
+					"reason", "resource quota exceeded",
+					"quota", quota,
+					"retryAfter", r.ScanJobRetryAfter)
+				return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+			}
+		}
+
+		err = r.Hooks.preScan(ctx, log, r.Client.Scheme(), resource, job)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("calling pre-scan hook: %w", err)
+		}
+
+		if delay := r.RateLimiter.Reserve(); delay > 0 {
+			log.V(1).Info("Pushing back reconcile key",
+				"reason", "scan jobs rate limit exceeded",
+				"retryAfter", delay)
+			return ctrl.Result{RequeueAfter: delay}, nil
+		}
+
+		if failedJob != nil {
+			log.V(1).Info("Deleting failed scan job", "attempt", attempt)
+			err = r.deleteJob(ctx, failedJob)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 
 		for _, secret := range secrets {
 			err := r.Client.Create(ctx, secret)
@@ -270,9 +440,10 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 		err = r.Client.Create(ctx, job)
 		if err != nil {
 			if errors.IsAlreadyExists(err) {
-				// TODO Delete secrets that were created in the previous step. Alternatively we can delete them on schedule.
-				log.V(1).Info("Job already exists", "jobName", job.Name)
-				return ctrl.Result{}, nil
+				// The scan job is not in the cache yet. Delete secrets that
+				// were created in the previous step and check again later.
+				log.V(1).Info("Job already exists", "jobName", job.Name, "retryAfter", r.ScanJobRetryAfter)
+				return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, deleteSecrets(ctx, r.Client, secrets)
 			}
 			return ctrl.Result{}, fmt.Errorf("creating job: %w", err)
 		}
@@ -296,26 +467,22 @@ func (r *ConfigAuditReportReconciler) hasReport(ctx context.Context, owner kube.
 	if kube.IsClusterScopedKind(string(owner.Kind)) {
 		return r.hasClusterReport(ctx, owner, podSpecHash, pluginConfigHash)
 	}
-	// TODO FindByOwner should accept optional label selector to further narrow down search results
-	report, err := r.ReadWriter.FindReportByOwner(ctx, owner)
-	if err != nil {
-		return false, err
-	}
-	if report != nil {
-		return report.Labels[starboard.LabelResourceSpecHash] == podSpecHash &&
-			report.Labels[starboard.LabelPluginConfigHash] == pluginConfigHash, nil
-	}
-	return false, nil
+	return r.hasReportOfKind(ctx, v1alpha1.ConfigAuditReportKind, owner, podSpecHash, pluginConfigHash)
 }
 
 func (r *ConfigAuditReportReconciler) hasClusterReport(ctx context.Context, owner kube.ObjectRef, podSpecHash string, pluginConfigHash string) (bool, error) {
-	report, err := r.ReadWriter.FindClusterReportByOwner(ctx, owner)
+	return r.hasReportOfKind(ctx, v1alpha1.ClusterConfigAuditReportKind, owner, podSpecHash, pluginConfigHash)
+}
+
+func (r *ConfigAuditReportReconciler) hasReportOfKind(ctx context.Context, kind string, owner kube.ObjectRef, podSpecHash string, pluginConfigHash string) (bool, error) {
+	list, err := listReportsMetadata(ctx, r.Client, kind, owner)
 	if err != nil {
 		return false, err
 	}
-	if report != nil {
-		return report.Labels[starboard.LabelResourceSpecHash] == podSpecHash &&
-			report.Labels[starboard.LabelPluginConfigHash] == pluginConfigHash, nil
+	// Only one config audit per specific workload exists on the cluster
+	if len(list) > 0 {
+		return list[0].Labels[starboard.LabelResourceSpecHash] == podSpecHash &&
+			list[0].Labels[starboard.LabelPluginConfigHash] == pluginConfigHash, nil
 	}
 	return false, nil
 }
@@ -323,7 +490,7 @@ func (r *ConfigAuditReportReconciler) hasClusterReport(ctx context.Context, owne
 func (r *ConfigAuditReportReconciler) hasActiveScanJob(ctx context.Context, obj client.Object, hash string) (bool, *batchv1.Job, error) {
 	jobName := configauditreport.GetScanJobName(obj)
 	job := &batchv1.Job{}
-	err := r.Client.Get(ctx, client.ObjectKey{Namespace: r.Config.Namespace, Name: jobName}, job)
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: r.Config.GetScanJobsNamespace(), Name: jobName}, job)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return false, nil, nil
@@ -333,7 +500,7 @@ func (r *ConfigAuditReportReconciler) hasActiveScanJob(ctx context.Context, obj
 	if job.Labels[starboard.LabelResourceSpecHash] == hash {
 		return true, job, nil
 	}
-	return false, nil, nil
+	return false, job, nil
 }
 
 func (r *ConfigAuditReportReconciler) reconcileJobs() reconcile.Func {
@@ -420,26 +587,88 @@ func (r *ConfigAuditReportReconciler) processCompleteScanJob(ctx context.Context
 		return err
 	}
 
+	if r.Config.ConfigAuditSecretHygieneEnabled {
+		checks, err := auditSecretHygiene(ctx, r.Client, owner)
+		if err != nil {
+			return fmt.Errorf("auditing secret hygiene: %w", err)
+		}
+		secrethygiene.AddChecks(&reportData, checks)
+	}
+
+	nameFormat, err := r.ConfigData.GetReportNameFormat()
+	if err != nil {
+		return err
+	}
+
+	reportLabels, reportAnnotations, err := getPropagatedMetadata(ctx, r.ObjectResolver, r.Config, r.ConfigData, owner)
+	if err != nil {
+		return err
+	}
+
 	reportBuilder := configauditreport.NewReportBuilder(r.Client.Scheme()).
 		Controller(owner).
+		Labels(reportLabels).
+		Annotations(reportAnnotations).
 		ResourceSpecHash(resourceSpecHash).
 		PluginConfigHash(pluginConfigHash).
-		Data(reportData)
+		Data(reportData).
+		NameFormat(nameFormat).
+		Orphaned(r.Config.RetainsOrphanedReports())
 	err = reportBuilder.Write(ctx, r.ReadWriter)
 	if err != nil {
 		return err
 	}
+	if r.Hooks.PostReport != nil {
+		report, err := getConfigAuditReport(owner, reportBuilder)
+		if err != nil {
+			return err
+		}
+		r.Hooks.postReport(ctx, log, r.Client.Scheme(), owner, []client.Object{report})
+	}
+
+	scanFailureReportNamespace := owner.GetNamespace()
+	if scanFailureReportNamespace == "" {
+		scanFailureReportNamespace = r.Config.Namespace
+	}
+	err = r.ScanFailureReports.Delete(ctx, types.NamespacedName{
+		Name:      scanfailurereport.GetReportName(owner, v1alpha1.ConfigAuditReportKind),
+		Namespace: scanFailureReportNamespace,
+	})
+	if err != nil {
+		return fmt.Errorf("deleting scan failure report: %w", err)
+	}
 
 	log.V(1).Info("Deleting complete scan job", "owner", owner)
 	return r.deleteJob(ctx, job)
 }
 
+// processFailedScanJob records the failure of the specified scan job, which is
+// kept until it's replaced according to the RetryPolicy.
 func (r *ConfigAuditReportReconciler) processFailedScanJob(ctx context.Context, scanJob *batchv1.Job) error {
 	log := r.Logger.WithValues("job", fmt.Sprintf("%s/%s", scanJob.Namespace, scanJob.Name))
 
+	ownerRef, err := kube.ObjectRefFromObjectMeta(scanJob.ObjectMeta)
+	if err != nil {
+		return fmt.Errorf("getting owner ref from scan job metadata: %w", err)
+	}
+
+	owner, err := r.ObjectFromObjectRef(ctx, ownerRef)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.V(1).Info("Deleting failed scan job of resource that must have been deleted")
+			return r.deleteJob(ctx, scanJob)
+		}
+		return fmt.Errorf("getting object from object ref: %w", err)
+	}
+
+	if _, recorded := scanJob.Annotations[starboard.AnnotationScanJobFailure]; recorded {
+		return nil
+	}
+
 	statuses, err := r.LogsReader.GetTerminatedContainersStatusesByJob(ctx, scanJob)
 	if err != nil {
-		return err
+		// Pods of scan jobs that exceeded their deadline are deleted.
+		log.V(1).Info("Getting terminated containers statuses", "error", err.Error())
 	}
 	for container, status := range statuses {
 		if status.ExitCode == 0 {
@@ -447,8 +676,40 @@ func (r *ConfigAuditReportReconciler) processFailedScanJob(ctx context.Context,
 		}
 		log.Error(nil, "Scan job container", "container", container, "status.reason", status.Reason, "status.message", status.Message)
 	}
-	log.V(1).Info("Deleting failed scan job")
-	return r.Client.Delete(ctx, scanJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+
+	attempt, _, retry := r.RetryPolicy.Next(scanJob)
+	if retry {
+		log.V(1).Info("Keeping failed scan job until it's retried", "nextAttempt", attempt)
+	} else {
+		log.Info("Giving up configuration audit after failed attempts", "attempts", attempt)
+	}
+
+	data := newScanFailureReportData(ctx, r.LogsReader, scanJob, statuses, nil)
+	data.Scanner = r.PluginContext.GetName()
+	data.ReportKind = v1alpha1.ConfigAuditReportKind
+	data.GaveUp = !retry
+	report, err := scanfailurereport.NewReportBuilder(r.Client.Scheme()).
+		Controller(owner).
+		Labels(r.Config.GetClusterLabels()).
+		Namespace(r.Config.Namespace).
+		Data(data).
+		Get()
+	if err != nil {
+		return err
+	}
+	commonMetadata, err := r.GetCommonMetadata()
+	if err != nil {
+		return err
+	}
+	commonMetadata.ApplyTo(&report.ObjectMeta)
+	// Failing to write the report, e.g. because the ScanFailureReport CRD is
+	// not installed, must not prevent retrying the scan job.
+	err = r.ScanFailureReports.Write(ctx, report)
+	if err != nil {
+		log.Error(err, "Writing scan failure report")
+	}
+
+	return recordScanJobFailure(ctx, r.Client, scanJob, getScanJobFailure(scanJob, statuses), !retry)
 }
 
 func (r *ConfigAuditReportReconciler) deleteJob(ctx context.Context, job *batchv1.Job) error {
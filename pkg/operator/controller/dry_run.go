@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	// EventReasonDryRun is the reason of events recorded for scan jobs and
+	// reports that the operator would have written in dry-run mode.
+	EventReasonDryRun = "DryRun"
+)
+
+// DryRunClient is the client.Client of the operator in dry-run mode. It sends
+// creates, updates, patches and deletes of objects to the API server as
+// server-side dry-run requests, which are validated and admitted but not
+// persisted, and logs and counts each of them in the
+// starboard_dry_run_operations_total metric. Writes of scan jobs and reports
+// are also recorded as events.
+//
+// Writes of ConfigMaps are not intercepted, because they hold configuration
+// of the operator and plugins, e.g. defaults created by plugins at startup,
+// which must be read back for the operator to work.
+//
+// Objects created in dry-run mode don't exist, therefore updates, patches and
+// deletes of them, e.g. of owner references of Secrets of scan jobs, would
+// fail with NotFound. They succeed as if the objects existed instead.
+type DryRunClient struct {
+	client.Client
+	logr.Logger
+	record.EventRecorder
+
+	mu sync.Mutex
+	// created holds keys of objects created in dry-run mode.
+	created map[dryRunKey]bool
+}
+
+type dryRunKey struct {
+	gvk  schema.GroupVersionKind
+	name client.ObjectKey
+}
+
+func (c *DryRunClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if !c.intercepts(obj) {
+		return c.Client.Create(ctx, obj, opts...)
+	}
+	err := c.Client.Create(ctx, obj, append(opts, client.DryRunAll)...)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	if c.created == nil {
+		c.created = make(map[dryRunKey]bool)
+	}
+	c.created[c.keyOf(obj)] = true
+	c.mu.Unlock()
+	c.record("create", obj)
+	return nil
+}
+
+func (c *DryRunClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if !c.intercepts(obj) {
+		return c.Client.Update(ctx, obj, opts...)
+	}
+	err := c.Client.Update(ctx, obj, append(opts, client.DryRunAll)...)
+	if err != nil && !c.isCreated(obj, err) {
+		return err
+	}
+	c.record("update", obj)
+	return nil
+}
+
+func (c *DryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if !c.intercepts(obj) {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	err := c.Client.Patch(ctx, obj, patch, append(opts, client.DryRunAll)...)
+	if err != nil && !c.isCreated(obj, err) {
+		return err
+	}
+	c.record("patch", obj)
+	return nil
+}
+
+func (c *DryRunClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if !c.intercepts(obj) {
+		return c.Client.Delete(ctx, obj, opts...)
+	}
+	err := c.Client.Delete(ctx, obj, append(opts, client.DryRunAll)...)
+	if err != nil && !c.isCreated(obj, err) {
+		return err
+	}
+	c.mu.Lock()
+	delete(c.created, c.keyOf(obj))
+	c.mu.Unlock()
+	c.record("delete", obj)
+	return nil
+}
+
+func (c *DryRunClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if !c.intercepts(obj) {
+		return c.Client.DeleteAllOf(ctx, obj, opts...)
+	}
+	err := c.Client.DeleteAllOf(ctx, obj, append(opts, client.DryRunAll)...)
+	if err != nil {
+		return err
+	}
+	kind := c.kindOf(obj).Kind
+	dryRunOperations.WithLabelValues("deleteAllOf", kind).Inc()
+	c.Info("Dry run", "operation", "deleteAllOf", "kind", kind, "namespace", obj.GetNamespace())
+	return nil
+}
+
+func (c *DryRunClient) Status() client.StatusWriter {
+	return &dryRunStatusWriter{StatusWriter: c.Client.Status(), client: c}
+}
+
+func (c *DryRunClient) intercepts(obj client.Object) bool {
+	_, ok := obj.(*corev1.ConfigMap)
+	return !ok
+}
+
+func (c *DryRunClient) keyOf(obj client.Object) dryRunKey {
+	return dryRunKey{gvk: c.kindOf(obj), name: client.ObjectKeyFromObject(obj)}
+}
+
+// isCreated returns true if the error of a write of the object is NotFound,
+// and the object was created in dry-run mode.
+func (c *DryRunClient) isCreated(obj client.Object, err error) bool {
+	if !errors.IsNotFound(err) {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.created[c.keyOf(obj)]
+}
+
+func (c *DryRunClient) kindOf(obj client.Object) schema.GroupVersionKind {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return obj.GetObjectKind().GroupVersionKind()
+	}
+	return gvk
+}
+
+func (c *DryRunClient) record(operation string, obj client.Object) {
+	gvk := c.kindOf(obj)
+	dryRunOperations.WithLabelValues(operation, gvk.Kind).Inc()
+	c.Info("Dry run", "operation", operation, "kind", gvk.Kind,
+		"namespace", obj.GetNamespace(), "name", obj.GetName())
+
+	isScanJob := gvk.GroupKind() == batchv1.SchemeGroupVersion.WithKind("Job").GroupKind()
+	isReport := gvk.Group == v1alpha1.SchemeGroupVersion.Group
+	if c.EventRecorder != nil && (isScanJob || isReport) {
+		c.Eventf(obj, corev1.EventTypeNormal, EventReasonDryRun, "Would %s %s %s", operation, gvk.Kind, obj.GetName())
+	}
+}
+
+type dryRunStatusWriter struct {
+	client.StatusWriter
+	client *DryRunClient
+}
+
+func (w *dryRunStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if !w.client.intercepts(obj) {
+		return w.StatusWriter.Update(ctx, obj, opts...)
+	}
+	err := w.StatusWriter.Update(ctx, obj, append(opts, client.DryRunAll)...)
+	if err != nil {
+		return err
+	}
+	w.client.record("updateStatus", obj)
+	return nil
+}
+
+func (w *dryRunStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if !w.client.intercepts(obj) {
+		return w.StatusWriter.Patch(ctx, obj, patch, opts...)
+	}
+	err := w.StatusWriter.Patch(ctx, obj, patch, append(opts, client.DryRunAll)...)
+	if err != nil {
+		return err
+	}
+	w.client.record("patchStatus", obj)
+	return nil
+}
@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// deleteRecorder records options of deletes instead of deleting objects,
+// because the fake client ignores the dry-run option of deletes.
+type deleteRecorder struct {
+	client.Client
+	deletes []client.DeleteOptions
+}
+
+func (c *deleteRecorder) Delete(_ context.Context, _ client.Object, opts ...client.DeleteOption) error {
+	var options client.DeleteOptions
+	options.ApplyOptions(opts)
+	c.deletes = append(c.deletes, options)
+	return nil
+}
+
+// dryRunServer checks that objects exist before they are updated or patched
+// in dry-run mode, like the API server does, whereas the fake client doesn't.
+type dryRunServer struct {
+	client.Client
+}
+
+func (c *dryRunServer) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object)); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *dryRunServer) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object)); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func TestDryRunClient(t *testing.T) {
+	report := &v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "replicaset-nginx-6d4cf56db6-nginx"},
+	}
+	delegate := &deleteRecorder{
+		Client: &dryRunServer{Client: fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(report).Build()},
+	}
+	recorder := record.NewFakeRecorder(10)
+	c := &DryRunClient{
+		Client:        delegate,
+		Logger:        log.Log,
+		EventRecorder: recorder,
+	}
+
+	t.Run("Should not persist scan jobs", func(t *testing.T) {
+		before := testutil.ToFloat64(dryRunOperations.WithLabelValues("create", "Job"))
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "scan-vulnerabilityreport-5d4445db4f"},
+		}
+		require.NoError(t, c.Create(context.TODO(), job))
+
+		err := c.Get(context.TODO(), client.ObjectKeyFromObject(job), &batchv1.Job{})
+		assert.True(t, errors.IsNotFound(err))
+		assert.Equal(t, before+1, testutil.ToFloat64(dryRunOperations.WithLabelValues("create", "Job")))
+		assert.Equal(t, "Normal DryRun Would create Job scan-vulnerabilityreport-5d4445db4f", <-recorder.Events)
+	})
+
+	t.Run("Should not persist updates of reports", func(t *testing.T) {
+		updated := report.DeepCopy()
+		updated.Labels = map[string]string{"env": "prod"}
+		require.NoError(t, c.Update(context.TODO(), updated))
+
+		var actual v1alpha1.VulnerabilityReport
+		require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(report), &actual))
+		assert.Empty(t, actual.Labels)
+		assert.Equal(t, "Normal DryRun Would update VulnerabilityReport replicaset-nginx-6d4cf56db6-nginx", <-recorder.Events)
+	})
+
+	t.Run("Should send deletes as dry-run requests", func(t *testing.T) {
+		require.NoError(t, c.Delete(context.TODO(), report, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+
+		require.Len(t, delegate.deletes, 1)
+		assert.Equal(t, []string{metav1.DryRunAll}, delegate.deletes[0].DryRun)
+		assert.Equal(t, "Normal DryRun Would delete VulnerabilityReport replicaset-nginx-6d4cf56db6-nginx", <-recorder.Events)
+	})
+
+	t.Run("Should update objects created in dry-run mode", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "scan-vulnerabilityreport-7c8f6d9b5d"},
+		}
+		require.NoError(t, c.Create(context.TODO(), secret))
+		secret.Labels = map[string]string{"env": "prod"}
+		require.NoError(t, c.Update(context.TODO(), secret))
+		require.NoError(t, c.Delete(context.TODO(), secret))
+
+		err := c.Update(context.TODO(), secret)
+		assert.True(t, errors.IsNotFound(err), "Should not update objects deleted in dry-run mode")
+		delegate.deletes = nil
+	})
+
+	t.Run("Should persist ConfigMaps", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "starboard-trivy-config"},
+		}
+		require.NoError(t, c.Create(context.TODO(), cm))
+
+		require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{}))
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("Should not record events for other objects", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "scan-vulnerabilityreport-5d4445db4f"},
+		}
+		require.NoError(t, c.Create(context.TODO(), secret))
+
+		err := c.Get(context.TODO(), client.ObjectKeyFromObject(secret), &corev1.Secret{})
+		assert.True(t, errors.IsNotFound(err))
+		assert.Empty(t, recorder.Events)
+	})
+}
+
+func TestVulnerabilityReportReconciler_DryRun(t *testing.T) {
+	pullSecret, err := kube.NewImagePullSecret(metav1.ObjectMeta{Namespace: "prod", Name: "private-registry"},
+		"quay.io", "robot", "s3cret")
+	require.NoError(t, err)
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "backend"},
+		Spec: corev1.PodSpec{
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "private-registry"}},
+			Containers: []corev1.Container{
+				{Name: "backend", Image: "quay.io/acme/backend:1.0"},
+			},
+		},
+	}
+	delegate := &dryRunServer{Client: fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "default"}},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "starboard-trivy-config"},
+			Data: map[string]string{
+				"trivy.imageRef": "docker.io/aquasec/trivy:0.25.2",
+				"trivy.mode":     "Standalone",
+			},
+		},
+		pullSecret,
+		pod,
+	).Build()}
+	c := &DryRunClient{Client: delegate, Logger: log.Log}
+
+	r := &VulnerabilityReportReconciler{
+		Logger:        log.Log,
+		Config:        etc.Config{ScanJobTimeout: 5 * time.Minute, DryRun: true},
+		Client:        c,
+		SecretsReader: kube.NewSecretsReader(c),
+		Plugin:        trivy.NewPlugin(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator(), c),
+		PluginContext: starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-system").
+			WithClient(c).
+			Get(),
+		ConfigData:  starboard.GetDefaultConfig(),
+		RateLimiter: NewRateLimiter(etc.Config{}),
+	}
+
+	result, err := r.submitScanJob(context.TODO(), pod, nil, nil, 1)
+	require.NoError(t, err, "Should set owner references of secrets created in dry-run mode")
+	assert.Zero(t, result)
+
+	var secrets corev1.SecretList
+	require.NoError(t, delegate.List(context.TODO(), &secrets, client.InNamespace("starboard-system")))
+	assert.Empty(t, secrets.Items, "Should not persist secrets of scan jobs")
+	var jobs batchv1.JobList
+	require.NoError(t, delegate.List(context.TODO(), &jobs, client.InNamespace("starboard-system")))
+	assert.Empty(t, jobs.Items, "Should not persist scan jobs")
+}
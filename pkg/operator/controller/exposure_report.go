@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/exposurereport"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ExposureReportReconciler assesses how workloads are reachable from outside
+// the cluster by correlating LoadBalancer and NodePort Services, Ingresses,
+// and Gateway API routes with workloads, and records the assessment as an
+// ExposureReport owned by the workload. Workloads that are reachable from
+// outside the cluster and also have critical vulnerabilities or failed
+// checks of danger severity are flagged.
+//
+// The report is written whenever VulnerabilityReports or the
+// ConfigAuditReport of a workload are written, therefore changes of Services,
+// Ingresses, and routes are reflected by the next scan of the workload.
+// Gateway API routes are considered if the Gateway API is installed when the
+// operator starts.
+type ExposureReportReconciler struct {
+	logr.Logger
+	etc.Config
+	starboard.ConfigData
+	client.Client
+	kube.ObjectResolver
+	exposurereport.Writer
+	// Sharder is optional. If nil, reports in all namespaces are reconciled.
+	Sharder Sharder
+
+	gatewayRouteKinds []schema.GroupVersionKind
+}
+
+func (r *ExposureReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := predicate.InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+	r.gatewayRouteKinds, err = exposurereport.GetGatewayRouteKinds(mgr.GetRESTMapper())
+	if err != nil {
+		return fmt.Errorf("getting gateway route kinds: %w", err)
+	}
+	predicates := builder.WithPredicates(
+		predicate.Not(predicate.IsBeingTerminated),
+		installModePredicate,
+		predicate.InShard(r.Sharder))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("exposurereport").
+		WithOptions(controllerOptions(r.Config, 1)).
+		For(&v1alpha1.VulnerabilityReport{}, builder.OnlyMetadata, predicates).
+		Watches(&source.Kind{Type: &v1alpha1.ConfigAuditReport{}},
+			handler.EnqueueRequestsFromMapFunc(firstVulnerabilityReportOfOwner(r.Logger, r.Client)), builder.OnlyMetadata, predicates)
+	return watchGainedNamespaces(b, r.Sharder,
+		objectsInNamespace(r.Logger, mgr.GetClient(), newReportMetadata(v1alpha1.VulnerabilityReportKind), false,
+			predicate.Not(predicate.IsBeingTerminated), installModePredicate)).
+		Complete(r.reconcileReport())
+}
+
+func (r *ExposureReportReconciler) reconcileReport() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("report", req.NamespacedName)
+
+		metadata, err := getReportMetadata(ctx, r.Client, v1alpha1.VulnerabilityReportKind, req.NamespacedName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if metadata == nil {
+			log.V(1).Info("Ignoring cached report that must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		owner, err := kube.ObjectRefFromObjectMeta(metadata.ObjectMeta)
+		if err != nil {
+			log.V(1).Info("Ignoring report without owner", "error", err.Error())
+			return ctrl.Result{}, nil
+		}
+		workload, err := r.ObjectFromObjectRef(ctx, owner)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Ignoring report of workload that must have been deleted")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting workload: %w", err)
+		}
+
+		data, err := r.assess(ctx, owner, workload)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		report, err := exposurereport.NewReportBuilder(r.Client.Scheme()).
+			Controller(workload).
+			Data(data).
+			Get()
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		commonMetadata, err := r.GetCommonMetadata()
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		commonMetadata.ApplyTo(&report.ObjectMeta)
+		err = r.Write(ctx, report)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("writing exposure report: %w", err)
+		}
+		log.V(1).Info("Assessed exposure of workload", "owner", owner,
+			"internetReachable", data.InternetReachable, "flagged", data.Flagged)
+		return ctrl.Result{}, nil
+	}
+}
+
+// assess returns the exposure assessment of the specified workload.
+func (r *ExposureReportReconciler) assess(ctx context.Context, owner kube.ObjectRef, workload client.Object) (v1alpha1.ExposureReportData, error) {
+	selector := client.MatchingLabels(kube.ObjectRefToLabels(owner))
+
+	var vulnerabilities v1alpha1.VulnerabilitySummary
+	var vulnerabilityReports v1alpha1.VulnerabilityReportList
+	err := r.Client.List(ctx, &vulnerabilityReports, selector, client.InNamespace(owner.Namespace))
+	if err != nil {
+		return v1alpha1.ExposureReportData{}, fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	for _, report := range vulnerabilityReports.Items {
+		vulnerabilities = addVulnerabilitySummaries(vulnerabilities, report.Report.Summary)
+	}
+
+	var configAudit v1alpha1.ConfigAuditSummary
+	var configAuditReports v1alpha1.ConfigAuditReportList
+	err = r.Client.List(ctx, &configAuditReports, selector, client.InNamespace(owner.Namespace))
+	if err != nil {
+		return v1alpha1.ExposureReportData{}, fmt.Errorf("listing config audit reports: %w", err)
+	}
+	for _, report := range configAuditReports.Items {
+		configAudit.DangerCount += report.Report.Summary.DangerCount
+		configAudit.WarningCount += report.Report.Summary.WarningCount
+		configAudit.PassCount += report.Report.Summary.PassCount
+	}
+
+	podLabels, err := kube.GetPodTemplateLabels(workload)
+	if err != nil {
+		return v1alpha1.ExposureReportData{}, err
+	}
+	var services corev1.ServiceList
+	err = r.Client.List(ctx, &services, client.InNamespace(owner.Namespace))
+	if err != nil {
+		return v1alpha1.ExposureReportData{}, fmt.Errorf("listing services: %w", err)
+	}
+	var ingresses networkingv1.IngressList
+	err = r.Client.List(ctx, &ingresses, client.InNamespace(owner.Namespace))
+	if err != nil {
+		return v1alpha1.ExposureReportData{}, fmt.Errorf("listing ingresses: %w", err)
+	}
+	gatewayRoutes, err := r.listGatewayRoutes(ctx, owner.Namespace)
+	if err != nil {
+		return v1alpha1.ExposureReportData{}, err
+	}
+
+	routes := exposurereport.GetRoutes(podLabels, services.Items, ingresses.Items, gatewayRoutes)
+	return exposurereport.Assess(routes, vulnerabilities, configAudit), nil
+}
+
+// listGatewayRoutes returns Gateway API routes of all served kinds in the
+// specified namespace.
+func (r *ExposureReportReconciler) listGatewayRoutes(ctx context.Context, namespace string) ([]unstructured.Unstructured, error) {
+	var routes []unstructured.Unstructured
+	for _, gvk := range r.gatewayRouteKinds {
+		var list unstructured.UnstructuredList
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err := r.Client.List(ctx, &list, client.InNamespace(namespace))
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", gvk.Kind, err)
+		}
+		routes = append(routes, list.Items...)
+	}
+	return routes, nil
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/exposurereport"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestExposureReportReconciler(t *testing.T) {
+	httpRouteKind := schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1beta1", Kind: "HTTPRoute"}
+	podLabels := map[string]string{"app": "nginx"}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-6d4cf56db6", UID: "7e5c4d2a"},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nginx"}},
+				},
+			},
+		},
+	}
+	ownerLabels := kube.ObjectRefToLabels(kube.ObjectRef{Kind: kube.KindReplicaSet, Name: replicaSet.Name, Namespace: "default"})
+	vulnerabilityReport := &v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "replicaset-nginx-6d4cf56db6-nginx",
+			Labels:    ownerLabels,
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 2, HighCount: 1},
+		},
+	}
+	configAuditReport := &v1alpha1.ConfigAuditReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "replicaset-nginx-6d4cf56db6",
+			Labels:    ownerLabels,
+		},
+		Report: v1alpha1.ConfigAuditReportData{
+			Summary: v1alpha1.ConfigAuditSummary{DangerCount: 1},
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Selector: podLabels},
+	}
+	httpRoute := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"rules": []interface{}{
+				map[string]interface{}{
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": "nginx", "port": int64(80)},
+					},
+				},
+			},
+		},
+	}}
+	httpRoute.SetGroupVersionKind(httpRouteKind)
+	httpRoute.SetNamespace("default")
+	httpRoute.SetName("web")
+
+	scheme := starboard.NewScheme()
+	require.NoError(t, networkingv1.AddToScheme(scheme))
+	scheme.AddKnownTypeWithName(httpRouteKind, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(httpRouteKind.GroupVersion().WithKind("HTTPRouteList"), &unstructured.UnstructuredList{})
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(replicaSet, vulnerabilityReport, configAuditReport, service, httpRoute).Build()
+	r := &ExposureReportReconciler{
+		Logger:            log.Log,
+		ConfigData:        starboard.ConfigData{"common.labels": "env=prod"},
+		Client:            c,
+		ObjectResolver:    kube.ObjectResolver{Client: c},
+		Writer:            exposurereport.NewWriter(c),
+		gatewayRouteKinds: []schema.GroupVersionKind{httpRouteKind},
+	}
+
+	_, err := r.reconcileReport()(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vulnerabilityReport)})
+	require.NoError(t, err)
+
+	var report v1alpha1.ExposureReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "replicaset-nginx-6d4cf56db6"}, &report))
+	assert.Equal(t, "prod", report.Labels["env"])
+	assert.Equal(t, replicaSet.Name, report.Labels[starboard.LabelResourceName])
+	require.Len(t, report.OwnerReferences, 1)
+	assert.Equal(t, replicaSet.UID, report.OwnerReferences[0].UID)
+	assert.True(t, report.Report.InternetReachable)
+	assert.True(t, report.Report.Flagged)
+	assert.Equal(t, 2, report.Report.CriticalCount)
+	assert.Equal(t, 1, report.Report.DangerCount)
+	assert.Equal(t, []v1alpha1.ExposureRoute{{Kind: "HTTPRoute", Name: "web", Service: "nginx"}}, report.Report.Routes)
+
+	t.Run("Should update report when workload is no longer reachable", func(t *testing.T) {
+		require.NoError(t, c.Delete(context.TODO(), httpRoute))
+
+		_, err := r.reconcileReport()(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vulnerabilityReport)})
+		require.NoError(t, err)
+		require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(&report), &report))
+		assert.False(t, report.Report.InternetReachable)
+		assert.False(t, report.Report.Flagged)
+		assert.Empty(t, report.Report.Routes)
+	})
+}
@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// CacheSyncCheck returns a healthz.Checker that fails until informers of the
+// specified cache are synced, so that the operator is not ready before its
+// controllers have seen all watched objects. The check waits at most the
+// specified timeout for informers to sync.
+func CacheSyncCheck(c cache.Informers, timeout time.Duration) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("informers have not synced yet")
+		}
+		return nil
+	}
+}
+
+// PluginConfigCheck returns a healthz.Checker that fails if the ConfigMap of
+// the specified plugin is missing or its settings are invalid. The ConfigMap
+// is read each time the check runs, because plugins read it each time they're
+// used, so the operator is not ready until an invalid setting is fixed.
+func PluginConfigCheck(plugin interface{}, pluginContext starboard.PluginContext) healthz.Checker {
+	return func(_ *http.Request) error {
+		pluginConfig, err := pluginContext.GetConfig()
+		if err != nil {
+			return fmt.Errorf("getting config of %s plugin: %w", pluginContext.GetName(), err)
+		}
+		validator, ok := plugin.(starboard.PluginConfigValidator)
+		if !ok {
+			return nil
+		}
+		problems := validator.ValidateConfig(pluginConfig)
+		if len(problems) == 0 {
+			return nil
+		}
+		messages := make([]string, len(problems))
+		for i, problem := range problems {
+			messages[i] = problem.Error()
+		}
+		return fmt.Errorf("invalid config of %s plugin: %s", pluginContext.GetName(), strings.Join(messages, "; "))
+	}
+}
+
+// RegistryCheck returns a healthz.Checker that fails if a registry mirror or
+// an OCI repository of the databases configured for the Trivy plugin cannot
+// be reached, i.e. a TCP connection to its host cannot be established within
+// the specified timeout. Hosts without a port are dialed on port 443.
+func RegistryCheck(pluginContext starboard.PluginContext, timeout time.Duration) healthz.Checker {
+	return func(req *http.Request) error {
+		pluginConfig, err := pluginContext.GetConfig()
+		if err != nil {
+			return fmt.Errorf("getting config of %s plugin: %w", pluginContext.GetName(), err)
+		}
+		hosts := trivy.Config{PluginConfig: pluginConfig}.GetRegistryHosts()
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		errs := make([]error, len(hosts))
+		var wg sync.WaitGroup
+		for i, host := range hosts {
+			wg.Add(1)
+			go func(i int, host string) {
+				defer wg.Done()
+				errs[i] = dial(ctx, host)
+			}(i, host)
+		}
+		wg.Wait()
+
+		var messages []string
+		for _, err := range errs {
+			if err != nil {
+				messages = append(messages, err.Error())
+			}
+		}
+		if len(messages) > 0 {
+			return errors.New(strings.Join(messages, "; "))
+		}
+		return nil
+	}
+}
+
+func dial(ctx context.Context, host string) error {
+	address := host
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		address = net.JoinHostPort(host, "443")
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("registry %s is unreachable: %w", host, err)
+	}
+	return conn.Close()
+}
+
+// LeaderElectionCheck is a healthz.Checker of the Lease that the operator
+// holds once it's elected as the leader. It passes until the operator is
+// elected, and then fails if the Lease is held by another replica, or it
+// hasn't been renewed within the lease duration, e.g. because renewals hang.
+// In both cases the operator may still run controllers that require leader
+// election alongside the new leader, so it must be restarted.
+//
+// The holder of the Lease is recorded by the first check after the operator
+// is elected.
+type LeaderElectionCheck struct {
+	// Client reads the Lease, which is not cached.
+	Client client.Reader
+	Clock  ext.Clock
+	// Elected is closed once the operator is elected as the leader.
+	Elected <-chan struct{}
+	// Namespace and Name of the Lease, i.e. the namespace of the operator and
+	// the leader election ID.
+	Namespace     string
+	Name          string
+	LeaseDuration time.Duration
+
+	mu     sync.Mutex
+	holder string
+}
+
+// Check implements healthz.Checker.
+func (c *LeaderElectionCheck) Check(req *http.Request) error {
+	select {
+	case <-c.Elected:
+	default:
+		return nil
+	}
+
+	var lease coordinationv1.Lease
+	err := c.Client.Get(req.Context(), types.NamespacedName{Namespace: c.Namespace, Name: c.Name}, &lease)
+	if err != nil {
+		return fmt.Errorf("getting lease %s/%s: %w", c.Namespace, c.Name, err)
+	}
+	var holder string
+	if lease.Spec.HolderIdentity != nil {
+		holder = *lease.Spec.HolderIdentity
+	}
+
+	c.mu.Lock()
+	if c.holder == "" {
+		c.holder = holder
+	}
+	elected := c.holder
+	c.mu.Unlock()
+
+	if holder != elected {
+		return fmt.Errorf("lease %s/%s is held by %s instead of %s", c.Namespace, c.Name, holder, elected)
+	}
+	if lease.Spec.RenewTime == nil {
+		return fmt.Errorf("lease %s/%s has never been renewed", c.Namespace, c.Name)
+	}
+	if renewed := lease.Spec.RenewTime.Time; c.Clock.Now().Sub(renewed) > c.LeaseDuration {
+		return fmt.Errorf("lease %s/%s was last renewed at %s, more than %s ago", c.Namespace, c.Name,
+			renewed.UTC().Format(time.RFC3339), c.LeaseDuration)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package controller_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCacheSyncCheck(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/readyz/informers", nil)
+
+	t.Run("Should pass when informers are synced", func(t *testing.T) {
+		check := controller.CacheSyncCheck(&informertest.FakeInformers{Synced: pointer.BoolPtr(true)}, time.Second)
+		assert.NoError(t, check(req))
+	})
+
+	t.Run("Should fail when informers are not synced", func(t *testing.T) {
+		check := controller.CacheSyncCheck(&informertest.FakeInformers{Synced: pointer.BoolPtr(false)}, time.Second)
+		assert.EqualError(t, check(req), "informers have not synced yet")
+	})
+}
+
+func TestPluginConfigCheck(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/readyz/plugin-config-trivy", nil)
+	newCheck := func(objects ...*corev1.ConfigMap) func(*http.Request) error {
+		builder := fake.NewClientBuilder().WithScheme(starboard.NewScheme())
+		for _, object := range objects {
+			builder = builder.WithObjects(object)
+		}
+		c := builder.Build()
+		pluginContext := starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-system").
+			WithClient(c).
+			Get()
+		return controller.PluginConfigCheck(trivy.NewPlugin(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator(), c), pluginContext)
+	}
+	newConfig := func(severity string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "starboard-trivy-config"},
+			Data: map[string]string{
+				"trivy.imageRef": "docker.io/aquasec/trivy:0.25.2",
+				"trivy.mode":     "Standalone",
+				"trivy.severity": severity,
+			},
+		}
+	}
+
+	t.Run("Should pass when config is valid", func(t *testing.T) {
+		assert.NoError(t, newCheck(newConfig("CRITICAL,HIGH"))(req))
+	})
+
+	t.Run("Should fail when config is invalid", func(t *testing.T) {
+		assert.EqualError(t, newCheck(newConfig("CRITICAL,SEVERE"))(req),
+			"invalid config of Trivy plugin: invalid severity (SEVERE) in trivy.severity; allowed values (CRITICAL, HIGH, MEDIUM, LOW, UNKNOWN)")
+	})
+
+	t.Run("Should fail when config is missing", func(t *testing.T) {
+		assert.EqualError(t, newCheck()(req),
+			`getting config of Trivy plugin: configmaps "starboard-trivy-config" not found`)
+	})
+}
+
+func TestRegistryCheck(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/readyz/registries", nil)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, closed.Close())
+
+	newCheck := func(data map[string]string) func(*http.Request) error {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "starboard-trivy-config"},
+			Data:       data,
+		}).Build()
+		pluginContext := starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-system").
+			WithClient(c).
+			Get()
+		return controller.RegistryCheck(pluginContext, time.Second)
+	}
+
+	t.Run("Should pass when there are no mirrors", func(t *testing.T) {
+		assert.NoError(t, newCheck(map[string]string{"trivy.mode": "Standalone"})(req))
+	})
+
+	t.Run("Should pass when mirrors are reachable", func(t *testing.T) {
+		assert.NoError(t, newCheck(map[string]string{
+			"trivy.registry.mirror.docker.io": listener.Addr().String(),
+			"trivy.dbRepository":              listener.Addr().String() + "/aquasecurity/trivy-db",
+		})(req))
+	})
+
+	t.Run("Should fail when mirror is unreachable", func(t *testing.T) {
+		err := newCheck(map[string]string{
+			"trivy.registry.mirror.docker.io": listener.Addr().String(),
+			"trivy.dbRepository":              closed.Addr().String() + "/aquasecurity/trivy-db",
+		})(req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "registry "+closed.Addr().String()+" is unreachable")
+		assert.NotContains(t, err.Error(), listener.Addr().String())
+	})
+}
+
+func TestLeaderElectionCheck(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/healthz/leader-election", nil)
+	now := time.Date(2022, time.March, 14, 10, 0, 0, 0, time.UTC)
+	elected := make(chan struct{})
+	close(elected)
+
+	newLease := func(holder string, renewed time.Time) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "starboard-lock"},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity: pointer.StringPtr(holder),
+				RenewTime:      &metav1.MicroTime{Time: renewed},
+			},
+		}
+	}
+	newCheck := func(elected <-chan struct{}, lease *coordinationv1.Lease) *controller.LeaderElectionCheck {
+		builder := fake.NewClientBuilder().WithScheme(starboard.NewScheme())
+		if lease != nil {
+			builder = builder.WithObjects(lease)
+		}
+		return &controller.LeaderElectionCheck{
+			Client:        builder.Build(),
+			Clock:         ext.NewFixedClock(now),
+			Elected:       elected,
+			Namespace:     "starboard-system",
+			Name:          "starboard-lock",
+			LeaseDuration: 15 * time.Second,
+		}
+	}
+
+	t.Run("Should pass when replica is not elected", func(t *testing.T) {
+		check := newCheck(make(chan struct{}), nil)
+		assert.NoError(t, check.Check(req))
+	})
+
+	t.Run("Should pass when lease is renewed", func(t *testing.T) {
+		check := newCheck(elected, newLease("starboard-operator-6d4cf56db6-7bs2z_1", now.Add(-5*time.Second)))
+		assert.NoError(t, check.Check(req))
+		assert.NoError(t, check.Check(req))
+	})
+
+	t.Run("Should fail when lease is missing", func(t *testing.T) {
+		check := newCheck(elected, nil)
+		assert.EqualError(t, check.Check(req),
+			`getting lease starboard-system/starboard-lock: leases.coordination.k8s.io "starboard-lock" not found`)
+	})
+
+	t.Run("Should fail when lease is not renewed", func(t *testing.T) {
+		check := newCheck(elected, newLease("starboard-operator-6d4cf56db6-7bs2z_1", now.Add(-time.Minute)))
+		assert.EqualError(t, check.Check(req),
+			"lease starboard-system/starboard-lock was last renewed at 2022-03-14T09:59:00Z, more than 15s ago")
+	})
+
+	t.Run("Should fail when lease is held by another replica", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newLease("starboard-operator-6d4cf56db6-7bs2z_1", now)).
+			Build()
+		check := newCheck(elected, nil)
+		check.Client = c
+		require.NoError(t, check.Check(req))
+
+		var lease coordinationv1.Lease
+		require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "starboard-system", Name: "starboard-lock"}, &lease))
+		lease.Spec.HolderIdentity = pointer.StringPtr("starboard-operator-6d4cf56db6-x9k4p_2")
+		require.NoError(t, c.Update(context.TODO(), &lease))
+		assert.EqualError(t, check.Check(req),
+			"lease starboard-system/starboard-lock is held by starboard-operator-6d4cf56db6-x9k4p_2 instead of starboard-operator-6d4cf56db6-7bs2z_1")
+	})
+}
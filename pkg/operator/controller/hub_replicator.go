@@ -0,0 +1,256 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// HubReplicator replicates VulnerabilityReports and ConfigAuditReports of
+// this cluster to a namespace of the hub cluster, which gives fleet-wide
+// visibility of reports of many clusters.
+//
+// Copies are labeled with the name of the cluster and the namespace and name
+// of the original report, and they are deleted from the hub once the original
+// report is deleted. Copies of reports that were deleted while the operator
+// was not running are deleted by a sweep every OPERATOR_HUB_SWEEP_INTERVAL. In
+// the Summary mode lists of vulnerabilities and checks are dropped from
+// copies, so that the hub only keeps their summaries.
+type HubReplicator struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	// Hub is the client of the hub cluster.
+	Hub  client.Client
+	Mode etc.HubReplicationMode
+	// Sharder is optional. If nil, reports in all namespaces are replicated.
+	Sharder Sharder
+}
+
+func (r *HubReplicator) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+
+	reports := []struct {
+		kind      string
+		newReport func() client.Object
+	}{
+		{kind: v1alpha1.VulnerabilityReportKind, newReport: func() client.Object { return &v1alpha1.VulnerabilityReport{} }},
+		{kind: v1alpha1.ConfigAuditReportKind, newReport: func() client.Object { return &v1alpha1.ConfigAuditReport{} }},
+	}
+
+	for _, report := range reports {
+		err = ctrl.NewControllerManagedBy(mgr).
+			Named("hubreplicator-"+report.kind).
+			For(report.newReport(), builder.OnlyMetadata, builder.WithPredicates(
+				installModePredicate,
+				InShard(r.Sharder))).
+			WithOptions(controllerOptions(r.Config, 1)).
+			Complete(r.reconcileReport(report.newReport))
+		if err != nil {
+			return fmt.Errorf("constructing controller for %s: %w", report.kind, err)
+		}
+	}
+	return mgr.Add(r)
+}
+
+// Start sweeps stale copies of reports from the hub when it's started and
+// then every sweep interval until the context is cancelled.
+func (r *HubReplicator) Start(ctx context.Context) error {
+	if r.Config.HubSweepInterval <= 0 {
+		return fmt.Errorf("OPERATOR_HUB_SWEEP_INTERVAL must be positive")
+	}
+	ticker := time.NewTicker(r.Config.HubSweepInterval)
+	defer ticker.Stop()
+	for {
+		if err := r.sweep(ctx); err != nil {
+			r.Logger.Error(err, "Unable to delete stale copies of reports from hub")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep deletes copies of reports of this cluster from the hub whose original
+// reports do not exist, e.g. because they were deleted while the operator was
+// not running. Errors of individual copies are logged so that they don't
+// prevent sweeping other copies.
+func (r *HubReplicator) sweep(ctx context.Context) error {
+	for _, kind := range []string{v1alpha1.VulnerabilityReportKind, v1alpha1.ConfigAuditReportKind} {
+		copies := &metav1.PartialObjectMetadataList{}
+		copies.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(kind + "List"))
+		err := r.Hub.List(ctx, copies, client.InNamespace(r.Config.HubNamespace),
+			client.MatchingLabels{starboard.LabelClusterName: r.Config.ClusterName})
+		if err != nil {
+			return fmt.Errorf("listing copies of %s: %w", kind, err)
+		}
+		for i := range copies.Items {
+			copied := &copies.Items[i]
+			copied.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(kind))
+			if err := r.sweepCopy(ctx, copied); err != nil {
+				r.Logger.Error(err, "Unable to sweep copy of report", "kind", kind,
+					"copy", copied.Namespace+"/"+copied.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *HubReplicator) sweepCopy(ctx context.Context, copied *metav1.PartialObjectMetadata) error {
+	key := types.NamespacedName{
+		Namespace: copied.Labels[starboard.LabelReportNamespace],
+		Name:      copied.Labels[starboard.LabelReportName],
+	}
+	if key.Namespace == "" || key.Name == "" {
+		return nil
+	}
+	if r.Sharder != nil && !r.Sharder.Owns(key.Namespace) {
+		return nil
+	}
+	report := &metav1.PartialObjectMetadata{}
+	report.SetGroupVersionKind(copied.GroupVersionKind())
+	err := r.Client.Get(ctx, key, report)
+	if err == nil || !k8sapierror.IsNotFound(err) {
+		return err
+	}
+	r.Logger.V(1).Info("Deleting stale copy of report from hub", "kind", copied.Kind,
+		"copy", copied.Namespace+"/"+copied.Name, "report", key)
+	return client.IgnoreNotFound(r.Hub.Delete(ctx, copied))
+}
+
+func (r *HubReplicator) reconcileReport(newReport func() client.Object) reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("report", req.NamespacedName)
+
+		hubKey := types.NamespacedName{
+			Namespace: r.Config.HubNamespace,
+			Name:      GetHubReportName(r.Config.ClusterName, req.NamespacedName),
+		}
+
+		report := newReport()
+		err := r.Client.Get(ctx, req.NamespacedName, report)
+		if err != nil {
+			if !k8sapierror.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("getting report: %w", err)
+			}
+			log.V(1).Info("Deleting copy of deleted report from hub", "copy", hubKey)
+			copied := newReport()
+			copied.SetNamespace(hubKey.Namespace)
+			copied.SetName(hubKey.Name)
+			err = r.Hub.Delete(ctx, copied)
+			if err != nil && !k8sapierror.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("deleting copy of report from hub: %w", err)
+			}
+			return ctrl.Result{}, nil
+		}
+		if report.GetDeletionTimestamp() != nil {
+			return ctrl.Result{}, nil
+		}
+
+		copied := newReport()
+		copied.SetNamespace(hubKey.Namespace)
+		copied.SetName(hubKey.Name)
+		op, err := controllerutil.CreateOrUpdate(ctx, r.Hub, copied, func() error {
+			return r.copyReport(report, copied)
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("replicating report to hub: %w", err)
+		}
+		log.V(1).Info("Replicated report to hub", "copy", hubKey, "operation", op)
+		return ctrl.Result{}, nil
+	}
+}
+
+// hubOriginLabels are labels of reports that identify the scanned workload
+// and image, which are replaced on copies by labels with keys returned by
+// GetHubOriginLabel. Otherwise, the operator would treat copies as reports of
+// workloads in the hub, if it also runs there, and delete them, e.g. because
+// no such workload or image runs in the hub.
+var hubOriginLabels = map[string]bool{
+	starboard.LabelResourceKind:      true,
+	starboard.LabelResourceName:      true,
+	starboard.LabelResourceNameHash:  true,
+	starboard.LabelResourceNamespace: true,
+	starboard.LabelContainerName:     true,
+	starboard.LabelImageDigest:       true,
+	starboard.LabelResourceSpecHash:  true,
+	starboard.LabelPluginConfigHash:  true,
+}
+
+// GetHubOriginLabel returns the key of the label that replaces the specified
+// label of a report on its copy in the hub cluster, e.g.
+// starboard.origin.resource.kind for starboard.resource.kind.
+func GetHubOriginLabel(key string) string {
+	return starboard.LabelHubOriginPrefix + strings.TrimPrefix(key, "starboard.")
+}
+
+// copyReport copies labels and data of the specified report to its copy in
+// the hub cluster.
+//
+// Owner references are not copied, because owners do not exist in the hub.
+func (r *HubReplicator) copyReport(report, copied client.Object) error {
+	labels := make(map[string]string)
+	for key, value := range report.GetLabels() {
+		if hubOriginLabels[key] {
+			key = GetHubOriginLabel(key)
+		}
+		labels[key] = value
+	}
+	labels[starboard.LabelClusterName] = r.Config.ClusterName
+	labels[starboard.LabelReportNamespace] = report.GetNamespace()
+	labels[starboard.LabelReportName] = report.GetName()
+	copied.SetLabels(labels)
+
+	switch report := report.(type) {
+	case *v1alpha1.VulnerabilityReport:
+		data := *report.Report.DeepCopy()
+		if r.Mode == etc.HubReplicationSummary {
+			data.Vulnerabilities = []v1alpha1.Vulnerability{}
+		}
+		copied.(*v1alpha1.VulnerabilityReport).Report = data
+	case *v1alpha1.ConfigAuditReport:
+		data := *report.Report.DeepCopy()
+		if r.Mode == etc.HubReplicationSummary {
+			data.Checks = []v1alpha1.Check{}
+			data.PodChecks = nil
+			data.ContainerChecks = nil
+		}
+		copied.(*v1alpha1.ConfigAuditReport).Report = data
+	default:
+		return fmt.Errorf("unsupported report type: %T", report)
+	}
+	return nil
+}
+
+// GetHubReportName returns the name of the copy of the report with the
+// specified key in the hub cluster, which is unique among copies of reports
+// of all clusters replicated to the same namespace of the hub.
+func GetHubReportName(clusterName string, key types.NamespacedName) string {
+	name := fmt.Sprintf("%s-%s-%s", clusterName, key.Namespace, key.Name)
+	if len(validation.IsDNS1123Subdomain(name)) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s-%s", clusterName, kube.ComputeHash(key.String()))
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestHubReplicator(t *testing.T) {
+	report := &v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "replicaset-nginx-nginx",
+			Labels: map[string]string{
+				starboard.LabelResourceKind: "ReplicaSet",
+				starboard.LabelResourceName: "nginx",
+				starboard.LabelImageDigest:  "2834dc507516af02784808c5f48b7cbe",
+				starboard.LabelSLABreach:    "true",
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2022-0001", Severity: v1alpha1.SeverityCritical},
+			},
+		},
+	}
+	key := types.NamespacedName{Namespace: "starboard-hub", Name: "dev-default-replicaset-nginx-nginx"}
+
+	testCases := []struct {
+		mode            etc.HubReplicationMode
+		vulnerabilities int
+	}{
+		{mode: etc.HubReplicationSummary, vulnerabilities: 0},
+		{mode: etc.HubReplicationFull, vulnerabilities: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.mode), func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(report.DeepCopy()).Build()
+			hub := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+			r := &HubReplicator{
+				Logger: log.Log,
+				Config: etc.Config{ClusterName: "dev", HubNamespace: "starboard-hub"},
+				Client: c,
+				Hub:    hub,
+				Mode:   tc.mode,
+			}
+			reconcile := r.reconcileReport(func() client.Object { return &v1alpha1.VulnerabilityReport{} })
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "replicaset-nginx-nginx"}}
+
+			_, err := reconcile(context.TODO(), req)
+			require.NoError(t, err)
+
+			var copied v1alpha1.VulnerabilityReport
+			require.NoError(t, hub.Get(context.TODO(), key, &copied))
+			assert.Equal(t, map[string]string{
+				"starboard.origin.resource.kind":          "ReplicaSet",
+				"starboard.origin.resource.name":          "nginx",
+				"starboard.origin.container.image-digest": "2834dc507516af02784808c5f48b7cbe",
+				starboard.LabelSLABreach:                  "true",
+				starboard.LabelClusterName:                "dev",
+				starboard.LabelReportNamespace:            "default",
+				starboard.LabelReportName:                 "replicaset-nginx-nginx",
+			}, copied.Labels)
+			assert.Equal(t, 1, copied.Report.Summary.CriticalCount)
+			assert.Len(t, copied.Report.Vulnerabilities, tc.vulnerabilities)
+
+			require.NoError(t, c.Delete(context.TODO(), report.DeepCopy()))
+			_, err = reconcile(context.TODO(), req)
+			require.NoError(t, err)
+
+			err = hub.Get(context.TODO(), key, &v1alpha1.VulnerabilityReport{})
+			assert.True(t, k8sapierror.IsNotFound(err), "copy should be deleted from hub")
+		})
+	}
+}
+
+func TestHubReplicator_Sweep(t *testing.T) {
+	newCopy := func(cluster, namespace, name string) *v1alpha1.VulnerabilityReport {
+		return &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "starboard-hub",
+				Name:      GetHubReportName(cluster, types.NamespacedName{Namespace: namespace, Name: name}),
+				Labels: map[string]string{
+					starboard.LabelClusterName:     cluster,
+					starboard.LabelReportNamespace: namespace,
+					starboard.LabelReportName:      name,
+				},
+			},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "replicaset-nginx-nginx"},
+		},
+	).Build()
+	hub := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		newCopy("dev", "default", "replicaset-nginx-nginx"),
+		newCopy("dev", "default", "replicaset-redis-redis"),
+		newCopy("prod", "default", "replicaset-redis-redis"),
+	).Build()
+	r := &HubReplicator{
+		Logger: log.Log,
+		Config: etc.Config{ClusterName: "dev", HubNamespace: "starboard-hub"},
+		Client: c,
+		Hub:    hub,
+		Mode:   etc.HubReplicationSummary,
+	}
+
+	require.NoError(t, r.sweep(context.TODO()))
+
+	var copies v1alpha1.VulnerabilityReportList
+	require.NoError(t, hub.List(context.TODO(), &copies))
+	var names []string
+	for _, copied := range copies.Items {
+		names = append(names, copied.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"dev-default-replicaset-nginx-nginx",
+		"prod-default-replicaset-redis-redis",
+	}, names, "only the copy of the deleted report of this cluster should be deleted")
+}
+
+func TestGetHubReportName(t *testing.T) {
+	assert.Equal(t, "dev-default-replicaset-nginx-nginx",
+		GetHubReportName("dev", types.NamespacedName{Namespace: "default", Name: "replicaset-nginx-nginx"}))
+
+	name := GetHubReportName("dev", types.NamespacedName{Namespace: "default", Name: strings.Repeat("a", 253)})
+	assert.True(t, strings.HasPrefix(name, "dev-"))
+	assert.LessOrEqual(t, len(name), 253)
+}
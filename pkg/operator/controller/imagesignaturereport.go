@@ -0,0 +1,532 @@
+package controller
+
+import (
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/imagesignature"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ImageSignatureReportReconciler verifies signatures of container images that
+// are subject to image signature policies and records the results as
+// v1alpha1.ImageSignatureReport instances.
+type ImageSignatureReportReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	kube.ObjectResolver
+	LimitChecker
+	RateLimiter
+	kube.LogsReader
+	kube.SecretsReader
+	imagesignature.Plugin
+	starboard.PluginContext
+	imagesignature.ReadWriter
+	starboard.ConfigData
+	// QuotaChecker is optional. If nil, scan jobs are created regardless of
+	// ResourceQuotas of the scan jobs namespace.
+	QuotaChecker QuotaChecker
+	// Sharder is optional. If nil, workloads in all namespaces are reconciled.
+	Sharder Sharder
+	// NamespaceSelector is optional. If nil, workloads are reconciled regardless
+	// of labels of their namespaces.
+	NamespaceSelector NamespaceSelector
+}
+
+func (r *ImageSignatureReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+
+	workloads := []struct {
+		kind       kube.Kind
+		forObject  client.Object
+		ownsObject client.Object
+	}{
+		{kind: kube.KindPod, forObject: &corev1.Pod{}, ownsObject: &v1alpha1.ImageSignatureReport{}},
+		{kind: kube.KindReplicaSet, forObject: &appsv1.ReplicaSet{}, ownsObject: &v1alpha1.ImageSignatureReport{}},
+		{kind: kube.KindReplicationController, forObject: &corev1.ReplicationController{}, ownsObject: &v1alpha1.ImageSignatureReport{}},
+		{kind: kube.KindStatefulSet, forObject: &appsv1.StatefulSet{}, ownsObject: &v1alpha1.ImageSignatureReport{}},
+		{kind: kube.KindDaemonSet, forObject: &appsv1.DaemonSet{}, ownsObject: &v1alpha1.ImageSignatureReport{}},
+		{kind: kube.KindCronJob, forObject: &batchv1beta1.CronJob{}, ownsObject: &v1alpha1.ImageSignatureReport{}},
+		{kind: kube.KindJob, forObject: &batchv1.Job{}, ownsObject: &v1alpha1.ImageSignatureReport{}},
+	}
+
+	for _, workload := range workloads {
+		workloadPredicates := []predicate.Predicate{
+			Not(ManagedByStarboardOperator),
+			Not(IsBeingTerminated),
+			installModePredicate,
+			InSelectedNamespace(r.NamespaceSelector),
+			ScanEnabled(r.Config.ScanOptInEnabled),
+		}
+		b := ctrl.NewControllerManagedBy(mgr).
+			WithOptions(controllerOptions(r.Config, 1)).
+			For(workload.forObject, builder.WithPredicates(append(workloadPredicates, InShard(r.Sharder))...)).
+			Owns(workload.ownsObject, builder.OnlyMetadata, builder.WithPredicates(InShard(r.Sharder)))
+		b = watchSelectedNamespaces(b, r.NamespaceSelector,
+			objectsInNamespace(r.Logger, mgr.GetClient(), workload.forObject, false, workloadPredicates...))
+		err = watchGainedNamespaces(b, r.Sharder,
+			objectsInNamespace(r.Logger, mgr.GetClient(), workload.forObject, false, workloadPredicates...)).
+			Complete(r.reconcileWorkload(workload.kind))
+		if err != nil {
+			return err
+		}
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controllerOptions(r.Config, 1)).
+		For(&batchv1.Job{}, builder.WithPredicates(
+			InNamespace(r.Config.GetScanJobsNamespace()),
+			ManagedByStarboardOperator,
+			IsImageSignatureReportScan,
+			JobHasAnyCondition,
+			ScanJobInShard(r.Sharder),
+		))
+	return watchGainedNamespaces(b, r.Sharder,
+		scanJobsOfNamespace(r.Logger, mgr.GetClient(), r.Config.GetScanJobsNamespace(), starboard.LabelImageSignatureReportScanner)).
+		Complete(r.reconcileJobs())
+}
+
+func (r *ImageSignatureReportReconciler) reconcileWorkload(workloadKind kube.Kind) reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("kind", workloadKind, "name", req.NamespacedName)
+
+		workloadPartial := kube.ObjectRefFromKindAndNamespacedName(workloadKind, req.NamespacedName)
+
+		log.V(1).Info("Getting workload from cache")
+		workloadObj, err := r.ObjectFromObjectRef(ctx, workloadPartial)
+		if err != nil {
+			if k8sapierror.IsNotFound(err) {
+				log.V(1).Info("Ignoring cached workload that must have been deleted")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting %s from cache: %w", workloadKind, err)
+		}
+
+		// Skip processing if scanning is disabled by the annotation of the
+		// workload. Events of owned reports pass the predicates of workloads.
+		if !IsScanEnabled(workloadObj, r.Config.ScanOptInEnabled) {
+			log.V(1).Info("Ignoring workload with scanning disabled", "annotation", starboard.AnnotationScan)
+			return ctrl.Result{}, nil
+		}
+
+		// Skip processing if it's a Pod controlled by a built-in K8s workload.
+		if workloadKind == kube.KindPod {
+			controller := metav1.GetControllerOf(workloadObj)
+			if kube.IsBuiltInWorkload(controller) {
+				log.V(1).Info("Ignoring managed pod", "controllerKind", controller.Kind, "controllerName", controller.Name)
+				return ctrl.Result{}, nil
+			}
+		}
+
+		// Skip processing if it's a Job controlled by CronJob.
+		if workloadKind == kube.KindJob {
+			controller := metav1.GetControllerOf(workloadObj)
+			if controller != nil && controller.Kind == string(kube.KindCronJob) {
+				log.V(1).Info("Ignoring managed job", "controllerKind", controller.Kind, "controllerName", controller.Name)
+				return ctrl.Result{}, nil
+			}
+		}
+
+		podSpec, err := kube.GetPodSpec(workloadObj)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		containerImages, err := r.Plugin.GetContainerImages(workloadObj)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("getting container images: %w", err)
+		}
+
+		if len(containerImages) == 0 {
+			log.V(1).Info("Ignoring workload without images subject to image signature policies")
+			return ctrl.Result{}, nil
+		}
+
+		hash := kube.ComputeHash(podSpec)
+
+		log = log.WithValues("podSpecHash", hash)
+
+		// Check if containers of the Pod have corresponding ImageSignatureReports.
+		hasReports, err := r.hasReports(ctx, workloadPartial, hash, containerImages)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("getting image signature reports: %w", err)
+		}
+
+		if hasReports {
+			log.V(1).Info("ImageSignatureReports already exist")
+			return ctrl.Result{}, nil
+		}
+
+		_, job, err := r.hasActiveScanJob(ctx, workloadObj, hash)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("checking scan job: %w", err)
+		}
+
+		if job != nil {
+			log.V(1).Info("Scan job already exists",
+				"job", fmt.Sprintf("%s/%s", job.Namespace, job.Name))
+			return ctrl.Result{}, nil
+		}
+
+		limitExceeded, scanJobsCount, err := r.LimitChecker.Check(ctx)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.V(1).Info("Checking scan jobs limit", "count", scanJobsCount, "limit", r.ConcurrentScanJobsLimit)
+
+		if limitExceeded {
+			log.V(1).Info("Pushing back scan job", "count", scanJobsCount, "retryAfter", r.ScanJobRetryAfter)
+			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+		}
+
+		return r.submitScanJob(ctx, workloadObj)
+	}
+}
+
+func (r *ImageSignatureReportReconciler) hasReports(ctx context.Context, owner kube.ObjectRef, hash string, images kube.ContainerImages) (bool, error) {
+	list, err := listReportsMetadata(ctx, r.Client, v1alpha1.ImageSignatureReportKind, owner)
+	if err != nil {
+		return false, err
+	}
+
+	actual := map[string]bool{}
+	for _, report := range list {
+		if containerName, ok := report.Labels[starboard.LabelContainerName]; ok {
+			if hash == report.Labels[starboard.LabelResourceSpecHash] {
+				actual[containerName] = true
+			}
+		}
+	}
+
+	expected := map[string]bool{}
+	for containerName := range images {
+		expected[containerName] = true
+	}
+
+	return reflect.DeepEqual(actual, expected), nil
+}
+
+func (r *ImageSignatureReportReconciler) hasActiveScanJob(ctx context.Context, owner client.Object, hash string) (bool, *batchv1.Job, error) {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: r.Config.GetScanJobsNamespace(), Name: imagesignature.GetScanJobName(owner)}, job)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return false, nil, nil
+		}
+		return false, nil, fmt.Errorf("getting job from cache: %w", err)
+	}
+	if job.Labels[starboard.LabelResourceSpecHash] == hash {
+		return true, job, nil
+	}
+	return false, nil, nil
+}
+
+func (r *ImageSignatureReportReconciler) submitScanJob(ctx context.Context, owner client.Object) (ctrl.Result, error) {
+	log := r.Logger.WithValues("kind", owner.GetObjectKind().GroupVersionKind().Kind,
+		"name", owner.GetName(), "namespace", owner.GetNamespace())
+	credentials, err := r.CredentialsByWorkload(ctx, owner)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	scanJobTolerations, err := r.GetScanJobTolerations()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting scan job tolerations: %w", err)
+	}
+
+	scanJobScheduling, err := r.GetScanJobScheduling()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting scan job scheduling: %w", err)
+	}
+
+	scanJobSandbox, err := r.GetScanJobSandbox()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting scan job sandbox: %w", err)
+	}
+
+	scanJobImages, err := r.GetScanJobImages()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting scan job images: %w", err)
+	}
+
+	scanJobAnnotations, err := r.GetScanJobAnnotations()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting scan job annotations: %w", err)
+	}
+
+	scanJobPodTemplateLabels, err := r.GetScanJobPodTemplateLabels()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting scan job template labels: %w", err)
+	}
+
+	commonMetadata, err := r.GetCommonMetadata()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting common metadata: %w", err)
+	}
+
+	scanJob, secrets, err := imagesignature.NewScanJobBuilder().
+		WithPlugin(r.Plugin).
+		WithPluginContext(r.PluginContext).
+		WithTimeout(r.Config.ScanJobTimeout).
+		WithObject(owner).
+		WithTolerations(scanJobTolerations).
+		WithScheduling(scanJobScheduling).
+		WithSandbox(scanJobSandbox).
+		WithImages(scanJobImages).
+		WithAnnotations(scanJobAnnotations).
+		WithPodTemplateLabels(scanJobPodTemplateLabels).
+		WithCredentials(credentials).
+		WithCommonMetadata(commonMetadata).
+		Get()
+
+	if err != nil {
+		if errors.Is(err, kube.ErrReplicaSetNotFound) || errors.Is(err, kube.ErrNoRunningPods) ||
+			errors.Is(err, kube.ErrUnSupportedKind) {
+			log.V(1).Info("ignoring image signature verification", "reason", err)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("constructing scan job: %w", err)
+	}
+
+	if r.QuotaChecker != nil {
+		quota, err := r.QuotaChecker.Check(ctx, scanJob)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("checking resource quotas: %w", err)
+		}
+		if quota != "" {
+			log.V(1).Info("Pushing back scan job", "reason", "resource quota exceeded", "quota", quota,
+				"retryAfter", r.ScanJobRetryAfter)
+			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+		}
+	}
+
+	if delay := r.RateLimiter.Reserve(); delay > 0 {
+		log.V(1).Info("Pushing back scan job", "reason", "scan jobs rate limit exceeded", "retryAfter", delay)
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
+	for _, secret := range secrets {
+		secret.Namespace = r.PluginContext.GetNamespace()
+		err = r.Client.Create(ctx, secret)
+		if err != nil {
+			if k8sapierror.IsAlreadyExists(err) {
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("creating secret used by scan job failed: %s: %w", secret.Namespace+"/"+secret.Name, err)
+		}
+	}
+
+	err = r.Client.Create(ctx, scanJob)
+	if err != nil {
+		if k8sapierror.IsAlreadyExists(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("creating scan job failed: %s: %w", scanJob.Namespace+"/"+scanJob.Name, err)
+	}
+
+	for _, secret := range secrets {
+		err = controllerutil.SetOwnerReference(scanJob, secret, r.Client.Scheme())
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("setting owner reference: %w", err)
+		}
+		err := r.Client.Update(ctx, secret)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("setting owner reference of secret used by scan job failed: %s: %w", secret.Namespace+"/"+secret.Name, err)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *ImageSignatureReportReconciler) reconcileJobs() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("job", req.NamespacedName)
+
+		job := &batchv1.Job{}
+		err := r.Client.Get(ctx, req.NamespacedName, job)
+		if err != nil {
+			if k8sapierror.IsNotFound(err) {
+				log.V(1).Info("Ignoring cached job that must have been deleted")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting job from cache: %w", err)
+		}
+
+		if len(job.Status.Conditions) == 0 {
+			log.V(1).Info("Ignoring Job without conditions")
+			return ctrl.Result{}, nil
+		}
+
+		switch jobCondition := job.Status.Conditions[0].Type; jobCondition {
+		case batchv1.JobComplete, batchv1.JobFailed:
+			// Cosign exits with non-zero code when an image does not have
+			// a valid signature, which is not a failure of the scan job
+			// itself. Therefore, failed jobs also produce reports.
+			err = r.processScanJob(ctx, job)
+		default:
+			err = fmt.Errorf("unrecognized scan job condition: %v", jobCondition)
+		}
+
+		return ctrl.Result{}, err
+	}
+}
+
+func (r *ImageSignatureReportReconciler) processScanJob(ctx context.Context, job *batchv1.Job) error {
+	log := r.Logger.WithValues("job", fmt.Sprintf("%s/%s", job.Namespace, job.Name))
+
+	ownerRef, err := kube.ObjectRefFromObjectMeta(job.ObjectMeta)
+	if err != nil {
+		return fmt.Errorf("getting owner ref from scan job metadata: %w", err)
+	}
+
+	owner, err := r.ObjectFromObjectRef(ctx, ownerRef)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.V(1).Info("Report owner must have been deleted", "owner", owner)
+			return r.deleteJob(ctx, job)
+		}
+		return fmt.Errorf("getting object from object ref: %w", err)
+	}
+
+	containerImages, err := kube.GetContainerImagesFromJob(job)
+	if err != nil {
+		return fmt.Errorf("getting container images: %w", err)
+	}
+
+	podSpecHash, ok := job.Labels[starboard.LabelResourceSpecHash]
+	if !ok {
+		return fmt.Errorf("expected label %s not set", starboard.LabelResourceSpecHash)
+	}
+
+	hasReports, err := r.hasReports(ctx, ownerRef, podSpecHash, containerImages)
+	if err != nil {
+		return err
+	}
+
+	if hasReports {
+		log.V(1).Info("ImageSignatureReports already exist", "owner", owner)
+		log.V(1).Info("Deleting scan job", "owner", owner)
+		return r.deleteJob(ctx, job)
+	}
+
+	statuses, err := r.GetTerminatedContainersStatusesByJob(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	nameFormat, err := r.GetReportNameFormat()
+	if err != nil {
+		return err
+	}
+
+	reportLabels, reportAnnotations, err := getPropagatedMetadata(ctx, r.ObjectResolver, r.Config, r.ConfigData, owner)
+	if err != nil {
+		return err
+	}
+
+	var reports []v1alpha1.ImageSignatureReport
+
+	for containerName, containerImage := range containerImages {
+		var reportData v1alpha1.ImageSignatureReportData
+
+		if status, ok := statuses[containerName]; ok && status.ExitCode != 0 {
+			log.V(1).Info("Image signature verification failed", "container", containerName,
+				"status.reason", status.Reason, "status.message", status.Message)
+			reportData, err = r.Plugin.GetFailedImageSignatureReportData(containerImage, status.Message)
+			if err != nil {
+				return err
+			}
+		} else {
+			logsStream, err := r.LogsReader.GetLogsByJobAndContainerName(ctx, job, containerName)
+			if err != nil {
+				return fmt.Errorf("getting logs for pod %q: %w", job.Namespace+"/"+job.Name, err)
+			}
+			reportData, err = r.Plugin.ParseImageSignatureReportData(containerImage, logsStream)
+			_ = logsStream.Close()
+			if err != nil {
+				return err
+			}
+		}
+
+		// Provenance attestations are verified by a separate container, which
+		// exists only if the policy requires provenance verification.
+		provenanceContainerName := imagesignature.GetProvenanceContainerName(containerName)
+		if status, ok := statuses[provenanceContainerName]; ok {
+			if status.ExitCode != 0 {
+				log.V(1).Info("Provenance verification failed", "container", provenanceContainerName,
+					"status.reason", status.Reason, "status.message", status.Message)
+				reportData.Provenance, err = r.Plugin.GetFailedImageProvenance(containerImage, status.Message)
+				if err != nil {
+					return err
+				}
+			} else {
+				logsStream, err := r.LogsReader.GetLogsByJobAndContainerName(ctx, job, provenanceContainerName)
+				if err != nil {
+					return fmt.Errorf("getting logs for pod %q: %w", job.Namespace+"/"+job.Name, err)
+				}
+				reportData.Provenance, err = r.Plugin.ParseImageProvenance(containerImage, logsStream)
+				_ = logsStream.Close()
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		report, err := imagesignature.NewReportBuilder(r.Client.Scheme()).
+			Controller(owner).
+			Labels(reportLabels).
+			Annotations(reportAnnotations).
+			Container(containerName).
+			Data(reportData).
+			PodSpecHash(podSpecHash).
+			NameFormat(nameFormat).
+			Get()
+		if err != nil {
+			return err
+		}
+
+		reports = append(reports, report)
+	}
+
+	err = r.ReadWriter.Write(ctx, reports)
+	if err != nil {
+		return err
+	}
+
+	log.V(1).Info("Deleting scan job", "owner", owner)
+	return r.deleteJob(ctx, job)
+}
+
+func (r *ImageSignatureReportReconciler) deleteJob(ctx context.Context, job *batchv1.Job) error {
+	err := r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("deleting job: %w", err)
+	}
+	return nil
+}
@@ -38,10 +38,19 @@ func (c *checker) countScanJobs(ctx context.Context) (int, error) {
 	var scanJobs batchv1.JobList
 	err := c.client.List(ctx, &scanJobs, client.MatchingLabels{
 		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
-	}, client.InNamespace(c.config.Namespace))
+	}, client.InNamespace(c.config.GetScanJobsNamespace()))
 	if err != nil {
 		return 0, err
 	}
 
-	return len(scanJobs.Items), nil
+	count := 0
+	for _, job := range scanJobs.Items {
+		// Failed scan jobs are kept until they are retried, but they do not
+		// run any pods.
+		if hasJobCondition(job, batchv1.JobFailed) {
+			continue
+		}
+		count++
+	}
+	return count, nil
 }
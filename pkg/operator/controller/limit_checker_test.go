@@ -10,6 +10,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -62,6 +63,42 @@ var _ = Describe("LimitChecker", func() {
 
 	})
 
+	Context("When there are failed jobs", func() {
+
+		It("Should not count them", func() {
+			client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				&batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "scan-vulnerabilityreport-hash1",
+						Namespace: "starboard-operator",
+						Labels: map[string]string{
+							starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+						},
+					},
+					Status: batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{
+							{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+						},
+					},
+				},
+				&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+					Name:      "scan-vulnerabilityreport-hash2",
+					Namespace: "starboard-operator",
+					Labels: map[string]string{
+						starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+					},
+				}},
+			).Build()
+
+			instance := controller.NewLimitChecker(config, client)
+			limitExceeded, jobsCount, err := instance.Check(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(limitExceeded).To(BeFalse())
+			Expect(jobsCount).To(Equal(1))
+		})
+
+	})
+
 	Context("When there are less jobs than limit", func() {
 
 		It("Should return false", func() {
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// IndexImageDigest is the name of the field index of metadata of
+	// VulnerabilityReports by the digest of the scanned image, as encoded in
+	// the starboard.LabelImageDigest label.
+	IndexImageDigest = "report.imageDigest"
+	// IndexVulnerabilityID is the name of the field index of metadata of
+	// VulnerabilityReports by IDs of vulnerabilities they contain.
+	IndexVulnerabilityID = "report.vulnerabilityID"
+)
+
+// ReportsNotCached returns reports which are read from the API server instead
+// of the cache of the manager.
+//
+// Reports are watched with metadata-only informers, because their labels are
+// enough to decide whether a workload must be scanned, whereas their data,
+// e.g. the list of vulnerabilities, takes most of the memory of the operator.
+// Bypassing the cache for full reports prevents it from starting informers
+// that would keep all reports in memory next to their metadata.
+func ReportsNotCached() []client.Object {
+	return []client.Object{
+		&v1alpha1.VulnerabilityReport{},
+		&v1alpha1.ConfigAuditReport{},
+		&v1alpha1.ClusterConfigAuditReport{},
+		&v1alpha1.ClusterVulnerabilityReport{},
+		&v1alpha1.CISKubeBenchReport{},
+		&v1alpha1.ImageSignatureReport{},
+		&v1alpha1.ScanFailureReport{},
+		&v1alpha1.VulnerabilityHistory{},
+		&v1alpha1.ExposureReport{},
+		&v1alpha1.ClusterNetworkPolicyReport{},
+	}
+}
+
+// IndexVulnerabilityReports registers field indexes of metadata of
+// VulnerabilityReports, which allow finding reports of an image digest or
+// reports that contain a vulnerability without iterating over all reports in
+// the cache. It must be called before the manager is started.
+func IndexVulnerabilityReports(ctx context.Context, indexer client.FieldIndexer) error {
+	obj := newReportMetadata(v1alpha1.VulnerabilityReportKind)
+	err := indexer.IndexField(ctx, obj, IndexImageDigest, indexImageDigest)
+	if err != nil {
+		return fmt.Errorf("indexing %s: %w", IndexImageDigest, err)
+	}
+	err = indexer.IndexField(ctx, obj, IndexVulnerabilityID, indexVulnerabilityID)
+	if err != nil {
+		return fmt.Errorf("indexing %s: %w", IndexVulnerabilityID, err)
+	}
+	return nil
+}
+
+func indexImageDigest(obj client.Object) []string {
+	if digest, ok := obj.GetLabels()[starboard.LabelImageDigest]; ok {
+		return []string{digest}
+	}
+	return nil
+}
+
+func indexVulnerabilityID(obj client.Object) []string {
+	return vulnerabilityreport.GetVulnerabilityIDs(obj.GetLabels())
+}
+
+// newReportMetadata returns the metav1.PartialObjectMetadata of a report of
+// the specified kind.
+func newReportMetadata(kind string) *metav1.PartialObjectMetadata {
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(kind))
+	return obj
+}
+
+// listReportsMetadata returns metadata of reports of the specified kind owned
+// by the specified object from the metadata-only cache.
+func listReportsMetadata(ctx context.Context, c client.Client, kind string, owner kube.ObjectRef) ([]metav1.PartialObjectMetadata, error) {
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(kind + "List"))
+	err := c.List(ctx, list, client.MatchingLabels(kube.ObjectRefToLabels(owner)), client.InNamespace(owner.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("listing %s metadata: %w", kind, err)
+	}
+	return list.Items, nil
+}
+
+// listReportsMetadataByField returns metadata of reports of the specified
+// kind, in all namespaces, whose field index has the specified value.
+func listReportsMetadataByField(ctx context.Context, c client.Client, kind, field, value string) ([]metav1.PartialObjectMetadata, error) {
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(kind + "List"))
+	err := c.List(ctx, list, client.MatchingFields{field: value})
+	if err != nil {
+		return nil, fmt.Errorf("listing %s metadata by %s: %w", kind, field, err)
+	}
+	return list.Items, nil
+}
+
+// getReportMetadata returns metadata of the report of the specified kind with
+// the specified key from the metadata-only cache, or nil if the report does
+// not exist.
+func getReportMetadata(ctx context.Context, c client.Client, kind string, key types.NamespacedName) (*metav1.PartialObjectMetadata, error) {
+	obj := newReportMetadata(kind)
+	err := c.Get(ctx, key, obj)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting %s metadata: %w", kind, err)
+	}
+	return obj, nil
+}
+
+// firstVulnerabilityReportOfOwner returns the handler.MapFunc that enqueues
+// the first VulnerabilityReport of the workload of a report, for controllers
+// that process all reports of a workload together.
+func firstVulnerabilityReportOfOwner(log logr.Logger, c client.Client) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		owner, err := kube.ObjectRefFromObjectMeta(metav1.ObjectMeta{Labels: obj.GetLabels(), Annotations: obj.GetAnnotations()})
+		if err != nil {
+			return nil
+		}
+		reports, err := listReportsMetadata(context.Background(), c, v1alpha1.VulnerabilityReportKind, owner)
+		if err != nil {
+			log.Error(err, "Unable to list reports of workload", "owner", owner)
+			return nil
+		}
+		if len(reports) == 0 {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(&reports[0])}}
+	}
+}
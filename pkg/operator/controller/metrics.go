@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	vulnerabilityDBUpdatedTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starboard_vulnerability_db_updated_timestamp_seconds",
+		Help: "Time, in seconds since the epoch, when the vulnerability database used by the last completed scan job was built.",
+	}, []string{"scanner"})
+
+	vulnerabilityDBNextUpdateTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starboard_vulnerability_db_next_update_timestamp_seconds",
+		Help: "Time, in seconds since the epoch, when the next build of the vulnerability database used by the last completed scan job is expected.",
+	}, []string{"scanner"})
+
+	vulnerabilityDBAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starboard_vulnerability_db_age_seconds",
+		Help: "Age, in seconds, of the vulnerability database used by the last completed scan job at the time of the scan.",
+	}, []string{"scanner"})
+
+	vulnerabilityRemediationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "starboard_vulnerability_remediation_duration_seconds",
+		Help:    "Time, in seconds, from when a vulnerability first appeared in reports of a workload to when it disappeared from them.",
+		Buckets: []float64{3600, 6 * 3600, 86400, 3 * 86400, 7 * 86400, 14 * 86400, 30 * 86400, 60 * 86400, 90 * 86400, 180 * 86400},
+	}, []string{"namespace", "severity"})
+
+	vulnerabilitySLABreaches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "starboard_vulnerability_sla_breaches_total",
+		Help: "Number of times workloads were labeled with starboard.sla-breach=true because their open vulnerabilities breached the SLA.",
+	}, []string{"namespace"})
+
+	workloadRiskScore = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starboard_workload_risk_score",
+		Help: "Risk score, from 0 to 100, of the workload combining its vulnerabilities, config audit failures and exposure.",
+	}, []string{"namespace", "resource_kind", "resource_name"})
+
+	dryRunOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "starboard_dry_run_operations_total",
+		Help: "Number of writes of objects that the operator would have made if it wasn't running in dry-run mode.",
+	}, []string{"operation", "kind"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(vulnerabilityDBUpdatedTimestamp, vulnerabilityDBNextUpdateTimestamp,
+		vulnerabilityDBAge, vulnerabilityRemediationDuration, vulnerabilitySLABreaches, workloadRiskScore, dryRunOperations)
+}
+
+// NewRunRegistry returns the registry of metrics registered while the
+// operator runs with the current configuration. It also gathers metrics of
+// the specified registry, which are registered once per process, e.g.
+// metrics of controller-runtime, whereas metrics registered into the
+// returned registry are dropped when the configuration is reloaded.
+func NewRunRegistry(base prometheus.Gatherer) metrics.RegistererGatherer {
+	registry := prometheus.NewRegistry()
+	return &runRegistry{Registerer: registry, Gatherer: prometheus.Gatherers{base, registry}}
+}
+
+type runRegistry struct {
+	prometheus.Registerer
+	prometheus.Gatherer
+}
+
+// WithClusterLabels returns the registry whose metrics are labeled with the
+// cluster_name and cluster_environment labels, if the name or the environment
+// of the cluster are configured, so that metrics scraped from many clusters
+// into one place can be told apart. Labels of metrics take precedence.
+//
+// It wraps the registry at gather time, therefore it also labels metrics that
+// were registered before it's called, e.g. metrics of controller-runtime.
+func WithClusterLabels(registry metrics.RegistererGatherer, config etc.Config) metrics.RegistererGatherer {
+	var labels []*dto.LabelPair
+	for name, value := range map[string]string{
+		"cluster_name":        config.ClusterName,
+		"cluster_environment": config.ClusterEnvironment,
+	} {
+		if value == "" {
+			continue
+		}
+		name, value := name, value
+		labels = append(labels, &dto.LabelPair{Name: &name, Value: &value})
+	}
+	if len(labels) == 0 {
+		return registry
+	}
+	return &labeledRegistry{RegistererGatherer: registry, labels: labels}
+}
+
+type labeledRegistry struct {
+	metrics.RegistererGatherer
+	labels []*dto.LabelPair
+}
+
+func (r *labeledRegistry) Gather() ([]*dto.MetricFamily, error) {
+	families, err := r.RegistererGatherer.Gather()
+	for _, family := range families {
+		for _, metric := range family.Metric {
+			metric.Label = withLabels(metric.Label, r.labels)
+		}
+	}
+	return families, err
+}
+
+func withLabels(pairs, labels []*dto.LabelPair) []*dto.LabelPair {
+	names := make(map[string]bool)
+	for _, pair := range pairs {
+		names[pair.GetName()] = true
+	}
+	for _, label := range labels {
+		if !names[label.GetName()] {
+			pairs = append(pairs, label)
+		}
+	}
+	// Prometheus expects labels of a metric to be sorted by name.
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].GetName() < pairs[j].GetName()
+	})
+	return pairs
+}
+
+// VulnerabilityReportsCollector collects counts of vulnerabilities of each
+// severity in VulnerabilityReports as the
+// starboard_vulnerability_report_vulnerabilities gauge. Metrics are labeled
+// with the workload and the container of each report, and with labels copied
+// from workloads onto reports, e.g. label_team for the team label, so that
+// findings can be attributed to their owners.
+//
+// Full reports are not cached by the operator, therefore they are listed
+// page by page from the API server at each scrape.
+type VulnerabilityReportsCollector struct {
+	Client client.Reader
+	Config etc.Config
+}
+
+// Describe doesn't send descriptors, which makes the collector unchecked,
+// because names of labels depend on labels of reports.
+func (c *VulnerabilityReportsCollector) Describe(chan<- *prometheus.Desc) {
+}
+
+func (c *VulnerabilityReportsCollector) Collect(ch chan<- prometheus.Metric) {
+	const name = "starboard_vulnerability_report_vulnerabilities"
+	const help = "Number of vulnerabilities of the given severity in the VulnerabilityReport."
+	labelNames := []string{"namespace", "name", "resource_kind", "resource_name", "container", "severity"}
+
+	var reports []v1alpha1.VulnerabilityReport
+	var list v1alpha1.VulnerabilityReportList
+	for {
+		err := c.Client.List(context.Background(), &list, client.Limit(500), client.Continue(list.Continue))
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(prometheus.NewDesc(name, help, nil, nil), err)
+			return
+		}
+		reports = append(reports, list.Items...)
+		if list.Continue == "" {
+			break
+		}
+	}
+
+	// Metrics of one family must have the same labels, therefore each metric
+	// gets the propagated labels of all reports.
+	keys := make(map[string]string)
+	for _, report := range reports {
+		for key := range report.Labels {
+			if c.Config.IsPropagated(key) {
+				labelName := "label_" + invalidMetricLabelChars.ReplaceAllString(key, "_")
+				if existing, ok := keys[labelName]; !ok || key < existing {
+					keys[labelName] = key
+				}
+			}
+		}
+	}
+	propagatedNames := make([]string, 0, len(keys))
+	for labelName := range keys {
+		propagatedNames = append(propagatedNames, labelName)
+	}
+	sort.Strings(propagatedNames)
+	desc := prometheus.NewDesc(name, help, append(labelNames, propagatedNames...), nil)
+
+	for _, report := range reports {
+		resourceName := report.Labels[starboard.LabelResourceName]
+		if resourceName == "" {
+			resourceName = report.Annotations[starboard.LabelResourceName]
+		}
+		values := []string{report.Namespace, report.Name, report.Labels[starboard.LabelResourceKind], resourceName,
+			report.Labels[starboard.LabelContainerName], ""}
+		for _, labelName := range propagatedNames {
+			values = append(values, report.Labels[keys[labelName]])
+		}
+		summary := report.Report.Summary
+		for severity, count := range map[v1alpha1.Severity]int{
+			v1alpha1.SeverityCritical: summary.CriticalCount,
+			v1alpha1.SeverityHigh:     summary.HighCount,
+			v1alpha1.SeverityMedium:   summary.MediumCount,
+			v1alpha1.SeverityLow:      summary.LowCount,
+			v1alpha1.SeverityUnknown:  summary.UnknownCount,
+		} {
+			values[5] = string(severity)
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(count), values...)
+		}
+	}
+}
+
+// invalidMetricLabelChars matches characters that are not allowed in names of
+// labels of metrics, such as dots and slashes in keys of Kubernetes labels.
+var invalidMetricLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWithClusterLabels(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starboard_test_gauge",
+	}, []string{"scanner", "cluster_name"})
+	registry.MustRegister(gauge)
+	gauge.WithLabelValues("Trivy", "").Set(1)
+
+	assert.Same(t, registry, WithClusterLabels(registry, etc.Config{}))
+
+	families, err := WithClusterLabels(registry, etc.Config{
+		ClusterName:        "eu-west-1-prod",
+		ClusterEnvironment: "production",
+	}).Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	require.Len(t, families[0].Metric, 1)
+
+	labels := make(map[string]string)
+	var names []string
+	for _, pair := range families[0].Metric[0].Label {
+		labels[pair.GetName()] = pair.GetValue()
+		names = append(names, pair.GetName())
+	}
+	assert.Equal(t, map[string]string{
+		"cluster_environment": "production",
+		"cluster_name":        "",
+		"scanner":             "Trivy",
+	}, labels)
+	assert.Equal(t, []string{"cluster_environment", "cluster_name", "scanner"}, names)
+}
+
+func TestNewRunRegistry(t *testing.T) {
+	base := prometheus.NewRegistry()
+	base.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "starboard_test_base"}))
+	collector := prometheus.NewGauge(prometheus.GaugeOpts{Name: "starboard_test_run"})
+
+	for i := 0; i < 2; i++ {
+		registry := NewRunRegistry(base)
+		require.NoError(t, registry.Register(collector))
+
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		require.Len(t, families, 2)
+		assert.Equal(t, "starboard_test_base", families[0].GetName())
+		assert.Equal(t, "starboard_test_run", families[1].GetName())
+	}
+}
+
+func TestVulnerabilityReportsCollector(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6-nginx",
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:  "ReplicaSet",
+					starboard.LabelResourceName:  "nginx-6d4cf56db6",
+					starboard.LabelContainerName: "nginx",
+					"team":                       "payments",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 2, HighCount: 1},
+			},
+		},
+		&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "statefulset-redis-redis",
+				Namespace: "cache",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:  "StatefulSet",
+					starboard.LabelResourceName:  "redis",
+					starboard.LabelContainerName: "redis",
+					"app.kubernetes.io/name":     "redis",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Summary: v1alpha1.VulnerabilitySummary{LowCount: 3},
+			},
+		},
+	).Build()
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&VulnerabilityReportsCollector{
+		Client: client,
+		Config: etc.Config{PropagatedLabels: "team,app.kubernetes.io/*"},
+	})
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	assert.Equal(t, "starboard_vulnerability_report_vulnerabilities", families[0].GetName())
+	require.Len(t, families[0].Metric, 10)
+
+	values := make(map[string]float64)
+	for _, metric := range families[0].Metric {
+		labels := make(map[string]string)
+		for _, pair := range metric.Label {
+			labels[pair.GetName()] = pair.GetValue()
+		}
+		assert.Contains(t, labels, "label_team")
+		assert.Contains(t, labels, "label_app_kubernetes_io_name")
+		key := labels["namespace"] + "/" + labels["resource_name"] + "/" + labels["severity"] + "/" +
+			labels["label_team"] + labels["label_app_kubernetes_io_name"]
+		values[key] = metric.GetGauge().GetValue()
+	}
+	assert.Equal(t, 2.0, values["default/nginx-6d4cf56db6/CRITICAL/payments"])
+	assert.Equal(t, 1.0, values["default/nginx-6d4cf56db6/HIGH/payments"])
+	assert.Equal(t, 3.0, values["cache/redis/LOW/redis"])
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	predicatex "sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// NamespaceSelector determines which namespaces are scanned by labels of
+// namespaces, in addition to the namespaces of the install mode.
+//
+// SelectsLabels checks whether a namespace with the specified labels is
+// selected, so that namespaces can be evaluated as they're created and
+// labeled.
+type NamespaceSelector interface {
+	predicate.NamespaceSelection
+	SelectsLabels(namespaceLabels map[string]string) bool
+}
+
+// LabelNamespaceSelector is the NamespaceSelector that selects namespaces
+// which match the target selector, if set, and don't match the exclude
+// selector, if set. Namespaces are read from the cache, so that labels are
+// evaluated dynamically without calling the API server for each event.
+type LabelNamespaceSelector struct {
+	reader  client.Reader
+	target  labels.Selector
+	exclude labels.Selector
+}
+
+func NewLabelNamespaceSelector(config etc.Config, reader client.Reader) (*LabelNamespaceSelector, error) {
+	target, exclude, err := config.GetNamespaceSelectors()
+	if err != nil {
+		return nil, err
+	}
+	if target == nil && exclude == nil {
+		return nil, fmt.Errorf("namespace selection is disabled")
+	}
+	return &LabelNamespaceSelector{
+		reader:  reader,
+		target:  target,
+		exclude: exclude,
+	}, nil
+}
+
+// Selects checks whether objects in the specified namespace are scanned.
+// The empty namespace of cluster-scoped objects is always selected. Namespaces
+// that cannot be read are not selected.
+func (s *LabelNamespaceSelector) Selects(namespace string) bool {
+	if namespace == "" {
+		return true
+	}
+	var ns corev1.Namespace
+	err := s.reader.Get(context.Background(), client.ObjectKey{Name: namespace}, &ns)
+	if err != nil {
+		return false
+	}
+	return s.SelectsLabels(ns.Labels)
+}
+
+func (s *LabelNamespaceSelector) SelectsLabels(namespaceLabels map[string]string) bool {
+	set := labels.Set(namespaceLabels)
+	if s.target != nil && !s.target.Matches(set) {
+		return false
+	}
+	if s.exclude != nil && s.exclude.Matches(set) {
+		return false
+	}
+	return true
+}
+
+// watchSelectedNamespaces makes the controller reconcile objects returned by
+// the given handler.MapFunc for each namespace that becomes selected, either
+// because it's created with matching labels or because its labels are
+// changed. It's a no-op if the NamespaceSelector is nil.
+func watchSelectedNamespaces(b *builder.Builder, selector NamespaceSelector, fn handler.MapFunc) *builder.Builder {
+	if selector == nil {
+		return b
+	}
+	return b.Watches(&source.Kind{Type: &corev1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(fn),
+		builder.WithPredicates(becomesSelected(selector)))
+}
+
+// becomesSelected returns the predicate that passes created namespaces which
+// are selected and updated namespaces which were not selected before.
+func becomesSelected(selector NamespaceSelector) predicatex.Predicate {
+	return predicatex.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return selector.SelectsLabels(e.Object.GetLabels())
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !selector.SelectsLabels(e.ObjectOld.GetLabels()) && selector.SelectsLabels(e.ObjectNew.GetLabels())
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(_ event.GenericEvent) bool {
+			return false
+		},
+	}
+}
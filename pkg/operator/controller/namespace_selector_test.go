@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestLabelNamespaceSelector(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{
+			"security-scan": "enabled",
+		}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments-sandbox", Labels: map[string]string{
+			"security-scan": "enabled",
+			"environment":   "sandbox",
+		}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	).Build()
+
+	t.Run("Should return error when namespace selection is disabled", func(t *testing.T) {
+		_, err := NewLabelNamespaceSelector(etc.Config{}, c)
+		require.EqualError(t, err, "namespace selection is disabled")
+	})
+
+	t.Run("Should select namespaces by labels", func(t *testing.T) {
+		selector, err := NewLabelNamespaceSelector(etc.Config{
+			TargetNamespaceSelector:  "security-scan=enabled",
+			ExcludeNamespaceSelector: "environment=sandbox",
+		}, c)
+		require.NoError(t, err)
+
+		assert.True(t, selector.Selects("payments"))
+		assert.False(t, selector.Selects("payments-sandbox"))
+		assert.False(t, selector.Selects("default"))
+		assert.False(t, selector.Selects("unknown"), "namespaces that don't exist must not be selected")
+		assert.True(t, selector.Selects(""), "the namespace of cluster-scoped objects must be selected")
+	})
+
+	t.Run("Should select all namespaces but excluded ones", func(t *testing.T) {
+		selector, err := NewLabelNamespaceSelector(etc.Config{
+			ExcludeNamespaceSelector: "environment=sandbox",
+		}, c)
+		require.NoError(t, err)
+
+		assert.True(t, selector.Selects("payments"))
+		assert.False(t, selector.Selects("payments-sandbox"))
+		assert.True(t, selector.Selects("default"))
+	})
+
+	t.Run("Should pass namespaces that become selected", func(t *testing.T) {
+		selector, err := NewLabelNamespaceSelector(etc.Config{
+			TargetNamespaceSelector: "security-scan=enabled",
+		}, c)
+		require.NoError(t, err)
+		p := becomesSelected(selector)
+
+		unlabeled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "orders"}}
+		labeled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "orders", Labels: map[string]string{
+			"security-scan": "enabled",
+		}}}
+
+		assert.True(t, p.Create(event.CreateEvent{Object: labeled}))
+		assert.False(t, p.Create(event.CreateEvent{Object: unlabeled}))
+		assert.True(t, p.Update(event.UpdateEvent{ObjectOld: unlabeled, ObjectNew: labeled}))
+		assert.False(t, p.Update(event.UpdateEvent{ObjectOld: labeled, ObjectNew: labeled}))
+		assert.False(t, p.Update(event.UpdateEvent{ObjectOld: labeled, ObjectNew: unlabeled}))
+		assert.False(t, p.Delete(event.DeleteEvent{Object: labeled}))
+	})
+}
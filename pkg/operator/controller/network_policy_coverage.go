@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/networkpolicyreport"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	predicatex "sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// NetworkPolicyCoverageReconciler assesses which namespaces and workloads
+// lack any applicable NetworkPolicy, and records the assessment as the
+// ClusterNetworkPolicyReport named "cluster".
+//
+// The assessment runs when the operator starts and every
+// OPERATOR_NETWORK_POLICY_COVERAGE_INTERVAL. It covers workloads in target
+// namespaces of the install mode that are selected by the namespace selector,
+// except scan jobs and their pods. With sharding enabled the report is written
+// by the replica that owns cluster-scoped objects.
+type NetworkPolicyCoverageReconciler struct {
+	logr.Logger
+	etc.Config
+	starboard.ConfigData
+	client.Client
+	kube.ObjectResolver
+	networkpolicyreport.Writer
+	// Sharder is optional. If nil, the report is written by this replica.
+	Sharder Sharder
+	// NamespaceSelector is optional. If nil, workloads in all namespaces of
+	// the install mode are assessed.
+	NamespaceSelector NamespaceSelector
+
+	installModePredicate predicatex.Predicate
+}
+
+func (r *NetworkPolicyCoverageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := predicate.InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+	r.installModePredicate = installModePredicate
+	return mgr.Add(r)
+}
+
+// Start assesses NetworkPolicy coverage right away and then every interval
+// until the context is cancelled. It's called by the manager once caches are
+// synced.
+func (r *NetworkPolicyCoverageReconciler) Start(ctx context.Context) error {
+	if r.Config.NetworkPolicyCoverageInterval <= 0 {
+		return fmt.Errorf("OPERATOR_NETWORK_POLICY_COVERAGE_INTERVAL must be positive")
+	}
+	ticker := time.NewTicker(r.Config.NetworkPolicyCoverageInterval)
+	defer ticker.Stop()
+	for {
+		if err := r.assess(ctx); err != nil {
+			r.Logger.Error(err, "Unable to assess NetworkPolicy coverage")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// assess writes the ClusterNetworkPolicyReport with the NetworkPolicy
+// coverage of workloads.
+func (r *NetworkPolicyCoverageReconciler) assess(ctx context.Context) error {
+	if r.Sharder != nil && !r.Sharder.Owns("") {
+		return nil
+	}
+	workloads, err := r.listWorkloads(ctx)
+	if err != nil {
+		return err
+	}
+	var policies networkingv1.NetworkPolicyList
+	err = r.Client.List(ctx, &policies)
+	if err != nil {
+		return fmt.Errorf("listing network policies: %w", err)
+	}
+
+	report := networkpolicyreport.NewReport(networkpolicyreport.Assess(workloads, policies.Items))
+	commonMetadata, err := r.GetCommonMetadata()
+	if err != nil {
+		return err
+	}
+	commonMetadata.ApplyTo(&report.ObjectMeta)
+	err = r.Write(ctx, report)
+	if err != nil {
+		return fmt.Errorf("writing network policy report: %w", err)
+	}
+	r.Logger.V(1).Info("Assessed NetworkPolicy coverage",
+		"uncoveredNamespaces", report.Report.Summary.UncoveredNamespaceCount,
+		"uncoveredWorkloads", report.Report.Summary.UncoveredWorkloadCount)
+	return nil
+}
+
+// listWorkloads returns assessed workloads with labels of their pod
+// templates.
+func (r *NetworkPolicyCoverageReconciler) listWorkloads(ctx context.Context) ([]networkpolicyreport.Workload, error) {
+	refs, err := r.ObjectResolver.ListWorkloads(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing workloads: %w", err)
+	}
+	var workloads []networkpolicyreport.Workload
+	for _, ref := range refs {
+		if r.NamespaceSelector != nil && !r.NamespaceSelector.Selects(ref.Namespace) {
+			continue
+		}
+		obj, err := r.ObjectFromObjectRef(ctx, ref)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("getting workload: %w", err)
+		}
+		if !r.installModePredicate.Generic(event.GenericEvent{Object: obj}) ||
+			obj.GetLabels()[starboard.LabelK8SAppManagedBy] == starboard.AppStarboard {
+			continue
+		}
+		podLabels, err := kube.GetPodTemplateLabels(obj)
+		if err != nil {
+			return nil, err
+		}
+		workloads = append(workloads, networkpolicyreport.Workload{
+			Kind:      ref.Kind,
+			Name:      ref.Name,
+			Namespace: ref.Namespace,
+			PodLabels: podLabels,
+		})
+	}
+	return workloads, nil
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/networkpolicyreport"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestNetworkPolicyCoverageReconciler_Assess(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "web"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+			},
+		},
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "db"},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "db"}},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "dev", Name: "debug"},
+	}
+	scanJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "starboard-system",
+			Name:      "scan-vulnerabilityreport-5d4445db4f",
+			Labels:    map[string]string{starboard.LabelK8SAppManagedBy: starboard.AppStarboard},
+		},
+	}
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "web"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+
+	scheme := starboard.NewScheme()
+	require.NoError(t, networkingv1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(deployment, statefulSet, pod, scanJob, policy).Build()
+	config := etc.Config{Namespace: "starboard-system"}
+	installModePredicate, err := predicate.InstallModePredicate(config)
+	require.NoError(t, err)
+	r := &NetworkPolicyCoverageReconciler{
+		Logger:               log.Log,
+		Config:               config,
+		ConfigData:           starboard.ConfigData{"common.labels": "env=prod"},
+		Client:               c,
+		ObjectResolver:       kube.ObjectResolver{Client: c},
+		Writer:               networkpolicyreport.NewWriter(c),
+		installModePredicate: installModePredicate,
+	}
+
+	require.NoError(t, r.assess(context.TODO()))
+
+	var report v1alpha1.ClusterNetworkPolicyReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: networkpolicyreport.ReportName}, &report))
+	assert.Equal(t, "prod", report.Labels["env"])
+	assert.Equal(t, v1alpha1.NetworkPolicySummary{
+		NamespaceCount:          2,
+		UncoveredNamespaceCount: 1,
+		WorkloadCount:           3,
+		UncoveredWorkloadCount:  2,
+	}, report.Report.Summary)
+	assert.Equal(t, []v1alpha1.NetworkPolicyNamespace{
+		{
+			Name:               "dev",
+			UncoveredWorkloads: []v1alpha1.NetworkPolicyWorkload{{Kind: "Pod", Name: "debug"}},
+		},
+		{
+			Name:               "prod",
+			NetworkPolicyCount: 1,
+			UncoveredWorkloads: []v1alpha1.NetworkPolicyWorkload{{Kind: "StatefulSet", Name: "db"}},
+		},
+	}, report.Report.Namespaces)
+
+	t.Run("Should update report when workloads are covered", func(t *testing.T) {
+		require.NoError(t, c.Create(context.TODO(), &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "default-deny"},
+		}))
+		require.NoError(t, c.Delete(context.TODO(), pod))
+
+		require.NoError(t, r.assess(context.TODO()))
+		require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: networkpolicyreport.ReportName}, &report))
+		assert.Equal(t, v1alpha1.NetworkPolicySummary{NamespaceCount: 1, WorkloadCount: 2}, report.Report.Summary)
+		assert.Empty(t, report.Report.Namespaces)
+	})
+}
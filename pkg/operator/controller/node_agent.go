@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/nodeagent"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrNoNodeAgent is returned by NodeAgents if there's no ready node agent on
+// the node.
+var ErrNoNodeAgent = errors.New("no ready node agent")
+
+// NodeAgents dispatches scans of images to node agents, i.e. pods of the
+// starboard-node-agent DaemonSet in the operator namespace, which scan images
+// present in the container runtime of their nodes.
+type NodeAgents struct {
+	client    client.Client
+	namespace string
+	selector  labels.Selector
+	port      int
+	agent     *nodeagent.Client
+}
+
+// NewNodeAgents constructs NodeAgents that selects pods of node agents with
+// OPERATOR_NODE_AGENT_SELECTOR, and verifies their certificates with the CA
+// in OPERATOR_NODE_AGENT_CA_FILE.
+func NewNodeAgents(config etc.Config, c client.Client) (*NodeAgents, error) {
+	selector, err := labels.Parse(config.NodeAgentSelector)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", "OPERATOR_NODE_AGENT_SELECTOR", err)
+	}
+	tlsConfig, err := nodeagent.NewClientTLSConfig(config.NodeAgentCAFile, config.NodeAgentServerName)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", "OPERATOR_NODE_AGENT_CA_FILE", err)
+	}
+	return &NodeAgents{
+		client:    c,
+		namespace: config.Namespace,
+		selector:  selector,
+		port:      config.NodeAgentPort,
+		agent:     nodeagent.NewClient(config.NodeAgentTimeout, tlsConfig, config.NodeAgentTokenFile),
+	}, nil
+}
+
+// Scan scans the images of containers with the node agent running on the
+// specified node. It returns ErrNoNodeAgent if there's no ready node agent on
+// the node.
+func (a *NodeAgents) Scan(ctx context.Context, nodeName string, images kube.ContainerImages,
+	credentials map[string]docker.Auth) (map[string]nodeagent.Result, error) {
+	var pods corev1.PodList
+	err := a.client.List(ctx, &pods, client.InNamespace(a.namespace),
+		client.MatchingLabelsSelector{Selector: a.selector})
+	if err != nil {
+		return nil, fmt.Errorf("listing node agents: %w", err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != nodeName || pod.Status.PodIP == "" || !isPodReady(pod) {
+			continue
+		}
+		resp, err := a.agent.Scan(ctx, net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(a.port)), nodeagent.ScanRequest{
+			Images:      images,
+			Credentials: credentials,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scanning with node agent %s: %w", pod.Name, err)
+		}
+		return resp.Results, nil
+	}
+	return nil, ErrNoNodeAgent
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// scanWithNodeAgent scans the images of the workload with the node agent of
+// the node that runs a pod of the workload, and creates VulnerabilityReports
+// from the results. It returns false if the workload must be scanned with a
+// scan job instead, because it has no running pods or there's no ready node
+// agent on their node.
+func (r *VulnerabilityReportReconciler) scanWithNodeAgent(ctx context.Context, log logr.Logger, owner client.Object,
+	hash string, images, digests kube.ContainerImages) (bool, error) {
+	nodeName, err := r.GetNodeName(ctx, owner)
+	if err != nil {
+		if errors.Is(err, kube.ErrNoRunningPods) || errors.Is(err, kube.ErrUnSupportedKind) ||
+			errors.Is(err, kube.ErrReplicaSetNotFound) {
+			log.V(1).Info("Scanning with scan job instead of node agent", "reason", err)
+			return false, nil
+		}
+		return false, fmt.Errorf("getting node name: %w", err)
+	}
+	if nodeName == "" {
+		log.V(1).Info("Scanning with scan job instead of node agent", "reason", "pod is not scheduled")
+		return false, nil
+	}
+
+	credentials, err := r.CredentialsByWorkload(ctx, owner)
+	if err != nil {
+		return false, err
+	}
+
+	log.V(1).Info("Scanning with node agent", "node", nodeName)
+	results, err := r.NodeAgents.Scan(ctx, nodeName, images, credentials)
+	if err != nil {
+		if errors.Is(err, ErrNoNodeAgent) {
+			log.V(1).Info("Scanning with scan job instead of node agent", "node", nodeName, "reason", err)
+			return false, nil
+		}
+		return false, err
+	}
+	return true, r.createReportsFromResults(ctx, owner, hash, images, digests, results, "node agent of node "+nodeName)
+}
+
+// createReportsFromResults parses the output of Trivy returned by node agents
+// or workers of the scanner pool for each container, and creates
+// VulnerabilityReports of the workload. The source of results is only used in
+// error messages.
+func (r *VulnerabilityReportReconciler) createReportsFromResults(ctx context.Context, owner client.Object,
+	hash string, images, digests kube.ContainerImages, results map[string]nodeagent.Result, source string) error {
+	var scanner string
+	var err error
+	if r.ScanResultCache != nil && len(digests) > 0 {
+		scanner, err = r.getScannerCacheKey()
+		if err != nil {
+			return err
+		}
+	}
+
+	reports := make(map[string]v1alpha1.VulnerabilityReportData)
+	for containerName, containerImage := range images {
+		result, ok := results[containerName]
+		if !ok {
+			return fmt.Errorf("%s returned no result of container %s", source, containerName)
+		}
+		if result.Error != "" {
+			return fmt.Errorf("scanning image %s of container %s with %s: %s",
+				containerImage, containerName, source, result.Error)
+		}
+		reportData, err := r.Plugin.ParseVulnerabilityReportData(r.PluginContext, containerImage,
+			io.NopCloser(strings.NewReader(result.Output)))
+		if err != nil {
+			return err
+		}
+		if digest, ok := digests[containerName]; ok && r.ScanResultCache != nil {
+			r.ScanResultCache.Put(vulnerabilityreport.CacheKey{Digest: digest, Scanner: scanner}, reportData)
+		}
+		reports[containerName] = reportData
+	}
+	return r.createReports(ctx, owner, hash, images, digests, reports)
+}
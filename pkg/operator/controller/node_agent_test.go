@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/nodeagent"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type imageScannerFunc func(ctx context.Context, image string, credentials *docker.Auth) ([]byte, error)
+
+func (f imageScannerFunc) Scan(ctx context.Context, image string, credentials *docker.Auth) ([]byte, error) {
+	return f(ctx, image, credentials)
+}
+
+// tokenAuth authenticates tokens as users with the same names.
+type tokenAuth struct{}
+
+func (a *tokenAuth) Authenticate(_ context.Context, token string) (authenticationv1.UserInfo, bool, error) {
+	return authenticationv1.UserInfo{Username: token}, true, nil
+}
+
+const operatorUser = "system:serviceaccount:starboard-system:starboard-operator"
+
+// startNodeAgent starts the TLS server of a node agent, which scans images
+// with the scanner on request of the operator. It returns the host and port of
+// the server, and paths of files with its CA certificate and the token of the
+// operator.
+func startNodeAgent(t *testing.T, scanner nodeagent.Scanner) (string, int, string, string) {
+	server := httptest.NewTLSServer(nodeagent.NewHandler(scanner, &tokenAuth{}, []string{operatorUser}))
+	t.Cleanup(server.Close)
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte(operatorUser), 0600))
+	return host, portNumber, caFile, tokenFile
+}
+
+func TestNodeAgents_Scan(t *testing.T) {
+	host, port, caFile, tokenFile := startNodeAgent(t, imageScannerFunc(func(_ context.Context, image string, _ *docker.Auth) ([]byte, error) {
+		return []byte(image), nil
+	}))
+
+	newAgent := func(name, nodeName string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "starboard-system",
+				Name:      name,
+				Labels:    map[string]string{"app.kubernetes.io/name": "starboard-node-agent"},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				PodIP:      host,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		newAgent("starboard-node-agent-zx9vq", "node-1", corev1.ConditionTrue),
+		newAgent("starboard-node-agent-7bd2k", "node-2", corev1.ConditionFalse),
+	).Build()
+
+	agents, err := NewNodeAgents(etc.Config{
+		Namespace:           "starboard-system",
+		NodeAgentSelector:   "app.kubernetes.io/name=starboard-node-agent",
+		NodeAgentPort:       port,
+		NodeAgentTimeout:    time.Second,
+		NodeAgentCAFile:     caFile,
+		NodeAgentServerName: "example.com",
+		NodeAgentTokenFile:  tokenFile,
+	}, c)
+	require.NoError(t, err)
+
+	results, err := agents.Scan(context.TODO(), "node-1", kube.ContainerImages{"nginx": "nginx:1.16"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]nodeagent.Result{"nginx": {Output: "nginx:1.16"}}, results)
+
+	_, err = agents.Scan(context.TODO(), "node-2", kube.ContainerImages{"nginx": "nginx:1.16"}, nil)
+	assert.ErrorIs(t, err, ErrNoNodeAgent, "Should ignore agents that are not ready")
+
+	_, err = agents.Scan(context.TODO(), "node-3", kube.ContainerImages{"nginx": "nginx:1.16"}, nil)
+	assert.ErrorIs(t, err, ErrNoNodeAgent)
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NodeLimiter caps the number of vulnerability scan jobs running concurrently
+// on a single node.
+//
+// Apply returns false if the given scan job must wait because the node it is
+// bound to already runs the maximum number of scan jobs. Otherwise, it
+// prepares the scan job so that the Kubernetes scheduler does not place more
+// scan jobs than the limit on the same node and returns true.
+//
+// A scan job that is not bound to a node is assigned one of N slots, where N
+// is the per-node limit, and is using pod anti-affinity to repel scan jobs
+// with the same slot from its node. Slots are assigned evenly across active
+// scan jobs. A scan job bound to a node with spec.nodeName, which bypasses the
+// scheduler, is checked against the number of active scan pods on that node.
+type NodeLimiter interface {
+	Apply(ctx context.Context, job *batchv1.Job) (bool, error)
+}
+
+func NewNodeLimiter(config etc.Config, client client.Client) NodeLimiter {
+	return &nodeLimiter{
+		config: config,
+		client: client,
+	}
+}
+
+type nodeLimiter struct {
+	config etc.Config
+	client client.Client
+}
+
+func (l *nodeLimiter) Apply(ctx context.Context, job *batchv1.Job) (bool, error) {
+	limit := l.config.ConcurrentNodeScanJobsLimit
+	if limit <= 0 {
+		return true, nil
+	}
+
+	if nodeName := job.Spec.Template.Spec.NodeName; nodeName != "" {
+		count, err := l.countScanPodsOnNode(ctx, nodeName)
+		if err != nil {
+			return false, err
+		}
+		return count < limit, nil
+	}
+
+	slot, err := l.leastUsedSlot(ctx, limit)
+	if err != nil {
+		return false, err
+	}
+	if job.Spec.Template.Labels == nil {
+		job.Spec.Template.Labels = make(map[string]string)
+	}
+	job.Spec.Template.Labels[starboard.LabelScanJobSlot] = slot
+
+	spec := &job.Spec.Template.Spec
+	if spec.Affinity == nil {
+		spec.Affinity = &corev1.Affinity{}
+	}
+	if spec.Affinity.PodAntiAffinity == nil {
+		spec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+		spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+		corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					starboard.LabelScanJobSlot: slot,
+				},
+			},
+			TopologyKey: corev1.LabelHostname,
+		})
+	return true, nil
+}
+
+func (l *nodeLimiter) countScanPodsOnNode(ctx context.Context, nodeName string) (int, error) {
+	var pods corev1.PodList
+	err := l.client.List(ctx, &pods, client.InNamespace(l.config.GetScanJobsNamespace()),
+		client.MatchingLabels{
+			starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+		},
+		client.HasLabels{starboard.LabelVulnerabilityReportScanner})
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != nodeName {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (l *nodeLimiter) leastUsedSlot(ctx context.Context, limit int) (string, error) {
+	var jobs batchv1.JobList
+	err := l.client.List(ctx, &jobs, client.InNamespace(l.config.GetScanJobsNamespace()),
+		client.MatchingLabels{
+			starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+		},
+		client.HasLabels{starboard.LabelVulnerabilityReportScanner})
+	if err != nil {
+		return "", err
+	}
+	usage := make([]int, limit)
+	for _, job := range jobs.Items {
+		if hasJobCondition(job, batchv1.JobComplete) || hasJobCondition(job, batchv1.JobFailed) {
+			continue
+		}
+		slot, err := strconv.Atoi(job.Spec.Template.Labels[starboard.LabelScanJobSlot])
+		if err != nil || slot < 0 || slot >= limit {
+			continue
+		}
+		usage[slot]++
+	}
+	leastUsed := 0
+	for slot := range usage {
+		if usage[slot] < usage[leastUsed] {
+			leastUsed = slot
+		}
+	}
+	return strconv.Itoa(leastUsed), nil
+}
@@ -0,0 +1,145 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("NodeLimiter", func() {
+
+	config := etc.Config{
+		Namespace:                   "starboard-operator",
+		ConcurrentNodeScanJobsLimit: 2,
+	}
+
+	scanLabels := map[string]string{
+		starboard.LabelK8SAppManagedBy:            starboard.AppStarboard,
+		starboard.LabelVulnerabilityReportScanner: "Trivy",
+	}
+
+	newScanJob := func(name, slot string, conditions ...batchv1.JobCondition) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "starboard-operator",
+				Labels:    scanLabels,
+			},
+			Status: batchv1.JobStatus{
+				Conditions: conditions,
+			},
+		}
+		if slot != "" {
+			job.Spec.Template.Labels = map[string]string{
+				starboard.LabelScanJobSlot: slot,
+			}
+		}
+		return job
+	}
+
+	newScanPod := func(name, nodeName string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "starboard-operator",
+				Labels:    scanLabels,
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+			},
+		}
+	}
+
+	Context("When limit is not set", func() {
+		It("Should neither change nor push back scan job", func() {
+			client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+
+			job := newScanJob("scan-vulnerabilityreport-hash1", "")
+			instance := controller.NewNodeLimiter(etc.Config{Namespace: "starboard-operator"}, client)
+			admitted, err := instance.Apply(context.TODO(), job)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(admitted).To(BeTrue())
+			Expect(job).To(Equal(newScanJob("scan-vulnerabilityreport-hash1", "")))
+		})
+	})
+
+	Context("When scan job is not bound to a node", func() {
+		It("Should assign least used slot and pod anti-affinity", func() {
+			client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				newScanJob("scan-vulnerabilityreport-hash1", "0"),
+				newScanJob("scan-vulnerabilityreport-hash2", "1", batchv1.JobCondition{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				}),
+			).Build()
+
+			job := newScanJob("scan-vulnerabilityreport-hash3", "")
+			job.Spec.Template.Spec.Affinity = starboard.LinuxNodeAffinity()
+
+			instance := controller.NewNodeLimiter(config, client)
+			admitted, err := instance.Apply(context.TODO(), job)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(admitted).To(BeTrue())
+			Expect(job.Spec.Template.Labels).To(HaveKeyWithValue(starboard.LabelScanJobSlot, "1"))
+			Expect(job.Spec.Template.Spec.Affinity.NodeAffinity).To(Equal(starboard.LinuxNodeAffinity().NodeAffinity))
+			Expect(job.Spec.Template.Spec.Affinity.PodAntiAffinity).To(Equal(&corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+					{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								starboard.LabelScanJobSlot: "1",
+							},
+						},
+						TopologyKey: "kubernetes.io/hostname",
+					},
+				},
+			}))
+		})
+	})
+
+	Context("When scan job is bound to a node", func() {
+		It("Should push back scan job when node runs scan pods up to the limit", func() {
+			client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				newScanPod("scan-vulnerabilityreport-hash1-abcde", "node-1", corev1.PodRunning),
+				newScanPod("scan-vulnerabilityreport-hash2-abcde", "node-1", corev1.PodPending),
+			).Build()
+
+			job := newScanJob("scan-vulnerabilityreport-hash3", "")
+			job.Spec.Template.Spec.NodeName = "node-1"
+
+			instance := controller.NewNodeLimiter(config, client)
+			admitted, err := instance.Apply(context.TODO(), job)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(admitted).To(BeFalse())
+		})
+
+		It("Should admit scan job when node runs less scan pods than the limit", func() {
+			client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				newScanPod("scan-vulnerabilityreport-hash1-abcde", "node-1", corev1.PodRunning),
+				newScanPod("scan-vulnerabilityreport-hash2-abcde", "node-1", corev1.PodSucceeded),
+				newScanPod("scan-vulnerabilityreport-hash3-abcde", "node-2", corev1.PodRunning),
+			).Build()
+
+			job := newScanJob("scan-vulnerabilityreport-hash4", "")
+			job.Spec.Template.Spec.NodeName = "node-1"
+
+			instance := controller.NewNodeLimiter(config, client)
+			admitted, err := instance.Apply(context.TODO(), job)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(admitted).To(BeTrue())
+			Expect(job.Spec.Template.Spec.Affinity).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/operatorconfig"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	predicatex "sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Reasons of the v1alpha1.OperatorConfigConditionValid condition.
+const (
+	OperatorConfigReasonValid   = "Valid"
+	OperatorConfigReasonInvalid = "Invalid"
+)
+
+// OperatorConfigReconciler validates the OperatorConfig of the operator and
+// reports the result in its Valid condition. Settings of plugins of a valid
+// OperatorConfig are set in ConfigMaps of plugins, which are read each time
+// they're used, whereas other settings are applied by restarting the operator
+// or by the ConfigReloader.
+type OperatorConfigReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	// Sharder is optional. If nil, the OperatorConfig is reconciled by each
+	// replica.
+	Sharder Sharder
+}
+
+func (r *OperatorConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	_, err := mgr.GetRESTMapper().RESTMapping(schema.GroupKind{
+		Group: v1alpha1.SchemeGroupVersion.Group,
+		Kind:  v1alpha1.OperatorConfigKind,
+	}, v1alpha1.SchemeGroupVersion.Version)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			r.Logger.Info("Skipping OperatorConfig reconciler because OperatorConfig CRD is not installed")
+			return nil
+		}
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.OperatorConfig{}, builder.WithPredicates(
+			predicate.InNamespace(r.Config.Namespace),
+			predicate.HasName(v1alpha1.OperatorConfigName),
+			predicatex.GenerationChangedPredicate{},
+			predicate.InShard(r.Sharder),
+		)).
+		Complete(r)
+}
+
+func (r *OperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Logger.WithValues("operatorConfig", req.NamespacedName)
+
+	config := &v1alpha1.OperatorConfig{}
+	err := r.Client.Get(ctx, req.NamespacedName, config)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.V(1).Info("Ignoring cached OperatorConfig that must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("getting OperatorConfig from cache: %w", err)
+	}
+
+	overrides, err := operatorconfig.GetOverrides(ctx, r.Client, r.Config)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	condition := metav1.Condition{
+		Type:               v1alpha1.OperatorConfigConditionValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: config.Generation,
+		Reason:             OperatorConfigReasonValid,
+		Message:            "OperatorConfig is valid",
+	}
+	err = operatorconfig.Validate(config.Spec, overrides)
+	if err != nil {
+		log.Info("Ignoring invalid OperatorConfig", "error", err.Error())
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = OperatorConfigReasonInvalid
+		condition.Message = err.Error()
+	} else {
+		err = r.setPluginSettings(ctx, config.Spec.Plugins)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	config.Status.ObservedGeneration = config.Generation
+	meta.SetStatusCondition(&config.Status.Conditions, condition)
+	err = r.Client.Status().Update(ctx, config)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating OperatorConfig status: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// setPluginSettings sets the specified settings in ConfigMaps of plugins.
+// ConfigMaps that do not exist are skipped, because they're created with
+// default settings when plugins are initialized, after which the
+// OperatorConfig is reconciled again.
+func (r *OperatorConfigReconciler) setPluginSettings(ctx context.Context, plugins map[string]map[string]string) error {
+	var names []string
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cm := &corev1.ConfigMap{}
+		err := r.Client.Get(ctx, types.NamespacedName{
+			Namespace: r.Config.GetScanJobsNamespace(),
+			Name:      starboard.GetPluginConfigMapName(name),
+		}, cm)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				r.Logger.V(1).Info("Skipping settings of plugin that is not initialized", "plugin", name)
+				continue
+			}
+			return fmt.Errorf("getting %s plugin config: %w", name, err)
+		}
+		changed := false
+		for key, value := range plugins[name] {
+			if current, ok := cm.Data[key]; ok && current == value {
+				continue
+			}
+			if cm.Data == nil {
+				cm.Data = make(map[string]string)
+			}
+			cm.Data[key] = value
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		err = r.Client.Update(ctx, cm)
+		if err != nil {
+			return fmt.Errorf("updating %s plugin config: %w", name, err)
+		}
+		r.Logger.Info("Updated plugin settings", "plugin", name, "configMap", cm.Namespace+"/"+cm.Name)
+	}
+	return nil
+}
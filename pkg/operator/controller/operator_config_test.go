@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestOperatorConfigReconciler(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "starboard-system")
+
+	trivyConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "starboard-trivy-config"},
+		Data: map[string]string{
+			"trivy.imageRef": "docker.io/aquasec/trivy:0.25.2",
+			"trivy.severity": "UNKNOWN,LOW,MEDIUM,HIGH,CRITICAL",
+		},
+	}
+	reconcile := func(t *testing.T, spec v1alpha1.OperatorConfigSpec) (client.Client, *metav1.Condition) {
+		t.Helper()
+		config := &v1alpha1.OperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: v1alpha1.OperatorConfigName, Generation: 2},
+			Spec:       spec,
+		}
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(config, trivyConfig.DeepCopy()).Build()
+		r := &OperatorConfigReconciler{
+			Logger: log.Log,
+			Config: etc.Config{Namespace: "starboard-system"},
+			Client: c,
+		}
+		_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+		require.NoError(t, err)
+
+		var actual v1alpha1.OperatorConfig
+		require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(config), &actual))
+		assert.Equal(t, int64(2), actual.Status.ObservedGeneration)
+		return c, meta.FindStatusCondition(actual.Status.Conditions, v1alpha1.OperatorConfigConditionValid)
+	}
+
+	t.Run("Should report valid config and set plugin settings", func(t *testing.T) {
+		c, condition := reconcile(t, v1alpha1.OperatorConfigSpec{
+			VulnerabilityScanner: &v1alpha1.OperatorConfigScanner{Plugin: "Trivy"},
+			Plugins: map[string]map[string]string{
+				"Trivy":   {"trivy.severity": "CRITICAL,HIGH"},
+				"Polaris": {"polaris.imageRef": "quay.io/fairwinds/polaris:4.2"},
+			},
+		})
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, OperatorConfigReasonValid, condition.Reason)
+		assert.Equal(t, int64(2), condition.ObservedGeneration)
+
+		var cm corev1.ConfigMap
+		require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(trivyConfig), &cm))
+		assert.Equal(t, map[string]string{
+			"trivy.imageRef": "docker.io/aquasec/trivy:0.25.2",
+			"trivy.severity": "CRITICAL,HIGH",
+		}, cm.Data)
+	})
+
+	t.Run("Should report invalid config and not set plugin settings", func(t *testing.T) {
+		c, condition := reconcile(t, v1alpha1.OperatorConfigSpec{
+			ConfigAuditScanner: &v1alpha1.OperatorConfigScanner{Plugin: "Trivy"},
+			Plugins: map[string]map[string]string{
+				"Trivy": {"trivy.severity": "CRITICAL,HIGH"},
+			},
+		})
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, OperatorConfigReasonInvalid, condition.Reason)
+		assert.Equal(t, "invalid value (Trivy) of configAuditReports.scanner; allowed values (Polaris, Conftest)", condition.Message)
+
+		var cm corev1.ConfigMap
+		require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(trivyConfig), &cm))
+		assert.Equal(t, trivyConfig.Data, cm.Data)
+	})
+}
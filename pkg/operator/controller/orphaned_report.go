@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// orphanedReportKinds are kinds of reports which are created without owner
+// references when OPERATOR_ORPHANED_REPORTS_RETENTION is set.
+var orphanedReportKinds = []string{
+	v1alpha1.VulnerabilityReportKind,
+	v1alpha1.ConfigAuditReportKind,
+	v1alpha1.ClusterConfigAuditReportKind,
+}
+
+// OrphanedReportReconciler deletes reports of deleted workloads once
+// OPERATOR_ORPHANED_REPORTS_RETENTION has passed since their workloads were
+// deleted.
+//
+// When the retention is set, VulnerabilityReports and ConfigAuditReports are
+// created without owner references, so that they're not garbage collected by
+// Kubernetes together with their workloads. Every
+// OPERATOR_ORPHANED_REPORTS_SWEEP_INTERVAL the reconciler annotates reports
+// whose workloads don't exist with the time when they were found orphaned, and
+// deletes reports that were orphaned longer than the retention. The annotation
+// is removed if a workload with the same name is created again.
+type OrphanedReportReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	kube.ObjectResolver
+	Clock ext.Clock
+	// Sharder is optional. If nil, reports in all namespaces are reconciled.
+	Sharder Sharder
+}
+
+func (r *OrphanedReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(r)
+}
+
+// Start sweeps orphaned reports every sweep interval until the context is
+// cancelled. It's called by the manager once caches are synced.
+func (r *OrphanedReportReconciler) Start(ctx context.Context) error {
+	if r.Config.OrphanedReportsSweepInterval <= 0 {
+		return fmt.Errorf("OPERATOR_ORPHANED_REPORTS_SWEEP_INTERVAL must be positive")
+	}
+	ticker := time.NewTicker(r.Config.OrphanedReportsSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.sweep(ctx); err != nil {
+				r.Logger.Error(err, "Unable to delete orphaned reports")
+			}
+		}
+	}
+}
+
+// sweep annotates, deletes or adopts again reports without owner references
+// of all kinds listed in orphanedReportKinds. Errors of individual reports are
+// logged so that they don't prevent sweeping other reports.
+func (r *OrphanedReportReconciler) sweep(ctx context.Context) error {
+	now := r.Clock.Now()
+	for _, kind := range orphanedReportKinds {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(kind + "List"))
+		err := r.Client.List(ctx, list)
+		if err != nil {
+			return fmt.Errorf("listing %s: %w", kind, err)
+		}
+		for i := range list.Items {
+			report := &list.Items[i]
+			if len(report.OwnerReferences) > 0 {
+				continue
+			}
+			if r.Sharder != nil && !r.Sharder.Owns(report.Labels[starboard.LabelResourceNamespace]) {
+				continue
+			}
+			report.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(kind))
+			if err := r.sweepReport(ctx, report, now); err != nil {
+				r.Logger.Error(err, "Unable to sweep orphaned report", "kind", kind,
+					"report", report.Namespace+"/"+report.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *OrphanedReportReconciler) sweepReport(ctx context.Context, report *metav1.PartialObjectMetadata, now time.Time) error {
+	log := r.Logger.WithValues("kind", report.Kind, "report", report.Namespace+"/"+report.Name)
+	owner, err := kube.ObjectRefFromObjectMeta(report.ObjectMeta)
+	if err != nil {
+		log.V(1).Info("Ignoring report without owner labels")
+		return nil
+	}
+	_, err = r.ObjectFromObjectRef(ctx, owner)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("getting owner: %w", err)
+	}
+	return sweepStaleReport(ctx, r.Client, log, report, starboard.AnnotationOrphanedAt, errors.IsNotFound(err),
+		now, r.Config.OrphanedReportsRetention)
+}
+
+// sweepStaleReport annotates the specified report with the time when it was
+// first found stale, and deletes it once it has been stale for the grace
+// period. The annotation is removed if the report is no longer stale.
+func sweepStaleReport(ctx context.Context, c client.Client, log logr.Logger, report *metav1.PartialObjectMetadata,
+	annotation string, stale bool, now time.Time, gracePeriod time.Duration) error {
+	value, annotated := report.Annotations[annotation]
+	if !stale {
+		if !annotated {
+			return nil
+		}
+		log.V(1).Info("Removing annotation of report which is no longer stale", "annotation", annotation)
+		patch := client.MergeFrom(report.DeepCopy())
+		delete(report.Annotations, annotation)
+		return client.IgnoreNotFound(c.Patch(ctx, report, patch))
+	}
+
+	staleSince, err := time.Parse(time.RFC3339, value)
+	if !annotated || err != nil {
+		log.V(1).Info("Annotating stale report", "annotation", annotation, "gracePeriod", gracePeriod)
+		patch := client.MergeFrom(report.DeepCopy())
+		metav1.SetMetaDataAnnotation(&report.ObjectMeta, annotation, now.UTC().Format(time.RFC3339))
+		return client.IgnoreNotFound(c.Patch(ctx, report, patch))
+	}
+	if now.Sub(staleSince) < gracePeriod {
+		return nil
+	}
+	log.V(1).Info("Deleting report stale longer than grace period", "annotation", annotation, "since", value)
+	return client.IgnoreNotFound(c.Delete(ctx, report))
+}
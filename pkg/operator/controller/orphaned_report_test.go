@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestOrphanedReportReconciler_Sweep(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	newReport := func(name, replicaSet string, orphanedAt *time.Time, owned bool) *v1alpha1.VulnerabilityReport {
+		report := &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      string(kube.KindReplicaSet),
+					starboard.LabelResourceName:      replicaSet,
+					starboard.LabelResourceNamespace: "default",
+				},
+			},
+		}
+		if orphanedAt != nil {
+			report.Annotations = map[string]string{
+				starboard.AnnotationOrphanedAt: orphanedAt.Format(time.RFC3339),
+			}
+		}
+		if owned {
+			report.OwnerReferences = []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: replicaSet, UID: "5a4e6a4c"},
+			}
+		}
+		return report
+	}
+	expired := now.Add(-48 * time.Hour)
+	recent := now.Add(-time.Hour)
+
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "redis"}},
+		newReport("replicaset-nginx-nginx", "nginx", nil, false),
+		newReport("replicaset-app-app", "app", &expired, false),
+		newReport("replicaset-db-db", "db", &recent, false),
+		newReport("replicaset-redis-redis", "redis", &recent, false),
+		newReport("replicaset-web-web", "web", nil, true),
+	).Build()
+	r := &OrphanedReportReconciler{
+		Logger:         log.Log,
+		Config:         etc.Config{OrphanedReportsRetention: 24 * time.Hour},
+		Client:         c,
+		ObjectResolver: kube.ObjectResolver{Client: c},
+		Clock:          ext.NewFixedClock(now),
+	}
+	require.NoError(t, r.sweep(context.TODO()))
+
+	get := func(name string) (*v1alpha1.VulnerabilityReport, error) {
+		var report v1alpha1.VulnerabilityReport
+		err := c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, &report)
+		return &report, err
+	}
+
+	report, err := get("replicaset-nginx-nginx")
+	require.NoError(t, err)
+	assert.Equal(t, now.Format(time.RFC3339), report.Annotations[starboard.AnnotationOrphanedAt],
+		"Should annotate report whose owner was deleted")
+
+	_, err = get("replicaset-app-app")
+	assert.True(t, errors.IsNotFound(err), "Should delete report orphaned longer than retention")
+
+	report, err = get("replicaset-db-db")
+	require.NoError(t, err)
+	assert.Equal(t, recent.Format(time.RFC3339), report.Annotations[starboard.AnnotationOrphanedAt],
+		"Should retain report orphaned shorter than retention")
+
+	report, err = get("replicaset-redis-redis")
+	require.NoError(t, err)
+	assert.NotContains(t, report.Annotations, starboard.AnnotationOrphanedAt,
+		"Should remove annotation of report whose owner was recreated")
+
+	report, err = get("replicaset-web-web")
+	require.NoError(t, err)
+	assert.NotContains(t, report.Annotations, starboard.AnnotationOrphanedAt,
+		"Should ignore report with owner references")
+}
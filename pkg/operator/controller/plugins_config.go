@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,25 +28,33 @@ type PluginsConfigReconciler struct {
 	client.Client
 	starboard.PluginContext
 	configauditreport.Plugin
+	// Sharder is optional. If nil, the plugin ConfigMap is reconciled by
+	// each replica.
+	Sharder Sharder
 }
 
 func (r *PluginsConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	opts := builder.WithPredicates(
 		predicate.Not(predicate.IsBeingTerminated),
 		predicate.HasName(starboard.GetPluginConfigMapName(r.PluginContext.GetName())),
-		predicate.InNamespace(r.Config.Namespace))
+		predicate.InNamespace(r.Config.GetScanJobsNamespace()),
+		predicate.InShard(r.Sharder))
+	configMap := objectOfNamespace(types.NamespacedName{
+		Namespace: r.Config.GetScanJobsNamespace(),
+		Name:      starboard.GetPluginConfigMapName(r.PluginContext.GetName()),
+	})
 
 	for _, kind := range r.Plugin.SupportedKinds() {
 		if kube.IsClusterScopedKind(string(kind)) {
-			err := ctrl.NewControllerManagedBy(mgr).
-				For(&corev1.ConfigMap{}, opts).
+			err := watchGainedNamespaces(ctrl.NewControllerManagedBy(mgr).
+				For(&corev1.ConfigMap{}, opts), r.Sharder, configMap).
 				Complete(r.reconcileClusterConfig(kind))
 			if err != nil {
 				return err
 			}
 		} else {
-			err := ctrl.NewControllerManagedBy(mgr).
-				For(&corev1.ConfigMap{}, opts).
+			err := watchGainedNamespaces(ctrl.NewControllerManagedBy(mgr).
+				For(&corev1.ConfigMap{}, opts), r.Sharder, configMap).
 				Complete(r.reconcileConfig(kind))
 			if err != nil {
 				return err
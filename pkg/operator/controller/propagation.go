@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getPropagatedMetadata returns labels and annotations of reports of the
+// specified owner: common labels and annotations configured in the specified
+// ConfigData, labels and annotations of the owner, and of the Deployment or the
+// CronJob that controls it, that are configured to be propagated, and labels
+// that identify the cluster. Labels and annotations of the owner take
+// precedence over those of the workload that controls it, and both take
+// precedence over common labels and annotations.
+func getPropagatedMetadata(ctx context.Context, resolver kube.ObjectResolver, config etc.Config,
+	data starboard.ConfigData, owner client.Object) (map[string]string, map[string]string, error) {
+	common, err := data.GetCommonMetadata()
+	if err != nil {
+		return nil, nil, err
+	}
+	labels := make(map[string]string)
+	for key, value := range common.Labels {
+		labels[key] = value
+	}
+	annotations := make(map[string]string)
+	for key, value := range common.Annotations {
+		annotations[key] = value
+	}
+	if config.PropagatedLabels != "" || config.PropagatedAnnotations != "" {
+		objects := []client.Object{owner}
+		controller := metav1.GetControllerOf(owner)
+		if controller != nil && (controller.Kind == string(kube.KindDeployment) || controller.Kind == string(kube.KindCronJob)) {
+			workload, err := resolver.ObjectFromObjectRef(ctx, kube.ObjectRef{
+				Kind:      kube.Kind(controller.Kind),
+				Name:      controller.Name,
+				Namespace: owner.GetNamespace(),
+			})
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, nil, err
+			}
+			if err == nil {
+				objects = append([]client.Object{workload}, objects...)
+			}
+		}
+		for _, obj := range objects {
+			for key, value := range config.GetPropagatedLabels(obj.GetLabels()) {
+				labels[key] = value
+			}
+			for key, value := range config.GetPropagatedAnnotations(obj.GetAnnotations()) {
+				annotations[key] = value
+			}
+		}
+	}
+	for key, value := range config.GetClusterLabels() {
+		labels[key] = value
+	}
+	return labels, annotations, nil
+}
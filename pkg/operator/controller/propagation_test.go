@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetPropagatedMetadata(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "default",
+			Labels: map[string]string{
+				"team":                   "payments",
+				"app.kubernetes.io/name": "nginx",
+			},
+			Annotations: map[string]string{
+				"cost-center": "cc-42",
+			},
+		},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6d4cf56db6",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app.kubernetes.io/name": "nginx-canary",
+				"pod-template-hash":      "6d4cf56db6",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       "nginx",
+					Controller: pointer.BoolPtr(true),
+				},
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(deployment).Build()
+	resolver := kube.ObjectResolver{Client: client}
+
+	t.Run("Should return cluster labels when propagation is not configured", func(t *testing.T) {
+		labels, annotations, err := getPropagatedMetadata(context.TODO(), resolver, etc.Config{
+			ClusterName: "prod",
+		}, starboard.ConfigData{}, replicaSet)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{starboard.LabelClusterName: "prod"}, labels)
+		assert.Empty(t, annotations)
+	})
+
+	t.Run("Should propagate labels and annotations of owner and its Deployment", func(t *testing.T) {
+		labels, annotations, err := getPropagatedMetadata(context.TODO(), resolver, etc.Config{
+			ClusterName:           "prod",
+			PropagatedLabels:      "team,app.kubernetes.io/*",
+			PropagatedAnnotations: "cost-center",
+		}, starboard.ConfigData{}, replicaSet)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			starboard.LabelClusterName: "prod",
+			"team":                     "payments",
+			"app.kubernetes.io/name":   "nginx-canary",
+		}, labels)
+		assert.Equal(t, map[string]string{"cost-center": "cc-42"}, annotations)
+	})
+	t.Run("Should add common labels and annotations unless propagated ones are set", func(t *testing.T) {
+		labels, annotations, err := getPropagatedMetadata(context.TODO(), resolver, etc.Config{
+			PropagatedLabels: "team",
+		}, starboard.ConfigData{
+			"common.labels":      "team=platform,env=prod",
+			"common.annotations": "owner=security",
+		}, replicaSet)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"team": "payments",
+			"env":  "prod",
+		}, labels)
+		assert.Equal(t, map[string]string{"owner": "security"}, annotations)
+	})
+}
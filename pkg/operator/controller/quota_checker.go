@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// QuotaChecker checks whether pods of a scan job fit in ResourceQuotas of the
+// namespace where the scan job is created.
+//
+// Check returns the name of a ResourceQuota which would be exceeded by the pod
+// of the given scan job, or an empty string if the pod fits in all quotas. It
+// takes into account pods of scan jobs which have been created but whose pods
+// have not been created yet, because their usage is not recorded in quotas.
+// Scan jobs that do not fit are pushed back instead of being created with pods
+// that are rejected by the quota until other pods terminate.
+//
+// Only quotas without scopes are checked, and only for the number of pods and
+// jobs, and compute resources.
+type QuotaChecker interface {
+	Check(ctx context.Context, job *batchv1.Job) (string, error)
+}
+
+func NewQuotaChecker(client client.Client) QuotaChecker {
+	return &quotaChecker{
+		client: client,
+	}
+}
+
+type quotaChecker struct {
+	client client.Client
+}
+
+func (c *quotaChecker) Check(ctx context.Context, job *batchv1.Job) (string, error) {
+	var quotas corev1.ResourceQuotaList
+	err := c.client.List(ctx, &quotas, client.InNamespace(job.Namespace))
+	if err != nil {
+		return "", fmt.Errorf("listing resource quotas: %w", err)
+	}
+	if len(quotas.Items) == 0 {
+		return "", nil
+	}
+
+	usage, err := c.pendingUsage(ctx, job.Namespace)
+	if err != nil {
+		return "", err
+	}
+	addUsage(usage, podUsage(&job.Spec.Template.Spec))
+	addUsage(usage, corev1.ResourceList{
+		"count/jobs.batch": resource.MustParse("1"),
+	})
+
+	for _, quota := range quotas.Items {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+		for name, hard := range quota.Status.Hard {
+			requested, ok := usage[name]
+			if !ok {
+				continue
+			}
+			used := quota.Status.Used[name]
+			used.Add(requested)
+			if used.Cmp(hard) > 0 {
+				return quota.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// pendingUsage returns the usage of pods of scan jobs in the specified
+// namespace which have not been created yet.
+func (c *quotaChecker) pendingUsage(ctx context.Context, namespace string) (corev1.ResourceList, error) {
+	var jobs batchv1.JobList
+	err := c.client.List(ctx, &jobs, client.InNamespace(namespace), client.MatchingLabels{
+		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing scan jobs: %w", err)
+	}
+	usage := corev1.ResourceList{}
+	for _, job := range jobs.Items {
+		if job.Status.Active > 0 || len(job.Status.Conditions) > 0 {
+			continue
+		}
+		addUsage(usage, podUsage(&job.Spec.Template.Spec))
+	}
+	return usage, nil
+}
+
+// podUsage returns the usage of a pod with the specified spec as evaluated by
+// ResourceQuotas. Init containers run one after another before containers,
+// therefore the pod requests the maximum of the sum of resources of containers
+// and resources of any init container.
+func podUsage(spec *corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+	for _, container := range spec.Containers {
+		addUsage(requests, container.Resources.Requests)
+		addUsage(limits, container.Resources.Limits)
+	}
+	for _, container := range spec.InitContainers {
+		maxUsage(requests, container.Resources.Requests)
+		maxUsage(limits, container.Resources.Limits)
+	}
+
+	usage := corev1.ResourceList{
+		corev1.ResourcePods: resource.MustParse("1"),
+	}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage} {
+		if quantity, ok := requests[name]; ok {
+			usage[name] = quantity
+			usage[corev1.ResourceName("requests."+name)] = quantity
+		}
+		if quantity, ok := limits[name]; ok {
+			usage[corev1.ResourceName("limits."+name)] = quantity
+		}
+	}
+	return usage
+}
+
+func addUsage(usage, other corev1.ResourceList) {
+	for name, quantity := range other {
+		total := usage[name]
+		total.Add(quantity)
+		usage[name] = total
+	}
+}
+
+func maxUsage(usage, other corev1.ResourceList) {
+	for name, quantity := range other {
+		if current, ok := usage[name]; !ok || quantity.Cmp(current) > 0 {
+			usage[name] = quantity.DeepCopy()
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("QuotaChecker", func() {
+
+	newScanJob := func(name, cpu string, active int32, conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "starboard-scans",
+				Labels: map[string]string{
+					starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+				},
+			},
+			Spec: batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "scanner",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU: resource.MustParse(cpu),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Status: batchv1.JobStatus{
+				Active:     active,
+				Conditions: conditions,
+			},
+		}
+	}
+
+	newQuota := func(name string, hard, used corev1.ResourceList) *corev1.ResourceQuota {
+		return &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "starboard-scans",
+			},
+			Spec: corev1.ResourceQuotaSpec{
+				Hard: hard,
+			},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: hard,
+				Used: used,
+			},
+		}
+	}
+
+	Context("When there are no quotas", func() {
+		It("Should admit scan job", func() {
+			client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+
+			instance := controller.NewQuotaChecker(client)
+			exceeded, err := instance.Check(context.TODO(), newScanJob("scan-vulnerabilityreport-hash1", "500m", 0))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exceeded).To(BeEmpty())
+		})
+	})
+
+	Context("When scan job fits in quota", func() {
+		It("Should admit scan job", func() {
+			client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				newQuota("compute", corev1.ResourceList{
+					corev1.ResourceRequestsCPU: resource.MustParse("1"),
+					corev1.ResourcePods:        resource.MustParse("2"),
+				}, corev1.ResourceList{
+					corev1.ResourceRequestsCPU: resource.MustParse("500m"),
+					corev1.ResourcePods:        resource.MustParse("1"),
+				}),
+			).Build()
+
+			instance := controller.NewQuotaChecker(client)
+			exceeded, err := instance.Check(context.TODO(), newScanJob("scan-vulnerabilityreport-hash1", "500m", 0))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exceeded).To(BeEmpty())
+		})
+	})
+
+	Context("When scan job exceeds quota", func() {
+		It("Should return name of exceeded quota", func() {
+			client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				newQuota("compute", corev1.ResourceList{
+					corev1.ResourceRequestsCPU: resource.MustParse("1"),
+				}, corev1.ResourceList{
+					corev1.ResourceRequestsCPU: resource.MustParse("800m"),
+				}),
+			).Build()
+
+			instance := controller.NewQuotaChecker(client)
+			exceeded, err := instance.Check(context.TODO(), newScanJob("scan-vulnerabilityreport-hash1", "500m", 0))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exceeded).To(Equal("compute"))
+		})
+	})
+
+	Context("When pending scan jobs exhaust quota", func() {
+		It("Should return name of exceeded quota", func() {
+			client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				newQuota("pods", corev1.ResourceList{
+					corev1.ResourcePods: resource.MustParse("2"),
+				}, corev1.ResourceList{
+					corev1.ResourcePods: resource.MustParse("1"),
+				}),
+				newScanJob("scan-vulnerabilityreport-hash2", "500m", 0),
+				newScanJob("scan-vulnerabilityreport-hash3", "500m", 1),
+				newScanJob("scan-vulnerabilityreport-hash4", "500m", 0, batchv1.JobCondition{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				}),
+			).Build()
+
+			instance := controller.NewQuotaChecker(client)
+			exceeded, err := instance.Check(context.TODO(), newScanJob("scan-vulnerabilityreport-hash1", "500m", 0))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exceeded).To(Equal("pods"))
+		})
+	})
+
+	Context("When quota has scopes", func() {
+		It("Should ignore quota", func() {
+			quota := newQuota("best-effort", corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse("0"),
+			}, corev1.ResourceList{})
+			quota.Spec.Scopes = []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeBestEffort}
+			client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(quota).Build()
+
+			instance := controller.NewQuotaChecker(client)
+			exceeded, err := instance.Check(context.TODO(), newScanJob("scan-vulnerabilityreport-hash1", "500m", 0))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exceeded).To(BeEmpty())
+		})
+	})
+})
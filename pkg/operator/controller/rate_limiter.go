@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter limits the rate at which controllers create scan jobs, which is
+// shared by all controllers, so that mass events such as restarts of all
+// workloads or upgrades of the operator do not flood the API server and
+// container registries with requests.
+//
+// Reserve returns zero if a scan job can be created now. Otherwise, it returns
+// the duration to wait before trying again. Controllers reserve a token right
+// before creating a scan job, after other limits, such as the NodeLimiter or
+// the QuotaChecker, admitted it, so that pushed back scan jobs do not consume
+// tokens.
+type RateLimiter interface {
+	Reserve() time.Duration
+}
+
+// NewRateLimiter constructs a new token bucket RateLimiter which allows
+// creating etc.Config.ScanJobsRateLimit scan jobs per minute with bursts of at
+// most etc.Config.ScanJobsRateBurst scan jobs. If the rate limit is not
+// positive, the returned RateLimiter allows creating scan jobs at any rate.
+func NewRateLimiter(config etc.Config) RateLimiter {
+	if config.ScanJobsRateLimit <= 0 {
+		return &unlimited{}
+	}
+	burst := config.ScanJobsRateBurst
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		limiter: rate.NewLimiter(rate.Limit(config.ScanJobsRateLimit/time.Minute.Seconds()), burst),
+	}
+}
+
+type unlimited struct {
+}
+
+func (u *unlimited) Reserve() time.Duration {
+	return 0
+}
+
+type tokenBucket struct {
+	limiter *rate.Limiter
+}
+
+func (b *tokenBucket) Reserve() time.Duration {
+	reservation := b.limiter.Reserve()
+	delay := reservation.Delay()
+	if delay > 0 {
+		// Give the token back, the reconcile key is requeued instead of
+		// waiting for the token.
+		reservation.Cancel()
+	}
+	return delay
+}
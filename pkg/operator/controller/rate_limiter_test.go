@@ -0,0 +1,37 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+)
+
+var _ = Describe("RateLimiter", func() {
+
+	Context("When rate limit is not set", func() {
+		It("Should never push back scan jobs", func() {
+			instance := controller.NewRateLimiter(etc.Config{ScanJobsRateBurst: 1})
+			for i := 0; i < 100; i++ {
+				Expect(instance.Reserve()).To(BeZero())
+			}
+		})
+	})
+
+	Context("When rate limit is set", func() {
+		It("Should push back scan jobs exceeding the burst", func() {
+			instance := controller.NewRateLimiter(etc.Config{
+				ScanJobsRateLimit: 6,
+				ScanJobsRateBurst: 2,
+			})
+			Expect(instance.Reserve()).To(BeZero())
+			Expect(instance.Reserve()).To(BeZero())
+			Expect(instance.Reserve()).To(BeNumerically(">", 0))
+			// Pushed back scan jobs do not consume tokens.
+			Expect(instance.Reserve()).To(BeNumerically("<=", 10*time.Second))
+		})
+	})
+})
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/export"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ReportExporter exports VulnerabilityReports and ConfigAuditReports before
+// they are deleted, so that compliance archives are complete even if reports
+// are short-lived in the cluster.
+//
+// The exporter adds the starboard.FinalizerReportExport finalizer to reports,
+// which prevents Kubernetes from removing them when they are deleted, either
+// by the TTL of reports or by garbage collection of their owners. Once a
+// report that is being deleted is exported the finalizer is removed. Failed
+// exports are retried with backoff, and the report is not removed until it's
+// exported.
+type ReportExporter struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	Exporter export.Exporter
+	// Sharder is optional. If nil, reports in all namespaces are exported.
+	Sharder Sharder
+}
+
+func (r *ReportExporter) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+
+	reports := []struct {
+		kind      string
+		newReport func() client.Object
+	}{
+		{kind: v1alpha1.VulnerabilityReportKind, newReport: func() client.Object { return &v1alpha1.VulnerabilityReport{} }},
+		{kind: v1alpha1.ConfigAuditReportKind, newReport: func() client.Object { return &v1alpha1.ConfigAuditReport{} }},
+	}
+
+	for _, report := range reports {
+		err = ctrl.NewControllerManagedBy(mgr).
+			Named("reportexporter-"+report.kind).
+			For(report.newReport(), builder.OnlyMetadata, builder.WithPredicates(
+				installModePredicate,
+				InShard(r.Sharder))).
+			WithOptions(controllerOptions(r.Config, 1)).
+			Complete(r.reconcileReport(report.kind, report.newReport))
+		if err != nil {
+			return fmt.Errorf("constructing controller for %s: %w", report.kind, err)
+		}
+	}
+	return nil
+}
+
+func (r *ReportExporter) reconcileReport(kind string, newReport func() client.Object) reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("kind", kind, "report", req.NamespacedName)
+
+		// Check the cached metadata first, so that full reports are only read
+		// from the API server when they're exported.
+		metadata, err := getReportMetadata(ctx, r.Client, kind, req.NamespacedName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if metadata == nil {
+			log.V(1).Info("Ignoring cached report that must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		if metadata.DeletionTimestamp == nil {
+			if controllerutil.ContainsFinalizer(metadata, starboard.FinalizerReportExport) {
+				return ctrl.Result{}, nil
+			}
+			log.V(1).Info("Adding export finalizer")
+			patch := client.MergeFrom(metadata.DeepCopy())
+			controllerutil.AddFinalizer(metadata, starboard.FinalizerReportExport)
+			return ctrl.Result{}, client.IgnoreNotFound(r.Client.Patch(ctx, metadata, patch))
+		}
+		if !controllerutil.ContainsFinalizer(metadata, starboard.FinalizerReportExport) {
+			return ctrl.Result{}, nil
+		}
+
+		report := newReport()
+		err = r.Client.Get(ctx, req.NamespacedName, report)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting report: %w", err)
+		}
+		gvk, err := apiutil.GVKForObject(report, r.Client.Scheme())
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		report.GetObjectKind().SetGroupVersionKind(gvk)
+
+		log.V(1).Info("Exporting report that is being deleted")
+		err = r.Exporter.Export(ctx, export.Payload{
+			Event:   export.EventDeleted,
+			Cluster: r.Config.ClusterName,
+			Report:  report,
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("exporting report: %w", err)
+		}
+
+		patch := client.MergeFrom(metadata.DeepCopy())
+		controllerutil.RemoveFinalizer(metadata, starboard.FinalizerReportExport)
+		err = r.Client.Patch(ctx, metadata, patch)
+		if err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		log.V(1).Info("Exported report and removed export finalizer")
+		return ctrl.Result{}, nil
+	}
+}
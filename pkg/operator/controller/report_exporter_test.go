@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/export"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type exporterFunc func(ctx context.Context, payload export.Payload) error
+
+func (f exporterFunc) Export(ctx context.Context, payload export.Payload) error {
+	return f(ctx, payload)
+}
+
+func TestReportExporter(t *testing.T) {
+	now := metav1.Now()
+	key := types.NamespacedName{Namespace: "default", Name: "replicaset-nginx-nginx"}
+	newReport := func() client.Object { return &v1alpha1.VulnerabilityReport{} }
+
+	t.Run("Should add finalizer to report", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		}).Build()
+		r := &ReportExporter{Logger: log.Log, Client: c, Exporter: exporterFunc(func(context.Context, export.Payload) error {
+			t.Fatal("unexpected export")
+			return nil
+		})}
+
+		_, err := r.reconcileReport(v1alpha1.VulnerabilityReportKind, newReport)(context.TODO(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+
+		var report v1alpha1.VulnerabilityReport
+		require.NoError(t, c.Get(context.TODO(), key, &report))
+		assert.Equal(t, []string{starboard.FinalizerReportExport}, report.Finalizers)
+	})
+
+	t.Run("Should export report that is being deleted and remove finalizer", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         key.Namespace,
+				Name:              key.Name,
+				DeletionTimestamp: &now,
+				Finalizers:        []string{starboard.FinalizerReportExport},
+			},
+		}).Build()
+		var exported export.Payload
+		r := &ReportExporter{Logger: log.Log, Client: c, Exporter: exporterFunc(func(_ context.Context, payload export.Payload) error {
+			exported = payload
+			return nil
+		})}
+
+		_, err := r.reconcileReport(v1alpha1.VulnerabilityReportKind, newReport)(context.TODO(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+		assert.Equal(t, export.EventDeleted, exported.Event)
+		assert.Equal(t, v1alpha1.VulnerabilityReportKind, exported.Report.GetObjectKind().GroupVersionKind().Kind)
+
+		var report v1alpha1.VulnerabilityReport
+		err = c.Get(context.TODO(), key, &report)
+		assert.True(t, k8sapierror.IsNotFound(err), "Should remove report once finalizer is removed")
+	})
+
+	t.Run("Should keep finalizer when export fails", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         key.Namespace,
+				Name:              key.Name,
+				DeletionTimestamp: &now,
+				Finalizers:        []string{starboard.FinalizerReportExport},
+			},
+		}).Build()
+		r := &ReportExporter{Logger: log.Log, Client: c, Exporter: exporterFunc(func(context.Context, export.Payload) error {
+			return errors.New("connection refused")
+		})}
+
+		_, err := r.reconcileReport(v1alpha1.VulnerabilityReportKind, newReport)(context.TODO(), ctrl.Request{NamespacedName: key})
+		assert.EqualError(t, err, "exporting report: connection refused")
+
+		var report v1alpha1.VulnerabilityReport
+		require.NoError(t, c.Get(context.TODO(), key, &report))
+		assert.Equal(t, []string{starboard.FinalizerReportExport}, report.Finalizers)
+	})
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// maxScanJobFailureLength is the maximum length of the
+// starboard.AnnotationScanJobFailure annotation value.
+const maxScanJobFailureLength = 1024
+
+// RetryPolicy determines whether and when to replace a failed scan job of a
+// workload with a new one.
+//
+// Failed scan jobs are kept in the operator namespace until they are replaced,
+// so that the number of attempts, which is stored in the
+// starboard.AnnotationScanJobAttempt annotation, survives restarts of the
+// operator.
+//
+// Next returns the attempt number of the scan job that replaces the given
+// failed scan job and how long to wait before creating it. It returns false if
+// the maximum number of attempts has been reached, in which case the workload
+// is not scanned again until its spec changes.
+type RetryPolicy interface {
+	Next(failedJob *batchv1.Job) (int, time.Duration, bool)
+}
+
+// NewRetryPolicy constructs a new RetryPolicy which doubles the delay after
+// each failed attempt, starting with etc.Config.ScanJobRetryAfter up to
+// etc.Config.ScanJobMaxRetryAfter, and gives up after
+// etc.Config.ScanJobMaxAttempts attempts. If the maximum number of attempts is
+// not positive, the returned RetryPolicy never gives up.
+func NewRetryPolicy(clock ext.Clock, config etc.Config) RetryPolicy {
+	return &exponentialBackoff{
+		clock:        clock,
+		initialDelay: config.ScanJobRetryAfter,
+		maxDelay:     config.ScanJobMaxRetryAfter,
+		maxAttempts:  config.ScanJobMaxAttempts,
+	}
+}
+
+type exponentialBackoff struct {
+	clock        ext.Clock
+	initialDelay time.Duration
+	maxDelay     time.Duration
+	maxAttempts  int
+}
+
+func (b *exponentialBackoff) Next(failedJob *batchv1.Job) (int, time.Duration, bool) {
+	attempt := getScanJobAttempt(failedJob)
+	if b.maxAttempts > 0 && attempt >= b.maxAttempts {
+		return attempt, 0, false
+	}
+
+	delay := b.initialDelay
+	for i := 1; i < attempt && delay < b.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > b.maxDelay {
+		delay = b.maxDelay
+	}
+
+	failedAt := failedJob.CreationTimestamp.Time
+	for _, condition := range failedJob.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			failedAt = condition.LastTransitionTime.Time
+		}
+	}
+
+	retryAfter := failedAt.Add(delay).Sub(b.clock.Now())
+	if retryAfter < 0 {
+		retryAfter = 0
+	}
+	return attempt + 1, retryAfter, true
+}
+
+// getScanJobAttempt returns the value of the
+// starboard.AnnotationScanJobAttempt annotation of the given scan job. Scan
+// jobs without the annotation are considered the first attempt.
+func getScanJobAttempt(job *batchv1.Job) int {
+	attempt, err := strconv.Atoi(job.Annotations[starboard.AnnotationScanJobAttempt])
+	if err != nil || attempt < 1 {
+		return 1
+	}
+	return attempt
+}
+
+func setScanJobAttempt(job *batchv1.Job, attempt int) {
+	if job.Annotations == nil {
+		job.Annotations = make(map[string]string)
+	}
+	job.Annotations[starboard.AnnotationScanJobAttempt] = strconv.Itoa(attempt)
+}
+
+// getScanJobFailure returns a human readable reason of the failure of the given
+// scan job. It consists of the reasons and messages of the containers that
+// terminated with a non-zero exit code or, if there are no such containers,
+// e.g. because the scan job exceeded its deadline, of the job's condition.
+func getScanJobFailure(job *batchv1.Job, statuses map[string]*corev1.ContainerStateTerminated) string {
+	var containers []string
+	for container, status := range statuses {
+		if status.ExitCode == 0 {
+			continue
+		}
+		containers = append(containers, container)
+	}
+	sort.Strings(containers)
+
+	var reasons []string
+	for _, container := range containers {
+		status := statuses[container]
+		reasons = append(reasons, strings.TrimSpace(fmt.Sprintf("%s: %s: %s", container, status.Reason, status.Message)))
+	}
+	if len(reasons) == 0 {
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobFailed {
+				reasons = append(reasons, fmt.Sprintf("%s: %s", condition.Reason, condition.Message))
+			}
+		}
+	}
+
+	failure := strings.Join(reasons, "; ")
+	if len(failure) > maxScanJobFailureLength {
+		failure = failure[:maxScanJobFailureLength]
+	}
+	return failure
+}
+
+// recordScanJobFailure annotates the given failed scan job with the reason of
+// its failure and labels it with starboard.LabelScanJobGaveUp if it will not be
+// retried.
+func recordScanJobFailure(ctx context.Context, c client.Client, job *batchv1.Job, failure string, gaveUp bool) error {
+	patch := client.MergeFrom(job.DeepCopy())
+	if job.Annotations == nil {
+		job.Annotations = make(map[string]string)
+	}
+	job.Annotations[starboard.AnnotationScanJobFailure] = failure
+	if gaveUp {
+		if job.Labels == nil {
+			job.Labels = make(map[string]string)
+		}
+		job.Labels[starboard.LabelScanJobGaveUp] = "true"
+	}
+	err := c.Patch(ctx, job, patch)
+	if err != nil {
+		return fmt.Errorf("recording scan job failure: %w", err)
+	}
+	return nil
+}
+
+// scanJobOwner returns a handler.MapFunc that maps a scan job to the
+// reconcile.Request of the workload it scans, provided that the workload is
+// of the specified kind. It is used to reconcile workloads as soon as their
+// scan jobs fail.
+func scanJobOwner(kind kube.Kind) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		job, ok := obj.(*batchv1.Job)
+		if !ok {
+			return nil
+		}
+		owner, err := kube.ObjectRefFromObjectMeta(job.ObjectMeta)
+		if err != nil || owner.Kind != kind {
+			return nil
+		}
+		return []reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: owner.Namespace, Name: owner.Name}},
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"strconv"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RetryPolicy", func() {
+
+	var clock *ext.FakeClock
+
+	config := etc.Config{
+		ScanJobRetryAfter:    30 * time.Second,
+		ScanJobMaxRetryAfter: time.Hour,
+		ScanJobMaxAttempts:   5,
+	}
+
+	newFailedJob := func(attempt int, failedAt time.Time) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "scan-vulnerabilityreport-hash1",
+				Namespace: "starboard-operator",
+			},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{
+					{
+						Type:               batchv1.JobFailed,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(failedAt),
+					},
+				},
+			},
+		}
+		if attempt > 0 {
+			job.Annotations = map[string]string{
+				starboard.AnnotationScanJobAttempt: strconv.Itoa(attempt),
+			}
+		}
+		return job
+	}
+
+	BeforeEach(func() {
+		clock = ext.NewFakeClock(time.Date(2022, 1, 10, 8, 0, 0, 0, time.UTC))
+	})
+
+	Context("When scan job failed for the first time", func() {
+		It("Should retry after initial delay", func() {
+			instance := controller.NewRetryPolicy(clock, config)
+			attempt, retryAfter, retry := instance.Next(newFailedJob(0, clock.Now()))
+			Expect(retry).To(BeTrue())
+			Expect(attempt).To(Equal(2))
+			Expect(retryAfter).To(Equal(30 * time.Second))
+		})
+	})
+
+	Context("When scan job failed repeatedly", func() {
+		It("Should double delay after each attempt", func() {
+			instance := controller.NewRetryPolicy(clock, config)
+			failedAt := clock.Now()
+			clock.Step(time.Minute)
+			attempt, retryAfter, retry := instance.Next(newFailedJob(3, failedAt))
+			Expect(retry).To(BeTrue())
+			Expect(attempt).To(Equal(4))
+			Expect(retryAfter).To(Equal(time.Minute))
+
+			clock.Step(time.Minute)
+			_, retryAfter, _ = instance.Next(newFailedJob(3, failedAt))
+			Expect(retryAfter).To(BeZero())
+		})
+
+		It("Should not exceed max delay", func() {
+			instance := controller.NewRetryPolicy(clock, etc.Config{
+				ScanJobRetryAfter:    30 * time.Second,
+				ScanJobMaxRetryAfter: time.Hour,
+			})
+			attempt, retryAfter, retry := instance.Next(newFailedJob(20, clock.Now()))
+			Expect(retry).To(BeTrue())
+			Expect(attempt).To(Equal(21))
+			Expect(retryAfter).To(Equal(time.Hour))
+		})
+
+		It("Should give up after max attempts", func() {
+			instance := controller.NewRetryPolicy(clock, config)
+			attempt, _, retry := instance.Next(newFailedJob(5, clock.Now()))
+			Expect(retry).To(BeFalse())
+			Expect(attempt).To(Equal(5))
+		})
+	})
+})
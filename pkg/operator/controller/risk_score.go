@@ -0,0 +1,261 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/riskscore"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// EventReasonRiskPriorityP1 is the reason of the warning event of a workload
+// whose risk score became P1.
+const EventReasonRiskPriorityP1 = "RiskPriorityP1"
+
+// RiskScoreReconciler scores the risk of workloads by combining their
+// VulnerabilityReports, their ConfigAuditReport, and their exposure, e.g.
+// privileged containers or Services reachable from outside the cluster.
+//
+// The score is set as the starboard.risk-score annotation of all reports of
+// a workload, and exported as the starboard_workload_risk_score gauge. It's
+// computed whenever reports of the workload are written, therefore changes of
+// Services and Ingresses are reflected by the next scan of the workload.
+//
+// The workload and its reports are also labeled with the priority derived
+// from the score, e.g. starboard.risk-priority=P1, so that alerting tools and
+// exported reports can key off it. A warning event is recorded when a
+// workload becomes P1.
+type RiskScoreReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	kube.ObjectResolver
+	record.EventRecorder
+	// PriorityTTLs shorten TTLs of VulnerabilityReports of workloads of the
+	// riskiest priorities, so that they're rescanned sooner.
+	PriorityTTLs riskscore.PriorityTTLs
+	// Sharder is optional. If nil, reports in all namespaces are reconciled.
+	Sharder Sharder
+}
+
+func (r *RiskScoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := predicate.InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+	predicates := builder.WithPredicates(
+		predicate.Not(predicate.IsBeingTerminated),
+		installModePredicate,
+		predicate.InShard(r.Sharder))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("riskscore").
+		WithOptions(controllerOptions(r.Config, 1)).
+		For(&v1alpha1.VulnerabilityReport{}, builder.OnlyMetadata, predicates).
+		Watches(&source.Kind{Type: &v1alpha1.ConfigAuditReport{}},
+			handler.EnqueueRequestsFromMapFunc(firstVulnerabilityReportOfOwner(r.Logger, r.Client)), builder.OnlyMetadata, predicates)
+	return watchGainedNamespaces(b, r.Sharder,
+		objectsInNamespace(r.Logger, mgr.GetClient(), newReportMetadata(v1alpha1.VulnerabilityReportKind), false,
+			predicate.Not(predicate.IsBeingTerminated), installModePredicate)).
+		Complete(r.reconcileReport())
+}
+
+func (r *RiskScoreReconciler) reconcileReport() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("report", req.NamespacedName)
+
+		metadata, err := getReportMetadata(ctx, r.Client, v1alpha1.VulnerabilityReportKind, req.NamespacedName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if metadata == nil {
+			log.V(1).Info("Ignoring cached report that must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		owner, err := kube.ObjectRefFromObjectMeta(metadata.ObjectMeta)
+		if err != nil {
+			log.V(1).Info("Ignoring report without owner", "error", err.Error())
+			return ctrl.Result{}, nil
+		}
+		workload, err := r.ObjectFromObjectRef(ctx, owner)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Ignoring report of workload that must have been deleted")
+				workloadRiskScore.DeleteLabelValues(owner.Namespace, string(owner.Kind), owner.Name)
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting workload: %w", err)
+		}
+
+		signals, err := r.getSignals(ctx, owner, workload)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		score := riskscore.Score(signals)
+		workloadRiskScore.WithLabelValues(owner.Namespace, string(owner.Kind), owner.Name).Set(float64(score))
+
+		priority := riskscore.GetPriority(score)
+		err = r.updateReports(ctx, owner, score, priority)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		err = r.setPriorityLabel(ctx, workload, score, priority)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("patching workload labels: %w", err)
+		}
+		log.V(1).Info("Scored risk of workload", "owner", owner, "score", score, "priority", priority)
+		return ctrl.Result{}, nil
+	}
+}
+
+// getSignals returns the signals of the risk of the specified workload.
+func (r *RiskScoreReconciler) getSignals(ctx context.Context, owner kube.ObjectRef, workload client.Object) (riskscore.Signals, error) {
+	var signals riskscore.Signals
+	selector := client.MatchingLabels(kube.ObjectRefToLabels(owner))
+
+	var vulnerabilityReports v1alpha1.VulnerabilityReportList
+	err := r.Client.List(ctx, &vulnerabilityReports, selector, client.InNamespace(owner.Namespace))
+	if err != nil {
+		return signals, fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	for _, report := range vulnerabilityReports.Items {
+		signals.Vulnerabilities = addVulnerabilitySummaries(signals.Vulnerabilities, report.Report.Summary)
+		if cvss := riskscore.GetMaxCVSS(report.Report.Vulnerabilities); cvss > signals.MaxCVSS {
+			signals.MaxCVSS = cvss
+		}
+	}
+
+	var configAuditReports v1alpha1.ConfigAuditReportList
+	err = r.Client.List(ctx, &configAuditReports, selector, client.InNamespace(owner.Namespace))
+	if err != nil {
+		return signals, fmt.Errorf("listing config audit reports: %w", err)
+	}
+	for _, report := range configAuditReports.Items {
+		signals.ConfigAudit.DangerCount += report.Report.Summary.DangerCount
+		signals.ConfigAudit.WarningCount += report.Report.Summary.WarningCount
+		signals.ConfigAudit.PassCount += report.Report.Summary.PassCount
+	}
+
+	spec, err := kube.GetPodSpec(workload)
+	if err != nil {
+		return signals, err
+	}
+	podLabels, err := kube.GetPodTemplateLabels(workload)
+	if err != nil {
+		return signals, err
+	}
+	var services corev1.ServiceList
+	err = r.Client.List(ctx, &services, client.InNamespace(owner.Namespace))
+	if err != nil {
+		return signals, fmt.Errorf("listing services: %w", err)
+	}
+	var ingresses networkingv1.IngressList
+	err = r.Client.List(ctx, &ingresses, client.InNamespace(owner.Namespace))
+	if err != nil {
+		return signals, fmt.Errorf("listing ingresses: %w", err)
+	}
+	signals.Exposure = riskscore.GetExposure(spec, podLabels, services.Items, ingresses.Items)
+	return signals, nil
+}
+
+// updateReports sets the starboard.risk-score annotation and the
+// starboard.risk-priority label of VulnerabilityReports and
+// ConfigAuditReports of the specified workload. The TTL of VulnerabilityReports
+// is shortened to the TTL of the priority, if it's set.
+func (r *RiskScoreReconciler) updateReports(ctx context.Context, owner kube.ObjectRef, score int, priority riskscore.Priority) error {
+	value := strconv.Itoa(score)
+	for _, kind := range []string{v1alpha1.VulnerabilityReportKind, v1alpha1.ConfigAuditReportKind} {
+		reports, err := listReportsMetadata(ctx, r.Client, kind, owner)
+		if err != nil {
+			return err
+		}
+		for i := range reports {
+			report := &reports[i]
+			report.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(kind))
+			patch := client.MergeFrom(report.DeepCopy())
+			changed := false
+			if report.Annotations[starboard.AnnotationRiskScore] != value {
+				metav1.SetMetaDataAnnotation(&report.ObjectMeta, starboard.AnnotationRiskScore, value)
+				changed = true
+			}
+			if report.Labels[starboard.LabelRiskPriority] != string(priority) {
+				metav1.SetMetaDataLabel(&report.ObjectMeta, starboard.LabelRiskPriority, string(priority))
+				changed = true
+			}
+			if ttl, ok := r.PriorityTTLs[priority]; ok && kind == v1alpha1.VulnerabilityReportKind &&
+				isShorterTTL(report.Annotations[v1alpha1.TTLReportAnnotation], ttl) {
+				metav1.SetMetaDataAnnotation(&report.ObjectMeta, v1alpha1.TTLReportAnnotation, ttl.String())
+				changed = true
+			}
+			if !changed {
+				continue
+			}
+			err = r.Client.Patch(ctx, report, patch)
+			if err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("patching %s metadata: %w", kind, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isShorterTTL returns true if the TTL is shorter than the value of the TTL
+// annotation of a report, or if the annotation is not set or invalid.
+func isShorterTTL(value string, ttl time.Duration) bool {
+	current, err := time.ParseDuration(value)
+	return err != nil || ttl < current
+}
+
+// setPriorityLabel sets the starboard.risk-priority label of the specified
+// workload, and records a warning event when the workload becomes P1.
+func (r *RiskScoreReconciler) setPriorityLabel(ctx context.Context, workload client.Object, score int, priority riskscore.Priority) error {
+	previous := workload.GetLabels()[starboard.LabelRiskPriority]
+	if previous == string(priority) {
+		return nil
+	}
+	patch := client.MergeFrom(workload.DeepCopyObject().(client.Object))
+	labels := workload.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[starboard.LabelRiskPriority] = string(priority)
+	workload.SetLabels(labels)
+	err := r.Client.Patch(ctx, workload, patch)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if priority == riskscore.PriorityP1 && r.EventRecorder != nil {
+		r.EventRecorder.Eventf(workload, corev1.EventTypeWarning, EventReasonRiskPriorityP1,
+			"Risk score %d of the workload is %s", score, priority)
+	}
+	return nil
+}
+
+func addVulnerabilitySummaries(a, b v1alpha1.VulnerabilitySummary) v1alpha1.VulnerabilitySummary {
+	return v1alpha1.VulnerabilitySummary{
+		CriticalCount: a.CriticalCount + b.CriticalCount,
+		HighCount:     a.HighCount + b.HighCount,
+		MediumCount:   a.MediumCount + b.MediumCount,
+		LowCount:      a.LowCount + b.LowCount,
+		UnknownCount:  a.UnknownCount + b.UnknownCount,
+		NoneCount:     a.NoneCount + b.NoneCount,
+	}
+}
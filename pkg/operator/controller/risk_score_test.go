@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/riskscore"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestRiskScoreReconciler(t *testing.T) {
+	podLabels := map[string]string{"app": "nginx"}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-6d4cf56db6"},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "nginx", SecurityContext: &corev1.SecurityContext{Privileged: pointer.Bool(true)}},
+					},
+				},
+			},
+		},
+	}
+	ownerLabels := func(container string) map[string]string {
+		labels := kube.ObjectRefToLabels(kube.ObjectRef{Kind: kube.KindReplicaSet, Name: replicaSet.Name, Namespace: "default"})
+		if container != "" {
+			labels[starboard.LabelContainerName] = container
+		}
+		return labels
+	}
+	vulnerabilityReport := &v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "replicaset-nginx-6d4cf56db6-nginx",
+			Labels:    ownerLabels("nginx"),
+			Annotations: map[string]string{
+				v1alpha1.TTLReportAnnotation: "24h0m0s",
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 1},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2021-44228", Severity: v1alpha1.SeverityCritical, Score: pointer.Float64(10)},
+				{VulnerabilityID: "CVE-2020-1234", Severity: v1alpha1.SeverityHigh, Score: pointer.Float64(7.5)},
+			},
+		},
+	}
+	configAuditReport := &v1alpha1.ConfigAuditReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "replicaset-nginx-6d4cf56db6",
+			Labels:    ownerLabels(""),
+		},
+		Report: v1alpha1.ConfigAuditReportData{
+			Summary: v1alpha1.ConfigAuditSummary{DangerCount: 1, WarningCount: 1},
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, Selector: podLabels},
+	}
+
+	scheme := starboard.NewScheme()
+	require.NoError(t, networkingv1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(replicaSet, vulnerabilityReport, configAuditReport, service).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &RiskScoreReconciler{
+		Logger:         log.Log,
+		Client:         c,
+		ObjectResolver: kube.ObjectResolver{Client: c},
+		EventRecorder:  recorder,
+		PriorityTTLs:   riskscore.PriorityTTLs{riskscore.PriorityP2: 12 * time.Hour},
+	}
+
+	_, err := r.reconcileReport()(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vulnerabilityReport)})
+	require.NoError(t, err)
+
+	// 10+4 for vulnerabilities, 10 for CVSS, 4+1 for config audit, 7+8 for exposure.
+	const expectedScore = "44"
+	var foundVulnerabilityReport v1alpha1.VulnerabilityReport
+	require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(vulnerabilityReport), &foundVulnerabilityReport))
+	assert.Equal(t, expectedScore, foundVulnerabilityReport.Annotations[starboard.AnnotationRiskScore])
+	var foundConfigAuditReport v1alpha1.ConfigAuditReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: configAuditReport.Name}, &foundConfigAuditReport))
+	assert.Equal(t, expectedScore, foundConfigAuditReport.Annotations[starboard.AnnotationRiskScore])
+	assert.Equal(t, 44.0, testutil.ToFloat64(workloadRiskScore.WithLabelValues("default", "ReplicaSet", replicaSet.Name)))
+
+	// The score is P2, whose TTL is shorter than the TTL of the report.
+	assert.Equal(t, "P2", foundVulnerabilityReport.Labels[starboard.LabelRiskPriority])
+	assert.Equal(t, "12h0m0s", foundVulnerabilityReport.Annotations[v1alpha1.TTLReportAnnotation])
+	assert.Equal(t, "P2", foundConfigAuditReport.Labels[starboard.LabelRiskPriority])
+	assert.NotContains(t, foundConfigAuditReport.Annotations, v1alpha1.TTLReportAnnotation)
+	var foundReplicaSet appsv1.ReplicaSet
+	require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(replicaSet), &foundReplicaSet))
+	assert.Equal(t, "P2", foundReplicaSet.Labels[starboard.LabelRiskPriority])
+	assert.Empty(t, recorder.Events, "Expected events only for P1 workloads")
+
+	t.Run("Should record event when workload becomes P1", func(t *testing.T) {
+		require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(vulnerabilityReport), &foundVulnerabilityReport))
+		foundVulnerabilityReport.Report.Summary.CriticalCount = 4
+		require.NoError(t, c.Update(context.TODO(), &foundVulnerabilityReport))
+
+		_, err := r.reconcileReport()(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vulnerabilityReport)})
+		require.NoError(t, err)
+		require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(replicaSet), &foundReplicaSet))
+		assert.Equal(t, "P1", foundReplicaSet.Labels[starboard.LabelRiskPriority])
+		require.Len(t, recorder.Events, 1)
+		assert.Equal(t, "Warning RiskPriorityP1 Risk score 74 of the workload is P1", <-recorder.Events)
+	})
+
+	t.Run("Should enqueue vulnerability report of config audit report", func(t *testing.T) {
+		requests := firstVulnerabilityReportOfOwner(r.Logger, c)(configAuditReport)
+		require.Len(t, requests, 1)
+		assert.Equal(t, client.ObjectKeyFromObject(vulnerabilityReport), requests[0].NamespacedName)
+	})
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxScanFailureLogsLength is the maximum length of the logs recorded for each
+// container of a failed scan job.
+const maxScanFailureLogsLength = 2048
+
+// newScanFailureReportData returns the record of the specified failed scan job
+// with the image, exit code, termination reason and message, and the tail of
+// the logs of each container that terminated with a non-zero exit code. It's
+// up to the caller to set the scanner, report kind and whether the operator
+// gave up.
+func newScanFailureReportData(ctx context.Context, logsReader kube.LogsReader, job *batchv1.Job,
+	statuses map[string]*corev1.ContainerStateTerminated, images kube.ContainerImages) v1alpha1.ScanFailureReportData {
+	data := v1alpha1.ScanFailureReportData{
+		UpdateTimestamp: metav1.Now(),
+		Job:             job.Name,
+		Attempt:         getScanJobAttempt(job),
+		Containers:      []v1alpha1.ScanFailureContainer{},
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed {
+			data.Reason = condition.Reason
+			data.Message = condition.Message
+		}
+	}
+
+	var containers []string
+	for container, status := range statuses {
+		if status.ExitCode == 0 {
+			continue
+		}
+		containers = append(containers, container)
+	}
+	sort.Strings(containers)
+
+	for _, container := range containers {
+		status := statuses[container]
+		data.Containers = append(data.Containers, v1alpha1.ScanFailureContainer{
+			Name:     container,
+			Image:    images[container],
+			ExitCode: status.ExitCode,
+			Reason:   status.Reason,
+			Message:  strings.TrimSpace(status.Message),
+			Logs:     tailLogs(ctx, logsReader, job, container),
+		})
+	}
+	return data
+}
+
+// tailLogs returns the last maxScanFailureLogsLength bytes of the logs of the
+// specified container of the scan job, or an empty string if the logs cannot
+// be read.
+func tailLogs(ctx context.Context, logsReader kube.LogsReader, job *batchv1.Job, container string) string {
+	logsStream, err := logsReader.GetLogsByJobAndContainerName(ctx, job, container)
+	if err != nil {
+		return ""
+	}
+	defer func() {
+		_ = logsStream.Close()
+	}()
+	tail := &tailWriter{size: maxScanFailureLogsLength}
+	_, err = io.Copy(tail, logsStream)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(tail.buf))
+}
+
+// tailWriter is an io.Writer which keeps only the last size bytes written.
+type tailWriter struct {
+	size int
+	buf  []byte
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	if len(w.buf) > w.size {
+		w.buf = append(w.buf[:0], w.buf[len(w.buf)-w.size:]...)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/hook"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ScanHooks calls hooks of the scan lifecycle of workloads. The zero value
+// doesn't call any hooks.
+type ScanHooks struct {
+	// Cluster is the name of the cluster sent to hooks.
+	Cluster string
+	// PreScan is optional. If set, it's called before a scan job is created.
+	PreScan hook.Hook
+	// PreScanFailurePolicy determines whether the scan job is created if the
+	// PreScan hook fails.
+	PreScanFailurePolicy etc.HookFailurePolicy
+	// PostReport is optional. If set, it's called after reports produced by
+	// a scan job are persisted.
+	PostReport hook.Hook
+}
+
+// NewScanHooks constructs ScanHooks with webhooks configured with
+// OPERATOR_PRE_SCAN_HOOK_URL and OPERATOR_POST_REPORT_HOOK_URL.
+func NewScanHooks(config etc.Config) (ScanHooks, error) {
+	hooks := ScanHooks{Cluster: config.ClusterName}
+	if config.PreScanHookURL != "" {
+		policy, err := config.GetPreScanHookFailurePolicy()
+		if err != nil {
+			return ScanHooks{}, err
+		}
+		hooks.PreScan = hook.NewWebhook(config.PreScanHookURL, config.ScanHooksTimeout)
+		hooks.PreScanFailurePolicy = policy
+	}
+	if config.PostReportHookURL != "" {
+		hooks.PostReport = hook.NewWebhook(config.PostReportHookURL, config.ScanHooksTimeout)
+	}
+	return hooks, nil
+}
+
+// preScan calls the PreScan hook with the scan job of the workload. It returns
+// the error of the hook unless the failure policy is to ignore it.
+func (h ScanHooks) preScan(ctx context.Context, log logr.Logger, scheme *runtime.Scheme, workload client.Object, job *batchv1.Job) error {
+	if h.PreScan == nil {
+		return nil
+	}
+	payload, err := h.newPayload(hook.StagePreScan, scheme, workload)
+	if err != nil {
+		return err
+	}
+	payload.ScanJob = job.DeepCopy()
+	payload.ScanJob.SetGroupVersionKind(batchv1.SchemeGroupVersion.WithKind("Job"))
+	err = h.PreScan.Call(ctx, payload)
+	if err != nil {
+		if h.PreScanFailurePolicy == etc.HookFailurePolicyIgnore {
+			log.Error(err, "Ignoring failure of pre-scan hook")
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// postReport calls the PostReport hook with the persisted reports of the
+// workload. Reports are already persisted, hence failures are only logged.
+func (h ScanHooks) postReport(ctx context.Context, log logr.Logger, scheme *runtime.Scheme, workload client.Object, reports []client.Object) {
+	if h.PostReport == nil {
+		return
+	}
+	payload, err := h.newPayload(hook.StagePostReport, scheme, workload)
+	if err != nil {
+		log.Error(err, "Unable to call post-report hook")
+		return
+	}
+	for _, report := range reports {
+		gvk, err := apiutil.GVKForObject(report, scheme)
+		if err != nil {
+			log.Error(err, "Unable to call post-report hook")
+			return
+		}
+		report = report.DeepCopyObject().(client.Object)
+		report.GetObjectKind().SetGroupVersionKind(gvk)
+		payload.Reports = append(payload.Reports, report)
+	}
+	err = h.PostReport.Call(ctx, payload)
+	if err != nil {
+		log.Error(err, "Post-report hook failed")
+	}
+}
+
+func (h ScanHooks) newPayload(stage hook.Stage, scheme *runtime.Scheme, workload client.Object) (hook.Payload, error) {
+	gvk, err := apiutil.GVKForObject(workload, scheme)
+	if err != nil {
+		return hook.Payload{}, fmt.Errorf("getting kind of workload: %w", err)
+	}
+	return hook.Payload{
+		Stage:   stage,
+		Cluster: h.Cluster,
+		Workload: hook.Workload{
+			Kind:      gvk.Kind,
+			Name:      workload.GetName(),
+			Namespace: workload.GetNamespace(),
+		},
+	}, nil
+}
+
+// callPostReportHook calls the PostReport hook with the specified reports,
+// which have just been written.
+func (r *VulnerabilityReportReconciler) callPostReportHook(ctx context.Context, owner client.Object, reports []v1alpha1.VulnerabilityReport) {
+	var objects []client.Object
+	for i := range reports {
+		objects = append(objects, &reports[i])
+	}
+	r.Hooks.postReport(ctx, r.Logger.WithValues("owner", client.ObjectKeyFromObject(owner)), r.Client.Scheme(), owner, objects)
+}
+
+// getConfigAuditReport returns the ConfigAuditReport, or the
+// ClusterConfigAuditReport of a cluster-scoped owner, built by the specified
+// builder.
+func getConfigAuditReport(owner client.Object, builder *configauditreport.ReportBuilder) (client.Object, error) {
+	if kube.IsClusterScopedKind(owner.GetObjectKind().GroupVersionKind().Kind) {
+		report, err := builder.GetClusterReport()
+		return &report, err
+	}
+	report, err := builder.GetReport()
+	return &report, err
+}
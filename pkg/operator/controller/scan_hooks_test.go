@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/hook"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type hookFunc func(ctx context.Context, payload hook.Payload) error
+
+func (f hookFunc) Call(ctx context.Context, payload hook.Payload) error {
+	return f(ctx, payload)
+}
+
+func TestScanHooks(t *testing.T) {
+	scheme := starboard.NewScheme()
+	workload := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-6d4cf56db6"},
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "scan-vulnerabilityreport-5d4445db4f"},
+	}
+	failing := hookFunc(func(_ context.Context, _ hook.Payload) error {
+		return errors.New("connection refused")
+	})
+
+	t.Run("Should not call hooks by default", func(t *testing.T) {
+		hooks, err := NewScanHooks(etc.Config{PreScanHookFailurePolicy: "Fail"})
+		require.NoError(t, err)
+		assert.NoError(t, hooks.preScan(context.TODO(), log.Log, scheme, workload, job))
+		hooks.postReport(context.TODO(), log.Log, scheme, workload, nil)
+	})
+
+	t.Run("Should call pre-scan hook with scan job", func(t *testing.T) {
+		var payload hook.Payload
+		hooks := ScanHooks{
+			Cluster: "prod",
+			PreScan: hookFunc(func(_ context.Context, p hook.Payload) error {
+				payload = p
+				return nil
+			}),
+			PreScanFailurePolicy: etc.HookFailurePolicyFail,
+		}
+		require.NoError(t, hooks.preScan(context.TODO(), log.Log, scheme, workload, job))
+		assert.Equal(t, hook.StagePreScan, payload.Stage)
+		assert.Equal(t, "prod", payload.Cluster)
+		assert.Equal(t, hook.Workload{Kind: "ReplicaSet", Name: "nginx-6d4cf56db6", Namespace: "default"}, payload.Workload)
+		require.NotNil(t, payload.ScanJob)
+		assert.Equal(t, "Job", payload.ScanJob.Kind)
+		assert.Equal(t, job.Name, payload.ScanJob.Name)
+		assert.Empty(t, job.Kind, "Should not modify scan job")
+	})
+
+	t.Run("Should return error of pre-scan hook when failure policy is Fail", func(t *testing.T) {
+		hooks := ScanHooks{PreScan: failing, PreScanFailurePolicy: etc.HookFailurePolicyFail}
+		assert.EqualError(t, hooks.preScan(context.TODO(), log.Log, scheme, workload, job), "connection refused")
+	})
+
+	t.Run("Should ignore error of pre-scan hook when failure policy is Ignore", func(t *testing.T) {
+		hooks := ScanHooks{PreScan: failing, PreScanFailurePolicy: etc.HookFailurePolicyIgnore}
+		assert.NoError(t, hooks.preScan(context.TODO(), log.Log, scheme, workload, job))
+	})
+
+	t.Run("Should call post-report hook with reports", func(t *testing.T) {
+		var payload hook.Payload
+		hooks := ScanHooks{
+			PostReport: hookFunc(func(_ context.Context, p hook.Payload) error {
+				payload = p
+				return nil
+			}),
+		}
+		report := &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "replicaset-nginx-6d4cf56db6-nginx"},
+		}
+		hooks.postReport(context.TODO(), log.Log, scheme, workload, []client.Object{report})
+		assert.Equal(t, hook.StagePostReport, payload.Stage)
+		require.Len(t, payload.Reports, 1)
+		assert.Equal(t, "VulnerabilityReport", payload.Reports[0].GetObjectKind().GroupVersionKind().Kind)
+		assert.Equal(t, report.Name, payload.Reports[0].GetName())
+	})
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ScanJobsResumer adopts scan jobs that are still running after a restart of
+// the operator.
+//
+// Running scan jobs are not recreated, because their names are derived from
+// the scanned objects, and their results are processed by the job controllers
+// as soon as they complete. However, objects that were deleted while the
+// operator was down would never be scanned, therefore their scan jobs are
+// deleted right away instead of being waited for.
+type ScanJobsResumer struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	kube.ObjectResolver
+	// Sharder is optional. If nil, scan jobs of objects in all namespaces are
+	// adopted.
+	Sharder Sharder
+}
+
+func (r *ScanJobsResumer) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(r)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so that scan
+// jobs are adopted by the leader only.
+func (r *ScanJobsResumer) NeedLeaderElection() bool {
+	return true
+}
+
+// Start adopts running scan jobs. It's called by the manager once caches are
+// synced.
+func (r *ScanJobsResumer) Start(ctx context.Context) error {
+	var jobList batchv1.JobList
+	err := r.Client.List(ctx, &jobList, client.MatchingLabels{
+		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+	}, client.InNamespace(r.Config.GetScanJobsNamespace()))
+	if err != nil {
+		return fmt.Errorf("listing scan jobs: %w", err)
+	}
+
+	resumed := 0
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if len(job.Status.Conditions) > 0 {
+			continue
+		}
+		log := r.Logger.WithValues("job", fmt.Sprintf("%s/%s", job.Namespace, job.Name))
+
+		owner, err := kube.ObjectRefFromObjectMeta(job.ObjectMeta)
+		if err != nil {
+			log.V(1).Info("Ignoring scan job without owner", "reason", err)
+			continue
+		}
+		if r.Sharder != nil && !r.Sharder.Owns(owner.Namespace) {
+			continue
+		}
+
+		_, err = r.ObjectFromObjectRef(ctx, owner)
+		if err == nil {
+			log.V(1).Info("Resuming scan job", "owner", owner)
+			resumed++
+			continue
+		}
+		if !k8sapierror.IsNotFound(err) {
+			log.Error(err, "Unable to get scan job owner", "owner", owner)
+			continue
+		}
+
+		log.V(1).Info("Deleting orphaned scan job", "owner", owner)
+		err = r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !k8sapierror.IsNotFound(err) {
+			log.Error(err, "Unable to delete orphaned scan job")
+		}
+	}
+	r.Logger.Info("Resumed running scan jobs", "count", resumed)
+	return nil
+}
@@ -0,0 +1,75 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ScanJobsResumer", func() {
+
+	config := etc.Config{
+		Namespace: "starboard-operator",
+	}
+
+	newScanJob := func(name, replicaSet string, conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "starboard-operator",
+				Labels: map[string]string{
+					starboard.LabelK8SAppManagedBy:   starboard.AppStarboard,
+					starboard.LabelResourceKind:      string(kube.KindReplicaSet),
+					starboard.LabelResourceName:      replicaSet,
+					starboard.LabelResourceNamespace: "default",
+				},
+			},
+			Status: batchv1.JobStatus{
+				Conditions: conditions,
+			},
+		}
+	}
+
+	It("Should delete running scan jobs of deleted objects", func() {
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+				Name:      "nginx-6d4cf56db6",
+				Namespace: "default",
+			}},
+			newScanJob("scan-vulnerabilityreport-hash1", "nginx-6d4cf56db6"),
+			newScanJob("scan-vulnerabilityreport-hash2", "wordpress-5f6b6c9d8"),
+			newScanJob("scan-vulnerabilityreport-hash3", "redis-7c9f8d6b4",
+				batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}),
+		).Build()
+
+		instance := &controller.ScanJobsResumer{
+			Logger:         logr.Discard(),
+			Config:         config,
+			Client:         client,
+			ObjectResolver: kube.ObjectResolver{Client: client},
+		}
+		Expect(instance.Start(context.TODO())).To(Succeed())
+
+		var jobs batchv1.JobList
+		Expect(client.List(context.TODO(), &jobs)).To(Succeed())
+		var names []string
+		for _, job := range jobs.Items {
+			names = append(names, job.Name)
+		}
+		// Complete scan jobs are left to the job controllers.
+		Expect(names).To(ConsistOf("scan-vulnerabilityreport-hash1", "scan-vulnerabilityreport-hash3"))
+
+	})
+})
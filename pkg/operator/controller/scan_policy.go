@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getScanPolicy returns the spec of the ScanPolicy that applies to workloads
+// in the specified namespace, or nil if there's no such policy or the
+// ScanPolicy CRD is not installed, in which case the cluster-wide
+// configuration applies.
+func getScanPolicy(ctx context.Context, c client.Client, namespace string) (*v1alpha1.ScanPolicySpec, error) {
+	if namespace == "" {
+		return nil, nil
+	}
+	var policy v1alpha1.ScanPolicy
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: v1alpha1.ScanPolicyName}, &policy)
+	if err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting scan policy: %w", err)
+	}
+	return &policy.Spec, nil
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+)
+
+// ScanQueue decides the order in which pending scans of workloads create their
+// scan jobs when the number of scan jobs approaches the limit of concurrent
+// scan jobs. Pending scans are ordered by the priority of the namespace of a
+// workload, then initial scans of workloads come before rescans of workloads
+// whose reports have expired, and then scans are ordered by the time they
+// have been queued.
+//
+// Admit queues the scan of the specified workload, unless it is already
+// queued, and returns true if the scan is among the given number of free
+// scan job slots, in which case the scan is removed from the queue.
+//
+// HasBeenScanned returns true if a scan of the specified workload has been
+// admitted before, i.e. the next scan of the workload is a rescan.
+type ScanQueue interface {
+	Admit(workload kube.ObjectRef, rescan bool, slots int) bool
+	HasBeenScanned(workload kube.ObjectRef) bool
+}
+
+// NewScanQueue constructs a new in-memory ScanQueue with the given priorities
+// of namespaces. Namespaces without a priority have the priority 0, and higher
+// values come first.
+//
+// Workloads are expected to retry Admit periodically until admitted. Scans of
+// workloads that have not retried for the staleAfter duration, e.g. because
+// they have been deleted or their reports have been created otherwise, are
+// dropped from the queue. Admitted scans are remembered for the
+// rememberScannedFor duration.
+func NewScanQueue(clock ext.Clock, namespacePriorities map[string]int, staleAfter, rememberScannedFor time.Duration) ScanQueue {
+	return &scanQueue{
+		clock:               clock,
+		namespacePriorities: namespacePriorities,
+		staleAfter:          staleAfter,
+		rememberScannedFor:  rememberScannedFor,
+		pending:             make(map[kube.ObjectRef]*pendingScan),
+		scanned:             make(map[kube.ObjectRef]time.Time),
+	}
+}
+
+type pendingScan struct {
+	workload kube.ObjectRef
+	priority int
+	rescan   bool
+	queuedAt time.Time
+	seenAt   time.Time
+}
+
+type scanQueue struct {
+	clock               ext.Clock
+	namespacePriorities map[string]int
+	staleAfter          time.Duration
+	rememberScannedFor  time.Duration
+
+	mu      sync.Mutex
+	pending map[kube.ObjectRef]*pendingScan
+	scanned map[kube.ObjectRef]time.Time
+}
+
+func (q *scanQueue) Admit(workload kube.ObjectRef, rescan bool, slots int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.clock.Now()
+	q.prune(now)
+
+	scan, ok := q.pending[workload]
+	if !ok {
+		scan = &pendingScan{
+			workload: workload,
+			queuedAt: now,
+		}
+		q.pending[workload] = scan
+	}
+	scan.priority = q.namespacePriorities[workload.Namespace]
+	scan.rescan = rescan
+	scan.seenAt = now
+
+	if slots <= 0 {
+		return false
+	}
+
+	ahead := 0
+	for _, other := range q.pending {
+		if other != scan && other.before(scan) {
+			ahead++
+		}
+	}
+	if ahead >= slots {
+		return false
+	}
+
+	delete(q.pending, workload)
+	q.scanned[workload] = now
+	return true
+}
+
+func (q *scanQueue) HasBeenScanned(workload kube.ObjectRef) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	scannedAt, ok := q.scanned[workload]
+	return ok && q.clock.Now().Sub(scannedAt) < q.rememberScannedFor
+}
+
+func (q *scanQueue) prune(now time.Time) {
+	for workload, scan := range q.pending {
+		if now.Sub(scan.seenAt) >= q.staleAfter {
+			delete(q.pending, workload)
+		}
+	}
+	for workload, scannedAt := range q.scanned {
+		if now.Sub(scannedAt) >= q.rememberScannedFor {
+			delete(q.scanned, workload)
+		}
+	}
+}
+
+// before returns true if the scan s should create its scan job before the
+// other scan.
+func (s *pendingScan) before(other *pendingScan) bool {
+	if s.priority != other.priority {
+		return s.priority > other.priority
+	}
+	if s.rescan != other.rescan {
+		return !s.rescan
+	}
+	if !s.queuedAt.Equal(other.queuedAt) {
+		return s.queuedAt.Before(other.queuedAt)
+	}
+	// Make the order total, so that the same scans are admitted regardless
+	// of the order of calls.
+	return sortKey(s.workload) < sortKey(other.workload)
+}
+
+func sortKey(workload kube.ObjectRef) string {
+	return workload.Namespace + "/" + string(workload.Kind) + "/" + workload.Name
+}
@@ -0,0 +1,97 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+)
+
+var _ = Describe("ScanQueue", func() {
+
+	var clock *ext.FakeClock
+	var queue controller.ScanQueue
+
+	prodNginx := kube.ObjectRef{Kind: kube.KindReplicaSet, Name: "nginx-6d4cf56db6", Namespace: "prod"}
+	prodRedis := kube.ObjectRef{Kind: kube.KindStatefulSet, Name: "redis", Namespace: "prod"}
+	devNginx := kube.ObjectRef{Kind: kube.KindReplicaSet, Name: "nginx-7ff6d7c6d5", Namespace: "dev"}
+	devRedis := kube.ObjectRef{Kind: kube.KindStatefulSet, Name: "redis", Namespace: "dev"}
+
+	BeforeEach(func() {
+		clock = ext.NewFakeClock(time.Date(2022, 1, 10, 8, 0, 0, 0, time.UTC))
+		queue = controller.NewScanQueue(clock, map[string]int{"prod": 100}, 90*time.Second, 48*time.Hour)
+	})
+
+	Context("When there are free slots and no pending scans", func() {
+		It("Should admit scan", func() {
+			Expect(queue.Admit(devNginx, false, 1)).To(BeTrue())
+		})
+	})
+
+	Context("When there are no free slots", func() {
+		It("Should not admit scan", func() {
+			Expect(queue.Admit(devNginx, false, 0)).To(BeFalse())
+		})
+	})
+
+	Context("When scans are pending", func() {
+		It("Should admit scans of higher priority namespaces first", func() {
+			Expect(queue.Admit(devNginx, false, 0)).To(BeFalse())
+			clock.Step(time.Second)
+			Expect(queue.Admit(prodNginx, false, 0)).To(BeFalse())
+
+			Expect(queue.Admit(devNginx, false, 1)).To(BeFalse())
+			Expect(queue.Admit(prodNginx, false, 1)).To(BeTrue())
+			Expect(queue.Admit(devNginx, false, 1)).To(BeTrue())
+		})
+
+		It("Should admit initial scans before rescans", func() {
+			Expect(queue.Admit(devRedis, true, 0)).To(BeFalse())
+			clock.Step(time.Second)
+			Expect(queue.Admit(devNginx, false, 0)).To(BeFalse())
+
+			Expect(queue.Admit(devRedis, true, 1)).To(BeFalse())
+			Expect(queue.Admit(devNginx, false, 1)).To(BeTrue())
+			Expect(queue.Admit(devRedis, true, 1)).To(BeTrue())
+		})
+
+		It("Should admit scans in the order they have been queued", func() {
+			Expect(queue.Admit(prodRedis, false, 0)).To(BeFalse())
+			clock.Step(time.Second)
+			Expect(queue.Admit(prodNginx, false, 0)).To(BeFalse())
+
+			Expect(queue.Admit(prodNginx, false, 1)).To(BeFalse())
+			Expect(queue.Admit(prodRedis, false, 1)).To(BeTrue())
+		})
+
+		It("Should admit as many scans as there are free slots", func() {
+			Expect(queue.Admit(prodRedis, false, 0)).To(BeFalse())
+			Expect(queue.Admit(prodNginx, false, 0)).To(BeFalse())
+
+			Expect(queue.Admit(devNginx, false, 2)).To(BeFalse())
+			Expect(queue.Admit(prodNginx, false, 2)).To(BeTrue())
+		})
+
+		It("Should drop stale scans", func() {
+			Expect(queue.Admit(prodNginx, false, 0)).To(BeFalse())
+			clock.Step(2 * time.Minute)
+
+			Expect(queue.Admit(devNginx, false, 1)).To(BeTrue())
+		})
+	})
+
+	Context("When scan has been admitted", func() {
+		It("Should remember that workload has been scanned", func() {
+			Expect(queue.HasBeenScanned(devNginx)).To(BeFalse())
+			Expect(queue.Admit(devNginx, false, 1)).To(BeTrue())
+			Expect(queue.HasBeenScanned(devNginx)).To(BeTrue())
+
+			clock.Step(48 * time.Hour)
+			Expect(queue.HasBeenScanned(devNginx)).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/nodeagent"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrNoScannerWorker is returned by ScannerPool if none of its workers is
+// ready.
+var ErrNoScannerWorker = errors.New("no ready scanner worker")
+
+// ScannerPool dispatches scans of images to a pool of warm scanner workers,
+// i.e. pods of the starboard-scanner-pool Deployment in the operator
+// namespace, which keep the vulnerability database loaded and scan images
+// pulled from registries. Unlike scan jobs, workers don't have to be
+// scheduled and download the database for each scan, which reduces the time
+// to the first report of a new workload from minutes to seconds.
+//
+// Workers serve the same protocol as node agents, and scans are spread
+// between ready workers in a round-robin fashion.
+type ScannerPool struct {
+	client    client.Client
+	namespace string
+	selector  labels.Selector
+	port      int
+	worker    *nodeagent.Client
+	next      uint32
+}
+
+// NewScannerPool constructs ScannerPool that selects pods of workers with
+// OPERATOR_SCANNER_POOL_SELECTOR, and verifies their certificates with the
+// CA in OPERATOR_SCANNER_POOL_CA_FILE.
+func NewScannerPool(config etc.Config, c client.Client) (*ScannerPool, error) {
+	selector, err := labels.Parse(config.ScannerPoolSelector)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", "OPERATOR_SCANNER_POOL_SELECTOR", err)
+	}
+	tlsConfig, err := nodeagent.NewClientTLSConfig(config.ScannerPoolCAFile, config.ScannerPoolServerName)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", "OPERATOR_SCANNER_POOL_CA_FILE", err)
+	}
+	return &ScannerPool{
+		client:    c,
+		namespace: config.Namespace,
+		selector:  selector,
+		port:      config.ScannerPoolPort,
+		worker:    nodeagent.NewClient(config.ScannerPoolTimeout, tlsConfig, config.ScannerPoolTokenFile),
+	}, nil
+}
+
+// Scan scans the images of containers with the next ready worker. It
+// returns ErrNoScannerWorker if none of the workers is ready.
+func (p *ScannerPool) Scan(ctx context.Context, images kube.ContainerImages,
+	credentials map[string]docker.Auth) (map[string]nodeagent.Result, error) {
+	var pods corev1.PodList
+	err := p.client.List(ctx, &pods, client.InNamespace(p.namespace),
+		client.MatchingLabelsSelector{Selector: p.selector})
+	if err != nil {
+		return nil, fmt.Errorf("listing scanner workers: %w", err)
+	}
+	var workers []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Status.PodIP != "" && isPodReady(pod) {
+			workers = append(workers, pod)
+		}
+	}
+	if len(workers) == 0 {
+		return nil, ErrNoScannerWorker
+	}
+	worker := workers[int(atomic.AddUint32(&p.next, 1)-1)%len(workers)]
+	resp, err := p.worker.Scan(ctx, net.JoinHostPort(worker.Status.PodIP, strconv.Itoa(p.port)), nodeagent.ScanRequest{
+		Images:      images,
+		Credentials: credentials,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning with scanner worker %s: %w", worker.Name, err)
+	}
+	return resp.Results, nil
+}
+
+// scanWithScannerPool scans the images of the workload with a worker of the
+// scanner pool, and creates VulnerabilityReports from the results. It returns
+// false if the workload must be scanned with a scan job instead, because none
+// of the workers is ready.
+func (r *VulnerabilityReportReconciler) scanWithScannerPool(ctx context.Context, log logr.Logger, owner client.Object,
+	hash string, images, digests kube.ContainerImages) (bool, error) {
+	credentials, err := r.CredentialsByWorkload(ctx, owner)
+	if err != nil {
+		return false, err
+	}
+
+	log.V(1).Info("Scanning with scanner pool")
+	results, err := r.ScannerPool.Scan(ctx, images, credentials)
+	if err != nil {
+		if errors.Is(err, ErrNoScannerWorker) {
+			log.V(1).Info("Scanning with scan job instead of scanner pool", "reason", err)
+			return false, nil
+		}
+		return false, err
+	}
+	return true, r.createReportsFromResults(ctx, owner, hash, images, digests, results, "scanner pool")
+}
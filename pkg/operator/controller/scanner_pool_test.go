@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/nodeagent"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestScannerPool_Scan(t *testing.T) {
+	var scanned []string
+	host, port, caFile, tokenFile := startNodeAgent(t, imageScannerFunc(func(_ context.Context, image string, credentials *docker.Auth) ([]byte, error) {
+		scanned = append(scanned, image)
+		return []byte(credentials.Username), nil
+	}))
+
+	newWorker := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "starboard-system",
+				Name:      name,
+				Labels:    map[string]string{"app.kubernetes.io/name": "starboard-scanner-pool"},
+			},
+			Status: corev1.PodStatus{
+				PodIP:      host,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+	config := etc.Config{
+		Namespace:             "starboard-system",
+		ScannerPoolSelector:   "app.kubernetes.io/name=starboard-scanner-pool",
+		ScannerPoolPort:       port,
+		ScannerPoolTimeout:    time.Second,
+		ScannerPoolCAFile:     caFile,
+		ScannerPoolServerName: "example.com",
+		ScannerPoolTokenFile:  tokenFile,
+	}
+
+	t.Run("Should scan images with a ready worker", func(t *testing.T) {
+		scanned = nil
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newWorker("starboard-scanner-pool-7d9f8-zx9vq", corev1.ConditionTrue),
+			newWorker("starboard-scanner-pool-7d9f8-7bd2k", corev1.ConditionFalse),
+		).Build()
+		pool, err := NewScannerPool(config, c)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			results, err := pool.Scan(context.TODO(), kube.ContainerImages{"app": "acme/app:1.0"},
+				map[string]docker.Auth{"app": {Username: "acme"}})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]nodeagent.Result{"app": {Output: "acme"}}, results)
+		}
+		assert.Equal(t, []string{"acme/app:1.0", "acme/app:1.0"}, scanned)
+	})
+
+	t.Run("Should return error when no worker is ready", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newWorker("starboard-scanner-pool-7d9f8-7bd2k", corev1.ConditionFalse),
+		).Build()
+		pool, err := NewScannerPool(config, c)
+		require.NoError(t, err)
+
+		_, err = pool.Scan(context.TODO(), kube.ContainerImages{"app": "acme/app:1.0"}, nil)
+		assert.ErrorIs(t, err, ErrNoScannerWorker)
+	})
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/secrethygiene"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// auditSecretHygiene returns checks of credential-looking values in literal
+// environment variables and referenced ConfigMaps of the specified workload,
+// or in the specified ConfigMap itself. It returns no checks for other kinds
+// of objects, such as Roles. ConfigMaps that do not exist are skipped.
+func auditSecretHygiene(ctx context.Context, c client.Client, obj client.Object) ([]v1alpha1.Check, error) {
+	if configMap, ok := obj.(*corev1.ConfigMap); ok {
+		return secrethygiene.AuditConfigMap(*configMap), nil
+	}
+	spec, err := kube.GetPodSpec(obj)
+	if err != nil {
+		return nil, nil
+	}
+	configMaps := make(map[string]corev1.ConfigMap)
+	for _, name := range secrethygiene.GetConfigMapNames(spec) {
+		var configMap corev1.ConfigMap
+		err := c.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}, &configMap)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("getting configmap: %w", err)
+		}
+		configMaps[name] = configMap
+	}
+	return secrethygiene.AuditPodSpec(spec, configMaps), nil
+}
@@ -0,0 +1,492 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	predicatex "sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// shardLeasePrefix is the prefix of names of Leases that replicas of the
+	// operator renew to announce their membership in the sharding mode.
+	shardLeasePrefix = "starboard-shard-"
+	// labelShardMember labels Leases of replicas with their shard names.
+	labelShardMember = "starboard.aquasecurity.github.io/shard-member"
+	// shardNamespacesResyncPeriod is how often namespaces are listed to pick
+	// up new namespaces and changes of sharding labels if membership does not
+	// change in the meantime.
+	shardNamespacesResyncPeriod = time.Minute
+)
+
+// Sharder determines which namespaces are reconciled by this replica of the
+// operator when multiple replicas share the load.
+//
+// Subscribe returns a channel of events with namespaces that this replica has
+// started to own after a membership change, so that controllers can enqueue
+// objects in these namespaces, which were previously filtered out. The
+// object of each event is a corev1.Namespace with the name of the namespace,
+// or an empty name for cluster-scoped objects. Subscribe must be called
+// before the Sharder is started.
+type Sharder interface {
+	predicate.Shard
+	Subscribe() <-chan event.GenericEvent
+}
+
+// NamespaceSharder is the Sharder that assigns namespaces to replicas with
+// rendezvous hashing, so that a membership change moves only namespaces of
+// replicas that joined or left.
+//
+// Each replica announces its membership by renewing a Lease in the operator
+// namespace. Replicas whose Leases have not been renewed for the lease
+// duration are no longer members, and their namespaces are taken over by the
+// remaining replicas. A replica deletes its Lease when it's stopped, so that
+// its namespaces are taken over right away.
+type NamespaceSharder struct {
+	logger    logr.Logger
+	config    etc.Config
+	mode      etc.ShardingMode
+	clock     ext.Clock
+	clientset kubernetes.Interface
+
+	mu          sync.RWMutex
+	members     []string
+	keys        map[string]string
+	owned       map[string]bool
+	resyncedAt  time.Time
+	subscribers []chan event.GenericEvent
+}
+
+func NewNamespaceSharder(logger logr.Logger, config etc.Config, clock ext.Clock, clientset kubernetes.Interface) (*NamespaceSharder, error) {
+	mode, err := config.GetShardingMode()
+	if err != nil {
+		return nil, err
+	}
+	if mode == etc.ShardingDisabled {
+		return nil, fmt.Errorf("sharding is disabled")
+	}
+	return &NamespaceSharder{
+		logger:    logger,
+		config:    config,
+		mode:      mode,
+		clock:     clock,
+		clientset: clientset,
+		keys:      make(map[string]string),
+		owned:     make(map[string]bool),
+	}, nil
+}
+
+// Join announces the membership of this replica and resolves the namespaces
+// that it owns. It must be called before the manager is started, so that
+// objects are filtered from the first reconciliation on.
+func (s *NamespaceSharder) Join(ctx context.Context) error {
+	_, err := s.refresh(ctx)
+	return err
+}
+
+func (s *NamespaceSharder) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(s)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so that each
+// replica renews its membership.
+func (s *NamespaceSharder) NeedLeaderElection() bool {
+	return false
+}
+
+// Start renews the membership of this replica and rebalances namespaces on
+// membership changes until the context is cancelled.
+func (s *NamespaceSharder) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.ShardingLeaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return s.leave()
+		case <-ticker.C:
+			gained, err := s.refresh(ctx)
+			if err != nil {
+				s.logger.Error(err, "Unable to refresh shard membership")
+				continue
+			}
+			s.notify(ctx, gained)
+		}
+	}
+}
+
+// Owns checks whether objects in the specified namespace are reconciled by
+// this replica.
+func (s *NamespaceSharder) Owns(namespace string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ownerOf(namespace) == s.config.ShardName
+}
+
+func (s *NamespaceSharder) Subscribe() <-chan event.GenericEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := make(chan event.GenericEvent)
+	s.subscribers = append(s.subscribers, ch)
+	return ch
+}
+
+// ownerOf returns the member which owns the specified namespace. The caller
+// must hold the lock.
+func (s *NamespaceSharder) ownerOf(namespace string) string {
+	key := namespace
+	if s.mode == etc.ShardingLabel {
+		if value, ok := s.keys[namespace]; ok {
+			key = value
+		}
+	}
+	return rendezvousOwner(s.members, key)
+}
+
+// refresh renews the Lease of this replica and recomputes the namespaces that
+// it owns. It returns namespaces that this replica has started to own.
+func (s *NamespaceSharder) refresh(ctx context.Context) ([]string, error) {
+	err := s.renewLease(ctx)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.listMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	membersChanged := !reflect.DeepEqual(members, s.members)
+	resync := membersChanged || s.clock.Now().Sub(s.resyncedAt) >= shardNamespacesResyncPeriod
+	s.mu.RUnlock()
+	if !resync {
+		return nil, nil
+	}
+
+	keys, err := s.listNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.members = members
+	s.keys = keys
+	s.resyncedAt = s.clock.Now()
+
+	owned := make(map[string]bool)
+	var gained []string
+	for namespace := range keys {
+		if s.ownerOf(namespace) != s.config.ShardName {
+			continue
+		}
+		owned[namespace] = true
+		if !s.owned[namespace] {
+			gained = append(gained, namespace)
+		}
+	}
+	sort.Strings(gained)
+	if membersChanged {
+		s.logger.Info("Shard membership changed", "members", members, "owned namespaces", len(owned),
+			"gained namespaces", len(gained), "lost namespaces", len(s.owned)+len(gained)-len(owned))
+	}
+	s.owned = owned
+	return gained, nil
+}
+
+// notify sends events with the gained namespaces to subscribers without
+// blocking the renewal of the membership.
+func (s *NamespaceSharder) notify(ctx context.Context, gained []string) {
+	if len(gained) == 0 {
+		return
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, ch := range s.subscribers {
+		go func(ch chan event.GenericEvent) {
+			for _, namespace := range gained {
+				select {
+				case ch <- event.GenericEvent{Object: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(ch)
+	}
+}
+
+func (s *NamespaceSharder) renewLease(ctx context.Context) error {
+	leases := s.clientset.CoordinationV1().Leases(s.config.Namespace)
+	now := metav1.NewMicroTime(s.clock.Now())
+	lease, err := leases.Get(ctx, shardLeasePrefix+s.config.ShardName, metav1.GetOptions{})
+	if err != nil {
+		if !k8sapierror.IsNotFound(err) {
+			return fmt.Errorf("getting shard lease: %w", err)
+		}
+		_, err = leases.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      shardLeasePrefix + s.config.ShardName,
+				Namespace: s.config.Namespace,
+				Labels: map[string]string{
+					starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+					labelShardMember:               s.config.ShardName,
+				},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       pointer.String(s.config.ShardName),
+				LeaseDurationSeconds: pointer.Int32(s.leaseDurationSeconds()),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("creating shard lease: %w", err)
+		}
+		return nil
+	}
+	lease.Spec.HolderIdentity = pointer.String(s.config.ShardName)
+	lease.Spec.LeaseDurationSeconds = pointer.Int32(s.leaseDurationSeconds())
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("renewing shard lease: %w", err)
+	}
+	return nil
+}
+
+// leaseDurationSeconds returns the lease duration rounded up to seconds.
+func (s *NamespaceSharder) leaseDurationSeconds() int32 {
+	return int32(math.Ceil(s.config.ShardingLeaseDuration.Seconds()))
+}
+
+// listMembers returns sorted names of replicas whose Leases have not expired.
+func (s *NamespaceSharder) listMembers(ctx context.Context) ([]string, error) {
+	leaseList, err := s.clientset.CoordinationV1().Leases(s.config.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelShardMember,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing shard leases: %w", err)
+	}
+	members := []string{s.config.ShardName}
+	for _, lease := range leaseList.Items {
+		name := lease.Labels[labelShardMember]
+		if name == s.config.ShardName || lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+			continue
+		}
+		expiresAt := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+		if s.clock.Now().Before(expiresAt) {
+			members = append(members, name)
+		}
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// listNamespaces returns the keys hashed to assign namespaces, including the
+// empty namespace of cluster-scoped objects, to members.
+func (s *NamespaceSharder) listNamespaces(ctx context.Context) (map[string]string, error) {
+	namespaceList, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing namespaces: %w", err)
+	}
+	keys := map[string]string{"": ""}
+	for _, namespace := range namespaceList.Items {
+		key := namespace.Name
+		if value := strings.TrimSpace(namespace.Labels[s.config.ShardingLabel]); s.mode == etc.ShardingLabel && value != "" {
+			key = value
+		}
+		keys[namespace.Name] = key
+	}
+	return keys, nil
+}
+
+// leave deletes the Lease of this replica, so that other replicas take over
+// its namespaces without waiting for the Lease to expire.
+func (s *NamespaceSharder) leave() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := s.clientset.CoordinationV1().Leases(s.config.Namespace).
+		Delete(ctx, shardLeasePrefix+s.config.ShardName, metav1.DeleteOptions{})
+	if err != nil && !k8sapierror.IsNotFound(err) {
+		return fmt.Errorf("deleting shard lease: %w", err)
+	}
+	return nil
+}
+
+// rendezvousOwner returns the member with the highest hash of the member name
+// and the key.
+func rendezvousOwner(members []string, key string) string {
+	var owner string
+	var highest uint64
+	for _, member := range members {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(member))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(key))
+		if sum := mix64(h.Sum64()); owner == "" || sum > highest {
+			owner, highest = member, sum
+		}
+	}
+	return owner
+}
+
+// mix64 is the finalizer of MurmurHash3, which spreads differences of FNV
+// hashes of similar member names, such as names of pods of a Deployment, to
+// all bits.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// watchGainedNamespaces makes the controller reconcile objects returned by
+// the given handler.MapFunc for each namespace that the Sharder has started
+// to own. It's a no-op if the Sharder is nil.
+func watchGainedNamespaces(b *builder.Builder, sharder Sharder, fn handler.MapFunc) *builder.Builder {
+	if sharder == nil {
+		return b
+	}
+	return b.Watches(&source.Channel{Source: sharder.Subscribe()}, handler.EnqueueRequestsFromMapFunc(fn))
+}
+
+// objectsInNamespace returns the handler.MapFunc that enqueues objects of the
+// same kind as the specified object in the namespace of the event, which pass
+// the given predicates. Events of the empty namespace enqueue cluster-scoped
+// objects only, if the kind is cluster-scoped, and vice versa.
+func objectsInNamespace(logger logr.Logger, c client.Client, obj client.Object, clusterScoped bool, predicates ...predicatex.Predicate) handler.MapFunc {
+	return func(ns client.Object) []reconcile.Request {
+		namespace := ns.GetName()
+		if clusterScoped != (namespace == "") {
+			return nil
+		}
+		gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+		if err != nil {
+			logger.Error(err, "Unable to get kind of objects in gained namespace")
+			return nil
+		}
+		list, err := newObjectList(c.Scheme(), obj, gvk)
+		if err != nil {
+			logger.Error(err, "Unable to construct list of objects in gained namespace", "kind", gvk.Kind)
+			return nil
+		}
+		err = c.List(context.Background(), list, client.InNamespace(namespace))
+		if err != nil {
+			logger.Error(err, "Unable to list objects in gained namespace", "kind", gvk.Kind, "namespace", namespace)
+			return nil
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil
+		}
+
+		var requests []reconcile.Request
+	items:
+		for _, item := range items {
+			o, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			for _, p := range predicates {
+				if !p.Generic(event.GenericEvent{Object: o}) {
+					continue items
+				}
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()},
+			})
+		}
+		return requests
+	}
+}
+
+// newObjectList returns the list of objects of the specified kind. The list of
+// metav1.PartialObjectMetadata is returned for metav1.PartialObjectMetadata,
+// so that objects watched with metadata-only informers are listed from the
+// cache.
+func newObjectList(scheme *runtime.Scheme, obj client.Object, gvk schema.GroupVersionKind) (client.ObjectList, error) {
+	if _, ok := obj.(*metav1.PartialObjectMetadata); ok {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		return list, nil
+	}
+	listObj, err := scheme.New(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err != nil {
+		return nil, err
+	}
+	list, ok := listObj.(client.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected list type: %T", listObj)
+	}
+	return list, nil
+}
+
+// scanJobsOfNamespace returns the handler.MapFunc that enqueues completed or
+// failed scan jobs, labeled with the specified scanner label, of objects in
+// the namespace of the event.
+func scanJobsOfNamespace(logger logr.Logger, c client.Client, scanJobsNamespace, scannerLabel string) handler.MapFunc {
+	return func(ns client.Object) []reconcile.Request {
+		var jobList batchv1.JobList
+		err := c.List(context.Background(), &jobList, client.InNamespace(scanJobsNamespace), client.MatchingLabels{
+			starboard.LabelK8SAppManagedBy:   starboard.AppStarboard,
+			starboard.LabelResourceNamespace: ns.GetName(),
+		}, client.HasLabels{scannerLabel})
+		if err != nil {
+			logger.Error(err, "Unable to list scan jobs of gained namespace", "namespace", ns.GetName())
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, job := range jobList.Items {
+			if len(job.Status.Conditions) == 0 {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: job.Namespace, Name: job.Name},
+			})
+		}
+		return requests
+	}
+}
+
+// objectOfNamespace returns the handler.MapFunc that enqueues the object with
+// the specified key when its namespace is gained.
+func objectOfNamespace(key types.NamespacedName) handler.MapFunc {
+	return func(ns client.Object) []reconcile.Request {
+		if ns.GetName() != key.Namespace {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: key}}
+	}
+}
@@ -0,0 +1,180 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("NamespaceSharder", func() {
+
+	var clock *ext.FakeClock
+
+	BeforeEach(func() {
+		clock = ext.NewFakeClock(time.Date(2022, 1, 10, 8, 0, 0, 0, time.UTC))
+	})
+
+	newNamespaces := func(prefix string, count int, labels map[string]string) []runtime.Object {
+		var objects []runtime.Object
+		for i := 0; i < count; i++ {
+			objects = append(objects, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   fmt.Sprintf("%s-%d", prefix, i),
+					Labels: labels,
+				},
+			})
+		}
+		return objects
+	}
+
+	newSharder := func(clientset *fake.Clientset, mode etc.ShardingMode, name string) *controller.NamespaceSharder {
+		sharder, err := controller.NewNamespaceSharder(log.Log, etc.Config{
+			Namespace:             "starboard-operator",
+			ShardingMode:          string(mode),
+			ShardingLabel:         "starboard.aquasecurity.github.io/shard",
+			ShardingLeaseDuration: 300 * time.Millisecond,
+			ShardName:             name,
+		}, clock, clientset)
+		Expect(err).ToNot(HaveOccurred())
+		return sharder
+	}
+
+	joinAll := func(sharders ...*controller.NamespaceSharder) {
+		// Join twice so that each replica sees the leases of all others.
+		for i := 0; i < 2; i++ {
+			for _, sharder := range sharders {
+				Expect(sharder.Join(context.TODO())).To(Succeed())
+			}
+		}
+	}
+
+	Context("When there are multiple replicas", func() {
+		It("Should assign each namespace to exactly one replica", func() {
+			clientset := fake.NewSimpleClientset(newNamespaces("ns", 20, nil)...)
+			sharders := []*controller.NamespaceSharder{
+				newSharder(clientset, etc.ShardingHash, "starboard-operator-a"),
+				newSharder(clientset, etc.ShardingHash, "starboard-operator-b"),
+				newSharder(clientset, etc.ShardingHash, "starboard-operator-c"),
+			}
+			joinAll(sharders...)
+
+			owned := make(map[string]int)
+			for _, namespace := range []string{"", "ns-0", "ns-1", "ns-2", "ns-3", "ns-4", "ns-5", "ns-6", "ns-7",
+				"ns-8", "ns-9", "ns-10", "ns-11", "ns-12", "ns-13", "ns-14", "ns-15", "ns-16", "ns-17", "ns-18", "ns-19"} {
+				owners := 0
+				for i, sharder := range sharders {
+					if sharder.Owns(namespace) {
+						owners++
+						owned[fmt.Sprintf("%d", i)]++
+					}
+				}
+				Expect(owners).To(Equal(1), "namespace %q", namespace)
+			}
+			Expect(owned).To(HaveLen(3))
+		})
+	})
+
+	Context("When sharding by label", func() {
+		It("Should assign namespaces with the same label to the same replica", func() {
+			objects := append(
+				newNamespaces("team-a", 10, map[string]string{"starboard.aquasecurity.github.io/shard": "team-a"}),
+				newNamespaces("team-b", 10, map[string]string{"starboard.aquasecurity.github.io/shard": "team-b"})...)
+			clientset := fake.NewSimpleClientset(objects...)
+			a := newSharder(clientset, etc.ShardingLabel, "starboard-operator-a")
+			b := newSharder(clientset, etc.ShardingLabel, "starboard-operator-b")
+			joinAll(a, b)
+
+			for _, team := range []string{"team-a", "team-b"} {
+				owner := a.Owns(team + "-0")
+				for i := 0; i < 10; i++ {
+					namespace := fmt.Sprintf("%s-%d", team, i)
+					Expect(a.Owns(namespace)).To(Equal(owner), "namespace %q", namespace)
+					Expect(b.Owns(namespace)).To(Equal(!owner), "namespace %q", namespace)
+				}
+			}
+		})
+	})
+
+	Context("When lease of replica has expired", func() {
+		It("Should take over its namespaces", func() {
+			renewTime := metav1.NewMicroTime(clock.Now().Add(-time.Minute))
+			clientset := fake.NewSimpleClientset(append(newNamespaces("ns", 10, nil), &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-shard-starboard-operator-b",
+					Namespace: "starboard-operator",
+					Labels: map[string]string{
+						"starboard.aquasecurity.github.io/shard-member": "starboard-operator-b",
+					},
+				},
+				Spec: coordinationv1.LeaseSpec{
+					HolderIdentity:       pointer.String("starboard-operator-b"),
+					LeaseDurationSeconds: pointer.Int32(15),
+					RenewTime:            &renewTime,
+				},
+			})...)
+			a := newSharder(clientset, etc.ShardingHash, "starboard-operator-a")
+			Expect(a.Join(context.TODO())).To(Succeed())
+
+			Expect(a.Owns("")).To(BeTrue())
+			for i := 0; i < 10; i++ {
+				Expect(a.Owns(fmt.Sprintf("ns-%d", i))).To(BeTrue())
+			}
+		})
+	})
+
+	Context("When replica leaves", func() {
+		It("Should notify subscribers of gained namespaces", func() {
+			clientset := fake.NewSimpleClientset(newNamespaces("ns", 20, nil)...)
+			a := newSharder(clientset, etc.ShardingHash, "starboard-operator-a")
+			b := newSharder(clientset, etc.ShardingHash, "starboard-operator-b")
+			joinAll(a, b)
+
+			var lost []string
+			for _, namespace := range []string{"", "ns-0", "ns-1", "ns-2", "ns-3", "ns-4", "ns-5", "ns-6", "ns-7",
+				"ns-8", "ns-9", "ns-10", "ns-11", "ns-12", "ns-13", "ns-14", "ns-15", "ns-16", "ns-17", "ns-18", "ns-19"} {
+				if b.Owns(namespace) {
+					lost = append(lost, namespace)
+				}
+			}
+			Expect(lost).ToNot(BeEmpty())
+
+			events := a.Subscribe()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(a.Start(ctx)).To(Succeed())
+			}()
+
+			err := clientset.CoordinationV1().Leases("starboard-operator").
+				Delete(context.TODO(), "starboard-shard-starboard-operator-b", metav1.DeleteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			var gained []string
+			for range lost {
+				var e event.GenericEvent
+				Eventually(events, 5*time.Second).Should(Receive(&e))
+				gained = append(gained, e.Object.GetName())
+			}
+			Expect(gained).To(ConsistOf(lost))
+			for _, namespace := range lost {
+				Expect(a.Owns(namespace)).To(BeTrue())
+			}
+		})
+	})
+})
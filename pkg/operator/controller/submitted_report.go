@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// submittedReportClockSkew is how far in the future the update timestamp of a
+// submitted report may be, to tolerate clocks of CI pipelines that are ahead
+// of the clock of the operator.
+const submittedReportClockSkew = 5 * time.Minute
+
+// getSubmittedReports returns, for each container name, the report data of
+// the most recently updated valid ClusterVulnerabilityReport submitted for the
+// image digest run by the container, e.g. by a CI pipeline that scanned the
+// image before it was deployed.
+//
+// Submitted reports opt in with the starboard.LabelSubmittedReport label and
+// are labeled with the starboard.LabelImageDigest label of the image digest.
+// Reports that don't pass validateSubmittedReport are logged and ignored, so
+// that a malformed or outdated submission falls back to a scan.
+func (r *VulnerabilityReportReconciler) getSubmittedReports(ctx context.Context, log logr.Logger,
+	images, digests kube.ContainerImages) (map[string]v1alpha1.VulnerabilityReportData, error) {
+	submittedReports := make(map[string]v1alpha1.VulnerabilityReportData)
+	for containerName := range images {
+		digest, ok := digests[containerName]
+		if !ok {
+			continue
+		}
+		var list v1alpha1.ClusterVulnerabilityReportList
+		err := r.Client.List(ctx, &list, client.MatchingLabels{
+			starboard.LabelSubmittedReport: "true",
+			starboard.LabelImageDigest:     vulnerabilityreport.GetImageDigestLabelValue(digest),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing submitted reports: %w", err)
+		}
+		var latest *v1alpha1.ClusterVulnerabilityReport
+		for i := range list.Items {
+			report := &list.Items[i]
+			if err := validateSubmittedReport(report, digest, r.Config.SubmittedReportsMaxAge); err != nil {
+				log.V(1).Info("Ignoring invalid submitted report", "report", report.Name, "reason", err.Error())
+				continue
+			}
+			if latest == nil || report.Report.UpdateTimestamp.After(latest.Report.UpdateTimestamp.Time) {
+				latest = report
+			}
+		}
+		if latest != nil {
+			submittedReports[containerName] = latest.Report
+		}
+	}
+	return submittedReports, nil
+}
+
+// validateSubmittedReport checks that the submitted report is a report of the
+// specified image digest, which satisfies the contract checked by
+// vulnerabilityreport.ValidateSubmittedReport, and that it was updated within
+// the max age, so that vulnerabilities disclosed since the CI scan are not
+// missed for longer than that. Reports updated in the future, beyond the clock
+// skew, are rejected, because they would never get older than the max age.
+func validateSubmittedReport(report *v1alpha1.ClusterVulnerabilityReport, digest string, maxAge time.Duration) error {
+	if report.Report.Artifact.Digest != digest {
+		return fmt.Errorf("artifact digest %q doesn't match image digest %q", report.Report.Artifact.Digest, digest)
+	}
+	if problems := vulnerabilityreport.ValidateSubmittedReport(*report); len(problems) > 0 {
+		messages := make([]string, len(problems))
+		for i, problem := range problems {
+			messages[i] = problem.Error()
+		}
+		return errors.New(strings.Join(messages, "; "))
+	}
+	age := time.Since(report.Report.UpdateTimestamp.Time)
+	if age < -submittedReportClockSkew {
+		return fmt.Errorf("report is updated in the future")
+	}
+	if age > maxAge {
+		return fmt.Errorf("report is older than %s", maxAge)
+	}
+	return nil
+}
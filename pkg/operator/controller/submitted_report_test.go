@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestVulnerabilityReportReconciler_GetSubmittedReports(t *testing.T) {
+	const digest = "sha256:4ed1d8ba4b1d1a2d4b1ad6e2b8a1b0fa5b2d6d5e5a1f8c0e3e3a1c1b0d4c2a9f"
+	updated := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	newReport := func(name, artifactDigest, scanner string, updateTimestamp time.Time) *v1alpha1.ClusterVulnerabilityReport {
+		return &v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					starboard.LabelSubmittedReport: "true",
+					starboard.LabelImageDigest:     vulnerabilityreport.GetImageDigestLabelValue(digest),
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(updateTimestamp),
+				Scanner:         v1alpha1.Scanner{Name: scanner},
+				Artifact:        v1alpha1.Artifact{Repository: "acme/app", Digest: artifactDigest},
+			},
+		}
+	}
+	unmarked := newReport("ci-app-5", digest, "Trivy", updated.Add(time.Hour))
+	delete(unmarked.Labels, starboard.LabelSubmittedReport)
+	miscounted := newReport("ci-app-6", digest, "Trivy", updated.Add(time.Hour))
+	miscounted.Report.Vulnerabilities = []v1alpha1.Vulnerability{{VulnerabilityID: "CVE-2022-0778", Severity: v1alpha1.SeverityHigh}}
+
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		newReport("ci-app-1", digest, "Trivy", updated.Add(-time.Hour)),
+		newReport("ci-app-2", digest, "Trivy", updated),
+		newReport("ci-app-3", "sha256:2b6a", "Trivy", updated.Add(time.Hour)),
+		newReport("ci-app-4", digest, "", updated.Add(time.Hour)),
+		unmarked,
+		miscounted,
+		newReport("ci-app-7", digest, "Trivy", time.Now().AddDate(1, 0, 0)),
+	).Build()
+	r := &VulnerabilityReportReconciler{Client: c, Config: etc.Config{SubmittedReportsMaxAge: 24 * time.Hour}}
+
+	reports, err := r.getSubmittedReports(context.TODO(), log.Log,
+		kube.ContainerImages{"app": "acme/app:1.0", "nginx": "nginx:1.16"},
+		kube.ContainerImages{"app": digest})
+	require.NoError(t, err)
+	require.Len(t, reports, 1, "Should not return reports of containers without resolved digests")
+	assert.True(t, updated.Equal(reports["app"].UpdateTimestamp.Time),
+		"Should return the most recently updated valid report")
+
+	err = validateSubmittedReport(newReport("ci-app-8", digest, "Trivy", time.Now().Add(time.Minute)), digest, time.Hour)
+	assert.NoError(t, err, "Should tolerate clock skew")
+	err = validateSubmittedReport(newReport("ci-app-9", digest, "Trivy", time.Now().Add(time.Hour)), digest, time.Hour)
+	assert.EqualError(t, err, "report is updated in the future")
+
+	r.Config.SubmittedReportsMaxAge = time.Hour
+	reports, err = r.getSubmittedReports(context.TODO(), log.Log,
+		kube.ContainerImages{"app": "acme/app:1.0"},
+		kube.ContainerImages{"app": digest})
+	require.NoError(t, err)
+	assert.Empty(t, reports, "Should ignore reports older than the max age")
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// TrivyDBCacheReconciler maintains the shared cache of the vulnerability
+// database used by scan jobs in the Standalone mode if the trivy.dbCache.type
+// key of the Trivy plugin ConfigMap is set. The CronJobs that refresh the
+// cache and the PersistentVolumeClaim that holds it are owned by the
+// ConfigMap and deleted when the cache is disabled.
+type TrivyDBCacheReconciler struct {
+	logr.Logger
+	etc.Config
+	starboard.ConfigData
+	client.Client
+	// Sharder is optional. If nil, the Trivy plugin ConfigMap is reconciled
+	// by each replica.
+	Sharder Sharder
+}
+
+func (r *TrivyDBCacheReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(
+			predicate.Not(predicate.IsBeingTerminated),
+			predicate.HasName(starboard.GetPluginConfigMapName(trivy.Plugin)),
+			predicate.InNamespace(r.Config.GetScanJobsNamespace()),
+			predicate.InShard(r.Sharder))).
+		Owns(&batchv1.CronJob{}, builder.WithPredicates(predicate.ManagedByStarboardOperator, predicate.InShard(r.Sharder))).
+		Owns(&corev1.PersistentVolumeClaim{}, builder.WithPredicates(predicate.ManagedByStarboardOperator, predicate.InShard(r.Sharder)))
+	return watchGainedNamespaces(b, r.Sharder, objectOfNamespace(types.NamespacedName{
+		Namespace: r.Config.GetScanJobsNamespace(),
+		Name:      starboard.GetPluginConfigMapName(trivy.Plugin),
+	})).Complete(r)
+}
+
+func (r *TrivyDBCacheReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Logger.WithValues("configMap", req.NamespacedName)
+
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, req.NamespacedName, cm)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.V(1).Info("Ignoring cached ConfigMap that must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("getting ConfigMap from cache: %w", err)
+	}
+
+	config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: cm.Data}}
+	mode, err := config.GetMode()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	cacheType, err := config.GetDBCacheType()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if mode != trivy.Standalone || cacheType == "" {
+		log.V(1).Info("Deleting Trivy DB cache if exists")
+		return ctrl.Result{}, r.deleteDBCache(ctx, cm, cm.Namespace)
+	}
+
+	cache, err := trivy.NewDBCache(config, cm.Namespace, r.Config.ServiceAccount)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("constructing Trivy DB cache: %w", err)
+	}
+
+	log.V(1).Info("Ensuring Trivy DB cache", "type", cacheType)
+
+	if cache.PersistentVolumeClaim != nil {
+		pvc := cache.PersistentVolumeClaim.DeepCopy()
+		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, pvc, func() error {
+			// The spec of a bound PersistentVolumeClaim is immutable except
+			// for the requested storage, which may only grow.
+			if pvc.CreationTimestamp.IsZero() {
+				pvc.Spec = cache.PersistentVolumeClaim.Spec
+			}
+			pvc.Labels = cache.PersistentVolumeClaim.Labels
+			return controllerutil.SetControllerReference(cm, pvc, r.Client.Scheme())
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("ensuring PersistentVolumeClaim: %w", err)
+		}
+	} else {
+		// Switched from PersistentVolumeClaim to HostPath or to an existing
+		// PersistentVolumeClaim.
+		err = r.deleteControlledObject(ctx, cm, &corev1.PersistentVolumeClaim{}, cm.Namespace, trivy.DBCacheName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	images, err := r.ConfigData.GetScanJobImages()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting scan job images: %w", err)
+	}
+
+	// The CronJob is nil if updates of the vulnerability database are skipped.
+	err = r.ensureCronJob(ctx, cm, trivy.DBCacheName, cache.CronJob, images)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// The JavaDBCronJob is nil unless the Java index database is cached.
+	err = r.ensureCronJob(ctx, cm, trivy.JavaDBCacheName, cache.JavaDBCronJob, images)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// ensureCronJob creates or updates the specified CronJob of the Trivy DB
+// cache, or deletes the CronJob with the given name if it's nil.
+func (r *TrivyDBCacheReconciler) ensureCronJob(ctx context.Context, cm *corev1.ConfigMap, name string,
+	desired *batchv1.CronJob, images starboard.ScanJobImages) error {
+	if desired == nil {
+		return r.deleteControlledObject(ctx, cm, &batchv1.CronJob{}, cm.Namespace, name)
+	}
+	images.ApplyTo(&desired.Spec.JobTemplate.Spec.Template.Spec)
+
+	cronJob := desired.DeepCopy()
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, cronJob, func() error {
+		cronJob.Labels = desired.Labels
+		cronJob.Spec = desired.Spec
+		return controllerutil.SetControllerReference(cm, cronJob, r.Client.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("ensuring CronJob %s: %w", name, err)
+	}
+	return nil
+}
+
+func (r *TrivyDBCacheReconciler) deleteDBCache(ctx context.Context, cm *corev1.ConfigMap, namespace string) error {
+	err := r.deleteControlledObject(ctx, cm, &batchv1.CronJob{}, namespace, trivy.DBCacheName)
+	if err != nil {
+		return err
+	}
+	err = r.deleteControlledObject(ctx, cm, &batchv1.CronJob{}, namespace, trivy.JavaDBCacheName)
+	if err != nil {
+		return err
+	}
+	return r.deleteControlledObject(ctx, cm, &corev1.PersistentVolumeClaim{}, namespace, trivy.DBCacheName)
+}
+
+// deleteControlledObject deletes the object of the Trivy DB cache with the
+// specified name. Objects that are not controlled by the ConfigMap are left
+// intact.
+func (r *TrivyDBCacheReconciler) deleteControlledObject(ctx context.Context, cm *corev1.ConfigMap, obj client.Object, namespace, name string) error {
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("getting %T: %w", obj, err)
+	}
+	if !metav1.IsControlledBy(obj, cm) {
+		return nil
+	}
+	err = r.Client.Delete(ctx, obj)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("deleting %T: %w", obj, err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// TrivyServerReconciler deploys the Trivy server used by scan jobs in the
+// ClientServer mode if the trivy.server.managed key of the Trivy plugin
+// ConfigMap is set to "true". The Deployment, Service, and
+// PersistentVolumeClaim of the server are owned by the ConfigMap and deleted
+// when the managed server is disabled.
+type TrivyServerReconciler struct {
+	logr.Logger
+	etc.Config
+	starboard.ConfigData
+	client.Client
+	// Sharder is optional. If nil, the Trivy plugin ConfigMap is reconciled
+	// by each replica.
+	Sharder Sharder
+}
+
+func (r *TrivyServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(
+			predicate.Not(predicate.IsBeingTerminated),
+			predicate.HasName(starboard.GetPluginConfigMapName(trivy.Plugin)),
+			predicate.InNamespace(r.Config.GetScanJobsNamespace()),
+			predicate.InShard(r.Sharder))).
+		Owns(&appsv1.Deployment{}, builder.WithPredicates(predicate.ManagedByStarboardOperator, predicate.InShard(r.Sharder))).
+		Owns(&corev1.Service{}, builder.WithPredicates(predicate.ManagedByStarboardOperator, predicate.InShard(r.Sharder))).
+		Owns(&corev1.PersistentVolumeClaim{}, builder.WithPredicates(predicate.ManagedByStarboardOperator, predicate.InShard(r.Sharder)))
+	return watchGainedNamespaces(b, r.Sharder, objectOfNamespace(types.NamespacedName{
+		Namespace: r.Config.GetScanJobsNamespace(),
+		Name:      starboard.GetPluginConfigMapName(trivy.Plugin),
+	})).Complete(r)
+}
+
+func (r *TrivyServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Logger.WithValues("configMap", req.NamespacedName)
+
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, req.NamespacedName, cm)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.V(1).Info("Ignoring cached ConfigMap that must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("getting ConfigMap from cache: %w", err)
+	}
+
+	config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: cm.Data}}
+	mode, err := config.GetMode()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	server, err := trivy.NewManagedServer(config, cm.Namespace)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("constructing managed Trivy server: %w", err)
+	}
+
+	if mode != trivy.ClientServer || !config.IsServerManaged() {
+		log.V(1).Info("Deleting managed Trivy server if exists")
+		return ctrl.Result{}, r.deleteManagedServer(ctx, cm, server)
+	}
+
+	images, err := r.ConfigData.GetScanJobImages()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting scan job images: %w", err)
+	}
+	images.ApplyTo(&server.Deployment.Spec.Template.Spec)
+
+	log.V(1).Info("Ensuring managed Trivy server", "url", trivy.GetManagedServerURL(cm.Namespace))
+
+	pvc := server.PersistentVolumeClaim.DeepCopy()
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, pvc, func() error {
+		// The spec of a bound PersistentVolumeClaim is immutable except for
+		// the requested storage, which may only grow.
+		if pvc.CreationTimestamp.IsZero() {
+			pvc.Spec = server.PersistentVolumeClaim.Spec
+		}
+		pvc.Labels = server.PersistentVolumeClaim.Labels
+		return controllerutil.SetControllerReference(cm, pvc, r.Client.Scheme())
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("ensuring PersistentVolumeClaim: %w", err)
+	}
+
+	service := server.Service.DeepCopy()
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		service.Labels = server.Service.Labels
+		service.Spec.Selector = server.Service.Spec.Selector
+		service.Spec.Ports = server.Service.Spec.Ports
+		return controllerutil.SetControllerReference(cm, service, r.Client.Scheme())
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("ensuring Service: %w", err)
+	}
+
+	deployment := server.Deployment.DeepCopy()
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		deployment.Labels = server.Deployment.Labels
+		deployment.Spec = server.Deployment.Spec
+		return controllerutil.SetControllerReference(cm, deployment, r.Client.Scheme())
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("ensuring Deployment: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// deleteManagedServer deletes objects of the managed Trivy server. Objects
+// that are not controlled by the ConfigMap, e.g. a Trivy server deployed by
+// the user under the same name, are left intact.
+func (r *TrivyServerReconciler) deleteManagedServer(ctx context.Context, cm *corev1.ConfigMap, server trivy.ManagedServer) error {
+	for _, obj := range []client.Object{server.Deployment, server.Service, server.PersistentVolumeClaim} {
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, obj)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("getting %T: %w", obj, err)
+		}
+		if !metav1.IsControlledBy(obj, cm) {
+			continue
+		}
+		err = r.Client.Delete(ctx, obj)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("deleting %T: %w", obj, err)
+		}
+	}
+	return nil
+}
@@ -3,23 +3,35 @@ package controller
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
 	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// TTLReportReconciler deletes VulnerabilityReports whose TTL has expired.
+//
+// Expired reports are labeled by the reconciler and deleted in bulk with one
+// DeleteCollection request per namespace every
+// OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL_SWEEP_INTERVAL, because deleting
+// thousands of reports that expire at once one by one overloads the API
+// server.
 type TTLReportReconciler struct {
 	logr.Logger
 	etc.Config
 	client.Client
+	// Sharder is optional. If nil, reports in all namespaces are reconciled.
+	Sharder Sharder
 }
 
 func (r *TTLReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -28,36 +40,52 @@ func (r *TTLReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	err = ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.VulnerabilityReport{}, builder.WithPredicates(
+	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controllerOptions(r.Config, r.Config.VulnerabilityScannerReportTTLMaxConcurrentReconciles)).
+		For(&v1alpha1.VulnerabilityReport{}, builder.OnlyMetadata, builder.WithPredicates(
 			predicate.Not(predicate.IsBeingTerminated),
-			installModePredicate)).
+			installModePredicate,
+			predicate.InShard(r.Sharder)))
+	err = watchGainedNamespaces(b, r.Sharder,
+		objectsInNamespace(r.Logger, mgr.GetClient(), newReportMetadata(v1alpha1.VulnerabilityReportKind), false,
+			predicate.Not(predicate.IsBeingTerminated), installModePredicate)).
 		Complete(r.reconcileReport())
 	if err != nil {
 		return err
 	}
-	return nil
+	return mgr.Add(r)
 }
 
 func (r *TTLReportReconciler) reconcileReport() reconcile.Func {
 	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 		log := r.Logger.WithValues("report", req.NamespacedName)
 
+		// Check the cached metadata first, so that full reports without TTL
+		// are not read from the API server.
+		metadata, err := getReportMetadata(ctx, r.Client, v1alpha1.VulnerabilityReportKind, req.NamespacedName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if metadata == nil {
+			log.V(1).Info("Ignoring cached report that must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		if _, ok := metadata.Annotations[v1alpha1.TTLReportAnnotation]; !ok {
+			log.V(1).Info("Ignoring report without TTL set")
+			return ctrl.Result{}, nil
+		}
+
 		report := &v1alpha1.VulnerabilityReport{}
-		err := r.Client.Get(ctx, req.NamespacedName, report)
+		err = r.Client.Get(ctx, req.NamespacedName, report)
 		if err != nil {
 			if errors.IsNotFound(err) {
 				log.V(1).Info("Ignoring cached report that must have been deleted")
 				return ctrl.Result{}, nil
 			}
-			return ctrl.Result{}, fmt.Errorf("getting report from cache: %w", err)
+			return ctrl.Result{}, fmt.Errorf("getting report: %w", err)
 		}
 
-		ttlReportAnnotationStr, ok := report.Annotations[v1alpha1.TTLReportAnnotation]
-		if !ok {
-			log.V(1).Info("Ignoring report without TTL set")
-			return ctrl.Result{}, nil
-		}
+		ttlReportAnnotationStr := report.Annotations[v1alpha1.TTLReportAnnotation]
 
 		reportTTLTime, err := time.ParseDuration(ttlReportAnnotationStr)
 		if err != nil {
@@ -69,12 +97,20 @@ func (r *TTLReportReconciler) reconcileReport() reconcile.Func {
 			return ctrl.Result{}, err
 		}
 		if ttlExpired {
-			log.V(1).Info("Removing vulnerabilityReport with expired TTL")
-			err := r.Client.Delete(ctx, report, &client.DeleteOptions{})
+			if report.Labels[starboard.LabelReportExpired] == "true" {
+				return ctrl.Result{}, nil
+			}
+			log.V(1).Info("Marking vulnerabilityReport with expired TTL for deletion")
+			patch := client.MergeFrom(report.DeepCopy())
+			if report.Labels == nil {
+				report.Labels = make(map[string]string)
+			}
+			report.Labels[starboard.LabelReportExpired] = "true"
+			err := r.Client.Patch(ctx, report, patch)
 			if err != nil && !errors.IsNotFound(err) {
 				return ctrl.Result{}, err
 			}
-			// Since the report is deleted there is no reason to requeue
+			// Since the report is deleted by the next sweep there is no reason to requeue
 			return ctrl.Result{}, nil
 		}
 		log.V(1).Info("RequeueAfter", "durationToTTLExpiration", durationToTTLExpiration)
@@ -82,6 +118,68 @@ func (r *TTLReportReconciler) reconcileReport() reconcile.Func {
 	}
 }
 
+// Start deletes expired reports every sweep interval until the context is
+// cancelled. It's called by the manager once caches are synced.
+func (r *TTLReportReconciler) Start(ctx context.Context) error {
+	if r.Config.VulnerabilityScannerReportTTLSweepInterval <= 0 {
+		return fmt.Errorf("OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL_SWEEP_INTERVAL must be positive")
+	}
+	ticker := time.NewTicker(r.Config.VulnerabilityScannerReportTTLSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.sweep(ctx); err != nil {
+				r.Logger.Error(err, "Unable to delete expired reports")
+			}
+		}
+	}
+}
+
+// sweep deletes reports labeled as expired with one DeleteCollection request
+// per namespace.
+func (r *TTLReportReconciler) sweep(ctx context.Context) error {
+	namespaces, err := r.namespacesOfExpiredReports(ctx)
+	if err != nil {
+		return err
+	}
+	for _, namespace := range namespaces {
+		r.Logger.V(1).Info("Deleting vulnerabilityReports with expired TTL", "namespace", namespace)
+		err := r.Client.DeleteAllOf(ctx, &v1alpha1.VulnerabilityReport{},
+			client.InNamespace(namespace),
+			client.MatchingLabels{starboard.LabelReportExpired: "true"})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("deleting expired reports in namespace %s: %w", namespace, err)
+		}
+	}
+	return nil
+}
+
+// namespacesOfExpiredReports returns sorted namespaces of reports labeled as
+// expired, which are owned by the Sharder if it's set. DeleteCollection of a
+// namespaced resource cannot span namespaces.
+func (r *TTLReportReconciler) namespacesOfExpiredReports(ctx context.Context) ([]string, error) {
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.VulnerabilityReportListKind))
+	err := r.Client.List(ctx, list, client.MatchingLabels{starboard.LabelReportExpired: "true"})
+	if err != nil {
+		return nil, fmt.Errorf("listing expired reports: %w", err)
+	}
+	seen := make(map[string]bool)
+	var namespaces []string
+	for _, item := range list.Items {
+		if seen[item.Namespace] || (r.Sharder != nil && !r.Sharder.Owns(item.Namespace)) {
+			continue
+		}
+		seen[item.Namespace] = true
+		namespaces = append(namespaces, item.Namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
 func ttlIsExpired(reportTTL time.Duration, creationTime time.Time) (bool, time.Duration, error) {
 	expiresAt := creationTime.Add(reportTTL)
 	currentTime := time.Now()
@@ -1,10 +1,19 @@
 package controller
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 func TestTTLIsExpired(t *testing.T) {
@@ -26,3 +35,52 @@ func TestTTLIsNotExpired(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, ttlExpired)
 }
+
+func TestTTLReportReconciler_Expired(t *testing.T) {
+	newReport := func(namespace, name string, updated time.Time, labels map[string]string) *v1alpha1.VulnerabilityReport {
+		return &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels:    labels,
+				Annotations: map[string]string{
+					v1alpha1.TTLReportAnnotation: "24h",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(updated),
+			},
+		}
+	}
+	expiredLabels := map[string]string{starboard.LabelReportExpired: "true"}
+
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		newReport("default", "replicaset-nginx-nginx", time.Now().Add(-48*time.Hour), nil),
+		newReport("default", "replicaset-redis-redis", time.Now(), nil),
+		newReport("prod", "replicaset-app-app", time.Now().Add(-48*time.Hour), expiredLabels),
+	).Build()
+	r := &TTLReportReconciler{Logger: log.Log, Client: c}
+
+	result, err := r.reconcileReport()(context.TODO(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "replicaset-nginx-nginx"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+
+	var report v1alpha1.VulnerabilityReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "replicaset-nginx-nginx"}, &report))
+	assert.Equal(t, "true", report.Labels[starboard.LabelReportExpired])
+
+	result, err = r.reconcileReport()(context.TODO(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "replicaset-redis-redis"},
+	})
+	require.NoError(t, err)
+	assert.Greater(t, result.RequeueAfter, time.Duration(0))
+
+	require.NoError(t, r.sweep(context.TODO()))
+
+	var list v1alpha1.VulnerabilityReportList
+	require.NoError(t, c.List(context.TODO(), &list))
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "replicaset-redis-redis", list.Items[0].Name)
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VanishedImageReportReconciler deletes VulnerabilityReports of image digests
+// which are not used by any pod in their namespaces for
+// OPERATOR_VANISHED_IMAGE_REPORTS_GRACE_PERIOD.
+//
+// Unlike the TTL of reports, which is based on the age of reports, it keeps
+// the set of reports aligned with images that actually run in the cluster,
+// e.g. after a workload has been rolled out with a new image while its old
+// ReplicaSet is retained. Every OPERATOR_VANISHED_IMAGE_REPORTS_SWEEP_INTERVAL
+// reports of digests that are not used by any pod are annotated with the time
+// when they were found, and deleted once the grace period has passed since
+// then. The annotation is removed if a pod of the digest is started again.
+type VanishedImageReportReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	Clock ext.Clock
+	// Sharder is optional. If nil, reports in all namespaces are reconciled.
+	Sharder Sharder
+}
+
+func (r *VanishedImageReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(r)
+}
+
+// Start sweeps reports of vanished images every sweep interval until the
+// context is cancelled. It's called by the manager once caches are synced.
+func (r *VanishedImageReportReconciler) Start(ctx context.Context) error {
+	if r.Config.VanishedImageReportsSweepInterval <= 0 {
+		return fmt.Errorf("OPERATOR_VANISHED_IMAGE_REPORTS_SWEEP_INTERVAL must be positive")
+	}
+	ticker := time.NewTicker(r.Config.VanishedImageReportsSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.sweep(ctx); err != nil {
+				r.Logger.Error(err, "Unable to delete reports of vanished images")
+			}
+		}
+	}
+}
+
+// sweep annotates or deletes VulnerabilityReports labeled with image digests
+// depending on whether the digests are used by pods in their namespaces.
+// Errors of individual reports are logged so that they don't prevent sweeping
+// other reports.
+func (r *VanishedImageReportReconciler) sweep(ctx context.Context) error {
+	digests, err := r.getPodImageDigests(ctx)
+	if err != nil {
+		return err
+	}
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.VulnerabilityReportListKind))
+	err = r.Client.List(ctx, list, client.HasLabels{starboard.LabelImageDigest})
+	if err != nil {
+		return fmt.Errorf("listing reports: %w", err)
+	}
+	now := r.Clock.Now()
+	for i := range list.Items {
+		report := &list.Items[i]
+		if r.Sharder != nil && !r.Sharder.Owns(report.Namespace) {
+			continue
+		}
+		report.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.VulnerabilityReportKind))
+		log := r.Logger.WithValues("report", report.Namespace+"/"+report.Name)
+		vanished := !digests[report.Namespace][report.Labels[starboard.LabelImageDigest]]
+		err := sweepStaleReport(ctx, r.Client, log, report, starboard.AnnotationImageVanishedAt, vanished,
+			now, r.Config.VanishedImageReportsGracePeriod)
+		if err != nil {
+			log.Error(err, "Unable to sweep report of vanished image")
+		}
+	}
+	return nil
+}
+
+// getPodImageDigests returns values of the starboard.LabelImageDigest label
+// of digests of images used by containers of pods, grouped by namespaces of
+// the pods.
+func (r *VanishedImageReportReconciler) getPodImageDigests(ctx context.Context) (map[string]map[string]bool, error) {
+	var pods corev1.PodList
+	err := r.Client.List(ctx, &pods)
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+	digests := make(map[string]map[string]bool)
+	for _, pod := range pods.Items {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...),
+			pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			digest := kube.GetImageDigestFromImageID(status.ImageID)
+			if digest == "" {
+				continue
+			}
+			if digests[pod.Namespace] == nil {
+				digests[pod.Namespace] = make(map[string]bool)
+			}
+			digests[pod.Namespace][vulnerabilityreport.GetImageDigestLabelValue(digest)] = true
+		}
+	}
+	return digests, nil
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestVanishedImageReportReconciler_Sweep(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	newReport := func(namespace, name, digest string, vanishedAt *time.Time) *v1alpha1.VulnerabilityReport {
+		report := &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels: map[string]string{
+					starboard.LabelImageDigest: digest,
+				},
+			},
+		}
+		if vanishedAt != nil {
+			report.Annotations = map[string]string{
+				starboard.AnnotationImageVanishedAt: vanishedAt.Format(time.RFC3339),
+			}
+		}
+		return report
+	}
+	expired := now.Add(-96 * time.Hour)
+	recent := now.Add(-time.Hour)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-6d4cf56db6-7cvjx"},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "nginx", ImageID: "docker-pullable://nginx@sha256:4ed1"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		pod,
+		newReport("default", "replicaset-nginx-6d4cf56db6-nginx", "4ed1", &recent),
+		newReport("default", "replicaset-nginx-5fbc9cc6b9-nginx", "2b6a", nil),
+		newReport("default", "replicaset-nginx-7848d4b86f-nginx", "9c8e", &expired),
+		newReport("prod", "replicaset-nginx-6d4cf56db6-nginx", "4ed1", &recent),
+	).Build()
+	r := &VanishedImageReportReconciler{
+		Logger: log.Log,
+		Config: etc.Config{VanishedImageReportsGracePeriod: 72 * time.Hour},
+		Client: c,
+		Clock:  ext.NewFixedClock(now),
+	}
+	require.NoError(t, r.sweep(context.TODO()))
+
+	get := func(namespace, name string) (*v1alpha1.VulnerabilityReport, error) {
+		var report v1alpha1.VulnerabilityReport
+		err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, &report)
+		return &report, err
+	}
+
+	report, err := get("default", "replicaset-nginx-6d4cf56db6-nginx")
+	require.NoError(t, err)
+	assert.NotContains(t, report.Annotations, starboard.AnnotationImageVanishedAt,
+		"Should remove annotation of report whose digest is used by a pod")
+
+	report, err = get("default", "replicaset-nginx-5fbc9cc6b9-nginx")
+	require.NoError(t, err)
+	assert.Equal(t, now.Format(time.RFC3339), report.Annotations[starboard.AnnotationImageVanishedAt],
+		"Should annotate report whose digest is not used by any pod")
+
+	_, err = get("default", "replicaset-nginx-7848d4b86f-nginx")
+	assert.True(t, errors.IsNotFound(err), "Should delete report whose digest vanished longer than grace period")
+
+	report, err = get("prod", "replicaset-nginx-6d4cf56db6-nginx")
+	require.NoError(t, err)
+	assert.Equal(t, recent.Format(time.RFC3339), report.Annotations[starboard.AnnotationImageVanishedAt],
+		"Should not match digests used by pods in other namespaces")
+}
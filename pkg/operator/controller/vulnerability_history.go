@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityhistory"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// writeHistory records the specified reports in the VulnerabilityHistory of
+// the workload. Failing to write the history, e.g. because the
+// VulnerabilityHistory CRD is not installed, must not prevent processing
+// reports, therefore errors are only logged.
+func (r *VulnerabilityReportReconciler) writeHistory(ctx context.Context, owner client.Object, reports []v1alpha1.VulnerabilityReport) {
+	err := r.recordHistory(ctx, owner, reports)
+	if err != nil {
+		r.Logger.Error(err, "Writing vulnerability history", "owner", client.ObjectKeyFromObject(owner))
+	}
+}
+
+// recordHistory updates the VulnerabilityHistory of the workload of the
+// specified report owner with the specified reports, which have just been
+// written, and observes remediation durations of resolved vulnerabilities.
+func (r *VulnerabilityReportReconciler) recordHistory(ctx context.Context, owner client.Object, reports []v1alpha1.VulnerabilityReport) error {
+	if r.History == nil {
+		return nil
+	}
+	spec, err := kube.GetPodSpec(owner)
+	if err != nil {
+		return err
+	}
+	var containers []string
+	for _, container := range spec.Containers {
+		containers = append(containers, container.Name)
+	}
+	reported := make(map[string][]v1alpha1.Vulnerability)
+	for _, report := range reports {
+		reported[report.Labels[starboard.LabelContainerName]] = report.Report.Vulnerabilities
+	}
+
+	historyOwner := vulnerabilityhistory.GetOwner(owner)
+	history := vulnerabilityhistory.NewHistory(historyOwner, owner.GetNamespace())
+	commonMetadata, err := r.GetCommonMetadata()
+	if err != nil {
+		return err
+	}
+	commonMetadata.ApplyTo(&history.ObjectMeta)
+	existing, err := r.History.Get(ctx, types.NamespacedName{Name: history.Name, Namespace: history.Namespace})
+	if err != nil {
+		return fmt.Errorf("getting vulnerability history: %w", err)
+	}
+	if existing != nil {
+		history.Report = existing.Report
+	}
+
+	resolved := vulnerabilityhistory.Update(&history.Report, reported, containers, metav1.Now(),
+		r.Config.VulnerabilityHistoryMaxResolved)
+	for _, record := range resolved {
+		vulnerabilityRemediationDuration.WithLabelValues(owner.GetNamespace(), string(record.Severity)).
+			Observe(vulnerabilityhistory.RemediationDuration(record).Seconds())
+	}
+	return r.History.Write(ctx, history)
+}
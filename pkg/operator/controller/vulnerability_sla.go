@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityhistory"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// EventReasonVulnerabilitySLABreached is the reason of the warning event
+	// of a workload whose open vulnerabilities breached the SLA.
+	EventReasonVulnerabilitySLABreached = "VulnerabilitySLABreached"
+	// EventReasonVulnerabilitySLAMet is the reason of the event of a workload
+	// whose vulnerabilities that breached the SLA were resolved.
+	EventReasonVulnerabilitySLAMet = "VulnerabilitySLAMet"
+
+	// maxSLABreachesInEvent is the maximum number of vulnerability IDs listed
+	// in the message of an event.
+	maxSLABreachesInEvent = 5
+)
+
+// VulnerabilitySLAReconciler checks open vulnerabilities recorded in
+// VulnerabilityHistories against the SLA, i.e. the maximum time that open
+// vulnerabilities of each severity may remain in workloads.
+//
+// Workloads with vulnerabilities that breached the SLA are labeled with
+// starboard.sla-breach=true and a warning event is recorded when the label is
+// set, so that alerting tools watching labels or events are notified. The
+// label is removed when such vulnerabilities are resolved.
+type VulnerabilitySLAReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	kube.ObjectResolver
+	record.EventRecorder
+	// SLA is the cluster-wide SLA, which is overridden by the VulnerabilitySLA
+	// of ScanPolicies.
+	SLA vulnerabilityhistory.SLA
+	// Sharder is optional. If nil, histories in all namespaces are reconciled.
+	Sharder Sharder
+}
+
+func (r *VulnerabilitySLAReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := predicate.InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("vulnerabilitysla").
+		WithOptions(controllerOptions(r.Config, 1)).
+		For(&v1alpha1.VulnerabilityHistory{}, builder.OnlyMetadata, builder.WithPredicates(
+			predicate.Not(predicate.IsBeingTerminated),
+			installModePredicate,
+			predicate.InShard(r.Sharder)))
+	return watchGainedNamespaces(b, r.Sharder,
+		objectsInNamespace(r.Logger, mgr.GetClient(), newReportMetadata(v1alpha1.VulnerabilityHistoryKind), false,
+			predicate.Not(predicate.IsBeingTerminated), installModePredicate)).
+		Complete(r.reconcileHistory())
+}
+
+func (r *VulnerabilitySLAReconciler) reconcileHistory() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("history", req.NamespacedName)
+
+		history := &v1alpha1.VulnerabilityHistory{}
+		err := r.Client.Get(ctx, req.NamespacedName, history)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Ignoring cached history that must have been deleted")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting history: %w", err)
+		}
+		controller := metav1.GetControllerOf(history)
+		if controller == nil {
+			log.V(1).Info("Ignoring history without controller")
+			return ctrl.Result{}, nil
+		}
+		workload, err := r.ObjectFromObjectRef(ctx, kube.ObjectRef{
+			Kind:      kube.Kind(controller.Kind),
+			Name:      controller.Name,
+			Namespace: history.Namespace,
+		})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Ignoring history of workload that must have been deleted")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting workload: %w", err)
+		}
+
+		sla := r.SLA
+		policy, err := getScanPolicy(ctx, r.Client, history.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if policy != nil {
+			sla = sla.WithDays(policy.VulnerabilitySLA)
+		}
+		breaches, next := sla.Breaches(history.Report, time.Now())
+
+		breached := len(breaches) > 0
+		if breached != (workload.GetLabels()[starboard.LabelSLABreach] == "true") {
+			err = r.setBreachLabel(ctx, workload, breached)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if breached {
+				log.V(1).Info("Vulnerability SLA breached", "count", len(breaches))
+				vulnerabilitySLABreaches.WithLabelValues(history.Namespace).Inc()
+				r.EventRecorder.Event(workload, corev1.EventTypeWarning, EventReasonVulnerabilitySLABreached,
+					slaBreachMessage(breaches))
+			} else {
+				r.EventRecorder.Event(workload, corev1.EventTypeNormal, EventReasonVulnerabilitySLAMet,
+					"All open vulnerabilities are within the SLA")
+			}
+		}
+		if next > 0 {
+			return ctrl.Result{RequeueAfter: next}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+}
+
+// setBreachLabel sets or removes the starboard.sla-breach label of the
+// specified workload.
+func (r *VulnerabilitySLAReconciler) setBreachLabel(ctx context.Context, workload client.Object, breached bool) error {
+	patch := client.MergeFrom(workload.DeepCopyObject().(client.Object))
+	labels := workload.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	if breached {
+		labels[starboard.LabelSLABreach] = "true"
+	} else {
+		delete(labels, starboard.LabelSLABreach)
+	}
+	workload.SetLabels(labels)
+	err := r.Client.Patch(ctx, workload, patch)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("patching workload labels: %w", err)
+	}
+	return nil
+}
+
+func slaBreachMessage(breaches []v1alpha1.VulnerabilityRecord) string {
+	var ids []string
+	for i, record := range breaches {
+		if i == maxSLABreachesInEvent {
+			ids = append(ids, fmt.Sprintf("and %d more", len(breaches)-i))
+			break
+		}
+		ids = append(ids, fmt.Sprintf("%s (%s)", record.VulnerabilityID, record.Severity))
+	}
+	return fmt.Sprintf("%d open vulnerabilities breached the SLA: %s", len(breaches), strings.Join(ids, ", "))
+}
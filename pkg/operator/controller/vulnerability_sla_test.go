@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityhistory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestVulnerabilitySLAReconciler(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default", UID: "deploy-uid"},
+	}
+	history := vulnerabilityhistory.NewHistory(vulnerabilityhistory.GetOwner(deploy), "default")
+	history.Report.Open = []v1alpha1.VulnerabilityRecord{
+		{
+			Container:       "nginx",
+			VulnerabilityID: "CVE-2022-0001",
+			Resource:        "openssl",
+			Severity:        v1alpha1.SeverityCritical,
+			FirstSeen:       metav1.NewTime(time.Now().Add(-10 * 24 * time.Hour)),
+		},
+		{
+			Container:       "nginx",
+			VulnerabilityID: "CVE-2022-0002",
+			Resource:        "curl",
+			Severity:        v1alpha1.SeverityHigh,
+			FirstSeen:       metav1.NewTime(time.Now().Add(-10 * 24 * time.Hour)),
+		},
+	}
+	policy := &v1alpha1.ScanPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.ScanPolicyName, Namespace: "default"},
+		Spec: v1alpha1.ScanPolicySpec{
+			VulnerabilitySLA: map[v1alpha1.Severity]int{v1alpha1.SeverityHigh: 30},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(deploy, &history, policy).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &VulnerabilitySLAReconciler{
+		Logger:         log.Log,
+		Client:         c,
+		ObjectResolver: kube.ObjectResolver{Client: c},
+		EventRecorder:  recorder,
+		SLA: vulnerabilityhistory.SLA{
+			v1alpha1.SeverityCritical: 7 * 24 * time.Hour,
+			v1alpha1.SeverityHigh:     7 * 24 * time.Hour,
+		},
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: history.Name}}
+
+	result, err := r.reconcileHistory()(context.TODO(), req)
+	require.NoError(t, err)
+	assert.Greater(t, result.RequeueAfter, 19*24*time.Hour, "Expected requeue when the HIGH vulnerability breaches the SLA of the policy")
+
+	var found appsv1.Deployment
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "nginx"}, &found))
+	assert.Equal(t, "true", found.Labels[starboard.LabelSLABreach])
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning VulnerabilitySLABreached 1 open vulnerabilities breached the SLA: CVE-2022-0001 (CRITICAL)", <-recorder.Events)
+
+	// Resolving the vulnerability removes the label.
+	var updated v1alpha1.VulnerabilityHistory
+	require.NoError(t, c.Get(context.TODO(), req.NamespacedName, &updated))
+	updated.Report.Open = updated.Report.Open[1:]
+	require.NoError(t, c.Update(context.TODO(), &updated))
+
+	_, err = r.reconcileHistory()(context.TODO(), req)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "nginx"}, &found))
+	assert.NotContains(t, found.Labels, starboard.LabelSLABreach)
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Normal VulnerabilitySLAMet All open vulnerabilities are within the SLA", <-recorder.Events)
+}
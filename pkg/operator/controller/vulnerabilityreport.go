@@ -6,25 +6,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/scanfailurereport"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityhistory"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 type VulnerabilityReportReconciler struct {
@@ -33,12 +40,45 @@ type VulnerabilityReportReconciler struct {
 	client.Client
 	kube.ObjectResolver
 	LimitChecker
+	RateLimiter
+	RetryPolicy
 	kube.LogsReader
 	kube.SecretsReader
 	vulnerabilityreport.Plugin
 	starboard.PluginContext
 	vulnerabilityreport.ReadWriter
 	starboard.ConfigData
+	ScanFailureReports scanfailurereport.ReadWriter
+	// History is nil unless the vulnerability history is enabled.
+	History vulnerabilityhistory.ReadWriter
+	// ScanResultCache is nil unless the scan result cache is enabled.
+	ScanResultCache vulnerabilityreport.ScanResultCache
+	// ImageFilter determines which images are scanned. The zero value scans
+	// all images.
+	ImageFilter vulnerabilityreport.ImageFilter
+	// ScanQueue is optional. If nil, scan jobs are submitted in the order
+	// workloads are reconciled.
+	ScanQueue ScanQueue
+	// NodeLimiter is optional. If nil, the number of scan jobs per node is not
+	// limited.
+	NodeLimiter NodeLimiter
+	// QuotaChecker is optional. If nil, scan jobs are created regardless of
+	// ResourceQuotas of the scan jobs namespace.
+	QuotaChecker QuotaChecker
+	// Sharder is optional. If nil, workloads in all namespaces are reconciled.
+	Sharder Sharder
+	// NamespaceSelector is optional. If nil, workloads are reconciled regardless
+	// of labels of their namespaces.
+	NamespaceSelector NamespaceSelector
+	// Hooks are called before scan jobs are created and after reports are
+	// persisted. The zero value doesn't call any hooks.
+	Hooks ScanHooks
+	// NodeAgents is optional. If nil, workloads are always scanned with scan
+	// jobs.
+	NodeAgents *NodeAgents
+	// ScannerPool is optional. If nil, workloads that aren't scanned with
+	// node agents are scanned with scan jobs.
+	ScannerPool *ScannerPool
 }
 
 func (r *VulnerabilityReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -62,25 +102,46 @@ func (r *VulnerabilityReportReconciler) SetupWithManager(mgr ctrl.Manager) error
 	}
 
 	for _, workload := range workloads {
-		err = ctrl.NewControllerManagedBy(mgr).
-			For(workload.forObject, builder.WithPredicates(
-				Not(ManagedByStarboardOperator),
-				Not(IsBeingTerminated),
-				installModePredicate,
-			)).
-			Owns(workload.ownsObject).
+		workloadPredicates := []predicate.Predicate{
+			Not(ManagedByStarboardOperator),
+			Not(IsBeingTerminated),
+			installModePredicate,
+			InSelectedNamespace(r.NamespaceSelector),
+			ScanEnabled(r.Config.ScanOptInEnabled),
+		}
+		b := ctrl.NewControllerManagedBy(mgr).
+			WithOptions(controllerOptions(r.Config, r.Config.VulnerabilityScannerMaxConcurrentReconciles)).
+			For(workload.forObject, builder.WithPredicates(append(workloadPredicates, InShard(r.Sharder))...)).
+			Owns(workload.ownsObject, builder.OnlyMetadata, builder.WithPredicates(InShard(r.Sharder))).
+			Watches(&source.Kind{Type: &batchv1.Job{}},
+				handler.EnqueueRequestsFromMapFunc(scanJobOwner(workload.kind)),
+				builder.WithPredicates(
+					InNamespace(r.Config.GetScanJobsNamespace()),
+					ManagedByStarboardOperator,
+					IsVulnerabilityReportScan,
+					JobHasFailedCondition,
+					ScanJobInShard(r.Sharder),
+				))
+		b = watchSelectedNamespaces(b, r.NamespaceSelector,
+			objectsInNamespace(r.Logger, mgr.GetClient(), workload.forObject, false, workloadPredicates...))
+		err = watchGainedNamespaces(b, r.Sharder,
+			objectsInNamespace(r.Logger, mgr.GetClient(), workload.forObject, false, workloadPredicates...)).
 			Complete(r.reconcileWorkload(workload.kind))
 		if err != nil {
 			return err
 		}
 	}
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controllerOptions(r.Config, r.Config.VulnerabilityScannerMaxConcurrentReconciles)).
 		For(&batchv1.Job{}, builder.WithPredicates(
-			InNamespace(r.Config.Namespace),
+			InNamespace(r.Config.GetScanJobsNamespace()),
 			ManagedByStarboardOperator,
 			IsVulnerabilityReportScan,
 			JobHasAnyCondition,
-		)).
+			ScanJobInShard(r.Sharder),
+		))
+	return watchGainedNamespaces(b, r.Sharder,
+		scanJobsOfNamespace(r.Logger, mgr.GetClient(), r.Config.GetScanJobsNamespace(), starboard.LabelVulnerabilityReportScanner)).
 		Complete(r.reconcileJobs())
 }
 
@@ -100,6 +161,13 @@ func (r *VulnerabilityReportReconciler) reconcileWorkload(workloadKind kube.Kind
 			return ctrl.Result{}, fmt.Errorf("getting %s from cache: %w", workloadKind, err)
 		}
 
+		// Skip processing if scanning is disabled by the annotation of the
+		// workload. Events of owned reports pass the predicates of workloads.
+		if !IsScanEnabled(workloadObj, r.Config.ScanOptInEnabled) {
+			log.V(1).Info("Ignoring workload with scanning disabled", "annotation", starboard.AnnotationScan)
+			return ctrl.Result{}, nil
+		}
+
 		// Skip processing if it's a Pod controlled by a built-in K8s workload.
 		if workloadKind == kube.KindPod {
 			controller := metav1.GetControllerOf(workloadObj)
@@ -135,11 +203,16 @@ func (r *VulnerabilityReportReconciler) reconcileWorkload(workloadKind kube.Kind
 			return ctrl.Result{}, err
 		}
 
-		containerImages := kube.GetContainerImagesFromPodSpec(podSpec)
+		containerImages := r.ImageFilter.Apply(kube.GetContainerImagesFromPodSpec(podSpec))
 		hash := kube.ComputeHash(podSpec)
 
 		log = log.WithValues("podSpecHash", hash)
 
+		if len(containerImages) == 0 {
+			log.V(1).Info("Ignoring workload whose images are all excluded from scanning")
+			return ctrl.Result{}, nil
+		}
+
 		// Check if containers of the Pod have corresponding VulnerabilityReports.
 		hasReports, err := r.hasReports(ctx, workloadPartial, hash, containerImages)
 		if err != nil {
@@ -151,15 +224,115 @@ func (r *VulnerabilityReportReconciler) reconcileWorkload(workloadKind kube.Kind
 			return ctrl.Result{}, nil
 		}
 
-		_, job, err := r.hasActiveScanJob(ctx, workloadPartial, hash)
+		hasScanJob, job, err := r.hasActiveScanJob(ctx, workloadPartial, hash)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("checking scan job: %w", err)
 		}
 
-		if job != nil {
+		var failedJob *batchv1.Job
+		attempt := 1
+		switch {
+		case job != nil && hasJobCondition(*job, batchv1.JobFailed):
+			failedJob = job
+			// If the workload has changed since its scan job failed, the
+			// failed scan job is replaced without waiting.
+			if !hasScanJob {
+				break
+			}
+			var retry bool
+			var retryAfter time.Duration
+			attempt, retryAfter, retry = r.RetryPolicy.Next(job)
+			if !retry {
+				log.V(1).Info("Giving up scanning after failed attempts", "attempts", attempt,
+					"failure", job.Annotations[starboard.AnnotationScanJobFailure])
+				return ctrl.Result{}, nil
+			}
+			if retryAfter > 0 {
+				log.V(1).Info("Waiting before retrying failed scan job", "attempt", attempt, "retryAfter", retryAfter)
+				return ctrl.Result{RequeueAfter: retryAfter}, nil
+			}
+		case hasScanJob:
 			log.V(1).Info("Scan job already exists",
 				"job", fmt.Sprintf("%s/%s", job.Namespace, job.Name))
 			return ctrl.Result{}, nil
+		case job != nil:
+			// The scan job of the previous pod spec is still running, e.g.
+			// because the workload changed while the operator was down. It
+			// has the same name as the new scan job, which is created once
+			// the running one completes.
+			log.V(1).Info("Waiting for scan job of previous pod spec",
+				"job", fmt.Sprintf("%s/%s", job.Namespace, job.Name), "retryAfter", r.ScanJobRetryAfter)
+			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+		}
+
+		var digests kube.ContainerImages
+		if r.ScanResultCache != nil || r.Config.VulnerabilityScannerDigestCacheMaxAge != nil ||
+			r.Config.SubmittedReportsEnabled {
+			digests, err = r.getContainerImageDigests(ctx, workloadObj, containerImages)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("getting container image digests: %w", err)
+			}
+		}
+
+		if r.Config.SubmittedReportsEnabled {
+			submittedReports, err := r.getSubmittedReports(ctx, log, containerImages, digests)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if len(submittedReports) == len(containerImages) {
+				log.V(1).Info("Creating VulnerabilityReports from submitted reports")
+				return ctrl.Result{}, r.createReports(ctx, workloadObj, hash, containerImages, digests, submittedReports)
+			}
+		}
+
+		if r.ScanResultCache != nil {
+			cachedResults, err := r.getCachedScanResults(containerImages, digests)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("getting cached scan results: %w", err)
+			}
+			if len(cachedResults) == len(containerImages) {
+				log.V(1).Info("Creating VulnerabilityReports from cached scan results")
+				return ctrl.Result{}, r.createReports(ctx, workloadObj, hash, containerImages, digests, cachedResults)
+			}
+		}
+
+		if r.Config.VulnerabilityScannerDigestCacheMaxAge != nil {
+			cachedReports, missingDigests, err := r.findCachedReports(ctx, containerImages, digests)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("getting cached vulnerability reports: %w", err)
+			}
+
+			if len(cachedReports) == len(containerImages) {
+				log.V(1).Info("Reusing VulnerabilityReports of the same image digests")
+				return ctrl.Result{}, r.createReports(ctx, workloadObj, hash, containerImages, digests, cachedReports)
+			}
+
+			// Wait for scan jobs of other workloads if they are already
+			// scanning all images whose reports are missing.
+			if len(cachedReports)+len(missingDigests) == len(containerImages) {
+				scanning, err := r.hasActiveScanJobForDigests(ctx, workloadObj, missingDigests)
+				if err != nil {
+					return ctrl.Result{}, fmt.Errorf("checking scan jobs of image digests: %w", err)
+				}
+				if scanning {
+					log.V(1).Info("Waiting for scan jobs of the same image digests", "retryAfter", r.ScanJobRetryAfter)
+					return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+				}
+			}
+		}
+
+		if r.NodeAgents != nil {
+			scanned, err := r.scanWithNodeAgent(ctx, log, workloadObj, hash, containerImages, digests)
+			if err != nil || scanned {
+				return ctrl.Result{}, err
+			}
+		}
+
+		if r.ScannerPool != nil {
+			scanned, err := r.scanWithScannerPool(ctx, log, workloadObj, hash, containerImages, digests)
+			if err != nil || scanned {
+				return ctrl.Result{}, err
+			}
 		}
 
 		limitExceeded, scanJobsCount, err := r.LimitChecker.Check(ctx)
@@ -168,18 +341,311 @@ func (r *VulnerabilityReportReconciler) reconcileWorkload(workloadKind kube.Kind
 		}
 		log.V(1).Info("Checking scan jobs limit", "count", scanJobsCount, "limit", r.ConcurrentScanJobsLimit)
 
-		if limitExceeded {
+		if r.ScanQueue != nil {
+			rescan, err := r.isRescan(ctx, workloadPartial)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("checking previous scans: %w", err)
+			}
+			if !r.ScanQueue.Admit(workloadPartial, rescan, r.ConcurrentScanJobsLimit-scanJobsCount) {
+				log.V(1).Info("Pushing back scan job", "count", scanJobsCount, "rescan", rescan, "retryAfter", r.ScanJobRetryAfter)
+				return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+			}
+		} else if limitExceeded {
 			log.V(1).Info("Pushing back scan job", "count", scanJobsCount, "retryAfter", r.ScanJobRetryAfter)
 			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
 		}
 
-		return ctrl.Result{}, r.submitScanJob(ctx, workloadObj)
+		return r.submitScanJob(ctx, workloadObj, digests, failedJob, attempt)
+	}
+}
+
+// getContainerImageDigests returns digests of images run by containers of
+// the specified workload. Digests of images referenced by digest are known
+// upfront, whereas digests of images referenced by tag are resolved from
+// statuses of running pods. Containers whose digests cannot be resolved, e.g.
+// if there are no running pods yet, are omitted from the returned mapping,
+// in which case the workload is scanned as if caching was disabled.
+func (r *VulnerabilityReportReconciler) getContainerImageDigests(ctx context.Context, workload client.Object, images kube.ContainerImages) (kube.ContainerImages, error) {
+	digests, err := r.GetContainerImageDigests(ctx, workload)
+	if err != nil {
+		if !errors.Is(err, kube.ErrReplicaSetNotFound) && !errors.Is(err, kube.ErrNoRunningPods) &&
+			!errors.Is(err, kube.ErrUnSupportedKind) {
+			return nil, err
+		}
+		digests = kube.ContainerImages{}
+	}
+	for containerName, containerImage := range images {
+		if ref, err := name.NewDigest(containerImage); err == nil {
+			digests[containerName] = ref.DigestStr()
+		}
+	}
+	return digests, nil
+}
+
+// getCachedScanResults returns, for each container name, the cached scan
+// result of the image digest run by the container.
+func (r *VulnerabilityReportReconciler) getCachedScanResults(images, digests kube.ContainerImages) (map[string]v1alpha1.VulnerabilityReportData, error) {
+	scanner, err := r.getScannerCacheKey()
+	if err != nil {
+		return nil, err
+	}
+	cachedResults := map[string]v1alpha1.VulnerabilityReportData{}
+	for containerName := range images {
+		digest, ok := digests[containerName]
+		if !ok {
+			continue
+		}
+		if data, ok := r.ScanResultCache.Get(vulnerabilityreport.CacheKey{Digest: digest, Scanner: scanner}); ok {
+			cachedResults[containerName] = data
+		}
+	}
+	return cachedResults, nil
+}
+
+func (r *VulnerabilityReportReconciler) getScannerCacheKey() (string, error) {
+	config, err := r.PluginContext.GetConfig()
+	if err != nil {
+		return "", fmt.Errorf("getting plugin config: %w", err)
+	}
+	return vulnerabilityreport.GetScannerCacheKey(r.PluginContext.GetName(), config), nil
+}
+
+// getEcosystems returns toggles of ecosystems of vulnerabilities kept in
+// reports of workloads in the specified namespace.
+func (r *VulnerabilityReportReconciler) getEcosystems(namespace string) (starboard.Ecosystems, error) {
+	config, err := r.PluginContext.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("getting plugin config: %w", err)
+	}
+	ecosystems, err := config.GetEcosystems(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("getting ecosystems: %w", err)
+	}
+	return ecosystems, nil
+}
+
+// findCachedReports returns, for each container name, the most recent
+// VulnerabilityReport of the image digest run by the container if the report
+// was produced by the configured plugin and is not older than the configured
+// max age of the digest cache. It also returns digests of containers that do
+// not have such a report.
+func (r *VulnerabilityReportReconciler) findCachedReports(ctx context.Context, images, digests kube.ContainerImages) (map[string]v1alpha1.VulnerabilityReportData, []string, error) {
+	cachedReports := map[string]v1alpha1.VulnerabilityReportData{}
+	var missingDigests []string
+
+	for containerName := range images {
+		digest, ok := digests[containerName]
+		if !ok {
+			continue
+		}
+		// Look up the index of the metadata cache first, so that full reports
+		// are not listed from the API server for images scanned for the first
+		// time.
+		indexed, err := listReportsMetadataByField(ctx, r.Client, v1alpha1.VulnerabilityReportKind,
+			IndexImageDigest, vulnerabilityreport.GetImageDigestLabelValue(digest))
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(indexed) == 0 {
+			missingDigests = append(missingDigests, digest)
+			continue
+		}
+		reports, err := r.FindByImageDigest(ctx, digest)
+		if err != nil {
+			return nil, nil, err
+		}
+		var cached *v1alpha1.VulnerabilityReport
+		for i, report := range reports {
+			if report.Labels[starboard.LabelVulnerabilityReportScanner] != r.PluginContext.GetName() {
+				continue
+			}
+			// Vulnerabilities of the report might have been dropped by the
+			// ScanPolicy or ecosystem toggles of its namespace.
+			if _, ok := report.Labels[starboard.LabelScanPolicyHash]; ok {
+				continue
+			}
+			if _, ok := report.Labels[starboard.LabelEcosystemsHash]; ok {
+				continue
+			}
+			if time.Since(report.Report.UpdateTimestamp.Time) > *r.Config.VulnerabilityScannerDigestCacheMaxAge {
+				continue
+			}
+			if cached == nil || report.Report.UpdateTimestamp.After(cached.Report.UpdateTimestamp.Time) {
+				cached = &reports[i]
+			}
+		}
+		if cached == nil {
+			missingDigests = append(missingDigests, digest)
+			continue
+		}
+		cachedReports[containerName] = cached.Report
+	}
+
+	return cachedReports, missingDigests, nil
+}
+
+// createReports creates VulnerabilityReports of the specified workload from
+// the given data of reports of other workloads that run the same images, from
+// cached scan results, from submitted reports, or from results of node agents.
+// The data is copied as is, including the update timestamp, so that the age of
+// a cached result is always measured from the time of the scan.
+func (r *VulnerabilityReportReconciler) createReports(ctx context.Context, owner client.Object, hash string,
+	images, digests kube.ContainerImages, cachedReports map[string]v1alpha1.VulnerabilityReportData) error {
+	policy, err := getScanPolicy(ctx, r.Client, owner.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	ecosystems, err := r.getEcosystems(owner.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	nameFormat, err := r.GetReportNameFormat()
+	if err != nil {
+		return err
 	}
+
+	reportLabels, reportAnnotations, err := getPropagatedMetadata(ctx, r.ObjectResolver, r.Config, r.ConfigData, owner)
+	if err != nil {
+		return err
+	}
+
+	var vulnerabilityReports []v1alpha1.VulnerabilityReport
+
+	for containerName, containerImage := range images {
+		cached := cachedReports[containerName]
+		reportData := *cached.DeepCopy()
+		// The same image might be referenced by another tag or by digest.
+		if ref, err := name.ParseReference(containerImage); err == nil {
+			reportData.Registry = v1alpha1.Registry{
+				Server: ref.Context().RegistryStr(),
+			}
+			reportData.Artifact = v1alpha1.Artifact{
+				Repository: ref.Context().RepositoryStr(),
+			}
+			switch t := ref.(type) {
+			case name.Tag:
+				reportData.Artifact.Tag = t.TagStr()
+			case name.Digest:
+				reportData.Artifact.Digest = t.DigestStr()
+			}
+		}
+
+		reportBuilder := vulnerabilityreport.NewReportBuilder(r.Client.Scheme()).
+			Controller(owner).
+			Labels(reportLabels).
+			Annotations(reportAnnotations).
+			Container(containerName).
+			Data(reportData).
+			PodSpecHash(hash).
+			ImageDigest(digests[containerName]).
+			Scanner(r.PluginContext.GetName()).
+			ScanPolicy(policy).
+			Ecosystems(ecosystems).
+			NameFormat(nameFormat).
+			Orphaned(r.Config.RetainsOrphanedReports())
+
+		if r.Config.VulnerabilityScannerReportTTL != nil {
+			reportBuilder.ReportTTL(r.Config.VulnerabilityScannerReportTTL)
+		}
+
+		report, err := reportBuilder.Get()
+		if err != nil {
+			return err
+		}
+
+		vulnerabilityReports = append(vulnerabilityReports, report)
+	}
+
+	err = r.ReadWriter.Write(ctx, vulnerabilityReports)
+	if err != nil {
+		return err
+	}
+	r.writeHistory(ctx, owner, vulnerabilityReports)
+	r.callPostReportHook(ctx, owner, vulnerabilityReports)
+	return nil
+}
+
+// hasActiveScanJobForDigests checks whether scan jobs of other workloads are
+// scanning images with all the specified digests.
+func (r *VulnerabilityReportReconciler) hasActiveScanJobForDigests(ctx context.Context, owner client.Object, digests []string) (bool, error) {
+	if len(digests) == 0 {
+		return false, nil
+	}
+
+	var jobList batchv1.JobList
+	err := r.List(ctx, &jobList, client.InNamespace(r.Config.GetScanJobsNamespace()), client.MatchingLabels{
+		starboard.LabelK8SAppManagedBy:            starboard.AppStarboard,
+		starboard.LabelVulnerabilityReportScanner: r.PluginContext.GetName(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("listing scan jobs: %w", err)
+	}
+
+	ownJobName := vulnerabilityreport.GetScanJobName(owner)
+	scanning := map[string]bool{}
+	for _, job := range jobList.Items {
+		if job.Name == ownJobName || hasJobCondition(job, batchv1.JobFailed) {
+			continue
+		}
+		jobDigests, err := getContainerImageDigestsFromJob(&job)
+		if err != nil {
+			continue
+		}
+		for _, digest := range jobDigests {
+			scanning[digest] = true
+		}
+	}
+
+	for _, digest := range digests {
+		if !scanning[digest] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func hasJobCondition(job batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteSecrets deletes secrets that were created for a scan job which could
+// not be created.
+func deleteSecrets(ctx context.Context, c client.Client, secrets []*corev1.Secret) error {
+	for _, secret := range secrets {
+		err := c.Delete(ctx, secret)
+		if err != nil && !k8sapierror.IsNotFound(err) {
+			return fmt.Errorf("deleting secret used by scan job failed: %s: %w", secret.Namespace+"/"+secret.Name, err)
+		}
+	}
+	return nil
+}
+
+// getContainerImageDigestsFromJob returns the mapping between container names
+// and image digests encoded as JSON value of the
+// starboard.AnnotationContainerImageDigests annotation of the scan job. The
+// mapping is empty if the annotation is not set.
+func getContainerImageDigestsFromJob(job *batchv1.Job) (kube.ContainerImages, error) {
+	digests := kube.ContainerImages{}
+	value, ok := job.Annotations[starboard.AnnotationContainerImageDigests]
+	if !ok {
+		return digests, nil
+	}
+	err := digests.FromJSON(value)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s annotation: %w", starboard.AnnotationContainerImageDigests, err)
+	}
+	return digests, nil
 }
 
 func (r *VulnerabilityReportReconciler) hasReports(ctx context.Context, owner kube.ObjectRef, hash string, images kube.ContainerImages) (bool, error) {
-	// TODO FindByOwner should accept optional label selector to further narrow down search results
-	list, err := r.FindByOwner(ctx, owner)
+	list, err := listReportsMetadata(ctx, r.Client, v1alpha1.VulnerabilityReportKind, owner)
 	if err != nil {
 		return false, err
 	}
@@ -193,18 +659,33 @@ func (r *VulnerabilityReportReconciler) hasReports(ctx context.Context, owner ku
 		}
 	}
 
-	expected := map[string]bool{}
+	// Reports of containers whose images have been excluded from scanning
+	// since they were created are kept until the pod spec changes.
 	for containerName := range images {
-		expected[containerName] = true
+		if !actual[containerName] {
+			return false, nil
+		}
 	}
+	return true, nil
+}
 
-	return reflect.DeepEqual(actual, expected), nil
+// isRescan returns true if the specified workload has been scanned before and
+// all its reports have been deleted because their TTL has expired.
+func (r *VulnerabilityReportReconciler) isRescan(ctx context.Context, owner kube.ObjectRef) (bool, error) {
+	if r.Config.VulnerabilityScannerReportTTL == nil || !r.ScanQueue.HasBeenScanned(owner) {
+		return false, nil
+	}
+	list, err := listReportsMetadata(ctx, r.Client, v1alpha1.VulnerabilityReportKind, owner)
+	if err != nil {
+		return false, err
+	}
+	return len(list) == 0, nil
 }
 
 func (r *VulnerabilityReportReconciler) hasActiveScanJob(ctx context.Context, owner kube.ObjectRef, hash string) (bool, *batchv1.Job, error) {
 	jobName := fmt.Sprintf("scan-vulnerabilityreport-%s", kube.ComputeHash(owner))
 	job := &batchv1.Job{}
-	err := r.Get(ctx, client.ObjectKey{Namespace: r.Config.Namespace, Name: jobName}, job)
+	err := r.Get(ctx, client.ObjectKey{Namespace: r.Config.GetScanJobsNamespace(), Name: jobName}, job)
 	if err != nil {
 		if k8sapierror.IsNotFound(err) {
 			return false, nil, nil
@@ -214,30 +695,64 @@ func (r *VulnerabilityReportReconciler) hasActiveScanJob(ctx context.Context, ow
 	if job.Labels[starboard.LabelResourceSpecHash] == hash {
 		return true, job, nil
 	}
-	return false, nil, nil
+	return false, job, nil
 }
 
-func (r *VulnerabilityReportReconciler) submitScanJob(ctx context.Context, owner client.Object) error {
+// submitScanJob creates a scan job for the specified workload. If the previous
+// scan job of the workload failed, it is deleted right before the new scan job
+// is created, which carries the specified attempt number.
+func (r *VulnerabilityReportReconciler) submitScanJob(ctx context.Context, owner client.Object, digests kube.ContainerImages,
+	failedJob *batchv1.Job, attempt int) (ctrl.Result, error) {
 	log := r.Logger.WithValues("kind", owner.GetObjectKind().GroupVersionKind().Kind,
 		"name", owner.GetName(), "namespace", owner.GetNamespace())
 	credentials, err := r.CredentialsByWorkload(ctx, owner)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	scanJobTolerations, err := r.GetScanJobTolerations()
 	if err != nil {
-		return fmt.Errorf("getting scan job tolerations: %w", err)
+		return ctrl.Result{}, fmt.Errorf("getting scan job tolerations: %w", err)
+	}
+
+	scanJobScheduling, err := r.GetScanJobScheduling()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting scan job scheduling: %w", err)
+	}
+
+	scanJobSandbox, err := r.GetScanJobSandbox()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting scan job sandbox: %w", err)
+	}
+
+	scanJobImages, err := r.GetScanJobImages()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting scan job images: %w", err)
+	}
+
+	pluginConfig, err := r.PluginContext.GetConfig()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting plugin config: %w", err)
+	}
+
+	scanJobOverride, err := pluginConfig.GetScanJobOverride(owner.GetNamespace())
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting scan job override: %w", err)
 	}
 
 	scanJobAnnotations, err := r.GetScanJobAnnotations()
 	if err != nil {
-		return fmt.Errorf("getting scan job annotations: %w", err)
+		return ctrl.Result{}, fmt.Errorf("getting scan job annotations: %w", err)
 	}
 
 	scanJobPodTemplateLabels, err := r.GetScanJobPodTemplateLabels()
 	if err != nil {
-		return fmt.Errorf("getting scan job template labels: %w", err)
+		return ctrl.Result{}, fmt.Errorf("getting scan job template labels: %w", err)
+	}
+
+	commonMetadata, err := r.GetCommonMetadata()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting common metadata: %w", err)
 	}
 
 	scanJob, secrets, err := vulnerabilityreport.NewScanJobBuilder().
@@ -246,18 +761,75 @@ func (r *VulnerabilityReportReconciler) submitScanJob(ctx context.Context, owner
 		WithTimeout(r.Config.ScanJobTimeout).
 		WithObject(owner).
 		WithTolerations(scanJobTolerations).
+		WithScheduling(scanJobScheduling).
+		WithSandbox(scanJobSandbox).
+		WithImages(scanJobImages).
+		WithOverride(scanJobOverride).
 		WithAnnotations(scanJobAnnotations).
 		WithPodTemplateLabels(scanJobPodTemplateLabels).
 		WithCredentials(credentials).
+		WithImageFilter(r.ImageFilter).
+		WithCommonMetadata(commonMetadata).
 		Get()
 
 	if err != nil {
 		if errors.Is(err, kube.ErrReplicaSetNotFound) || errors.Is(err, kube.ErrNoRunningPods) ||
 			errors.Is(err, kube.ErrUnSupportedKind) {
 			log.V(1).Info("ignoring vulnerability scan", "reason", err)
-			return nil
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("constructing scan job: %w", err)
+	}
+
+	if len(digests) > 0 {
+		digestsAsJSON, err := digests.AsJSON()
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		scanJob.Annotations[starboard.AnnotationContainerImageDigests] = digestsAsJSON
+	}
+	setScanJobAttempt(scanJob, attempt)
+
+	if r.NodeLimiter != nil {
+		admitted, err := r.NodeLimiter.Apply(ctx, scanJob)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("applying node scan jobs limit: %w", err)
+		}
+		if !admitted {
+			log.V(1).Info("Pushing back scan job bound to node", "node", scanJob.Spec.Template.Spec.NodeName,
+				"limit", r.ConcurrentNodeScanJobsLimit, "retryAfter", r.ScanJobRetryAfter)
+			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+		}
+	}
+
+	if r.QuotaChecker != nil {
+		quota, err := r.QuotaChecker.Check(ctx, scanJob)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("checking resource quotas: %w", err)
+		}
+		if quota != "" {
+			log.V(1).Info("Pushing back scan job", "reason", "resource quota exceeded", "quota", quota,
+				"retryAfter", r.ScanJobRetryAfter)
+			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+		}
+	}
+
+	err = r.Hooks.preScan(ctx, log, r.Client.Scheme(), owner, scanJob)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("calling pre-scan hook: %w", err)
+	}
+
+	if delay := r.RateLimiter.Reserve(); delay > 0 {
+		log.V(1).Info("Pushing back scan job", "reason", "scan jobs rate limit exceeded", "retryAfter", delay)
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
+	if failedJob != nil {
+		log.V(1).Info("Deleting failed scan job", "attempt", attempt)
+		err = r.deleteJob(ctx, failedJob)
+		if err != nil {
+			return ctrl.Result{}, err
 		}
-		return fmt.Errorf("constructing scan job: %w", err)
 	}
 
 	for _, secret := range secrets {
@@ -265,33 +837,35 @@ func (r *VulnerabilityReportReconciler) submitScanJob(ctx context.Context, owner
 		err = r.Client.Create(ctx, secret)
 		if err != nil {
 			if k8sapierror.IsAlreadyExists(err) {
-				return nil
+				return ctrl.Result{}, nil
 			}
-			return fmt.Errorf("creating secret used by scan job failed: %s: %w", secret.Namespace+"/"+secret.Name, err)
+			return ctrl.Result{}, fmt.Errorf("creating secret used by scan job failed: %s: %w", secret.Namespace+"/"+secret.Name, err)
 		}
 	}
 
 	err = r.Client.Create(ctx, scanJob)
 	if err != nil {
 		if k8sapierror.IsAlreadyExists(err) {
-			// TODO Delete secrets that were created in the previous step. Alternatively we can delete them on schedule.
-			return nil
+			// The scan job is not in the cache yet. Delete secrets that were
+			// created in the previous step and check again later.
+			log.V(1).Info("Scan job already exists", "job", scanJob.Namespace+"/"+scanJob.Name, "retryAfter", r.ScanJobRetryAfter)
+			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, deleteSecrets(ctx, r.Client, secrets)
 		}
-		return fmt.Errorf("creating scan job failed: %s: %w", scanJob.Namespace+"/"+scanJob.Name, err)
+		return ctrl.Result{}, fmt.Errorf("creating scan job failed: %s: %w", scanJob.Namespace+"/"+scanJob.Name, err)
 	}
 
 	for _, secret := range secrets {
 		err = controllerutil.SetOwnerReference(scanJob, secret, r.Client.Scheme())
 		if err != nil {
-			return fmt.Errorf("setting owner reference: %w", err)
+			return ctrl.Result{}, fmt.Errorf("setting owner reference: %w", err)
 		}
 		err := r.Client.Update(ctx, secret)
 		if err != nil {
-			return fmt.Errorf("setting owner reference of secret used by scan job failed: %s: %w", secret.Namespace+"/"+secret.Name, err)
+			return ctrl.Result{}, fmt.Errorf("setting owner reference of secret used by scan job failed: %s: %w", secret.Namespace+"/"+secret.Name, err)
 		}
 	}
 
-	return nil
+	return ctrl.Result{}, nil
 }
 
 func (r *VulnerabilityReportReconciler) reconcileJobs() reconcile.Func {
@@ -365,6 +939,49 @@ func (r *VulnerabilityReportReconciler) processCompleteScanJob(ctx context.Conte
 		return r.deleteJob(ctx, job)
 	}
 
+	digests, err := getContainerImageDigestsFromJob(job)
+	if err != nil {
+		return err
+	}
+
+	var scanner string
+	if r.ScanResultCache != nil && len(digests) > 0 {
+		scanner, err = r.getScannerCacheKey()
+		if err != nil {
+			return err
+		}
+	}
+
+	statuses, err := r.LogsReader.GetTerminatedContainersStatusesByJob(ctx, job)
+	if err != nil {
+		log.Error(err, "Unable to get terminated containers statuses")
+	}
+	dbMetadata, err := vulnerabilityreport.GetDBMetadata(r.Plugin, r.PluginContext, statuses)
+	if err != nil {
+		log.Error(err, "Unable to parse vulnerability database metadata")
+	}
+	recordDBMetadata(r.PluginContext.GetName(), dbMetadata)
+
+	policy, err := getScanPolicy(ctx, r.Client, owner.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	ecosystems, err := r.getEcosystems(owner.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	nameFormat, err := r.GetReportNameFormat()
+	if err != nil {
+		return err
+	}
+
+	reportLabels, reportAnnotations, err := getPropagatedMetadata(ctx, r.ObjectResolver, r.Config, r.ConfigData, owner)
+	if err != nil {
+		return err
+	}
+
 	var vulnerabilityReports []v1alpha1.VulnerabilityReport
 
 	for containerName, containerImage := range containerImages {
@@ -377,12 +994,26 @@ func (r *VulnerabilityReportReconciler) processCompleteScanJob(ctx context.Conte
 			return err
 		}
 		_ = logsStream.Close()
+		reportData.Scan = vulnerabilityreport.NewScanMetadata(statuses[containerName], dbMetadata)
 
 		reportBuilder := vulnerabilityreport.NewReportBuilder(r.Client.Scheme()).
 			Controller(owner).
+			Labels(reportLabels).
+			Annotations(reportAnnotations).
 			Container(containerName).
 			Data(reportData).
-			PodSpecHash(podSpecHash)
+			PodSpecHash(podSpecHash).
+			ScanPolicy(policy).
+			Ecosystems(ecosystems).
+			NameFormat(nameFormat).
+			Orphaned(r.Config.RetainsOrphanedReports())
+
+		if digest, ok := digests[containerName]; ok {
+			reportBuilder.ImageDigest(digest).Scanner(r.PluginContext.GetName())
+			if r.ScanResultCache != nil {
+				r.ScanResultCache.Put(vulnerabilityreport.CacheKey{Digest: digest, Scanner: scanner}, reportData)
+			}
+		}
 
 		if r.Config.VulnerabilityScannerReportTTL != nil {
 			reportBuilder.ReportTTL(r.Config.VulnerabilityScannerReportTTL)
@@ -401,17 +1032,62 @@ func (r *VulnerabilityReportReconciler) processCompleteScanJob(ctx context.Conte
 	if err != nil {
 		return err
 	}
+	r.writeHistory(ctx, owner, vulnerabilityReports)
+	r.callPostReportHook(ctx, owner, vulnerabilityReports)
+
+	err = r.ScanFailureReports.Delete(ctx, types.NamespacedName{
+		Name:      scanfailurereport.GetReportName(owner, v1alpha1.VulnerabilityReportKind),
+		Namespace: owner.GetNamespace(),
+	})
+	if err != nil {
+		return fmt.Errorf("deleting scan failure report: %w", err)
+	}
 
 	log.V(1).Info("Deleting complete scan job", "owner", owner)
 	return r.deleteJob(ctx, job)
 }
 
+// recordDBMetadata reports the freshness of the vulnerability database used
+// by the last completed scan job of the specified scanner, if the plugin
+// records metadata of the database.
+func recordDBMetadata(scanner string, metadata *vulnerabilityreport.DBMetadata) {
+	if metadata == nil {
+		return
+	}
+	vulnerabilityDBUpdatedTimestamp.WithLabelValues(scanner).Set(float64(metadata.UpdatedAt.Unix()))
+	vulnerabilityDBAge.WithLabelValues(scanner).Set(time.Since(metadata.UpdatedAt).Seconds())
+	if !metadata.NextUpdate.IsZero() {
+		vulnerabilityDBNextUpdateTimestamp.WithLabelValues(scanner).Set(float64(metadata.NextUpdate.Unix()))
+	}
+}
+
+// processFailedScanJob records the failure of the specified scan job, which is
+// kept until it's replaced according to the RetryPolicy.
 func (r *VulnerabilityReportReconciler) processFailedScanJob(ctx context.Context, scanJob *batchv1.Job) error {
 	log := r.Logger.WithValues("job", fmt.Sprintf("%s/%s", scanJob.Namespace, scanJob.Name))
 
+	ownerRef, err := kube.ObjectRefFromObjectMeta(scanJob.ObjectMeta)
+	if err != nil {
+		return fmt.Errorf("getting owner ref from scan job metadata: %w", err)
+	}
+
+	owner, err := r.ObjectFromObjectRef(ctx, ownerRef)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.V(1).Info("Deleting failed scan job of workload that must have been deleted")
+			return r.deleteJob(ctx, scanJob)
+		}
+		return fmt.Errorf("getting object from object ref: %w", err)
+	}
+
+	if _, recorded := scanJob.Annotations[starboard.AnnotationScanJobFailure]; recorded {
+		return nil
+	}
+
 	statuses, err := r.GetTerminatedContainersStatusesByJob(ctx, scanJob)
 	if err != nil {
-		return err
+		// Pods of scan jobs that exceeded their deadline are deleted.
+		log.V(1).Info("Getting terminated containers statuses", "error", err.Error())
 	}
 	for container, status := range statuses {
 		if status.ExitCode == 0 {
@@ -419,8 +1095,44 @@ func (r *VulnerabilityReportReconciler) processFailedScanJob(ctx context.Context
 		}
 		log.Error(nil, "Scan job container", "container", container, "status.reason", status.Reason, "status.message", status.Message)
 	}
-	log.V(1).Info("Deleting failed scan job")
-	return r.deleteJob(ctx, scanJob)
+
+	attempt, _, retry := r.RetryPolicy.Next(scanJob)
+	if retry {
+		log.V(1).Info("Keeping failed scan job until it's retried", "nextAttempt", attempt)
+	} else {
+		log.Info("Giving up scanning after failed attempts", "attempts", attempt)
+	}
+
+	containerImages, err := kube.GetContainerImagesFromJob(scanJob)
+	if err != nil {
+		return fmt.Errorf("getting container images: %w", err)
+	}
+	data := newScanFailureReportData(ctx, r.LogsReader, scanJob, statuses, containerImages)
+	data.Scanner = r.PluginContext.GetName()
+	data.ReportKind = v1alpha1.VulnerabilityReportKind
+	data.GaveUp = !retry
+	report, err := scanfailurereport.NewReportBuilder(r.Client.Scheme()).
+		Controller(owner).
+		Labels(r.Config.GetClusterLabels()).
+		Namespace(r.Config.Namespace).
+		Data(data).
+		Get()
+	if err != nil {
+		return err
+	}
+	commonMetadata, err := r.GetCommonMetadata()
+	if err != nil {
+		return err
+	}
+	commonMetadata.ApplyTo(&report.ObjectMeta)
+	// Failing to write the report, e.g. because the ScanFailureReport CRD is
+	// not installed, must not prevent retrying the scan job.
+	err = r.ScanFailureReports.Write(ctx, report)
+	if err != nil {
+		log.Error(err, "Writing scan failure report")
+	}
+
+	return recordScanJobFailure(ctx, r.Client, scanJob, getScanJobFailure(scanJob, statuses), !retry)
 }
 
 func (r *VulnerabilityReportReconciler) deleteJob(ctx context.Context, job *batchv1.Job) error {
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// quotaFunc is a QuotaChecker backed by a function.
+type quotaFunc func() string
+
+func (f quotaFunc) Check(_ context.Context, _ *batchv1.Job) (string, error) {
+	return f(), nil
+}
+
+func TestVulnerabilityReportReconciler_RateLimit(t *testing.T) {
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "backend"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "backend", Image: "quay.io/acme/backend:1.0"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-system", Name: "starboard-trivy-config"},
+			Data: map[string]string{
+				"trivy.imageRef": "docker.io/aquasec/trivy:0.25.2",
+				"trivy.mode":     "Standalone",
+			},
+		},
+		pod,
+	).Build()
+
+	quota := "scan-jobs"
+	r := &VulnerabilityReportReconciler{
+		Logger:        log.Log,
+		Config:        etc.Config{ScanJobTimeout: 5 * time.Minute, Namespace: "starboard-system"},
+		Client:        c,
+		SecretsReader: kube.NewSecretsReader(c),
+		Plugin:        trivy.NewPlugin(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator(), c),
+		PluginContext: starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-system").
+			WithClient(c).
+			Get(),
+		ConfigData:   starboard.GetDefaultConfig(),
+		QuotaChecker: quotaFunc(func() string { return quota }),
+		RateLimiter:  NewRateLimiter(etc.Config{ScanJobsRateLimit: 1, ScanJobsRateBurst: 1}),
+	}
+
+	for i := 0; i < 3; i++ {
+		result, err := r.submitScanJob(context.TODO(), pod, nil, nil, 1)
+		require.NoError(t, err)
+		assert.Equal(t, r.Config.ScanJobRetryAfter, result.RequeueAfter, "Should push back scan job exceeding quota")
+	}
+
+	quota = ""
+	result, err := r.submitScanJob(context.TODO(), pod, nil, nil, 1)
+	require.NoError(t, err)
+	assert.Zero(t, result, "Should not take tokens of scan jobs pushed back by other limits")
+	var jobs batchv1.JobList
+	require.NoError(t, c.List(context.TODO(), &jobs, client.InNamespace("starboard-system")))
+	assert.Len(t, jobs.Items, 1)
+
+	assert.Positive(t, r.RateLimiter.Reserve(), "Should take the token of the created scan job")
+}
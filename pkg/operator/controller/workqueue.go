@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	controllerx "sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+// controllerOptions returns options of a controller that reconciles at most
+// the specified number of objects in parallel.
+//
+// Each controller gets its own workqueue rate limiter, which combines the
+// per-item exponential backoff of failed reconciles with the overall rate of
+// the workqueue, as the default rate limiter of controller-runtime does, but
+// with the delays and rates of the operator config.
+func controllerOptions(config etc.Config, maxConcurrentReconciles int) controllerx.Options {
+	return controllerx.Options{
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		RateLimiter: workqueue.NewMaxOfRateLimiter(
+			workqueue.NewItemExponentialFailureRateLimiter(config.WorkqueueBaseDelay, config.WorkqueueMaxDelay),
+			&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(config.WorkqueueQPS), config.WorkqueueBurst)},
+		),
+	}
+}
@@ -2,32 +2,137 @@ package etc
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/aquasecurity/starboard/pkg/riskscore"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityhistory"
 	"github.com/caarlos0/env/v6"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// ConfigMapName is the name of the optional ConfigMap in the operator
+// namespace whose keys override environment variables of the operator. It's
+// only read if OPERATOR_CONFIG_RELOAD_ENABLED is true.
+const ConfigMapName = "starboard-operator"
+
+// notOverridable are environment variables that cannot be overridden by keys
+// of the ConfigMapName ConfigMap.
+var notOverridable = map[string]bool{
+	"OPERATOR_NAMESPACE":             true,
+	"OPERATOR_CONFIG_RELOAD_ENABLED": true,
+}
+
 // Config defines parameters for running the operator.
 type Config struct {
-	Namespace                                    string         `env:"OPERATOR_NAMESPACE"`
-	TargetNamespaces                             string         `env:"OPERATOR_TARGET_NAMESPACES"`
-	ServiceAccount                               string         `env:"OPERATOR_SERVICE_ACCOUNT" envDefault:"starboard-operator"`
-	LogDevMode                                   bool           `env:"OPERATOR_LOG_DEV_MODE" envDefault:"false"`
-	ScanJobTimeout                               time.Duration  `env:"OPERATOR_SCAN_JOB_TIMEOUT" envDefault:"5m"`
-	ConcurrentScanJobsLimit                      int            `env:"OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT" envDefault:"10"`
-	ScanJobRetryAfter                            time.Duration  `env:"OPERATOR_SCAN_JOB_RETRY_AFTER" envDefault:"30s"`
-	BatchDeleteLimit                             int            `env:"OPERATOR_BATCH_DELETE_LIMIT" envDefault:"10"`
-	BatchDeleteDelay                             time.Duration  `env:"OPERATOR_BATCH_DELETE_DELAY" envDefault:"10s"`
-	MetricsBindAddress                           string         `env:"OPERATOR_METRICS_BIND_ADDRESS" envDefault:":8080"`
-	HealthProbeBindAddress                       string         `env:"OPERATOR_HEALTH_PROBE_BIND_ADDRESS" envDefault:":9090"`
-	CISKubernetesBenchmarkEnabled                bool           `env:"OPERATOR_CIS_KUBERNETES_BENCHMARK_ENABLED" envDefault:"true"`
-	VulnerabilityScannerEnabled                  bool           `env:"OPERATOR_VULNERABILITY_SCANNER_ENABLED" envDefault:"true"`
-	VulnerabilityScannerScanOnlyCurrentRevisions bool           `env:"OPERATOR_VULNERABILITY_SCANNER_SCAN_ONLY_CURRENT_REVISIONS" envDefault:"false"`
-	VulnerabilityScannerReportTTL                *time.Duration `env:"OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL"`
-	ConfigAuditScannerEnabled                    bool           `env:"OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED" envDefault:"true"`
-	LeaderElectionEnabled                        bool           `env:"OPERATOR_LEADER_ELECTION_ENABLED" envDefault:"false"`
-	LeaderElectionID                             string         `env:"OPERATOR_LEADER_ELECTION_ID" envDefault:"starboard-lock"`
+	Namespace                                            string         `env:"OPERATOR_NAMESPACE"`
+	TargetNamespaces                                     string         `env:"OPERATOR_TARGET_NAMESPACES"`
+	TargetNamespaceSelector                              string         `env:"OPERATOR_TARGET_NAMESPACE_SELECTOR"`
+	ExcludeNamespaceSelector                             string         `env:"OPERATOR_EXCLUDE_NAMESPACE_SELECTOR"`
+	ScanJobsNamespace                                    string         `env:"OPERATOR_SCAN_JOBS_NAMESPACE"`
+	ServiceAccount                                       string         `env:"OPERATOR_SERVICE_ACCOUNT" envDefault:"starboard-operator"`
+	LogDevMode                                           bool           `env:"OPERATOR_LOG_DEV_MODE" envDefault:"false"`
+	DryRun                                               bool           `env:"OPERATOR_DRY_RUN" envDefault:"false"`
+	ConfigReloadEnabled                                  bool           `env:"OPERATOR_CONFIG_RELOAD_ENABLED" envDefault:"false"`
+	ScanJobTimeout                                       time.Duration  `env:"OPERATOR_SCAN_JOB_TIMEOUT" envDefault:"5m"`
+	ConcurrentScanJobsLimit                              int            `env:"OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT" envDefault:"10"`
+	ConcurrentNodeScanJobsLimit                          int            `env:"OPERATOR_CONCURRENT_NODE_SCAN_JOBS_LIMIT" envDefault:"0"`
+	ScanJobRetryAfter                                    time.Duration  `env:"OPERATOR_SCAN_JOB_RETRY_AFTER" envDefault:"30s"`
+	ScanJobMaxRetryAfter                                 time.Duration  `env:"OPERATOR_SCAN_JOB_MAX_RETRY_AFTER" envDefault:"1h"`
+	ScanJobMaxAttempts                                   int            `env:"OPERATOR_SCAN_JOB_MAX_ATTEMPTS" envDefault:"5"`
+	ScanJobsRateLimit                                    float64        `env:"OPERATOR_SCAN_JOBS_RATE_LIMIT" envDefault:"0"`
+	ScanJobsRateBurst                                    int            `env:"OPERATOR_SCAN_JOBS_RATE_BURST" envDefault:"10"`
+	BatchDeleteLimit                                     int            `env:"OPERATOR_BATCH_DELETE_LIMIT" envDefault:"10"`
+	BatchDeleteDelay                                     time.Duration  `env:"OPERATOR_BATCH_DELETE_DELAY" envDefault:"10s"`
+	KubeClientQPS                                        float32        `env:"OPERATOR_KUBE_CLIENT_QPS" envDefault:"20"`
+	KubeClientBurst                                      int            `env:"OPERATOR_KUBE_CLIENT_BURST" envDefault:"30"`
+	WorkqueueBaseDelay                                   time.Duration  `env:"OPERATOR_WORKQUEUE_BASE_DELAY" envDefault:"5ms"`
+	WorkqueueMaxDelay                                    time.Duration  `env:"OPERATOR_WORKQUEUE_MAX_DELAY" envDefault:"1000s"`
+	WorkqueueQPS                                         float64        `env:"OPERATOR_WORKQUEUE_QPS" envDefault:"10"`
+	WorkqueueBurst                                       int            `env:"OPERATOR_WORKQUEUE_BURST" envDefault:"100"`
+	MetricsBindAddress                                   string         `env:"OPERATOR_METRICS_BIND_ADDRESS" envDefault:":8080"`
+	HealthProbeBindAddress                               string         `env:"OPERATOR_HEALTH_PROBE_BIND_ADDRESS" envDefault:":9090"`
+	RegistryHealthCheckEnabled                           bool           `env:"OPERATOR_REGISTRY_HEALTH_CHECK_ENABLED" envDefault:"false"`
+	CISKubernetesBenchmarkEnabled                        bool           `env:"OPERATOR_CIS_KUBERNETES_BENCHMARK_ENABLED" envDefault:"true"`
+	CISKubernetesBenchmarkMaxConcurrentReconciles        int            `env:"OPERATOR_CIS_KUBERNETES_BENCHMARK_MAX_CONCURRENT_RECONCILES" envDefault:"1"`
+	VulnerabilityScannerEnabled                          bool           `env:"OPERATOR_VULNERABILITY_SCANNER_ENABLED" envDefault:"true"`
+	VulnerabilityScannerMaxConcurrentReconciles          int            `env:"OPERATOR_VULNERABILITY_SCANNER_MAX_CONCURRENT_RECONCILES" envDefault:"1"`
+	VulnerabilityScannerScanOnlyCurrentRevisions         bool           `env:"OPERATOR_VULNERABILITY_SCANNER_SCAN_ONLY_CURRENT_REVISIONS" envDefault:"false"`
+	VulnerabilityScannerReportTTL                        *time.Duration `env:"OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL"`
+	VulnerabilityScannerReportTTLSweepInterval           time.Duration  `env:"OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL_SWEEP_INTERVAL" envDefault:"1m"`
+	VulnerabilityScannerReportTTLMaxConcurrentReconciles int            `env:"OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL_MAX_CONCURRENT_RECONCILES" envDefault:"1"`
+	VulnerabilityScannerDigestCacheMaxAge                *time.Duration `env:"OPERATOR_VULNERABILITY_SCANNER_DIGEST_CACHE_MAX_AGE"`
+	VulnerabilityScannerScanResultCacheTTL               *time.Duration `env:"OPERATOR_VULNERABILITY_SCANNER_SCAN_RESULT_CACHE_TTL"`
+	VulnerabilityScannerNamespacePriorities              string         `env:"OPERATOR_VULNERABILITY_SCANNER_NAMESPACE_PRIORITIES"`
+	VulnerabilityScannerIncludeImages                    string         `env:"OPERATOR_VULNERABILITY_SCANNER_INCLUDE_IMAGES"`
+	VulnerabilityScannerExcludeImages                    string         `env:"OPERATOR_VULNERABILITY_SCANNER_EXCLUDE_IMAGES"`
+	VulnerabilityHistoryEnabled                          bool           `env:"OPERATOR_VULNERABILITY_HISTORY_ENABLED" envDefault:"true"`
+	VulnerabilityHistoryMaxResolved                      int            `env:"OPERATOR_VULNERABILITY_HISTORY_MAX_RESOLVED" envDefault:"100"`
+	VulnerabilitySLA                                     string         `env:"OPERATOR_VULNERABILITY_SLA"`
+	RiskScoreEnabled                                     bool           `env:"OPERATOR_RISK_SCORE_ENABLED" envDefault:"false"`
+	RiskPriorityReportTTL                                string         `env:"OPERATOR_RISK_PRIORITY_REPORT_TTL"`
+	ExposureReportEnabled                                bool           `env:"OPERATOR_EXPOSURE_REPORT_ENABLED" envDefault:"false"`
+	NetworkPolicyCoverageEnabled                         bool           `env:"OPERATOR_NETWORK_POLICY_COVERAGE_ENABLED" envDefault:"false"`
+	NetworkPolicyCoverageInterval                        time.Duration  `env:"OPERATOR_NETWORK_POLICY_COVERAGE_INTERVAL" envDefault:"1h"`
+	ConfigAuditScannerEnabled                            bool           `env:"OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED" envDefault:"true"`
+	ConfigAuditScannerMaxConcurrentReconciles            int            `env:"OPERATOR_CONFIG_AUDIT_SCANNER_MAX_CONCURRENT_RECONCILES" envDefault:"1"`
+	ConfigAuditSecretHygieneEnabled                      bool           `env:"OPERATOR_CONFIG_AUDIT_SECRET_HYGIENE_ENABLED" envDefault:"false"`
+	ImageSignatureVerifierEnabled                        bool           `env:"OPERATOR_IMAGE_SIGNATURE_VERIFIER_ENABLED" envDefault:"false"`
+	ScanOptInEnabled                                     bool           `env:"OPERATOR_SCAN_OPT_IN_ENABLED" envDefault:"false"`
+	LeaderElectionEnabled                                bool           `env:"OPERATOR_LEADER_ELECTION_ENABLED" envDefault:"false"`
+	LeaderElectionID                                     string         `env:"OPERATOR_LEADER_ELECTION_ID" envDefault:"starboard-lock"`
+	ShardingMode                                         string         `env:"OPERATOR_SHARDING_MODE"`
+	ShardingLabel                                        string         `env:"OPERATOR_SHARDING_LABEL" envDefault:"starboard.aquasecurity.github.io/shard"`
+	ShardingLeaseDuration                                time.Duration  `env:"OPERATOR_SHARDING_LEASE_DURATION" envDefault:"15s"`
+	ShardName                                            string         `env:"OPERATOR_SHARD_NAME"`
+	ClusterName                                          string         `env:"OPERATOR_CLUSTER_NAME"`
+	ClusterEnvironment                                   string         `env:"OPERATOR_CLUSTER_ENVIRONMENT"`
+	PropagatedLabels                                     string         `env:"OPERATOR_PROPAGATED_LABELS"`
+	PropagatedAnnotations                                string         `env:"OPERATOR_PROPAGATED_ANNOTATIONS"`
+	MetricsVulnerabilityReportsEnabled                   bool           `env:"OPERATOR_METRICS_VULNERABILITY_REPORTS_ENABLED" envDefault:"false"`
+	OrphanedReportsRetention                             time.Duration  `env:"OPERATOR_ORPHANED_REPORTS_RETENTION" envDefault:"0"`
+	OrphanedReportsSweepInterval                         time.Duration  `env:"OPERATOR_ORPHANED_REPORTS_SWEEP_INTERVAL" envDefault:"10m"`
+	VanishedImageReportsGracePeriod                      time.Duration  `env:"OPERATOR_VANISHED_IMAGE_REPORTS_GRACE_PERIOD" envDefault:"0"`
+	VanishedImageReportsSweepInterval                    time.Duration  `env:"OPERATOR_VANISHED_IMAGE_REPORTS_SWEEP_INTERVAL" envDefault:"10m"`
+	HubKubeconfig                                        string         `env:"OPERATOR_HUB_KUBECONFIG"`
+	HubNamespace                                         string         `env:"OPERATOR_HUB_NAMESPACE" envDefault:"starboard-hub"`
+	HubReplicationMode                                   string         `env:"OPERATOR_HUB_REPLICATION_MODE" envDefault:"Summary"`
+	HubSweepInterval                                     time.Duration  `env:"OPERATOR_HUB_SWEEP_INTERVAL" envDefault:"1h"`
+	ReportExportWebhookURL                               string         `env:"OPERATOR_REPORT_EXPORT_WEBHOOK_URL"`
+	ReportExportTimeout                                  time.Duration  `env:"OPERATOR_REPORT_EXPORT_TIMEOUT" envDefault:"30s"`
+	PreScanHookURL                                       string         `env:"OPERATOR_PRE_SCAN_HOOK_URL"`
+	PreScanHookFailurePolicy                             string         `env:"OPERATOR_PRE_SCAN_HOOK_FAILURE_POLICY" envDefault:"Fail"`
+	PostReportHookURL                                    string         `env:"OPERATOR_POST_REPORT_HOOK_URL"`
+	ScanHooksTimeout                                     time.Duration  `env:"OPERATOR_SCAN_HOOKS_TIMEOUT" envDefault:"10s"`
+	NodeAgentEnabled                                     bool           `env:"OPERATOR_NODE_AGENT_ENABLED" envDefault:"false"`
+	NodeAgentSelector                                    string         `env:"OPERATOR_NODE_AGENT_SELECTOR" envDefault:"app.kubernetes.io/name=starboard-node-agent"`
+	NodeAgentPort                                        int            `env:"OPERATOR_NODE_AGENT_PORT" envDefault:"8090"`
+	NodeAgentTimeout                                     time.Duration  `env:"OPERATOR_NODE_AGENT_TIMEOUT" envDefault:"5m"`
+	NodeAgentCAFile                                      string         `env:"OPERATOR_NODE_AGENT_CA_FILE"`
+	NodeAgentServerName                                  string         `env:"OPERATOR_NODE_AGENT_SERVER_NAME" envDefault:"starboard-node-agent"`
+	NodeAgentTokenFile                                   string         `env:"OPERATOR_NODE_AGENT_TOKEN_FILE" envDefault:"/var/run/secrets/starboard/node-agent/token"`
+	ScannerPoolEnabled                                   bool           `env:"OPERATOR_SCANNER_POOL_ENABLED" envDefault:"false"`
+	ScannerPoolSelector                                  string         `env:"OPERATOR_SCANNER_POOL_SELECTOR" envDefault:"app.kubernetes.io/name=starboard-scanner-pool"`
+	ScannerPoolPort                                      int            `env:"OPERATOR_SCANNER_POOL_PORT" envDefault:"8090"`
+	ScannerPoolTimeout                                   time.Duration  `env:"OPERATOR_SCANNER_POOL_TIMEOUT" envDefault:"5m"`
+	ScannerPoolCAFile                                    string         `env:"OPERATOR_SCANNER_POOL_CA_FILE"`
+	ScannerPoolServerName                                string         `env:"OPERATOR_SCANNER_POOL_SERVER_NAME" envDefault:"starboard-scanner-pool"`
+	ScannerPoolTokenFile                                 string         `env:"OPERATOR_SCANNER_POOL_TOKEN_FILE" envDefault:"/var/run/secrets/starboard/node-agent/token"`
+	SubmittedReportsEnabled                              bool           `env:"OPERATOR_SUBMITTED_REPORTS_ENABLED" envDefault:"false"`
+	SubmittedReportsMaxAge                               time.Duration  `env:"OPERATOR_SUBMITTED_REPORTS_MAX_AGE" envDefault:"24h"`
+	ReportsAPIBindAddress                                string         `env:"OPERATOR_REPORTS_API_BIND_ADDRESS"`
+	ReportsAPITLSCertFile                                string         `env:"OPERATOR_REPORTS_API_TLS_CERT_FILE"`
+	ReportsAPITLSKeyFile                                 string         `env:"OPERATOR_REPORTS_API_TLS_KEY_FILE"`
+	SummaryAPIBindAddress                                string         `env:"OPERATOR_SUMMARY_API_BIND_ADDRESS"`
+	SummaryAPITLSCertFile                                string         `env:"OPERATOR_SUMMARY_API_TLS_CERT_FILE"`
+	SummaryAPITLSKeyFile                                 string         `env:"OPERATOR_SUMMARY_API_TLS_KEY_FILE"`
+	ValidatingWebhookBindAddress                         string         `env:"OPERATOR_VALIDATING_WEBHOOK_BIND_ADDRESS"`
+	ValidatingWebhookTLSCertFile                         string         `env:"OPERATOR_VALIDATING_WEBHOOK_TLS_CERT_FILE"`
+	ValidatingWebhookTLSKeyFile                          string         `env:"OPERATOR_VALIDATING_WEBHOOK_TLS_KEY_FILE"`
 }
 
 // GetOperatorConfig loads Config from environment variables.
@@ -37,6 +142,39 @@ func GetOperatorConfig() (Config, error) {
 	return config, err
 }
 
+// GetOperatorConfigWithOverrides loads Config from environment variables,
+// whose values are overridden by the specified ones, e.g. read from the
+// ConfigMapName ConfigMap. Keys of overrides must be names of environment
+// variables of the operator, except for the ones that cannot be overridden
+// because they are needed to read overrides.
+func GetOperatorConfigWithOverrides(overrides map[string]string) (Config, error) {
+	environment := make(map[string]string)
+	for _, pair := range os.Environ() {
+		if parts := strings.SplitN(pair, "=", 2); len(parts) == 2 {
+			environment[parts[0]] = parts[1]
+		}
+	}
+	for key, value := range overrides {
+		if !strings.HasPrefix(key, "OPERATOR_") {
+			return Config{}, fmt.Errorf("invalid key %s of %s ConfigMap; expected OPERATOR_* environment variable", key, ConfigMapName)
+		}
+		if notOverridable[key] {
+			return Config{}, fmt.Errorf("%s cannot be set in %s ConfigMap", key, ConfigMapName)
+		}
+		environment[key] = value
+	}
+	return ParseOperatorConfig(environment)
+}
+
+// ParseOperatorConfig loads Config from the specified environment variables
+// rather than the ones of the current process, e.g. read from the Deployment
+// of the operator.
+func ParseOperatorConfig(environment map[string]string) (Config, error) {
+	var config Config
+	err := env.Parse(&config, env.Options{Environment: environment})
+	return config, err
+}
+
 // GetOperatorNamespace returns the namespace the operator should be running in.
 func (c Config) GetOperatorNamespace() (string, error) {
 	namespace := c.Namespace
@@ -46,6 +184,23 @@ func (c Config) GetOperatorNamespace() (string, error) {
 	return "", fmt.Errorf("%s must be set", "OPERATOR_NAMESPACE")
 }
 
+// GetScanJobsNamespace returns the namespace where the operator runs scan jobs
+// and creates other helper objects of plugins. Unless a dedicated namespace is
+// configured, it's the operator namespace.
+func (c Config) GetScanJobsNamespace() string {
+	if c.ScanJobsNamespace != "" {
+		return c.ScanJobsNamespace
+	}
+	return c.Namespace
+}
+
+// RetainsOrphanedReports checks whether reports are created without owner
+// references and retained for OrphanedReportsRetention after their owners
+// are deleted.
+func (c Config) RetainsOrphanedReports() bool {
+	return c.OrphanedReportsRetention > 0
+}
+
 // GetTargetNamespaces returns namespaces the operator should be watching for changes.
 func (c Config) GetTargetNamespaces() []string {
 	namespaces := c.TargetNamespaces
@@ -55,6 +210,256 @@ func (c Config) GetTargetNamespaces() []string {
 	return []string{}
 }
 
+// GetVulnerabilityScannerNamespacePriorities returns priorities of scans of
+// workloads in namespaces, which are specified as comma separated list of
+// namespace=priority pairs, e.g. prod=100,staging=50.
+func (c Config) GetVulnerabilityScannerNamespacePriorities() (map[string]int, error) {
+	priorities := make(map[string]int)
+	if strings.TrimSpace(c.VulnerabilityScannerNamespacePriorities) == "" {
+		return priorities, nil
+	}
+	for _, pair := range strings.Split(c.VulnerabilityScannerNamespacePriorities, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid namespace priority %q; expected namespace=priority", pair)
+		}
+		priority, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace priority %q: %w", pair, err)
+		}
+		priorities[parts[0]] = priority
+	}
+	return priorities, nil
+}
+
+// GetVulnerabilitySLA returns the maximum time that open vulnerabilities of
+// each severity may remain in workloads, which is specified as the comma
+// separated list of severity=days pairs, e.g. CRITICAL=7,HIGH=30.
+func (c Config) GetVulnerabilitySLA() (vulnerabilityhistory.SLA, error) {
+	return vulnerabilityhistory.ParseSLA(c.VulnerabilitySLA)
+}
+
+// GetRiskPriorityReportTTLs returns TTLs of VulnerabilityReports of workloads
+// of each risk priority, which are specified as the comma separated list of
+// priority=duration pairs, e.g. P1=24h,P2=72h.
+func (c Config) GetRiskPriorityReportTTLs() (riskscore.PriorityTTLs, error) {
+	return riskscore.ParsePriorityTTLs(c.RiskPriorityReportTTL)
+}
+
+// GetVulnerabilityScannerImagePatterns returns glob patterns of image
+// references that are exclusively scanned and patterns of image references
+// that are not scanned, respectively, which are specified as comma separated
+// lists, e.g. k8s.gcr.io/pause:*,*/busybox:*.
+func (c Config) GetVulnerabilityScannerImagePatterns() ([]string, []string) {
+	return splitPatterns(c.VulnerabilityScannerIncludeImages), splitPatterns(c.VulnerabilityScannerExcludeImages)
+}
+
+func splitPatterns(value string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// ShardingMode determines how namespaces are assigned to replicas of the
+// operator which reconcile objects in parallel.
+type ShardingMode string
+
+const (
+	// ShardingDisabled means that each replica reconciles objects in all
+	// target namespaces, which is only safe with leader election.
+	ShardingDisabled ShardingMode = ""
+	// ShardingHash assigns each namespace to a replica by hashing its name.
+	ShardingHash ShardingMode = "Hash"
+	// ShardingLabel assigns namespaces with the same value of the
+	// Config.ShardingLabel label to the same replica by hashing the value.
+	// Namespaces without the label are assigned by hashing their names.
+	ShardingLabel ShardingMode = "Label"
+)
+
+// GetShardingMode returns the configured ShardingMode. Sharding requires the
+// name of the shard, which must be unique among replicas, and it's mutually
+// exclusive with leader election.
+func (c Config) GetShardingMode() (ShardingMode, error) {
+	mode := ShardingMode(c.ShardingMode)
+	switch mode {
+	case ShardingDisabled:
+		return ShardingDisabled, nil
+	case ShardingHash, ShardingLabel:
+	default:
+		return "", fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s)",
+			c.ShardingMode, "OPERATOR_SHARDING_MODE", ShardingHash, ShardingLabel)
+	}
+	if c.ShardName == "" {
+		return "", fmt.Errorf("%s must be set in the %s sharding mode", "OPERATOR_SHARD_NAME", mode)
+	}
+	if c.LeaderElectionEnabled {
+		return "", fmt.Errorf("%s cannot be enabled in the %s sharding mode", "OPERATOR_LEADER_ELECTION_ENABLED", mode)
+	}
+	if c.ShardingLeaseDuration <= 0 {
+		return "", fmt.Errorf("%s must be positive", "OPERATOR_SHARDING_LEASE_DURATION")
+	}
+	return mode, nil
+}
+
+// HubReplicationMode determines which data of reports is replicated to the
+// hub cluster.
+type HubReplicationMode string
+
+const (
+	// HubReplicationDisabled means that reports are not replicated.
+	HubReplicationDisabled HubReplicationMode = ""
+	// HubReplicationSummary replicates reports without the lists of
+	// vulnerabilities and checks, which keeps the hub small.
+	HubReplicationSummary HubReplicationMode = "Summary"
+	// HubReplicationFull replicates reports as they are.
+	HubReplicationFull HubReplicationMode = "Full"
+)
+
+// GetHubReplicationMode returns the configured HubReplicationMode, or
+// HubReplicationDisabled if the kubeconfig of the hub cluster is not set.
+// Replication requires the name of the cluster, which must be unique among
+// clusters that replicate reports to the same hub.
+func (c Config) GetHubReplicationMode() (HubReplicationMode, error) {
+	if c.HubKubeconfig == "" {
+		return HubReplicationDisabled, nil
+	}
+	mode := HubReplicationMode(c.HubReplicationMode)
+	switch mode {
+	case HubReplicationSummary, HubReplicationFull:
+	default:
+		return "", fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s)",
+			c.HubReplicationMode, "OPERATOR_HUB_REPLICATION_MODE", HubReplicationSummary, HubReplicationFull)
+	}
+	if c.ClusterName == "" {
+		return "", fmt.Errorf("%s must be set to replicate reports to the hub cluster", "OPERATOR_CLUSTER_NAME")
+	}
+	if c.HubNamespace == "" {
+		return "", fmt.Errorf("%s must be set to replicate reports to the hub cluster", "OPERATOR_HUB_NAMESPACE")
+	}
+	return mode, nil
+}
+
+// HookFailurePolicy defines how the failure of a hook is handled.
+type HookFailurePolicy string
+
+const (
+	// HookFailurePolicyFail doesn't proceed until the hook succeeds.
+	HookFailurePolicyFail HookFailurePolicy = "Fail"
+	// HookFailurePolicyIgnore logs the failure and proceeds.
+	HookFailurePolicyIgnore HookFailurePolicy = "Ignore"
+)
+
+// GetPreScanHookFailurePolicy returns the configured HookFailurePolicy of the
+// pre-scan hook.
+func (c Config) GetPreScanHookFailurePolicy() (HookFailurePolicy, error) {
+	policy := HookFailurePolicy(c.PreScanHookFailurePolicy)
+	switch policy {
+	case HookFailurePolicyFail, HookFailurePolicyIgnore:
+		return policy, nil
+	}
+	return "", fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s)",
+		c.PreScanHookFailurePolicy, "OPERATOR_PRE_SCAN_HOOK_FAILURE_POLICY", HookFailurePolicyFail, HookFailurePolicyIgnore)
+}
+
+// GetClusterLabels returns the labels that identify this cluster, which are
+// set on all reports so that reports of many clusters can be told apart once
+// they are collected in one place.
+func (c Config) GetClusterLabels() map[string]string {
+	labels := make(map[string]string)
+	if c.ClusterName != "" {
+		labels[starboard.LabelClusterName] = c.ClusterName
+	}
+	if c.ClusterEnvironment != "" {
+		labels[starboard.LabelClusterEnvironment] = c.ClusterEnvironment
+	}
+	return labels
+}
+
+// GetPropagatedLabels returns the specified labels of a workload that are
+// copied onto its reports, which are specified as the comma separated list of
+// keys, e.g. team,cost-center. Keys that end with an asterisk match prefixes,
+// e.g. app.kubernetes.io/* matches app.kubernetes.io/name.
+func (c Config) GetPropagatedLabels(workloadLabels map[string]string) map[string]string {
+	return filterKeys(workloadLabels, splitPatterns(c.PropagatedLabels))
+}
+
+// GetPropagatedAnnotations returns the specified annotations of a workload
+// that are copied onto its reports, which are specified in the same way as
+// propagated labels.
+func (c Config) GetPropagatedAnnotations(workloadAnnotations map[string]string) map[string]string {
+	return filterKeys(workloadAnnotations, splitPatterns(c.PropagatedAnnotations))
+}
+
+// IsPropagated checks whether the label with the specified key is copied
+// from workloads onto their reports.
+func (c Config) IsPropagated(key string) bool {
+	return matchesKey(key, splitPatterns(c.PropagatedLabels))
+}
+
+func filterKeys(values map[string]string, patterns []string) map[string]string {
+	filtered := make(map[string]string)
+	if len(patterns) == 0 {
+		return filtered
+	}
+	for key, value := range values {
+		if matchesKey(key, patterns) {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+func matchesKey(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix := strings.TrimSuffix(pattern, "*"); prefix != pattern {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateClusterLabels checks that the name and the environment of the
+// cluster are valid label values.
+func (c Config) ValidateClusterLabels() error {
+	if errs := validation.IsValidLabelValue(c.ClusterName); len(errs) > 0 {
+		return fmt.Errorf("invalid value (%s) of %s: %s", c.ClusterName, "OPERATOR_CLUSTER_NAME", strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(c.ClusterEnvironment); len(errs) > 0 {
+		return fmt.Errorf("invalid value (%s) of %s: %s", c.ClusterEnvironment, "OPERATOR_CLUSTER_ENVIRONMENT", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// GetNamespaceSelectors returns the label selectors of namespaces whose
+// workloads are scanned and of namespaces whose workloads are not scanned,
+// respectively. A selector is nil if it's not set. If both are nil, workloads
+// are scanned regardless of labels of their namespaces.
+func (c Config) GetNamespaceSelectors() (labels.Selector, labels.Selector, error) {
+	var target, exclude labels.Selector
+	var err error
+	if c.TargetNamespaceSelector != "" {
+		target, err = labels.Parse(c.TargetNamespaceSelector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing OPERATOR_TARGET_NAMESPACE_SELECTOR: %w", err)
+		}
+	}
+	if c.ExcludeNamespaceSelector != "" {
+		exclude, err = labels.Parse(c.ExcludeNamespaceSelector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing OPERATOR_EXCLUDE_NAMESPACE_SELECTOR: %w", err)
+		}
+	}
+	return target, exclude, nil
+}
+
 // InstallMode represents multitenancy support defined by the Operator Lifecycle Manager spec.
 type InstallMode string
 
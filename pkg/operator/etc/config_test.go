@@ -2,6 +2,7 @@ package etc_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
 	"github.com/stretchr/testify/assert"
@@ -44,6 +45,16 @@ func TestOperator_GetTargetNamespaces(t *testing.T) {
 	}
 }
 
+func TestConfig_GetScanJobsNamespace(t *testing.T) {
+	assert.Equal(t, "starboard-operator", etc.Config{
+		Namespace: "starboard-operator",
+	}.GetScanJobsNamespace())
+	assert.Equal(t, "starboard-scans", etc.Config{
+		Namespace:         "starboard-operator",
+		ScanJobsNamespace: "starboard-scans",
+	}.GetScanJobsNamespace())
+}
+
 func TestOperator_ResolveInstallMode(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -103,3 +114,284 @@ func TestOperator_ResolveInstallMode(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_GetVulnerabilityScannerNamespacePriorities(t *testing.T) {
+	testCases := []struct {
+		name               string
+		operator           etc.Config
+		expectedPriorities map[string]int
+		expectedError      string
+	}{
+		{
+			name:               "Should return empty priorities",
+			operator:           etc.Config{},
+			expectedPriorities: map[string]int{},
+		},
+		{
+			name: "Should return priorities",
+			operator: etc.Config{
+				VulnerabilityScannerNamespacePriorities: "prod=100, staging=50,dev=-1",
+			},
+			expectedPriorities: map[string]int{
+				"prod":    100,
+				"staging": 50,
+				"dev":     -1,
+			},
+		},
+		{
+			name: "Should return error when priority is not a number",
+			operator: etc.Config{
+				VulnerabilityScannerNamespacePriorities: "prod=high",
+			},
+			expectedError: "invalid namespace priority \"prod=high\": strconv.Atoi: parsing \"high\": invalid syntax",
+		},
+		{
+			name: "Should return error when priority is missing",
+			operator: etc.Config{
+				VulnerabilityScannerNamespacePriorities: "prod",
+			},
+			expectedError: "invalid namespace priority \"prod\"; expected namespace=priority",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			priorities, err := tc.operator.GetVulnerabilityScannerNamespacePriorities()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPriorities, priorities)
+		})
+	}
+}
+
+func TestConfig_GetShardingMode(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        etc.Config
+		expectedMode  etc.ShardingMode
+		expectedError string
+	}{
+		{
+			name:         "Should return disabled sharding by default",
+			config:       etc.Config{},
+			expectedMode: etc.ShardingDisabled,
+		},
+		{
+			name: "Should return hash sharding",
+			config: etc.Config{
+				ShardingMode:          "Hash",
+				ShardName:             "starboard-operator-7d9f8c6b5-x2k4p",
+				ShardingLeaseDuration: 15 * time.Second,
+			},
+			expectedMode: etc.ShardingHash,
+		},
+		{
+			name: "Should return error when mode is invalid",
+			config: etc.Config{
+				ShardingMode: "RoundRobin",
+				ShardName:    "starboard-operator-7d9f8c6b5-x2k4p",
+			},
+			expectedError: "invalid value (RoundRobin) of OPERATOR_SHARDING_MODE; allowed values (Hash, Label)",
+		},
+		{
+			name: "Should return error when shard name is not set",
+			config: etc.Config{
+				ShardingMode:          "Label",
+				ShardingLeaseDuration: 15 * time.Second,
+			},
+			expectedError: "OPERATOR_SHARD_NAME must be set in the Label sharding mode",
+		},
+		{
+			name: "Should return error when leader election is enabled",
+			config: etc.Config{
+				ShardingMode:          "Hash",
+				ShardName:             "starboard-operator-7d9f8c6b5-x2k4p",
+				ShardingLeaseDuration: 15 * time.Second,
+				LeaderElectionEnabled: true,
+			},
+			expectedError: "OPERATOR_LEADER_ELECTION_ENABLED cannot be enabled in the Hash sharding mode",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mode, err := tc.config.GetShardingMode()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMode, mode)
+		})
+	}
+}
+
+func TestConfig_GetHubReplicationMode(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        etc.Config
+		expectedMode  etc.HubReplicationMode
+		expectedError string
+	}{
+		{
+			name: "Should return disabled replication when kubeconfig of hub is not set",
+			config: etc.Config{
+				HubReplicationMode: "Summary",
+			},
+			expectedMode: etc.HubReplicationDisabled,
+		},
+		{
+			name: "Should return full replication",
+			config: etc.Config{
+				ClusterName:        "eu-west-1-prod",
+				HubKubeconfig:      "/etc/starboard/hub/kubeconfig",
+				HubNamespace:       "starboard-hub",
+				HubReplicationMode: "Full",
+			},
+			expectedMode: etc.HubReplicationFull,
+		},
+		{
+			name: "Should return error when mode is invalid",
+			config: etc.Config{
+				ClusterName:        "eu-west-1-prod",
+				HubKubeconfig:      "/etc/starboard/hub/kubeconfig",
+				HubNamespace:       "starboard-hub",
+				HubReplicationMode: "Partial",
+			},
+			expectedError: "invalid value (Partial) of OPERATOR_HUB_REPLICATION_MODE; allowed values (Summary, Full)",
+		},
+		{
+			name: "Should return error when cluster name is not set",
+			config: etc.Config{
+				HubKubeconfig:      "/etc/starboard/hub/kubeconfig",
+				HubNamespace:       "starboard-hub",
+				HubReplicationMode: "Summary",
+			},
+			expectedError: "OPERATOR_CLUSTER_NAME must be set to replicate reports to the hub cluster",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mode, err := tc.config.GetHubReplicationMode()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMode, mode)
+		})
+	}
+}
+
+func TestConfig_GetPreScanHookFailurePolicy(t *testing.T) {
+	policy, err := etc.Config{PreScanHookFailurePolicy: "Ignore"}.GetPreScanHookFailurePolicy()
+	require.NoError(t, err)
+	assert.Equal(t, etc.HookFailurePolicyIgnore, policy)
+
+	_, err = etc.Config{PreScanHookFailurePolicy: "Retry"}.GetPreScanHookFailurePolicy()
+	assert.EqualError(t, err, "invalid value (Retry) of OPERATOR_PRE_SCAN_HOOK_FAILURE_POLICY; allowed values (Fail, Ignore)")
+}
+
+func TestConfig_GetClusterLabels(t *testing.T) {
+	assert.Equal(t, map[string]string{}, etc.Config{}.GetClusterLabels())
+	assert.Equal(t, map[string]string{
+		"starboard.cluster.name":        "eu-west-1-prod",
+		"starboard.cluster.environment": "production",
+	}, etc.Config{
+		ClusterName:        "eu-west-1-prod",
+		ClusterEnvironment: "production",
+	}.GetClusterLabels())
+}
+
+func TestConfig_ValidateClusterLabels(t *testing.T) {
+	assert.NoError(t, etc.Config{}.ValidateClusterLabels())
+	assert.NoError(t, etc.Config{ClusterName: "eu-west-1-prod", ClusterEnvironment: "production"}.ValidateClusterLabels())
+	err := etc.Config{ClusterName: "eu west"}.ValidateClusterLabels()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid value (eu west) of OPERATOR_CLUSTER_NAME")
+}
+
+func TestConfig_GetNamespaceSelectors(t *testing.T) {
+	target, exclude, err := etc.Config{}.GetNamespaceSelectors()
+	require.NoError(t, err)
+	assert.Nil(t, target)
+	assert.Nil(t, exclude)
+
+	target, exclude, err = etc.Config{
+		TargetNamespaceSelector:  "security-scan=enabled",
+		ExcludeNamespaceSelector: "environment in (sandbox)",
+	}.GetNamespaceSelectors()
+	require.NoError(t, err)
+	assert.Equal(t, "security-scan=enabled", target.String())
+	assert.Equal(t, "environment in (sandbox)", exclude.String())
+
+	_, _, err = etc.Config{TargetNamespaceSelector: "security-scan==="}.GetNamespaceSelectors()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parsing OPERATOR_TARGET_NAMESPACE_SELECTOR")
+}
+
+func TestConfig_GetVulnerabilityScannerImagePatterns(t *testing.T) {
+	include, exclude := etc.Config{}.GetVulnerabilityScannerImagePatterns()
+	assert.Empty(t, include)
+	assert.Empty(t, exclude)
+
+	include, exclude = etc.Config{
+		VulnerabilityScannerIncludeImages: "registry.example.com/*",
+		VulnerabilityScannerExcludeImages: "k8s.gcr.io/pause:*, */busybox:* ,",
+	}.GetVulnerabilityScannerImagePatterns()
+	assert.Equal(t, []string{"registry.example.com/*"}, include)
+	assert.Equal(t, []string{"k8s.gcr.io/pause:*", "*/busybox:*"}, exclude)
+}
+
+func TestConfig_GetPropagatedLabels(t *testing.T) {
+	workloadLabels := map[string]string{
+		"team":                         "payments",
+		"cost-center":                  "cc-42",
+		"app.kubernetes.io/name":       "api",
+		"app.kubernetes.io/managed-by": "helm",
+		"pod-template-hash":            "6d4cf56db6",
+	}
+
+	assert.Empty(t, etc.Config{}.GetPropagatedLabels(workloadLabels))
+
+	config := etc.Config{
+		PropagatedLabels:      "team, app.kubernetes.io/*",
+		PropagatedAnnotations: "cost-center",
+	}
+	assert.Equal(t, map[string]string{
+		"team":                         "payments",
+		"app.kubernetes.io/name":       "api",
+		"app.kubernetes.io/managed-by": "helm",
+	}, config.GetPropagatedLabels(workloadLabels))
+	assert.Equal(t, map[string]string{
+		"cost-center": "cc-42",
+	}, config.GetPropagatedAnnotations(workloadLabels))
+	assert.True(t, config.IsPropagated("app.kubernetes.io/version"))
+	assert.False(t, config.IsPropagated("teams"))
+}
+
+func TestGetOperatorConfigWithOverrides(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "starboard-system")
+	t.Setenv("OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT", "10")
+	t.Setenv("OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL", "24h")
+
+	config, err := etc.GetOperatorConfigWithOverrides(map[string]string{
+		"OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT": "3",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "starboard-system", config.Namespace)
+	assert.Equal(t, 3, config.ConcurrentScanJobsLimit)
+	require.NotNil(t, config.VulnerabilityScannerReportTTL)
+	assert.Equal(t, 24*time.Hour, *config.VulnerabilityScannerReportTTL)
+
+	_, err = etc.GetOperatorConfigWithOverrides(map[string]string{"concurrentScanJobsLimit": "3"})
+	assert.EqualError(t, err, "invalid key concurrentScanJobsLimit of starboard-operator ConfigMap; expected OPERATOR_* environment variable")
+
+	_, err = etc.GetOperatorConfigWithOverrides(map[string]string{"OPERATOR_NAMESPACE": "default"})
+	assert.EqualError(t, err, "OPERATOR_NAMESPACE cannot be set in starboard-operator ConfigMap")
+
+	_, err = etc.GetOperatorConfigWithOverrides(map[string]string{"OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT": "many"})
+	assert.Error(t, err)
+}
@@ -3,62 +3,224 @@ package operator
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
+	"time"
 
+	"github.com/aquasecurity/starboard/pkg/admission"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/export"
+	"github.com/aquasecurity/starboard/pkg/exposurereport"
 	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/imagesignature"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"github.com/aquasecurity/starboard/pkg/networkpolicyreport"
 	"github.com/aquasecurity/starboard/pkg/operator/controller"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operatorconfig"
 	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/reportapi"
+	"github.com/aquasecurity/starboard/pkg/scanfailurereport"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/summaryapi"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityhistory"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 var (
 	setupLog = log.Log.WithName("operator")
+	// baseRegistry is the registry of metrics registered once per process,
+	// e.g. metrics of controller-runtime.
+	baseRegistry = metrics.Registry
 )
 
 // Start starts all registered reconcilers and blocks until the context is cancelled.
 // Returns an error if there is an error starting any reconciler.
+//
+// Environment variables of the operator are overridden by the OperatorConfig,
+// if it exists and is valid. If reloading of the configuration is enabled,
+// reconcilers are stopped and started again with the reloaded configuration
+// each time the configuration is changed.
 func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig etc.Config) error {
+	kubeConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("getting kube client config: %w", err)
+	}
+	kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+	if err != nil {
+		return fmt.Errorf("constructing kube client: %w", err)
+	}
+
+	if !operatorConfig.ConfigReloadEnabled {
+		resolvedConfig, overrides, spec, err := resolveConfig(ctx, kubeClient, operatorConfig)
+		if err != nil {
+			return err
+		}
+		return start(ctx, buildInfo, resolvedConfig, overrides, spec, nil)
+	}
+
+	for {
+		resolvedConfig, overrides, spec, err := resolveConfig(ctx, kubeClient, operatorConfig)
+		if err != nil {
+			return err
+		}
+
+		var reloaded int32
+		runCtx, cancel := context.WithCancel(ctx)
+		err = start(runCtx, buildInfo, resolvedConfig, overrides, spec, func() {
+			atomic.StoreInt32(&reloaded, 1)
+			cancel()
+		})
+		cancel()
+		if err != nil || atomic.LoadInt32(&reloaded) == 0 {
+			return err
+		}
+		setupLog.Info("Restarting controllers with reloaded configuration")
+	}
+}
+
+// resolveConfig returns the specified configuration of the operator with
+// environment variables overridden by the etc.ConfigMapName ConfigMap, if
+// reloading of the configuration is enabled, and by the OperatorConfig. It
+// also returns the overridden environment variables and the spec of the
+// OperatorConfig, which is nil if there's no valid OperatorConfig.
+func resolveConfig(ctx context.Context, c client.Reader, operatorConfig etc.Config) (etc.Config, map[string]string, *v1alpha1.OperatorConfigSpec, error) {
+	overrides, err := operatorconfig.GetOverrides(ctx, c, operatorConfig)
+	if err != nil {
+		return etc.Config{}, nil, nil, err
+	}
+
+	var spec *v1alpha1.OperatorConfigSpec
+	config, err := operatorconfig.Get(ctx, c, operatorConfig.Namespace)
+	if err != nil {
+		return etc.Config{}, nil, nil, err
+	}
+	if config != nil {
+		// An invalid OperatorConfig is reported in its status by the
+		// OperatorConfigReconciler and ignored until it's fixed.
+		err = operatorconfig.Validate(config.Spec, overrides)
+		if err != nil {
+			setupLog.Error(err, "Ignoring invalid OperatorConfig")
+		} else {
+			setupLog.Info("Applying OperatorConfig", "generation", config.Generation)
+			spec = &config.Spec
+		}
+	}
+
+	overrides = operatorconfig.GetEnv(spec, overrides)
+	resolvedConfig, err := etc.GetOperatorConfigWithOverrides(overrides)
+	if err != nil {
+		return etc.Config{}, nil, nil, fmt.Errorf("getting operator config: %w", err)
+	}
+	return resolvedConfig, overrides, spec, nil
+}
+
+// start starts all registered reconcilers with the specified configuration
+// and blocks until the context is cancelled. If reload is not nil, the
+// configuration is watched and reload is called once it's changed.
+func start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig etc.Config,
+	overrides map[string]string, spec *v1alpha1.OperatorConfigSpec, reload func()) error {
 	installMode, operatorNamespace, targetNamespaces, err := operatorConfig.ResolveInstallMode()
 	if err != nil {
 		return fmt.Errorf("resolving install mode: %w", err)
 	}
+
+	err = operatorConfig.ValidateClusterLabels()
+	if err != nil {
+		return err
+	}
+	metrics.Registry = controller.WithClusterLabels(controller.NewRunRegistry(baseRegistry), operatorConfig)
+	scanJobsNamespace := operatorConfig.GetScanJobsNamespace()
 	setupLog.Info("Resolved install mode", "install mode", installMode,
 		"operator namespace", operatorNamespace,
-		"target namespaces", targetNamespaces)
+		"target namespaces", targetNamespaces,
+		"scan jobs namespace", scanJobsNamespace)
+
+	shardingMode, err := operatorConfig.GetShardingMode()
+	if err != nil {
+		return fmt.Errorf("resolving sharding mode: %w", err)
+	}
+
+	targetNamespaceSelector, excludeNamespaceSelector, err := operatorConfig.GetNamespaceSelectors()
+	if err != nil {
+		return fmt.Errorf("resolving namespace selectors: %w", err)
+	}
+
+	// Ingresses are only read by the operator, e.g. to score the risk of
+	// workloads, whereas the CLI reads them from manifests as unstructured
+	// objects.
+	scheme := starboard.NewScheme()
+	_ = networkingv1.AddToScheme(scheme)
 
 	// Set the default manager options.
 	options := manager.Options{
-		Scheme:                 starboard.NewScheme(),
+		Scheme:                 scheme,
 		MetricsBindAddress:     operatorConfig.MetricsBindAddress,
 		HealthProbeBindAddress: operatorConfig.HealthProbeBindAddress,
+		ClientDisableCacheFor:  controller.ReportsNotCached(),
+	}
+
+	// In dry-run mode writes of the manager's client are sent to the API
+	// server as dry-run requests. The event recorder is set once the manager
+	// is constructed.
+	var dryRunClient *controller.DryRunClient
+	if operatorConfig.DryRun {
+		dryRunClient = &controller.DryRunClient{Logger: ctrl.Log.WithName("dryrun")}
+		options.NewClient = func(cache cache.Cache, config *rest.Config, clientOptions client.Options, uncachedObjects ...client.Object) (client.Client, error) {
+			c, err := cluster.DefaultNewClient(cache, config, clientOptions, uncachedObjects...)
+			if err != nil {
+				return nil, err
+			}
+			dryRunClient.Client = c
+			return dryRunClient, nil
+		}
+		setupLog.Info("Running in dry-run mode")
 	}
 
 	if operatorConfig.LeaderElectionEnabled {
 		options.LeaderElection = operatorConfig.LeaderElectionEnabled
 		options.LeaderElectionID = operatorConfig.LeaderElectionID
 		options.LeaderElectionNamespace = operatorNamespace
+		// Release the lease when reloading the configuration so that the
+		// restarted manager doesn't wait for the lease to expire.
+		options.LeaderElectionReleaseOnCancel = reload != nil
 	}
 
 	switch installMode {
 	case etc.OwnNamespace:
 		// Add support for OwnNamespace set in OPERATOR_NAMESPACE (e.g. `starboard-operator`)
 		// and OPERATOR_TARGET_NAMESPACES (e.g. `starboard-operator`).
+		if scanJobsNamespace != operatorNamespace {
+			cachedNamespaces := []string{operatorNamespace, scanJobsNamespace}
+			setupLog.Info("Constructing client cache", "namespaces", cachedNamespaces)
+			options.NewCache = cache.MultiNamespacedCacheBuilder(cachedNamespaces)
+			break
+		}
 		setupLog.Info("Constructing client cache", "namespace", targetNamespaces[0])
 		options.Namespace = targetNamespaces[0]
 	case etc.SingleNamespace:
 		// Add support for SingleNamespace set in OPERATOR_NAMESPACE (e.g. `starboard-operator`)
 		// and OPERATOR_TARGET_NAMESPACES (e.g. `default`).
 		cachedNamespaces := append(targetNamespaces, operatorNamespace)
+		if scanJobsNamespace != operatorNamespace {
+			cachedNamespaces = append(cachedNamespaces, scanJobsNamespace)
+		}
 		if operatorConfig.CISKubernetesBenchmarkEnabled {
 			// Cache cluster-scoped resources such as Nodes
 			cachedNamespaces = append(cachedNamespaces, "")
@@ -71,6 +233,9 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		// Note that you may face performance issues when using this mode with a high number of namespaces.
 		// More: https://godoc.org/github.com/kubernetes-sigs/controller-runtime/pkg/cache#MultiNamespacedCacheBuilder
 		cachedNamespaces := append(targetNamespaces, operatorNamespace)
+		if scanJobsNamespace != operatorNamespace {
+			cachedNamespaces = append(cachedNamespaces, scanJobsNamespace)
+		}
 		if operatorConfig.CISKubernetesBenchmarkEnabled {
 			// Cache cluster-scoped resources such as Nodes
 			cachedNamespaces = append(cachedNamespaces, "")
@@ -89,6 +254,8 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 	if err != nil {
 		return fmt.Errorf("getting kube client config: %w", err)
 	}
+	kubeConfig.QPS = operatorConfig.KubeClientQPS
+	kubeConfig.Burst = operatorConfig.KubeClientBurst
 
 	// The only reason we're using kubernetes.Clientset is that we need it to read Pod logs,
 	// which is not supported by the client returned by the ctrl.Manager.
@@ -102,6 +269,10 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		return fmt.Errorf("constructing controllers manager: %w", err)
 	}
 
+	if dryRunClient != nil {
+		dryRunClient.EventRecorder = mgr.GetEventRecorderFor("starboard-operator")
+	}
+
 	err = mgr.AddReadyzCheck("ping", healthz.Ping)
 	if err != nil {
 		return err
@@ -112,6 +283,56 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		return err
 	}
 
+	err = mgr.AddReadyzCheck("informers", controller.CacheSyncCheck(mgr.GetCache(), time.Second))
+	if err != nil {
+		return err
+	}
+
+	if operatorConfig.LeaderElectionEnabled {
+		err = mgr.AddHealthzCheck("leader-election", (&controller.LeaderElectionCheck{
+			Client:    mgr.GetAPIReader(),
+			Clock:     ext.NewSystemClock(),
+			Elected:   mgr.Elected(),
+			Namespace: operatorNamespace,
+			Name:      operatorConfig.LeaderElectionID,
+			// The default lease duration of the manager.
+			LeaseDuration: 15 * time.Second,
+		}).Check)
+		if err != nil {
+			return err
+		}
+	}
+
+	var sharder controller.Sharder
+	if shardingMode != etc.ShardingDisabled {
+		namespaceSharder, err := controller.NewNamespaceSharder(ctrl.Log.WithName("sharder"),
+			operatorConfig, ext.NewSystemClock(), kubeClientset)
+		if err != nil {
+			return fmt.Errorf("constructing namespace sharder: %w", err)
+		}
+		err = namespaceSharder.Join(ctx)
+		if err != nil {
+			return fmt.Errorf("joining shards: %w", err)
+		}
+		err = namespaceSharder.SetupWithManager(mgr)
+		if err != nil {
+			return fmt.Errorf("unable to setup namespace sharder: %w", err)
+		}
+		setupLog.Info("Joined shards", "sharding mode", shardingMode, "shard name", operatorConfig.ShardName)
+		sharder = namespaceSharder
+	}
+
+	var namespaceSelector controller.NamespaceSelector
+	if targetNamespaceSelector != nil || excludeNamespaceSelector != nil {
+		labelNamespaceSelector, err := controller.NewLabelNamespaceSelector(operatorConfig, mgr.GetClient())
+		if err != nil {
+			return fmt.Errorf("constructing namespace selector: %w", err)
+		}
+		setupLog.Info("Selecting namespaces by labels", "target namespace selector", operatorConfig.TargetNamespaceSelector,
+			"exclude namespace selector", operatorConfig.ExcludeNamespaceSelector)
+		namespaceSelector = labelNamespaceSelector
+	}
+
 	configManager := starboard.NewConfigManager(kubeClientset, operatorNamespace)
 	err = configManager.EnsureDefault(context.Background())
 	if err != nil {
@@ -122,16 +343,50 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 	if err != nil {
 		return err
 	}
+	starboardConfig = operatorconfig.GetConfigData(spec, starboardConfig)
+
+	err = starboard.ValidateImageRefs(starboardConfig)
+	if err != nil {
+		return fmt.Errorf("validating starboard config: %w", err)
+	}
+	scanJobImages, err := starboardConfig.GetScanJobImages()
+	if err != nil {
+		return fmt.Errorf("getting scan job images: %w", err)
+	}
+	setupLog.Info("Resolved scanner images", "registry", scanJobImages.Registry, "pull secrets", scanJobImages.PullSecrets)
 
 	objectResolver := kube.ObjectResolver{Client: mgr.GetClient()}
 	limitChecker := controller.NewLimitChecker(operatorConfig, mgr.GetClient())
+	quotaChecker := controller.NewQuotaChecker(mgr.GetClient())
+	rateLimiter := controller.NewRateLimiter(operatorConfig)
+	retryPolicy := controller.NewRetryPolicy(ext.NewSystemClock(), operatorConfig)
+	scanHooks, err := controller.NewScanHooks(operatorConfig)
+	if err != nil {
+		return fmt.Errorf("constructing scan hooks: %w", err)
+	}
 	logsReader := kube.NewLogsReader(kubeClientset)
-	secretsReader := kube.NewSecretsReader(mgr.GetClient())
+	credentialsProviders, err := kube.NewCredentialsProviders(mgr.GetClient(), operatorNamespace, starboardConfig)
+	if err != nil {
+		return fmt.Errorf("constructing registry credentials providers: %w", err)
+	}
+	secretsReader := kube.NewSecretsReader(mgr.GetClient(), credentialsProviders...)
 
 	if operatorConfig.VulnerabilityScannerEnabled {
+		err = controller.IndexVulnerabilityReports(ctx, mgr.GetFieldIndexer())
+		if err != nil {
+			return fmt.Errorf("indexing vulnerability reports: %w", err)
+		}
+
+		if operatorConfig.MetricsVulnerabilityReportsEnabled {
+			metrics.Registry.MustRegister(&controller.VulnerabilityReportsCollector{
+				Client: mgr.GetAPIReader(),
+				Config: operatorConfig,
+			})
+		}
+
 		plugin, pluginContext, err := plugin.NewResolver().
 			WithBuildInfo(buildInfo).
-			WithNamespace(operatorNamespace).
+			WithNamespace(scanJobsNamespace).
 			WithServiceAccountName(operatorConfig.ServiceAccount).
 			WithConfig(starboardConfig).
 			WithClient(mgr.GetClient()).
@@ -145,29 +400,192 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
 		}
 
+		err = validatePluginImageRefs(ctx, kubeClientset, pluginContext)
+		if err != nil {
+			return err
+		}
+
+		err = mgr.AddReadyzCheck("vulnerability-plugin-config", controller.PluginConfigCheck(plugin, pluginContext))
+		if err != nil {
+			return err
+		}
+
+		if operatorConfig.RegistryHealthCheckEnabled {
+			if pluginContext.GetName() != trivy.Plugin {
+				return fmt.Errorf("registry health check requires the %s plugin", trivy.Plugin)
+			}
+			err = mgr.AddReadyzCheck("registries", controller.RegistryCheck(pluginContext, 3*time.Second))
+			if err != nil {
+				return err
+			}
+		}
+
+		var scanResultCache vulnerabilityreport.ScanResultCache
+		if operatorConfig.VulnerabilityScannerScanResultCacheTTL != nil {
+			scanResultCache = vulnerabilityreport.NewScanResultCache(ext.NewSystemClock(),
+				*operatorConfig.VulnerabilityScannerScanResultCacheTTL)
+		}
+
+		var history vulnerabilityhistory.ReadWriter
+		if operatorConfig.VulnerabilityHistoryEnabled {
+			history = vulnerabilityhistory.NewReadWriter(mgr.GetClient())
+		}
+
+		namespacePriorities, err := operatorConfig.GetVulnerabilityScannerNamespacePriorities()
+		if err != nil {
+			return fmt.Errorf("getting vulnerability scanner namespace priorities: %w", err)
+		}
+		var rememberScannedFor time.Duration
+		if operatorConfig.VulnerabilityScannerReportTTL != nil {
+			rememberScannedFor = 2 * *operatorConfig.VulnerabilityScannerReportTTL
+		}
+		scanQueue := controller.NewScanQueue(ext.NewSystemClock(), namespacePriorities,
+			3*operatorConfig.ScanJobRetryAfter, rememberScannedFor)
+
+		var nodeAgents *controller.NodeAgents
+		if operatorConfig.NodeAgentEnabled {
+			if pluginContext.GetName() != trivy.Plugin {
+				return fmt.Errorf("node agents require the %s plugin", trivy.Plugin)
+			}
+			nodeAgents, err = controller.NewNodeAgents(operatorConfig, mgr.GetClient())
+			if err != nil {
+				return err
+			}
+		}
+
+		var scannerPool *controller.ScannerPool
+		if operatorConfig.ScannerPoolEnabled {
+			if pluginContext.GetName() != trivy.Plugin {
+				return fmt.Errorf("scanner pool requires the %s plugin", trivy.Plugin)
+			}
+			scannerPool, err = controller.NewScannerPool(operatorConfig, mgr.GetClient())
+			if err != nil {
+				return err
+			}
+		}
+
 		if err = (&controller.VulnerabilityReportReconciler{
-			Logger:         ctrl.Log.WithName("reconciler").WithName("vulnerabilityreport"),
-			Config:         operatorConfig,
-			ConfigData:     starboardConfig,
-			Client:         mgr.GetClient(),
-			ObjectResolver: objectResolver,
-			LimitChecker:   limitChecker,
-			LogsReader:     logsReader,
-			SecretsReader:  secretsReader,
-			Plugin:         plugin,
-			PluginContext:  pluginContext,
-			ReadWriter:     vulnerabilityreport.NewReadWriter(mgr.GetClient()),
+			Logger:             ctrl.Log.WithName("reconciler").WithName("vulnerabilityreport"),
+			Config:             operatorConfig,
+			ConfigData:         starboardConfig,
+			Client:             mgr.GetClient(),
+			ObjectResolver:     objectResolver,
+			LimitChecker:       limitChecker,
+			QuotaChecker:       quotaChecker,
+			RateLimiter:        rateLimiter,
+			RetryPolicy:        retryPolicy,
+			LogsReader:         logsReader,
+			SecretsReader:      secretsReader,
+			Plugin:             plugin,
+			PluginContext:      pluginContext,
+			ReadWriter:         vulnerabilityreport.NewReadWriter(mgr.GetClient()),
+			ScanFailureReports: scanfailurereport.NewReadWriter(mgr.GetClient()),
+			History:            history,
+			ScanResultCache:    scanResultCache,
+			ImageFilter:        vulnerabilityreport.NewImageFilter(operatorConfig.GetVulnerabilityScannerImagePatterns()),
+			ScanQueue:          scanQueue,
+			NodeLimiter:        controller.NewNodeLimiter(operatorConfig, mgr.GetClient()),
+			Sharder:            sharder,
+			NamespaceSelector:  namespaceSelector,
+			Hooks:              scanHooks,
+			NodeAgents:         nodeAgents,
+			ScannerPool:        scannerPool,
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup vulnerabilityreport reconciler: %w", err)
 		}
 
-		if operatorConfig.VulnerabilityScannerReportTTL != nil {
-			if err = (&controller.TTLReportReconciler{
-				Logger: ctrl.Log.WithName("reconciler").WithName("ttlreport"),
-				Config: operatorConfig,
-				Client: mgr.GetClient(),
+		if pluginContext.GetName() == trivy.Plugin {
+			if err = (&controller.TrivyServerReconciler{
+				Logger:     ctrl.Log.WithName("reconciler").WithName("trivyserver"),
+				Config:     operatorConfig,
+				ConfigData: starboardConfig,
+				Client:     mgr.GetClient(),
+				Sharder:    sharder,
+			}).SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup trivyserver reconciler: %w", err)
+			}
+
+			if err = (&controller.TrivyDBCacheReconciler{
+				Logger:     ctrl.Log.WithName("reconciler").WithName("trivydbcache"),
+				Config:     operatorConfig,
+				ConfigData: starboardConfig,
+				Client:     mgr.GetClient(),
+				Sharder:    sharder,
+			}).SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup trivydbcache reconciler: %w", err)
+			}
+		}
+
+		// The TTL of reports might be set by ScanPolicies of namespaces even
+		// if OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL is not set.
+		if err = (&controller.TTLReportReconciler{
+			Logger:  ctrl.Log.WithName("reconciler").WithName("ttlreport"),
+			Config:  operatorConfig,
+			Client:  mgr.GetClient(),
+			Sharder: sharder,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup TTLreport reconciler: %w", err)
+		}
+
+		if operatorConfig.VanishedImageReportsGracePeriod > 0 {
+			if err = (&controller.VanishedImageReportReconciler{
+				Logger:  ctrl.Log.WithName("reconciler").WithName("vanishedimagereport"),
+				Config:  operatorConfig,
+				Client:  mgr.GetClient(),
+				Clock:   ext.NewSystemClock(),
+				Sharder: sharder,
+			}).SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup vanishedimagereport reconciler: %w", err)
+			}
+		}
+
+		if operatorConfig.VulnerabilityHistoryEnabled && operatorConfig.VulnerabilitySLA != "" {
+			sla, err := operatorConfig.GetVulnerabilitySLA()
+			if err != nil {
+				return fmt.Errorf("getting vulnerability SLA: %w", err)
+			}
+			if err = (&controller.VulnerabilitySLAReconciler{
+				Logger:         ctrl.Log.WithName("reconciler").WithName("vulnerabilitysla"),
+				Config:         operatorConfig,
+				Client:         mgr.GetClient(),
+				ObjectResolver: objectResolver,
+				EventRecorder:  mgr.GetEventRecorderFor("starboard-operator"),
+				SLA:            sla,
+				Sharder:        sharder,
 			}).SetupWithManager(mgr); err != nil {
-				return fmt.Errorf("unable to setup TTLreport reconciler: %w", err)
+				return fmt.Errorf("unable to setup vulnerabilitysla reconciler: %w", err)
+			}
+		}
+
+		if operatorConfig.RiskScoreEnabled {
+			priorityTTLs, err := operatorConfig.GetRiskPriorityReportTTLs()
+			if err != nil {
+				return fmt.Errorf("getting risk priority report TTLs: %w", err)
+			}
+			if err = (&controller.RiskScoreReconciler{
+				Logger:         ctrl.Log.WithName("reconciler").WithName("riskscore"),
+				Config:         operatorConfig,
+				Client:         mgr.GetClient(),
+				ObjectResolver: objectResolver,
+				EventRecorder:  mgr.GetEventRecorderFor("starboard-operator"),
+				PriorityTTLs:   priorityTTLs,
+				Sharder:        sharder,
+			}).SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup riskscore reconciler: %w", err)
+			}
+		}
+
+		if operatorConfig.ExposureReportEnabled {
+			if err = (&controller.ExposureReportReconciler{
+				Logger:         ctrl.Log.WithName("reconciler").WithName("exposurereport"),
+				Config:         operatorConfig,
+				ConfigData:     starboardConfig,
+				Client:         mgr.GetClient(),
+				ObjectResolver: objectResolver,
+				Writer:         exposurereport.NewWriter(mgr.GetClient()),
+				Sharder:        sharder,
+			}).SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup exposurereport reconciler: %w", err)
 			}
 		}
 	}
@@ -175,7 +593,7 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 	if operatorConfig.ConfigAuditScannerEnabled {
 		plugin, pluginContext, err := plugin.NewResolver().
 			WithBuildInfo(buildInfo).
-			WithNamespace(operatorNamespace).
+			WithNamespace(scanJobsNamespace).
 			WithServiceAccountName(operatorConfig.ServiceAccount).
 			WithConfig(starboardConfig).
 			WithClient(mgr.GetClient()).
@@ -189,17 +607,34 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
 		}
 
+		err = validatePluginImageRefs(ctx, kubeClientset, pluginContext)
+		if err != nil {
+			return err
+		}
+
+		err = mgr.AddReadyzCheck("config-audit-plugin-config", controller.PluginConfigCheck(plugin, pluginContext))
+		if err != nil {
+			return err
+		}
+
 		if err = (&controller.ConfigAuditReportReconciler{
-			Logger:         ctrl.Log.WithName("reconciler").WithName("configauditreport"),
-			Config:         operatorConfig,
-			ConfigData:     starboardConfig,
-			Client:         mgr.GetClient(),
-			ObjectResolver: objectResolver,
-			LimitChecker:   limitChecker,
-			LogsReader:     logsReader,
-			Plugin:         plugin,
-			PluginContext:  pluginContext,
-			ReadWriter:     configauditreport.NewReadWriter(mgr.GetClient()),
+			Logger:             ctrl.Log.WithName("reconciler").WithName("configauditreport"),
+			Config:             operatorConfig,
+			ConfigData:         starboardConfig,
+			Client:             mgr.GetClient(),
+			ObjectResolver:     objectResolver,
+			LimitChecker:       limitChecker,
+			QuotaChecker:       quotaChecker,
+			RateLimiter:        rateLimiter,
+			RetryPolicy:        retryPolicy,
+			LogsReader:         logsReader,
+			Plugin:             plugin,
+			PluginContext:      pluginContext,
+			ReadWriter:         configauditreport.NewReadWriter(mgr.GetClient()),
+			ScanFailureReports: scanfailurereport.NewReadWriter(mgr.GetClient()),
+			Sharder:            sharder,
+			NamespaceSelector:  namespaceSelector,
+			Hooks:              scanHooks,
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup configauditreport reconciler: %w", err)
 		}
@@ -210,11 +645,25 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			Client:        mgr.GetClient(),
 			Plugin:        plugin,
 			PluginContext: pluginContext,
+			Sharder:       sharder,
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup %T: %w", controller.PluginsConfigReconciler{}, err)
 		}
 	}
 
+	if operatorConfig.RetainsOrphanedReports() {
+		if err = (&controller.OrphanedReportReconciler{
+			Logger:         ctrl.Log.WithName("reconciler").WithName("orphanedreport"),
+			Config:         operatorConfig,
+			Client:         mgr.GetClient(),
+			ObjectResolver: objectResolver,
+			Clock:          ext.NewSystemClock(),
+			Sharder:        sharder,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup orphanedreport reconciler: %w", err)
+		}
+	}
+
 	if operatorConfig.CISKubernetesBenchmarkEnabled {
 		if err = (&controller.CISKubeBenchReportReconciler{
 			Logger:       ctrl.Log.WithName("reconciler").WithName("ciskubebenchreport"),
@@ -223,13 +672,178 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			Client:       mgr.GetClient(),
 			LogsReader:   logsReader,
 			LimitChecker: limitChecker,
+			QuotaChecker: quotaChecker,
+			RateLimiter:  rateLimiter,
 			ReadWriter:   kubebench.NewReadWriter(mgr.GetClient()),
 			Plugin:       kubebench.NewKubeBenchPlugin(ext.NewSystemClock(), starboardConfig),
+			Sharder:      sharder,
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup ciskubebenchreport reconciler: %w", err)
 		}
 	}
 
+	if operatorConfig.ImageSignatureVerifierEnabled {
+		pluginContext := starboard.NewPluginContext().
+			WithName(imagesignature.CosignPlugin).
+			WithNamespace(scanJobsNamespace).
+			WithServiceAccountName(operatorConfig.ServiceAccount).
+			WithClient(mgr.GetClient()).
+			Get()
+
+		if err = (&controller.ImageSignatureReportReconciler{
+			Logger:            ctrl.Log.WithName("reconciler").WithName("imagesignaturereport"),
+			Config:            operatorConfig,
+			ConfigData:        starboardConfig,
+			Client:            mgr.GetClient(),
+			ObjectResolver:    objectResolver,
+			LimitChecker:      limitChecker,
+			QuotaChecker:      quotaChecker,
+			RateLimiter:       rateLimiter,
+			LogsReader:        logsReader,
+			SecretsReader:     secretsReader,
+			Plugin:            imagesignature.NewCosignPlugin(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator(), starboardConfig),
+			PluginContext:     pluginContext,
+			ReadWriter:        imagesignature.NewReadWriter(mgr.GetClient()),
+			Sharder:           sharder,
+			NamespaceSelector: namespaceSelector,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup imagesignaturereport reconciler: %w", err)
+		}
+	}
+
+	if operatorConfig.NetworkPolicyCoverageEnabled {
+		if err = (&controller.NetworkPolicyCoverageReconciler{
+			Logger:            ctrl.Log.WithName("reconciler").WithName("networkpolicycoverage"),
+			Config:            operatorConfig,
+			ConfigData:        starboardConfig,
+			Client:            mgr.GetClient(),
+			ObjectResolver:    objectResolver,
+			Writer:            networkpolicyreport.NewWriter(mgr.GetClient()),
+			Sharder:           sharder,
+			NamespaceSelector: namespaceSelector,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup networkpolicycoverage reconciler: %w", err)
+		}
+	}
+
+	hubReplicationMode, err := operatorConfig.GetHubReplicationMode()
+	if err != nil {
+		return err
+	}
+	if hubReplicationMode != etc.HubReplicationDisabled {
+		hubConfig, err := clientcmd.BuildConfigFromFlags("", operatorConfig.HubKubeconfig)
+		if err != nil {
+			return fmt.Errorf("loading kubeconfig of hub cluster: %w", err)
+		}
+		hubClient, err := client.New(hubConfig, client.Options{Scheme: starboard.NewScheme()})
+		if err != nil {
+			return fmt.Errorf("constructing client of hub cluster: %w", err)
+		}
+		if dryRunClient != nil {
+			hubClient = &controller.DryRunClient{Client: hubClient, Logger: ctrl.Log.WithName("dryrun").WithName("hub")}
+		}
+		if err = (&controller.HubReplicator{
+			Logger:  ctrl.Log.WithName("replicator").WithName("hub"),
+			Config:  operatorConfig,
+			Client:  mgr.GetClient(),
+			Hub:     hubClient,
+			Mode:    hubReplicationMode,
+			Sharder: sharder,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup hub replicator: %w", err)
+		}
+	}
+
+	if operatorConfig.ReportExportWebhookURL != "" {
+		if err = (&controller.ReportExporter{
+			Logger:   ctrl.Log.WithName("exporter").WithName("report"),
+			Config:   operatorConfig,
+			Client:   mgr.GetClient(),
+			Exporter: export.NewWebhookExporter(operatorConfig.ReportExportWebhookURL, operatorConfig.ReportExportTimeout),
+			Sharder:  sharder,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup report exporter: %w", err)
+		}
+	}
+
+	if operatorConfig.ReportsAPIBindAddress != "" {
+		err = mgr.Add(&reportapi.Server{
+			Addr:     operatorConfig.ReportsAPIBindAddress,
+			CertFile: operatorConfig.ReportsAPITLSCertFile,
+			KeyFile:  operatorConfig.ReportsAPITLSKeyFile,
+			Handler: reportapi.NewHandler(ctrl.Log.WithName("reportapi"), mgr.GetClient(),
+				reportapi.NewKubeAuth(kubeClientset)),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to setup reports API: %w", err)
+		}
+	}
+
+	if operatorConfig.SummaryAPIBindAddress != "" {
+		requestHeader, err := summaryapi.GetRequestHeaderConfig(ctx, kubeClientset)
+		if err != nil {
+			return fmt.Errorf("unable to setup summary API: %w", err)
+		}
+		err = mgr.Add(&summaryapi.Server{
+			Addr:          operatorConfig.SummaryAPIBindAddress,
+			CertFile:      operatorConfig.SummaryAPITLSCertFile,
+			KeyFile:       operatorConfig.SummaryAPITLSKeyFile,
+			RequestHeader: requestHeader,
+			Handler: summaryapi.NewHandler(ctrl.Log.WithName("summaryapi"),
+				summaryapi.NewSummarizer(mgr.GetCache(), mgr.GetAPIReader()),
+				summaryapi.NewKubeAuth(kubeClientset, requestHeader.AllowedNames),
+				ext.NewSystemClock()),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to setup summary API: %w", err)
+		}
+	}
+
+	if operatorConfig.ValidatingWebhookBindAddress != "" {
+		err = mgr.Add(&admission.Server{
+			Addr:     operatorConfig.ValidatingWebhookBindAddress,
+			CertFile: operatorConfig.ValidatingWebhookTLSCertFile,
+			KeyFile:  operatorConfig.ValidatingWebhookTLSKeyFile,
+			Handler: admission.NewHandler(ctrl.Log.WithName("admission"),
+				admission.NewValidator(mgr.GetClient(), operatorConfig)),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to setup validating webhook: %w", err)
+		}
+	}
+
+	if err = (&controller.OperatorConfigReconciler{
+		Logger:  ctrl.Log.WithName("reconciler").WithName("operatorconfig"),
+		Config:  operatorConfig,
+		Client:  mgr.GetClient(),
+		Sharder: sharder,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to setup operatorconfig reconciler: %w", err)
+	}
+
+	if reload != nil {
+		if err = (&controller.ConfigReloader{
+			Logger:     ctrl.Log.WithName("reloader").WithName("config"),
+			Config:     operatorConfig,
+			Client:     mgr.GetClient(),
+			ConfigData: starboardConfig,
+			Overrides:  overrides,
+			Reload:     reload,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup config reloader: %w", err)
+		}
+	}
+
+	if err = (&controller.ScanJobsResumer{
+		Logger:         ctrl.Log.WithName("resumer").WithName("scanjobs"),
+		Config:         operatorConfig,
+		Client:         mgr.GetClient(),
+		ObjectResolver: objectResolver,
+		Sharder:        sharder,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to setup scan jobs resumer: %w", err)
+	}
+
 	setupLog.Info("Starting controllers manager")
 	if err := mgr.Start(ctx); err != nil {
 		return fmt.Errorf("starting controllers manager: %w", err)
@@ -237,3 +851,22 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 
 	return nil
 }
+
+// validatePluginImageRefs checks image references of scanners set in the
+// ConfigMap of the specified plugin. The ConfigMap is read from the API server
+// because the cache of the manager is not started yet.
+func validatePluginImageRefs(ctx context.Context, clientset kubernetes.Interface, pluginContext starboard.PluginContext) error {
+	cm, err := clientset.CoreV1().ConfigMaps(pluginContext.GetNamespace()).
+		Get(ctx, starboard.GetPluginConfigMapName(pluginContext.GetName()), metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("getting %s plugin config: %w", pluginContext.GetName(), err)
+	}
+	err = starboard.ValidateImageRefs(cm.Data)
+	if err != nil {
+		return fmt.Errorf("validating %s plugin config: %w", pluginContext.GetName(), err)
+	}
+	return nil
+}
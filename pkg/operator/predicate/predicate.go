@@ -1,6 +1,8 @@
 package predicate
 
 import (
+	"strconv"
+
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
 	"github.com/aquasecurity/starboard/pkg/starboard"
@@ -44,6 +46,65 @@ var InstallModePredicate = func(config etc.Config) (predicate.Predicate, error)
 	}), nil
 }
 
+// Shard represents the subset of namespaces reconciled by a replica of the
+// operator. Cluster-scoped objects belong to the empty namespace.
+type Shard interface {
+	Owns(namespace string) bool
+}
+
+// InShard is a predicate.Predicate that returns true if the namespace of the
+// specified client.Object is owned by the given Shard, or if the Shard is nil.
+var InShard = func(shard Shard) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return shard == nil || shard.Owns(obj.GetNamespace())
+	})
+}
+
+// ScanJobInShard is a predicate.Predicate that returns true if the namespace
+// of the object scanned by the specified scan job is owned by the given Shard,
+// or if the Shard is nil.
+var ScanJobInShard = func(shard Shard) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return shard == nil || shard.Owns(obj.GetLabels()[starboard.LabelResourceNamespace])
+	})
+}
+
+// NamespaceSelection represents the namespaces whose workloads are scanned,
+// which are selected by labels of namespaces. Cluster-scoped objects belong to
+// the empty namespace, which is always selected.
+type NamespaceSelection interface {
+	Selects(namespace string) bool
+}
+
+// InSelectedNamespace is a predicate.Predicate that returns true if the
+// namespace of the specified client.Object is selected by the given
+// NamespaceSelection, or if the NamespaceSelection is nil.
+var InSelectedNamespace = func(selection NamespaceSelection) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return selection == nil || selection.Selects(obj.GetNamespace())
+	})
+}
+
+// IsScanEnabled checks whether the specified client.Object is scanned and
+// audited according to its starboard.AnnotationScan annotation. Objects
+// without the annotation, or with an invalid value, are scanned unless the
+// opt-in mode is enabled.
+func IsScanEnabled(obj client.Object, optIn bool) bool {
+	enabled, err := strconv.ParseBool(obj.GetAnnotations()[starboard.AnnotationScan])
+	if err != nil {
+		return !optIn
+	}
+	return enabled
+}
+
+// ScanEnabled is a predicate.Predicate that returns true if the specified
+// client.Object is scanned and audited. See IsScanEnabled.
+var ScanEnabled = func(optIn bool) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return IsScanEnabled(obj, optIn)
+	})
+}
+
 // HasName is predicate.Predicate that returns true if the
 // specified client.Object has the desired name.
 var HasName = func(name string) predicate.Predicate {
@@ -87,6 +148,19 @@ var JobHasAnyCondition = predicate.NewPredicateFuncs(func(obj client.Object) boo
 	return false
 })
 
+// JobHasFailedCondition is a predicate.Predicate that returns true if the
+// specified client.Object is a v1.Job with the v1.JobFailed condition.
+var JobHasFailedCondition = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	if job, ok := obj.(*batchv1.Job); ok {
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+				return true
+			}
+		}
+	}
+	return false
+})
+
 var IsVulnerabilityReportScan = predicate.NewPredicateFuncs(func(obj client.Object) bool {
 	if _, ok := obj.GetLabels()[starboard.LabelVulnerabilityReportScanner]; ok {
 		return true
@@ -108,6 +182,13 @@ var IsKubeBenchReportScan = predicate.NewPredicateFuncs(func(obj client.Object)
 	return false
 })
 
+var IsImageSignatureReportScan = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	if _, ok := obj.GetLabels()[starboard.LabelImageSignatureReportScanner]; ok {
+		return true
+	}
+	return false
+})
+
 var IsLinuxNode = predicate.NewPredicateFuncs(func(obj client.Object) bool {
 	if os, exists := obj.GetLabels()[corev1.LabelOSStable]; exists && os == "linux" {
 		return true
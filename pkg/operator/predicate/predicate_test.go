@@ -8,6 +8,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
 	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -175,6 +176,97 @@ var _ = Describe("Predicate", func() {
 		})
 	})
 
+	Describe("When checking a InShard predicate", func() {
+		shard := fakeShard{"prod": true}
+
+		Context("When object is in owned namespace", func() {
+			It("Should return true", func() {
+				instance := predicate.InShard(shard)
+				obj := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "prod",
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeTrue())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeTrue())
+			})
+		})
+
+		Context("When object is not in owned namespace", func() {
+			It("Should return false", func() {
+				instance := predicate.InShard(shard)
+				obj := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "staging",
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeFalse())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeFalse())
+			})
+		})
+
+		Context("When shard is nil", func() {
+			It("Should return true", func() {
+				instance := predicate.InShard(nil)
+				obj := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "staging",
+					},
+				}
+
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("When checking a ScanJobInShard predicate", func() {
+		shard := fakeShard{"prod": true}
+
+		Context("When scanned object is in owned namespace", func() {
+			It("Should return true", func() {
+				instance := predicate.ScanJobInShard(shard)
+				obj := &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "starboard-operator",
+						Labels: map[string]string{
+							"starboard.resource.namespace": "prod",
+						},
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeTrue())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeTrue())
+			})
+		})
+
+		Context("When scanned object is cluster-scoped and cluster is not owned", func() {
+			It("Should return false", func() {
+				instance := predicate.ScanJobInShard(shard)
+				obj := &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "starboard-operator",
+						Labels: map[string]string{
+							"starboard.resource.namespace": "",
+						},
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeFalse())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("When checking a ManagedByStarboardOperator predicate", func() {
 		instance := predicate.ManagedByStarboardOperator
 
@@ -299,6 +391,85 @@ var _ = Describe("Predicate", func() {
 		})
 	})
 
+	Describe("When checking a JobHasFailedCondition predicate", func() {
+		instance := predicate.JobHasFailedCondition
+		Context("Where job has failed condition", func() {
+			It("Should return true", func() {
+				obj := &batchv1.Job{
+					Status: batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{
+							{
+								Type:   batchv1.JobFailed,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeTrue())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeTrue())
+			})
+		})
+		Context("Where job has complete condition", func() {
+			It("Should return false", func() {
+				obj := &batchv1.Job{
+					Status: batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{
+							{
+								Type:   batchv1.JobComplete,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeFalse())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("When checking a ScanEnabled predicate", func() {
+		newPod := func(annotations map[string]string) *corev1.Pod {
+			return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Annotations: annotations}}
+		}
+
+		Context("Where opt-in mode is disabled", func() {
+			instance := predicate.ScanEnabled(false)
+
+			It("Should return true for object without annotation", func() {
+				Expect(instance.Generic(event.GenericEvent{Object: newPod(nil)})).To(BeTrue())
+			})
+
+			It("Should return false for object opted out", func() {
+				obj := newPod(map[string]string{starboard.AnnotationScan: "false"})
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeFalse())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeFalse())
+			})
+		})
+
+		Context("Where opt-in mode is enabled", func() {
+			instance := predicate.ScanEnabled(true)
+
+			It("Should return false for object without annotation", func() {
+				Expect(instance.Generic(event.GenericEvent{Object: newPod(nil)})).To(BeFalse())
+				Expect(instance.Generic(event.GenericEvent{Object: newPod(map[string]string{starboard.AnnotationScan: "yes please"})})).To(BeFalse())
+			})
+
+			It("Should return true for object opted in", func() {
+				obj := newPod(map[string]string{starboard.AnnotationScan: "true"})
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeTrue())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("When checking a Not predicate", func() {
 		Context("Where input predicate returns true", func() {
 			It("Should return false", func() {
@@ -314,3 +485,9 @@ var _ = Describe("Predicate", func() {
 		})
 	})
 })
+
+type fakeShard map[string]bool
+
+func (s fakeShard) Owns(namespace string) bool {
+	return s[namespace]
+}
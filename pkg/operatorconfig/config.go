@@ -0,0 +1,196 @@
+package operatorconfig
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Keys of the starboard ConfigMap that are overridden by the spec.
+const (
+	keyVulnerabilityReportsScanner = "vulnerabilityReports.scanner"
+	keyConfigAuditReportsScanner   = "configAuditReports.scanner"
+)
+
+// GetEnv returns the environment variables of the operator that are
+// overridden by the specified spec, merged with the specified overrides, e.g.
+// keys of the etc.ConfigMapName ConfigMap. Settings of the spec take
+// precedence. The spec may be nil, in which case the overrides are returned.
+func GetEnv(spec *v1alpha1.OperatorConfigSpec, overrides map[string]string) map[string]string {
+	env := make(map[string]string)
+	for key, value := range overrides {
+		env[key] = value
+	}
+	if spec == nil {
+		return env
+	}
+	if scanner := spec.VulnerabilityScanner; scanner != nil && scanner.Enabled != nil {
+		env["OPERATOR_VULNERABILITY_SCANNER_ENABLED"] = strconv.FormatBool(*scanner.Enabled)
+	}
+	if scanner := spec.ConfigAuditScanner; scanner != nil && scanner.Enabled != nil {
+		env["OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED"] = strconv.FormatBool(*scanner.Enabled)
+	}
+	if spec.VulnerabilityReportTTL != nil {
+		env["OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL"] = spec.VulnerabilityReportTTL.Duration.String()
+	}
+	if spec.ScanJobTimeout != nil {
+		env["OPERATOR_SCAN_JOB_TIMEOUT"] = spec.ScanJobTimeout.Duration.String()
+	}
+	if spec.ConcurrentScanJobsLimit != nil {
+		env["OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT"] = strconv.Itoa(*spec.ConcurrentScanJobsLimit)
+	}
+	if namespaces := spec.Namespaces; namespaces != nil {
+		if len(namespaces.Targets) > 0 {
+			env["OPERATOR_TARGET_NAMESPACES"] = strings.Join(namespaces.Targets, ",")
+		}
+		if namespaces.Selector != "" {
+			env["OPERATOR_TARGET_NAMESPACE_SELECTOR"] = namespaces.Selector
+		}
+		if namespaces.ExcludeSelector != "" {
+			env["OPERATOR_EXCLUDE_NAMESPACE_SELECTOR"] = namespaces.ExcludeSelector
+		}
+		if namespaces.ScanJobs != "" {
+			env["OPERATOR_SCAN_JOBS_NAMESPACE"] = namespaces.ScanJobs
+		}
+	}
+	return env
+}
+
+// GetConfigData returns the specified data of the starboard ConfigMap with
+// keys that are overridden by the specified spec, which may be nil.
+func GetConfigData(spec *v1alpha1.OperatorConfigSpec, data starboard.ConfigData) starboard.ConfigData {
+	overridden := make(starboard.ConfigData)
+	for key, value := range data {
+		overridden[key] = value
+	}
+	if spec == nil {
+		return overridden
+	}
+	if scanner := spec.VulnerabilityScanner; scanner != nil && scanner.Plugin != "" {
+		overridden[keyVulnerabilityReportsScanner] = scanner.Plugin
+	}
+	if scanner := spec.ConfigAuditScanner; scanner != nil && scanner.Plugin != "" {
+		overridden[keyConfigAuditReportsScanner] = scanner.Plugin
+	}
+	return overridden
+}
+
+// Validate checks whether the operator can be started with the specified
+// spec, whose settings override environment variables of the operator and the
+// specified overrides.
+func Validate(spec v1alpha1.OperatorConfigSpec, overrides map[string]string) error {
+	config, err := etc.GetOperatorConfigWithOverrides(GetEnv(&spec, overrides))
+	if err != nil {
+		return err
+	}
+	_, _, _, err = config.ResolveInstallMode()
+	if err != nil {
+		return err
+	}
+	_, _, err = config.GetNamespaceSelectors()
+	if err != nil {
+		return err
+	}
+	if spec.ConcurrentScanJobsLimit != nil && *spec.ConcurrentScanJobsLimit < 1 {
+		return fmt.Errorf("invalid value (%d) of concurrentScanJobsLimit; expected a positive number", *spec.ConcurrentScanJobsLimit)
+	}
+
+	data := GetConfigData(&spec, starboard.ConfigData{})
+	if scanner := spec.VulnerabilityScanner; scanner != nil && scanner.Plugin != "" {
+		if _, err := data.GetVulnerabilityReportsScanner(); err != nil {
+			return err
+		}
+	}
+	if scanner := spec.ConfigAuditScanner; scanner != nil && scanner.Plugin != "" {
+		if _, err := data.GetConfigAuditReportsScanner(); err != nil {
+			return err
+		}
+	}
+
+	var plugins []string
+	for plugin := range spec.Plugins {
+		plugins = append(plugins, plugin)
+	}
+	sort.Strings(plugins)
+	for _, plugin := range plugins {
+		if err := starboard.ValidateImageRefs(spec.Plugins[plugin]); err != nil {
+			return fmt.Errorf("validating settings of %s plugin: %w", plugin, err)
+		}
+	}
+	return nil
+}
+
+// FromConfig converts the specified configuration of the operator, i.e. its
+// environment variables and the data of the starboard ConfigMap, and the
+// settings of plugins by the name of the plugin, to the OperatorConfig that
+// configures the operator in the same way.
+func FromConfig(config etc.Config, data starboard.ConfigData, plugins map[string]map[string]string) v1alpha1.OperatorConfig {
+	vulnerabilityScannerEnabled := config.VulnerabilityScannerEnabled
+	configAuditScannerEnabled := config.ConfigAuditScannerEnabled
+	concurrentScanJobsLimit := config.ConcurrentScanJobsLimit
+
+	spec := v1alpha1.OperatorConfigSpec{
+		VulnerabilityScanner: &v1alpha1.OperatorConfigScanner{
+			Enabled: &vulnerabilityScannerEnabled,
+			Plugin:  data[keyVulnerabilityReportsScanner],
+		},
+		ConfigAuditScanner: &v1alpha1.OperatorConfigScanner{
+			Enabled: &configAuditScannerEnabled,
+			Plugin:  data[keyConfigAuditReportsScanner],
+		},
+		ScanJobTimeout:          &metav1.Duration{Duration: config.ScanJobTimeout},
+		ConcurrentScanJobsLimit: &concurrentScanJobsLimit,
+		Plugins:                 plugins,
+	}
+	if config.VulnerabilityScannerReportTTL != nil {
+		spec.VulnerabilityReportTTL = &metav1.Duration{Duration: *config.VulnerabilityScannerReportTTL}
+	}
+	namespaces := v1alpha1.OperatorConfigNamespaces{
+		Selector:        config.TargetNamespaceSelector,
+		ExcludeSelector: config.ExcludeNamespaceSelector,
+		ScanJobs:        config.ScanJobsNamespace,
+	}
+	if targets := config.GetTargetNamespaces(); len(targets) > 0 {
+		namespaces.Targets = targets
+	}
+	if len(namespaces.Targets) > 0 || namespaces.Selector != "" || namespaces.ExcludeSelector != "" || namespaces.ScanJobs != "" {
+		spec.Namespaces = &namespaces
+	}
+
+	return v1alpha1.OperatorConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.OperatorConfigKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v1alpha1.OperatorConfigName,
+			Namespace: config.Namespace,
+		},
+		Spec: spec,
+	}
+}
+
+// GetContainerEnv returns environment variables of the specified container
+// of the operator running in the specified namespace. Values from Secrets or
+// ConfigMaps are skipped, except for the namespace of the operator, which is
+// usually set with the downward API.
+func GetContainerEnv(container corev1.Container, namespace string) map[string]string {
+	env := make(map[string]string)
+	for _, e := range container.Env {
+		if e.ValueFrom == nil {
+			env[e.Name] = e.Value
+			continue
+		}
+		if ref := e.ValueFrom.FieldRef; ref != nil && ref.FieldPath == "metadata.namespace" {
+			env[e.Name] = namespace
+		}
+	}
+	return env
+}
@@ -0,0 +1,201 @@
+package operatorconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operatorconfig"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestGetEnv(t *testing.T) {
+	spec := &v1alpha1.OperatorConfigSpec{
+		VulnerabilityScanner:    &v1alpha1.OperatorConfigScanner{Enabled: pointer.BoolPtr(false)},
+		VulnerabilityReportTTL:  &metav1.Duration{Duration: 72 * time.Hour},
+		ConcurrentScanJobsLimit: pointer.IntPtr(5),
+		Namespaces: &v1alpha1.OperatorConfigNamespaces{
+			Targets:  []string{"prod", "staging"},
+			ScanJobs: "starboard-scans",
+		},
+	}
+	overrides := map[string]string{
+		"OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT": "20",
+		"OPERATOR_LOG_DEV_MODE":               "true",
+	}
+
+	assert.Equal(t, map[string]string{
+		"OPERATOR_VULNERABILITY_SCANNER_ENABLED":    "false",
+		"OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL": "72h0m0s",
+		"OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT":       "5",
+		"OPERATOR_TARGET_NAMESPACES":                "prod,staging",
+		"OPERATOR_SCAN_JOBS_NAMESPACE":              "starboard-scans",
+		"OPERATOR_LOG_DEV_MODE":                     "true",
+	}, operatorconfig.GetEnv(spec, overrides))
+	assert.Equal(t, overrides, operatorconfig.GetEnv(nil, overrides))
+}
+
+func TestGetConfigData(t *testing.T) {
+	data := starboard.ConfigData{
+		"vulnerabilityReports.scanner": "Trivy",
+		"configAuditReports.scanner":   "Polaris",
+	}
+	spec := &v1alpha1.OperatorConfigSpec{
+		ConfigAuditScanner: &v1alpha1.OperatorConfigScanner{Plugin: "Conftest"},
+	}
+
+	assert.Equal(t, starboard.ConfigData{
+		"vulnerabilityReports.scanner": "Trivy",
+		"configAuditReports.scanner":   "Conftest",
+	}, operatorconfig.GetConfigData(spec, data))
+	assert.Equal(t, "Polaris", data["configAuditReports.scanner"])
+}
+
+func TestValidate(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "starboard-system")
+
+	testCases := []struct {
+		name          string
+		spec          v1alpha1.OperatorConfigSpec
+		overrides     map[string]string
+		expectedError string
+	}{
+		{
+			name: "Should accept valid spec",
+			spec: v1alpha1.OperatorConfigSpec{
+				VulnerabilityScanner:    &v1alpha1.OperatorConfigScanner{Plugin: "Trivy"},
+				ConcurrentScanJobsLimit: pointer.IntPtr(5),
+				Plugins: map[string]map[string]string{
+					"Trivy": {"trivy.imageRef": "docker.io/aquasec/trivy:0.25.2"},
+				},
+			},
+		},
+		{
+			name: "Should reject unknown vulnerability scanner",
+			spec: v1alpha1.OperatorConfigSpec{
+				VulnerabilityScanner: &v1alpha1.OperatorConfigScanner{Plugin: "Clair"},
+			},
+			expectedError: "invalid value (Clair) of vulnerabilityReports.scanner; allowed values (Trivy, Aqua, Harbor, Quay, External)",
+		},
+		{
+			name: "Should reject unknown config audit scanner",
+			spec: v1alpha1.OperatorConfigSpec{
+				ConfigAuditScanner: &v1alpha1.OperatorConfigScanner{Plugin: "Trivy"},
+			},
+			expectedError: "invalid value (Trivy) of configAuditReports.scanner; allowed values (Polaris, Conftest)",
+		},
+		{
+			name: "Should reject non-positive concurrent scan jobs limit",
+			spec: v1alpha1.OperatorConfigSpec{
+				ConcurrentScanJobsLimit: pointer.IntPtr(0),
+			},
+			expectedError: "invalid value (0) of concurrentScanJobsLimit; expected a positive number",
+		},
+		{
+			name: "Should reject invalid namespace selector",
+			spec: v1alpha1.OperatorConfigSpec{
+				Namespaces: &v1alpha1.OperatorConfigNamespaces{Selector: "env in (prod"},
+			},
+			expectedError: "parsing OPERATOR_TARGET_NAMESPACE_SELECTOR: unable to parse requirement: found '', expected: ',' or ')'",
+		},
+		{
+			name: "Should reject invalid image reference of plugin",
+			spec: v1alpha1.OperatorConfigSpec{
+				Plugins: map[string]map[string]string{
+					"Trivy": {"trivy.imageRef": "docker.io/aquasec/trivy:0.25.2:latest"},
+				},
+			},
+			expectedError: "validating settings of Trivy plugin: invalid value (docker.io/aquasec/trivy:0.25.2:latest) of trivy.imageRef: could not parse reference: docker.io/aquasec/trivy:0.25.2:latest",
+		},
+		{
+			name: "Should reject invalid overrides",
+			spec: v1alpha1.OperatorConfigSpec{},
+			overrides: map[string]string{
+				"OPERATOR_NAMESPACE": "default",
+			},
+			expectedError: "OPERATOR_NAMESPACE cannot be set in starboard-operator ConfigMap",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := operatorconfig.Validate(tc.spec, tc.overrides)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "starboard-system")
+	t.Setenv("OPERATOR_TARGET_NAMESPACES", "prod,staging")
+	t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+	t.Setenv("OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL", "24h")
+	t.Setenv("OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT", "3")
+
+	config, err := etc.GetOperatorConfig()
+	require.NoError(t, err)
+	data := starboard.ConfigData{
+		"vulnerabilityReports.scanner": "Trivy",
+		"configAuditReports.scanner":   "Polaris",
+	}
+	plugins := map[string]map[string]string{
+		"Trivy": {"trivy.severity": "CRITICAL,HIGH"},
+	}
+
+	operatorConfig := operatorconfig.FromConfig(config, data, plugins)
+	assert.Equal(t, v1alpha1.OperatorConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "aquasecurity.github.io/v1alpha1",
+			Kind:       "OperatorConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "starboard",
+			Namespace: "starboard-system",
+		},
+		Spec: v1alpha1.OperatorConfigSpec{
+			VulnerabilityScanner:    &v1alpha1.OperatorConfigScanner{Enabled: pointer.BoolPtr(true), Plugin: "Trivy"},
+			ConfigAuditScanner:      &v1alpha1.OperatorConfigScanner{Enabled: pointer.BoolPtr(false), Plugin: "Polaris"},
+			VulnerabilityReportTTL:  &metav1.Duration{Duration: 24 * time.Hour},
+			ScanJobTimeout:          &metav1.Duration{Duration: 5 * time.Minute},
+			ConcurrentScanJobsLimit: pointer.IntPtr(3),
+			Namespaces:              &v1alpha1.OperatorConfigNamespaces{Targets: []string{"prod", "staging"}},
+			Plugins:                 plugins,
+		},
+	}, operatorConfig)
+
+	t.Run("Should convert back to the same configuration", func(t *testing.T) {
+		converted, err := etc.GetOperatorConfigWithOverrides(operatorconfig.GetEnv(&operatorConfig.Spec, nil))
+		require.NoError(t, err)
+		assert.Equal(t, config, converted)
+		assert.Equal(t, data, operatorconfig.GetConfigData(&operatorConfig.Spec, starboard.ConfigData{}))
+	})
+}
+
+func TestGetContainerEnv(t *testing.T) {
+	container := corev1.Container{
+		Env: []corev1.EnvVar{
+			{Name: "OPERATOR_NAMESPACE", ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+			}},
+			{Name: "OPERATOR_TARGET_NAMESPACES", Value: "prod"},
+			{Name: "OPERATOR_WEBHOOK_BROADCAST_URL", ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{Key: "url"},
+			}},
+		},
+	}
+
+	assert.Equal(t, map[string]string{
+		"OPERATOR_NAMESPACE":         "starboard-system",
+		"OPERATOR_TARGET_NAMESPACES": "prod",
+	}, operatorconfig.GetContainerEnv(container, "starboard-system"))
+}
@@ -0,0 +1,4 @@
+// Package operatorconfig provides primitives for configuring the operator
+// with the OperatorConfig resource, i.e. for converting it to and from
+// environment variables of the operator and keys of its ConfigMaps.
+package operatorconfig
@@ -0,0 +1,48 @@
+package operatorconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Get returns the OperatorConfig that configures the operator running in the
+// specified namespace, or nil if there's no such OperatorConfig or the
+// OperatorConfig CRD is not installed.
+func Get(ctx context.Context, c client.Reader, namespace string) (*v1alpha1.OperatorConfig, error) {
+	var config v1alpha1.OperatorConfig
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: v1alpha1.OperatorConfigName}, &config)
+	if err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting operator config: %w", err)
+	}
+	return &config, nil
+}
+
+// GetOverrides returns the keys of the etc.ConfigMapName ConfigMap that
+// override environment variables of the operator with the specified
+// configuration, or nil if the ConfigMap is not read because
+// OPERATOR_CONFIG_RELOAD_ENABLED is false or doesn't exist.
+func GetOverrides(ctx context.Context, c client.Reader, config etc.Config) (map[string]string, error) {
+	if !config.ConfigReloadEnabled {
+		return nil, nil
+	}
+	var cm corev1.ConfigMap
+	err := c.Get(ctx, types.NamespacedName{Namespace: config.Namespace, Name: etc.ConfigMapName}, &cm)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting %s ConfigMap: %w", etc.ConfigMapName, err)
+	}
+	return cm.Data, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -160,6 +161,61 @@ func (c Config) setResourceLimit(configKey string, k8sResourceList *corev1.Resou
 	return nil
 }
 
+// Validate returns problems with configuration settings, e.g. policies
+// without kinds or with syntax errors, or nil if the settings are valid.
+func (c Config) Validate() []error {
+	var errs []error
+	if _, err := c.GetImageRef(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := c.GetResourceRequirements(); err != nil {
+		errs = append(errs, err)
+	}
+	var keys []string
+	for key := range c.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	policies := 0
+	for _, key := range keys {
+		value := c.Data[key]
+		switch {
+		case strings.HasPrefix(key, keyPrefixPolicy) && strings.HasSuffix(key, keySuffixRego):
+			policies++
+			kindsKey := strings.TrimSuffix(key, keySuffixRego) + keySuffixKinds
+			if _, ok := c.Data[kindsKey]; !ok {
+				errs = append(errs, fmt.Errorf("kinds not defined for policy: %s; set %s", key, kindsKey))
+			}
+			if err := checkRegoSyntax(value); err != nil {
+				errs = append(errs, fmt.Errorf("policy %s: %w", key, err))
+			}
+		case strings.HasPrefix(key, keyPrefixPolicy) && strings.HasSuffix(key, keySuffixKinds):
+			policyKey := strings.TrimSuffix(key, keySuffixKinds) + keySuffixRego
+			if _, ok := c.Data[policyKey]; !ok {
+				errs = append(errs, fmt.Errorf("expected policy not found: %s", policyKey))
+			}
+			for _, kind := range strings.Split(value, ",") {
+				if strings.TrimSpace(kind) == "" {
+					errs = append(errs, fmt.Errorf("invalid value (%s) of %s; expected comma-separated kinds, %s, or %s",
+						value, key, kindWorkload, kindAny))
+					break
+				}
+			}
+		case strings.HasPrefix(key, keyPrefixLibrary) && strings.HasSuffix(key, keySuffixRego):
+			if err := checkRegoSyntax(value); err != nil {
+				errs = append(errs, fmt.Errorf("library %s: %w", key, err))
+			}
+		case strings.HasPrefix(key, keyPrefixPolicy), strings.HasPrefix(key, keyPrefixLibrary):
+			errs = append(errs, fmt.Errorf("unexpected key %s; keys of policies must end with %s or %s, and keys of libraries with %s",
+				key, keySuffixRego, keySuffixKinds, keySuffixRego))
+		}
+	}
+	if policies == 0 {
+		errs = append(errs, fmt.Errorf("no policies defined; add policies with %s<name>%s keys", keyPrefixPolicy, keySuffixRego))
+	}
+	return errs
+}
+
 type plugin struct {
 	idGenerator ext.IDGenerator
 	clock       ext.Clock
@@ -242,6 +298,11 @@ func (p *plugin) ConfigHash(ctx starboard.PluginContext, kind kube.Kind) (string
 	return kube.ComputeHash(modules), nil
 }
 
+// ValidateConfig returns problems with the specified configuration settings.
+func (p *plugin) ValidateConfig(config starboard.PluginConfig) []error {
+	return Config{PluginConfig: config}.Validate()
+}
+
 func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, obj client.Object) (corev1.PodSpec, []*corev1.Secret, error) {
 	config, err := p.newConfigFrom(ctx)
 	if err != nil {
@@ -167,6 +167,70 @@ func TestConfig_GetResourceRequirements(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		config         conftest.Config
+		expectedErrors []string
+	}{
+		{
+			name: "Should return no errors for valid settings",
+			config: conftest.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"conftest.imageRef":                "openpolicyagent/conftest:v0.28.2",
+					"conftest.library.kubernetes.rego": "package lib.kubernetes\n\ndefault is_gatekeeper = false\n",
+					"conftest.policy.privileged.rego": "# METADATA\npackage main\n\n" +
+						"deny[msg] {\n  input.kind == \"Pod\" # {\n  msg := \"Pod is \\\"privileged\\\" {\"\n}\n",
+					"conftest.policy.privileged.kinds": "Workload",
+				},
+			}},
+		},
+		{
+			name: "Should return errors for invalid settings",
+			config: conftest.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"conftest.library.kubernetes.rego": "default is_gatekeeper = false\n",
+					"conftest.policy.privileged.rego":  "package main\n\ndeny[msg] {\n  msg := \"privileged\"\n",
+					"conftest.policy.privileged.kind":  "Workload",
+					"conftest.policy.latest.rego":      "package main\n\ndeny[msg] {\n  msg := \"latest)\n}\n",
+					"conftest.policy.latest.kinds":     "Pod,",
+					"conftest.policy.missing.kinds":    "Workload",
+				},
+			}},
+			expectedErrors: []string{
+				"property conftest.imageRef not set",
+				"library conftest.library.kubernetes.rego: line 1: expected package declaration, e.g. package main",
+				"invalid value (Pod,) of conftest.policy.latest.kinds; expected comma-separated kinds, Workload, or *",
+				"policy conftest.policy.latest.rego: line 4: unterminated string",
+				"expected policy not found: conftest.policy.missing.rego",
+				"unexpected key conftest.policy.privileged.kind; keys of policies must end with .rego or .kinds, and keys of libraries with .rego",
+				"kinds not defined for policy: conftest.policy.privileged.rego; set conftest.policy.privileged.kinds",
+				"policy conftest.policy.privileged.rego: line 3: unclosed {",
+			},
+		},
+		{
+			name: "Should return error when there are no policies",
+			config: conftest.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"conftest.imageRef": "openpolicyagent/conftest:v0.28.2",
+				},
+			}},
+			expectedErrors: []string{
+				"no policies defined; add policies with conftest.policy.<name>.rego keys",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errs []string
+			for _, err := range tc.config.Validate() {
+				errs = append(errs, err.Error())
+			}
+			require.Equal(t, tc.expectedErrors, errs)
+		})
+	}
+}
+
 func TestPlugin_IsApplicable(t *testing.T) {
 
 	testCases := []struct {
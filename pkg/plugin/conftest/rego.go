@@ -0,0 +1,95 @@
+package conftest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var packagePattern = regexp.MustCompile(`^package\s+[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*|\["[^"]*"\])*\s*$`)
+
+// checkRegoSyntax checks that the specified Rego module starts with a valid
+// package declaration and that brackets, braces, and parentheses outside of
+// strings and comments are balanced. The module is not compiled, because the
+// Rego compiler ships with Conftest in scan jobs, so errors it doesn't catch
+// are still reported by scan jobs.
+func checkRegoSyntax(module string) error {
+	lines := strings.Split(module, "\n")
+	declared := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if !packagePattern.MatchString(stripComment(trimmed)) {
+			return fmt.Errorf("line %d: expected package declaration, e.g. package main", i+1)
+		}
+		declared = true
+		break
+	}
+	if !declared {
+		return fmt.Errorf("expected package declaration, e.g. package main")
+	}
+
+	type bracket struct {
+		char rune
+		line int
+	}
+	closing := map[rune]rune{')': '(', ']': '[', '}': '{'}
+	var open []bracket
+	// Raw strings may span lines, but other strings may not.
+	var quote rune
+	for i, line := range lines {
+		escaped := false
+		for _, char := range line {
+			switch {
+			case quote == '"':
+				if escaped {
+					escaped = false
+				} else if char == '\\' {
+					escaped = true
+				} else if char == '"' {
+					quote = 0
+				}
+				continue
+			case quote == '`':
+				if char == '`' {
+					quote = 0
+				}
+				continue
+			}
+			if char == '#' {
+				break
+			}
+			switch char {
+			case '"', '`':
+				quote = char
+			case '(', '[', '{':
+				open = append(open, bracket{char: char, line: i + 1})
+			case ')', ']', '}':
+				if len(open) == 0 || open[len(open)-1].char != closing[char] {
+					return fmt.Errorf("line %d: unexpected %c", i+1, char)
+				}
+				open = open[:len(open)-1]
+			}
+		}
+		if quote == '"' {
+			return fmt.Errorf("line %d: unterminated string", i+1)
+		}
+	}
+	if quote == '`' {
+		return fmt.Errorf("unterminated raw string")
+	}
+	if len(open) > 0 {
+		last := open[len(open)-1]
+		return fmt.Errorf("line %d: unclosed %c", last.line, last.char)
+	}
+	return nil
+}
+
+func stripComment(line string) string {
+	if index := strings.Index(line, "#"); index >= 0 {
+		return strings.TrimSpace(line[:index])
+	}
+	return line
+}
@@ -0,0 +1,11 @@
+// Package external provides primitives for integrating vulnerability scanners
+// which run out of tree, i.e. as separate services that implement the
+// Starboard external plugin protocol.
+//
+// The protocol consists of two JSON-over-HTTP methods that mirror the
+// vulnerabilityreport.Plugin interface: POST /v1alpha1/scanJobSpec returns the
+// pod spec of a scan job for a workload, and POST /v1alpha1/reportData parses
+// logs of a completed scan job into a VulnerabilityReport. Vendors can serve
+// the protocol by implementing the Scanner interface and mounting the handler
+// returned by NewHandler, without forking Starboard.
+package external
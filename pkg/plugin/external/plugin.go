@@ -0,0 +1,242 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	// Plugin the name of this plugin.
+	Plugin = "External"
+)
+
+const (
+	keyExternalURL     = "external.url"
+	keyExternalTimeout = "external.timeout"
+	keyExternalToken   = "external.token"
+)
+
+// Config defines configuration params for this plugin.
+type Config struct {
+	starboard.PluginConfig
+}
+
+// GetURL returns the base URL of the service that implements the external
+// plugin protocol.
+func (c Config) GetURL() (string, error) {
+	value, err := c.GetRequiredData(keyExternalURL)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(value, "/"), nil
+}
+
+// GetTimeout returns the timeout of requests sent to the service. It returns
+// 30 seconds if the timeout is not set.
+func (c Config) GetTimeout() (time.Duration, error) {
+	value, ok := c.Data[keyExternalTimeout]
+	if !ok {
+		return 30 * time.Second, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %w", keyExternalTimeout, err)
+	}
+	return timeout, nil
+}
+
+// GetToken returns the bearer token sent to the service, or an empty string
+// if requests are not authenticated.
+func (c Config) GetToken() string {
+	return string(c.SecretData[keyExternalToken])
+}
+
+// Validate returns problems with the configuration settings.
+func (c Config) Validate() []error {
+	var problems []error
+	if _, err := c.GetURL(); err != nil {
+		problems = append(problems, err)
+	}
+	if _, err := c.GetTimeout(); err != nil {
+		problems = append(problems, err)
+	}
+	return problems
+}
+
+type plugin struct {
+	client client.Client
+}
+
+// NewPlugin constructs a new vulnerabilityreport.Plugin, which delegates
+// creating scan jobs and parsing their logs to a service that implements the
+// external plugin protocol, e.g. with NewHandler.
+//
+// The plugin only forwards calls, it doesn't make any assumptions about the
+// scanner. Scan jobs are created by Starboard the same way as for in-tree
+// plugins, hence vendors ship scanners as a container image and a service
+// without forking Starboard.
+func NewPlugin(client client.Client) vulnerabilityreport.Plugin {
+	return &plugin{
+		client: client,
+	}
+}
+
+// Init ensures the default Config required by this plugin.
+func (p *plugin) Init(ctx starboard.PluginContext) error {
+	return ctx.EnsureConfig(starboard.PluginConfig{
+		Data: map[string]string{
+			keyExternalTimeout: "30s",
+		},
+	})
+}
+
+// ValidateConfig returns problems with the specified configuration settings.
+func (p *plugin) ValidateConfig(config starboard.PluginConfig) []error {
+	return Config{PluginConfig: config}.Validate()
+}
+
+func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	object, err := p.toUnstructured(workload)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	var resp ScanJobSpecResponse
+	err = p.call(config, PathScanJobSpec, ScanJobSpecRequest{
+		Workload:           object,
+		Credentials:        credentials,
+		Namespace:          ctx.GetNamespace(),
+		ServiceAccountName: ctx.GetServiceAccountName(),
+		Config:             config.Data,
+	}, &resp)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	var secrets []*corev1.Secret
+	for i := range resp.Secrets {
+		if resp.Secrets[i].Namespace == "" {
+			resp.Secrets[i].Namespace = ctx.GetNamespace()
+		}
+		secrets = append(secrets, &resp.Secrets[i])
+	}
+	return resp.PodSpec, secrets, nil
+}
+
+func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	logs, err := io.ReadAll(logsReader)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+
+	var resp ReportDataResponse
+	err = p.call(config, PathReportData, ReportDataRequest{
+		ImageRef: imageRef,
+		Logs:     string(logs),
+		Config:   config.Data,
+	}, &resp)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	return resp.ReportData, nil
+}
+
+// call sends the request to the method of the service at the specified path
+// and decodes the response. Responses with a non-2xx status are returned as
+// errors with the message of the ErrorResponse.
+func (p *plugin) call(config Config, path string, request, response interface{}) error {
+	url, err := config.GetURL()
+	if err != nil {
+		return err
+	}
+	timeout, err := config.GetTimeout()
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("encoding request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token := config.GetToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling external plugin: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var errResp ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Message == "" {
+			return fmt.Errorf("calling external plugin %s: unexpected response status: %s", path, resp.Status)
+		}
+		return fmt.Errorf("calling external plugin %s: %s", path, errResp.Message)
+	}
+	err = json.NewDecoder(resp.Body).Decode(response)
+	if err != nil {
+		return fmt.Errorf("decoding response of external plugin %s: %w", path, err)
+	}
+	return nil
+}
+
+// toUnstructured converts the workload to the unstructured object with its
+// apiVersion and kind set, which are typically empty for typed objects read
+// with the client.
+func (p *plugin) toUnstructured(workload client.Object) (*unstructured.Unstructured, error) {
+	values, err := runtime.DefaultUnstructuredConverter.ToUnstructured(workload)
+	if err != nil {
+		return nil, err
+	}
+	object := &unstructured.Unstructured{Object: values}
+	if object.GetKind() == "" {
+		gvk, err := apiutil.GVKForObject(workload, p.client.Scheme())
+		if err != nil {
+			return nil, err
+		}
+		object.SetGroupVersionKind(gvk)
+	}
+	return object, nil
+}
+
+func (p *plugin) newConfigFrom(ctx starboard.PluginContext) (Config, error) {
+	pluginConfig, err := ctx.GetConfig()
+	if err != nil {
+		return Config{}, err
+	}
+	return Config{PluginConfig: pluginConfig}, nil
+}
@@ -0,0 +1,205 @@
+package external_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/plugin/external"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type scanner struct {
+	scanJobSpecRequests []external.ScanJobSpecRequest
+	reportDataRequests  []external.ReportDataRequest
+}
+
+func (s *scanner) GetScanJobSpec(_ context.Context, req external.ScanJobSpecRequest) (external.ScanJobSpecResponse, error) {
+	s.scanJobSpecRequests = append(s.scanJobSpecRequests, req)
+	return external.ScanJobSpecResponse{
+		PodSpec: corev1.PodSpec{
+			ServiceAccountName: req.ServiceAccountName,
+			Containers: []corev1.Container{
+				{Name: "nginx", Image: req.Config["external.imageRef"]},
+			},
+		},
+		Secrets: []corev1.Secret{
+			{ObjectMeta: metav1.ObjectMeta{Name: "scan-vulnerabilityreport-5d4445db4f"}},
+		},
+	}, nil
+}
+
+func (s *scanner) ParseReportData(_ context.Context, req external.ReportDataRequest) (external.ReportDataResponse, error) {
+	s.reportDataRequests = append(s.reportDataRequests, req)
+	if req.Logs == "" {
+		return external.ReportDataResponse{}, errors.New("no scan results")
+	}
+	return external.ReportDataResponse{
+		ReportData: v1alpha1.VulnerabilityReportData{
+			Scanner: v1alpha1.Scanner{Name: "Acme", Vendor: "Acme Corp"},
+			Summary: v1alpha1.VulnerabilitySummary{HighCount: 1},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2022-0778", Resource: "openssl", Severity: v1alpha1.SeverityHigh},
+			},
+		},
+	}, nil
+}
+
+// newServer starts the server of the scanner, which rejects requests
+// without the bearer token.
+func newServer(t *testing.T, s external.Scanner, token string) *httptest.Server {
+	handler := external.NewHandler(s)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newPluginContext(c client.Client) starboard.PluginContext {
+	return starboard.NewPluginContext().
+		WithName(external.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(c).
+		Get()
+}
+
+func newClient(url, token string) client.Client {
+	objects := []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-ns", Name: "starboard-external-config"},
+			Data: map[string]string{
+				"external.url":      url + "/",
+				"external.imageRef": "acme/scanner:1.0",
+			},
+		},
+	}
+	if token != "" {
+		objects = append(objects, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "starboard-ns", Name: "starboard-external-config"},
+			Data:       map[string][]byte{"external.token": []byte(token)},
+		})
+	}
+	return fake.NewClientBuilder().WithObjects(objects...).Build()
+}
+
+func TestPlugin_Init(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+
+	err := external.NewPlugin(c).Init(newPluginContext(c))
+	require.NoError(t, err)
+
+	var cm corev1.ConfigMap
+	err = c.Get(context.Background(), types.NamespacedName{
+		Namespace: "starboard-ns",
+		Name:      "starboard-external-config",
+	}, &cm)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"external.timeout": "30s",
+	}, cm.Data)
+}
+
+func TestPlugin_GetScanJobSpec(t *testing.T) {
+	s := &scanner{}
+	server := newServer(t, s, "s3cret")
+	c := newClient(server.URL, "s3cret")
+
+	instance := external.NewPlugin(c)
+	spec, secrets, err := instance.GetScanJobSpec(newPluginContext(c), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod-ns", Name: "nginx"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.16"}},
+				},
+			},
+		},
+	}, map[string]docker.Auth{
+		"nginx": {Username: "admin", Password: "Password12345"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, s.scanJobSpecRequests, 1)
+	req := s.scanJobSpecRequests[0]
+	assert.Equal(t, "apps/v1", req.Workload.GetAPIVersion())
+	assert.Equal(t, "Deployment", req.Workload.GetKind())
+	assert.Equal(t, "nginx", req.Workload.GetName())
+	assert.Equal(t, map[string]docker.Auth{
+		"nginx": {Username: "admin", Password: "Password12345"},
+	}, req.Credentials)
+	assert.Equal(t, "starboard-ns", req.Namespace)
+	assert.Equal(t, "acme/scanner:1.0", req.Config["external.imageRef"])
+
+	assert.Equal(t, corev1.PodSpec{
+		ServiceAccountName: "starboard-sa",
+		Containers: []corev1.Container{
+			{Name: "nginx", Image: "acme/scanner:1.0"},
+		},
+	}, spec)
+	require.Len(t, secrets, 1)
+	assert.Equal(t, "starboard-ns", secrets[0].Namespace,
+		"Should default namespace of secrets to namespace of scan jobs")
+}
+
+func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
+	s := &scanner{}
+	server := newServer(t, s, "s3cret")
+
+	t.Run("Should return report data parsed by scanner", func(t *testing.T) {
+		c := newClient(server.URL, "s3cret")
+		data, err := external.NewPlugin(c).ParseVulnerabilityReportData(newPluginContext(c), "nginx:1.16",
+			io.NopCloser(strings.NewReader(`{"results":[]}`)))
+		require.NoError(t, err)
+		assert.Equal(t, "Acme", data.Scanner.Name)
+		assert.Equal(t, 1, data.Summary.HighCount)
+		require.Len(t, s.reportDataRequests, 1)
+		assert.Equal(t, "nginx:1.16", s.reportDataRequests[0].ImageRef)
+		assert.Equal(t, `{"results":[]}`, s.reportDataRequests[0].Logs)
+	})
+
+	t.Run("Should return error message of scanner", func(t *testing.T) {
+		c := newClient(server.URL, "s3cret")
+		_, err := external.NewPlugin(c).ParseVulnerabilityReportData(newPluginContext(c), "nginx:1.16",
+			io.NopCloser(strings.NewReader("")))
+		assert.EqualError(t, err, "calling external plugin /v1alpha1/reportData: no scan results")
+	})
+
+	t.Run("Should return error when request is rejected", func(t *testing.T) {
+		c := newClient(server.URL, "")
+		_, err := external.NewPlugin(c).ParseVulnerabilityReportData(newPluginContext(c), "nginx:1.16",
+			io.NopCloser(strings.NewReader(`{"results":[]}`)))
+		assert.EqualError(t, err, "calling external plugin /v1alpha1/reportData: unexpected response status: 401 Unauthorized")
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	config := external.Config{PluginConfig: starboard.PluginConfig{
+		Data: map[string]string{
+			"external.timeout": "1 minute",
+		},
+	}}
+	problems := config.Validate()
+	require.Len(t, problems, 2)
+	assert.EqualError(t, problems[0], "property external.url not set")
+	assert.EqualError(t, problems[1], `parsing external.timeout: time: unknown unit " minute" in duration "1 minute"`)
+}
@@ -0,0 +1,64 @@
+package external
+
+import (
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// PathScanJobSpec is the path of the method that returns the pod spec of
+	// a scan job.
+	PathScanJobSpec = "/v1alpha1/scanJobSpec"
+	// PathReportData is the path of the method that parses logs of a scan
+	// job into a VulnerabilityReport.
+	PathReportData = "/v1alpha1/reportData"
+)
+
+// ScanJobSpecRequest is the body of a request sent to PathScanJobSpec.
+type ScanJobSpecRequest struct {
+	// Workload is the scanned workload with its apiVersion and kind set.
+	Workload *unstructured.Unstructured `json:"workload"`
+	// Credentials are image pull credentials of containers of the workload,
+	// keyed by container names.
+	Credentials map[string]docker.Auth `json:"credentials,omitempty"`
+	// Namespace is the namespace where the scan job is created.
+	Namespace string `json:"namespace"`
+	// ServiceAccountName is the name of the service account that the scan
+	// job should run as.
+	ServiceAccountName string `json:"serviceAccountName"`
+	// Config holds settings of the starboard-external-config ConfigMap.
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// ScanJobSpecResponse is the body of a successful response of
+// PathScanJobSpec.
+type ScanJobSpecResponse struct {
+	// PodSpec is the template spec of the scan job. Logs of each container
+	// are parsed with a separate call to PathReportData.
+	PodSpec corev1.PodSpec `json:"podSpec"`
+	// Secrets are created in the namespace of the scan job before the job,
+	// and garbage collected together with it.
+	Secrets []corev1.Secret `json:"secrets,omitempty"`
+}
+
+// ReportDataRequest is the body of a request sent to PathReportData.
+type ReportDataRequest struct {
+	// ImageRef is the image of the container that produced the logs.
+	ImageRef string `json:"imageRef"`
+	// Logs are logs of the container of the completed scan job.
+	Logs string `json:"logs"`
+	// Config holds settings of the starboard-external-config ConfigMap.
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// ReportDataResponse is the body of a successful response of PathReportData.
+type ReportDataResponse struct {
+	ReportData v1alpha1.VulnerabilityReportData `json:"reportData"`
+}
+
+// ErrorResponse is the body of a response with a non-2xx status.
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,73 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Scanner is implemented by vendors to serve the external plugin protocol
+// with NewHandler.
+type Scanner interface {
+	// GetScanJobSpec returns the pod spec of the scan job for the workload,
+	// and secrets, e.g. with image pull credentials, mounted by the job.
+	GetScanJobSpec(ctx context.Context, req ScanJobSpecRequest) (ScanJobSpecResponse, error)
+	// ParseReportData parses logs of a container of a completed scan job.
+	ParseReportData(ctx context.Context, req ReportDataRequest) (ReportDataResponse, error)
+}
+
+// NewHandler returns the http.Handler, which serves methods of the external
+// plugin protocol by decoding requests and calling the Scanner. Errors
+// returned by the Scanner are sent as ErrorResponse with the 500 status.
+//
+// The handler doesn't authenticate requests. If the plugin is configured
+// with the external.token secret, wrap the handler to verify the bearer
+// token of the Authorization header.
+func NewHandler(scanner Scanner) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathScanJobSpec, func(w http.ResponseWriter, r *http.Request) {
+		var req ScanJobSpecRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+		resp, err := scanner.GetScanJobSpec(r.Context(), req)
+		writeResponse(w, resp, err)
+	})
+	mux.HandleFunc(PathReportData, func(w http.ResponseWriter, r *http.Request) {
+		var req ReportDataRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+		resp, err := scanner.ParseReportData(r.Context(), req)
+		writeResponse(w, resp, err)
+	})
+	return mux
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return false
+	}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("decoding request: %w", err))
+		return false
+	}
+	return true
+}
+
+func writeResponse(w http.ResponseWriter, resp interface{}, err error) {
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(ErrorResponse{Message: err.Error()})
+}
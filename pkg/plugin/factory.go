@@ -7,7 +7,10 @@ import (
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/plugin/aqua"
 	"github.com/aquasecurity/starboard/pkg/plugin/conftest"
+	"github.com/aquasecurity/starboard/pkg/plugin/external"
+	"github.com/aquasecurity/starboard/pkg/plugin/harbor"
 	"github.com/aquasecurity/starboard/pkg/plugin/polaris"
+	"github.com/aquasecurity/starboard/pkg/plugin/quay"
 	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
@@ -54,9 +57,10 @@ func (r *Resolver) WithClient(client client.Client) *Resolver {
 // GetVulnerabilityPlugin is a factory method that instantiates the vulnerabilityreport.Plugin.
 //
 // Starboard currently supports Trivy scanner in Standalone and ClientServer
-// mode, and Aqua Enterprise scanner.
+// mode, Aqua Enterprise scanner, and importing scan results from Harbor and Quay.
 //
-// You could add your own scanner by implementing the vulnerabilityreport.Plugin interface.
+// You could add your own scanner by implementing the vulnerabilityreport.Plugin interface,
+// or serve it out of tree with the External plugin.
 func (r *Resolver) GetVulnerabilityPlugin() (vulnerabilityreport.Plugin, starboard.PluginContext, error) {
 	scanner, err := r.config.GetVulnerabilityReportsScanner()
 	if err != nil {
@@ -75,6 +79,12 @@ func (r *Resolver) GetVulnerabilityPlugin() (vulnerabilityreport.Plugin, starboa
 		return trivy.NewPlugin(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator(), r.client), pluginContext, nil
 	case starboard.Aqua:
 		return aqua.NewPlugin(ext.NewGoogleUUIDGenerator(), r.buildInfo), pluginContext, nil
+	case starboard.Harbor:
+		return harbor.NewPlugin(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator()), pluginContext, nil
+	case starboard.Quay:
+		return quay.NewPlugin(ext.NewSystemClock()), pluginContext, nil
+	case starboard.External:
+		return external.NewPlugin(r.client), pluginContext, nil
 	}
 	return nil, nil, fmt.Errorf("unsupported vulnerability scanner plugin: %s", scanner)
 }
@@ -0,0 +1,3 @@
+// Package harbor provides primitives for importing vulnerability scan results
+// of container images hosted by Harbor.
+package harbor
@@ -0,0 +1,100 @@
+package harbor
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+const (
+	// mimeTypeNativeReport is the MIME type of the vulnerability report in
+	// the format native to Harbor.
+	mimeTypeNativeReport = "application/vnd.security.vulnerability.report; version=1.1"
+
+	// mimeTypeAdapterReport is the MIME type of the vulnerability report
+	// returned by older scanner adapters.
+	mimeTypeAdapterReport = "application/vnd.scanner.adapter.vuln.report.harbor+json; version=1.0"
+)
+
+// Reports represents the response of the Harbor API endpoint that returns
+// vulnerabilities of an artifact, where the keys are MIME types of reports.
+type Reports map[string]Report
+
+// Report is a vulnerability report generated by the scanner configured in
+// Harbor.
+type Report struct {
+	GeneratedAt     string          `json:"generated_at"`
+	Scanner         Scanner         `json:"scanner"`
+	Severity        string          `json:"severity"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+type Scanner struct {
+	Name    string `json:"name"`
+	Vendor  string `json:"vendor"`
+	Version string `json:"version"`
+}
+
+type Vulnerability struct {
+	ID            string   `json:"id"`
+	Package       string   `json:"package"`
+	Version       string   `json:"version"`
+	FixVersion    string   `json:"fix_version"`
+	Severity      string   `json:"severity"`
+	Description   string   `json:"description"`
+	Links         []string `json:"links"`
+	PreferredCVSS *CVSS    `json:"preferred_cvss,omitempty"`
+}
+
+type CVSS struct {
+	ScoreV3 *float64 `json:"score_v3,omitempty"`
+	ScoreV2 *float64 `json:"score_v2,omitempty"`
+}
+
+// Get returns the Report in the native format if present, or the report
+// returned by the scanner adapter otherwise.
+func (r Reports) Get() (Report, bool) {
+	if report, ok := r[mimeTypeNativeReport]; ok {
+		return report, true
+	}
+	report, ok := r[mimeTypeAdapterReport]
+	return report, ok
+}
+
+// toSeverity maps Harbor severity levels to v1alpha1.Severity.
+func toSeverity(severity string) v1alpha1.Severity {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return v1alpha1.SeverityCritical
+	case "high":
+		return v1alpha1.SeverityHigh
+	case "medium":
+		return v1alpha1.SeverityMedium
+	case "low", "negligible":
+		return v1alpha1.SeverityLow
+	case "none":
+		return v1alpha1.SeverityNone
+	default:
+		return v1alpha1.SeverityUnknown
+	}
+}
+
+// toTitle returns the first line of the description, because Harbor does not
+// return titles of vulnerabilities.
+func toTitle(v Vulnerability) string {
+	title := strings.TrimSpace(strings.SplitN(strings.TrimSpace(v.Description), "\n", 2)[0])
+	if title == "" {
+		return v.ID
+	}
+	return title
+}
+
+func toScore(cvss *CVSS) *float64 {
+	if cvss == nil {
+		return nil
+	}
+	if cvss.ScoreV3 != nil {
+		return cvss.ScoreV3
+	}
+	return cvss.ScoreV2
+}
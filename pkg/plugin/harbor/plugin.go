@@ -0,0 +1,397 @@
+package harbor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Plugin the name of this plugin.
+	Plugin = "Harbor"
+)
+
+const (
+	keyHarborImageRef  = "harbor.imageRef"
+	keyHarborServerURL = "harbor.serverURL"
+	keyHarborInsecure  = "harbor.insecure"
+	keyHarborUsername  = "harbor.username"
+	keyHarborPassword  = "harbor.password"
+
+	keyResourcesRequestsCPU    = "harbor.resources.requests.cpu"
+	keyResourcesRequestsMemory = "harbor.resources.requests.memory"
+	keyResourcesLimitsCPU      = "harbor.resources.limits.cpu"
+	keyResourcesLimitsMemory   = "harbor.resources.limits.memory"
+)
+
+// Config defines configuration params for this plugin.
+type Config struct {
+	starboard.PluginConfig
+}
+
+// GetImageRef returns the reference of the container image with the curl
+// command, which is used to fetch vulnerability reports from Harbor.
+func (c Config) GetImageRef() (string, error) {
+	return c.GetRequiredData(keyHarborImageRef)
+}
+
+// GetServerURL returns the URL of Harbor API. It returns an empty string if
+// the URL should be derived from the registry server of a scanned image.
+func (c Config) GetServerURL() string {
+	return strings.TrimSuffix(c.Data[keyHarborServerURL], "/")
+}
+
+// IsInsecure returns true if the TLS certificate of Harbor API should not be
+// verified.
+func (c Config) IsInsecure() bool {
+	return c.Data[keyHarborInsecure] == "true"
+}
+
+// GetResourceRequirements creates ResourceRequirements from the Config.
+func (c Config) GetResourceRequirements() (corev1.ResourceRequirements, error) {
+	requirements := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+
+	err := c.setResourceLimit(keyResourcesRequestsCPU, &requirements.Requests, corev1.ResourceCPU)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyResourcesRequestsMemory, &requirements.Requests, corev1.ResourceMemory)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyResourcesLimitsCPU, &requirements.Limits, corev1.ResourceCPU)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyResourcesLimitsMemory, &requirements.Limits, corev1.ResourceMemory)
+	if err != nil {
+		return requirements, err
+	}
+
+	return requirements, nil
+}
+
+func (c Config) setResourceLimit(configKey string, k8sResourceList *corev1.ResourceList, k8sResourceName corev1.ResourceName) error {
+	if value, found := c.Data[configKey]; found {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("parsing resource definition %s: %s %w", configKey, value, err)
+		}
+
+		(*k8sResourceList)[k8sResourceName] = quantity
+	}
+	return nil
+}
+
+type plugin struct {
+	clock       ext.Clock
+	idGenerator ext.IDGenerator
+}
+
+// NewPlugin constructs a new vulnerabilityreport.Plugin, which imports
+// results of vulnerability scans that Harbor has already run for container
+// images it hosts, rather than scanning images again.
+//
+// The scan job fetches reports from Harbor API with the curl command.
+// Credentials of a workload are used to authenticate with Harbor, and if
+// there are no such credentials, the plugin falls back to the robot account
+// configured with the harbor.username and harbor.password keys.
+func NewPlugin(clock ext.Clock, idGenerator ext.IDGenerator) vulnerabilityreport.Plugin {
+	return &plugin{
+		clock:       clock,
+		idGenerator: idGenerator,
+	}
+}
+
+// Init ensures the default Config required by this plugin.
+func (p *plugin) Init(ctx starboard.PluginContext) error {
+	return ctx.EnsureConfig(starboard.PluginConfig{
+		Data: map[string]string{
+			keyHarborImageRef: "docker.io/curlimages/curl:7.81.0",
+
+			keyResourcesRequestsCPU:    "10m",
+			keyResourcesRequestsMemory: "16M",
+			keyResourcesLimitsCPU:      "100m",
+			keyResourcesLimitsMemory:   "64M",
+		},
+	})
+}
+
+func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	spec, err := kube.GetPodSpec(workload)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	imageRef, err := config.GetImageRef()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	requirements, err := config.GetResourceRequirements()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	var secret *corev1.Secret
+	var secrets []*corev1.Secret
+	if len(credentials) > 0 {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: p.idGenerator.GenerateID(),
+			},
+			Data: kube.AggregateImagePullSecretsData(kube.GetContainerImagesFromPodSpec(spec), credentials),
+		}
+		secrets = append(secrets, secret)
+	}
+
+	var containers []corev1.Container
+	for _, c := range spec.Containers {
+		reportURL, err := p.getReportURL(config, c.Image)
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
+
+		env := []corev1.EnvVar{
+			{
+				Name:  "HARBOR_REPORT_URL",
+				Value: reportURL,
+			},
+		}
+		if _, ok := credentials[c.Name]; ok && secret != nil {
+			env = append(env,
+				newEnvVarFromSecret("HARBOR_USERNAME", secret.Name, fmt.Sprintf("%s.username", c.Name), false),
+				newEnvVarFromSecret("HARBOR_PASSWORD", secret.Name, fmt.Sprintf("%s.password", c.Name), false),
+			)
+		} else {
+			env = append(env,
+				newEnvVarFromSecret("HARBOR_USERNAME", starboard.GetPluginConfigMapName(Plugin), keyHarborUsername, true),
+				newEnvVarFromSecret("HARBOR_PASSWORD", starboard.GetPluginConfigMapName(Plugin), keyHarborPassword, true),
+			)
+		}
+
+		containers = append(containers, corev1.Container{
+			Name:                     c.Name,
+			Image:                    imageRef,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			Env:                      env,
+			Command: []string{
+				"/bin/sh",
+			},
+			Args: []string{
+				"-c",
+				p.getCurlCommand(config),
+			},
+			Resources: requirements,
+			SecurityContext: &corev1.SecurityContext{
+				Privileged:               pointer.BoolPtr(false),
+				AllowPrivilegeEscalation: pointer.BoolPtr(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"all"},
+				},
+				ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+			},
+		})
+	}
+
+	return corev1.PodSpec{
+		Affinity:                     starboard.LinuxNodeAffinity(),
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ServiceAccountName:           ctx.GetServiceAccountName(),
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		Containers:                   containers,
+	}, secrets, nil
+}
+
+// getCurlCommand returns the shell command that prints the vulnerability
+// report of the image to the standard output. Credentials are passed only if
+// they are set, which allows reading reports of public Harbor projects.
+func (p *plugin) getCurlCommand(config Config) string {
+	args := []string{
+		"curl",
+		"--silent",
+		"--show-error",
+		"--fail",
+		"--location",
+	}
+	if config.IsInsecure() {
+		args = append(args, "--insecure")
+	}
+	args = append(args,
+		"--header", `"Accept: application/json"`,
+		"--header", fmt.Sprintf(`"X-Accept-Vulnerabilities: %s, %s"`, mimeTypeNativeReport, mimeTypeAdapterReport),
+		`${HARBOR_USERNAME:+--user "$HARBOR_USERNAME:$HARBOR_PASSWORD"}`,
+		`"$HARBOR_REPORT_URL"`,
+	)
+	return strings.Join(args, " ")
+}
+
+// getReportURL returns the URL of Harbor API endpoint that returns
+// vulnerabilities of the specified image, i.e.
+// /api/v2.0/projects/{project}/repositories/{repository}/artifacts/{reference}/additions/vulnerabilities
+func (p *plugin) getReportURL(config Config, imageRef string) (string, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return "", err
+	}
+	serverURL := config.GetServerURL()
+	if serverURL == "" {
+		serverURL = "https://" + ref.Context().RegistryStr()
+	}
+	segments := strings.SplitN(ref.Context().RepositoryStr(), "/", 2)
+	if len(segments) != 2 {
+		return "", fmt.Errorf("image %s is not hosted in a Harbor project", imageRef)
+	}
+	project, repository := segments[0], segments[1]
+	// Harbor requires slashes in repository names to be double URL encoded.
+	repository = url.PathEscape(url.PathEscape(repository))
+
+	return fmt.Sprintf("%s/api/v2.0/projects/%s/repositories/%s/artifacts/%s/additions/vulnerabilities",
+		serverURL, url.PathEscape(project), repository, url.PathEscape(ref.Identifier())), nil
+}
+
+func (p *plugin) ParseVulnerabilityReportData(_ starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	var reports Reports
+	err := json.NewDecoder(logsReader).Decode(&reports)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	report, ok := reports.Get()
+	if !ok {
+		return v1alpha1.VulnerabilityReportData{}, errors.New("harbor has not scanned the image yet")
+	}
+
+	vulnerabilities := make([]v1alpha1.Vulnerability, 0)
+	for _, v := range report.Vulnerabilities {
+		links := v.Links
+		if links == nil {
+			links = []string{}
+		}
+		var primaryLink string
+		if len(links) > 0 {
+			primaryLink = links[0]
+		}
+		vulnerabilities = append(vulnerabilities, v1alpha1.Vulnerability{
+			VulnerabilityID:  v.ID,
+			Resource:         v.Package,
+			InstalledVersion: v.Version,
+			FixedVersion:     v.FixVersion,
+			Severity:         toSeverity(v.Severity),
+			Title:            toTitle(v),
+			Description:      v.Description,
+			PrimaryLink:      primaryLink,
+			Links:            links,
+			Score:            toScore(v.PreferredCVSS),
+		})
+	}
+
+	registry, artifact, err := p.parseImageRef(imageRef)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+
+	return v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(p.clock.Now()),
+		Scanner: v1alpha1.Scanner{
+			Name:    report.Scanner.Name,
+			Vendor:  report.Scanner.Vendor,
+			Version: report.Scanner.Version,
+		},
+		Registry:        registry,
+		Artifact:        artifact,
+		Summary:         p.toSummary(vulnerabilities),
+		Vulnerabilities: vulnerabilities,
+	}, nil
+}
+
+func (p *plugin) newConfigFrom(ctx starboard.PluginContext) (Config, error) {
+	pluginConfig, err := ctx.GetConfig()
+	if err != nil {
+		return Config{}, err
+	}
+	return Config{PluginConfig: pluginConfig}, nil
+}
+
+func (p *plugin) toSummary(vulnerabilities []v1alpha1.Vulnerability) v1alpha1.VulnerabilitySummary {
+	var vs v1alpha1.VulnerabilitySummary
+	for _, v := range vulnerabilities {
+		switch v.Severity {
+		case v1alpha1.SeverityCritical:
+			vs.CriticalCount++
+		case v1alpha1.SeverityHigh:
+			vs.HighCount++
+		case v1alpha1.SeverityMedium:
+			vs.MediumCount++
+		case v1alpha1.SeverityLow:
+			vs.LowCount++
+		case v1alpha1.SeverityNone:
+			vs.NoneCount++
+		default:
+			vs.UnknownCount++
+		}
+	}
+	return vs
+}
+
+func (p *plugin) parseImageRef(imageRef string) (v1alpha1.Registry, v1alpha1.Artifact, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return v1alpha1.Registry{}, v1alpha1.Artifact{}, err
+	}
+	registry := v1alpha1.Registry{
+		Server: ref.Context().RegistryStr(),
+	}
+	artifact := v1alpha1.Artifact{
+		Repository: ref.Context().RepositoryStr(),
+	}
+	switch t := ref.(type) {
+	case name.Tag:
+		artifact.Tag = t.TagStr()
+	case name.Digest:
+		artifact.Digest = t.DigestStr()
+	}
+	return registry, artifact, nil
+}
+
+func newEnvVarFromSecret(envName, secretName, key string, optional bool) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: envName,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key:      key,
+				Optional: pointer.BoolPtr(optional),
+			},
+		},
+	}
+}
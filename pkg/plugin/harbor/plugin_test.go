@@ -0,0 +1,265 @@
+package harbor_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/plugin/harbor"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	fixedTime  = time.Now()
+	fixedClock = ext.NewFixedClock(fixedTime)
+)
+
+func TestPlugin_Init(t *testing.T) {
+	client := fake.NewClientBuilder().WithObjects().Build()
+
+	instance := harbor.NewPlugin(fixedClock, ext.NewSimpleIDGenerator())
+
+	pluginContext := starboard.NewPluginContext().
+		WithName(harbor.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(client).
+		Get()
+	err := instance.Init(pluginContext)
+	require.NoError(t, err)
+
+	var cm corev1.ConfigMap
+	err = client.Get(context.Background(), types.NamespacedName{
+		Namespace: "starboard-ns",
+		Name:      "starboard-harbor-config",
+	}, &cm)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"harbor.imageRef": "docker.io/curlimages/curl:7.81.0",
+
+		"harbor.resources.requests.cpu":    "10m",
+		"harbor.resources.requests.memory": "16M",
+		"harbor.resources.limits.cpu":      "100m",
+		"harbor.resources.limits.memory":   "64M",
+	}, cm.Data)
+}
+
+func TestPlugin_GetScanJobSpec(t *testing.T) {
+	client := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-harbor-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"harbor.imageRef": "docker.io/curlimages/curl:7.81.0",
+				"harbor.insecure": "true",
+			},
+		}).Build()
+
+	instance := harbor.NewPlugin(fixedClock, ext.NewSimpleIDGenerator())
+
+	pluginContext := starboard.NewPluginContext().
+		WithName(harbor.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(client).
+		Get()
+
+	spec, secrets, err := instance.GetScanJobSpec(pluginContext, &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "app", Image: "harbor.acme.io/acme/backend/app:1.2.0"},
+						{Name: "db", Image: "harbor.acme.io/library/postgres:13"},
+					},
+				},
+			},
+		},
+	}, map[string]docker.Auth{
+		"app": {Username: "robot$acme", Password: "s3cret"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, secrets, 1)
+	assert.Equal(t, map[string][]byte{
+		"app.username": []byte("robot$acme"),
+		"app.password": []byte("s3cret"),
+	}, secrets[0].Data)
+
+	assert.Equal(t, "starboard-sa", spec.ServiceAccountName)
+	assert.Equal(t, corev1.RestartPolicyNever, spec.RestartPolicy)
+	require.Len(t, spec.Containers, 2)
+
+	command := `curl --silent --show-error --fail --location --insecure --header "Accept: application/json" --header "X-Accept-Vulnerabilities: application/vnd.security.vulnerability.report; version=1.1, application/vnd.scanner.adapter.vuln.report.harbor+json; version=1.0" ${HARBOR_USERNAME:+--user "$HARBOR_USERNAME:$HARBOR_PASSWORD"} "$HARBOR_REPORT_URL"`
+
+	assert.Equal(t, "app", spec.Containers[0].Name)
+	assert.Equal(t, []string{"/bin/sh"}, spec.Containers[0].Command)
+	assert.Equal(t, []string{"-c", command}, spec.Containers[0].Args)
+	assert.Equal(t, []corev1.EnvVar{
+		{
+			Name:  "HARBOR_REPORT_URL",
+			Value: "https://harbor.acme.io/api/v2.0/projects/acme/repositories/backend%252Fapp/artifacts/1.2.0/additions/vulnerabilities",
+		},
+		{
+			Name: "HARBOR_USERNAME",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secrets[0].Name},
+					Key:                  "app.username",
+					Optional:             pointer.BoolPtr(false),
+				},
+			},
+		},
+		{
+			Name: "HARBOR_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secrets[0].Name},
+					Key:                  "app.password",
+					Optional:             pointer.BoolPtr(false),
+				},
+			},
+		},
+	}, spec.Containers[0].Env)
+
+	assert.Equal(t, "db", spec.Containers[1].Name)
+	assert.Equal(t, []corev1.EnvVar{
+		{
+			Name:  "HARBOR_REPORT_URL",
+			Value: "https://harbor.acme.io/api/v2.0/projects/library/repositories/postgres/artifacts/13/additions/vulnerabilities",
+		},
+		{
+			Name: "HARBOR_USERNAME",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "starboard-harbor-config"},
+					Key:                  "harbor.username",
+					Optional:             pointer.BoolPtr(true),
+				},
+			},
+		},
+		{
+			Name: "HARBOR_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "starboard-harbor-config"},
+					Key:                  "harbor.password",
+					Optional:             pointer.BoolPtr(true),
+				},
+			},
+		},
+	}, spec.Containers[1].Env)
+}
+
+const sampleReport = `{
+  "application/vnd.security.vulnerability.report; version=1.1": {
+    "generated_at": "2022-01-12T10:32:14.858Z",
+    "scanner": {
+      "name": "Trivy",
+      "vendor": "Aqua Security",
+      "version": "v0.22.0"
+    },
+    "severity": "High",
+    "vulnerabilities": [
+      {
+        "id": "CVE-2021-3711",
+        "package": "libssl1.1",
+        "version": "1.1.1d-0+deb10u6",
+        "fix_version": "1.1.1d-0+deb10u7",
+        "severity": "Critical",
+        "description": "In order to decrypt SM2 encrypted data an application is expected to call the API function EVP_PKEY_decrypt().\nTypically an application will call this function twice.",
+        "links": [
+          "https://avd.aquasec.com/nvd/cve-2021-3711"
+        ],
+        "preferred_cvss": {
+          "score_v3": 9.8,
+          "score_v2": 7.5
+        }
+      },
+      {
+        "id": "CVE-2019-1551",
+        "package": "libssl1.1",
+        "version": "1.1.1d-0+deb10u6",
+        "fix_version": "",
+        "severity": "Negligible",
+        "description": "",
+        "links": null
+      }
+    ]
+  }
+}`
+
+func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
+	instance := harbor.NewPlugin(fixedClock, ext.NewSimpleIDGenerator())
+
+	t.Run("Should convert Harbor report", func(t *testing.T) {
+		data, err := instance.ParseVulnerabilityReportData(nil, "harbor.acme.io/library/openssl:1.1.1",
+			io.NopCloser(strings.NewReader(sampleReport)))
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(fixedTime),
+			Scanner: v1alpha1.Scanner{
+				Name:    "Trivy",
+				Vendor:  "Aqua Security",
+				Version: "v0.22.0",
+			},
+			Registry: v1alpha1.Registry{Server: "harbor.acme.io"},
+			Artifact: v1alpha1.Artifact{Repository: "library/openssl", Tag: "1.1.1"},
+			Summary: v1alpha1.VulnerabilitySummary{
+				CriticalCount: 1,
+				LowCount:      1,
+			},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{
+					VulnerabilityID:  "CVE-2021-3711",
+					Resource:         "libssl1.1",
+					InstalledVersion: "1.1.1d-0+deb10u6",
+					FixedVersion:     "1.1.1d-0+deb10u7",
+					Severity:         v1alpha1.SeverityCritical,
+					Title:            "In order to decrypt SM2 encrypted data an application is expected to call the API function EVP_PKEY_decrypt().",
+					Description:      "In order to decrypt SM2 encrypted data an application is expected to call the API function EVP_PKEY_decrypt().\nTypically an application will call this function twice.",
+					PrimaryLink:      "https://avd.aquasec.com/nvd/cve-2021-3711",
+					Links:            []string{"https://avd.aquasec.com/nvd/cve-2021-3711"},
+					Score:            pointer.Float64Ptr(9.8),
+				},
+				{
+					VulnerabilityID:  "CVE-2019-1551",
+					Resource:         "libssl1.1",
+					InstalledVersion: "1.1.1d-0+deb10u6",
+					Severity:         v1alpha1.SeverityLow,
+					Title:            "CVE-2019-1551",
+					Links:            []string{},
+				},
+			},
+		}, data)
+	})
+
+	t.Run("Should return error when image has not been scanned", func(t *testing.T) {
+		_, err := instance.ParseVulnerabilityReportData(nil, "harbor.acme.io/library/openssl:1.1.1",
+			io.NopCloser(strings.NewReader("{}")))
+		require.EqualError(t, err, "harbor has not scanned the image yet")
+	})
+}
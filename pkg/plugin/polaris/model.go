@@ -1,5 +1,7 @@
 package polaris
 
+import "encoding/json"
+
 type Report struct {
 	PolarisOutputVersion string       `json:"PolarisOutputVersion"`
 	SourceType           string       `json:"SourceType"`
@@ -42,3 +44,24 @@ type Check struct {
 	Severity string `json:"Severity"`
 	Category string `json:"Category"`
 }
+
+// ConfigFile models the Polaris configuration file stored under the
+// polaris.config.yaml key. Only the fields supported by Polaris are modeled,
+// so that unknown fields, e.g. misspelled ones, are rejected.
+type ConfigFile struct {
+	DisplayName                  string                     `json:"displayName,omitempty"`
+	Checks                       map[string]string          `json:"checks"`
+	CustomChecks                 map[string]json.RawMessage `json:"customChecks,omitempty"`
+	Exemptions                   []Exemption                `json:"exemptions,omitempty"`
+	DisallowExemptions           bool                       `json:"disallowExemptions,omitempty"`
+	DisallowConfigExemptions     bool                       `json:"disallowConfigExemptions,omitempty"`
+	DisallowAnnotationExemptions bool                       `json:"disallowAnnotationExemptions,omitempty"`
+	Mutations                    []string                   `json:"mutations,omitempty"`
+}
+
+type Exemption struct {
+	Namespace       string   `json:"namespace,omitempty"`
+	ControllerNames []string `json:"controllerNames,omitempty"`
+	ContainerNames  []string `json:"containerNames,omitempty"`
+	Rules           []string `json:"rules,omitempty"`
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -16,11 +17,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 )
 
 const (
 	polarisContainerName = "polaris"
 	configVolume         = "config"
+	manifestVolume       = "manifest"
+	keyManifest          = "starboard.manifest.yaml"
 )
 
 const (
@@ -272,6 +276,52 @@ func (c Config) setResourceLimit(configKey string, k8sResourceList *corev1.Resou
 	return nil
 }
 
+// Validate returns problems with configuration settings, e.g. fields of the
+// Polaris configuration file that are unknown or have invalid values, or nil
+// if the settings are valid.
+func (c Config) Validate() []error {
+	var errs []error
+	if _, err := c.GetImageRef(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := c.GetResourceRequirements(); err != nil {
+		errs = append(errs, err)
+	}
+	value, err := c.GetRequiredData(keyConfigYaml)
+	if err != nil {
+		return append(errs, err)
+	}
+	var file ConfigFile
+	if err := yaml.UnmarshalStrict([]byte(value), &file); err != nil {
+		return append(errs, fmt.Errorf("parsing %s: %w", keyConfigYaml, err))
+	}
+	var checks []string
+	for check := range file.Checks {
+		checks = append(checks, check)
+	}
+	sort.Strings(checks)
+	for _, check := range checks {
+		switch severity := file.Checks[check]; severity {
+		case "ignore", v1alpha1.ConfigAuditSeverityWarning, v1alpha1.ConfigAuditSeverityDanger:
+		default:
+			errs = append(errs, fmt.Errorf("invalid severity (%s) of check %s in %s; allowed values (ignore, %s, %s)",
+				severity, check, keyConfigYaml, v1alpha1.ConfigAuditSeverityWarning, v1alpha1.ConfigAuditSeverityDanger))
+		}
+	}
+	var customChecks []string
+	for check := range file.CustomChecks {
+		customChecks = append(customChecks, check)
+	}
+	sort.Strings(customChecks)
+	for _, check := range customChecks {
+		if _, ok := file.Checks[check]; !ok {
+			errs = append(errs, fmt.Errorf("custom check %s is never run, because its severity is not set in checks of %s",
+				check, keyConfigYaml))
+		}
+	}
+	return errs
+}
+
 type plugin struct {
 	clock ext.Clock
 }
@@ -342,6 +392,11 @@ func (p *plugin) newConfigFrom(ctx starboard.PluginContext) (Config, error) {
 	return Config{PluginConfig: pluginConfig}, nil
 }
 
+// ValidateConfig returns problems with the specified configuration settings.
+func (p *plugin) ValidateConfig(config starboard.PluginConfig) []error {
+	return Config{PluginConfig: config}.Validate()
+}
+
 func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, obj client.Object) (corev1.PodSpec, []*corev1.Secret, error) {
 	config, err := p.newConfigFrom(ctx)
 	if err != nil {
@@ -406,6 +461,56 @@ func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, obj client.Object)
 	}, nil, nil
 }
 
+// GetManifestScanJobSpec describes the pod that audits the manifest of the
+// specified object, which is copied to a Secret and mounted as a file,
+// because the spec returned by GetScanJobSpec reads the object from the
+// cluster.
+func (p *plugin) GetManifestScanJobSpec(ctx starboard.PluginContext, obj client.Object) (corev1.PodSpec, []*corev1.Secret, error) {
+	spec, _, err := p.GetScanJobSpec(ctx, obj)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	manifest, err := yaml.Marshal(obj)
+	if err != nil {
+		return corev1.PodSpec{}, nil, fmt.Errorf("marshalling manifest: %w", err)
+	}
+	secretName := configauditreport.GetScanJobName(obj) + "-volume"
+
+	spec.AutomountServiceAccountToken = pointer.BoolPtr(false)
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: manifestVolume,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+				Items: []corev1.KeyToPath{{
+					Key:  keyManifest,
+					Path: "manifest.yaml",
+				}},
+			},
+		},
+	})
+	container := &spec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      manifestVolume,
+		MountPath: "/manifest",
+		ReadOnly:  true,
+	})
+	container.Args = []string{
+		"-c",
+		"polaris audit --log-level error --config /etc/starboard/polaris.config.yaml --audit-path /manifest/manifest.yaml 2> /dev/null",
+	}
+
+	return spec, []*corev1.Secret{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: ctx.GetNamespace(),
+		},
+		StringData: map[string]string{
+			keyManifest: string(manifest),
+		},
+	}}, nil
+}
+
 func (p *plugin) GetContainerName() string {
 	return polarisContainerName
 }
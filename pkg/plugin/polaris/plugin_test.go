@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/plugin/polaris"
 	"github.com/aquasecurity/starboard/pkg/starboard"
@@ -102,6 +103,81 @@ func TestConfig_GetResourceRequirements(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		config         polaris.Config
+		expectedErrors []string
+	}{
+		{
+			name: "Should return no errors for default settings",
+			config: polaris.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"polaris.imageRef":    "quay.io/fairwinds/polaris:4.2",
+					"polaris.config.yaml": polaris.DefaultConfigYAML,
+				},
+			}},
+		},
+		{
+			name: "Should return error when config file is not set",
+			config: polaris.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"polaris.imageRef": "quay.io/fairwinds/polaris:4.2",
+				},
+			}},
+			expectedErrors: []string{
+				"property polaris.config.yaml not set",
+			},
+		},
+		{
+			name: "Should return error when config file has unknown fields",
+			config: polaris.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"polaris.imageRef": "quay.io/fairwinds/polaris:4.2",
+					"polaris.config.yaml": `checks:
+  hostIPCSet: danger
+exemption:
+  - namespace: kube-system
+`,
+				},
+			}},
+			expectedErrors: []string{
+				`parsing polaris.config.yaml: error unmarshaling JSON: while decoding JSON: json: unknown field "exemption"`,
+			},
+		},
+		{
+			name: "Should return errors for invalid checks",
+			config: polaris.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"polaris.resources.requests.cpu": "lots",
+					"polaris.config.yaml": `checks:
+  hostIPCSet: danger
+  hostPIDSet: critical
+customChecks:
+  imageRegistry:
+    successMessage: Image comes from allowed registries
+`,
+				},
+			}},
+			expectedErrors: []string{
+				"property polaris.imageRef not set",
+				"parsing resource definition polaris.resources.requests.cpu: lots quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+				"invalid severity (critical) of check hostPIDSet in polaris.config.yaml; allowed values (ignore, warning, danger)",
+				"custom check imageRegistry is never run, because its severity is not set in checks of polaris.config.yaml",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errs []string
+			for _, err := range tc.config.Validate() {
+				errs = append(errs, err.Error())
+			}
+			require.Equal(t, tc.expectedErrors, errs)
+		})
+	}
+}
+
 func TestPlugin_IsApplicable(t *testing.T) {
 
 	t.Run("Should always return true", func(t *testing.T) {
@@ -338,6 +414,52 @@ func TestPlugin_GetScanJobSpec(t *testing.T) {
 
 }
 
+func TestPlugin_GetManifestScanJobSpec(t *testing.T) {
+	g := NewGomegaWithT(t)
+	pluginContext := starboard.NewPluginContext().
+		WithName(string(starboard.Polaris)).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-polaris-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"polaris.imageRef": "quay.io/fairwinds/polaris:" + polarisVersion,
+			},
+		}).Build()).Get()
+	obj := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	instance := polaris.NewPlugin(fixedClock)
+	jobSpec, secrets, err := instance.(configauditreport.ManifestPlugin).GetManifestScanJobSpec(pluginContext, obj)
+
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(jobSpec.AutomountServiceAccountToken).To(Equal(pointer.BoolPtr(false)))
+	g.Expect(jobSpec.Containers[0].Args).To(Equal([]string{
+		"-c",
+		"polaris audit --log-level error --config /etc/starboard/polaris.config.yaml --audit-path /manifest/manifest.yaml 2> /dev/null",
+	}))
+	g.Expect(jobSpec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+		Name:      "manifest",
+		MountPath: "/manifest",
+		ReadOnly:  true,
+	}))
+	g.Expect(secrets).To(HaveLen(1))
+	g.Expect(secrets[0].Name).To(Equal(configauditreport.GetScanJobName(obj) + "-volume"))
+	g.Expect(secrets[0].Namespace).To(Equal("starboard-ns"))
+	g.Expect(secrets[0].StringData["starboard.manifest.yaml"]).To(ContainSubstring("name: nginx"))
+}
+
 func TestPlugin_GetContainerName(t *testing.T) {
 	g := NewGomegaWithT(t)
 	plugin := polaris.NewPlugin(fixedClock)
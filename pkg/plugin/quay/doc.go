@@ -0,0 +1,3 @@
+// Package quay provides primitives for importing vulnerability scan results
+// of container images hosted by Quay.
+package quay
@@ -0,0 +1,110 @@
+package quay
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+const (
+	// statusScanned is the status of the security scan of a manifest that
+	// has already been indexed by Clair.
+	statusScanned = "scanned"
+)
+
+// SecurityInfo represents the response of the Quay API endpoint that returns
+// the security information of a manifest.
+type SecurityInfo struct {
+	Status string        `json:"status"`
+	Data   *SecurityData `json:"data"`
+}
+
+type SecurityData struct {
+	Layer Layer `json:"Layer"`
+}
+
+type Layer struct {
+	Name          string    `json:"Name"`
+	NamespaceName string    `json:"NamespaceName"`
+	Features      []Feature `json:"Features"`
+}
+
+// Feature is a package detected in a manifest.
+type Feature struct {
+	Name            string          `json:"Name"`
+	Version         string          `json:"Version"`
+	AddedBy         string          `json:"AddedBy"`
+	Vulnerabilities []Vulnerability `json:"Vulnerabilities"`
+}
+
+type Vulnerability struct {
+	Name        string    `json:"Name"`
+	Severity    string    `json:"Severity"`
+	Link        string    `json:"Link"`
+	FixedBy     string    `json:"FixedBy"`
+	Description string    `json:"Description"`
+	Metadata    *Metadata `json:"Metadata"`
+}
+
+type Metadata struct {
+	NVD *NVD `json:"NVD,omitempty"`
+}
+
+type NVD struct {
+	CVSSv3 *CVSS `json:"CVSSv3,omitempty"`
+	CVSSv2 *CVSS `json:"CVSSv2,omitempty"`
+}
+
+type CVSS struct {
+	Vectors string   `json:"Vectors"`
+	Score   *float64 `json:"Score"`
+}
+
+// toSeverity maps Clair severity levels to v1alpha1.Severity.
+func toSeverity(severity string) v1alpha1.Severity {
+	switch strings.ToLower(severity) {
+	case "critical", "defcon1":
+		return v1alpha1.SeverityCritical
+	case "high":
+		return v1alpha1.SeverityHigh
+	case "medium":
+		return v1alpha1.SeverityMedium
+	case "low", "negligible":
+		return v1alpha1.SeverityLow
+	default:
+		return v1alpha1.SeverityUnknown
+	}
+}
+
+// toTitle returns the first line of the description, because Clair does not
+// return titles of vulnerabilities.
+func toTitle(v Vulnerability) string {
+	title := strings.TrimSpace(strings.SplitN(strings.TrimSpace(v.Description), "\n", 2)[0])
+	if title == "" {
+		return v.Name
+	}
+	return title
+}
+
+// toLinks splits the Link property, which may hold several URLs separated
+// with whitespaces.
+func toLinks(v Vulnerability) []string {
+	links := strings.Fields(v.Link)
+	if links == nil {
+		links = []string{}
+	}
+	return links
+}
+
+func toScore(metadata *Metadata) *float64 {
+	if metadata == nil || metadata.NVD == nil {
+		return nil
+	}
+	if metadata.NVD.CVSSv3 != nil && metadata.NVD.CVSSv3.Score != nil {
+		return metadata.NVD.CVSSv3.Score
+	}
+	if metadata.NVD.CVSSv2 != nil {
+		return metadata.NVD.CVSSv2.Score
+	}
+	return nil
+}
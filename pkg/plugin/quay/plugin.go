@@ -0,0 +1,375 @@
+package quay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Plugin the name of this plugin.
+	Plugin = "Quay"
+)
+
+const (
+	keyQuayImageRef  = "quay.imageRef"
+	keyQuayServerURL = "quay.serverURL"
+	keyQuayInsecure  = "quay.insecure"
+	keyQuayToken     = "quay.token"
+
+	keyResourcesRequestsCPU    = "quay.resources.requests.cpu"
+	keyResourcesRequestsMemory = "quay.resources.requests.memory"
+	keyResourcesLimitsCPU      = "quay.resources.limits.cpu"
+	keyResourcesLimitsMemory   = "quay.resources.limits.memory"
+)
+
+// Config defines configuration params for this plugin.
+type Config struct {
+	starboard.PluginConfig
+}
+
+// GetImageRef returns the reference of the container image with the curl
+// command, which is used to fetch security information from Quay.
+func (c Config) GetImageRef() (string, error) {
+	return c.GetRequiredData(keyQuayImageRef)
+}
+
+// GetServerURL returns the URL of Quay API. It returns an empty string if
+// the URL should be derived from the registry server of a scanned image.
+func (c Config) GetServerURL() string {
+	return strings.TrimSuffix(c.Data[keyQuayServerURL], "/")
+}
+
+// IsInsecure returns true if the TLS certificate of Quay API should not be
+// verified.
+func (c Config) IsInsecure() bool {
+	return c.Data[keyQuayInsecure] == "true"
+}
+
+// GetResourceRequirements creates ResourceRequirements from the Config.
+func (c Config) GetResourceRequirements() (corev1.ResourceRequirements, error) {
+	requirements := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+
+	err := c.setResourceLimit(keyResourcesRequestsCPU, &requirements.Requests, corev1.ResourceCPU)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyResourcesRequestsMemory, &requirements.Requests, corev1.ResourceMemory)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyResourcesLimitsCPU, &requirements.Limits, corev1.ResourceCPU)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyResourcesLimitsMemory, &requirements.Limits, corev1.ResourceMemory)
+	if err != nil {
+		return requirements, err
+	}
+
+	return requirements, nil
+}
+
+func (c Config) setResourceLimit(configKey string, k8sResourceList *corev1.ResourceList, k8sResourceName corev1.ResourceName) error {
+	if value, found := c.Data[configKey]; found {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("parsing resource definition %s: %s %w", configKey, value, err)
+		}
+
+		(*k8sResourceList)[k8sResourceName] = quantity
+	}
+	return nil
+}
+
+type plugin struct {
+	clock ext.Clock
+}
+
+// NewPlugin constructs a new vulnerabilityreport.Plugin, which imports
+// results of vulnerability scans that Quay security scanner, i.e. Clair, has
+// already run for container images hosted by Quay.
+//
+// The scan job fetches security information of image manifests from Quay API
+// with the curl command. Images referenced by tags are resolved to manifest
+// digests with Quay API before fetching the security information. Quay API
+// does not accept image pull credentials, hence the plugin authenticates with
+// the OAuth access token configured with the quay.token key.
+func NewPlugin(clock ext.Clock) vulnerabilityreport.Plugin {
+	return &plugin{
+		clock: clock,
+	}
+}
+
+// Init ensures the default Config required by this plugin.
+func (p *plugin) Init(ctx starboard.PluginContext) error {
+	return ctx.EnsureConfig(starboard.PluginConfig{
+		Data: map[string]string{
+			keyQuayImageRef: "docker.io/curlimages/curl:7.81.0",
+
+			keyResourcesRequestsCPU:    "10m",
+			keyResourcesRequestsMemory: "16M",
+			keyResourcesLimitsCPU:      "100m",
+			keyResourcesLimitsMemory:   "64M",
+		},
+	})
+}
+
+func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, _ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	spec, err := kube.GetPodSpec(workload)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	imageRef, err := config.GetImageRef()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	requirements, err := config.GetResourceRequirements()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	var containers []corev1.Container
+	for _, c := range spec.Containers {
+		env, err := p.getEnv(config, c.Image)
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
+
+		containers = append(containers, corev1.Container{
+			Name:                     c.Name,
+			Image:                    imageRef,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			Env:                      env,
+			Command: []string{
+				"/bin/sh",
+			},
+			Args: []string{
+				"-c",
+				p.getScript(config),
+			},
+			Resources: requirements,
+			SecurityContext: &corev1.SecurityContext{
+				Privileged:               pointer.BoolPtr(false),
+				AllowPrivilegeEscalation: pointer.BoolPtr(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"all"},
+				},
+				ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+			},
+		})
+	}
+
+	return corev1.PodSpec{
+		Affinity:                     starboard.LinuxNodeAffinity(),
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ServiceAccountName:           ctx.GetServiceAccountName(),
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		Containers:                   containers,
+	}, nil, nil
+}
+
+// getEnv returns environment variables of the scan job container, i.e. the
+// URL of Quay API endpoint of the image repository, the manifest digest or
+// the tag of the image, and the optional access token.
+func (p *plugin) getEnv(config Config, imageRef string) ([]corev1.EnvVar, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, err
+	}
+	serverURL := config.GetServerURL()
+	if serverURL == "" {
+		serverURL = "https://" + ref.Context().RegistryStr()
+	}
+	segments := strings.Split(ref.Context().RepositoryStr(), "/")
+	if len(segments) < 2 {
+		return nil, fmt.Errorf("image %s is not hosted in a Quay organization", imageRef)
+	}
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	env := []corev1.EnvVar{
+		{
+			Name:  "QUAY_REPOSITORY_URL",
+			Value: fmt.Sprintf("%s/api/v1/repository/%s", serverURL, strings.Join(segments, "/")),
+		},
+	}
+	switch t := ref.(type) {
+	case name.Digest:
+		env = append(env, corev1.EnvVar{Name: "QUAY_MANIFEST_DIGEST", Value: t.DigestStr()})
+	case name.Tag:
+		env = append(env, corev1.EnvVar{Name: "QUAY_TAG", Value: url.QueryEscape(t.TagStr())})
+	}
+	env = append(env, corev1.EnvVar{
+		Name: "QUAY_TOKEN",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: starboard.GetPluginConfigMapName(Plugin),
+				},
+				Key:      keyQuayToken,
+				Optional: pointer.BoolPtr(true),
+			},
+		},
+	})
+	return env, nil
+}
+
+// getScript returns the shell script that prints the security information
+// of the image manifest to the standard output. If the image is referenced by
+// a tag, the script first looks up the digest of the manifest the tag points
+// to. The access token is passed only if it is set, which allows reading
+// security information of public repositories.
+func (p *plugin) getScript(config Config) string {
+	curl := []string{
+		"curl",
+		"--silent",
+		"--show-error",
+		"--fail",
+		"--location",
+	}
+	if config.IsInsecure() {
+		curl = append(curl, "--insecure")
+	}
+	curl = append(curl,
+		"--header", `"Accept: application/json"`,
+		`${QUAY_TOKEN:+--header "Authorization: Bearer $QUAY_TOKEN"}`,
+		`"$@"`,
+	)
+	return strings.Join([]string{
+		"set -e",
+		fmt.Sprintf("quay() { %s; }", strings.Join(curl, " ")),
+		`DIGEST="$QUAY_MANIFEST_DIGEST"`,
+		`if [ -z "$DIGEST" ]; then DIGEST=$(quay "$QUAY_REPOSITORY_URL/tag/?specificTag=$QUAY_TAG&onlyActiveTags=true" | sed -n 's/.*"manifest_digest": *"\([^"]*\)".*/\1/p'); fi`,
+		`if [ -z "$DIGEST" ]; then echo "tag $QUAY_TAG not found" >&2; exit 1; fi`,
+		`quay "$QUAY_REPOSITORY_URL/manifest/$DIGEST/security?vulnerabilities=true"`,
+	}, "\n")
+}
+
+func (p *plugin) ParseVulnerabilityReportData(_ starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	var info SecurityInfo
+	err := json.NewDecoder(logsReader).Decode(&info)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	if info.Status != statusScanned || info.Data == nil {
+		return v1alpha1.VulnerabilityReportData{}, fmt.Errorf("quay has not scanned the image yet: status %q", info.Status)
+	}
+
+	vulnerabilities := make([]v1alpha1.Vulnerability, 0)
+	for _, f := range info.Data.Layer.Features {
+		for _, v := range f.Vulnerabilities {
+			links := toLinks(v)
+			var primaryLink string
+			if len(links) > 0 {
+				primaryLink = links[0]
+			}
+			vulnerabilities = append(vulnerabilities, v1alpha1.Vulnerability{
+				VulnerabilityID:  v.Name,
+				Resource:         f.Name,
+				InstalledVersion: f.Version,
+				FixedVersion:     v.FixedBy,
+				Severity:         toSeverity(v.Severity),
+				Title:            toTitle(v),
+				Description:      v.Description,
+				PrimaryLink:      primaryLink,
+				Links:            links,
+				Score:            toScore(v.Metadata),
+			})
+		}
+	}
+
+	registry, artifact, err := p.parseImageRef(imageRef)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+
+	return v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(p.clock.Now()),
+		Scanner: v1alpha1.Scanner{
+			Name:   "Clair",
+			Vendor: "Quay",
+		},
+		Registry:        registry,
+		Artifact:        artifact,
+		Summary:         p.toSummary(vulnerabilities),
+		Vulnerabilities: vulnerabilities,
+	}, nil
+}
+
+func (p *plugin) newConfigFrom(ctx starboard.PluginContext) (Config, error) {
+	pluginConfig, err := ctx.GetConfig()
+	if err != nil {
+		return Config{}, err
+	}
+	return Config{PluginConfig: pluginConfig}, nil
+}
+
+func (p *plugin) toSummary(vulnerabilities []v1alpha1.Vulnerability) v1alpha1.VulnerabilitySummary {
+	var vs v1alpha1.VulnerabilitySummary
+	for _, v := range vulnerabilities {
+		switch v.Severity {
+		case v1alpha1.SeverityCritical:
+			vs.CriticalCount++
+		case v1alpha1.SeverityHigh:
+			vs.HighCount++
+		case v1alpha1.SeverityMedium:
+			vs.MediumCount++
+		case v1alpha1.SeverityLow:
+			vs.LowCount++
+		default:
+			vs.UnknownCount++
+		}
+	}
+	return vs
+}
+
+func (p *plugin) parseImageRef(imageRef string) (v1alpha1.Registry, v1alpha1.Artifact, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return v1alpha1.Registry{}, v1alpha1.Artifact{}, err
+	}
+	registry := v1alpha1.Registry{
+		Server: ref.Context().RegistryStr(),
+	}
+	artifact := v1alpha1.Artifact{
+		Repository: ref.Context().RepositoryStr(),
+	}
+	switch t := ref.(type) {
+	case name.Tag:
+		artifact.Tag = t.TagStr()
+	case name.Digest:
+		artifact.Digest = t.DigestStr()
+	}
+	return registry, artifact, nil
+}
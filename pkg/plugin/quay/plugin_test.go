@@ -0,0 +1,244 @@
+package quay_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/plugin/quay"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	fixedTime  = time.Now()
+	fixedClock = ext.NewFixedClock(fixedTime)
+)
+
+func TestPlugin_Init(t *testing.T) {
+	client := fake.NewClientBuilder().WithObjects().Build()
+
+	instance := quay.NewPlugin(fixedClock)
+
+	pluginContext := starboard.NewPluginContext().
+		WithName(quay.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(client).
+		Get()
+	err := instance.Init(pluginContext)
+	require.NoError(t, err)
+
+	var cm corev1.ConfigMap
+	err = client.Get(context.Background(), types.NamespacedName{
+		Namespace: "starboard-ns",
+		Name:      "starboard-quay-config",
+	}, &cm)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"quay.imageRef": "docker.io/curlimages/curl:7.81.0",
+
+		"quay.resources.requests.cpu":    "10m",
+		"quay.resources.requests.memory": "16M",
+		"quay.resources.limits.cpu":      "100m",
+		"quay.resources.limits.memory":   "64M",
+	}, cm.Data)
+}
+
+func TestPlugin_GetScanJobSpec(t *testing.T) {
+	client := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-quay-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"quay.imageRef":  "docker.io/curlimages/curl:7.81.0",
+				"quay.serverURL": "https://quay.internal/",
+				"quay.insecure":  "true",
+			},
+		}).Build()
+
+	instance := quay.NewPlugin(fixedClock)
+
+	pluginContext := starboard.NewPluginContext().
+		WithName(quay.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(client).
+		Get()
+
+	spec, secrets, err := instance.GetScanJobSpec(pluginContext, &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "app", Image: "quay.io/acme/app:1.2.0"},
+						{Name: "db", Image: "quay.io/acme/postgres@sha256:2cf6a0e91f2b6a6fa5b4e1d7f7b3c1d6e4b5a8c9d0e1f2a3b4c5d6e7f8a9b0c1"},
+					},
+				},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, secrets)
+
+	assert.Equal(t, "starboard-sa", spec.ServiceAccountName)
+	assert.Equal(t, corev1.RestartPolicyNever, spec.RestartPolicy)
+	require.Len(t, spec.Containers, 2)
+
+	script := `set -e
+quay() { curl --silent --show-error --fail --location --insecure --header "Accept: application/json" ${QUAY_TOKEN:+--header "Authorization: Bearer $QUAY_TOKEN"} "$@"; }
+DIGEST="$QUAY_MANIFEST_DIGEST"
+if [ -z "$DIGEST" ]; then DIGEST=$(quay "$QUAY_REPOSITORY_URL/tag/?specificTag=$QUAY_TAG&onlyActiveTags=true" | sed -n 's/.*"manifest_digest": *"\([^"]*\)".*/\1/p'); fi
+if [ -z "$DIGEST" ]; then echo "tag $QUAY_TAG not found" >&2; exit 1; fi
+quay "$QUAY_REPOSITORY_URL/manifest/$DIGEST/security?vulnerabilities=true"`
+
+	tokenEnv := corev1.EnvVar{
+		Name: "QUAY_TOKEN",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "starboard-quay-config"},
+				Key:                  "quay.token",
+				Optional:             pointer.BoolPtr(true),
+			},
+		},
+	}
+
+	assert.Equal(t, "app", spec.Containers[0].Name)
+	assert.Equal(t, []string{"/bin/sh"}, spec.Containers[0].Command)
+	assert.Equal(t, []string{"-c", script}, spec.Containers[0].Args)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "QUAY_REPOSITORY_URL", Value: "https://quay.internal/api/v1/repository/acme/app"},
+		{Name: "QUAY_TAG", Value: "1.2.0"},
+		tokenEnv,
+	}, spec.Containers[0].Env)
+
+	assert.Equal(t, "db", spec.Containers[1].Name)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "QUAY_REPOSITORY_URL", Value: "https://quay.internal/api/v1/repository/acme/postgres"},
+		{Name: "QUAY_MANIFEST_DIGEST", Value: "sha256:2cf6a0e91f2b6a6fa5b4e1d7f7b3c1d6e4b5a8c9d0e1f2a3b4c5d6e7f8a9b0c1"},
+		tokenEnv,
+	}, spec.Containers[1].Env)
+}
+
+const sampleSecurityInfo = `{
+  "status": "scanned",
+  "data": {
+    "Layer": {
+      "Name": "sha256:2cf6a0e91f2b6a6fa5b4e1d7f7b3c1d6e4b5a8c9d0e1f2a3b4c5d6e7f8a9b0c1",
+      "NamespaceName": "",
+      "Features": [
+        {
+          "Name": "openssl",
+          "Version": "1.1.1k-r0",
+          "AddedBy": "sha256:a0d0a0d46f8b52473982a3c466318f479767577551a53ffc9074c9fa7035982e",
+          "Vulnerabilities": [
+            {
+              "Name": "CVE-2021-3711",
+              "Severity": "Critical",
+              "Link": "https://www.cve.org/CVERecord?id=CVE-2021-3711 https://nvd.nist.gov/vuln/detail/CVE-2021-3711",
+              "FixedBy": "1.1.1l-r0",
+              "Description": "In order to decrypt SM2 encrypted data an application is expected to call the API function EVP_PKEY_decrypt().\nTypically an application will call this function twice.",
+              "Metadata": {
+                "NVD": {
+                  "CVSSv3": {
+                    "Vectors": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+                    "Score": 9.8
+                  }
+                }
+              }
+            },
+            {
+              "Name": "CVE-2019-1551",
+              "Severity": "Negligible",
+              "Link": "",
+              "FixedBy": "",
+              "Description": "",
+              "Metadata": null
+            }
+          ]
+        },
+        {
+          "Name": "musl",
+          "Version": "1.2.2-r0",
+          "AddedBy": "sha256:a0d0a0d46f8b52473982a3c466318f479767577551a53ffc9074c9fa7035982e",
+          "Vulnerabilities": []
+        }
+      ]
+    }
+  }
+}`
+
+func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
+	instance := quay.NewPlugin(fixedClock)
+
+	t.Run("Should convert Quay security information", func(t *testing.T) {
+		data, err := instance.ParseVulnerabilityReportData(nil, "quay.io/acme/openssl:1.1.1",
+			io.NopCloser(strings.NewReader(sampleSecurityInfo)))
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(fixedTime),
+			Scanner: v1alpha1.Scanner{
+				Name:   "Clair",
+				Vendor: "Quay",
+			},
+			Registry: v1alpha1.Registry{Server: "quay.io"},
+			Artifact: v1alpha1.Artifact{Repository: "acme/openssl", Tag: "1.1.1"},
+			Summary: v1alpha1.VulnerabilitySummary{
+				CriticalCount: 1,
+				LowCount:      1,
+			},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{
+					VulnerabilityID:  "CVE-2021-3711",
+					Resource:         "openssl",
+					InstalledVersion: "1.1.1k-r0",
+					FixedVersion:     "1.1.1l-r0",
+					Severity:         v1alpha1.SeverityCritical,
+					Title:            "In order to decrypt SM2 encrypted data an application is expected to call the API function EVP_PKEY_decrypt().",
+					Description:      "In order to decrypt SM2 encrypted data an application is expected to call the API function EVP_PKEY_decrypt().\nTypically an application will call this function twice.",
+					PrimaryLink:      "https://www.cve.org/CVERecord?id=CVE-2021-3711",
+					Links: []string{
+						"https://www.cve.org/CVERecord?id=CVE-2021-3711",
+						"https://nvd.nist.gov/vuln/detail/CVE-2021-3711",
+					},
+					Score: pointer.Float64Ptr(9.8),
+				},
+				{
+					VulnerabilityID:  "CVE-2019-1551",
+					Resource:         "openssl",
+					InstalledVersion: "1.1.1k-r0",
+					Severity:         v1alpha1.SeverityLow,
+					Title:            "CVE-2019-1551",
+					Links:            []string{},
+				},
+			},
+		}, data)
+	})
+
+	t.Run("Should return error when image has not been scanned", func(t *testing.T) {
+		_, err := instance.ParseVulnerabilityReportData(nil, "quay.io/acme/openssl:1.1.1",
+			io.NopCloser(strings.NewReader(`{"status": "queued", "data": null}`)))
+		require.EqualError(t, err, `quay has not scanned the image yet: status "queued"`)
+	})
+}
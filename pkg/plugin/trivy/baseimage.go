@@ -0,0 +1,52 @@
+package trivy
+
+import (
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+// Labels of the base image defined by pre-defined annotations of the OCI image spec.
+const (
+	LabelBaseImageName   = "org.opencontainers.image.base.name"
+	LabelBaseImageDigest = "org.opencontainers.image.base.digest"
+)
+
+// DetectBaseImage returns the probable base image of the image scanned by
+// Trivy, or nil if it cannot be detected.
+//
+// The base image recorded in OCI image labels takes precedence. Otherwise, the
+// base image is the image of the OS distribution, e.g. alpine:3.10.2, which is
+// a good guess for most images built from official base images. Only the
+// vulnerabilities of OS packages are attributed to the base image.
+func DetectBaseImage(report ScanReport) *v1alpha1.BaseImage {
+	metadata := report.Metadata
+	var baseImage *v1alpha1.BaseImage
+	if name := metadata.ImageConfig.Config.Labels[LabelBaseImageName]; name != "" {
+		baseImage = &v1alpha1.BaseImage{
+			Name:   name,
+			Digest: metadata.ImageConfig.Config.Labels[LabelBaseImageDigest],
+			Source: v1alpha1.BaseImageSourceLabel,
+		}
+	} else if metadata.OS != nil && metadata.OS.Family != "" && metadata.OS.Name != "" {
+		baseImage = &v1alpha1.BaseImage{
+			Name:   metadata.OS.Family + ":" + metadata.OS.Name,
+			Source: v1alpha1.BaseImageSourceOS,
+		}
+	} else {
+		return nil
+	}
+	if metadata.OS != nil {
+		baseImage.EndOfServiceLife = metadata.OS.EOSL
+	}
+	for _, result := range report.Results {
+		if !isOSPkgsResult(result, metadata.OS) {
+			continue
+		}
+		for _, vulnerability := range result.Vulnerabilities {
+			if vulnerability.FixedVersion != "" {
+				baseImage.FixableCount++
+			}
+		}
+	}
+	baseImage.UpdateAvailable = baseImage.FixableCount > 0 || baseImage.EndOfServiceLife
+	return baseImage
+}
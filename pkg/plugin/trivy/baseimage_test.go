@@ -0,0 +1,133 @@
+package trivy_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectBaseImage(t *testing.T) {
+	results := []trivy.ScanResult{
+		{
+			Target: "alpine:3.10.2 (alpine 3.10.2)",
+			Class:  "os-pkgs",
+			Type:   "alpine",
+			Vulnerabilities: []trivy.Vulnerability{
+				{VulnerabilityID: "CVE-2019-1549", FixedVersion: "1.1.1d-r0"},
+				{VulnerabilityID: "CVE-2019-1563", FixedVersion: "1.1.1d-r0"},
+				{VulnerabilityID: "CVE-2019-1547"},
+			},
+		},
+		{
+			Target: "app/package-lock.json",
+			Class:  "lang-pkgs",
+			Type:   "npm",
+			Vulnerabilities: []trivy.Vulnerability{
+				{VulnerabilityID: "CVE-2021-23337", FixedVersion: "4.17.21"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		report   trivy.ScanReport
+		expected *v1alpha1.BaseImage
+	}{
+		{
+			name:   "Should return nil when metadata is not reported",
+			report: trivy.ScanReport{Results: results},
+		},
+		{
+			name: "Should detect base image from OCI labels",
+			report: trivy.ScanReport{
+				Metadata: trivy.Metadata{
+					OS: &trivy.OS{Family: "alpine", Name: "3.10.2"},
+					ImageConfig: trivy.ImageConfig{
+						Config: trivy.ConfigDetail{
+							Labels: map[string]string{
+								"org.opencontainers.image.base.name":   "docker.io/library/alpine:3.10",
+								"org.opencontainers.image.base.digest": "sha256:e4355b66995c96b4b468159fc5c7e3540fcef961189ca13fee877798649f531a",
+							},
+						},
+					},
+				},
+				Results: results,
+			},
+			expected: &v1alpha1.BaseImage{
+				Name:            "docker.io/library/alpine:3.10",
+				Digest:          "sha256:e4355b66995c96b4b468159fc5c7e3540fcef961189ca13fee877798649f531a",
+				Source:          v1alpha1.BaseImageSourceLabel,
+				FixableCount:    2,
+				UpdateAvailable: true,
+			},
+		},
+		{
+			name: "Should detect base image from OS",
+			report: trivy.ScanReport{
+				Metadata: trivy.Metadata{
+					OS: &trivy.OS{Family: "alpine", Name: "3.10.2"},
+				},
+				Results: results,
+			},
+			expected: &v1alpha1.BaseImage{
+				Name:            "alpine:3.10.2",
+				Source:          v1alpha1.BaseImageSourceOS,
+				FixableCount:    2,
+				UpdateAvailable: true,
+			},
+		},
+		{
+			name: "Should match results by type when class is not reported",
+			report: trivy.ScanReport{
+				Metadata: trivy.Metadata{
+					OS: &trivy.OS{Family: "alpine", Name: "3.10.2"},
+				},
+				Results: []trivy.ScanResult{
+					{Target: "alpine:3.10.2 (alpine 3.10.2)", Type: "alpine", Vulnerabilities: results[0].Vulnerabilities},
+					{Target: "app/package-lock.json", Type: "npm", Vulnerabilities: results[1].Vulnerabilities},
+				},
+			},
+			expected: &v1alpha1.BaseImage{
+				Name:            "alpine:3.10.2",
+				Source:          v1alpha1.BaseImageSourceOS,
+				FixableCount:    2,
+				UpdateAvailable: true,
+			},
+		},
+		{
+			name: "Should recommend update of end of service life OS without fixable vulnerabilities",
+			report: trivy.ScanReport{
+				Metadata: trivy.Metadata{
+					OS: &trivy.OS{Family: "debian", Name: "8.11", EOSL: true},
+				},
+			},
+			expected: &v1alpha1.BaseImage{
+				Name:             "debian:8.11",
+				Source:           v1alpha1.BaseImageSourceOS,
+				EndOfServiceLife: true,
+				UpdateAvailable:  true,
+			},
+		},
+		{
+			name: "Should not recommend update without fixable vulnerabilities",
+			report: trivy.ScanReport{
+				Metadata: trivy.Metadata{
+					OS: &trivy.OS{Family: "alpine", Name: "3.14.2"},
+				},
+				Results: results[1:],
+			},
+			expected: &v1alpha1.BaseImage{
+				Name:   "alpine:3.14.2",
+				Source: v1alpha1.BaseImageSourceOS,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, trivy.DetectBaseImage(tc.report))
+		})
+	}
+}
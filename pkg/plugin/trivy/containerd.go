@@ -0,0 +1,133 @@
+package trivy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ContainerdImages represents images that are scanned from the content store
+// of the containerd of the node where the scanned workload runs, instead of
+// being pulled from registries.
+type ContainerdImages string
+
+const (
+	// ContainerdImagesPullNever selects images of containers with the Never
+	// image pull policy, which are usually built or loaded on nodes and are
+	// not available in any registry.
+	ContainerdImagesPullNever ContainerdImages = "PullNever"
+	// ContainerdImagesAll selects images of all containers.
+	ContainerdImagesAll ContainerdImages = "All"
+)
+
+const (
+	keyTrivyContainerdSocket    = "trivy.containerd.socket"
+	keyTrivyContainerdNamespace = "trivy.containerd.namespace"
+	keyTrivyContainerdImages    = "trivy.containerd.images"
+
+	containerdSocketVolumeName = "containerd"
+	containerdSocketMountPath  = "/run/containerd/containerd.sock"
+
+	defaultContainerdNamespace = "k8s.io"
+)
+
+// GetContainerdSocket returns the path of the containerd socket on nodes, or
+// an empty string if images are never scanned from containerd.
+func (c Config) GetContainerdSocket() string {
+	return strings.TrimSpace(c.Data[keyTrivyContainerdSocket])
+}
+
+// GetContainerdNamespace returns the containerd namespace of images pulled by
+// the kubelet.
+func (c Config) GetContainerdNamespace() string {
+	if value, ok := c.Data[keyTrivyContainerdNamespace]; ok {
+		return value
+	}
+	return defaultContainerdNamespace
+}
+
+// GetContainerdImages returns which images are scanned from containerd.
+func (c Config) GetContainerdImages() (ContainerdImages, error) {
+	value, ok := c.Data[keyTrivyContainerdImages]
+	if !ok {
+		return ContainerdImagesPullNever, nil
+	}
+	switch ContainerdImages(value) {
+	case ContainerdImagesPullNever:
+		return ContainerdImagesPullNever, nil
+	case ContainerdImagesAll:
+		return ContainerdImagesAll, nil
+	}
+	return "", fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s)",
+		value, keyTrivyContainerdImages, ContainerdImagesPullNever, ContainerdImagesAll)
+}
+
+// withContainerd modifies the pod spec of a scan job so that images of the
+// specified workload's containers selected by Config.GetContainerdImages are
+// scanned from the containerd of the node where the workload runs. The scan
+// job is scheduled on that node, and containers that scan such images mount
+// the containerd socket and run as root, which is required to connect to it.
+//
+// The pod spec is not modified if Config.GetContainerdSocket is not set, or
+// none of the images is selected.
+func (p *plugin) withContainerd(config Config, workload client.Object, spec corev1.PodSpec, podSpec *corev1.PodSpec) error {
+	socket := config.GetContainerdSocket()
+	if socket == "" {
+		return nil
+	}
+	images, err := config.GetContainerdImages()
+	if err != nil {
+		return err
+	}
+	selected := make(map[string]bool)
+	for _, c := range spec.Containers {
+		if images == ContainerdImagesAll || c.ImagePullPolicy == corev1.PullNever {
+			selected[c.Name] = true
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+
+	nodeName, err := p.objectResolver.GetNodeName(context.Background(), workload)
+	if err != nil {
+		return fmt.Errorf("getting node of images scanned from containerd: %w", err)
+	}
+	podSpec.NodeName = nodeName
+	hostPathType := corev1.HostPathSocket
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: containerdSocketVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: socket,
+				Type: &hostPathType,
+			},
+		},
+	})
+	for i := range podSpec.Containers {
+		c := &podSpec.Containers[i]
+		if !selected[c.Name] {
+			continue
+		}
+		c.Env = append(c.Env, corev1.EnvVar{
+			Name:  "CONTAINERD_ADDRESS",
+			Value: containerdSocketMountPath,
+		}, corev1.EnvVar{
+			Name:  "CONTAINERD_NAMESPACE",
+			Value: config.GetContainerdNamespace(),
+		})
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      containerdSocketVolumeName,
+			MountPath: containerdSocketMountPath,
+			ReadOnly:  true,
+		})
+		if c.SecurityContext != nil {
+			c.SecurityContext.RunAsUser = pointer.Int64(0)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package trivy_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfig_GetContainerdImages(t *testing.T) {
+	testCases := []struct {
+		name           string
+		configData     map[string]string
+		expectedImages trivy.ContainerdImages
+		expectedError  string
+	}{
+		{
+			name:           "Should return PullNever when key is not set",
+			configData:     map[string]string{},
+			expectedImages: trivy.ContainerdImagesPullNever,
+		},
+		{
+			name:           "Should return All",
+			configData:     map[string]string{"trivy.containerd.images": "All"},
+			expectedImages: trivy.ContainerdImagesAll,
+		},
+		{
+			name:          "Should return error when value is not allowed",
+			configData:    map[string]string{"trivy.containerd.images": "Local"},
+			expectedError: "invalid value (Local) of trivy.containerd.images; allowed values (PullNever, All)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: tc.configData}}
+			images, err := config.GetContainerdImages()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedImages, images)
+		})
+	}
+}
+
+func TestPlugin_GetScanJobSpec_WithContainerd(t *testing.T) {
+	workload := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ReplicaSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-6799fc88d8",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "app"},
+			},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "app", Image: "app:dev", ImagePullPolicy: corev1.PullNever},
+						{Name: "nginx", Image: "nginx:1.16"},
+					},
+				},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-6799fc88d8-7cvjx",
+			Namespace: "prod-ns",
+			Labels:    map[string]string{"app": "app"},
+		},
+		Spec: corev1.PodSpec{NodeName: "kind-worker"},
+	}
+
+	getScanJobSpec := func(t *testing.T, config map[string]string) corev1.PodSpec {
+		t.Helper()
+		config["trivy.imageRef"] = "docker.io/aquasec/trivy:0.25.2"
+		config["trivy.mode"] = "Standalone"
+		fakeclient := fake.NewClientBuilder().WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-trivy-config",
+					Namespace: "starboard-ns",
+				},
+				Data: config,
+			},
+			pod,
+		).Build()
+		pluginContext := starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(fakeclient).
+			Get()
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), fakeclient)
+		jobSpec, _, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+		require.NoError(t, err)
+		require.Len(t, jobSpec.Containers, 2)
+		return jobSpec
+	}
+	hostPathType := corev1.HostPathSocket
+	socketVolume := corev1.Volume{
+		Name: "containerd",
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: "/run/k3s/containerd/containerd.sock",
+				Type: &hostPathType,
+			},
+		},
+	}
+	socketMount := corev1.VolumeMount{
+		Name:      "containerd",
+		MountPath: "/run/containerd/containerd.sock",
+		ReadOnly:  true,
+	}
+
+	t.Run("Should scan images with Never pull policy from containerd", func(t *testing.T) {
+		jobSpec := getScanJobSpec(t, map[string]string{
+			"trivy.containerd.socket": "/run/k3s/containerd/containerd.sock",
+		})
+		assert.Equal(t, "kind-worker", jobSpec.NodeName)
+		assert.Contains(t, jobSpec.Volumes, socketVolume)
+
+		app := jobSpec.Containers[0]
+		assert.Contains(t, app.VolumeMounts, socketMount)
+		assert.Contains(t, app.Env, corev1.EnvVar{Name: "CONTAINERD_ADDRESS", Value: "/run/containerd/containerd.sock"})
+		assert.Contains(t, app.Env, corev1.EnvVar{Name: "CONTAINERD_NAMESPACE", Value: "k8s.io"})
+		assert.Equal(t, pointer.Int64(0), app.SecurityContext.RunAsUser)
+
+		nginx := jobSpec.Containers[1]
+		assert.NotContains(t, nginx.VolumeMounts, socketMount)
+		assert.Nil(t, nginx.SecurityContext.RunAsUser)
+	})
+
+	t.Run("Should scan all images from containerd", func(t *testing.T) {
+		jobSpec := getScanJobSpec(t, map[string]string{
+			"trivy.containerd.socket":    "/run/k3s/containerd/containerd.sock",
+			"trivy.containerd.namespace": "default",
+			"trivy.containerd.images":    "All",
+		})
+		assert.Equal(t, "kind-worker", jobSpec.NodeName)
+		for _, c := range jobSpec.Containers {
+			assert.Contains(t, c.VolumeMounts, socketMount)
+			assert.Contains(t, c.Env, corev1.EnvVar{Name: "CONTAINERD_NAMESPACE", Value: "default"})
+		}
+	})
+
+	t.Run("Should pull images when socket is not set", func(t *testing.T) {
+		jobSpec := getScanJobSpec(t, map[string]string{})
+		assert.Empty(t, jobSpec.NodeName)
+		assert.NotContains(t, jobSpec.Volumes, socketVolume)
+	})
+}
@@ -0,0 +1,541 @@
+package trivy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// DBCacheType represents the kind of volume that holds the shared cache of
+// the vulnerability database in the Standalone mode.
+type DBCacheType string
+
+const (
+	DBCachePersistentVolumeClaim DBCacheType = "PersistentVolumeClaim"
+	DBCacheHostPath              DBCacheType = "HostPath"
+)
+
+const (
+	// DBCacheName the name of the CronJob that refreshes the shared cache of
+	// the vulnerability database, and of the PersistentVolumeClaim that
+	// holds the cache.
+	DBCacheName = "trivy-db-cache"
+	// JavaDBCacheName the name of the CronJob that refreshes the Java index
+	// database in the shared cache.
+	JavaDBCacheName = "trivy-java-db-cache"
+
+	dbCacheVolumeName = "dbcache"
+	dbCacheMountPath  = "/var/lib/trivy-db-cache"
+
+	defaultDBCacheSchedule    = "0 */6 * * *"
+	defaultDBCacheHostPath    = "/var/lib/starboard/trivy-db"
+	defaultDBCacheStorageSize = "2Gi"
+)
+
+// GetDBCacheType returns the type of the shared cache of the vulnerability
+// database, or an empty string if the cache is disabled.
+func (c Config) GetDBCacheType() (DBCacheType, error) {
+	value, ok := c.Data[keyTrivyDBCacheType]
+	if !ok {
+		return "", nil
+	}
+	switch DBCacheType(value) {
+	case DBCachePersistentVolumeClaim:
+		return DBCachePersistentVolumeClaim, nil
+	case DBCacheHostPath:
+		return DBCacheHostPath, nil
+	}
+	return "", fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s)",
+		value, keyTrivyDBCacheType, DBCachePersistentVolumeClaim, DBCacheHostPath)
+}
+
+// GetDBCacheSchedule returns the schedule, in the cron format, of the job
+// that refreshes the shared cache of the vulnerability database.
+func (c Config) GetDBCacheSchedule() string {
+	if value, ok := c.Data[keyTrivyDBCacheSchedule]; ok {
+		return value
+	}
+	return defaultDBCacheSchedule
+}
+
+// GetDBCacheHostPath returns the path on nodes' filesystem where the shared
+// cache of the vulnerability database is stored.
+func (c Config) GetDBCacheHostPath() string {
+	if value, ok := c.Data[keyTrivyDBCacheHostPath]; ok {
+		return value
+	}
+	return defaultDBCacheHostPath
+}
+
+// GetDBCacheStorageSize returns the size of the persistent volume that holds
+// the shared cache of the vulnerability database.
+func (c Config) GetDBCacheStorageSize() (resource.Quantity, error) {
+	value, ok := c.Data[keyTrivyDBCacheStorageSize]
+	if !ok {
+		return resource.MustParse(defaultDBCacheStorageSize), nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("parsing %s: %s %w", keyTrivyDBCacheStorageSize, value, err)
+	}
+	return quantity, nil
+}
+
+// GetDBCacheStorageClassName returns the name of the storage class of the
+// persistent volume that holds the shared cache of the vulnerability
+// database, or nil if the default storage class should be used.
+func (c Config) GetDBCacheStorageClassName() *string {
+	if value, ok := c.Data[keyTrivyDBCacheStorageClassName]; ok {
+		return &value
+	}
+	return nil
+}
+
+// GetDBCacheExistingClaim returns the name of a pre-populated
+// PersistentVolumeClaim that holds the shared cache of the vulnerability
+// database, or an empty string if the operator manages the claim.
+func (c Config) GetDBCacheExistingClaim() string {
+	return strings.TrimSpace(c.Data[keyTrivyDBCacheExistingClaim])
+}
+
+// GetDBRepository returns the OCI repository, e.g. a mirror of
+// ghcr.io/aquasecurity/trivy-db in a private registry, that the vulnerability
+// database is downloaded from, or an empty string if Trivy downloads the
+// database from its default location.
+func (c Config) GetDBRepository() string {
+	return strings.TrimSpace(c.Data[keyTrivyDBRepository])
+}
+
+// GetDBCacheJavaDBSchedule returns the schedule, in the cron format, of the
+// job that refreshes the Java index database in the shared cache, or an empty
+// string if the Java index database is not cached.
+func (c Config) GetDBCacheJavaDBSchedule() string {
+	return strings.TrimSpace(c.Data[keyTrivyDBCacheJavaDBSchedule])
+}
+
+// GetJavaDBRepository returns the OCI repository, e.g. a mirror of
+// ghcr.io/aquasecurity/trivy-java-db in a private registry, that the Java
+// index database used to identify JAR files is downloaded from, or an empty
+// string if Trivy downloads the database from its default location.
+func (c Config) GetJavaDBRepository() string {
+	return strings.TrimSpace(c.Data[keyTrivyJavaDBRepository])
+}
+
+// GetChecksBundleRepository returns the OCI repository, e.g. a mirror of
+// ghcr.io/aquasecurity/trivy-checks in a private registry, that the bundle
+// of misconfiguration checks is downloaded from, or an empty string if Trivy
+// downloads the bundle from its default location.
+func (c Config) GetChecksBundleRepository() string {
+	return strings.TrimSpace(c.Data[keyTrivyChecksBundleRepository])
+}
+
+// IsDBUpdateSkipped checks whether the vulnerability database is never
+// downloaded, neither by scan jobs nor by the job that refreshes the shared
+// cache, because the cache is populated by other means.
+func (c Config) IsDBUpdateSkipped() (bool, error) {
+	value, ok := c.Data[keyTrivySkipDBUpdate]
+	if !ok {
+		return false, nil
+	}
+	skipped, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("parsing %s: %w", keyTrivySkipDBUpdate, err)
+	}
+	return skipped, nil
+}
+
+// DBCache holds the objects that make up the shared cache of the
+// vulnerability database managed by the operator.
+type DBCache struct {
+	// CronJob is nil if updates of the vulnerability database are skipped.
+	CronJob *batchv1.CronJob
+	// JavaDBCronJob refreshes the Java index database in the cache on its own
+	// schedule. It's nil unless the schedule is set with
+	// Config.GetDBCacheJavaDBSchedule.
+	JavaDBCronJob *batchv1.CronJob
+	// PersistentVolumeClaim is nil unless the cache type is
+	// DBCachePersistentVolumeClaim and the claim is managed by the operator.
+	PersistentVolumeClaim *corev1.PersistentVolumeClaim
+}
+
+// NewDBCache constructs DBCache objects in the specified namespace from the
+// given Config.
+//
+// The CronJob downloads the vulnerability database to a temporary directory
+// and then replaces the cached copy, so scan jobs never copy a partially
+// downloaded database. The JavaDBCronJob does the same for the Java index
+// database.
+func NewDBCache(config Config, namespace, serviceAccountName string) (DBCache, error) {
+	cacheType, err := config.GetDBCacheType()
+	if err != nil {
+		return DBCache{}, err
+	}
+	skipDBUpdate, err := config.IsDBUpdateSkipped()
+	if err != nil {
+		return DBCache{}, err
+	}
+	imageRef, err := config.GetImageRef()
+	if err != nil {
+		return DBCache{}, err
+	}
+	requirements, err := config.GetResourceRequirements()
+	if err != nil {
+		return DBCache{}, err
+	}
+	volume, err := getDBCacheVolume(config, cacheType)
+	if err != nil {
+		return DBCache{}, err
+	}
+
+	objectMeta := metav1.ObjectMeta{
+		Name:      DBCacheName,
+		Namespace: namespace,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       DBCacheName,
+			starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+		},
+	}
+
+	var pvc *corev1.PersistentVolumeClaim
+	if cacheType == DBCachePersistentVolumeClaim && config.GetDBCacheExistingClaim() == "" {
+		storageSize, err := config.GetDBCacheStorageSize()
+		if err != nil {
+			return DBCache{}, err
+		}
+		pvc = &corev1.PersistentVolumeClaim{
+			ObjectMeta: objectMeta,
+			Spec: corev1.PersistentVolumeClaimSpec{
+				// The cache is written by the CronJob and read by scan jobs
+				// which may run on any node.
+				AccessModes: []corev1.PersistentVolumeAccessMode{
+					corev1.ReadWriteMany,
+				},
+				StorageClassName: config.GetDBCacheStorageClassName(),
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: storageSize,
+					},
+				},
+			},
+		}
+	}
+
+	if skipDBUpdate {
+		return DBCache{
+			PersistentVolumeClaim: pvc,
+		}, nil
+	}
+
+	cronJob := newDBCacheCronJob(objectMeta, config.GetDBCacheSchedule(),
+		getDBCacheScript(append([]string{"trivy", "--cache-dir", "/tmp/trivy", "image"}, getDBDownloadArgs(config)...), "db"),
+		imageRef, requirements, volume, serviceAccountName)
+
+	var javaDBCronJob *batchv1.CronJob
+	if schedule := config.GetDBCacheJavaDBSchedule(); schedule != "" {
+		javaDBObjectMeta := *objectMeta.DeepCopy()
+		javaDBObjectMeta.Name = JavaDBCacheName
+		javaDBObjectMeta.Labels["app.kubernetes.io/name"] = JavaDBCacheName
+		javaDBCronJob = newDBCacheCronJob(javaDBObjectMeta, schedule,
+			getDBCacheScript(append([]string{"trivy", "--cache-dir", "/tmp/trivy", "image"}, getJavaDBDownloadArgs(config)...), "java-db"),
+			imageRef, requirements, volume, serviceAccountName)
+	}
+
+	return DBCache{
+		CronJob:               cronJob,
+		JavaDBCronJob:         javaDBCronJob,
+		PersistentVolumeClaim: pvc,
+	}, nil
+}
+
+// getDBCacheScript returns the script that runs the specified Trivy command,
+// which downloads a database to the dir subdirectory of /tmp/trivy, and then
+// replaces the cached copy of the database.
+func getDBCacheScript(command []string, dir string) string {
+	return strings.Join([]string{
+		"set -e",
+		strings.Join(command, " "),
+		fmt.Sprintf("rm -rf %s/%s.new", dbCacheMountPath, dir),
+		fmt.Sprintf("cp -R /tmp/trivy/%s %s/%s.new", dir, dbCacheMountPath, dir),
+		fmt.Sprintf("rm -rf %s/%s", dbCacheMountPath, dir),
+		fmt.Sprintf("mv %[1]s/%[2]s.new %[1]s/%[2]s", dbCacheMountPath, dir),
+	}, "\n")
+}
+
+func newDBCacheCronJob(objectMeta metav1.ObjectMeta, schedule, script, imageRef string,
+	requirements corev1.ResourceRequirements, volume corev1.Volume, serviceAccountName string) *batchv1.CronJob {
+	trivyConfigName := starboard.GetPluginConfigMapName(Plugin)
+	return &batchv1.CronJob{
+		ObjectMeta: objectMeta,
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   schedule,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: pointer.Int32Ptr(1),
+			FailedJobsHistoryLimit:     pointer.Int32Ptr(1),
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: objectMeta.Labels,
+				},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: pointer.Int32Ptr(2),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: objectMeta.Labels,
+						},
+						Spec: corev1.PodSpec{
+							Affinity:                     starboard.LinuxNodeAffinity(),
+							RestartPolicy:                corev1.RestartPolicyOnFailure,
+							ServiceAccountName:           serviceAccountName,
+							AutomountServiceAccountToken: pointer.BoolPtr(false),
+							Containers: []corev1.Container{
+								{
+									Name:                     "trivy",
+									Image:                    imageRef,
+									ImagePullPolicy:          corev1.PullIfNotPresent,
+									TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+									Env: []corev1.EnvVar{
+										constructEnvVarSourceFromConfigMap("HTTP_PROXY", trivyConfigName, keyTrivyHTTPProxy),
+										constructEnvVarSourceFromConfigMap("HTTPS_PROXY", trivyConfigName, keyTrivyHTTPSProxy),
+										constructEnvVarSourceFromConfigMap("NO_PROXY", trivyConfigName, keyTrivyNoProxy),
+										constructEnvVarSourceFromSecret("GITHUB_TOKEN", trivyConfigName, keyTrivyGitHubToken),
+									},
+									Command: []string{
+										"/bin/sh",
+									},
+									Args: []string{
+										"-c",
+										script,
+									},
+									Resources: requirements,
+									SecurityContext: &corev1.SecurityContext{
+										Privileged:               pointer.BoolPtr(false),
+										AllowPrivilegeEscalation: pointer.BoolPtr(false),
+										Capabilities: &corev1.Capabilities{
+											Drop: []corev1.Capability{"all"},
+										},
+										ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+									},
+									VolumeMounts: []corev1.VolumeMount{
+										{
+											Name:      dbCacheVolumeName,
+											MountPath: dbCacheMountPath,
+										},
+										{
+											Name:      "tmp",
+											MountPath: "/tmp",
+										},
+									},
+								},
+							},
+							Volumes: []corev1.Volume{
+								volume,
+								{
+									Name: "tmp",
+									VolumeSource: corev1.VolumeSource{
+										EmptyDir: &corev1.EmptyDirVolumeSource{},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getDBCacheVolume(config Config, cacheType DBCacheType) (corev1.Volume, error) {
+	switch cacheType {
+	case DBCachePersistentVolumeClaim:
+		return corev1.Volume{
+			Name: dbCacheVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: getDBCacheClaimName(config),
+				},
+			},
+		}, nil
+	case DBCacheHostPath:
+		hostPathType := corev1.HostPathDirectoryOrCreate
+		return corev1.Volume{
+			Name: dbCacheVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: config.GetDBCacheHostPath(),
+					Type: &hostPathType,
+				},
+			},
+		}, nil
+	}
+	return corev1.Volume{}, fmt.Errorf("unrecognized trivy db cache type: %v", cacheType)
+}
+
+// getDBCacheClaimName returns the name of the PersistentVolumeClaim that holds
+// the shared cache of the vulnerability database.
+func getDBCacheClaimName(config Config) string {
+	if claimName := config.GetDBCacheExistingClaim(); claimName != "" {
+		return claimName
+	}
+	return DBCacheName
+}
+
+// getDBDownloadArgs returns the arguments of the Trivy image command that
+// downloads the vulnerability database, from the configured OCI repository if
+// any.
+func getDBDownloadArgs(config Config) []string {
+	args := []string{"--download-db-only"}
+	if repository := config.GetDBRepository(); repository != "" {
+		args = append(args, "--db-repository", repository)
+	}
+	return args
+}
+
+// getJavaDBDownloadArgs returns the arguments of the Trivy image command that
+// downloads the Java index database, from the configured OCI repository if
+// any.
+func getJavaDBDownloadArgs(config Config) []string {
+	args := []string{"--download-java-db-only"}
+	if repository := config.GetJavaDBRepository(); repository != "" {
+		args = append(args, "--java-db-repository", repository)
+	}
+	return args
+}
+
+// getScanRepositoryArgs returns the arguments of the Trivy scan commands that
+// select the OCI repositories of artifacts which Trivy downloads while
+// scanning, i.e. the Java index database and the bundle of misconfiguration
+// checks. If updates of databases are skipped, the Java index database must
+// be in the shared cache.
+func getScanRepositoryArgs(config Config) ([]string, error) {
+	var args []string
+	if repository := config.GetJavaDBRepository(); repository != "" {
+		args = append(args, "--java-db-repository", repository)
+	}
+	if repository := config.GetChecksBundleRepository(); repository != "" {
+		args = append(args, "--checks-bundle-repository", repository)
+	}
+	skipDBUpdate, err := config.IsDBUpdateSkipped()
+	if err != nil {
+		return nil, err
+	}
+	if skipDBUpdate {
+		args = append(args, "--skip-java-db-update")
+	}
+	return args, nil
+}
+
+// withDBCache makes the init container, which downloads the vulnerability
+// database to the specified cache directory, copy the database from the
+// shared cache instead. The init container still downloads the database if
+// the cache has not been populated yet, e.g. before the first run of the
+// CronJob or on a node with an empty host path, unless updates of the
+// database are skipped, in which case the init container fails. If the Java
+// index database is cached, it's copied as well when present.
+//
+// It returns the volume of the shared cache to be added to the pod spec, or
+// nil if the cache is disabled.
+func withDBCache(config Config, initContainer *corev1.Container, cacheDir string) (*corev1.Volume, error) {
+	cacheType, err := config.GetDBCacheType()
+	if err != nil {
+		return nil, err
+	}
+	skipDBUpdate, err := config.IsDBUpdateSkipped()
+	if err != nil {
+		return nil, err
+	}
+	if cacheType == "" {
+		if skipDBUpdate {
+			return nil, fmt.Errorf("%s requires %s to be set", keyTrivySkipDBUpdate, keyTrivyDBCacheType)
+		}
+		return nil, nil
+	}
+	volume, err := getDBCacheVolume(config, cacheType)
+	if err != nil {
+		return nil, err
+	}
+	if volume.PersistentVolumeClaim != nil {
+		volume.PersistentVolumeClaim.ReadOnly = true
+	}
+
+	download := strings.Join(append(initContainer.Command, initContainer.Args...), " ")
+	if skipDBUpdate {
+		download = fmt.Sprintf("echo 'Vulnerability database not found in %s' >&2; exit 1", dbCacheMountPath)
+	}
+	initContainer.Command = []string{
+		"/bin/sh",
+	}
+	script := fmt.Sprintf("if [ -f %[1]s/db/trivy.db ]; then mkdir -p %[2]s && cp -R %[1]s/db %[2]s/; else %[3]s; fi",
+		dbCacheMountPath, cacheDir, download)
+	if config.GetDBCacheJavaDBSchedule() != "" {
+		script = fmt.Sprintf("%[1]s && if [ -d %[2]s/java-db ]; then cp -R %[2]s/java-db %[3]s/; fi",
+			script, dbCacheMountPath, cacheDir)
+	}
+	initContainer.Args = []string{
+		"-c",
+		script,
+	}
+	// Copy volume mounts, which might be shared with other containers.
+	initContainer.VolumeMounts = append(append([]corev1.VolumeMount{}, initContainer.VolumeMounts...), corev1.VolumeMount{
+		Name:      dbCacheVolumeName,
+		MountPath: dbCacheMountPath,
+		ReadOnly:  true,
+	})
+	return &volume, nil
+}
+
+// withDBMetadata makes the init container, which downloads or copies the
+// vulnerability database to the specified cache directory, write the metadata
+// of the database to its termination message, so that the freshness of the
+// database used by the scan job can be reported.
+func withDBMetadata(initContainer *corev1.Container, cacheDir string) {
+	script := strings.Join(append(initContainer.Command, initContainer.Args...), " ")
+	if len(initContainer.Command) == 1 && initContainer.Command[0] == "/bin/sh" &&
+		len(initContainer.Args) == 2 && initContainer.Args[0] == "-c" {
+		script = initContainer.Args[1]
+	}
+	initContainer.Command = []string{
+		"/bin/sh",
+	}
+	initContainer.Args = []string{
+		"-c",
+		fmt.Sprintf("%s && cat %s/db/metadata.json > %s", script, cacheDir, corev1.TerminationMessagePathDefault),
+	}
+}
+
+// dbMetadata is the content of the metadata.json file of the vulnerability
+// database.
+type dbMetadata struct {
+	Version    int       `json:"Version"`
+	NextUpdate time.Time `json:"NextUpdate"`
+	UpdatedAt  time.Time `json:"UpdatedAt"`
+}
+
+// ParseDBMetadata returns the metadata of the vulnerability database written
+// to the termination message of the init container by withDBMetadata.
+func (p *plugin) ParseDBMetadata(_ starboard.PluginContext, statuses map[string]*corev1.ContainerStateTerminated) (*vulnerabilityreport.DBMetadata, error) {
+	for _, status := range statuses {
+		message := strings.TrimSpace(status.Message)
+		if status.ExitCode != 0 || !strings.HasPrefix(message, "{") {
+			continue
+		}
+		var metadata dbMetadata
+		if err := json.Unmarshal([]byte(message), &metadata); err != nil || metadata.UpdatedAt.IsZero() {
+			continue
+		}
+		return &vulnerabilityreport.DBMetadata{
+			Version:    metadata.Version,
+			UpdatedAt:  metadata.UpdatedAt,
+			NextUpdate: metadata.NextUpdate,
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,465 @@
+package trivy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfig_GetDBCacheType(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configData    trivy.Config
+		expectedType  trivy.DBCacheType
+		expectedError string
+	}{
+		{
+			name: "Should return empty type when key is not set",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{},
+			}},
+			expectedType: "",
+		},
+		{
+			name: "Should return PersistentVolumeClaim",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.dbCache.type": "PersistentVolumeClaim",
+				},
+			}},
+			expectedType: trivy.DBCachePersistentVolumeClaim,
+		},
+		{
+			name: "Should return HostPath",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.dbCache.type": "HostPath",
+				},
+			}},
+			expectedType: trivy.DBCacheHostPath,
+		},
+		{
+			name: "Should return error when value is not allowed",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.dbCache.type": "EmptyDir",
+				},
+			}},
+			expectedError: "invalid value (EmptyDir) of trivy.dbCache.type; allowed values (PersistentVolumeClaim, HostPath)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheType, err := tc.configData.GetDBCacheType()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedType, cacheType)
+		})
+	}
+}
+
+func TestNewDBCache(t *testing.T) {
+	t.Run("Should construct CronJob and PersistentVolumeClaim", func(t *testing.T) {
+		config := trivy.Config{PluginConfig: starboard.PluginConfig{
+			Data: map[string]string{
+				"trivy.imageRef":                 "docker.io/aquasec/trivy:0.22.0",
+				"trivy.mode":                     "Standalone",
+				"trivy.dbCache.type":             "PersistentVolumeClaim",
+				"trivy.dbCache.schedule":         "0 * * * *",
+				"trivy.dbCache.storageClassName": "nfs",
+			},
+		}}
+
+		cache, err := trivy.NewDBCache(config, "starboard-system", "starboard-operator")
+		require.NoError(t, err)
+
+		pvc := cache.PersistentVolumeClaim
+		require.NotNil(t, pvc)
+		assert.Equal(t, "trivy-db-cache", pvc.Name)
+		assert.Equal(t, "starboard-system", pvc.Namespace)
+		assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvc.Spec.AccessModes)
+		assert.Equal(t, pointer.StringPtr("nfs"), pvc.Spec.StorageClassName)
+		assert.Equal(t, "2Gi", pvc.Spec.Resources.Requests.Storage().String())
+
+		cronJob := cache.CronJob
+		assert.Equal(t, "trivy-db-cache", cronJob.Name)
+		assert.Equal(t, "starboard-system", cronJob.Namespace)
+		assert.Equal(t, "0 * * * *", cronJob.Spec.Schedule)
+		assert.Equal(t, batchv1.ForbidConcurrent, cronJob.Spec.ConcurrencyPolicy)
+
+		podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+		assert.Equal(t, "starboard-operator", podSpec.ServiceAccountName)
+		require.Len(t, podSpec.Containers, 1)
+		assert.Equal(t, []string{"/bin/sh"}, podSpec.Containers[0].Command)
+		assert.Equal(t, []string{"-c", `set -e
+trivy --cache-dir /tmp/trivy image --download-db-only
+rm -rf /var/lib/trivy-db-cache/db.new
+cp -R /tmp/trivy/db /var/lib/trivy-db-cache/db.new
+rm -rf /var/lib/trivy-db-cache/db
+mv /var/lib/trivy-db-cache/db.new /var/lib/trivy-db-cache/db`}, podSpec.Containers[0].Args)
+		assert.Equal(t, corev1.Volume{
+			Name: "dbcache",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "trivy-db-cache",
+				},
+			},
+		}, podSpec.Volumes[0])
+	})
+
+	t.Run("Should construct CronJob with host path volume", func(t *testing.T) {
+		config := trivy.Config{PluginConfig: starboard.PluginConfig{
+			Data: map[string]string{
+				"trivy.imageRef":     "docker.io/aquasec/trivy:0.22.0",
+				"trivy.mode":         "Standalone",
+				"trivy.dbCache.type": "HostPath",
+			},
+		}}
+
+		cache, err := trivy.NewDBCache(config, "starboard-system", "starboard-operator")
+		require.NoError(t, err)
+
+		assert.Nil(t, cache.PersistentVolumeClaim)
+		assert.Equal(t, "0 */6 * * *", cache.CronJob.Spec.Schedule)
+		volume := cache.CronJob.Spec.JobTemplate.Spec.Template.Spec.Volumes[0]
+		require.NotNil(t, volume.HostPath)
+		assert.Equal(t, "/var/lib/starboard/trivy-db", volume.HostPath.Path)
+	})
+
+	t.Run("Should construct CronJob downloading database from OCI repository to existing claim", func(t *testing.T) {
+		config := trivy.Config{PluginConfig: starboard.PluginConfig{
+			Data: map[string]string{
+				"trivy.imageRef":              "docker.io/aquasec/trivy:0.22.0",
+				"trivy.mode":                  "Standalone",
+				"trivy.dbRepository":          "registry.acme.internal/aquasecurity/trivy-db",
+				"trivy.dbCache.type":          "PersistentVolumeClaim",
+				"trivy.dbCache.existingClaim": "trivy-db",
+			},
+		}}
+
+		cache, err := trivy.NewDBCache(config, "starboard-system", "starboard-operator")
+		require.NoError(t, err)
+
+		assert.Nil(t, cache.PersistentVolumeClaim)
+		podSpec := cache.CronJob.Spec.JobTemplate.Spec.Template.Spec
+		assert.Equal(t, []string{"-c", `set -e
+trivy --cache-dir /tmp/trivy image --download-db-only --db-repository registry.acme.internal/aquasecurity/trivy-db
+rm -rf /var/lib/trivy-db-cache/db.new
+cp -R /tmp/trivy/db /var/lib/trivy-db-cache/db.new
+rm -rf /var/lib/trivy-db-cache/db
+mv /var/lib/trivy-db-cache/db.new /var/lib/trivy-db-cache/db`}, podSpec.Containers[0].Args)
+		assert.Equal(t, "trivy-db", podSpec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	})
+
+	t.Run("Should not construct CronJob when database updates are skipped", func(t *testing.T) {
+		config := trivy.Config{PluginConfig: starboard.PluginConfig{
+			Data: map[string]string{
+				"trivy.imageRef":              "docker.io/aquasec/trivy:0.22.0",
+				"trivy.mode":                  "Standalone",
+				"trivy.skipDBUpdate":          "true",
+				"trivy.dbCache.type":          "PersistentVolumeClaim",
+				"trivy.dbCache.existingClaim": "trivy-db",
+			},
+		}}
+
+		cache, err := trivy.NewDBCache(config, "starboard-system", "starboard-operator")
+		require.NoError(t, err)
+		assert.Equal(t, trivy.DBCache{}, cache)
+	})
+
+	t.Run("Should construct CronJob refreshing Java index database on its own schedule", func(t *testing.T) {
+		config := trivy.Config{PluginConfig: starboard.PluginConfig{
+			Data: map[string]string{
+				"trivy.imageRef":               "docker.io/aquasec/trivy:0.22.0",
+				"trivy.mode":                   "Standalone",
+				"trivy.javaDBRepository":       "registry.acme.internal/aquasecurity/trivy-java-db",
+				"trivy.dbCache.type":           "HostPath",
+				"trivy.dbCache.javaDBSchedule": "0 0 * * 0",
+			},
+		}}
+
+		cache, err := trivy.NewDBCache(config, "starboard-system", "starboard-operator")
+		require.NoError(t, err)
+
+		require.NotNil(t, cache.CronJob)
+		assert.Equal(t, "0 */6 * * *", cache.CronJob.Spec.Schedule)
+		require.NotNil(t, cache.JavaDBCronJob)
+		assert.Equal(t, "trivy-java-db-cache", cache.JavaDBCronJob.Name)
+		assert.Equal(t, "trivy-java-db-cache", cache.JavaDBCronJob.Labels["app.kubernetes.io/name"])
+		assert.Equal(t, "trivy-db-cache", cache.CronJob.Labels["app.kubernetes.io/name"])
+		assert.Equal(t, "0 0 * * 0", cache.JavaDBCronJob.Spec.Schedule)
+		podSpec := cache.JavaDBCronJob.Spec.JobTemplate.Spec.Template.Spec
+		assert.Equal(t, []string{"-c", `set -e
+trivy --cache-dir /tmp/trivy image --download-java-db-only --java-db-repository registry.acme.internal/aquasecurity/trivy-java-db
+rm -rf /var/lib/trivy-db-cache/java-db.new
+cp -R /tmp/trivy/java-db /var/lib/trivy-db-cache/java-db.new
+rm -rf /var/lib/trivy-db-cache/java-db
+mv /var/lib/trivy-db-cache/java-db.new /var/lib/trivy-db-cache/java-db`}, podSpec.Containers[0].Args)
+		assert.Equal(t, "/var/lib/starboard/trivy-db", podSpec.Volumes[0].HostPath.Path)
+	})
+}
+
+func TestPlugin_GetScanJobSpec_Offline(t *testing.T) {
+	newPluginContext := func(data map[string]string) starboard.PluginContext {
+		fakeclient := fake.NewClientBuilder().WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-trivy-config",
+					Namespace: "starboard-ns",
+				},
+				Data: data,
+			},
+		).Build()
+		return starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(fakeclient).
+			Get()
+	}
+	workload := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ReplicaSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6799fc88d8",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "nginx", Image: "nginx:1.16"},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("Should download database from OCI repository", func(t *testing.T) {
+		pluginContext := newPluginContext(map[string]string{
+			"trivy.imageRef":     "docker.io/aquasec/trivy:0.22.0",
+			"trivy.mode":         "Standalone",
+			"trivy.dbRepository": "registry.acme.internal/aquasecurity/trivy-db",
+		})
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), nil)
+
+		jobSpec, _, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+		require.NoError(t, err)
+
+		require.Len(t, jobSpec.InitContainers, 1)
+		assert.Equal(t, []string{"/bin/sh"}, jobSpec.InitContainers[0].Command)
+		assert.Equal(t, []string{
+			"-c",
+			"trivy --cache-dir /var/lib/trivy image --download-db-only --db-repository registry.acme.internal/aquasecurity/trivy-db && cat /var/lib/trivy/db/metadata.json > /dev/termination-log",
+		}, jobSpec.InitContainers[0].Args)
+	})
+
+	t.Run("Should copy database from existing claim without downloading it", func(t *testing.T) {
+		pluginContext := newPluginContext(map[string]string{
+			"trivy.imageRef":              "docker.io/aquasec/trivy:0.22.0",
+			"trivy.mode":                  "Standalone",
+			"trivy.skipDBUpdate":          "true",
+			"trivy.dbCache.type":          "PersistentVolumeClaim",
+			"trivy.dbCache.existingClaim": "trivy-db",
+		})
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), nil)
+
+		jobSpec, _, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+		require.NoError(t, err)
+
+		assert.Contains(t, jobSpec.Volumes, corev1.Volume{
+			Name: "dbcache",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "trivy-db",
+					ReadOnly:  true,
+				},
+			},
+		})
+		require.Len(t, jobSpec.InitContainers, 1)
+		assert.Equal(t, []string{
+			"-c",
+			"if [ -f /var/lib/trivy-db-cache/db/trivy.db ]; then mkdir -p /var/lib/trivy && cp -R /var/lib/trivy-db-cache/db /var/lib/trivy/; else echo 'Vulnerability database not found in /var/lib/trivy-db-cache' >&2; exit 1; fi && cat /var/lib/trivy/db/metadata.json > /dev/termination-log",
+		}, jobSpec.InitContainers[0].Args)
+	})
+
+	t.Run("Should download Java index database and checks bundle from OCI repositories", func(t *testing.T) {
+		pluginContext := newPluginContext(map[string]string{
+			"trivy.imageRef":               "docker.io/aquasec/trivy:0.22.0",
+			"trivy.mode":                   "Standalone",
+			"trivy.javaDBRepository":       "registry.acme.internal/aquasecurity/trivy-java-db",
+			"trivy.checksBundleRepository": "registry.acme.internal/aquasecurity/trivy-checks",
+		})
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), nil)
+
+		jobSpec, _, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+		require.NoError(t, err)
+
+		require.Len(t, jobSpec.Containers, 1)
+		assert.Equal(t, []string{
+			"--cache-dir",
+			"/var/lib/trivy",
+			"--quiet",
+			"image",
+			"--skip-update",
+			"--java-db-repository",
+			"registry.acme.internal/aquasecurity/trivy-java-db",
+			"--checks-bundle-repository",
+			"registry.acme.internal/aquasecurity/trivy-checks",
+			"--format",
+			"json",
+			"nginx:1.16",
+		}, jobSpec.Containers[0].Args)
+	})
+
+	t.Run("Should copy cached Java index database without downloading it", func(t *testing.T) {
+		pluginContext := newPluginContext(map[string]string{
+			"trivy.imageRef":               "docker.io/aquasec/trivy:0.22.0",
+			"trivy.mode":                   "Standalone",
+			"trivy.skipDBUpdate":           "true",
+			"trivy.dbCache.type":           "PersistentVolumeClaim",
+			"trivy.dbCache.javaDBSchedule": "0 0 * * 0",
+		})
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), nil)
+
+		jobSpec, _, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+		require.NoError(t, err)
+
+		require.Len(t, jobSpec.InitContainers, 1)
+		assert.Equal(t, []string{
+			"-c",
+			"if [ -f /var/lib/trivy-db-cache/db/trivy.db ]; then mkdir -p /var/lib/trivy && cp -R /var/lib/trivy-db-cache/db /var/lib/trivy/; else echo 'Vulnerability database not found in /var/lib/trivy-db-cache' >&2; exit 1; fi && if [ -d /var/lib/trivy-db-cache/java-db ]; then cp -R /var/lib/trivy-db-cache/java-db /var/lib/trivy/; fi && cat /var/lib/trivy/db/metadata.json > /dev/termination-log",
+		}, jobSpec.InitContainers[0].Args)
+		require.Len(t, jobSpec.Containers, 1)
+		assert.Contains(t, jobSpec.Containers[0].Args, "--skip-java-db-update")
+	})
+
+	t.Run("Should return error when database updates are skipped without cache", func(t *testing.T) {
+		pluginContext := newPluginContext(map[string]string{
+			"trivy.imageRef":     "docker.io/aquasec/trivy:0.22.0",
+			"trivy.mode":         "Standalone",
+			"trivy.skipDBUpdate": "true",
+		})
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), nil)
+
+		_, _, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+		assert.EqualError(t, err, "trivy.skipDBUpdate requires trivy.dbCache.type to be set")
+	})
+}
+
+func TestPlugin_GetScanJobSpec_WithDBCache(t *testing.T) {
+	fakeclient := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-trivy-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"trivy.imageRef":     "docker.io/aquasec/trivy:0.22.0",
+				"trivy.mode":         "Standalone",
+				"trivy.dbCache.type": "PersistentVolumeClaim",
+			},
+		},
+	).Build()
+	pluginContext := starboard.NewPluginContext().
+		WithName(trivy.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fakeclient).
+		Get()
+	instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), fakeclient)
+
+	jobSpec, _, err := instance.GetScanJobSpec(pluginContext, &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ReplicaSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6799fc88d8",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "nginx", Image: "nginx:1.16"},
+					},
+				},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, jobSpec.Volumes, corev1.Volume{
+		Name: "dbcache",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "trivy-db-cache",
+				ReadOnly:  true,
+			},
+		},
+	})
+
+	require.Len(t, jobSpec.InitContainers, 1)
+	initContainer := jobSpec.InitContainers[0]
+	assert.Equal(t, []string{"/bin/sh"}, initContainer.Command)
+	assert.Equal(t, []string{
+		"-c",
+		"if [ -f /var/lib/trivy-db-cache/db/trivy.db ]; then mkdir -p /var/lib/trivy && cp -R /var/lib/trivy-db-cache/db /var/lib/trivy/; else trivy --cache-dir /var/lib/trivy image --download-db-only; fi && cat /var/lib/trivy/db/metadata.json > /dev/termination-log",
+	}, initContainer.Args)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "data", MountPath: "/var/lib/trivy"},
+		{Name: "dbcache", MountPath: "/var/lib/trivy-db-cache", ReadOnly: true},
+	}, initContainer.VolumeMounts)
+
+	require.Len(t, jobSpec.Containers, 1)
+	for _, mount := range jobSpec.Containers[0].VolumeMounts {
+		assert.NotEqual(t, "dbcache", mount.Name)
+	}
+}
+
+func TestPlugin_ParseDBMetadata(t *testing.T) {
+	instance, ok := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), nil).(vulnerabilityreport.DBMetadataParser)
+	require.True(t, ok)
+
+	t.Run("Should parse metadata written by init container", func(t *testing.T) {
+		metadata, err := instance.ParseDBMetadata(nil, map[string]*corev1.ContainerStateTerminated{
+			"00000000-0000-0000-0000-000000000001": {
+				Message: `{"Version":2,"NextUpdate":"2022-03-01T12:00:00Z","UpdatedAt":"2022-03-01T06:00:00Z","DownloadedAt":"2022-03-01T07:30:00Z"}`,
+			},
+			"nginx": {},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &vulnerabilityreport.DBMetadata{
+			Version:    2,
+			UpdatedAt:  time.Date(2022, 3, 1, 6, 0, 0, 0, time.UTC),
+			NextUpdate: time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+		}, metadata)
+	})
+
+	t.Run("Should return nil when metadata is not recorded", func(t *testing.T) {
+		metadata, err := instance.ParseDBMetadata(nil, map[string]*corev1.ContainerStateTerminated{
+			"nginx": {ExitCode: 1, Message: "FATAL image scan error"},
+		})
+		require.NoError(t, err)
+		assert.Nil(t, metadata)
+	})
+}
@@ -0,0 +1,42 @@
+package trivy
+
+// EcosystemOS is the ecosystem of OS packages. Ecosystems of application
+// dependencies are types of Trivy results, e.g. jar, npm, pip, or gobinary.
+const EcosystemOS = "os"
+
+// osFamilies are types of Trivy results of OS packages.
+var osFamilies = map[string]bool{
+	"alma":                         true,
+	"alpine":                       true,
+	"amazon":                       true,
+	"cbl-mariner":                  true,
+	"centos":                       true,
+	"debian":                       true,
+	"distroless":                   true,
+	"fedora":                       true,
+	"opensuse.leap":                true,
+	"oracle":                       true,
+	"photon":                       true,
+	"redhat":                       true,
+	"rocky":                        true,
+	"suse linux enterprise server": true,
+	"ubuntu":                       true,
+}
+
+// ecosystem returns the ecosystem of packages of the specified result.
+func ecosystem(result ScanResult, os *OS) string {
+	if isOSPkgsResult(result, os) {
+		return EcosystemOS
+	}
+	return result.Type
+}
+
+// isOSPkgsResult checks whether the specified result lists vulnerabilities of
+// OS packages. Trivy versions before 0.20 don't report the class of results,
+// so the type is compared with the family of the OS or known OS families.
+func isOSPkgsResult(result ScanResult, os *OS) bool {
+	if result.Class != "" {
+		return result.Class == ResultClassOSPkgs
+	}
+	return (os != nil && result.Type == os.Family) || osFamilies[result.Type]
+}
@@ -0,0 +1,56 @@
+package trivy
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+// layerCommands maps diff IDs of layers to commands which created them.
+type layerCommands map[string]string
+
+// newLayerCommands returns commands which created layers of the image
+// described by the specified metadata. Entries of the history of the image
+// that didn't create empty layers correspond to diff IDs in the same order.
+// Commands are unknown if the history doesn't match diff IDs, e.g. for images
+// built by tools which don't record the history.
+func newLayerCommands(metadata Metadata) layerCommands {
+	commands := make(layerCommands)
+	var history []History
+	for _, entry := range metadata.ImageConfig.History {
+		if !entry.EmptyLayer {
+			history = append(history, entry)
+		}
+	}
+	if len(history) != len(metadata.DiffIDs) {
+		return commands
+	}
+	for i, diffID := range metadata.DiffIDs {
+		commands[diffID] = cleanCreatedBy(history[i].CreatedBy)
+	}
+	return commands
+}
+
+// layer returns the layer of the specified Trivy layer, or nil if the layer
+// is not reported.
+func (c layerCommands) layer(layer Layer) *v1alpha1.Layer {
+	if layer.Digest == "" && layer.DiffID == "" {
+		return nil
+	}
+	return &v1alpha1.Layer{
+		Digest:    layer.Digest,
+		DiffID:    layer.DiffID,
+		CreatedBy: c[layer.DiffID],
+	}
+}
+
+// cleanCreatedBy strips the shell prefix added by Docker to commands of RUN
+// instructions, and the #(nop) marker of other instructions.
+func cleanCreatedBy(createdBy string) string {
+	createdBy = strings.TrimSpace(createdBy)
+	createdBy = strings.TrimPrefix(createdBy, "/bin/sh -c ")
+	if strings.HasPrefix(createdBy, "#(nop)") {
+		return strings.TrimSpace(strings.TrimPrefix(createdBy, "#(nop)"))
+	}
+	return createdBy
+}
@@ -0,0 +1,91 @@
+package trivy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	keyTrivyLayerCacheURL = "trivy.layerCache.url"
+	keyTrivyLayerCacheTTL = "trivy.layerCache.ttl"
+)
+
+// GetLayerCacheURL returns the URL of the Redis server that stores the shared
+// cache of layers, or an empty string if the cache is disabled. The URL may be
+// set in the ConfigMap or, if it contains a password, in the Secret of the
+// plugin.
+func (c Config) GetLayerCacheURL() (string, error) {
+	value, ok := c.Data[keyTrivyLayerCacheURL]
+	if !ok {
+		value = string(c.SecretData[keyTrivyLayerCacheURL])
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	if !strings.HasPrefix(value, "redis://") && !strings.HasPrefix(value, "rediss://") {
+		return "", fmt.Errorf("invalid value of %s; expected redis:// or rediss:// URL", keyTrivyLayerCacheURL)
+	}
+	return value, nil
+}
+
+// GetLayerCacheTTL returns the duration after which layers expire from the
+// shared cache, or 0 if they never expire.
+func (c Config) GetLayerCacheTTL() (time.Duration, error) {
+	value, ok := c.Data[keyTrivyLayerCacheTTL]
+	if !ok {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %s %w", keyTrivyLayerCacheTTL, value, err)
+	}
+	return ttl, nil
+}
+
+// withLayerCache makes the containers of a scan job in Standalone mode use the
+// shared cache of layers instead of their ephemeral cache directories.
+//
+// Trivy caches the analysis of each layer, i.e. the packages and files it
+// contains, keyed by the digest of the layer and versions of analyzers, and
+// analyzes only layers that are missing from the cache. Vulnerabilities are
+// then detected in packages of cached and fresh layers together, so that
+// packages upgraded by upper layers are not reported. Therefore, a rescan of
+// an image that was rebuilt with changes of its top layers only downloads and
+// analyzes the changed layers.
+//
+// The pod spec is not modified if Config.GetLayerCacheURL is not set.
+func withLayerCache(config Config, podSpec *corev1.PodSpec) error {
+	url, err := config.GetLayerCacheURL()
+	if err != nil {
+		return err
+	}
+	if url == "" {
+		return nil
+	}
+	ttl, err := config.GetLayerCacheTTL()
+	if err != nil {
+		return err
+	}
+
+	trivyConfigName := starboard.GetPluginConfigMapName(Plugin)
+	backend := constructEnvVarSourceFromConfigMap("TRIVY_CACHE_BACKEND", trivyConfigName, keyTrivyLayerCacheURL)
+	if _, ok := config.Data[keyTrivyLayerCacheURL]; !ok {
+		backend = constructEnvVarSourceFromSecret("TRIVY_CACHE_BACKEND", trivyConfigName, keyTrivyLayerCacheURL)
+	}
+	for i := range podSpec.Containers {
+		c := &podSpec.Containers[i]
+		c.Env = append(c.Env, backend)
+		if ttl > 0 {
+			c.Env = append(c.Env, corev1.EnvVar{
+				Name:  "TRIVY_CACHE_TTL",
+				Value: ttl.String(),
+			})
+		}
+	}
+	return nil
+}
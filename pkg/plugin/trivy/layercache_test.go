@@ -0,0 +1,184 @@
+package trivy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfig_GetLayerCacheURL(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        starboard.PluginConfig
+		expectedURL   string
+		expectedError string
+	}{
+		{
+			name:   "Should return empty URL when key is not set",
+			config: starboard.PluginConfig{Data: map[string]string{}},
+		},
+		{
+			name: "Should return URL from ConfigMap",
+			config: starboard.PluginConfig{
+				Data: map[string]string{"trivy.layerCache.url": "redis://redis.starboard-system:6379"},
+			},
+			expectedURL: "redis://redis.starboard-system:6379",
+		},
+		{
+			name: "Should return URL from Secret",
+			config: starboard.PluginConfig{
+				SecretData: map[string][]byte{"trivy.layerCache.url": []byte("rediss://:s3cret@redis.starboard-system:6380")},
+			},
+			expectedURL: "rediss://:s3cret@redis.starboard-system:6380",
+		},
+		{
+			name: "Should return error when URL is not a Redis URL",
+			config: starboard.PluginConfig{
+				Data: map[string]string{"trivy.layerCache.url": "fs:///var/lib/trivy"},
+			},
+			expectedError: "invalid value of trivy.layerCache.url; expected redis:// or rediss:// URL",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			url, err := trivy.Config{PluginConfig: tc.config}.GetLayerCacheURL()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedURL, url)
+		})
+	}
+}
+
+func TestConfig_GetLayerCacheTTL(t *testing.T) {
+	config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: map[string]string{}}}
+	ttl, err := config.GetLayerCacheTTL()
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), ttl)
+
+	config.Data["trivy.layerCache.ttl"] = "168h"
+	ttl, err = config.GetLayerCacheTTL()
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, ttl)
+}
+
+func TestPlugin_GetScanJobSpec_WithLayerCache(t *testing.T) {
+	workload := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ReplicaSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6799fc88d8",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "nginx"},
+			},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "nginx", Image: "nginx:1.16"},
+						{Name: "sidecar", Image: "busybox:1.34"},
+					},
+				},
+			},
+		},
+	}
+
+	getScanJobSpec := func(t *testing.T, config map[string]string, secret map[string][]byte) corev1.PodSpec {
+		t.Helper()
+		config["trivy.imageRef"] = "docker.io/aquasec/trivy:0.25.2"
+		config["trivy.mode"] = "Standalone"
+		fakeclient := fake.NewClientBuilder().WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-trivy-config",
+					Namespace: "starboard-ns",
+				},
+				Data: config,
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-trivy-config",
+					Namespace: "starboard-ns",
+				},
+				Data: secret,
+			},
+		).Build()
+		pluginContext := starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(fakeclient).
+			Get()
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), fakeclient)
+		jobSpec, _, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+		require.NoError(t, err)
+		require.Len(t, jobSpec.Containers, 2)
+		return jobSpec
+	}
+	backendFrom := func(source corev1.EnvVarSource) corev1.EnvVar {
+		return corev1.EnvVar{Name: "TRIVY_CACHE_BACKEND", ValueFrom: &source}
+	}
+
+	t.Run("Should use layer cache with URL from ConfigMap", func(t *testing.T) {
+		jobSpec := getScanJobSpec(t, map[string]string{
+			"trivy.layerCache.url": "redis://redis.starboard-system:6379",
+			"trivy.layerCache.ttl": "168h",
+		}, nil)
+		for _, c := range jobSpec.Containers {
+			assert.Contains(t, c.Env, backendFrom(corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "starboard-trivy-config"},
+					Key:                  "trivy.layerCache.url",
+					Optional:             pointer.BoolPtr(true),
+				},
+			}))
+			assert.Contains(t, c.Env, corev1.EnvVar{Name: "TRIVY_CACHE_TTL", Value: "168h0m0s"})
+		}
+		for _, c := range jobSpec.InitContainers {
+			assert.NotContains(t, c.Env, corev1.EnvVar{Name: "TRIVY_CACHE_TTL", Value: "168h0m0s"})
+		}
+	})
+
+	t.Run("Should use layer cache with URL from Secret", func(t *testing.T) {
+		jobSpec := getScanJobSpec(t, map[string]string{}, map[string][]byte{
+			"trivy.layerCache.url": []byte("rediss://:s3cret@redis.starboard-system:6380"),
+		})
+		for _, c := range jobSpec.Containers {
+			assert.Contains(t, c.Env, backendFrom(corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "starboard-trivy-config"},
+					Key:                  "trivy.layerCache.url",
+					Optional:             pointer.BoolPtr(true),
+				},
+			}))
+			for _, env := range c.Env {
+				assert.NotEqual(t, "TRIVY_CACHE_TTL", env.Name)
+			}
+		}
+	})
+
+	t.Run("Should not use layer cache when URL is not set", func(t *testing.T) {
+		jobSpec := getScanJobSpec(t, map[string]string{}, nil)
+		for _, c := range jobSpec.Containers {
+			for _, env := range c.Env {
+				assert.NotEqual(t, "TRIVY_CACHE_BACKEND", env.Name)
+			}
+		}
+	})
+}
@@ -4,13 +4,48 @@ import (
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 )
 
+// ResultClassOSPkgs is the class of results of OS packages.
+const ResultClassOSPkgs = "os-pkgs"
+
 type ScanResult struct {
 	Target          string          `json:"Target"`
+	Class           string          `json:"Class"`
+	Type            string          `json:"Type"`
 	Vulnerabilities []Vulnerability `json:"Vulnerabilities"`
 }
 
 type ScanReport struct {
-	Results []ScanResult `json:"Results"`
+	Metadata Metadata     `json:"Metadata"`
+	Results  []ScanResult `json:"Results"`
+}
+
+// Metadata is the metadata of the scanned image reported by Trivy 0.20+.
+type Metadata struct {
+	OS          *OS         `json:"OS"`
+	ImageID     string      `json:"ImageID"`
+	DiffIDs     []string    `json:"DiffIDs"`
+	RepoDigests []string    `json:"RepoDigests"`
+	ImageConfig ImageConfig `json:"ImageConfig"`
+}
+
+type OS struct {
+	Family string `json:"Family"`
+	Name   string `json:"Name"`
+	EOSL   bool   `json:"EOSL"`
+}
+
+type ImageConfig struct {
+	History []History    `json:"history"`
+	Config  ConfigDetail `json:"config"`
+}
+
+type History struct {
+	CreatedBy  string `json:"created_by"`
+	EmptyLayer bool   `json:"empty_layer"`
+}
+
+type ConfigDetail struct {
+	Labels map[string]string `json:"Labels"`
 }
 
 type Vulnerability struct {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -48,6 +49,27 @@ const (
 	keyTrivyServerToken         = "trivy.serverToken"
 	keyTrivyServerCustomHeaders = "trivy.serverCustomHeaders"
 
+	keyTrivyServerManaged          = "trivy.server.managed"
+	keyTrivyServerStorageSize      = "trivy.server.storageSize"
+	keyTrivyServerStorageClassName = "trivy.server.storageClassName"
+
+	keyServerResourcesRequestsCPU    = "trivy.server.resources.requests.cpu"
+	keyServerResourcesRequestsMemory = "trivy.server.resources.requests.memory"
+	keyServerResourcesLimitsCPU      = "trivy.server.resources.limits.cpu"
+	keyServerResourcesLimitsMemory   = "trivy.server.resources.limits.memory"
+
+	keyTrivyDBCacheType             = "trivy.dbCache.type"
+	keyTrivyDBCacheSchedule         = "trivy.dbCache.schedule"
+	keyTrivyDBCacheHostPath         = "trivy.dbCache.hostPath"
+	keyTrivyDBCacheStorageSize      = "trivy.dbCache.storageSize"
+	keyTrivyDBCacheStorageClassName = "trivy.dbCache.storageClassName"
+	keyTrivyDBCacheExistingClaim    = "trivy.dbCache.existingClaim"
+	keyTrivyDBCacheJavaDBSchedule   = "trivy.dbCache.javaDBSchedule"
+	keyTrivyDBRepository            = "trivy.dbRepository"
+	keyTrivyJavaDBRepository        = "trivy.javaDBRepository"
+	keyTrivyChecksBundleRepository  = "trivy.checksBundleRepository"
+	keyTrivySkipDBUpdate            = "trivy.skipDBUpdate"
+
 	keyResourcesRequestsCPU    = "trivy.resources.requests.cpu"
 	keyResourcesRequestsMemory = "trivy.resources.requests.memory"
 	keyResourcesLimitsCPU      = "trivy.resources.limits.cpu"
@@ -118,6 +140,36 @@ func (c Config) GetServerURL() (string, error) {
 	return c.GetRequiredData(keyTrivyServerURL)
 }
 
+// IsServerManaged returns true if the Trivy server used in the ClientServer
+// mode is deployed and managed by the operator.
+func (c Config) IsServerManaged() bool {
+	return c.Data[keyTrivyServerManaged] == "true"
+}
+
+// GetServerStorageSize returns the size of the persistent volume that stores
+// the vulnerability database of the managed Trivy server.
+func (c Config) GetServerStorageSize() (resource.Quantity, error) {
+	value, ok := c.Data[keyTrivyServerStorageSize]
+	if !ok {
+		return resource.MustParse(defaultServerStorageSize), nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("parsing %s: %s %w", keyTrivyServerStorageSize, value, err)
+	}
+	return quantity, nil
+}
+
+// GetServerStorageClassName returns the name of the storage class of the
+// persistent volume claimed by the managed Trivy server, or nil if the
+// default storage class should be used.
+func (c Config) GetServerStorageClassName() *string {
+	if value, ok := c.Data[keyTrivyServerStorageClassName]; ok {
+		return &value
+	}
+	return nil
+}
+
 func (c Config) IgnoreFileExists() bool {
 	_, ok := c.Data[keyTrivyIgnoreFile]
 	return ok
@@ -161,6 +213,32 @@ func (c Config) GetMirrors() map[string]string {
 	return res
 }
 
+// GetRegistryHosts returns sorted hosts, with optional ports, of registry
+// mirrors and of OCI repositories of the vulnerability database and the Java
+// index database, i.e. the registries that Trivy must reach in addition to
+// the registries of scanned images.
+func (c Config) GetRegistryHosts() []string {
+	refs := []string{c.GetDBRepository(), c.GetJavaDBRepository()}
+	for _, mirror := range c.GetMirrors() {
+		refs = append(refs, mirror)
+	}
+	hosts := make(map[string]bool)
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		ref = strings.TrimPrefix(strings.TrimPrefix(ref, "https://"), "http://")
+		host := strings.SplitN(ref, "/", 2)[0]
+		if host != "" {
+			hosts[host] = true
+		}
+	}
+	res := make([]string, 0, len(hosts))
+	for host := range hosts {
+		res = append(res, host)
+	}
+	sort.Strings(res)
+	return res
+}
+
 // GetResourceRequirements creates ResourceRequirements from the Config.
 func (c Config) GetResourceRequirements() (corev1.ResourceRequirements, error) {
 	requirements := corev1.ResourceRequirements{
@@ -191,6 +269,37 @@ func (c Config) GetResourceRequirements() (corev1.ResourceRequirements, error) {
 	return requirements, nil
 }
 
+// GetServerResourceRequirements creates ResourceRequirements of the managed
+// Trivy server from the Config.
+func (c Config) GetServerResourceRequirements() (corev1.ResourceRequirements, error) {
+	requirements := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+
+	err := c.setResourceLimit(keyServerResourcesRequestsCPU, &requirements.Requests, corev1.ResourceCPU)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyServerResourcesRequestsMemory, &requirements.Requests, corev1.ResourceMemory)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyServerResourcesLimitsCPU, &requirements.Limits, corev1.ResourceCPU)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyServerResourcesLimitsMemory, &requirements.Limits, corev1.ResourceMemory)
+	if err != nil {
+		return requirements, err
+	}
+
+	return requirements, nil
+}
+
 func (c Config) setResourceLimit(configKey string, k8sResourceList *corev1.ResourceList, k8sResourceName corev1.ResourceName) error {
 	if value, found := c.Data[configKey]; found {
 		quantity, err := resource.ParseQuantity(value)
@@ -203,6 +312,59 @@ func (c Config) setResourceLimit(configKey string, k8sResourceList *corev1.Resou
 	return nil
 }
 
+// Validate returns problems with configuration settings, e.g. invalid values
+// or settings required by the configured mode that are not set, or nil if the
+// settings are valid.
+func (c Config) Validate() []error {
+	var errs []error
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	_, err := c.GetImageRef()
+	check(err)
+	mode, err := c.GetMode()
+	check(err)
+	_, err = c.GetCommand()
+	check(err)
+	if mode == ClientServer && !c.IsServerManaged() {
+		_, err = c.GetServerURL()
+		check(err)
+	}
+	if value, ok := c.Data[keyTrivySeverity]; ok {
+		for _, severity := range strings.Split(value, ",") {
+			switch v1alpha1.Severity(strings.TrimSpace(severity)) {
+			case v1alpha1.SeverityCritical, v1alpha1.SeverityHigh, v1alpha1.SeverityMedium, v1alpha1.SeverityLow,
+				v1alpha1.SeverityUnknown:
+			default:
+				check(fmt.Errorf("invalid severity (%s) in %s; allowed values (%s, %s, %s, %s, %s)", severity,
+					keyTrivySeverity, v1alpha1.SeverityCritical, v1alpha1.SeverityHigh, v1alpha1.SeverityMedium,
+					v1alpha1.SeverityLow, v1alpha1.SeverityUnknown))
+			}
+		}
+	}
+	_, err = c.GetResourceRequirements()
+	check(err)
+	_, err = c.GetServerResourceRequirements()
+	check(err)
+	_, err = c.GetServerStorageSize()
+	check(err)
+	_, err = c.GetDBCacheType()
+	check(err)
+	_, err = c.GetDBCacheStorageSize()
+	check(err)
+	_, err = c.IsDBUpdateSkipped()
+	check(err)
+	_, err = c.GetContainerdImages()
+	check(err)
+	_, err = c.GetLayerCacheURL()
+	check(err)
+	_, err = c.GetLayerCacheTTL()
+	check(err)
+	return errs
+}
+
 type plugin struct {
 	clock          ext.Clock
 	idGenerator    ext.IDGenerator
@@ -216,7 +378,8 @@ type plugin struct {
 // the settings returned by Config.GetMode.
 //
 // The ClientServer mode is usually more performant, however it
-// requires a Trivy server accessible at the configurable Config.GetServerURL.
+// requires a Trivy server accessible at the configurable Config.GetServerURL,
+// or the Trivy server managed by the operator if Config.IsServerManaged.
 func NewPlugin(clock ext.Clock, idGenerator ext.IDGenerator, client client.Client) vulnerabilityreport.Plugin {
 	return &plugin{
 		clock:          clock,
@@ -241,6 +404,11 @@ func (p *plugin) Init(ctx starboard.PluginContext) error {
 	})
 }
 
+// ValidateConfig returns problems with the specified configuration settings.
+func (p *plugin) ValidateConfig(config starboard.PluginConfig) []error {
+	return Config{PluginConfig: config}.Validate()
+}
+
 func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
 	spec, err := kube.GetPodSpec(workload)
 	if err != nil {
@@ -261,7 +429,19 @@ func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Obj
 	if command == ImageScan {
 		switch mode {
 		case Standalone:
-			return p.getPodSpecForStandaloneMode(ctx, config, spec, credentials)
+			podSpec, secrets, err := p.getPodSpecForStandaloneMode(ctx, config, spec, credentials)
+			if err != nil {
+				return corev1.PodSpec{}, nil, err
+			}
+			err = p.withContainerd(config, workload, spec, &podSpec)
+			if err != nil {
+				return corev1.PodSpec{}, nil, err
+			}
+			err = withLayerCache(config, &podSpec)
+			if err != nil {
+				return corev1.PodSpec{}, nil, err
+			}
+			return podSpec, secrets, nil
 		case ClientServer:
 			return p.getPodSpecForClientServerMode(ctx, config, spec, credentials)
 		default:
@@ -305,6 +485,9 @@ const (
 //
 //     trivy --cache-dir /var/lib/trivy image --download-db-only
 //
+// If the shared cache of the database is configured with Config.GetDBCacheType,
+// the init container copies the database from the cache instead.
+//
 // The number of main containers correspond to the number of containers
 // defined for the scanned workload. Each container runs the Trivy image scan
 // command and skips the database download:
@@ -332,6 +515,11 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 		return corev1.PodSpec{}, nil, err
 	}
 
+	scanArgs, err := getScanRepositoryArgs(config)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
 	initContainer := corev1.Container{
 		Name:                     p.idGenerator.GenerateID(),
 		Image:                    trivyImageRef,
@@ -390,12 +578,7 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 		Command: []string{
 			"trivy",
 		},
-		Args: []string{
-			"--cache-dir",
-			"/var/lib/trivy",
-			"image",
-			"--download-db-only",
-		},
+		Args:      append([]string{"--cache-dir", "/var/lib/trivy", "image"}, getDBDownloadArgs(config)...),
 		Resources: requirements,
 		VolumeMounts: []corev1.VolumeMount{
 			{
@@ -426,6 +609,17 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 		},
 	}
 
+	dbCacheVolume, err := withDBCache(config, &initContainer, "/var/lib/trivy")
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	if dbCacheVolume != nil {
+		volumes = append(volumes, *dbCacheVolume)
+	}
+	if dbCacheVolume != nil || config.GetDBRepository() != "" {
+		withDBMetadata(&initContainer, "/var/lib/trivy")
+	}
+
 	if config.IgnoreFileExists() {
 		volumes = append(volumes, corev1.Volume{
 			Name: ignoreFileVolumeName,
@@ -603,16 +797,17 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 			Command: []string{
 				"trivy",
 			},
-			Args: []string{
+			Args: append(append([]string{
 				"--cache-dir",
 				"/var/lib/trivy",
 				"--quiet",
 				"image",
 				"--skip-update",
+			}, scanArgs...),
 				"--format",
 				"json",
 				optionalMirroredImage,
-			},
+			),
 			Resources:    resourceRequirements,
 			VolumeMounts: volumeMounts,
 			SecurityContext: &corev1.SecurityContext{
@@ -641,7 +836,8 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 // In the ClientServer mode the number of containers of the pod
 // created by the scan job equals the number of containers defined for the
 // scanned workload. Each container runs Trivy image scan command and refers
-// to Trivy server URL returned by Config.GetServerURL:
+// to Trivy server URL returned by Config.GetServerURL, or to the URL of the
+// managed Trivy server returned by GetManagedServerURL:
 //
 //     trivy client --remote <server URL> \
 //       --format json <container image ref>
@@ -656,7 +852,17 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 		return corev1.PodSpec{}, nil, err
 	}
 
-	trivyServerURL, err := config.GetServerURL()
+	var trivyServerURL string
+	if config.IsServerManaged() {
+		trivyServerURL = GetManagedServerURL(ctx.GetNamespace())
+	} else {
+		trivyServerURL, err = config.GetServerURL()
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
+	}
+
+	scanArgs, err := getScanRepositoryArgs(config)
 	if err != nil {
 		return corev1.PodSpec{}, nil, err
 	}
@@ -885,15 +1091,16 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 			Command: []string{
 				"trivy",
 			},
-			Args: []string{
+			Args: append(append([]string{
 				"--quiet",
 				"client",
+			}, scanArgs...),
 				"--format",
 				"json",
 				"--remote",
 				trivyServerURL,
 				optionalMirroredImage,
-			},
+			),
 			VolumeMounts: volumeMounts,
 			Resources:    requirements,
 		})
@@ -940,6 +1147,11 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 		return corev1.PodSpec{}, nil, err
 	}
 
+	scanArgs, err := getScanRepositoryArgs(config)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
 	volumeMounts := []corev1.VolumeMount{
 		{
 			Name:      FsSharedVolumeName,
@@ -988,11 +1200,7 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 		Command: []string{
 			"trivy",
 		},
-		Args: []string{
-			"--download-db-only",
-			"--cache-dir",
-			"/var/starboard/trivy-db",
-		},
+		Args:         append(getDBDownloadArgs(config), "--cache-dir", "/var/starboard/trivy-db"),
 		Resources:    requirements,
 		VolumeMounts: volumeMounts,
 	}
@@ -1010,6 +1218,17 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 		},
 	}
 
+	dbCacheVolume, err := withDBCache(config, &initContainerDB, "/var/starboard/trivy-db")
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	if dbCacheVolume != nil {
+		volumes = append(volumes, *dbCacheVolume)
+	}
+	if dbCacheVolume != nil || config.GetDBRepository() != "" {
+		withDBMetadata(&initContainerDB, "/var/starboard/trivy-db")
+	}
+
 	//TODO Move this to function and refactor the code to use it
 	if config.IgnoreFileExists() {
 		volumes = append(volumes, corev1.Volume{
@@ -1075,16 +1294,17 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 			Command: []string{
 				SharedVolumeLocationOfTrivy,
 			},
-			Args: []string{
+			Args: append(append([]string{
 				"--skip-update",
 				"--cache-dir",
 				"/var/starboard/trivy-db",
 				"--quiet",
 				"fs",
+			}, scanArgs...),
 				"--format",
 				"json",
 				"/",
-			},
+			),
 			Resources:    resourceRequirements,
 			VolumeMounts: volumeMounts,
 			// Todo review security Context which is better for trivy fs scan
@@ -1159,6 +1379,7 @@ func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, image
 		return v1alpha1.VulnerabilityReportData{}, err
 	}
 	vulnerabilities := make([]v1alpha1.Vulnerability, 0)
+	layers := newLayerCommands(reports.Metadata)
 
 	for _, report := range reports.Results {
 		for _, sr := range report.Vulnerabilities {
@@ -1172,6 +1393,8 @@ func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, image
 				PrimaryLink:      sr.PrimaryURL,
 				Links:            []string{},
 				Score:            GetScoreFromCVSS(sr.Cvss),
+				Ecosystem:        ecosystem(report, reports.Metadata.OS),
+				Layer:            layers.layer(sr.Layer),
 			})
 		}
 	}
@@ -1200,6 +1423,7 @@ func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, image
 		},
 		Registry:        registry,
 		Artifact:        artifact,
+		BaseImage:       DetectBaseImage(reports),
 		Summary:         p.toSummary(vulnerabilities),
 		Vulnerabilities: vulnerabilities,
 	}, nil
@@ -1301,3 +1525,19 @@ func constructEnvVarSourceFromConfigMap(envName, trivyConfigName, trivyConfikey
 	}
 	return
 }
+
+func constructEnvVarSourceFromSecret(envName, trivyConfigName, trivyConfikey string) (res corev1.EnvVar) {
+	res = corev1.EnvVar{
+		Name: envName,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: trivyConfigName,
+				},
+				Key:      trivyConfikey,
+				Optional: pointer.BoolPtr(true),
+			},
+		},
+	}
+	return
+}
@@ -434,6 +434,42 @@ func TestConfig_GetMirrors(t *testing.T) {
 	}
 }
 
+func TestConfig_GetRegistryHosts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configData    trivy.Config
+		expectedHosts []string
+	}{
+		{
+			name: "Should return empty slice when there are no mirrors nor repositories",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.severity": "CRITICAL",
+				},
+			}},
+			expectedHosts: []string{},
+		},
+		{
+			name: "Should return sorted and unique hosts of mirrors and repositories",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.registry.mirror.docker.io": "mirror.example.com:5000",
+					"trivy.registry.mirror.quay.io":   "https://quay-mirror.example.com",
+					"trivy.dbRepository":              "registry.example.com/aquasecurity/trivy-db",
+					"trivy.javaDBRepository":          "registry.example.com/aquasecurity/trivy-java-db",
+				},
+			}},
+			expectedHosts: []string{"mirror.example.com:5000", "quay-mirror.example.com", "registry.example.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedHosts, tc.configData.GetRegistryHosts())
+		})
+	}
+}
+
 func TestPlugin_Init(t *testing.T) {
 
 	t.Run("Should create the default config", func(t *testing.T) {
@@ -3067,6 +3103,7 @@ var (
 				Title:            "openssl: information disclosure in fork()",
 				PrimaryLink:      "https://cve.mitre.org/cgi-bin/cvename.cgi?name=CVE-2019-1549",
 				Links:            []string{},
+				Ecosystem:        "os",
 			},
 			{
 				VulnerabilityID:  "CVE-2019-1547",
@@ -3077,6 +3114,7 @@ var (
 				Title:            "openssl: side-channel weak encryption vulnerability",
 				PrimaryLink:      "https://cve.mitre.org/cgi-bin/cvename.cgi?name=CVE-2019-1547",
 				Links:            []string{},
+				Ecosystem:        "os",
 			},
 		},
 	}
@@ -3137,6 +3175,184 @@ func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
 				Vulnerabilities: []v1alpha1.Vulnerability{},
 			},
 		},
+		{
+			name:     "Should convert vulnerability report in JSON format with image metadata",
+			imageRef: "alpine:3.10.2",
+			input: `{
+				"SchemaVersion": 2,
+				"Metadata": {
+					"OS": {"Family": "alpine", "Name": "3.10.2"},
+					"DiffIDs": [
+						"sha256:03901b4a2ea88eeaad62dbe59b072b28b6efa00491962b8741081c5df50c65e0",
+						"sha256:6c8e2d9a0cf0b8c5e5d4c5c0f1a7b6e7d5c4b3a2918f7e6d5c4b3a2918f7e6d"
+					],
+					"ImageConfig": {
+						"history": [
+							{"created_by": "/bin/sh -c #(nop) ADD file:fe1f09249227e2da2089afb4d07e16cbf832eeb804120074acd2b8192876cd28 in / "},
+							{"created_by": "/bin/sh -c #(nop)  CMD [\"/bin/sh\"]", "empty_layer": true},
+							{"created_by": "/bin/sh -c apk add --no-cache curl"}
+						]
+					}
+				},
+				"Results": [{
+					"Target": "alpine:3.10.2 (alpine 3.10.2)",
+					"Class": "os-pkgs",
+					"Type": "alpine",
+					"Vulnerabilities": [
+						{
+							"VulnerabilityID": "CVE-2019-1549",
+							"PkgName": "openssl",
+							"InstalledVersion": "1.1.1c-r0",
+							"FixedVersion": "1.1.1d-r0",
+							"Title": "openssl: information disclosure in fork()",
+							"Severity": "MEDIUM",
+							"Layer": {"DiffID": "sha256:03901b4a2ea88eeaad62dbe59b072b28b6efa00491962b8741081c5df50c65e0"}
+						},
+						{
+							"VulnerabilityID": "CVE-2019-5482",
+							"PkgName": "curl",
+							"InstalledVersion": "7.65.1-r0",
+							"FixedVersion": "7.66.0-r0",
+							"Title": "curl: heap buffer overflow in function tftp_receive_packet()",
+							"Severity": "HIGH",
+							"Layer": {"DiffID": "sha256:6c8e2d9a0cf0b8c5e5d4c5c0f1a7b6e7d5c4b3a2918f7e6d5c4b3a2918f7e6d"}
+						}
+					]
+				}]
+			}`,
+			expectedReport: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(fixedTime),
+				Scanner: v1alpha1.Scanner{
+					Name:    "Trivy",
+					Vendor:  "Aqua Security",
+					Version: "0.9.1",
+				},
+				Registry: v1alpha1.Registry{
+					Server: "index.docker.io",
+				},
+				Artifact: v1alpha1.Artifact{
+					Repository: "library/alpine",
+					Tag:        "3.10.2",
+				},
+				BaseImage: &v1alpha1.BaseImage{
+					Name:            "alpine:3.10.2",
+					Source:          v1alpha1.BaseImageSourceOS,
+					FixableCount:    2,
+					UpdateAvailable: true,
+				},
+				Summary: v1alpha1.VulnerabilitySummary{
+					HighCount:   1,
+					MediumCount: 1,
+				},
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{
+						VulnerabilityID:  "CVE-2019-1549",
+						Resource:         "openssl",
+						InstalledVersion: "1.1.1c-r0",
+						FixedVersion:     "1.1.1d-r0",
+						Severity:         v1alpha1.SeverityMedium,
+						Title:            "openssl: information disclosure in fork()",
+						Links:            []string{},
+						Ecosystem:        "os",
+						Layer: &v1alpha1.Layer{
+							DiffID:    "sha256:03901b4a2ea88eeaad62dbe59b072b28b6efa00491962b8741081c5df50c65e0",
+							CreatedBy: "ADD file:fe1f09249227e2da2089afb4d07e16cbf832eeb804120074acd2b8192876cd28 in /",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2019-5482",
+						Resource:         "curl",
+						InstalledVersion: "7.65.1-r0",
+						FixedVersion:     "7.66.0-r0",
+						Severity:         v1alpha1.SeverityHigh,
+						Title:            "curl: heap buffer overflow in function tftp_receive_packet()",
+						Links:            []string{},
+						Ecosystem:        "os",
+						Layer: &v1alpha1.Layer{
+							DiffID:    "sha256:6c8e2d9a0cf0b8c5e5d4c5c0f1a7b6e7d5c4b3a2918f7e6d5c4b3a2918f7e6d",
+							CreatedBy: "apk add --no-cache curl",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "Should set ecosystems of vulnerabilities in OS and language-specific packages",
+			imageRef: "alpine:3.10.2",
+			input: `{
+				"SchemaVersion": 2,
+				"Results": [{
+					"Target": "alpine:3.10.2 (alpine 3.10.2)",
+					"Class": "os-pkgs",
+					"Type": "alpine",
+					"Vulnerabilities": [
+						{
+							"VulnerabilityID": "CVE-2019-5482",
+							"PkgName": "curl",
+							"InstalledVersion": "7.65.1-r0",
+							"FixedVersion": "7.66.0-r0",
+							"Title": "curl: heap buffer overflow in function tftp_receive_packet()",
+							"Severity": "HIGH"
+						}
+					]
+				}, {
+					"Target": "app/app.jar",
+					"Class": "lang-pkgs",
+					"Type": "jar",
+					"Vulnerabilities": [
+						{
+							"VulnerabilityID": "CVE-2021-44228",
+							"PkgName": "org.apache.logging.log4j:log4j-core",
+							"InstalledVersion": "2.14.1",
+							"FixedVersion": "2.15.0",
+							"Title": "log4j-core: Remote code execution in Log4j 2.x when logs contain an attacker-controlled string value",
+							"Severity": "CRITICAL"
+						}
+					]
+				}]
+			}`,
+			expectedReport: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(fixedTime),
+				Scanner: v1alpha1.Scanner{
+					Name:    "Trivy",
+					Vendor:  "Aqua Security",
+					Version: "0.9.1",
+				},
+				Registry: v1alpha1.Registry{
+					Server: "index.docker.io",
+				},
+				Artifact: v1alpha1.Artifact{
+					Repository: "library/alpine",
+					Tag:        "3.10.2",
+				},
+				Summary: v1alpha1.VulnerabilitySummary{
+					CriticalCount: 1,
+					HighCount:     1,
+				},
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{
+						VulnerabilityID:  "CVE-2019-5482",
+						Resource:         "curl",
+						InstalledVersion: "7.65.1-r0",
+						FixedVersion:     "7.66.0-r0",
+						Severity:         v1alpha1.SeverityHigh,
+						Title:            "curl: heap buffer overflow in function tftp_receive_packet()",
+						Links:            []string{},
+						Ecosystem:        "os",
+					},
+					{
+						VulnerabilityID:  "CVE-2021-44228",
+						Resource:         "org.apache.logging.log4j:log4j-core",
+						InstalledVersion: "2.14.1",
+						FixedVersion:     "2.15.0",
+						Severity:         v1alpha1.SeverityCritical,
+						Title:            "log4j-core: Remote code execution in Log4j 2.x when logs contain an attacker-controlled string value",
+						Links:            []string{},
+						Ecosystem:        "jar",
+					},
+				},
+			},
+		},
 		{
 			name:          "Should return error when image reference cannot be parsed",
 			imageRef:      ":",
@@ -3273,3 +3489,57 @@ func TestGetMirroredImage(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		config         trivy.Config
+		expectedErrors []string
+	}{
+		{
+			name: "Should return no errors for valid settings",
+			config: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.imageRef": "docker.io/aquasec/trivy:0.25.2",
+					"trivy.mode":     "Standalone",
+					"trivy.severity": "CRITICAL,HIGH",
+				},
+			}},
+		},
+		{
+			name: "Should return errors for invalid settings",
+			config: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.mode":                   "ClientServer",
+					"trivy.severity":               "CRITICAL,HIHG",
+					"trivy.resources.requests.cpu": "lots",
+					"trivy.dbCache.type":           "Memcached",
+					"trivy.skipDBUpdate":           "yes",
+					"trivy.containerd.images":      "Local",
+					"trivy.layerCache.url":         "memcached://cache:11211",
+					"trivy.layerCache.ttl":         "forever",
+				},
+			}},
+			expectedErrors: []string{
+				"property trivy.imageRef not set",
+				"property trivy.serverURL not set",
+				"invalid severity (HIHG) in trivy.severity; allowed values (CRITICAL, HIGH, MEDIUM, LOW, UNKNOWN)",
+				"parsing resource definition trivy.resources.requests.cpu: lots quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+				"invalid value (Memcached) of trivy.dbCache.type; allowed values (PersistentVolumeClaim, HostPath)",
+				"parsing trivy.skipDBUpdate: strconv.ParseBool: parsing \"yes\": invalid syntax",
+				"invalid value (Local) of trivy.containerd.images; allowed values (PullNever, All)",
+				"invalid value of trivy.layerCache.url; expected redis:// or rediss:// URL",
+				"parsing trivy.layerCache.ttl: forever time: invalid duration \"forever\"",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errs []string
+			for _, err := range tc.config.Validate() {
+				errs = append(errs, err.Error())
+			}
+			assert.Equal(t, tc.expectedErrors, errs)
+		})
+	}
+}
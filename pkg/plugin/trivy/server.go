@@ -0,0 +1,223 @@
+package trivy
+
+import (
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// ManagedServerName the name of the Deployment, Service, and
+	// PersistentVolumeClaim of the Trivy server managed by the operator.
+	ManagedServerName = "trivy-server"
+
+	managedServerPort     = 4954
+	managedServerCacheDir = "/var/lib/trivy"
+
+	defaultServerStorageSize = "5Gi"
+)
+
+// GetManagedServerURL returns the URL of the Trivy server managed by the
+// operator in the specified namespace.
+func GetManagedServerURL(namespace string) string {
+	return fmt.Sprintf("http://%s.%s:%d", ManagedServerName, namespace, managedServerPort)
+}
+
+// ManagedServer holds the objects that make up the Trivy server deployed and
+// managed by the operator.
+type ManagedServer struct {
+	Deployment            *appsv1.Deployment
+	Service               *corev1.Service
+	PersistentVolumeClaim *corev1.PersistentVolumeClaim
+}
+
+// NewManagedServer constructs ManagedServer objects in the specified
+// namespace from the given Config.
+//
+// The server stores the vulnerability database on a persistent volume, which
+// is downloaded once and then kept up to date by the server itself, so scan
+// jobs running in the ClientServer mode do not download the database at all.
+// The server downloads the database from the OCI repository returned by
+// Config.GetDBRepository if set, and never updates it if
+// Config.IsDBUpdateSkipped, e.g. when the volume is pre-populated.
+// Because the volume is mounted read-write by a single Pod, the Deployment is
+// updated with the Recreate strategy.
+func NewManagedServer(config Config, namespace string) (ManagedServer, error) {
+	imageRef, err := config.GetImageRef()
+	if err != nil {
+		return ManagedServer{}, err
+	}
+	storageSize, err := config.GetServerStorageSize()
+	if err != nil {
+		return ManagedServer{}, err
+	}
+	requirements, err := config.GetServerResourceRequirements()
+	if err != nil {
+		return ManagedServer{}, err
+	}
+	skipDBUpdate, err := config.IsDBUpdateSkipped()
+	if err != nil {
+		return ManagedServer{}, err
+	}
+
+	args := []string{
+		"--cache-dir",
+		managedServerCacheDir,
+		"server",
+		"--listen",
+		fmt.Sprintf("0.0.0.0:%d", managedServerPort),
+	}
+	if repository := config.GetDBRepository(); repository != "" {
+		args = append(args, "--db-repository", repository)
+	}
+	if skipDBUpdate {
+		args = append(args, "--skip-update")
+	}
+
+	trivyConfigName := starboard.GetPluginConfigMapName(Plugin)
+	labels := map[string]string{
+		"app.kubernetes.io/name":       ManagedServerName,
+		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+	}
+	objectMeta := metav1.ObjectMeta{
+		Name:      ManagedServerName,
+		Namespace: namespace,
+		Labels:    labels,
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: objectMeta,
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			StorageClassName: config.GetServerStorageClassName(),
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: storageSize,
+				},
+			},
+		},
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: objectMeta,
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "trivy-http",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       managedServerPort,
+					TargetPort: intstr.FromInt(managedServerPort),
+				},
+			},
+		},
+	}
+
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/healthz",
+				Port: intstr.FromInt(managedServerPort),
+			},
+		},
+		PeriodSeconds: 10,
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: objectMeta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32Ptr(1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Affinity:                     starboard.LinuxNodeAffinity(),
+					AutomountServiceAccountToken: pointer.BoolPtr(false),
+					Containers: []corev1.Container{
+						{
+							Name:            "trivy-server",
+							Image:           imageRef,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Env: []corev1.EnvVar{
+								constructEnvVarSourceFromConfigMap("HTTP_PROXY", trivyConfigName, keyTrivyHTTPProxy),
+								constructEnvVarSourceFromConfigMap("HTTPS_PROXY", trivyConfigName, keyTrivyHTTPSProxy),
+								constructEnvVarSourceFromConfigMap("NO_PROXY", trivyConfigName, keyTrivyNoProxy),
+								constructEnvVarSourceFromConfigMap("TRIVY_TOKEN_HEADER", trivyConfigName, keyTrivyServerTokenHeader),
+								constructEnvVarSourceFromSecret("TRIVY_TOKEN", trivyConfigName, keyTrivyServerToken),
+								constructEnvVarSourceFromSecret("GITHUB_TOKEN", trivyConfigName, keyTrivyGitHubToken),
+							},
+							Command: []string{
+								"trivy",
+							},
+							Args: args,
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "trivy-http",
+									Protocol:      corev1.ProtocolTCP,
+									ContainerPort: managedServerPort,
+								},
+							},
+							ReadinessProbe: probe,
+							LivenessProbe:  probe,
+							Resources:      requirements,
+							SecurityContext: &corev1.SecurityContext{
+								Privileged:               pointer.BoolPtr(false),
+								AllowPrivilegeEscalation: pointer.BoolPtr(false),
+								Capabilities: &corev1.Capabilities{
+									Drop: []corev1.Capability{"all"},
+								},
+								ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "data",
+									MountPath: managedServerCacheDir,
+								},
+								{
+									Name:      "tmp",
+									MountPath: "/tmp",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "data",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: ManagedServerName,
+								},
+							},
+						},
+						{
+							Name: "tmp",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return ManagedServer{
+		Deployment:            deployment,
+		Service:               service,
+		PersistentVolumeClaim: pvc,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package trivy_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+)
+
+func TestConfig_IsServerManaged(t *testing.T) {
+	testCases := []struct {
+		name           string
+		configData     trivy.Config
+		expectedOutput bool
+	}{
+		{
+			name: "Should return false when key is not set",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{},
+			}},
+			expectedOutput: false,
+		},
+		{
+			name: "Should return true",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.server.managed": "true",
+				},
+			}},
+			expectedOutput: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedOutput, tc.configData.IsServerManaged())
+		})
+	}
+}
+
+func TestConfig_GetServerStorageSize(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configData    trivy.Config
+		expectedSize  resource.Quantity
+		expectedError string
+	}{
+		{
+			name: "Should return default size",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{},
+			}},
+			expectedSize: resource.MustParse("5Gi"),
+		},
+		{
+			name: "Should return configured size",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.server.storageSize": "10Gi",
+				},
+			}},
+			expectedSize: resource.MustParse("10Gi"),
+		},
+		{
+			name: "Should return error when size is invalid",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.server.storageSize": "large",
+				},
+			}},
+			expectedError: "parsing trivy.server.storageSize: large quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			size, err := tc.configData.GetServerStorageSize()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tc.expectedSize.Equal(size))
+		})
+	}
+}
+
+func TestGetManagedServerURL(t *testing.T) {
+	assert.Equal(t, "http://trivy-server.starboard-system:4954", trivy.GetManagedServerURL("starboard-system"))
+}
+
+func TestNewManagedServer(t *testing.T) {
+	config := trivy.Config{PluginConfig: starboard.PluginConfig{
+		Data: map[string]string{
+			"trivy.imageRef":                      "docker.io/aquasec/trivy:0.22.0",
+			"trivy.mode":                          "ClientServer",
+			"trivy.server.managed":                "true",
+			"trivy.server.storageClassName":       "fast",
+			"trivy.server.resources.requests.cpu": "200m",
+		},
+	}}
+
+	server, err := trivy.NewManagedServer(config, "starboard-system")
+	require.NoError(t, err)
+
+	expectedLabels := map[string]string{
+		"app.kubernetes.io/name":       "trivy-server",
+		"app.kubernetes.io/managed-by": "starboard",
+	}
+
+	pvc := server.PersistentVolumeClaim
+	assert.Equal(t, "trivy-server", pvc.Name)
+	assert.Equal(t, "starboard-system", pvc.Namespace)
+	assert.Equal(t, expectedLabels, pvc.Labels)
+	assert.Equal(t, pointer.StringPtr("fast"), pvc.Spec.StorageClassName)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, pvc.Spec.AccessModes)
+	assert.Equal(t, "5Gi", pvc.Spec.Resources.Requests.Storage().String())
+
+	service := server.Service
+	assert.Equal(t, "trivy-server", service.Name)
+	assert.Equal(t, expectedLabels, service.Spec.Selector)
+	require.Len(t, service.Spec.Ports, 1)
+	assert.Equal(t, int32(4954), service.Spec.Ports[0].Port)
+
+	deployment := server.Deployment
+	assert.Equal(t, "trivy-server", deployment.Name)
+	assert.Equal(t, "starboard-system", deployment.Namespace)
+	assert.Equal(t, pointer.Int32Ptr(1), deployment.Spec.Replicas)
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, deployment.Spec.Strategy.Type)
+	assert.Equal(t, expectedLabels, deployment.Spec.Selector.MatchLabels)
+	assert.Equal(t, expectedLabels, deployment.Spec.Template.Labels)
+
+	require.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "docker.io/aquasec/trivy:0.22.0", container.Image)
+	assert.Equal(t, []string{"trivy"}, container.Command)
+	assert.Equal(t, []string{"--cache-dir", "/var/lib/trivy", "server", "--listen", "0.0.0.0:4954"}, container.Args)
+	assert.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("200m"),
+		},
+		Limits: corev1.ResourceList{},
+	}, container.Resources)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "data", MountPath: "/var/lib/trivy"},
+		{Name: "tmp", MountPath: "/tmp"},
+	}, container.VolumeMounts)
+	require.Len(t, deployment.Spec.Template.Spec.Volumes, 2)
+	assert.Equal(t, "trivy-server", deployment.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+}
@@ -0,0 +1,4 @@
+// Package posture provides primitives for summarizing the security posture
+// of a cluster from security reports, e.g. to give a one-screen overview of
+// scan coverage and findings.
+package posture
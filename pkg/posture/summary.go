@@ -0,0 +1,225 @@
+package posture
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// Summary is the summary of the security posture of a cluster.
+type Summary struct {
+	// Workloads is the number of workloads in the cluster.
+	Workloads int `json:"workloads"`
+
+	// UnscannedWorkloads are workloads without vulnerability reports.
+	UnscannedWorkloads []kube.ObjectRef `json:"unscannedWorkloads"`
+
+	// Vulnerabilities are counts of vulnerabilities in all reports.
+	Vulnerabilities v1alpha1.VulnerabilitySummary `json:"vulnerabilities"`
+
+	// Namespaces are counts of vulnerabilities in each namespace ordered by
+	// the number of critical, and then high, vulnerabilities.
+	Namespaces []NamespaceSummary `json:"namespaces"`
+
+	// ConfigAudit are counts of failed checks in all config audit reports.
+	ConfigAudit v1alpha1.ConfigAuditSummary `json:"configAudit"`
+
+	// Nodes is the number of nodes with CIS Kubernetes Benchmark reports.
+	Nodes int `json:"nodes"`
+
+	// FailedControls are CIS Kubernetes Benchmark controls that failed on at
+	// least one node ordered by the number of nodes.
+	FailedControls []Control `json:"failedControls"`
+
+	// Scanners are unique scanners that generated vulnerability reports.
+	Scanners []v1alpha1.Scanner `json:"scanners"`
+
+	// OldestScan is the time of the oldest vulnerability scan.
+	OldestScan *time.Time `json:"oldestScan,omitempty"`
+
+	// NewestScan is the time of the newest vulnerability scan.
+	NewestScan *time.Time `json:"newestScan,omitempty"`
+}
+
+// NamespaceSummary is the summary of vulnerabilities in a namespace.
+type NamespaceSummary struct {
+	Name            string                        `json:"name"`
+	Vulnerabilities v1alpha1.VulnerabilitySummary `json:"vulnerabilities"`
+}
+
+// Control is a CIS Kubernetes Benchmark control that failed on nodes.
+type Control struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Nodes       int    `json:"nodes"`
+}
+
+// NewSummary summarizes the specified reports. Counts of workloads are not
+// set, because reports don't tell which workloads have no reports.
+func NewSummary(vulnerabilityReports []v1alpha1.VulnerabilityReport, configAuditReports []v1alpha1.ConfigAuditReport,
+	nodeReports []v1alpha1.CISKubeBenchReport) Summary {
+	var summary Summary
+	namespaces := make(map[string]*NamespaceSummary)
+	scanners := make(map[v1alpha1.Scanner]bool)
+	for _, report := range vulnerabilityReports {
+		counts := report.Report.Summary
+		add(&summary.Vulnerabilities, counts)
+		namespace, ok := namespaces[report.Namespace]
+		if !ok {
+			namespace = &NamespaceSummary{Name: report.Namespace}
+			namespaces[report.Namespace] = namespace
+		}
+		add(&namespace.Vulnerabilities, counts)
+
+		if scanner := report.Report.Scanner; !scanners[scanner] {
+			scanners[scanner] = true
+			summary.Scanners = append(summary.Scanners, scanner)
+		}
+		if updated := report.Report.UpdateTimestamp; !updated.IsZero() {
+			scanned := updated.Time
+			if summary.OldestScan == nil || scanned.Before(*summary.OldestScan) {
+				summary.OldestScan = &scanned
+			}
+			if summary.NewestScan == nil || scanned.After(*summary.NewestScan) {
+				summary.NewestScan = &scanned
+			}
+		}
+	}
+	for _, namespace := range namespaces {
+		summary.Namespaces = append(summary.Namespaces, *namespace)
+	}
+	sort.Slice(summary.Namespaces, func(i, j int) bool {
+		a, b := summary.Namespaces[i].Vulnerabilities, summary.Namespaces[j].Vulnerabilities
+		if a.CriticalCount != b.CriticalCount {
+			return a.CriticalCount > b.CriticalCount
+		}
+		if a.HighCount != b.HighCount {
+			return a.HighCount > b.HighCount
+		}
+		return summary.Namespaces[i].Name < summary.Namespaces[j].Name
+	})
+	sort.Slice(summary.Scanners, func(i, j int) bool {
+		return summary.Scanners[i].Name+" "+summary.Scanners[i].Version <
+			summary.Scanners[j].Name+" "+summary.Scanners[j].Version
+	})
+
+	for _, report := range configAuditReports {
+		summary.ConfigAudit.DangerCount += report.Report.Summary.DangerCount
+		summary.ConfigAudit.WarningCount += report.Report.Summary.WarningCount
+		summary.ConfigAudit.PassCount += report.Report.Summary.PassCount
+	}
+
+	summary.Nodes = len(nodeReports)
+	controls := make(map[string]*Control)
+	for _, report := range nodeReports {
+		for _, section := range report.Report.Sections {
+			for _, tests := range section.Tests {
+				for _, result := range tests.Results {
+					if result.Status != kubebench.StatusFail {
+						continue
+					}
+					control, ok := controls[result.TestNumber]
+					if !ok {
+						control = &Control{ID: result.TestNumber, Description: result.TestDesc}
+						controls[result.TestNumber] = control
+					}
+					control.Nodes++
+				}
+			}
+		}
+	}
+	for _, control := range controls {
+		summary.FailedControls = append(summary.FailedControls, *control)
+	}
+	sort.Slice(summary.FailedControls, func(i, j int) bool {
+		a, b := summary.FailedControls[i], summary.FailedControls[j]
+		if a.Nodes != b.Nodes {
+			return a.Nodes > b.Nodes
+		}
+		return a.ID < b.ID
+	})
+	return summary
+}
+
+func add(total *v1alpha1.VulnerabilitySummary, counts v1alpha1.VulnerabilitySummary) {
+	total.CriticalCount += counts.CriticalCount
+	total.HighCount += counts.HighCount
+	total.MediumCount += counts.MediumCount
+	total.LowCount += counts.LowCount
+	total.UnknownCount += counts.UnknownCount
+}
+
+// Write writes the specified summary. At most top namespaces, failed
+// controls, and unscanned workloads are listed, and ages of scans are
+// relative to the specified time.
+func Write(summary Summary, top int, now time.Time, out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	printf := func(format string, args ...interface{}) {
+		_, _ = fmt.Fprintf(w, format, args...)
+	}
+	scanned := summary.Workloads - len(summary.UnscannedWorkloads)
+	printf("Workloads:\t%d (%d scanned, %d not scanned)\n", summary.Workloads, scanned, len(summary.UnscannedWorkloads))
+	printf("Vulnerabilities:\t%s\n", formatVulnerabilities(summary.Vulnerabilities))
+	printf("Failed config checks:\tDANGER %d, WARNING %d\n", summary.ConfigAudit.DangerCount,
+		summary.ConfigAudit.WarningCount)
+	printf("Failed CIS controls:\t%d on %d nodes\n", len(summary.FailedControls), summary.Nodes)
+	var scanners []string
+	for _, scanner := range summary.Scanners {
+		scanners = append(scanners, strings.TrimSpace(scanner.Name+" "+scanner.Version))
+	}
+	if len(scanners) == 0 {
+		scanners = append(scanners, "<none>")
+	}
+	printf("Scanners:\t%s\n", strings.Join(scanners, ", "))
+	if summary.OldestScan != nil && summary.NewestScan != nil {
+		printf("Scans:\tnewest %s ago, oldest %s ago\n", duration.HumanDuration(now.Sub(*summary.NewestScan)),
+			duration.HumanDuration(now.Sub(*summary.OldestScan)))
+	}
+
+	if namespaces := limit(len(summary.Namespaces), top); namespaces > 0 {
+		printf("\nWorst namespaces:\n")
+		printf("NAMESPACE\tCRITICAL\tHIGH\tMEDIUM\tLOW\tUNKNOWN\n")
+		for _, namespace := range summary.Namespaces[:namespaces] {
+			counts := namespace.Vulnerabilities
+			printf("%s\t%d\t%d\t%d\t%d\t%d\n", namespace.Name, counts.CriticalCount, counts.HighCount,
+				counts.MediumCount, counts.LowCount, counts.UnknownCount)
+		}
+	}
+	if controls := limit(len(summary.FailedControls), top); controls > 0 {
+		printf("\nMost failed CIS controls:\n")
+		printf("ID\tNODES\tDESCRIPTION\n")
+		for _, control := range summary.FailedControls[:controls] {
+			printf("%s\t%d\t%s\n", control.ID, control.Nodes, control.Description)
+		}
+	}
+	if unscanned := limit(len(summary.UnscannedWorkloads), top); unscanned > 0 {
+		printf("\nWorkloads not scanned:\n")
+		for _, workload := range summary.UnscannedWorkloads[:unscanned] {
+			printf("%s/%s/%s\n", workload.Namespace, strings.ToLower(string(workload.Kind)), workload.Name)
+		}
+		if more := len(summary.UnscannedWorkloads) - unscanned; more > 0 {
+			printf("... and %d more\n", more)
+		}
+	}
+	return w.Flush()
+}
+
+func limit(n, top int) int {
+	if top > 0 && n > top {
+		return top
+	}
+	return n
+}
+
+func formatVulnerabilities(counts v1alpha1.VulnerabilitySummary) string {
+	return fmt.Sprintf("CRITICAL %d, HIGH %d, MEDIUM %d, LOW %d, UNKNOWN %d", counts.CriticalCount,
+		counts.HighCount, counts.MediumCount, counts.LowCount, counts.UnknownCount)
+}
@@ -0,0 +1,136 @@
+package posture_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/posture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newVulnerabilityReport(namespace string, updated time.Time, scanner v1alpha1.Scanner,
+	summary v1alpha1.VulnerabilitySummary) v1alpha1.VulnerabilityReport {
+	return v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+		Report: v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(updated),
+			Scanner:         scanner,
+			Summary:         summary,
+		},
+	}
+}
+
+func newNodeReport(name string, failed ...string) v1alpha1.CISKubeBenchReport {
+	var results []v1alpha1.CISKubeBenchResult
+	for _, id := range failed {
+		results = append(results, v1alpha1.CISKubeBenchResult{TestNumber: id, TestDesc: "Control " + id, Status: "FAIL"})
+	}
+	results = append(results, v1alpha1.CISKubeBenchResult{TestNumber: "1.1.1", TestDesc: "Control 1.1.1", Status: "PASS"})
+	return v1alpha1.CISKubeBenchReport{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Report: v1alpha1.CISKubeBenchReportData{
+			Sections: []v1alpha1.CISKubeBenchSection{{Tests: []v1alpha1.CISKubeBenchTests{{Results: results}}}},
+		},
+	}
+}
+
+var (
+	trivy = v1alpha1.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.25.2"}
+
+	oldest = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	newest = time.Date(2022, 3, 3, 8, 0, 0, 0, time.UTC)
+
+	summary = posture.NewSummary(
+		[]v1alpha1.VulnerabilityReport{
+			newVulnerabilityReport("default", oldest, trivy,
+				v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 4, MediumCount: 10, LowCount: 20}),
+			newVulnerabilityReport("payments", newest, trivy,
+				v1alpha1.VulnerabilitySummary{CriticalCount: 3, HighCount: 2, UnknownCount: 1}),
+			newVulnerabilityReport("default", newest.Add(-time.Hour), trivy,
+				v1alpha1.VulnerabilitySummary{CriticalCount: 2, LowCount: 1}),
+			newVulnerabilityReport("monitoring", newest, trivy,
+				v1alpha1.VulnerabilitySummary{HighCount: 7}),
+		},
+		[]v1alpha1.ConfigAuditReport{
+			{Report: v1alpha1.ConfigAuditReportData{Summary: v1alpha1.ConfigAuditSummary{DangerCount: 2, WarningCount: 5, PassCount: 10}}},
+			{Report: v1alpha1.ConfigAuditReportData{Summary: v1alpha1.ConfigAuditSummary{DangerCount: 1, WarningCount: 0, PassCount: 12}}},
+		},
+		[]v1alpha1.CISKubeBenchReport{
+			newNodeReport("kind-control-plane", "1.2.16", "4.2.6"),
+			newNodeReport("kind-worker", "4.2.6"),
+		},
+	)
+)
+
+func TestNewSummary(t *testing.T) {
+	assert.Equal(t, v1alpha1.VulnerabilitySummary{CriticalCount: 6, HighCount: 13, MediumCount: 10, LowCount: 21,
+		UnknownCount: 1}, summary.Vulnerabilities)
+	assert.Equal(t, []posture.NamespaceSummary{
+		{Name: "default", Vulnerabilities: v1alpha1.VulnerabilitySummary{CriticalCount: 3, HighCount: 4, MediumCount: 10, LowCount: 21}},
+		{Name: "payments", Vulnerabilities: v1alpha1.VulnerabilitySummary{CriticalCount: 3, HighCount: 2, UnknownCount: 1}},
+		{Name: "monitoring", Vulnerabilities: v1alpha1.VulnerabilitySummary{HighCount: 7}},
+	}, summary.Namespaces)
+	assert.Equal(t, v1alpha1.ConfigAuditSummary{DangerCount: 3, WarningCount: 5, PassCount: 22}, summary.ConfigAudit)
+	assert.Equal(t, 2, summary.Nodes)
+	assert.Equal(t, []posture.Control{
+		{ID: "4.2.6", Description: "Control 4.2.6", Nodes: 2},
+		{ID: "1.2.16", Description: "Control 1.2.16", Nodes: 1},
+	}, summary.FailedControls)
+	assert.Equal(t, []v1alpha1.Scanner{trivy}, summary.Scanners)
+	require.NotNil(t, summary.OldestScan)
+	require.NotNil(t, summary.NewestScan)
+	assert.Equal(t, oldest, *summary.OldestScan)
+	assert.Equal(t, newest, *summary.NewestScan)
+}
+
+func TestWrite(t *testing.T) {
+	s := summary
+	s.Workloads = 5
+	s.UnscannedWorkloads = []kube.ObjectRef{
+		{Kind: kube.KindDeployment, Name: "web", Namespace: "default"},
+		{Kind: kube.KindCronJob, Name: "backup", Namespace: "default"},
+		{Kind: kube.KindDaemonSet, Name: "fluentd", Namespace: "logging"},
+	}
+	var out bytes.Buffer
+	err := posture.Write(s, 2, time.Date(2022, 3, 3, 10, 0, 0, 0, time.UTC), &out)
+	require.NoError(t, err)
+	assert.Equal(t, `Workloads:             5 (2 scanned, 3 not scanned)
+Vulnerabilities:       CRITICAL 6, HIGH 13, MEDIUM 10, LOW 21, UNKNOWN 1
+Failed config checks:  DANGER 3, WARNING 5
+Failed CIS controls:   2 on 2 nodes
+Scanners:              Trivy 0.25.2
+Scans:                 newest 120m ago, oldest 2d ago
+
+Worst namespaces:
+NAMESPACE  CRITICAL  HIGH  MEDIUM  LOW  UNKNOWN
+default    3         4     10      21   0
+payments   3         2     0       0    1
+
+Most failed CIS controls:
+ID      NODES  DESCRIPTION
+4.2.6   2      Control 4.2.6
+1.2.16  1      Control 1.2.16
+
+Workloads not scanned:
+default/deployment/web
+default/cronjob/backup
+... and 1 more
+`, out.String())
+}
+
+func TestWrite_NoReports(t *testing.T) {
+	var out bytes.Buffer
+	err := posture.Write(posture.NewSummary(nil, nil, nil), 5, time.Now(), &out)
+	require.NoError(t, err)
+	assert.Equal(t, `Workloads:             0 (0 scanned, 0 not scanned)
+Vulnerabilities:       CRITICAL 0, HIGH 0, MEDIUM 0, LOW 0, UNKNOWN 0
+Failed config checks:  DANGER 0, WARNING 0
+Failed CIS controls:   0 on 0 nodes
+Scanners:              <none>
+`, out.String())
+}
@@ -5,71 +5,76 @@
 package templates
 
 //line pkg/report/templates/workload_report.qtpl:1
-import "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+import (
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
 
-//line pkg/report/templates/workload_report.qtpl:3
+//line pkg/report/templates/workload_report.qtpl:8
 import (
 	qtio422016 "io"
 
 	qt422016 "github.com/valyala/quicktemplate"
 )
 
-//line pkg/report/templates/workload_report.qtpl:3
+//line pkg/report/templates/workload_report.qtpl:8
 var (
 	_ = qtio422016.Copy
 	_ = qt422016.AcquireByteBuffer
 )
 
-//line pkg/report/templates/workload_report.qtpl:3
+//line pkg/report/templates/workload_report.qtpl:8
 func (p *WorkloadReport) StreamTitle(qw422016 *qt422016.Writer) {
-//line pkg/report/templates/workload_report.qtpl:3
+//line pkg/report/templates/workload_report.qtpl:8
 	qw422016.N().S(`
 Aqua Starboard Workload Security Report - `)
-//line pkg/report/templates/workload_report.qtpl:4
+//line pkg/report/templates/workload_report.qtpl:9
 	qw422016.E().S(p.Workload.Namespace)
-//line pkg/report/templates/workload_report.qtpl:4
+//line pkg/report/templates/workload_report.qtpl:9
 	qw422016.N().S(`/`)
-//line pkg/report/templates/workload_report.qtpl:4
+//line pkg/report/templates/workload_report.qtpl:9
 	qw422016.E().S(string(p.Workload.Kind))
-//line pkg/report/templates/workload_report.qtpl:4
+//line pkg/report/templates/workload_report.qtpl:9
 	qw422016.N().S(`/`)
-//line pkg/report/templates/workload_report.qtpl:4
+//line pkg/report/templates/workload_report.qtpl:9
 	qw422016.E().S(p.Workload.Name)
-//line pkg/report/templates/workload_report.qtpl:4
+//line pkg/report/templates/workload_report.qtpl:9
 	qw422016.N().S(`
 `)
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 }
 
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 func (p *WorkloadReport) WriteTitle(qq422016 qtio422016.Writer) {
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 	p.StreamTitle(qw422016)
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 	qt422016.ReleaseWriter(qw422016)
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 }
 
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 func (p *WorkloadReport) Title() string {
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 	qb422016 := qt422016.AcquireByteBuffer()
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 	p.WriteTitle(qb422016)
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 	qs422016 := string(qb422016.B)
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 	qt422016.ReleaseByteBuffer(qb422016)
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 	return qs422016
-//line pkg/report/templates/workload_report.qtpl:5
+//line pkg/report/templates/workload_report.qtpl:10
 }
 
 // TODO Check if we need that summary logic. If yes move this logic out of HTML templating.
 
-//line pkg/report/templates/workload_report.qtpl:9
+//line pkg/report/templates/workload_report.qtpl:14
 func (p *WorkloadReport) GetMergedVulnsSummary() v1alpha1.VulnerabilitySummary {
 	merged := v1alpha1.VulnerabilitySummary{}
 	for _, report := range p.VulnsReports {
@@ -82,27 +87,211 @@ func (p *WorkloadReport) GetMergedVulnsSummary() v1alpha1.VulnerabilitySummary {
 	return merged
 }
 
-//line pkg/report/templates/workload_report.qtpl:22
+// Containers returns sorted names of containers with vulnerability reports.
+func (p *WorkloadReport) Containers() []string {
+	var containers []string
+	for container := range p.VulnsReports {
+		containers = append(containers, container)
+	}
+	sort.Strings(containers)
+	return containers
+}
+
+// ConfigAuditContainers returns sorted names of containers with config audit checks.
+func (p *WorkloadReport) ConfigAuditContainers() []string {
+	var containers []string
+	if p.ConfigAuditReport == nil {
+		return containers
+	}
+	for container := range p.ConfigAuditReport.Report.ContainerChecks {
+		containers = append(containers, container)
+	}
+	sort.Strings(containers)
+	return containers
+}
+
+// nvdLink returns the URL of the specified vulnerability in the National
+// Vulnerability Database, or an empty string if it's not a CVE.
+func nvdLink(vulnerabilityID string) string {
+	if !strings.HasPrefix(vulnerabilityID, "CVE-") {
+		return ""
+	}
+	return "https://nvd.nist.gov/vuln/detail/" + vulnerabilityID
+}
+
+// barWidth returns the width of the bar of the specified count in a chart
+// whose longest bar, of the max count, is the specified width.
+func barWidth(count, max, width int) int {
+	if max == 0 {
+		return 0
+	}
+	return count * width / max
+}
+
+// severities lists severities of vulnerabilities in the order of filters and charts.
+
+//line pkg/report/templates/workload_report.qtpl:70
+var severities = []v1alpha1.Severity{
+	v1alpha1.SeverityCritical,
+	v1alpha1.SeverityHigh,
+	v1alpha1.SeverityMedium,
+	v1alpha1.SeverityLow,
+	v1alpha1.SeverityUnknown,
+}
+
+// severityChart prints an SVG bar chart of the severity distribution of the specified summary.
+
+//line pkg/report/templates/workload_report.qtpl:80
+func streamseverityChart(qw422016 *qt422016.Writer, summary v1alpha1.VulnerabilitySummary) {
+//line pkg/report/templates/workload_report.qtpl:80
+	qw422016.N().S(`
+`)
+//line pkg/report/templates/workload_report.qtpl:82
+	counts := map[v1alpha1.Severity]int{
+		v1alpha1.SeverityCritical: summary.CriticalCount,
+		v1alpha1.SeverityHigh:     summary.HighCount,
+		v1alpha1.SeverityMedium:   summary.MediumCount,
+		v1alpha1.SeverityLow:      summary.LowCount,
+		v1alpha1.SeverityUnknown:  summary.UnknownCount,
+	}
+	max := 0
+	for _, count := range counts {
+		if count > max {
+			max = count
+		}
+	}
+
+//line pkg/report/templates/workload_report.qtpl:95
+	qw422016.N().S(`
+<svg class="chart" width="420" height="`)
+//line pkg/report/templates/workload_report.qtpl:96
+	qw422016.N().D(len(severities) * 24)
+//line pkg/report/templates/workload_report.qtpl:96
+	qw422016.N().S(`" role="img" aria-label="Severity distribution">
+  `)
+//line pkg/report/templates/workload_report.qtpl:97
+	for i, severity := range severities {
+//line pkg/report/templates/workload_report.qtpl:97
+		qw422016.N().S(`
+  <g transform="translate(0,`)
+//line pkg/report/templates/workload_report.qtpl:98
+		qw422016.N().D(i * 24)
+//line pkg/report/templates/workload_report.qtpl:98
+		qw422016.N().S(`)">
+    <text x="0" y="16">`)
+//line pkg/report/templates/workload_report.qtpl:99
+		qw422016.E().S(string(severity))
+//line pkg/report/templates/workload_report.qtpl:99
+		qw422016.N().S(`</text>
+    <rect class="severity-`)
+//line pkg/report/templates/workload_report.qtpl:100
+		qw422016.E().S(strings.ToLower(string(severity)))
+//line pkg/report/templates/workload_report.qtpl:100
+		qw422016.N().S(`" x="80" y="4" height="16" width="`)
+//line pkg/report/templates/workload_report.qtpl:100
+		qw422016.N().D(barWidth(counts[severity], max, 300))
+//line pkg/report/templates/workload_report.qtpl:100
+		qw422016.N().S(`"></rect>
+    <text x="`)
+//line pkg/report/templates/workload_report.qtpl:101
+		qw422016.N().D(86 + barWidth(counts[severity], max, 300))
+//line pkg/report/templates/workload_report.qtpl:101
+		qw422016.N().S(`" y="16">`)
+//line pkg/report/templates/workload_report.qtpl:101
+		qw422016.N().D(counts[severity])
+//line pkg/report/templates/workload_report.qtpl:101
+		qw422016.N().S(`</text>
+  </g>
+  `)
+//line pkg/report/templates/workload_report.qtpl:103
+	}
+//line pkg/report/templates/workload_report.qtpl:103
+	qw422016.N().S(`
+</svg>
+`)
+//line pkg/report/templates/workload_report.qtpl:105
+}
+
+//line pkg/report/templates/workload_report.qtpl:105
+func writeseverityChart(qq422016 qtio422016.Writer, summary v1alpha1.VulnerabilitySummary) {
+//line pkg/report/templates/workload_report.qtpl:105
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line pkg/report/templates/workload_report.qtpl:105
+	streamseverityChart(qw422016, summary)
+//line pkg/report/templates/workload_report.qtpl:105
+	qt422016.ReleaseWriter(qw422016)
+//line pkg/report/templates/workload_report.qtpl:105
+}
+
+//line pkg/report/templates/workload_report.qtpl:105
+func severityChart(summary v1alpha1.VulnerabilitySummary) string {
+//line pkg/report/templates/workload_report.qtpl:105
+	qb422016 := qt422016.AcquireByteBuffer()
+//line pkg/report/templates/workload_report.qtpl:105
+	writeseverityChart(qb422016, summary)
+//line pkg/report/templates/workload_report.qtpl:105
+	qs422016 := string(qb422016.B)
+//line pkg/report/templates/workload_report.qtpl:105
+	qt422016.ReleaseByteBuffer(qb422016)
+//line pkg/report/templates/workload_report.qtpl:105
+	return qs422016
+//line pkg/report/templates/workload_report.qtpl:105
+}
+
+//line pkg/report/templates/workload_report.qtpl:107
 func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
-//line pkg/report/templates/workload_report.qtpl:22
+//line pkg/report/templates/workload_report.qtpl:107
 	qw422016.N().S(`
   <style>
   a {
     color: inherit;
   }
+  .severity-critical { fill: #dc3545; background-color: #dc3545; color: #fff; }
+  .severity-high { fill: #fd7e14; background-color: #fd7e14; color: #fff; }
+  .severity-medium { fill: #ffc107; background-color: #ffc107; }
+  .severity-low { fill: #17a2b8; background-color: #17a2b8; color: #fff; }
+  .severity-unknown { fill: #adb5bd; background-color: #adb5bd; }
+  .badge { display: inline-block; padding: .1em .4em; font-size: 75%; font-weight: 700; border-radius: .25rem; }
+  .chart text { font-size: small; fill: #212529; }
+  .controls { display: flex; flex-wrap: wrap; align-items: center; margin-bottom: 1rem; font-size: small; }
+  .controls label { margin-right: 1rem; margin-bottom: 0; }
+  .controls input[type="search"] { flex-grow: 1; padding: .25rem .5rem; border: 1px solid #ced4da; border-radius: .25rem; }
+  .tabs { display: none; flex-wrap: wrap; margin-bottom: 1rem; border-bottom: 1px solid #dee2e6; }
+  .tab { padding: .5rem 1rem; margin-bottom: -1px; cursor: pointer; background: none; border: 1px solid transparent;
+    border-top-left-radius: .25rem; border-top-right-radius: .25rem; }
+  .tab.active { color: #495057; background-color: #fff; border-color: #dee2e6 #dee2e6 #fff; }
+  .js .tabs { display: flex; }
+  .js .tab-panel { display: none; }
+  .js .tab-panel.active { display: block; }
   @media print {
     .container {
         display: inline;
     }
+    .no-print {
+      display: none !important;
+    }
+    .js .tab-panel {
+      display: block;
+    }
+    .badge, .chart rect {
+      -webkit-print-color-adjust: exact;
+      print-color-adjust: exact;
+    }
+    thead {
+      display: table-header-group;
+    }
+    tr, .tab-panel > .row:first-child {
+      page-break-inside: avoid;
+    }
   }
   </style>
-  <div class="container border-right border-left" style="height: 100%; overflow: scroll;">
+  <div class="container border-right border-left" id="report" style="height: 100%; overflow: scroll;">
     <div class="col mt-5">
       <div class="row text-center">
         `)
-//line pkg/report/templates/workload_report.qtpl:36
+//line pkg/report/templates/workload_report.qtpl:154
 	streamimgAquaLogo(qw422016)
-//line pkg/report/templates/workload_report.qtpl:36
+//line pkg/report/templates/workload_report.qtpl:154
 	qw422016.N().S(`
       </div>
       <div class="row mt-4 text-center">
@@ -110,27 +299,27 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
       </div>
       <div class="row text-center">
         <h3 class="text-muted mx-auto">Workload: `)
-//line pkg/report/templates/workload_report.qtpl:42
+//line pkg/report/templates/workload_report.qtpl:160
 	qw422016.E().S(string(p.Workload.Kind))
-//line pkg/report/templates/workload_report.qtpl:42
+//line pkg/report/templates/workload_report.qtpl:160
 	qw422016.N().S(`/`)
-//line pkg/report/templates/workload_report.qtpl:42
+//line pkg/report/templates/workload_report.qtpl:160
 	qw422016.E().S(p.Workload.Name)
-//line pkg/report/templates/workload_report.qtpl:42
+//line pkg/report/templates/workload_report.qtpl:160
 	qw422016.N().S(`</h3>
       </div>
       <div class="row text-center">
         <h3 class="text-muted mx-auto">Namespace: `)
-//line pkg/report/templates/workload_report.qtpl:45
+//line pkg/report/templates/workload_report.qtpl:163
 	qw422016.E().S(p.Workload.Namespace)
-//line pkg/report/templates/workload_report.qtpl:45
+//line pkg/report/templates/workload_report.qtpl:163
 	qw422016.N().S(`</h3>
       </div>
       <div class="row text-center">
         <h3 class="text-muted mx-auto">Generated on `)
-//line pkg/report/templates/workload_report.qtpl:48
+//line pkg/report/templates/workload_report.qtpl:166
 	qw422016.E().S(p.GeneratedAt.Format("2 Jan 2006 15:04:01"))
-//line pkg/report/templates/workload_report.qtpl:48
+//line pkg/report/templates/workload_report.qtpl:166
 	qw422016.N().S(`</h3>
       </div>
 
@@ -141,82 +330,86 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                 <div class="row">
                     <ul>
                         `)
-//line pkg/report/templates/workload_report.qtpl:57
+//line pkg/report/templates/workload_report.qtpl:175
 	if len(p.VulnsReports) > 0 {
-//line pkg/report/templates/workload_report.qtpl:57
+//line pkg/report/templates/workload_report.qtpl:175
 		qw422016.N().S(`
                         <li>
                             <a href="#vuln_header">Vulnerabilities</a></li>
                             <ul>
                               `)
-//line pkg/report/templates/workload_report.qtpl:61
-		for container, _ := range p.VulnsReports {
-//line pkg/report/templates/workload_report.qtpl:61
+//line pkg/report/templates/workload_report.qtpl:179
+		for _, container := range p.Containers() {
+//line pkg/report/templates/workload_report.qtpl:179
 			qw422016.N().S(`
                                 <li><a href="#vulns_container_`)
-//line pkg/report/templates/workload_report.qtpl:62
+//line pkg/report/templates/workload_report.qtpl:180
+			qw422016.E().S(container)
+//line pkg/report/templates/workload_report.qtpl:180
+			qw422016.N().S(`" data-tab="vulns_container_`)
+//line pkg/report/templates/workload_report.qtpl:180
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:62
+//line pkg/report/templates/workload_report.qtpl:180
 			qw422016.N().S(`">`)
-//line pkg/report/templates/workload_report.qtpl:62
+//line pkg/report/templates/workload_report.qtpl:180
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:62
+//line pkg/report/templates/workload_report.qtpl:180
 			qw422016.N().S(`</a></li>
                               `)
-//line pkg/report/templates/workload_report.qtpl:63
+//line pkg/report/templates/workload_report.qtpl:181
 		}
-//line pkg/report/templates/workload_report.qtpl:63
+//line pkg/report/templates/workload_report.qtpl:181
 		qw422016.N().S(`
                             </ul>
                         </li>
                         `)
-//line pkg/report/templates/workload_report.qtpl:66
+//line pkg/report/templates/workload_report.qtpl:184
 	}
-//line pkg/report/templates/workload_report.qtpl:66
+//line pkg/report/templates/workload_report.qtpl:184
 	qw422016.N().S(`
                         `)
-//line pkg/report/templates/workload_report.qtpl:67
+//line pkg/report/templates/workload_report.qtpl:185
 	if p.ConfigAuditReport != nil && len(p.ConfigAuditReport.Report.PodChecks) > 0 {
-//line pkg/report/templates/workload_report.qtpl:67
+//line pkg/report/templates/workload_report.qtpl:185
 		qw422016.N().S(`
                         <li>
                             <a href="#ca_header">Configuration Audit</a>
                             <ul>
                               <li><a href="#ca_pod_checks">Pod Checks</a></li>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:72
-		for container, _ := range p.ConfigAuditReport.Report.ContainerChecks {
-//line pkg/report/templates/workload_report.qtpl:72
+//line pkg/report/templates/workload_report.qtpl:190
+		for _, container := range p.ConfigAuditContainers() {
+//line pkg/report/templates/workload_report.qtpl:190
 			qw422016.N().S(`
                                   <li><a href="#ca_container_`)
-//line pkg/report/templates/workload_report.qtpl:73
+//line pkg/report/templates/workload_report.qtpl:191
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:73
+//line pkg/report/templates/workload_report.qtpl:191
 			qw422016.N().S(`">`)
-//line pkg/report/templates/workload_report.qtpl:73
+//line pkg/report/templates/workload_report.qtpl:191
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:73
+//line pkg/report/templates/workload_report.qtpl:191
 			qw422016.N().S(`</a></li>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:74
+//line pkg/report/templates/workload_report.qtpl:192
 		}
-//line pkg/report/templates/workload_report.qtpl:74
+//line pkg/report/templates/workload_report.qtpl:192
 		qw422016.N().S(`
                             </ul>
                         </li>
                         `)
-//line pkg/report/templates/workload_report.qtpl:77
+//line pkg/report/templates/workload_report.qtpl:195
 	}
-//line pkg/report/templates/workload_report.qtpl:77
+//line pkg/report/templates/workload_report.qtpl:195
 	qw422016.N().S(`
                     </ul>
                 </div>
 
 
                 `)
-//line pkg/report/templates/workload_report.qtpl:82
+//line pkg/report/templates/workload_report.qtpl:200
 	if len(p.VulnsReports) > 0 {
-//line pkg/report/templates/workload_report.qtpl:82
+//line pkg/report/templates/workload_report.qtpl:200
 		qw422016.N().S(`
                 <!-- Vulnerabilities -->
                 <div class="row text-center border-bottom mt-4">
@@ -235,9 +428,10 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                              <div class="row">
                                 <div class="col">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:100
+//line pkg/report/templates/workload_report.qtpl:218
 		var scanner_name, scanner_vendor, scanner_version, creation_timestamp string
-		for _, report := range p.VulnsReports {
+		for _, container := range p.Containers() {
+			report := p.VulnsReports[container]
 			scanner_name = report.Scanner.Name
 			scanner_vendor = report.Scanner.Vendor
 			scanner_version = report.Scanner.Version
@@ -245,22 +439,22 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
 			break
 		}
 
-//line pkg/report/templates/workload_report.qtpl:108
+//line pkg/report/templates/workload_report.qtpl:227
 		qw422016.N().S(`
                                     <p class="my-0">Name:  `)
-//line pkg/report/templates/workload_report.qtpl:109
+//line pkg/report/templates/workload_report.qtpl:228
 		qw422016.E().S(scanner_name)
-//line pkg/report/templates/workload_report.qtpl:109
+//line pkg/report/templates/workload_report.qtpl:228
 		qw422016.N().S(`</p>
                                     <p class="my-0">Vendor:  `)
-//line pkg/report/templates/workload_report.qtpl:110
+//line pkg/report/templates/workload_report.qtpl:229
 		qw422016.E().S(scanner_vendor)
-//line pkg/report/templates/workload_report.qtpl:110
+//line pkg/report/templates/workload_report.qtpl:229
 		qw422016.N().S(`</p>
                                     <p class="my-0">Version:  `)
-//line pkg/report/templates/workload_report.qtpl:111
+//line pkg/report/templates/workload_report.qtpl:230
 		qw422016.E().S(scanner_version)
-//line pkg/report/templates/workload_report.qtpl:111
+//line pkg/report/templates/workload_report.qtpl:230
 		qw422016.N().S(`</p>
                                 </div>
                              </div>
@@ -274,96 +468,96 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                             </div>
                             <div class="row">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:124
+//line pkg/report/templates/workload_report.qtpl:243
 		summary := p.GetMergedVulnsSummary()
 
-//line pkg/report/templates/workload_report.qtpl:125
+//line pkg/report/templates/workload_report.qtpl:244
 		qw422016.N().S(`
                                 `)
-//line pkg/report/templates/workload_report.qtpl:126
+//line pkg/report/templates/workload_report.qtpl:245
 		if summary.CriticalCount > 0 {
-//line pkg/report/templates/workload_report.qtpl:126
+//line pkg/report/templates/workload_report.qtpl:245
 			qw422016.N().S(`
                                 <div class="col text-center p-0 text-danger font-weight-bold">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:128
+//line pkg/report/templates/workload_report.qtpl:247
 		} else {
-//line pkg/report/templates/workload_report.qtpl:128
+//line pkg/report/templates/workload_report.qtpl:247
 			qw422016.N().S(`
                                 <div class="col text-center p-0">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:130
+//line pkg/report/templates/workload_report.qtpl:249
 		}
-//line pkg/report/templates/workload_report.qtpl:130
+//line pkg/report/templates/workload_report.qtpl:249
 		qw422016.N().S(`
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:131
+//line pkg/report/templates/workload_report.qtpl:250
 		qw422016.N().D(summary.CriticalCount)
-//line pkg/report/templates/workload_report.qtpl:131
+//line pkg/report/templates/workload_report.qtpl:250
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">CRITICAL</p>
                                 </div>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:134
+//line pkg/report/templates/workload_report.qtpl:253
 		if summary.HighCount > 0 {
-//line pkg/report/templates/workload_report.qtpl:134
+//line pkg/report/templates/workload_report.qtpl:253
 			qw422016.N().S(`
                                 <div class="col text-center p-0 text-danger font-weight-bold">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:136
+//line pkg/report/templates/workload_report.qtpl:255
 		} else {
-//line pkg/report/templates/workload_report.qtpl:136
+//line pkg/report/templates/workload_report.qtpl:255
 			qw422016.N().S(`
                                 <div class="col text-center p-0">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:138
+//line pkg/report/templates/workload_report.qtpl:257
 		}
-//line pkg/report/templates/workload_report.qtpl:138
+//line pkg/report/templates/workload_report.qtpl:257
 		qw422016.N().S(`
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:139
+//line pkg/report/templates/workload_report.qtpl:258
 		qw422016.N().D(summary.HighCount)
-//line pkg/report/templates/workload_report.qtpl:139
+//line pkg/report/templates/workload_report.qtpl:258
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">HIGH</p>
                                 </div>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:142
+//line pkg/report/templates/workload_report.qtpl:261
 		if summary.MediumCount > 0 {
-//line pkg/report/templates/workload_report.qtpl:142
+//line pkg/report/templates/workload_report.qtpl:261
 			qw422016.N().S(`
                                 <div class="col text-center p-0 text-warning font-weight-bold">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:144
+//line pkg/report/templates/workload_report.qtpl:263
 		} else {
-//line pkg/report/templates/workload_report.qtpl:144
+//line pkg/report/templates/workload_report.qtpl:263
 			qw422016.N().S(`
                                 <div class="col text-center p-0">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:146
+//line pkg/report/templates/workload_report.qtpl:265
 		}
-//line pkg/report/templates/workload_report.qtpl:146
+//line pkg/report/templates/workload_report.qtpl:265
 		qw422016.N().S(`
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:147
+//line pkg/report/templates/workload_report.qtpl:266
 		qw422016.N().D(summary.MediumCount)
-//line pkg/report/templates/workload_report.qtpl:147
+//line pkg/report/templates/workload_report.qtpl:266
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">MEDIUM</p>
                                 </div>
                                 <div class="col text-center p-0">
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:151
+//line pkg/report/templates/workload_report.qtpl:270
 		qw422016.N().D(summary.LowCount)
-//line pkg/report/templates/workload_report.qtpl:151
+//line pkg/report/templates/workload_report.qtpl:270
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">LOW</p>
                                 </div>
                                 <div class="col text-center p-0">
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:155
+//line pkg/report/templates/workload_report.qtpl:274
 		qw422016.N().D(summary.UnknownCount)
-//line pkg/report/templates/workload_report.qtpl:155
+//line pkg/report/templates/workload_report.qtpl:274
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">UNKNOWN</p>
                                 </div>
@@ -380,9 +574,9 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                                 <div class="col">
                                     <p class="my-0">
                                         Generated at:  `)
-//line pkg/report/templates/workload_report.qtpl:170
+//line pkg/report/templates/workload_report.qtpl:289
 		qw422016.E().S(creation_timestamp)
-//line pkg/report/templates/workload_report.qtpl:170
+//line pkg/report/templates/workload_report.qtpl:289
 		qw422016.N().S(`
                                     </p>
                                 </div>
@@ -391,52 +585,118 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
 
                     </div>      
                 </div>
+                <!-- Chart -->
+                <div class="row mb-2">
+                    <h5 class="text-info">Severity Distribution</h5>
+                </div>
+                <div class="row my-4">
+                    `)
+//line pkg/report/templates/workload_report.qtpl:302
+		streamseverityChart(qw422016, summary)
+//line pkg/report/templates/workload_report.qtpl:302
+		qw422016.N().S(`
+                </div>
+                <!-- Filters -->
+                <div class="controls no-print">
+                  `)
+//line pkg/report/templates/workload_report.qtpl:306
+		for _, severity := range severities {
+//line pkg/report/templates/workload_report.qtpl:306
+			qw422016.N().S(`
+                  <label><input type="checkbox" class="severity-filter" value="`)
+//line pkg/report/templates/workload_report.qtpl:307
+			qw422016.E().S(string(severity))
+//line pkg/report/templates/workload_report.qtpl:307
+			qw422016.N().S(`" checked> `)
+//line pkg/report/templates/workload_report.qtpl:307
+			qw422016.E().S(string(severity))
+//line pkg/report/templates/workload_report.qtpl:307
+			qw422016.N().S(`</label>
+                  `)
+//line pkg/report/templates/workload_report.qtpl:308
+		}
+//line pkg/report/templates/workload_report.qtpl:308
+		qw422016.N().S(`
+                  <input type="search" id="vulns_search" placeholder="Search by ID, package, version, or title" aria-label="Search vulnerabilities">
+                </div>
+                <!-- Tabs -->
+                <div class="tabs no-print" role="tablist">
+                  `)
+//line pkg/report/templates/workload_report.qtpl:313
+		for _, container := range p.Containers() {
+//line pkg/report/templates/workload_report.qtpl:313
+			qw422016.N().S(`
+                  <button type="button" class="tab" role="tab" data-tab="vulns_container_`)
+//line pkg/report/templates/workload_report.qtpl:314
+			qw422016.E().S(container)
+//line pkg/report/templates/workload_report.qtpl:314
+			qw422016.N().S(`">`)
+//line pkg/report/templates/workload_report.qtpl:314
+			qw422016.E().S(container)
+//line pkg/report/templates/workload_report.qtpl:314
+			qw422016.N().S(` (`)
+//line pkg/report/templates/workload_report.qtpl:314
+			qw422016.N().D(len(p.VulnsReports[container].Vulnerabilities))
+//line pkg/report/templates/workload_report.qtpl:314
+			qw422016.N().S(`)</button>
+                  `)
+//line pkg/report/templates/workload_report.qtpl:315
+		}
+//line pkg/report/templates/workload_report.qtpl:315
+		qw422016.N().S(`
+                </div>
                 `)
-//line pkg/report/templates/workload_report.qtpl:178
+//line pkg/report/templates/workload_report.qtpl:317
 	}
-//line pkg/report/templates/workload_report.qtpl:178
+//line pkg/report/templates/workload_report.qtpl:317
 	qw422016.N().S(`
-                
+
                 `)
-//line pkg/report/templates/workload_report.qtpl:180
-	for container, report := range p.VulnsReports {
-//line pkg/report/templates/workload_report.qtpl:180
+//line pkg/report/templates/workload_report.qtpl:319
+	for _, container := range p.Containers() {
+//line pkg/report/templates/workload_report.qtpl:319
+		qw422016.N().S(`
+                `)
+//line pkg/report/templates/workload_report.qtpl:320
+		report := p.VulnsReports[container]
+
+//line pkg/report/templates/workload_report.qtpl:320
 		qw422016.N().S(`
-                
-                  <div class="row"><h5 class="text-info" id="vulns_container_`)
-//line pkg/report/templates/workload_report.qtpl:182
+                <div class="tab-panel" id="vulns_container_`)
+//line pkg/report/templates/workload_report.qtpl:321
 		qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:182
-		qw422016.N().S(`">Container `)
-//line pkg/report/templates/workload_report.qtpl:182
+//line pkg/report/templates/workload_report.qtpl:321
+		qw422016.N().S(`" role="tabpanel">
+                  <div class="row"><h5 class="text-info">Container `)
+//line pkg/report/templates/workload_report.qtpl:322
 		qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:182
+//line pkg/report/templates/workload_report.qtpl:322
 		qw422016.N().S(`</h5></div>
                   <div class="row"><p>`)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:323
 		qw422016.E().S(report.Registry.Server)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:323
 		qw422016.N().S(`/`)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:323
 		qw422016.E().S(report.Artifact.Repository)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:323
 		qw422016.N().S(`:`)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:323
 		qw422016.E().S(report.Artifact.Tag)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:323
 		qw422016.N().S(`</p></div>
                   `)
-//line pkg/report/templates/workload_report.qtpl:184
+//line pkg/report/templates/workload_report.qtpl:324
 		if len(report.Vulnerabilities) == 0 {
-//line pkg/report/templates/workload_report.qtpl:184
+//line pkg/report/templates/workload_report.qtpl:324
 			qw422016.N().S(`
                     <div class="row">
                       <p class="alert alert-success py-0 m-0" style="font-size: small;">No Vulnerabilities</p>
-                    </div>                  
+                    </div>
                   `)
-//line pkg/report/templates/workload_report.qtpl:188
+//line pkg/report/templates/workload_report.qtpl:328
 		} else {
-//line pkg/report/templates/workload_report.qtpl:188
+//line pkg/report/templates/workload_report.qtpl:328
 			qw422016.N().S(`
 
                   <div class="row">
@@ -448,71 +708,127 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                           <th scope="col">Resource</th>
                           <th scope="col">Installed Version</th>
                           <th scope="col">Fixed Version</th>
+                          <th scope="col">Title</th>
+                          <th scope="col">Links</th>
                         </tr>
                       </thead>
                       <tbody>
                         `)
-//line pkg/report/templates/workload_report.qtpl:202
+//line pkg/report/templates/workload_report.qtpl:344
 			for _, v := range report.Vulnerabilities {
-//line pkg/report/templates/workload_report.qtpl:202
+//line pkg/report/templates/workload_report.qtpl:344
 				qw422016.N().S(`
-                        <tr>
+                        <tr data-severity="`)
+//line pkg/report/templates/workload_report.qtpl:345
+				qw422016.E().S(string(v.Severity))
+//line pkg/report/templates/workload_report.qtpl:345
+				qw422016.N().S(`">
                           <td>
-                            <a target="_blank" href="`)
-//line pkg/report/templates/workload_report.qtpl:205
-				qw422016.E().S(v.PrimaryLink)
-//line pkg/report/templates/workload_report.qtpl:205
-				qw422016.N().S(`">`)
-//line pkg/report/templates/workload_report.qtpl:205
-				qw422016.E().S(v.VulnerabilityID)
-//line pkg/report/templates/workload_report.qtpl:205
-				qw422016.N().S(`</a>
+                            `)
+//line pkg/report/templates/workload_report.qtpl:347
+				if link := nvdLink(v.VulnerabilityID); link != "" {
+//line pkg/report/templates/workload_report.qtpl:347
+					qw422016.N().S(`
+                            <a target="_blank" rel="noopener" href="`)
+//line pkg/report/templates/workload_report.qtpl:348
+					qw422016.E().S(link)
+//line pkg/report/templates/workload_report.qtpl:348
+					qw422016.N().S(`">`)
+//line pkg/report/templates/workload_report.qtpl:348
+					qw422016.E().S(v.VulnerabilityID)
+//line pkg/report/templates/workload_report.qtpl:348
+					qw422016.N().S(`</a>
+                            `)
+//line pkg/report/templates/workload_report.qtpl:349
+				} else {
+//line pkg/report/templates/workload_report.qtpl:349
+					qw422016.N().S(`
+                            `)
+//line pkg/report/templates/workload_report.qtpl:350
+					qw422016.E().S(v.VulnerabilityID)
+//line pkg/report/templates/workload_report.qtpl:350
+					qw422016.N().S(`
+                            `)
+//line pkg/report/templates/workload_report.qtpl:351
+				}
+//line pkg/report/templates/workload_report.qtpl:351
+				qw422016.N().S(`
                           </td>
-                          <td>`)
-//line pkg/report/templates/workload_report.qtpl:207
+                          <td><span class="badge severity-`)
+//line pkg/report/templates/workload_report.qtpl:353
+				qw422016.E().S(strings.ToLower(string(v.Severity)))
+//line pkg/report/templates/workload_report.qtpl:353
+				qw422016.N().S(`">`)
+//line pkg/report/templates/workload_report.qtpl:353
 				qw422016.E().S(string(v.Severity))
-//line pkg/report/templates/workload_report.qtpl:207
-				qw422016.N().S(`</td>
+//line pkg/report/templates/workload_report.qtpl:353
+				qw422016.N().S(`</span></td>
                           <td>`)
-//line pkg/report/templates/workload_report.qtpl:208
+//line pkg/report/templates/workload_report.qtpl:354
 				qw422016.E().S(v.Resource)
-//line pkg/report/templates/workload_report.qtpl:208
+//line pkg/report/templates/workload_report.qtpl:354
 				qw422016.N().S(`</td>
                           <td>`)
-//line pkg/report/templates/workload_report.qtpl:209
+//line pkg/report/templates/workload_report.qtpl:355
 				qw422016.E().S(v.InstalledVersion)
-//line pkg/report/templates/workload_report.qtpl:209
+//line pkg/report/templates/workload_report.qtpl:355
 				qw422016.N().S(`</td>
                           <td>`)
-//line pkg/report/templates/workload_report.qtpl:210
+//line pkg/report/templates/workload_report.qtpl:356
 				qw422016.E().S(v.FixedVersion)
-//line pkg/report/templates/workload_report.qtpl:210
+//line pkg/report/templates/workload_report.qtpl:356
+				qw422016.N().S(`</td>
+                          <td>`)
+//line pkg/report/templates/workload_report.qtpl:357
+				qw422016.E().S(v.Title)
+//line pkg/report/templates/workload_report.qtpl:357
 				qw422016.N().S(`</td>
+                          <td>
+                            `)
+//line pkg/report/templates/workload_report.qtpl:359
+				if v.PrimaryLink != "" {
+//line pkg/report/templates/workload_report.qtpl:359
+					qw422016.N().S(`
+                            <a target="_blank" rel="noopener" href="`)
+//line pkg/report/templates/workload_report.qtpl:360
+					qw422016.E().S(v.PrimaryLink)
+//line pkg/report/templates/workload_report.qtpl:360
+					qw422016.N().S(`">Advisory</a>
+                            `)
+//line pkg/report/templates/workload_report.qtpl:361
+				}
+//line pkg/report/templates/workload_report.qtpl:361
+				qw422016.N().S(`
+                          </td>
                         </tr>
                         `)
-//line pkg/report/templates/workload_report.qtpl:212
+//line pkg/report/templates/workload_report.qtpl:364
 			}
-//line pkg/report/templates/workload_report.qtpl:212
+//line pkg/report/templates/workload_report.qtpl:364
 			qw422016.N().S(`
                       </tbody>
                     </table>
                   </div>
+                  <div class="row no-matches" hidden>
+                    <p class="text-muted" style="font-size: small;">No vulnerabilities match the filters</p>
+                  </div>
                 `)
-//line pkg/report/templates/workload_report.qtpl:216
+//line pkg/report/templates/workload_report.qtpl:371
 		}
-//line pkg/report/templates/workload_report.qtpl:216
+//line pkg/report/templates/workload_report.qtpl:371
 		qw422016.N().S(`
+                </div>
                 `)
-//line pkg/report/templates/workload_report.qtpl:217
+//line pkg/report/templates/workload_report.qtpl:373
 	}
-//line pkg/report/templates/workload_report.qtpl:217
+//line pkg/report/templates/workload_report.qtpl:373
 	qw422016.N().S(`
 
                 <!-- Config Audits -->
                 `)
-//line pkg/report/templates/workload_report.qtpl:220
+//line pkg/report/templates/workload_report.qtpl:376
 	if p.ConfigAuditReport != nil && len(p.ConfigAuditReport.Report.PodChecks) > 0 {
-//line pkg/report/templates/workload_report.qtpl:220
+//line pkg/report/templates/workload_report.qtpl:376
 		qw422016.N().S(`
                   <div class="row pt-3 text-center border-bottom my-4">
                       <h3 class="mx-auto" id="ca_header" style="color: rgb(0, 160, 170);">Configuration Audit</h3>
@@ -530,19 +846,19 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                              <div class="row">
                                 <div class="col">
                                     <p class="my-0">Name:  `)
-//line pkg/report/templates/workload_report.qtpl:236
+//line pkg/report/templates/workload_report.qtpl:392
 		qw422016.E().S(p.ConfigAuditReport.Report.Scanner.Name)
-//line pkg/report/templates/workload_report.qtpl:236
+//line pkg/report/templates/workload_report.qtpl:392
 		qw422016.N().S(`</p>
                                     <p class="my-0">Vendor:  `)
-//line pkg/report/templates/workload_report.qtpl:237
+//line pkg/report/templates/workload_report.qtpl:393
 		qw422016.E().S(p.ConfigAuditReport.Report.Scanner.Vendor)
-//line pkg/report/templates/workload_report.qtpl:237
+//line pkg/report/templates/workload_report.qtpl:393
 		qw422016.N().S(`</p>
                                     <p class="my-0">Version:  `)
-//line pkg/report/templates/workload_report.qtpl:238
+//line pkg/report/templates/workload_report.qtpl:394
 		qw422016.E().S(p.ConfigAuditReport.Report.Scanner.Version)
-//line pkg/report/templates/workload_report.qtpl:238
+//line pkg/report/templates/workload_report.qtpl:394
 		qw422016.N().S(`</p>
                                 </div>
                              </div>
@@ -556,85 +872,85 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                             </div>
                             <div class="row">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:251
+//line pkg/report/templates/workload_report.qtpl:407
 		sumDanger := p.ConfigAuditReport.Report.Summary.DangerCount
 		sumWarning := p.ConfigAuditReport.Report.Summary.WarningCount
 		sumPass := p.ConfigAuditReport.Report.Summary.PassCount
 
-//line pkg/report/templates/workload_report.qtpl:254
+//line pkg/report/templates/workload_report.qtpl:410
 		qw422016.N().S(`
 
                                 `)
-//line pkg/report/templates/workload_report.qtpl:256
+//line pkg/report/templates/workload_report.qtpl:412
 		if sumDanger > 0 {
-//line pkg/report/templates/workload_report.qtpl:256
+//line pkg/report/templates/workload_report.qtpl:412
 			qw422016.N().S(`
                                 <div class="col text-center p-0 text-danger font-weight-bold">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:258
+//line pkg/report/templates/workload_report.qtpl:414
 		} else {
-//line pkg/report/templates/workload_report.qtpl:258
+//line pkg/report/templates/workload_report.qtpl:414
 			qw422016.N().S(`
                                 <div class="col text-center p-0">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:260
+//line pkg/report/templates/workload_report.qtpl:416
 		}
-//line pkg/report/templates/workload_report.qtpl:260
+//line pkg/report/templates/workload_report.qtpl:416
 		qw422016.N().S(`
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:261
+//line pkg/report/templates/workload_report.qtpl:417
 		qw422016.N().D(sumDanger)
-//line pkg/report/templates/workload_report.qtpl:261
+//line pkg/report/templates/workload_report.qtpl:417
 		qw422016.N().S(`</p>
                                     <p class="mx-auto">DANGER</p>
                                 </div>
 
                                 `)
-//line pkg/report/templates/workload_report.qtpl:265
+//line pkg/report/templates/workload_report.qtpl:421
 		if sumWarning > 0 {
-//line pkg/report/templates/workload_report.qtpl:265
+//line pkg/report/templates/workload_report.qtpl:421
 			qw422016.N().S(`
                                 <div class="col text-center p-0 text-warning font-weight-bold">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:267
+//line pkg/report/templates/workload_report.qtpl:423
 		} else {
-//line pkg/report/templates/workload_report.qtpl:267
+//line pkg/report/templates/workload_report.qtpl:423
 			qw422016.N().S(`
                                 <div class="col text-center p-0">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:269
+//line pkg/report/templates/workload_report.qtpl:425
 		}
-//line pkg/report/templates/workload_report.qtpl:269
+//line pkg/report/templates/workload_report.qtpl:425
 		qw422016.N().S(`
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:270
+//line pkg/report/templates/workload_report.qtpl:426
 		qw422016.N().D(sumWarning)
-//line pkg/report/templates/workload_report.qtpl:270
+//line pkg/report/templates/workload_report.qtpl:426
 		qw422016.N().S(`</p>
                                     <p class="mx-auto">WARNING</p>
                                 </div>
 
                                 `)
-//line pkg/report/templates/workload_report.qtpl:274
+//line pkg/report/templates/workload_report.qtpl:430
 		if sumPass > 0 {
-//line pkg/report/templates/workload_report.qtpl:274
+//line pkg/report/templates/workload_report.qtpl:430
 			qw422016.N().S(`
                                 <div class="col text-center p-0 text-success font-weight-bold">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:276
+//line pkg/report/templates/workload_report.qtpl:432
 		} else {
-//line pkg/report/templates/workload_report.qtpl:276
+//line pkg/report/templates/workload_report.qtpl:432
 			qw422016.N().S(`
                                 <div class="col text-center p-0">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:278
+//line pkg/report/templates/workload_report.qtpl:434
 		}
-//line pkg/report/templates/workload_report.qtpl:278
+//line pkg/report/templates/workload_report.qtpl:434
 		qw422016.N().S(`
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:279
+//line pkg/report/templates/workload_report.qtpl:435
 		qw422016.N().D(sumPass)
-//line pkg/report/templates/workload_report.qtpl:279
+//line pkg/report/templates/workload_report.qtpl:435
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">PASS</p>
                                 </div>
@@ -651,9 +967,9 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                                 <div class="col">
                                     <p class="my-0">
                                         Generated at:  `)
-//line pkg/report/templates/workload_report.qtpl:294
+//line pkg/report/templates/workload_report.qtpl:450
 		qw422016.E().S(p.ConfigAuditReport.Report.UpdateTimestamp.Format("2 Jan 2006 15:04:01"))
-//line pkg/report/templates/workload_report.qtpl:294
+//line pkg/report/templates/workload_report.qtpl:450
 		qw422016.N().S(`
                                     </p>
                                 </div>
@@ -674,53 +990,59 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                             </thead>
                             <tbody>
                               `)
-//line pkg/report/templates/workload_report.qtpl:313
+//line pkg/report/templates/workload_report.qtpl:469
 		for _, check := range p.ConfigAuditReport.Report.PodChecks {
-//line pkg/report/templates/workload_report.qtpl:313
+//line pkg/report/templates/workload_report.qtpl:469
 			qw422016.N().S(`
                                 <tr>
                                   <td>`)
-//line pkg/report/templates/workload_report.qtpl:315
+//line pkg/report/templates/workload_report.qtpl:471
 			qw422016.E().V(check.Success)
-//line pkg/report/templates/workload_report.qtpl:315
+//line pkg/report/templates/workload_report.qtpl:471
 			qw422016.N().S(`</td>
                                   <td>`)
-//line pkg/report/templates/workload_report.qtpl:316
+//line pkg/report/templates/workload_report.qtpl:472
 			qw422016.E().S(check.ID)
-//line pkg/report/templates/workload_report.qtpl:316
+//line pkg/report/templates/workload_report.qtpl:472
 			qw422016.N().S(`</td>
                                   <td>`)
-//line pkg/report/templates/workload_report.qtpl:317
+//line pkg/report/templates/workload_report.qtpl:473
 			qw422016.E().S(check.Severity)
-//line pkg/report/templates/workload_report.qtpl:317
+//line pkg/report/templates/workload_report.qtpl:473
 			qw422016.N().S(`</td>
                                   <td>`)
-//line pkg/report/templates/workload_report.qtpl:318
+//line pkg/report/templates/workload_report.qtpl:474
 			qw422016.E().S(check.Category)
-//line pkg/report/templates/workload_report.qtpl:318
+//line pkg/report/templates/workload_report.qtpl:474
 			qw422016.N().S(`</td>
                                 </tr>
                               `)
-//line pkg/report/templates/workload_report.qtpl:320
+//line pkg/report/templates/workload_report.qtpl:476
 		}
-//line pkg/report/templates/workload_report.qtpl:320
+//line pkg/report/templates/workload_report.qtpl:476
 		qw422016.N().S(`
                             </tbody>
                       </table>
                   </div>
                   `)
-//line pkg/report/templates/workload_report.qtpl:324
-		for container, checks := range p.ConfigAuditReport.Report.ContainerChecks {
-//line pkg/report/templates/workload_report.qtpl:324
+//line pkg/report/templates/workload_report.qtpl:480
+		for _, container := range p.ConfigAuditContainers() {
+//line pkg/report/templates/workload_report.qtpl:480
+			qw422016.N().S(`
+                    `)
+//line pkg/report/templates/workload_report.qtpl:481
+			checks := p.ConfigAuditReport.Report.ContainerChecks[container]
+
+//line pkg/report/templates/workload_report.qtpl:481
 			qw422016.N().S(`
                     <div class="row"><h5 class="text-info" id="ca_container_`)
-//line pkg/report/templates/workload_report.qtpl:325
+//line pkg/report/templates/workload_report.qtpl:482
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:325
+//line pkg/report/templates/workload_report.qtpl:482
 			qw422016.N().S(`">Container `)
-//line pkg/report/templates/workload_report.qtpl:325
+//line pkg/report/templates/workload_report.qtpl:482
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:325
+//line pkg/report/templates/workload_report.qtpl:482
 			qw422016.N().S(`</h5></div>
                     <div class="row">
                         <table class="table table-sm table-bordered">
@@ -734,78 +1056,178 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                               </thead>
                               <tbody>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:337
+//line pkg/report/templates/workload_report.qtpl:494
 			for _, check := range checks {
-//line pkg/report/templates/workload_report.qtpl:337
+//line pkg/report/templates/workload_report.qtpl:494
 				qw422016.N().S(`
                                   <tr>
                                     <td>`)
-//line pkg/report/templates/workload_report.qtpl:339
+//line pkg/report/templates/workload_report.qtpl:496
 				qw422016.E().V(check.Success)
-//line pkg/report/templates/workload_report.qtpl:339
+//line pkg/report/templates/workload_report.qtpl:496
 				qw422016.N().S(`</td>
                                     <td>`)
-//line pkg/report/templates/workload_report.qtpl:340
+//line pkg/report/templates/workload_report.qtpl:497
 				qw422016.E().S(check.ID)
-//line pkg/report/templates/workload_report.qtpl:340
+//line pkg/report/templates/workload_report.qtpl:497
 				qw422016.N().S(`</td>
                                     <td>`)
-//line pkg/report/templates/workload_report.qtpl:341
+//line pkg/report/templates/workload_report.qtpl:498
 				qw422016.E().S(check.Severity)
-//line pkg/report/templates/workload_report.qtpl:341
+//line pkg/report/templates/workload_report.qtpl:498
 				qw422016.N().S(`</td>
                                     <td>`)
-//line pkg/report/templates/workload_report.qtpl:342
+//line pkg/report/templates/workload_report.qtpl:499
 				qw422016.E().S(check.Category)
-//line pkg/report/templates/workload_report.qtpl:342
+//line pkg/report/templates/workload_report.qtpl:499
 				qw422016.N().S(`</td>
                                   </tr>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:344
+//line pkg/report/templates/workload_report.qtpl:501
 			}
-//line pkg/report/templates/workload_report.qtpl:344
+//line pkg/report/templates/workload_report.qtpl:501
 			qw422016.N().S(`
                               </tbody>
                         </table>
                     </div>
                   `)
-//line pkg/report/templates/workload_report.qtpl:348
+//line pkg/report/templates/workload_report.qtpl:505
 		}
-//line pkg/report/templates/workload_report.qtpl:348
+//line pkg/report/templates/workload_report.qtpl:505
 		qw422016.N().S(`
                   `)
-//line pkg/report/templates/workload_report.qtpl:349
+//line pkg/report/templates/workload_report.qtpl:506
 	}
-//line pkg/report/templates/workload_report.qtpl:349
+//line pkg/report/templates/workload_report.qtpl:506
 	qw422016.N().S(`
             </div>
         </div>
+  `)
+//line pkg/report/templates/workload_report.qtpl:509
+	streamworkloadReportScript(qw422016)
+//line pkg/report/templates/workload_report.qtpl:509
+	qw422016.N().S(`
 `)
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:510
 }
 
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:510
 func (p *WorkloadReport) WriteBody(qq422016 qtio422016.Writer) {
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:510
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:510
 	p.StreamBody(qw422016)
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:510
 	qt422016.ReleaseWriter(qw422016)
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:510
 }
 
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:510
 func (p *WorkloadReport) Body() string {
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:510
 	qb422016 := qt422016.AcquireByteBuffer()
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:510
 	p.WriteBody(qb422016)
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:510
+	qs422016 := string(qb422016.B)
+//line pkg/report/templates/workload_report.qtpl:510
+	qt422016.ReleaseByteBuffer(qb422016)
+//line pkg/report/templates/workload_report.qtpl:510
+	return qs422016
+//line pkg/report/templates/workload_report.qtpl:510
+}
+
+// workloadReportScript prints the script that switches tabs of containers and filters
+// vulnerabilities by severity and search. Without the script all containers and
+// vulnerabilities are shown.
+
+//line pkg/report/templates/workload_report.qtpl:515
+func streamworkloadReportScript(qw422016 *qt422016.Writer) {
+//line pkg/report/templates/workload_report.qtpl:515
+	qw422016.N().S(`
+  <script>
+  (function () {
+    var report = document.getElementById("report");
+    var tabs = report.querySelectorAll(".tab");
+    var panels = report.querySelectorAll(".tab-panel");
+    var filters = report.querySelectorAll(".severity-filter");
+    var search = document.getElementById("vulns_search");
+    if (tabs.length === 0) {
+      return;
+    }
+    report.classList.add("js");
+
+    function showTab(id) {
+      tabs.forEach(function (tab) {
+        tab.classList.toggle("active", tab.dataset.tab === id);
+      });
+      panels.forEach(function (panel) {
+        panel.classList.toggle("active", panel.id === id);
+      });
+    }
+
+    function applyFilters() {
+      var severities = {};
+      filters.forEach(function (filter) {
+        severities[filter.value] = filter.checked;
+      });
+      var query = search.value.trim().toLowerCase();
+      panels.forEach(function (panel) {
+        var rows = panel.querySelectorAll("tr[data-severity]");
+        var shown = 0;
+        rows.forEach(function (row) {
+          var show = severities[row.dataset.severity] !== false &&
+            row.textContent.toLowerCase().indexOf(query) !== -1;
+          row.hidden = !show;
+          if (show) {
+            shown++;
+          }
+        });
+        var noMatches = panel.querySelector(".no-matches");
+        if (noMatches) {
+          noMatches.hidden = shown > 0;
+        }
+      });
+    }
+
+    report.querySelectorAll("[data-tab]").forEach(function (element) {
+      element.addEventListener("click", function () {
+        showTab(element.dataset.tab);
+      });
+    });
+    filters.forEach(function (filter) {
+      filter.addEventListener("change", applyFilters);
+    });
+    search.addEventListener("input", applyFilters);
+    showTab(tabs[0].dataset.tab);
+  })();
+  </script>
+`)
+//line pkg/report/templates/workload_report.qtpl:573
+}
+
+//line pkg/report/templates/workload_report.qtpl:573
+func writeworkloadReportScript(qq422016 qtio422016.Writer) {
+//line pkg/report/templates/workload_report.qtpl:573
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line pkg/report/templates/workload_report.qtpl:573
+	streamworkloadReportScript(qw422016)
+//line pkg/report/templates/workload_report.qtpl:573
+	qt422016.ReleaseWriter(qw422016)
+//line pkg/report/templates/workload_report.qtpl:573
+}
+
+//line pkg/report/templates/workload_report.qtpl:573
+func workloadReportScript() string {
+//line pkg/report/templates/workload_report.qtpl:573
+	qb422016 := qt422016.AcquireByteBuffer()
+//line pkg/report/templates/workload_report.qtpl:573
+	writeworkloadReportScript(qb422016)
+//line pkg/report/templates/workload_report.qtpl:573
 	qs422016 := string(qb422016.B)
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:573
 	qt422016.ReleaseByteBuffer(qb422016)
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:573
 	return qs422016
-//line pkg/report/templates/workload_report.qtpl:352
+//line pkg/report/templates/workload_report.qtpl:573
 }
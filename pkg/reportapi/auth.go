@@ -0,0 +1,68 @@
+package reportapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Auth authenticates and authorizes callers of the API.
+type Auth interface {
+	// Authenticate returns the user identified by the bearer token, or false
+	// if the token is not valid.
+	Authenticate(ctx context.Context, token string) (authenticationv1.UserInfo, bool, error)
+	// CanListReports returns true if the user can list reports of the
+	// resource, e.g. vulnerabilityreports, in the namespace, or in all
+	// namespaces if the namespace is empty.
+	CanListReports(ctx context.Context, user authenticationv1.UserInfo, resource, namespace string) (bool, error)
+}
+
+type kubeAuth struct {
+	clientset kubernetes.Interface
+}
+
+// NewKubeAuth constructs a new Auth, which reviews tokens with TokenReviews
+// and checks whether users can list reports with SubjectAccessReviews.
+func NewKubeAuth(clientset kubernetes.Interface) Auth {
+	return &kubeAuth{clientset: clientset}
+}
+
+func (a *kubeAuth) Authenticate(ctx context.Context, token string) (authenticationv1.UserInfo, bool, error) {
+	review, err := a.clientset.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, false, fmt.Errorf("reviewing token: %w", err)
+	}
+	return review.Status.User, review.Status.Authenticated, nil
+}
+
+func (a *kubeAuth) CanListReports(ctx context.Context, user authenticationv1.UserInfo, resource, namespace string) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review, err := a.clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "list",
+				Group:     v1alpha1.SchemeGroupVersion.Group,
+				Resource:  resource,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("reviewing access: %w", err)
+	}
+	return review.Status.Allowed, nil
+}
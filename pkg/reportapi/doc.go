@@ -0,0 +1,9 @@
+// Package reportapi provides a read-only REST API of reports, which lets
+// dashboards and scripts list and filter vulnerabilities without permissions
+// to list custom resources across the cluster or downloading full reports,
+// and a GraphQL endpoint that joins reports of different kinds by workloads.
+//
+// Callers are authenticated with bearer tokens reviewed by the Kubernetes API
+// server, and they can only read reports of kinds and namespaces in which
+// Kubernetes RBAC allows them to list those reports.
+package reportapi
@@ -0,0 +1,541 @@
+package reportapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/graph-gophers/graphql-go"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PathGraphQL is the path of the GraphQL endpoint, which joins
+// VulnerabilityReports and ConfigAuditReports of workloads, e.g. to find
+// workloads in a namespace with critical vulnerabilities and failed checks.
+//
+// The endpoint accepts POST requests with the JSON body of the query, the
+// operationName and variables, as defined by the GraphQL over HTTP
+// convention. The schema is defined by the Schema constant.
+const PathGraphQL = "/v1alpha1/graphql"
+
+// Schema is the GraphQL schema of PathGraphQL.
+//
+// Filters of the workloads query are combined with the logical AND. Workloads
+// match vulnerabilitySeverities and cve if any of their VulnerabilityReports
+// has a matching vulnerability, and they match failedChecks or
+// failedCheckSeverities if their ConfigAuditReport has a failed check, with
+// any of the severities if set.
+const Schema = `
+schema {
+	query: Query
+}
+
+type Query {
+	workloads(
+		namespace: String
+		kind: String
+		vulnerabilitySeverities: [String!]
+		cve: String
+		failedChecks: Boolean
+		failedCheckSeverities: [String!]
+	): [Workload!]!
+}
+
+type Workload {
+	namespace: String!
+	kind: String!
+	name: String!
+	vulnerabilityReports(severities: [String!], cve: String): [VulnerabilityReport!]!
+	configAuditReport: ConfigAuditReport
+}
+
+type VulnerabilityReport {
+	name: String!
+	container: String!
+	image: String!
+	scanner: String!
+	updateTimestamp: String!
+	summary: VulnerabilitySummary!
+	vulnerabilities(severities: [String!], cve: String): [Vulnerability!]!
+}
+
+type VulnerabilitySummary {
+	criticalCount: Int!
+	highCount: Int!
+	mediumCount: Int!
+	lowCount: Int!
+	unknownCount: Int!
+}
+
+type Vulnerability {
+	id: String!
+	severity: String!
+	resource: String!
+	installedVersion: String!
+	fixedVersion: String!
+	title: String!
+	score: Float
+}
+
+type ConfigAuditReport {
+	name: String!
+	scanner: String!
+	updateTimestamp: String!
+	summary: ConfigAuditSummary!
+	checks(success: Boolean, severities: [String!]): [Check!]!
+}
+
+type ConfigAuditSummary {
+	passCount: Int!
+	dangerCount: Int!
+	warningCount: Int!
+}
+
+type Check {
+	id: String!
+	message: String!
+	remediation: String!
+	success: Boolean!
+	severity: String!
+	category: String!
+}
+`
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type accessKey struct{}
+
+func (h *handler) graphQL(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	user, ok := h.authenticate(w, req)
+	if !ok {
+		return
+	}
+	var body graphQLRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	ctx := context.WithValue(req.Context(), accessKey{}, newNamespaceAccess(h.auth, user))
+	writeJSON(w, http.StatusOK, h.schema.Exec(ctx, body.Query, body.OperationName, body.Variables))
+}
+
+type queryResolver struct {
+	reader client.Reader
+}
+
+func newSchema(reader client.Reader) *graphql.Schema {
+	return graphql.MustParseSchema(Schema, &queryResolver{reader: reader})
+}
+
+type workloadsArgs struct {
+	Namespace               *string
+	Kind                    *string
+	VulnerabilitySeverities *[]string
+	CVE                     *string
+	FailedChecks            *bool
+	FailedCheckSeverities   *[]string
+}
+
+func (r *queryResolver) Workloads(ctx context.Context, args workloadsArgs) ([]*workloadResolver, error) {
+	access := ctx.Value(accessKey{}).(*namespaceAccess)
+	namespace := stringValue(args.Namespace)
+
+	vulnerabilityReports, err := r.listVulnerabilityReports(ctx, access, namespace, stringValue(args.CVE))
+	if err != nil {
+		return nil, err
+	}
+	configAuditReports, err := r.listConfigAuditReports(ctx, access, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	type workloadKey struct {
+		namespace string
+		workload  Workload
+	}
+	workloads := make(map[workloadKey]*workloadResolver)
+	get := func(namespace string, labels map[string]string) *workloadResolver {
+		key := workloadKey{
+			namespace: namespace,
+			workload:  Workload{Kind: labels[starboard.LabelResourceKind], Name: labels[starboard.LabelResourceName]},
+		}
+		if args.Kind != nil && key.workload.Kind != *args.Kind {
+			return nil
+		}
+		w, ok := workloads[key]
+		if !ok {
+			w = &workloadResolver{namespace: key.namespace, workload: key.workload}
+			workloads[key] = w
+		}
+		return w
+	}
+	for i := range vulnerabilityReports {
+		if w := get(vulnerabilityReports[i].Namespace, vulnerabilityReports[i].Labels); w != nil {
+			w.vulnerabilityReports = append(w.vulnerabilityReports, &vulnerabilityReports[i])
+		}
+	}
+	for i := range configAuditReports {
+		if w := get(configAuditReports[i].Namespace, configAuditReports[i].Labels); w != nil {
+			w.configAuditReport = &configAuditReports[i]
+		}
+	}
+
+	vulnerabilityFilter := filter{cve: stringValue(args.CVE)}
+	if args.VulnerabilitySeverities != nil {
+		vulnerabilityFilter.severities = newSeveritySet(*args.VulnerabilitySeverities)
+	}
+	var checkSeverities map[v1alpha1.Severity]bool
+	if args.FailedCheckSeverities != nil {
+		checkSeverities = newSeveritySet(*args.FailedCheckSeverities)
+	}
+
+	result := []*workloadResolver{}
+	for _, w := range workloads {
+		if (vulnerabilityFilter.severities != nil || vulnerabilityFilter.cve != "") && !w.hasVulnerabilities(vulnerabilityFilter) {
+			continue
+		}
+		if (checkSeverities != nil || args.FailedChecks != nil) &&
+			w.hasFailedChecks(checkSeverities) != (args.FailedChecks == nil || *args.FailedChecks) {
+			continue
+		}
+		result = append(result, w)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.namespace != b.namespace {
+			return a.namespace < b.namespace
+		}
+		if a.workload.Kind != b.workload.Kind {
+			return a.workload.Kind < b.workload.Kind
+		}
+		return a.workload.Name < b.workload.Name
+	})
+	return result, nil
+}
+
+// listVulnerabilityReports returns VulnerabilityReports in the namespace, or
+// in all namespaces if it's empty, that the caller can read.
+func (r *queryResolver) listVulnerabilityReports(ctx context.Context, access *namespaceAccess, namespace, cve string) ([]v1alpha1.VulnerabilityReport, error) {
+	opts := []client.ListOption{client.InNamespace(namespace)}
+	if cve != "" {
+		if label := vulnerabilityreport.GetVulnerabilityIDLabel(cve); label != "" {
+			opts = append(opts, client.HasLabels{label})
+		}
+	}
+	var list v1alpha1.VulnerabilityReportList
+	if err := r.reader.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	var reports []v1alpha1.VulnerabilityReport
+	for _, report := range list.Items {
+		allowed, err := access.canList(ctx, resourceVulnerabilityReports, report.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
+
+// listConfigAuditReports returns ConfigAuditReports in the namespace, or in
+// all namespaces if it's empty, that the caller can read.
+func (r *queryResolver) listConfigAuditReports(ctx context.Context, access *namespaceAccess, namespace string) ([]v1alpha1.ConfigAuditReport, error) {
+	var list v1alpha1.ConfigAuditReportList
+	if err := r.reader.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	var reports []v1alpha1.ConfigAuditReport
+	for _, report := range list.Items {
+		allowed, err := access.canList(ctx, resourceConfigAuditReports, report.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
+
+type workloadResolver struct {
+	namespace            string
+	workload             Workload
+	vulnerabilityReports []*v1alpha1.VulnerabilityReport
+	configAuditReport    *v1alpha1.ConfigAuditReport
+}
+
+func (r *workloadResolver) hasVulnerabilities(f filter) bool {
+	for _, report := range r.vulnerabilityReports {
+		if _, ok := f.match(report.Report); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFailedChecks returns true if the ConfigAuditReport of the workload has
+// failed checks with any of the severities, or any failed checks if
+// severities are nil.
+func (r *workloadResolver) hasFailedChecks(severities map[v1alpha1.Severity]bool) bool {
+	if r.configAuditReport == nil {
+		return false
+	}
+	for _, check := range r.configAuditReport.Report.Checks {
+		if !check.Success && (severities == nil || severities[v1alpha1.Severity(check.Severity)]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *workloadResolver) Namespace() string {
+	return r.namespace
+}
+
+func (r *workloadResolver) Kind() string {
+	return r.workload.Kind
+}
+
+func (r *workloadResolver) Name() string {
+	return r.workload.Name
+}
+
+type vulnerabilitiesArgs struct {
+	Severities *[]string
+	CVE        *string
+}
+
+func (args vulnerabilitiesArgs) filter() filter {
+	f := filter{cve: stringValue(args.CVE)}
+	if args.Severities != nil {
+		f.severities = newSeveritySet(*args.Severities)
+	}
+	return f
+}
+
+func (r *workloadResolver) VulnerabilityReports(args vulnerabilitiesArgs) []*vulnerabilityReportResolver {
+	f := args.filter()
+	result := []*vulnerabilityReportResolver{}
+	for _, report := range r.vulnerabilityReports {
+		if _, ok := f.match(report.Report); ok {
+			result = append(result, &vulnerabilityReportResolver{report: report})
+		}
+	}
+	return result
+}
+
+func (r *workloadResolver) ConfigAuditReport() *configAuditReportResolver {
+	if r.configAuditReport == nil {
+		return nil
+	}
+	return &configAuditReportResolver{report: r.configAuditReport}
+}
+
+type vulnerabilityReportResolver struct {
+	report *v1alpha1.VulnerabilityReport
+}
+
+func (r *vulnerabilityReportResolver) Name() string {
+	return r.report.Name
+}
+
+func (r *vulnerabilityReportResolver) Container() string {
+	return r.report.Labels[starboard.LabelContainerName]
+}
+
+func (r *vulnerabilityReportResolver) Image() string {
+	return vulnerabilityreport.GetImageRef(r.report.Report)
+}
+
+func (r *vulnerabilityReportResolver) Scanner() string {
+	return r.report.Report.Scanner.Name
+}
+
+func (r *vulnerabilityReportResolver) UpdateTimestamp() string {
+	return formatTime(r.report.Report.UpdateTimestamp.Time)
+}
+
+func (r *vulnerabilityReportResolver) Summary() *vulnerabilitySummaryResolver {
+	return &vulnerabilitySummaryResolver{summary: r.report.Report.Summary}
+}
+
+func (r *vulnerabilityReportResolver) Vulnerabilities(args vulnerabilitiesArgs) []*vulnerabilityResolver {
+	vulnerabilities, _ := args.filter().match(r.report.Report)
+	result := make([]*vulnerabilityResolver, len(vulnerabilities))
+	for i := range vulnerabilities {
+		result[i] = &vulnerabilityResolver{vulnerability: vulnerabilities[i]}
+	}
+	return result
+}
+
+type vulnerabilitySummaryResolver struct {
+	summary v1alpha1.VulnerabilitySummary
+}
+
+func (r *vulnerabilitySummaryResolver) CriticalCount() int32 {
+	return int32(r.summary.CriticalCount)
+}
+
+func (r *vulnerabilitySummaryResolver) HighCount() int32 {
+	return int32(r.summary.HighCount)
+}
+
+func (r *vulnerabilitySummaryResolver) MediumCount() int32 {
+	return int32(r.summary.MediumCount)
+}
+
+func (r *vulnerabilitySummaryResolver) LowCount() int32 {
+	return int32(r.summary.LowCount)
+}
+
+func (r *vulnerabilitySummaryResolver) UnknownCount() int32 {
+	return int32(r.summary.UnknownCount)
+}
+
+type vulnerabilityResolver struct {
+	vulnerability Vulnerability
+}
+
+func (r *vulnerabilityResolver) ID() string {
+	return r.vulnerability.ID
+}
+
+func (r *vulnerabilityResolver) Severity() string {
+	return string(r.vulnerability.Severity)
+}
+
+func (r *vulnerabilityResolver) Resource() string {
+	return r.vulnerability.Resource
+}
+
+func (r *vulnerabilityResolver) InstalledVersion() string {
+	return r.vulnerability.InstalledVersion
+}
+
+func (r *vulnerabilityResolver) FixedVersion() string {
+	return r.vulnerability.FixedVersion
+}
+
+func (r *vulnerabilityResolver) Title() string {
+	return r.vulnerability.Title
+}
+
+func (r *vulnerabilityResolver) Score() *float64 {
+	return r.vulnerability.Score
+}
+
+type configAuditReportResolver struct {
+	report *v1alpha1.ConfigAuditReport
+}
+
+func (r *configAuditReportResolver) Name() string {
+	return r.report.Name
+}
+
+func (r *configAuditReportResolver) Scanner() string {
+	return r.report.Report.Scanner.Name
+}
+
+func (r *configAuditReportResolver) UpdateTimestamp() string {
+	return formatTime(r.report.Report.UpdateTimestamp.Time)
+}
+
+func (r *configAuditReportResolver) Summary() *configAuditSummaryResolver {
+	return &configAuditSummaryResolver{summary: r.report.Report.Summary}
+}
+
+type checksArgs struct {
+	Success    *bool
+	Severities *[]string
+}
+
+func (r *configAuditReportResolver) Checks(args checksArgs) []*checkResolver {
+	var severities map[v1alpha1.Severity]bool
+	if args.Severities != nil {
+		severities = newSeveritySet(*args.Severities)
+	}
+	result := []*checkResolver{}
+	for _, check := range r.report.Report.Checks {
+		if args.Success != nil && check.Success != *args.Success {
+			continue
+		}
+		if severities != nil && !severities[v1alpha1.Severity(check.Severity)] {
+			continue
+		}
+		result = append(result, &checkResolver{check: check})
+	}
+	return result
+}
+
+type configAuditSummaryResolver struct {
+	summary v1alpha1.ConfigAuditSummary
+}
+
+func (r *configAuditSummaryResolver) PassCount() int32 {
+	return int32(r.summary.PassCount)
+}
+
+func (r *configAuditSummaryResolver) DangerCount() int32 {
+	return int32(r.summary.DangerCount)
+}
+
+func (r *configAuditSummaryResolver) WarningCount() int32 {
+	return int32(r.summary.WarningCount)
+}
+
+type checkResolver struct {
+	check v1alpha1.Check
+}
+
+func (r *checkResolver) ID() string {
+	return r.check.ID
+}
+
+func (r *checkResolver) Message() string {
+	return r.check.Message
+}
+
+func (r *checkResolver) Remediation() string {
+	return r.check.Remediation
+}
+
+func (r *checkResolver) Success() bool {
+	return r.check.Success
+}
+
+func (r *checkResolver) Severity() string {
+	return r.check.Severity
+}
+
+func (r *checkResolver) Category() string {
+	return r.check.Category
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
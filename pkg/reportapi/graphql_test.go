@@ -0,0 +1,155 @@
+package reportapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/reportapi"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestHandler_GraphQL(t *testing.T) {
+	labels := func(kind, name string) map[string]string {
+		return map[string]string{
+			starboard.LabelResourceKind:  kind,
+			starboard.LabelResourceName:  name,
+			starboard.LabelContainerName: name,
+		}
+	}
+	newVulnerabilityReport := func(namespace, kind, name string, severity v1alpha1.Severity) client.Object {
+		return &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name + "-" + name, Labels: labels(kind, name)},
+			Report: v1alpha1.VulnerabilityReportData{
+				Vulnerabilities: []v1alpha1.Vulnerability{{VulnerabilityID: "CVE-2021-44228", Severity: severity}},
+			},
+		}
+	}
+	newConfigAuditReport := func(namespace, kind, name string, success bool) client.Object {
+		return &v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels(kind, name)},
+			Report: v1alpha1.ConfigAuditReportData{
+				Checks: []v1alpha1.Check{{ID: "KSV012", Severity: "MEDIUM", Success: success}},
+			},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		newVulnerabilityReport("default", "ReplicaSet", "nginx", v1alpha1.SeverityCritical),
+		newConfigAuditReport("default", "ReplicaSet", "nginx", false),
+		newVulnerabilityReport("default", "ReplicaSet", "app", v1alpha1.SeverityCritical),
+		newConfigAuditReport("default", "ReplicaSet", "app", true),
+		newVulnerabilityReport("default", "StatefulSet", "redis", v1alpha1.SeverityLow),
+		newConfigAuditReport("default", "StatefulSet", "redis", false),
+		newVulnerabilityReport("kube-system", "ReplicaSet", "coredns", v1alpha1.SeverityCritical),
+		newConfigAuditReport("kube-system", "ReplicaSet", "coredns", false),
+	).Build()
+	server := httptest.NewServer(reportapi.NewHandler(log.Log, c, &fakeAuth{}))
+	defer server.Close()
+
+	type response struct {
+		Data struct {
+			Workloads []struct {
+				Namespace            string `json:"namespace"`
+				Kind                 string `json:"kind"`
+				Name                 string `json:"name"`
+				VulnerabilityReports []struct {
+					Container       string `json:"container"`
+					Vulnerabilities []struct {
+						ID string `json:"id"`
+					} `json:"vulnerabilities"`
+				} `json:"vulnerabilityReports"`
+				ConfigAuditReport *struct {
+					Checks []struct {
+						ID string `json:"id"`
+					} `json:"checks"`
+				} `json:"configAuditReport"`
+			} `json:"workloads"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	post := func(t *testing.T, token, query string) (int, response) {
+		t.Helper()
+		body, err := json.Marshal(map[string]string{"query": query})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, server.URL+reportapi.PathGraphQL, bytes.NewReader(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var r response
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&r))
+		}
+		return resp.StatusCode, r
+	}
+
+	t.Run("Should reject requests without token", func(t *testing.T) {
+		status, _ := post(t, "", `{ workloads { name } }`)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("Should join reports of workloads with critical vulnerabilities and failed checks", func(t *testing.T) {
+		status, r := post(t, "dev-token", `{
+			workloads(vulnerabilitySeverities: ["CRITICAL"], failedChecks: true) {
+				namespace
+				kind
+				name
+				vulnerabilityReports(severities: ["CRITICAL"]) {
+					container
+					vulnerabilities(severities: ["CRITICAL"]) { id }
+				}
+				configAuditReport {
+					checks(success: false) { id }
+				}
+			}
+		}`)
+		require.Equal(t, http.StatusOK, status)
+		require.Empty(t, r.Errors)
+		require.Len(t, r.Data.Workloads, 1)
+		workload := r.Data.Workloads[0]
+		assert.Equal(t, "default", workload.Namespace)
+		assert.Equal(t, "ReplicaSet", workload.Kind)
+		assert.Equal(t, "nginx", workload.Name)
+		require.Len(t, workload.VulnerabilityReports, 1)
+		assert.Equal(t, "nginx", workload.VulnerabilityReports[0].Container)
+		require.Len(t, workload.VulnerabilityReports[0].Vulnerabilities, 1)
+		assert.Equal(t, "CVE-2021-44228", workload.VulnerabilityReports[0].Vulnerabilities[0].ID)
+		require.NotNil(t, workload.ConfigAuditReport)
+		require.Len(t, workload.ConfigAuditReport.Checks, 1)
+		assert.Equal(t, "KSV012", workload.ConfigAuditReport.Checks[0].ID)
+	})
+
+	t.Run("Should return workloads of readable namespaces", func(t *testing.T) {
+		status, r := post(t, "dev-token", `{ workloads { namespace kind name } }`)
+		require.Equal(t, http.StatusOK, status)
+		require.Empty(t, r.Errors)
+		var names []string
+		for _, workload := range r.Data.Workloads {
+			assert.Equal(t, "default", workload.Namespace)
+			names = append(names, workload.Kind+"/"+workload.Name)
+		}
+		assert.Equal(t, []string{"ReplicaSet/app", "ReplicaSet/nginx", "StatefulSet/redis"}, names)
+	})
+
+	t.Run("Should filter workloads without failed checks", func(t *testing.T) {
+		status, r := post(t, "dev-token", `{ workloads(kind: "ReplicaSet", failedChecks: false) { name } }`)
+		require.Equal(t, http.StatusOK, status)
+		require.Empty(t, r.Errors)
+		require.Len(t, r.Data.Workloads, 1)
+		assert.Equal(t, "app", r.Data.Workloads[0].Name)
+	})
+}
@@ -0,0 +1,286 @@
+package reportapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	"github.com/graph-gophers/graphql-go"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PathVulnerabilityReports is the path of the endpoint that lists
+// VulnerabilityReports.
+//
+// The endpoint accepts the namespace, severity, cve and image query
+// parameters, which are combined with the logical AND. The severity is a
+// comma separated list of severities, and the image matches reports of images
+// whose references contain the value. Vulnerabilities matching the severity
+// and cve are only included in the response if the vulnerabilities parameter
+// is "true".
+const PathVulnerabilityReports = "/v1alpha1/vulnerabilityreports"
+
+// Workload identifies the workload of a report.
+type Workload struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// Vulnerability is a vulnerability of a report without its description and
+// links.
+type Vulnerability struct {
+	ID               string            `json:"id"`
+	Severity         v1alpha1.Severity `json:"severity"`
+	Resource         string            `json:"resource"`
+	InstalledVersion string            `json:"installedVersion"`
+	FixedVersion     string            `json:"fixedVersion"`
+	Title            string            `json:"title,omitempty"`
+	Score            *float64          `json:"score,omitempty"`
+}
+
+// VulnerabilityReport is a summary of a VulnerabilityReport.
+type VulnerabilityReport struct {
+	Namespace       string                        `json:"namespace"`
+	Name            string                        `json:"name"`
+	Workload        Workload                      `json:"workload"`
+	Container       string                        `json:"container"`
+	Image           string                        `json:"image"`
+	Scanner         string                        `json:"scanner"`
+	UpdateTimestamp metav1.Time                   `json:"updateTimestamp"`
+	Summary         v1alpha1.VulnerabilitySummary `json:"summary"`
+	Vulnerabilities []Vulnerability               `json:"vulnerabilities,omitempty"`
+}
+
+// VulnerabilityReportList is the response of PathVulnerabilityReports.
+type VulnerabilityReportList struct {
+	Items []VulnerabilityReport `json:"items"`
+}
+
+// ErrorResponse is the response of failed requests.
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+type handler struct {
+	logger logr.Logger
+	reader client.Reader
+	auth   Auth
+	schema *graphql.Schema
+}
+
+// NewHandler constructs a new http.Handler that serves reports read with the
+// specified reader to callers allowed by auth.
+func NewHandler(logger logr.Logger, reader client.Reader, auth Auth) http.Handler {
+	h := &handler{logger: logger, reader: reader, auth: auth, schema: newSchema(reader)}
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathVulnerabilityReports, h.listVulnerabilityReports)
+	mux.HandleFunc(PathGraphQL, h.graphQL)
+	return mux
+}
+
+func (h *handler) listVulnerabilityReports(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	ctx := req.Context()
+	user, ok := h.authenticate(w, req)
+	if !ok {
+		return
+	}
+	query := req.URL.Query()
+	namespace := query.Get("namespace")
+	access := newNamespaceAccess(h.auth, user)
+	allowed, err := access.canList(ctx, resourceVulnerabilityReports, namespace)
+	if err != nil {
+		h.internalError(w, err)
+		return
+	}
+	if namespace != "" && !allowed {
+		writeError(w, http.StatusForbidden, "listing reports in namespace "+namespace+" is forbidden")
+		return
+	}
+
+	filter := newFilter(query.Get("severity"), query.Get("cve"), query.Get("image"))
+	opts := []client.ListOption{client.InNamespace(namespace)}
+	if cve := query.Get("cve"); cve != "" {
+		// Reports are labeled with IDs of their vulnerabilities, which lets the
+		// API server filter them, unless IDs cannot be encoded as labels.
+		if label := vulnerabilityreport.GetVulnerabilityIDLabel(cve); label != "" {
+			opts = append(opts, client.HasLabels{label})
+		}
+	}
+	var list v1alpha1.VulnerabilityReportList
+	err = h.reader.List(ctx, &list, opts...)
+	if err != nil {
+		h.internalError(w, err)
+		return
+	}
+
+	includeVulnerabilities := query.Get("vulnerabilities") == "true"
+	response := VulnerabilityReportList{Items: []VulnerabilityReport{}}
+	for _, report := range list.Items {
+		vulnerabilities, ok := filter.match(report.Report)
+		if !ok {
+			continue
+		}
+		allowed, err := access.canList(ctx, resourceVulnerabilityReports, report.Namespace)
+		if err != nil {
+			h.internalError(w, err)
+			return
+		}
+		if !allowed {
+			continue
+		}
+		item := VulnerabilityReport{
+			Namespace: report.Namespace,
+			Name:      report.Name,
+			Workload: Workload{
+				Kind: report.Labels[starboard.LabelResourceKind],
+				Name: report.Labels[starboard.LabelResourceName],
+			},
+			Container:       report.Labels[starboard.LabelContainerName],
+			Image:           vulnerabilityreport.GetImageRef(report.Report),
+			Scanner:         report.Report.Scanner.Name,
+			UpdateTimestamp: report.Report.UpdateTimestamp,
+			Summary:         report.Report.Summary,
+		}
+		if includeVulnerabilities {
+			item.Vulnerabilities = vulnerabilities
+		}
+		response.Items = append(response.Items, item)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *handler) authenticate(w http.ResponseWriter, req *http.Request) (authenticationv1.UserInfo, bool) {
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == req.Header.Get("Authorization") {
+		writeError(w, http.StatusUnauthorized, "bearer token is required")
+		return authenticationv1.UserInfo{}, false
+	}
+	user, authenticated, err := h.auth.Authenticate(req.Context(), token)
+	if err != nil {
+		h.internalError(w, err)
+		return authenticationv1.UserInfo{}, false
+	}
+	if !authenticated {
+		writeError(w, http.StatusUnauthorized, "invalid bearer token")
+		return authenticationv1.UserInfo{}, false
+	}
+	return user, true
+}
+
+func (h *handler) internalError(w http.ResponseWriter, err error) {
+	h.logger.Error(err, "Unable to serve reports")
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
+
+const (
+	resourceVulnerabilityReports = "vulnerabilityreports"
+	resourceConfigAuditReports   = "configauditreports"
+)
+
+// namespaceAccess remembers whether a user can list reports of resources in
+// namespaces for the duration of a request, so that access is reviewed once
+// per resource and namespace.
+type namespaceAccess struct {
+	auth    Auth
+	user    authenticationv1.UserInfo
+	allowed map[string]map[string]bool
+}
+
+func newNamespaceAccess(auth Auth, user authenticationv1.UserInfo) *namespaceAccess {
+	return &namespaceAccess{auth: auth, user: user, allowed: make(map[string]map[string]bool)}
+}
+
+func (a *namespaceAccess) canList(ctx context.Context, resource, namespace string) (bool, error) {
+	allowed, ok := a.allowed[resource]
+	if !ok {
+		allowed = make(map[string]bool)
+		a.allowed[resource] = allowed
+	}
+	// Users who can list reports in all namespaces can list them in any
+	// namespace.
+	if allowed[""] {
+		return true, nil
+	}
+	if value, ok := allowed[namespace]; ok {
+		return value, nil
+	}
+	value, err := a.auth.CanListReports(ctx, a.user, resource, namespace)
+	if err != nil {
+		return false, err
+	}
+	allowed[namespace] = value
+	return value, nil
+}
+
+type filter struct {
+	severities map[v1alpha1.Severity]bool
+	cve        string
+	image      string
+}
+
+func newFilter(severities, cve, image string) filter {
+	f := filter{cve: cve, image: image}
+	if severities != "" {
+		f.severities = newSeveritySet(strings.Split(severities, ","))
+	}
+	return f
+}
+
+func newSeveritySet(severities []string) map[v1alpha1.Severity]bool {
+	set := make(map[v1alpha1.Severity]bool)
+	for _, severity := range severities {
+		set[v1alpha1.Severity(strings.ToUpper(strings.TrimSpace(severity)))] = true
+	}
+	return set
+}
+
+// match returns vulnerabilities of the report data that match the severity
+// and cve of the filter, and false if the data doesn't match the filter.
+func (f filter) match(data v1alpha1.VulnerabilityReportData) ([]Vulnerability, bool) {
+	if f.image != "" && !strings.Contains(vulnerabilityreport.GetImageRef(data), f.image) {
+		return nil, false
+	}
+	vulnerabilities := []Vulnerability{}
+	for _, v := range data.Vulnerabilities {
+		if f.severities != nil && !f.severities[v.Severity] {
+			continue
+		}
+		if f.cve != "" && v.VulnerabilityID != f.cve {
+			continue
+		}
+		vulnerabilities = append(vulnerabilities, Vulnerability{
+			ID:               v.VulnerabilityID,
+			Severity:         v.Severity,
+			Resource:         v.Resource,
+			InstalledVersion: v.InstalledVersion,
+			FixedVersion:     v.FixedVersion,
+			Title:            v.Title,
+			Score:            v.Score,
+		})
+	}
+	if (f.severities != nil || f.cve != "") && len(vulnerabilities) == 0 {
+		return nil, false
+	}
+	return vulnerabilities, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, ErrorResponse{Message: message})
+}
@@ -0,0 +1,135 @@
+package reportapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/reportapi"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// fakeAuth authenticates the "dev-token" as the user who can list reports
+// in the default namespace.
+type fakeAuth struct{}
+
+func (a *fakeAuth) Authenticate(_ context.Context, token string) (authenticationv1.UserInfo, bool, error) {
+	return authenticationv1.UserInfo{Username: "dev"}, token == "dev-token", nil
+}
+
+func (a *fakeAuth) CanListReports(_ context.Context, user authenticationv1.UserInfo, _, namespace string) (bool, error) {
+	return user.Username == "dev" && namespace == "default", nil
+}
+
+func TestHandler(t *testing.T) {
+	newReport := func(namespace, name string, vulnerabilities ...v1alpha1.Vulnerability) *v1alpha1.VulnerabilityReport {
+		report := &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels: map[string]string{
+					starboard.LabelResourceKind:  "ReplicaSet",
+					starboard.LabelResourceName:  "nginx-6d4cf56db6",
+					starboard.LabelContainerName: "nginx",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Scanner:         v1alpha1.Scanner{Name: "Trivy"},
+				Artifact:        v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+				Registry:        v1alpha1.Registry{Server: "index.docker.io"},
+				Vulnerabilities: vulnerabilities,
+			},
+		}
+		for _, v := range vulnerabilities {
+			report.Labels["starboard.vulnerability."+v.VulnerabilityID] = "true"
+		}
+		return report
+	}
+	critical := v1alpha1.Vulnerability{VulnerabilityID: "CVE-2021-44228", Severity: v1alpha1.SeverityCritical, Resource: "log4j"}
+	low := v1alpha1.Vulnerability{VulnerabilityID: "CVE-2020-1234", Severity: v1alpha1.SeverityLow, Resource: "libc"}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		newReport("default", "replicaset-nginx-6d4cf56db6-nginx", critical, low),
+		newReport("default", "replicaset-app-5fbc9cc6b9-app", low),
+		newReport("kube-system", "replicaset-coredns-7848d4b86f-coredns", critical),
+	).Build()
+	server := httptest.NewServer(reportapi.NewHandler(log.Log, c, &fakeAuth{}))
+	defer server.Close()
+
+	get := func(t *testing.T, token, query string) (int, reportapi.VulnerabilityReportList) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, server.URL+reportapi.PathVulnerabilityReports+query, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var list reportapi.VulnerabilityReportList
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+		}
+		return resp.StatusCode, list
+	}
+	names := func(list reportapi.VulnerabilityReportList) []string {
+		var names []string
+		for _, item := range list.Items {
+			names = append(names, item.Namespace+"/"+item.Name)
+		}
+		return names
+	}
+
+	t.Run("Should reject requests without valid token", func(t *testing.T) {
+		status, _ := get(t, "", "")
+		assert.Equal(t, http.StatusUnauthorized, status)
+		status, _ = get(t, "invalid", "")
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("Should reject requests for forbidden namespace", func(t *testing.T) {
+		status, _ := get(t, "dev-token", "?namespace=kube-system")
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("Should only list reports in allowed namespaces", func(t *testing.T) {
+		status, list := get(t, "dev-token", "")
+		require.Equal(t, http.StatusOK, status)
+		assert.ElementsMatch(t, []string{
+			"default/replicaset-nginx-6d4cf56db6-nginx",
+			"default/replicaset-app-5fbc9cc6b9-app",
+		}, names(list))
+		for _, item := range list.Items {
+			assert.Empty(t, item.Vulnerabilities, "Should not include vulnerabilities unless requested")
+		}
+	})
+
+	t.Run("Should filter reports by severity and include vulnerabilities", func(t *testing.T) {
+		status, list := get(t, "dev-token", "?namespace=default&severity=critical,high&vulnerabilities=true")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, []string{"default/replicaset-nginx-6d4cf56db6-nginx"}, names(list))
+		assert.Equal(t, reportapi.Workload{Kind: "ReplicaSet", Name: "nginx-6d4cf56db6"}, list.Items[0].Workload)
+		assert.Equal(t, "index.docker.io/library/nginx:1.16", list.Items[0].Image)
+		assert.Equal(t, []reportapi.Vulnerability{
+			{ID: "CVE-2021-44228", Severity: v1alpha1.SeverityCritical, Resource: "log4j"},
+		}, list.Items[0].Vulnerabilities)
+	})
+
+	t.Run("Should filter reports by CVE and image", func(t *testing.T) {
+		_, list := get(t, "dev-token", "?cve=CVE-2020-1234")
+		assert.ElementsMatch(t, []string{
+			"default/replicaset-nginx-6d4cf56db6-nginx",
+			"default/replicaset-app-5fbc9cc6b9-app",
+		}, names(list))
+		_, list = get(t, "dev-token", "?image=redis")
+		assert.Empty(t, list.Items)
+	})
+}
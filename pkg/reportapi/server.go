@@ -0,0 +1,57 @@
+package reportapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Server serves the API until the context passed to Start is cancelled. It
+// implements manager.Runnable, so that it's started by the manager of
+// controllers.
+type Server struct {
+	// Addr is the TCP address that the server listens on.
+	Addr string
+	// CertFile and KeyFile are paths of the TLS certificate and key. If they
+	// aren't set, the server serves plain HTTP.
+	CertFile string
+	KeyFile  string
+	Handler  http.Handler
+}
+
+// Start serves the API until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errs := make(chan error, 1)
+	go func() {
+		var err error
+		if s.CertFile != "" {
+			err = server.ListenAndServeTLS(s.CertFile, s.KeyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		errs <- err
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so that the
+// API is served by every replica of the operator.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,5 @@
+// Package riskscore provides primitives for scoring the risk of a workload by
+// combining vulnerabilities of its images, failed checks of its configuration
+// audit, and signals of its exposure, such as privileged containers or
+// Services that are reachable from outside the cluster.
+package riskscore
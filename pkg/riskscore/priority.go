@@ -0,0 +1,75 @@
+package riskscore
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Priority is the priority of fixing findings of a workload, from P1, the
+// most urgent, to P4, which is derived from its risk score.
+type Priority string
+
+const (
+	PriorityP1 Priority = "P1"
+	PriorityP2 Priority = "P2"
+	PriorityP3 Priority = "P3"
+	PriorityP4 Priority = "P4"
+)
+
+// Minimum scores of priorities.
+const (
+	minScoreP1 = 70
+	minScoreP2 = 40
+	minScoreP3 = 20
+)
+
+// GetPriority returns the priority of a workload with the specified score.
+// Scores of at least 70 are P1, at least 40 are P2, at least 20 are P3, and
+// lower scores are P4.
+func GetPriority(score int) Priority {
+	switch {
+	case score >= minScoreP1:
+		return PriorityP1
+	case score >= minScoreP2:
+		return PriorityP2
+	case score >= minScoreP3:
+		return PriorityP3
+	default:
+		return PriorityP4
+	}
+}
+
+// PriorityTTLs are TTLs of VulnerabilityReports of workloads of each
+// priority, which make reports of the riskiest workloads expire, and their
+// workloads rescanned, sooner. Priorities that are not set have no TTL.
+type PriorityTTLs map[Priority]time.Duration
+
+// ParsePriorityTTLs parses the comma separated list of priority=duration
+// pairs, e.g. P1=24h,P2=72h.
+func ParsePriorityTTLs(value string) (PriorityTTLs, error) {
+	ttls := PriorityTTLs{}
+	if strings.TrimSpace(value) == "" {
+		return ttls, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 || !isPriority(Priority(parts[0])) {
+			return nil, fmt.Errorf("invalid priority TTL %q; expected priority=duration", pair)
+		}
+		ttl, err := time.ParseDuration(parts[1])
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid priority TTL %q; expected positive duration", pair)
+		}
+		ttls[Priority(parts[0])] = ttl
+	}
+	return ttls, nil
+}
+
+func isPriority(priority Priority) bool {
+	switch priority {
+	case PriorityP1, PriorityP2, PriorityP3, PriorityP4:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package riskscore
+
+import (
+	"math"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/exposurereport"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// MaxScore is the score of the riskiest workloads.
+const MaxScore = 100
+
+// Points of each kind of signal, which add up to MaxScore.
+const (
+	maxVulnerabilityPoints = 50
+	criticalPoints         = 10
+	highPoints             = 4
+	mediumPoints           = 1
+
+	criticalCVSS       = 9.0
+	criticalCVSSPoints = 10
+	highCVSS           = 7.0
+	highCVSSPoints     = 5
+
+	maxConfigAuditPoints = 20
+	dangerPoints         = 4
+	warningPoints        = 1
+
+	privilegedPoints     = 7
+	hostNetworkPoints    = 5
+	internetFacingPoints = 8
+)
+
+// Exposure are signals of how exposed a workload is to attackers.
+type Exposure struct {
+	// Privileged is true if any container of the workload is privileged.
+	Privileged bool
+	// HostNetwork is true if the workload uses the network of the node.
+	HostNetwork bool
+	// InternetFacing is true if the workload is selected by a LoadBalancer or
+	// NodePort Service, or by a Service that is a backend of an Ingress.
+	InternetFacing bool
+}
+
+// Signals are the inputs of the score of a workload.
+type Signals struct {
+	// Vulnerabilities is the sum of summaries of VulnerabilityReports of all
+	// containers of the workload.
+	Vulnerabilities v1alpha1.VulnerabilitySummary
+	// MaxCVSS is the highest CVSS score of vulnerabilities of the workload,
+	// which approximates how easily they are exploited, or 0 if scores are
+	// unknown.
+	MaxCVSS float64
+	// ConfigAudit is the summary of the ConfigAuditReport of the workload.
+	ConfigAudit v1alpha1.ConfigAuditSummary
+	Exposure    Exposure
+}
+
+// Score returns the risk score of the workload with the specified signals,
+// which ranges from 0 to MaxScore. Vulnerabilities contribute up to 50
+// points, their CVSS scores up to 10, failed checks of the configuration
+// audit up to 20, and exposure signals up to 20.
+func Score(signals Signals) int {
+	vulnerabilities := signals.Vulnerabilities.CriticalCount*criticalPoints +
+		signals.Vulnerabilities.HighCount*highPoints +
+		signals.Vulnerabilities.MediumCount*mediumPoints
+	score := min(vulnerabilities, maxVulnerabilityPoints)
+
+	switch {
+	case signals.MaxCVSS >= criticalCVSS:
+		score += criticalCVSSPoints
+	case signals.MaxCVSS >= highCVSS:
+		score += highCVSSPoints
+	}
+
+	configAudit := signals.ConfigAudit.DangerCount*dangerPoints + signals.ConfigAudit.WarningCount*warningPoints
+	score += min(configAudit, maxConfigAuditPoints)
+
+	if signals.Exposure.Privileged {
+		score += privilegedPoints
+	}
+	if signals.Exposure.HostNetwork {
+		score += hostNetworkPoints
+	}
+	if signals.Exposure.InternetFacing {
+		score += internetFacingPoints
+	}
+	return min(score, MaxScore)
+}
+
+// GetMaxCVSS returns the highest CVSS score of the vulnerabilities, or 0 if
+// none of them has a score.
+func GetMaxCVSS(vulnerabilities []v1alpha1.Vulnerability) float64 {
+	var max float64
+	for _, vulnerability := range vulnerabilities {
+		if vulnerability.Score != nil {
+			max = math.Max(max, *vulnerability.Score)
+		}
+	}
+	return max
+}
+
+// GetExposure returns exposure signals of the workload with the specified pod
+// spec and labels of its pod template, which are matched against selectors
+// of the Services and backends of the Ingresses in the namespace of the
+// workload.
+func GetExposure(spec corev1.PodSpec, podLabels map[string]string, services []corev1.Service, ingresses []networkingv1.Ingress) Exposure {
+	exposure := Exposure{HostNetwork: spec.HostNetwork}
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for _, container := range containers {
+			if container.SecurityContext != nil && container.SecurityContext.Privileged != nil &&
+				*container.SecurityContext.Privileged {
+				exposure.Privileged = true
+			}
+		}
+	}
+
+	routes := exposurereport.GetRoutes(podLabels, services, ingresses, nil)
+	exposure.InternetFacing = len(routes) > 0
+	return exposure
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,192 @@
+package riskscore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/riskscore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestScore(t *testing.T) {
+	testCases := []struct {
+		name          string
+		signals       riskscore.Signals
+		expectedScore int
+	}{
+		{
+			name:          "Should return zero without any signals",
+			expectedScore: 0,
+		},
+		{
+			name: "Should add points of vulnerabilities and their CVSS scores",
+			signals: riskscore.Signals{
+				Vulnerabilities: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 2, MediumCount: 3, LowCount: 10},
+				MaxCVSS:         9.8,
+			},
+			expectedScore: 10 + 8 + 3 + 10,
+		},
+		{
+			name: "Should cap points of vulnerabilities and config audit",
+			signals: riskscore.Signals{
+				Vulnerabilities: v1alpha1.VulnerabilitySummary{CriticalCount: 20},
+				MaxCVSS:         7.5,
+				ConfigAudit:     v1alpha1.ConfigAuditSummary{DangerCount: 4, WarningCount: 10},
+			},
+			expectedScore: 50 + 5 + 20,
+		},
+		{
+			name: "Should add points of exposure",
+			signals: riskscore.Signals{
+				ConfigAudit: v1alpha1.ConfigAuditSummary{DangerCount: 1, WarningCount: 2, PassCount: 30},
+				Exposure:    riskscore.Exposure{Privileged: true, InternetFacing: true},
+			},
+			expectedScore: 6 + 7 + 8,
+		},
+		{
+			name: "Should return max score of riskiest workloads",
+			signals: riskscore.Signals{
+				Vulnerabilities: v1alpha1.VulnerabilitySummary{CriticalCount: 5},
+				MaxCVSS:         10,
+				ConfigAudit:     v1alpha1.ConfigAuditSummary{DangerCount: 5},
+				Exposure:        riskscore.Exposure{Privileged: true, HostNetwork: true, InternetFacing: true},
+			},
+			expectedScore: riskscore.MaxScore,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedScore, riskscore.Score(tc.signals))
+		})
+	}
+}
+
+func TestGetMaxCVSS(t *testing.T) {
+	assert.Equal(t, 0.0, riskscore.GetMaxCVSS(nil))
+	assert.Equal(t, 8.1, riskscore.GetMaxCVSS([]v1alpha1.Vulnerability{
+		{VulnerabilityID: "CVE-2020-1234", Score: pointer.Float64(5.3)},
+		{VulnerabilityID: "CVE-2020-5678"},
+		{VulnerabilityID: "CVE-2021-1234", Score: pointer.Float64(8.1)},
+	}))
+}
+
+func TestGetExposure(t *testing.T) {
+	podLabels := map[string]string{"app": "nginx", "tier": "web"}
+	newService := func(name string, serviceType corev1.ServiceType, selector map[string]string) corev1.Service {
+		return corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       corev1.ServiceSpec{Type: serviceType, Selector: selector},
+		}
+	}
+	ingress := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path: "/",
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{Name: "nginx"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("Should detect privileged containers and host network", func(t *testing.T) {
+		spec := corev1.PodSpec{
+			HostNetwork: true,
+			InitContainers: []corev1.Container{
+				{Name: "init", SecurityContext: &corev1.SecurityContext{Privileged: pointer.Bool(true)}},
+			},
+			Containers: []corev1.Container{{Name: "nginx"}},
+		}
+		assert.Equal(t, riskscore.Exposure{Privileged: true, HostNetwork: true},
+			riskscore.GetExposure(spec, podLabels, nil, nil))
+	})
+
+	t.Run("Should not treat cluster IP Services as internet-facing", func(t *testing.T) {
+		services := []corev1.Service{
+			newService("nginx", corev1.ServiceTypeClusterIP, map[string]string{"app": "nginx"}),
+			newService("redis", corev1.ServiceTypeLoadBalancer, map[string]string{"app": "redis"}),
+			newService("external", corev1.ServiceTypeLoadBalancer, nil),
+		}
+		assert.Equal(t, riskscore.Exposure{}, riskscore.GetExposure(corev1.PodSpec{}, podLabels, services, nil))
+	})
+
+	t.Run("Should detect LoadBalancer and NodePort Services", func(t *testing.T) {
+		for _, serviceType := range []corev1.ServiceType{corev1.ServiceTypeLoadBalancer, corev1.ServiceTypeNodePort} {
+			services := []corev1.Service{newService("nginx", serviceType, map[string]string{"app": "nginx"})}
+			assert.Equal(t, riskscore.Exposure{InternetFacing: true},
+				riskscore.GetExposure(corev1.PodSpec{}, podLabels, services, nil), serviceType)
+		}
+	})
+
+	t.Run("Should detect Services that are backends of Ingresses", func(t *testing.T) {
+		services := []corev1.Service{newService("nginx", corev1.ServiceTypeClusterIP, map[string]string{"app": "nginx"})}
+		assert.Equal(t, riskscore.Exposure{InternetFacing: true},
+			riskscore.GetExposure(corev1.PodSpec{}, podLabels, services, []networkingv1.Ingress{ingress}))
+	})
+}
+
+func TestGetPriority(t *testing.T) {
+	assert.Equal(t, riskscore.PriorityP1, riskscore.GetPriority(riskscore.MaxScore))
+	assert.Equal(t, riskscore.PriorityP1, riskscore.GetPriority(70))
+	assert.Equal(t, riskscore.PriorityP2, riskscore.GetPriority(69))
+	assert.Equal(t, riskscore.PriorityP2, riskscore.GetPriority(40))
+	assert.Equal(t, riskscore.PriorityP3, riskscore.GetPriority(20))
+	assert.Equal(t, riskscore.PriorityP4, riskscore.GetPriority(19))
+	assert.Equal(t, riskscore.PriorityP4, riskscore.GetPriority(0))
+}
+
+func TestParsePriorityTTLs(t *testing.T) {
+	testCases := []struct {
+		value         string
+		expectedTTLs  riskscore.PriorityTTLs
+		expectedError string
+	}{
+		{
+			value:        "",
+			expectedTTLs: riskscore.PriorityTTLs{},
+		},
+		{
+			value: "P1=24h, P2=72h",
+			expectedTTLs: riskscore.PriorityTTLs{
+				riskscore.PriorityP1: 24 * time.Hour,
+				riskscore.PriorityP2: 72 * time.Hour,
+			},
+		},
+		{
+			value:         "P0=24h",
+			expectedError: `invalid priority TTL "P0=24h"; expected priority=duration`,
+		},
+		{
+			value:         "P1=1d",
+			expectedError: `invalid priority TTL "P1=1d"; expected positive duration`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			ttls, err := riskscore.ParsePriorityTTLs(tc.value)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTTLs, ttls)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package sarif provides primitives for converting security reports to the
+// Static Analysis Results Interchange Format (SARIF), which is consumed by
+// GitHub Code Scanning and other tools.
+package sarif
@@ -0,0 +1,313 @@
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+)
+
+const (
+	Version = "2.1.0"
+	Schema  = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+)
+
+// Levels of results.
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+)
+
+// Log is the top-level SARIF object, which holds runs of tools. Only the
+// properties used by Starboard are modeled.
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []Rule `json:"rules"`
+}
+
+type Rule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name,omitempty"`
+	ShortDescription     *Message               `json:"shortDescription,omitempty"`
+	FullDescription      *Message               `json:"fullDescription,omitempty"`
+	HelpURI              string                 `json:"helpUri,omitempty"`
+	Help                 *Message               `json:"help,omitempty"`
+	DefaultConfiguration *Configuration         `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+type Configuration struct {
+	Level string `json:"level"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	RuleIndex int        `json:"ruleIndex"`
+	Level     string     `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations"`
+}
+
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           Region           `json:"region"`
+}
+
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Region is required by some consumers, such as GitHub Code Scanning, even
+// though findings in Kubernetes objects don't have lines.
+type Region struct {
+	StartLine int `json:"startLine"`
+}
+
+// Write writes the specified Log as indented JSON.
+func Write(log Log, out io.Writer) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// runBuilder accumulates rules and results of a single run, so that each
+// rule is defined once and referenced by its index.
+type runBuilder struct {
+	driver  Driver
+	indexes map[string]int
+	results []Result
+}
+
+func newRunBuilder(scanner v1alpha1.Scanner) *runBuilder {
+	return &runBuilder{
+		driver: Driver{
+			Name:    scanner.Name,
+			Version: scanner.Version,
+			Rules:   []Rule{},
+		},
+		indexes: make(map[string]int),
+		results: []Result{},
+	}
+}
+
+func (b *runBuilder) addResult(rule Rule, level, message, uri string) {
+	index, ok := b.indexes[rule.ID]
+	if !ok {
+		index = len(b.driver.Rules)
+		b.indexes[rule.ID] = index
+		b.driver.Rules = append(b.driver.Rules, rule)
+	}
+	b.results = append(b.results, Result{
+		RuleID:    rule.ID,
+		RuleIndex: index,
+		Level:     level,
+		Message:   Message{Text: message},
+		Locations: []Location{{
+			PhysicalLocation: PhysicalLocation{
+				ArtifactLocation: ArtifactLocation{URI: uri},
+				Region:           Region{StartLine: 1},
+			},
+		}},
+	})
+}
+
+func (b *runBuilder) run() Run {
+	return Run{
+		Tool:    Tool{Driver: b.driver},
+		Results: b.results,
+	}
+}
+
+// runs groups results by scanners, so that each scanner is a separate run,
+// sorted by names and versions of scanners.
+type runs map[v1alpha1.Scanner]*runBuilder
+
+func (r runs) get(scanner v1alpha1.Scanner) *runBuilder {
+	b, ok := r[scanner]
+	if !ok {
+		b = newRunBuilder(scanner)
+		r[scanner] = b
+	}
+	return b
+}
+
+func (r runs) log() Log {
+	var scanners []v1alpha1.Scanner
+	for scanner := range r {
+		scanners = append(scanners, scanner)
+	}
+	sort.Slice(scanners, func(i, j int) bool {
+		if scanners[i].Name != scanners[j].Name {
+			return scanners[i].Name < scanners[j].Name
+		}
+		return scanners[i].Version < scanners[j].Version
+	})
+	log := Log{Version: Version, Schema: Schema, Runs: []Run{}}
+	for _, scanner := range scanners {
+		log.Runs = append(log.Runs, r[scanner].run())
+	}
+	return log
+}
+
+// FromVulnerabilityReports converts the specified reports to the Log with a
+// result for each vulnerability of each container image. Results are located
+// in image references, because vulnerabilities are found in images rather
+// than in files.
+func FromVulnerabilityReports(reports []v1alpha1.VulnerabilityReport) Log {
+	r := make(runs)
+	for _, report := range reports {
+		b := r.get(report.Report.Scanner)
+		uri := vulnerabilityreport.GetImageRef(report.Report)
+		for _, vulnerability := range report.Report.Vulnerabilities {
+			level := vulnerabilityLevel(vulnerability.Severity)
+			b.addResult(vulnerabilityRule(vulnerability, level), level,
+				fmt.Sprintf("Package: %s\nInstalled Version: %s\nVulnerability: %s\nSeverity: %s\nFixed Version: %s\nImage: %s",
+					vulnerability.Resource, vulnerability.InstalledVersion, vulnerability.VulnerabilityID,
+					vulnerability.Severity, vulnerability.FixedVersion, uri),
+				uri)
+		}
+	}
+	return r.log()
+}
+
+// FromConfigAuditReport converts the specified report data of the object with
+// the specified URI, e.g. deployment/nginx, to the Log with a result for each
+// failed check.
+func FromConfigAuditReport(data v1alpha1.ConfigAuditReportData, uri string) Log {
+	r := make(runs)
+	b := r.get(data.Scanner)
+	for _, check := range data.Checks {
+		if check.Success {
+			continue
+		}
+		level := checkLevel(check.Severity)
+		message := check.Message
+		if check.Scope != nil {
+			message = fmt.Sprintf("%s (%s: %s)", message, check.Scope.Type, check.Scope.Value)
+		}
+		b.addResult(checkRule(check, level), level, message, uri)
+	}
+	return r.log()
+}
+
+func vulnerabilityRule(vulnerability v1alpha1.Vulnerability, level string) Rule {
+	title := vulnerability.Title
+	if title == "" {
+		title = vulnerability.VulnerabilityID
+	}
+	description := vulnerability.Description
+	if description == "" {
+		description = title
+	}
+	return Rule{
+		ID:               vulnerability.VulnerabilityID,
+		Name:             "Vulnerability",
+		ShortDescription: &Message{Text: title},
+		FullDescription:  &Message{Text: description},
+		HelpURI:          vulnerability.PrimaryLink,
+		Help: &Message{Text: fmt.Sprintf("Vulnerability: %s\nSeverity: %s\nPackage: %s\nFixed Version: %s\nLink: %s",
+			vulnerability.VulnerabilityID, vulnerability.Severity, vulnerability.Resource,
+			vulnerability.FixedVersion, vulnerability.PrimaryLink)},
+		DefaultConfiguration: &Configuration{Level: level},
+		Properties: map[string]interface{}{
+			"tags":              []string{"vulnerability", "security", string(vulnerability.Severity)},
+			"precision":         "very-high",
+			"security-severity": securitySeverity(vulnerability),
+		},
+	}
+}
+
+func checkRule(check v1alpha1.Check, level string) Rule {
+	tags := []string{"misconfiguration", "security"}
+	if check.Category != "" {
+		tags = append(tags, check.Category)
+	}
+	rule := Rule{
+		ID:                   check.ID,
+		Name:                 "Misconfiguration",
+		ShortDescription:     &Message{Text: check.ID},
+		FullDescription:      &Message{Text: check.Message},
+		DefaultConfiguration: &Configuration{Level: level},
+		Properties: map[string]interface{}{
+			"tags":      tags,
+			"precision": "very-high",
+		},
+	}
+	if check.Remediation != "" {
+		rule.Help = &Message{Text: check.Remediation}
+	}
+	return rule
+}
+
+func vulnerabilityLevel(severity v1alpha1.Severity) string {
+	switch severity {
+	case v1alpha1.SeverityCritical, v1alpha1.SeverityHigh:
+		return LevelError
+	case v1alpha1.SeverityMedium:
+		return LevelWarning
+	default:
+		return LevelNote
+	}
+}
+
+func checkLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case v1alpha1.ConfigAuditSeverityDanger:
+		return LevelError
+	case v1alpha1.ConfigAuditSeverityWarning:
+		return LevelWarning
+	default:
+		return LevelNote
+	}
+}
+
+// securitySeverity returns the score of the specified vulnerability, which
+// GitHub Code Scanning uses to rank alerts, or the lowest score of its
+// severity if the score is unknown.
+func securitySeverity(vulnerability v1alpha1.Vulnerability) string {
+	if vulnerability.Score != nil {
+		return fmt.Sprintf("%.1f", *vulnerability.Score)
+	}
+	switch vulnerability.Severity {
+	case v1alpha1.SeverityCritical:
+		return "9.0"
+	case v1alpha1.SeverityHigh:
+		return "7.0"
+	case v1alpha1.SeverityMedium:
+		return "4.0"
+	case v1alpha1.SeverityLow:
+		return "0.1"
+	default:
+		return "0.0"
+	}
+}
@@ -0,0 +1,106 @@
+package sarif_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/sarif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+)
+
+func TestFromVulnerabilityReports(t *testing.T) {
+	scanner := v1alpha1.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.24.0"}
+	vulnerability := v1alpha1.Vulnerability{
+		VulnerabilityID:  "CVE-2021-44228",
+		Resource:         "org.apache.logging.log4j:log4j-core",
+		InstalledVersion: "2.14.1",
+		FixedVersion:     "2.15.0",
+		Severity:         v1alpha1.SeverityCritical,
+		Title:            "Remote code injection in Log4j",
+		PrimaryLink:      "https://avd.aquasec.com/nvd/cve-2021-44228",
+		Score:            pointer.Float64(10),
+	}
+	reports := []v1alpha1.VulnerabilityReport{
+		{
+			Report: v1alpha1.VulnerabilityReportData{
+				Scanner:  scanner,
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact: v1alpha1.Artifact{Repository: "library/app", Tag: "1.0"},
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					vulnerability,
+					{VulnerabilityID: "CVE-2022-0778", Resource: "openssl", Severity: v1alpha1.SeverityMedium},
+				},
+			},
+		},
+		{
+			Report: v1alpha1.VulnerabilityReportData{
+				Scanner:         scanner,
+				Artifact:        v1alpha1.Artifact{Repository: "sidecar", Tag: "2.0"},
+				Vulnerabilities: []v1alpha1.Vulnerability{vulnerability},
+			},
+		},
+	}
+
+	log := sarif.FromVulnerabilityReports(reports)
+	assert.Equal(t, sarif.Version, log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "Trivy", run.Tool.Driver.Name)
+	assert.Equal(t, "0.24.0", run.Tool.Driver.Version)
+
+	require.Len(t, run.Tool.Driver.Rules, 2, "rules must be defined once per vulnerability")
+	assert.Equal(t, "CVE-2021-44228", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "10.0", run.Tool.Driver.Rules[0].Properties["security-severity"])
+	assert.Equal(t, "4.0", run.Tool.Driver.Rules[1].Properties["security-severity"])
+
+	require.Len(t, run.Results, 3)
+	assert.Equal(t, sarif.LevelError, run.Results[0].Level)
+	assert.Equal(t, "index.docker.io/library/app:1.0", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 1, run.Results[1].RuleIndex)
+	assert.Equal(t, sarif.LevelWarning, run.Results[1].Level)
+	assert.Equal(t, 0, run.Results[2].RuleIndex)
+	assert.Equal(t, "sidecar:2.0", run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+}
+
+func TestFromConfigAuditReport(t *testing.T) {
+	log := sarif.FromConfigAuditReport(v1alpha1.ConfigAuditReportData{
+		Scanner: v1alpha1.Scanner{Name: "Polaris", Version: "4.2"},
+		Checks: []v1alpha1.Check{
+			{ID: "hostIPCSet", Success: true, Severity: v1alpha1.ConfigAuditSeverityDanger},
+			{
+				ID:       "runAsRootAllowed",
+				Message:  "Should not be allowed to run as root",
+				Severity: v1alpha1.ConfigAuditSeverityDanger,
+				Category: "Security",
+				Scope:    &v1alpha1.CheckScope{Type: "Container", Value: "nginx"},
+			},
+			{ID: "cpuLimitsMissing", Message: "CPU limits should be set", Severity: v1alpha1.ConfigAuditSeverityWarning},
+		},
+	}, "default/deployment/nginx")
+
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "Polaris", run.Tool.Driver.Name)
+	require.Len(t, run.Results, 2, "passed checks must be omitted")
+	assert.Equal(t, "runAsRootAllowed", run.Results[0].RuleID)
+	assert.Equal(t, sarif.LevelError, run.Results[0].Level)
+	assert.Equal(t, "Should not be allowed to run as root (Container: nginx)", run.Results[0].Message.Text)
+	assert.Equal(t, "default/deployment/nginx", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, sarif.LevelWarning, run.Results[1].Level)
+}
+
+func TestWrite(t *testing.T) {
+	var out bytes.Buffer
+	err := sarif.Write(sarif.FromVulnerabilityReports(nil), &out)
+	require.NoError(t, err)
+
+	var log map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log["version"])
+	assert.Equal(t, sarif.Schema, log["$schema"])
+	assert.Equal(t, []interface{}{}, log["runs"])
+}
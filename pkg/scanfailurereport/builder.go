@@ -0,0 +1,102 @@
+package scanfailurereport
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// GetReportName returns the name of the ScanFailureReport that records failed
+// scan jobs of the given controller which were supposed to produce reports of
+// the given kind, e.g. v1alpha1.VulnerabilityReportKind.
+func GetReportName(controller client.Object, reportKind string) string {
+	kind := controller.GetObjectKind().GroupVersionKind().Kind
+	name := controller.GetName()
+	reportName := fmt.Sprintf("%s-%s-%s", strings.ToLower(reportKind), strings.ToLower(kind), name)
+	if len(validation.IsValidLabelValue(reportName)) == 0 {
+		return reportName
+	}
+
+	return fmt.Sprintf("%s-%s-%s", strings.ToLower(reportKind), strings.ToLower(kind), kube.ComputeHash(name))
+}
+
+type ReportBuilder struct {
+	scheme     *runtime.Scheme
+	controller client.Object
+	namespace  string
+	data       v1alpha1.ScanFailureReportData
+	labels     map[string]string
+}
+
+func NewReportBuilder(scheme *runtime.Scheme) *ReportBuilder {
+	return &ReportBuilder{
+		scheme: scheme,
+	}
+}
+
+func (b *ReportBuilder) Controller(controller client.Object) *ReportBuilder {
+	b.controller = controller
+	return b
+}
+
+// Namespace sets the namespace of the report if the controller is a
+// cluster-scoped object. Reports of namespaced objects are always created in
+// the namespace of the controller.
+func (b *ReportBuilder) Namespace(namespace string) *ReportBuilder {
+	b.namespace = namespace
+	return b
+}
+
+func (b *ReportBuilder) Data(data v1alpha1.ScanFailureReportData) *ReportBuilder {
+	b.data = data
+	return b
+}
+
+// Labels sets additional labels of the report, such as the labels that
+// identify the cluster. Labels set by the builder take precedence.
+func (b *ReportBuilder) Labels(labels map[string]string) *ReportBuilder {
+	b.labels = labels
+	return b
+}
+
+func (b *ReportBuilder) Get() (v1alpha1.ScanFailureReport, error) {
+	namespace := b.controller.GetNamespace()
+	if namespace == "" {
+		namespace = b.namespace
+	}
+
+	labels := make(map[string]string)
+	for key, value := range b.labels {
+		labels[key] = value
+	}
+
+	report := v1alpha1.ScanFailureReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetReportName(b.controller, b.data.ReportKind),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Report: b.data,
+	}
+	err := kube.ObjectToObjectMetadata(b.controller, &report.ObjectMeta)
+	if err != nil {
+		return v1alpha1.ScanFailureReport{}, err
+	}
+	err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
+	if err != nil {
+		return v1alpha1.ScanFailureReport{}, fmt.Errorf("setting controller reference: %w", err)
+	}
+	// We set metadata.ownerReferences[x].blockOwnerDeletion to false so that
+	// additional RBAC permissions are not required when the
+	// OwnerReferencesPermissionsEnforcement admission controller is enabled.
+	report.OwnerReferences[0].BlockOwnerDeletion = pointer.BoolPtr(false)
+	return report, nil
+}
@@ -0,0 +1,88 @@
+package scanfailurereport_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/scanfailurereport"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+)
+
+func TestReportBuilder(t *testing.T) {
+
+	t.Run("Should build report of namespaced object", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		report, err := scanfailurereport.NewReportBuilder(scheme.Scheme).
+			Controller(&appsv1.ReplicaSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ReplicaSet",
+					APIVersion: "apps/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "some-owner",
+					Namespace: "qa",
+				},
+			}).
+			Namespace("starboard-operator").
+			Data(v1alpha1.ScanFailureReportData{
+				ReportKind: v1alpha1.VulnerabilityReportKind,
+				Attempt:    2,
+			}).
+			Get()
+
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(report).To(gomega.Equal(v1alpha1.ScanFailureReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vulnerabilityreport-replicaset-some-owner",
+				Namespace: "qa",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion:         "apps/v1",
+						Kind:               "ReplicaSet",
+						Name:               "some-owner",
+						Controller:         pointer.BoolPtr(true),
+						BlockOwnerDeletion: pointer.BoolPtr(false),
+					},
+				},
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "some-owner",
+					starboard.LabelResourceNamespace: "qa",
+				},
+			},
+			Report: v1alpha1.ScanFailureReportData{
+				ReportKind: v1alpha1.VulnerabilityReportKind,
+				Attempt:    2,
+			},
+		}))
+	})
+
+	t.Run("Should build report of cluster-scoped object in given namespace", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		report, err := scanfailurereport.NewReportBuilder(scheme.Scheme).
+			Controller(&rbacv1.ClusterRole{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ClusterRole",
+					APIVersion: "rbac.authorization.k8s.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "view",
+				},
+			}).
+			Namespace("starboard-operator").
+			Data(v1alpha1.ScanFailureReportData{
+				ReportKind: v1alpha1.ConfigAuditReportKind,
+			}).
+			Get()
+
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(report.Name).To(gomega.Equal("configauditreport-clusterrole-view"))
+		g.Expect(report.Namespace).To(gomega.Equal("starboard-operator"))
+	})
+}
@@ -0,0 +1,3 @@
+// Package scanfailurereport provides primitives for recording failed scan
+// jobs as ScanFailureReport resources.
+package scanfailurereport
@@ -0,0 +1,101 @@
+package scanfailurereport
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Writer is the interface that wraps methods for persisting
+// v1alpha1.ScanFailureReport objects.
+//
+// Write creates or updates the given v1alpha1.ScanFailureReport.
+//
+// Delete deletes the v1alpha1.ScanFailureReport with the given name and
+// namespace. It does not return an error if the report does not exist or if
+// the ScanFailureReport CRD is not installed.
+type Writer interface {
+	Write(context.Context, v1alpha1.ScanFailureReport) error
+	Delete(context.Context, types.NamespacedName) error
+}
+
+// Reader is the interface that wraps methods for finding
+// v1alpha1.ScanFailureReport objects.
+//
+// FindByOwner returns the slice of v1alpha1.ScanFailureReport instances
+// owned by the given kube.ObjectRef or an empty slice if the reports are not found.
+type Reader interface {
+	FindByOwner(context.Context, kube.ObjectRef) ([]v1alpha1.ScanFailureReport, error)
+}
+
+type ReadWriter interface {
+	Reader
+	Writer
+}
+
+type readWriter struct {
+	client.Client
+}
+
+// NewReadWriter constructs a new ReadWriter which is using the client package
+// provided by the controller-runtime libraries for interacting with the
+// Kubernetes API server.
+func NewReadWriter(client client.Client) ReadWriter {
+	return &readWriter{
+		Client: client,
+	}
+}
+
+func (r *readWriter) Write(ctx context.Context, report v1alpha1.ScanFailureReport) error {
+	var existing v1alpha1.ScanFailureReport
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      report.Name,
+		Namespace: report.Namespace,
+	}, &existing)
+
+	if err == nil {
+		copied := existing.DeepCopy()
+		copied.Labels = report.Labels
+		copied.Report = report.Report
+
+		return r.Update(ctx, copied)
+	}
+
+	if errors.IsNotFound(err) {
+		return r.Create(ctx, &report)
+	}
+
+	return err
+}
+
+func (r *readWriter) Delete(ctx context.Context, name types.NamespacedName) error {
+	err := r.Client.Delete(ctx, &v1alpha1.ScanFailureReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+	})
+	if err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *readWriter) FindByOwner(ctx context.Context, owner kube.ObjectRef) ([]v1alpha1.ScanFailureReport, error) {
+	var list v1alpha1.ScanFailureReportList
+
+	labels := client.MatchingLabels(kube.ObjectRefToLabels(owner))
+
+	err := r.List(ctx, &list, labels, client.InNamespace(owner.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	return list.DeepCopy().Items, nil
+}
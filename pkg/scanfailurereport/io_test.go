@@ -0,0 +1,88 @@
+package scanfailurereport_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/scanfailurereport"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewReadWriter(t *testing.T) {
+
+	kubernetesScheme := starboard.NewScheme()
+
+	newReport := func(attempt int) *v1alpha1.ScanFailureReport {
+		return &v1alpha1.ScanFailureReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vulnerabilityreport-replicaset-app1",
+				Namespace: "qa",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "app1",
+					starboard.LabelResourceNamespace: "qa",
+				},
+			},
+			Report: v1alpha1.ScanFailureReportData{
+				ReportKind: v1alpha1.VulnerabilityReportKind,
+				Attempt:    attempt,
+			},
+		}
+	}
+
+	t.Run("Should create ScanFailureReport", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		readWriter := scanfailurereport.NewReadWriter(client)
+		err := readWriter.Write(context.TODO(), *newReport(1))
+		require.NoError(t, err)
+
+		var found v1alpha1.ScanFailureReport
+		err = client.Get(context.TODO(), types.NamespacedName{Namespace: "qa", Name: "vulnerabilityreport-replicaset-app1"}, &found)
+		require.NoError(t, err)
+		assert.Equal(t, 1, found.Report.Attempt)
+	})
+
+	t.Run("Should update ScanFailureReport", func(t *testing.T) {
+		existing := newReport(1)
+		existing.ResourceVersion = "0"
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(existing).Build()
+		readWriter := scanfailurereport.NewReadWriter(client)
+		err := readWriter.Write(context.TODO(), *newReport(2))
+		require.NoError(t, err)
+
+		var found v1alpha1.ScanFailureReport
+		err = client.Get(context.TODO(), types.NamespacedName{Namespace: "qa", Name: "vulnerabilityreport-replicaset-app1"}, &found)
+		require.NoError(t, err)
+		assert.Equal(t, 2, found.Report.Attempt)
+	})
+
+	t.Run("Should delete ScanFailureReport", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(newReport(1)).Build()
+		readWriter := scanfailurereport.NewReadWriter(client)
+		err := readWriter.Delete(context.TODO(), types.NamespacedName{Namespace: "qa", Name: "vulnerabilityreport-replicaset-app1"})
+		require.NoError(t, err)
+
+		reports, err := readWriter.FindByOwner(context.TODO(), kube.ObjectRef{Kind: kube.KindReplicaSet, Name: "app1", Namespace: "qa"})
+		require.NoError(t, err)
+		assert.Empty(t, reports)
+
+		err = readWriter.Delete(context.TODO(), types.NamespacedName{Namespace: "qa", Name: "vulnerabilityreport-replicaset-app1"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Should find ScanFailureReports by owner", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(newReport(1)).Build()
+		readWriter := scanfailurereport.NewReadWriter(client)
+		reports, err := readWriter.FindByOwner(context.TODO(), kube.ObjectRef{Kind: kube.KindReplicaSet, Name: "app1", Namespace: "qa"})
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, "vulnerabilityreport-replicaset-app1", reports[0].Name)
+	})
+}
@@ -0,0 +1,178 @@
+package secrethygiene
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// CheckIDEnv is the ID of checks of literal values of environment
+	// variables of containers.
+	CheckIDEnv = "credentialInEnv"
+	// CheckIDConfigMap is the ID of checks of keys of ConfigMaps.
+	CheckIDConfigMap = "credentialInConfigMap"
+	// CheckCategory is the category of secret hygiene checks.
+	CheckCategory = "Secret Hygiene"
+
+	scopeTypeContainer    = "Container"
+	scopeTypeConfigMapKey = "ConfigMapKey"
+
+	remediationEnv       = "Store the value in a Secret and reference it with valueFrom.secretKeyRef or envFrom.secretRef."
+	remediationConfigMap = "Store the value in a Secret and mount or reference the Secret instead of the ConfigMap."
+)
+
+// AuditPodSpec returns checks of literal values of environment variables of
+// containers of the specified pod spec, and of keys of the specified
+// ConfigMaps, keyed by name, which are referenced by the pod spec. There's a
+// failed check of danger severity for each credential-looking value, or a
+// passed check of each kind if there are none.
+func AuditPodSpec(spec corev1.PodSpec, configMaps map[string]corev1.ConfigMap) []v1alpha1.Check {
+	var checks []v1alpha1.Check
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for _, container := range containers {
+			for _, env := range container.Env {
+				if env.ValueFrom != nil {
+					continue
+				}
+				description, ok := Detect(env.Name, env.Value)
+				if !ok {
+					continue
+				}
+				checks = append(checks, v1alpha1.Check{
+					ID: CheckIDEnv,
+					Message: fmt.Sprintf("Environment variable %s of container %s has a literal value that looks like %s",
+						env.Name, container.Name, description),
+					Remediation: remediationEnv,
+					Severity:    v1alpha1.ConfigAuditSeverityDanger,
+					Category:    CheckCategory,
+					Scope:       &v1alpha1.CheckScope{Type: scopeTypeContainer, Value: container.Name},
+				})
+			}
+		}
+	}
+	if len(checks) == 0 {
+		checks = append(checks, passedCheck(CheckIDEnv,
+			"Environment variables of containers don't have literal values that look like credentials"))
+	}
+
+	var configMapChecks []v1alpha1.Check
+	for _, name := range GetConfigMapNames(spec) {
+		configMap, ok := configMaps[name]
+		if !ok {
+			continue
+		}
+		configMapChecks = append(configMapChecks, auditConfigMapKeys(configMap)...)
+	}
+	if len(configMapChecks) == 0 {
+		configMapChecks = append(configMapChecks, passedCheck(CheckIDConfigMap,
+			"ConfigMaps referenced by containers don't have values that look like credentials"))
+	}
+	return append(checks, configMapChecks...)
+}
+
+// AuditConfigMap returns checks of keys of the specified ConfigMap. There's a
+// failed check of danger severity for each credential-looking value, or a
+// passed check if there are none.
+func AuditConfigMap(configMap corev1.ConfigMap) []v1alpha1.Check {
+	checks := auditConfigMapKeys(configMap)
+	if len(checks) == 0 {
+		checks = append(checks, passedCheck(CheckIDConfigMap,
+			"Values of the ConfigMap don't look like credentials"))
+	}
+	return checks
+}
+
+func auditConfigMapKeys(configMap corev1.ConfigMap) []v1alpha1.Check {
+	keys := make([]string, 0, len(configMap.Data))
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var checks []v1alpha1.Check
+	for _, key := range keys {
+		description, ok := Detect(key, configMap.Data[key])
+		if !ok {
+			continue
+		}
+		checks = append(checks, v1alpha1.Check{
+			ID: CheckIDConfigMap,
+			Message: fmt.Sprintf("Key %s of ConfigMap %s has a value that looks like %s",
+				key, configMap.Name, description),
+			Remediation: remediationConfigMap,
+			Severity:    v1alpha1.ConfigAuditSeverityDanger,
+			Category:    CheckCategory,
+			Scope:       &v1alpha1.CheckScope{Type: scopeTypeConfigMapKey, Value: key},
+		})
+	}
+	return checks
+}
+
+func passedCheck(id, message string) v1alpha1.Check {
+	return v1alpha1.Check{
+		ID:       id,
+		Message:  message,
+		Success:  true,
+		Severity: v1alpha1.ConfigAuditSeverityDanger,
+		Category: CheckCategory,
+	}
+}
+
+// GetConfigMapNames returns sorted names of ConfigMaps referenced by the
+// specified pod spec, i.e. by environment variables, envFrom sources, and
+// volumes of containers.
+func GetConfigMapNames(spec corev1.PodSpec) []string {
+	names := make(map[string]bool)
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for _, container := range containers {
+			for _, env := range container.Env {
+				if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
+					names[env.ValueFrom.ConfigMapKeyRef.Name] = true
+				}
+			}
+			for _, envFrom := range container.EnvFrom {
+				if envFrom.ConfigMapRef != nil {
+					names[envFrom.ConfigMapRef.Name] = true
+				}
+			}
+		}
+	}
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			names[volume.ConfigMap.Name] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					names[source.ConfigMap.Name] = true
+				}
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// AddChecks adds the specified checks to the report data and updates its
+// summary accordingly.
+func AddChecks(data *v1alpha1.ConfigAuditReportData, checks []v1alpha1.Check) {
+	for _, check := range checks {
+		switch {
+		case check.Success:
+			data.Summary.PassCount++
+		case check.Severity == v1alpha1.ConfigAuditSeverityDanger:
+			data.Summary.DangerCount++
+		case check.Severity == v1alpha1.ConfigAuditSeverityWarning:
+			data.Summary.WarningCount++
+		}
+	}
+	data.Checks = append(data.Checks, checks...)
+}
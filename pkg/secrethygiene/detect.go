@@ -0,0 +1,89 @@
+package secrethygiene
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+// pattern matches values of a well-known credential format.
+type pattern struct {
+	description string
+	regexp      *regexp.Regexp
+}
+
+var patterns = []pattern{
+	{description: "an AWS access key ID", regexp: regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{description: "a GitHub token", regexp: regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{description: "a GitLab personal access token", regexp: regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20}\b`)},
+	{description: "a Slack token", regexp: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{description: "a Google API key", regexp: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{description: "a Stripe secret key", regexp: regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{24,}\b`)},
+	{description: "a private key", regexp: regexp.MustCompile(`-----BEGIN ((RSA|DSA|EC|OPENSSH|PGP) )?PRIVATE KEY( BLOCK)?-----`)},
+	{description: "a JSON Web Token", regexp: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{description: "a URL with credentials", regexp: regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.-]*://[^/\s:@]+:[^$/\s:@][^/\s:@]*@`)},
+}
+
+var (
+	// sensitiveName matches names of variables and keys that suggest that
+	// their values are credentials.
+	sensitiveName = regexp.MustCompile(`(?i)(passw(or)?d|pwd|secret|token|api_?key|access_?key|private_?key|credential)`)
+	// referenceName matches names of variables and keys whose values refer
+	// to credentials rather than hold them, e.g. SECRET_NAME or TOKEN_FILE.
+	referenceName = regexp.MustCompile(`(?i)((name|file|path|dir|ref|url|endpoint|host)|_id)$`)
+)
+
+const (
+	// minLength is the minimum length of values of variables and keys with
+	// sensitive names that are reported as credentials.
+	minLength = 8
+	// minEntropy is the minimum Shannon entropy, in bits per character, of
+	// values of variables and keys with sensitive names that are reported as
+	// credentials. It excludes placeholders such as changeme or xxxxxxxx.
+	minEntropy = 3.0
+)
+
+// Detect returns the description of the kind of credential that the value
+// of the environment variable or ConfigMap key with the specified name looks
+// like, e.g. "an AWS access key ID". The value looks like a credential if it
+// matches a well-known credential format, or if the name suggests a
+// credential, e.g. DB_PASSWORD, and the value looks random. Returns false if
+// the value doesn't look like a credential.
+func Detect(name, value string) (string, bool) {
+	for _, p := range patterns {
+		if p.regexp.MatchString(value) {
+			return p.description, true
+		}
+	}
+	name = strings.ReplaceAll(name, "-", "_")
+	if !sensitiveName.MatchString(name) || referenceName.MatchString(name) {
+		return "", false
+	}
+	if len(value) < minLength || strings.ContainsAny(value, " \t\n") ||
+		strings.Contains(value, "$(") || strings.HasPrefix(value, "/") {
+		return "", false
+	}
+	if Entropy(value) < minEntropy {
+		return "", false
+	}
+	return "a credential", true
+}
+
+// Entropy returns the Shannon entropy of the value in bits per character.
+func Entropy(value string) float64 {
+	if value == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	length := 0
+	for _, r := range value {
+		counts[r]++
+		length++
+	}
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / float64(length)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,4 @@
+// Package secrethygiene provides primitives for auditing literal values of
+// environment variables and keys of ConfigMaps for credential-looking values,
+// e.g. cloud provider keys or passwords, which should be stored in Secrets.
+package secrethygiene
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -19,7 +20,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
 )
 
 func NewScheme() *runtime.Scheme {
@@ -50,19 +53,69 @@ type Scanner string
 const (
 	Trivy    Scanner = "Trivy"
 	Aqua     Scanner = "Aqua"
+	Harbor   Scanner = "Harbor"
+	Quay     Scanner = "Quay"
+	External Scanner = "External"
 	Polaris  Scanner = "Polaris"
 	Conftest Scanner = "Conftest"
 )
 
+// CredentialProvider represents a source of short-lived container registry
+// credentials other than image pull Secrets.
+type CredentialProvider string
+
+const (
+	// ECR exchanges the AWS identity of the Pod (IRSA) or the Node
+	// (instance profile) for Amazon ECR authorization tokens.
+	ECR CredentialProvider = "ECR"
+	// GCR exchanges the Google service account of the Pod (Workload
+	// Identity) or the Node for OAuth 2.0 access tokens accepted by Google
+	// Container Registry and Artifact Registry.
+	GCR CredentialProvider = "GCR"
+	// ACR exchanges the Azure managed identity of the Pod or the Node
+	// (kubelet identity) for Azure Container Registry refresh tokens.
+	ACR CredentialProvider = "ACR"
+)
+
+// ReportNameFormat represents the scheme of names of reports.
+type ReportNameFormat string
+
+const (
+	// ReportNameReadable names reports after the kind and the name of the
+	// workload and the name of the container, e.g.
+	// replicaset-nginx-6d4cf56db6-nginx. Names that are too long are
+	// truncated and suffixed with a hash of the full name.
+	ReportNameReadable ReportNameFormat = "Readable"
+	// ReportNameHashed is the scheme of previous versions, which replaces
+	// names that are too long with the kind of the workload followed by a
+	// hash of the names of the workload and the container.
+	ReportNameHashed ReportNameFormat = "Hashed"
+)
+
 const (
 	keyVulnerabilityReportsScanner = "vulnerabilityReports.scanner"
 	keyConfigAuditReportsScanner   = "configAuditReports.scanner"
+	keyReportsNameFormat           = "reports.nameFormat"
+	keyCommonLabels                = "common.labels"
+	keyCommonAnnotations           = "common.annotations"
 	keyKubeBenchImageRef           = "kube-bench.imageRef"
 	keyKubeHunterImageRef          = "kube-hunter.imageRef"
 	keyKubeHunterQuick             = "kube-hunter.quick"
 	keyScanJobTolerations          = "scanJob.tolerations"
+	keyScanJobNodeSelector         = "scanJob.nodeSelector"
+	keyScanJobAffinity             = "scanJob.affinity"
+	keyScanJobTopologySpread       = "scanJob.topologySpreadConstraints"
+	keyScanJobPriorityClassName    = "scanJob.priorityClassName"
+	keyScanJobRuntimeClassName     = "scanJob.runtimeClassName"
+	keyScanJobRestricted           = "scanJob.restrictedSecurityContext"
+	keyScanJobImageRegistry        = "scanJob.imageRegistry"
+	keyScanJobImagePullSecrets     = "scanJob.imagePullSecrets"
 	keyScanJobAnnotations          = "scanJob.annotations"
 	keyScanJobPodTemplateLabels    = "scanJob.podTemplateLabels"
+	keyScanJobCredentialProviders  = "scanJob.credentialProviders"
+	keyScanJobRegistrySecret       = "scanJob.registryCredentialsSecret"
+	keyCosignImageRef              = "cosign.imageRef"
+	keyImageSignaturePolicies      = "imageSignatures.policies"
 )
 
 // ConfigData holds Starboard configuration settings as a set
@@ -85,6 +138,7 @@ func GetDefaultConfig() ConfigData {
 		"kube-bench.imageRef":  "docker.io/aquasec/kube-bench:v0.6.5",
 		"kube-hunter.imageRef": "docker.io/aquasec/kube-hunter:0.6.3",
 		"kube-hunter.quick":    "false",
+		"cosign.imageRef":      "gcr.io/projectsigstore/cosign:v2.0.0",
 	}
 }
 
@@ -100,10 +154,16 @@ func (c ConfigData) GetVulnerabilityReportsScanner() (Scanner, error) {
 		return Trivy, nil
 	case Aqua:
 		return Aqua, nil
+	case Harbor:
+		return Harbor, nil
+	case Quay:
+		return Quay, nil
+	case External:
+		return External, nil
 	}
 
-	return "", fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s)",
-		value, keyVulnerabilityReportsScanner, Trivy, Aqua)
+	return "", fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s, %s, %s, %s)",
+		value, keyVulnerabilityReportsScanner, Trivy, Aqua, Harbor, Quay, External)
 }
 
 func (c ConfigData) GetConfigAuditReportsScanner() (Scanner, error) {
@@ -123,6 +183,98 @@ func (c ConfigData) GetConfigAuditReportsScanner() (Scanner, error) {
 		value, keyConfigAuditReportsScanner, Polaris, Conftest)
 }
 
+// GetReportNameFormat returns the ReportNameFormat of names of reports, which
+// defaults to ReportNameReadable.
+func (c ConfigData) GetReportNameFormat() (ReportNameFormat, error) {
+	value, ok := c[keyReportsNameFormat]
+	if !ok || value == "" {
+		return ReportNameReadable, nil
+	}
+	switch ReportNameFormat(value) {
+	case ReportNameReadable:
+		return ReportNameReadable, nil
+	case ReportNameHashed:
+		return ReportNameHashed, nil
+	}
+	return "", fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s)",
+		value, keyReportsNameFormat, ReportNameReadable, ReportNameHashed)
+}
+
+// CommonMetadata holds labels and annotations that are set on all reports and
+// scan jobs, e.g. asset tags required by governance tooling.
+type CommonMetadata struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// ApplyTo adds labels and annotations to the specified ObjectMeta unless they
+// are already set. Maps of the ObjectMeta are copied rather than modified, as
+// they may be shared by many objects.
+func (m CommonMetadata) ApplyTo(meta *metav1.ObjectMeta) {
+	meta.Labels = withDefaults(meta.Labels, m.Labels)
+	meta.Annotations = withDefaults(meta.Annotations, m.Annotations)
+}
+
+func withDefaults(values, defaults map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return values
+	}
+	merged := make(map[string]string)
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range values {
+		merged[key] = value
+	}
+	return merged
+}
+
+// ApplyToJob adds labels and annotations to the specified Job and to the
+// template of its pods unless they are already set.
+func (m CommonMetadata) ApplyToJob(job *batchv1.Job) {
+	m.ApplyTo(&job.ObjectMeta)
+	m.ApplyTo(&job.Spec.Template.ObjectMeta)
+}
+
+// GetCommonMetadata returns the CommonMetadata set with the common.labels and
+// common.annotations keys, which are specified as comma separated lists of
+// key=value pairs, e.g. asset-id=a-1234,env=prod.
+func (c ConfigData) GetCommonMetadata() (CommonMetadata, error) {
+	labels, err := parseKeyValues(keyCommonLabels, c[keyCommonLabels])
+	if err != nil {
+		return CommonMetadata{}, err
+	}
+	for key, value := range labels {
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return CommonMetadata{}, fmt.Errorf("invalid value (%s) of label %s in %s: %s", value, key,
+				keyCommonLabels, strings.Join(errs, "; "))
+		}
+	}
+	annotations, err := parseKeyValues(keyCommonAnnotations, c[keyCommonAnnotations])
+	if err != nil {
+		return CommonMetadata{}, err
+	}
+	return CommonMetadata{Labels: labels, Annotations: annotations}, nil
+}
+
+func parseKeyValues(property, value string) (map[string]string, error) {
+	values := make(map[string]string)
+	if strings.TrimSpace(value) == "" {
+		return values, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid value (%s) of %s; expected key=value pairs", value, property)
+		}
+		if errs := validation.IsQualifiedName(parts[0]); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid key (%s) in %s: %s", parts[0], property, strings.Join(errs, "; "))
+		}
+		values[parts[0]] = parts[1]
+	}
+	return values, nil
+}
+
 func (c ConfigData) GetScanJobTolerations() ([]corev1.Toleration, error) {
 	var scanJobTolerations []corev1.Toleration
 	if c[keyScanJobTolerations] == "" {
@@ -171,6 +323,561 @@ func (c ConfigData) GetScanJobPodTemplateLabels() (labels.Set, error) {
 	return scanJobPodTemplateLabelsMap, nil
 }
 
+// GetScanJobCredentialProviders returns the list of CredentialProvider used
+// to obtain registry credentials for container images that are not covered
+// by image pull Secrets.
+func (c ConfigData) GetScanJobCredentialProviders() ([]CredentialProvider, error) {
+	value, found := c[keyScanJobCredentialProviders]
+	if !found || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var providers []CredentialProvider
+	for _, provider := range strings.Split(value, ",") {
+		switch CredentialProvider(strings.TrimSpace(provider)) {
+		case ECR:
+			providers = append(providers, ECR)
+		case GCR:
+			providers = append(providers, GCR)
+		case ACR:
+			providers = append(providers, ACR)
+		default:
+			return nil, fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s, %s)",
+				strings.TrimSpace(provider), keyScanJobCredentialProviders, ECR, GCR, ACR)
+		}
+	}
+	return providers, nil
+}
+
+// GetScanJobRegistryCredentialsSecret returns the name of a Secret of type
+// kubernetes.io/dockerconfigjson, in the Starboard namespace, that holds
+// cluster-wide registry credentials used as a fallback for all scan jobs.
+// It returns an empty string if such Secret is not configured.
+func (c ConfigData) GetScanJobRegistryCredentialsSecret() string {
+	return strings.TrimSpace(c[keyScanJobRegistrySecret])
+}
+
+// ScanJobScheduling holds the constraints on the nodes that scanner pods are
+// scheduled to, e.g. to run scans on dedicated node pools.
+type ScanJobScheduling struct {
+	NodeSelector              map[string]string
+	Affinity                  *corev1.Affinity
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+	PriorityClassName         string
+}
+
+// GetScanJobScheduling returns the scheduling constraints of scanner pods
+// parsed from the JSON values of the scanJob.nodeSelector, scanJob.affinity
+// and scanJob.topologySpreadConstraints keys, and the name of the
+// PriorityClass set with the scanJob.priorityClassName key.
+func (c ConfigData) GetScanJobScheduling() (ScanJobScheduling, error) {
+	scheduling := ScanJobScheduling{
+		PriorityClassName: strings.TrimSpace(c[keyScanJobPriorityClassName]),
+	}
+	if value := strings.TrimSpace(c[keyScanJobNodeSelector]); value != "" {
+		err := json.Unmarshal([]byte(value), &scheduling.NodeSelector)
+		if err != nil {
+			return ScanJobScheduling{}, fmt.Errorf("parsing %s: %w", keyScanJobNodeSelector, err)
+		}
+	}
+	if value := strings.TrimSpace(c[keyScanJobAffinity]); value != "" {
+		err := json.Unmarshal([]byte(value), &scheduling.Affinity)
+		if err != nil {
+			return ScanJobScheduling{}, fmt.Errorf("parsing %s: %w", keyScanJobAffinity, err)
+		}
+	}
+	if value := strings.TrimSpace(c[keyScanJobTopologySpread]); value != "" {
+		err := json.Unmarshal([]byte(value), &scheduling.TopologySpreadConstraints)
+		if err != nil {
+			return ScanJobScheduling{}, fmt.Errorf("parsing %s: %w", keyScanJobTopologySpread, err)
+		}
+	}
+	return scheduling, nil
+}
+
+// ApplyTo adds the scheduling constraints to the specified pod spec of a
+// scanner pod. The node selector and affinity are merged with the ones set by
+// the plugin, so that a scanner pod is scheduled to a node that satisfies
+// both. Scanner pods that must run on a given node, e.g. to scan the file
+// system of a container or the configuration of a node, only get the
+// PriorityClass.
+func (s ScanJobScheduling) ApplyTo(spec *corev1.PodSpec) {
+	if s.PriorityClassName != "" {
+		spec.PriorityClassName = s.PriorityClassName
+	}
+	if spec.NodeName != "" {
+		return
+	}
+	if len(s.NodeSelector) > 0 && spec.NodeSelector == nil {
+		spec.NodeSelector = make(map[string]string)
+	}
+	for key, value := range s.NodeSelector {
+		spec.NodeSelector[key] = value
+	}
+	if s.Affinity != nil {
+		spec.Affinity = mergeAffinity(spec.Affinity, s.Affinity.DeepCopy())
+	}
+	spec.TopologySpreadConstraints = append(spec.TopologySpreadConstraints, s.TopologySpreadConstraints...)
+}
+
+// mergeAffinity returns the affinity that is satisfied by nodes and pods that
+// satisfy both the specified affinities.
+func mergeAffinity(affinity, other *corev1.Affinity) *corev1.Affinity {
+	if affinity == nil {
+		return other
+	}
+	merged := affinity.DeepCopy()
+
+	if other.NodeAffinity != nil {
+		if merged.NodeAffinity == nil {
+			merged.NodeAffinity = &corev1.NodeAffinity{}
+		}
+		merged.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = mergeNodeSelector(
+			merged.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			other.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+		merged.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			merged.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			other.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution...)
+	}
+
+	if other.PodAffinity != nil {
+		if merged.PodAffinity == nil {
+			merged.PodAffinity = &corev1.PodAffinity{}
+		}
+		merged.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			merged.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			other.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+		merged.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			merged.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			other.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution...)
+	}
+
+	if other.PodAntiAffinity != nil {
+		if merged.PodAntiAffinity == nil {
+			merged.PodAntiAffinity = &corev1.PodAntiAffinity{}
+		}
+		merged.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			merged.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			other.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+		merged.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			merged.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			other.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution...)
+	}
+	return merged
+}
+
+// mergeNodeSelector returns the node selector that matches nodes matched by
+// both the specified node selectors. Node selector terms are ORed, therefore
+// each pair of terms is combined into a single term.
+func mergeNodeSelector(selector, other *corev1.NodeSelector) *corev1.NodeSelector {
+	if selector == nil || len(selector.NodeSelectorTerms) == 0 {
+		return other
+	}
+	if other == nil || len(other.NodeSelectorTerms) == 0 {
+		return selector
+	}
+	merged := &corev1.NodeSelector{}
+	for _, term := range selector.NodeSelectorTerms {
+		for _, otherTerm := range other.NodeSelectorTerms {
+			var combined corev1.NodeSelectorTerm
+			combined.MatchExpressions = append(combined.MatchExpressions, term.MatchExpressions...)
+			combined.MatchExpressions = append(combined.MatchExpressions, otherTerm.MatchExpressions...)
+			combined.MatchFields = append(combined.MatchFields, term.MatchFields...)
+			combined.MatchFields = append(combined.MatchFields, otherTerm.MatchFields...)
+			merged.NodeSelectorTerms = append(merged.NodeSelectorTerms, combined)
+		}
+	}
+	return merged
+}
+
+// nobodyUserID is the ID of the user that runs containers of scanner pods with
+// the restricted security context, unless the plugin sets a different user.
+const nobodyUserID = 65534
+
+// ScanJobSandbox holds the settings used to run scanner pods in a sandbox,
+// e.g. where untrusted image content must not be processed with the host
+// kernel.
+type ScanJobSandbox struct {
+	// RuntimeClassName is the name of the RuntimeClass of scanner pods, e.g.
+	// a RuntimeClass of gVisor or Kata Containers.
+	RuntimeClassName string
+
+	// Restricted indicates whether scanner pods run with the security context
+	// required by the restricted Pod Security Standard.
+	Restricted bool
+}
+
+// GetScanJobSandbox returns the sandbox settings of scanner pods set with the
+// scanJob.runtimeClassName and scanJob.restrictedSecurityContext keys.
+func (c ConfigData) GetScanJobSandbox() (ScanJobSandbox, error) {
+	sandbox := ScanJobSandbox{
+		RuntimeClassName: strings.TrimSpace(c[keyScanJobRuntimeClassName]),
+	}
+	if value := strings.TrimSpace(c[keyScanJobRestricted]); value != "" {
+		restricted, err := strconv.ParseBool(value)
+		if err != nil {
+			return ScanJobSandbox{}, fmt.Errorf("parsing %s: %w", keyScanJobRestricted, err)
+		}
+		sandbox.Restricted = restricted
+	}
+	return sandbox, nil
+}
+
+// ApplyTo sets the RuntimeClass and the restricted security context of the
+// specified pod spec of a scanner pod. Scanner pods that use host namespaces
+// or run containers as root, e.g. to scan the file system of a container,
+// cannot run in a sandbox and are left untouched.
+func (s ScanJobSandbox) ApplyTo(spec *corev1.PodSpec) {
+	if spec.HostPID || spec.HostIPC || spec.HostNetwork || runsAsRoot(spec) {
+		return
+	}
+	if s.RuntimeClassName != "" {
+		spec.RuntimeClassName = pointer.StringPtr(s.RuntimeClassName)
+	}
+	if !s.Restricted || usesHostPath(spec) {
+		return
+	}
+
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	spec.SecurityContext.RunAsNonRoot = pointer.BoolPtr(true)
+	if spec.SecurityContext.RunAsUser == nil {
+		spec.SecurityContext.RunAsUser = pointer.Int64Ptr(nobodyUserID)
+	}
+	if !isRestrictedSeccompProfile(spec.SecurityContext.SeccompProfile) {
+		spec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		}
+	}
+	for i := range spec.InitContainers {
+		restrictSecurityContext(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		restrictSecurityContext(&spec.Containers[i])
+	}
+}
+
+// restrictSecurityContext sets the security context of the specified container
+// as required by the restricted Pod Security Standard, which only allows the
+// NET_BIND_SERVICE capability to be added.
+func restrictSecurityContext(container *corev1.Container) {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	sc := container.SecurityContext
+	sc.Privileged = pointer.BoolPtr(false)
+	sc.AllowPrivilegeEscalation = pointer.BoolPtr(false)
+	sc.RunAsNonRoot = pointer.BoolPtr(true)
+	if sc.SeccompProfile != nil && !isRestrictedSeccompProfile(sc.SeccompProfile) {
+		// Inherit the seccomp profile of the pod.
+		sc.SeccompProfile = nil
+	}
+
+	var add []corev1.Capability
+	if sc.Capabilities != nil {
+		for _, capability := range sc.Capabilities.Add {
+			if capability == "NET_BIND_SERVICE" {
+				add = append(add, capability)
+			}
+		}
+	}
+	sc.Capabilities = &corev1.Capabilities{
+		Add:  add,
+		Drop: []corev1.Capability{"ALL"},
+	}
+}
+
+// isRestrictedSeccompProfile checks whether the specified seccomp profile is
+// allowed by the restricted Pod Security Standard.
+func isRestrictedSeccompProfile(profile *corev1.SeccompProfile) bool {
+	return profile != nil && (profile.Type == corev1.SeccompProfileTypeRuntimeDefault ||
+		profile.Type == corev1.SeccompProfileTypeLocalhost)
+}
+
+// usesHostPath checks whether the specified pod spec mounts any directory of
+// the host, which is not allowed by the restricted Pod Security Standard.
+func usesHostPath(spec *corev1.PodSpec) bool {
+	for _, volume := range spec.Volumes {
+		if volume.HostPath != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// runsAsRoot checks whether the specified pod spec explicitly runs any of its
+// containers as root.
+func runsAsRoot(spec *corev1.PodSpec) bool {
+	isRoot := func(user *int64) bool {
+		return user != nil && *user == 0
+	}
+	if spec.SecurityContext != nil && isRoot(spec.SecurityContext.RunAsUser) {
+		return true
+	}
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for _, container := range containers {
+			if container.SecurityContext != nil && isRoot(container.SecurityContext.RunAsUser) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ScanJobImages holds the settings used to pull images of scanners from a
+// private registry, e.g. in air-gapped clusters.
+type ScanJobImages struct {
+	// Registry is the host, optionally followed by a path, of the registry
+	// that mirrors images of scanners, e.g. `registry.acme.internal/mirror`.
+	Registry string
+
+	// PullSecrets are the names of image pull Secrets, in the namespace of
+	// scan jobs, used to pull images of scanners.
+	PullSecrets []string
+}
+
+// GetScanJobImages returns the settings of images of scanners set with the
+// scanJob.imageRegistry and scanJob.imagePullSecrets keys.
+func (c ConfigData) GetScanJobImages() (ScanJobImages, error) {
+	images := ScanJobImages{
+		Registry: strings.TrimSuffix(strings.TrimSpace(c[keyScanJobImageRegistry]), "/"),
+	}
+	if images.Registry != "" {
+		if _, err := name.NewRepository(images.Registry+"/starboard", name.StrictValidation); err != nil {
+			return ScanJobImages{}, fmt.Errorf("parsing %s: %w", keyScanJobImageRegistry, err)
+		}
+	}
+	for _, secret := range strings.Split(c[keyScanJobImagePullSecrets], ",") {
+		secret = strings.TrimSpace(secret)
+		if secret == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(secret); len(errs) > 0 {
+			return ScanJobImages{}, fmt.Errorf("invalid value (%s) of %s: %s",
+				secret, keyScanJobImagePullSecrets, strings.Join(errs, ", "))
+		}
+		images.PullSecrets = append(images.PullSecrets, secret)
+	}
+	return images, nil
+}
+
+// MirrorImageRef returns the reference of the specified image in the mirror
+// registry, keeping its repository path and its tag or digest. References
+// that already point to the mirror registry are returned as is.
+func (i ScanJobImages) MirrorImageRef(imageRef string) (string, error) {
+	if i.Registry == "" || strings.HasPrefix(imageRef, i.Registry+"/") {
+		return imageRef, nil
+	}
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return "", fmt.Errorf("parsing reference: %w", err)
+	}
+	repository := i.Registry + "/" + ref.Context().RepositoryStr()
+	if digest, ok := ref.(name.Digest); ok {
+		return repository + "@" + digest.DigestStr(), nil
+	}
+	return repository + ":" + ref.Identifier(), nil
+}
+
+// ApplyTo pulls images of init containers and containers of the specified pod
+// spec from the mirror registry with the configured image pull Secrets.
+// Images of scanned workloads, which are run by some scanners, e.g. Trivy in
+// the Filesystem mode, are specified as excluded and left intact.
+func (i ScanJobImages) ApplyTo(spec *corev1.PodSpec, excluded ...string) {
+	isExcluded := func(image string) bool {
+		for _, e := range excluded {
+			if image == e {
+				return true
+			}
+		}
+		return false
+	}
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for j := range containers {
+			if isExcluded(containers[j].Image) {
+				continue
+			}
+			// Image references of scanners are validated at startup.
+			if image, err := i.MirrorImageRef(containers[j].Image); err == nil {
+				containers[j].Image = image
+			}
+		}
+	}
+	for _, secret := range i.PullSecrets {
+		found := false
+		for _, ref := range spec.ImagePullSecrets {
+			if ref.Name == secret {
+				found = true
+				break
+			}
+		}
+		if !found {
+			spec.ImagePullSecrets = append(spec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+		}
+	}
+}
+
+// ValidateImageRefs checks whether the values of all `*.imageRef` keys of the
+// specified configuration data are valid image references, so that
+// misconfigured scanners are detected at startup rather than by failing scan
+// jobs.
+func ValidateImageRefs(data map[string]string) error {
+	for key, value := range data {
+		if !strings.HasSuffix(key, ".imageRef") {
+			continue
+		}
+		if _, err := name.ParseReference(value); err != nil {
+			return fmt.Errorf("invalid value (%s) of %s: %w", value, key, err)
+		}
+	}
+	return nil
+}
+
+// ImageSignaturePolicy defines how signatures of container images matching
+// any of the Images patterns are verified. Exactly one of Key or Keyless must
+// be set.
+type ImageSignaturePolicy struct {
+	// Name is the unique name of the policy.
+	Name string `json:"name"`
+
+	// Images is a list of image reference patterns, where the `*` wildcard
+	// matches any sequence of characters, e.g. `ghcr.io/acme/*`.
+	Images []string `json:"images"`
+
+	// Key is a PEM encoded public key used to verify signatures.
+	Key string `json:"key,omitempty"`
+
+	// Keyless enables verification of signatures with certificates issued
+	// by Fulcio.
+	Keyless *KeylessSignaturePolicy `json:"keyless,omitempty"`
+
+	// Provenance enables verification of SLSA provenance attestations
+	// signed with the same Key or Keyless identity as image signatures.
+	Provenance *ProvenancePolicy `json:"provenance,omitempty"`
+}
+
+// ProvenancePolicy defines how SLSA provenance attestations of container
+// images are verified.
+type ProvenancePolicy struct {
+	// Type is the Cosign attestation type, either `slsaprovenance` (v0.2)
+	// or `slsaprovenance1` (v1). Defaults to `slsaprovenance`.
+	Type string `json:"type,omitempty"`
+
+	// Builders is a list of trusted builders along with SLSA build levels
+	// they attain. Provenance produced by any other builder attains level 1.
+	Builders []TrustedBuilder `json:"builders,omitempty"`
+
+	// MinSLSALevel is the minimum SLSA build level required for the
+	// provenance to be considered verified.
+	MinSLSALevel int `json:"minSLSALevel,omitempty"`
+}
+
+// TrustedBuilder is a builder which produces provenance of the given SLSA
+// build level.
+type TrustedBuilder struct {
+	// ID is the identity of the builder as recorded in provenance, e.g.
+	// `https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.5.0`.
+	ID string `json:"id"`
+
+	// SLSALevel is the SLSA build level attained by the builder.
+	SLSALevel int `json:"slsaLevel"`
+}
+
+const (
+	ProvenanceTypeSLSAv02 = "slsaprovenance"
+	ProvenanceTypeSLSAv1  = "slsaprovenance1"
+)
+
+// GetType returns the Cosign attestation type, which defaults to
+// ProvenanceTypeSLSAv02.
+func (p ProvenancePolicy) GetType() string {
+	if p.Type == "" {
+		return ProvenanceTypeSLSAv02
+	}
+	return p.Type
+}
+
+// KeylessSignaturePolicy restricts identities of certificates issued by
+// Fulcio that are trusted to sign container images.
+type KeylessSignaturePolicy struct {
+	// Issuer is the OIDC issuer of the signing certificate,
+	// e.g. `https://token.actions.githubusercontent.com`.
+	Issuer string `json:"issuer"`
+
+	// Subject is a regular expression that the identity of the signing
+	// certificate must match, e.g. `^https://github.com/acme/`.
+	Subject string `json:"subject"`
+}
+
+// GetCosignImageRef returns the reference of the Cosign container image used
+// to verify signatures of container images.
+func (c ConfigData) GetCosignImageRef() (string, error) {
+	return c.GetRequiredData(keyCosignImageRef)
+}
+
+// GetImageSignaturePolicies returns the list of ImageSignaturePolicy parsed
+// from the JSON representation stored under the imageSignatures.policies key.
+func (c ConfigData) GetImageSignaturePolicies() ([]ImageSignaturePolicy, error) {
+	value, found := c[keyImageSignaturePolicies]
+	if !found || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var policies []ImageSignaturePolicy
+	err := json.Unmarshal([]byte(value), &policies)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", keyImageSignaturePolicies, err)
+	}
+	names := make(map[string]bool)
+	for _, policy := range policies {
+		if policy.Name == "" {
+			return nil, fmt.Errorf("invalid value of %s: policy name must not be empty", keyImageSignaturePolicies)
+		}
+		if errs := validation.IsConfigMapKey(policy.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid value of %s: invalid policy name %s: %s", keyImageSignaturePolicies, policy.Name, strings.Join(errs, "; "))
+		}
+		if names[policy.Name] {
+			return nil, fmt.Errorf("invalid value of %s: duplicate policy name %s", keyImageSignaturePolicies, policy.Name)
+		}
+		names[policy.Name] = true
+		if len(policy.Images) == 0 {
+			return nil, fmt.Errorf("invalid value of %s: policy %s must match at least one image", keyImageSignaturePolicies, policy.Name)
+		}
+		if (policy.Key == "") == (policy.Keyless == nil) {
+			return nil, fmt.Errorf("invalid value of %s: policy %s must specify either key or keyless", keyImageSignaturePolicies, policy.Name)
+		}
+		if policy.Keyless != nil && (policy.Keyless.Issuer == "" || policy.Keyless.Subject == "") {
+			return nil, fmt.Errorf("invalid value of %s: keyless policy %s must specify issuer and subject", keyImageSignaturePolicies, policy.Name)
+		}
+		if policy.Provenance != nil {
+			err = validateProvenancePolicy(policy.Name, *policy.Provenance)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return policies, nil
+}
+
+func validateProvenancePolicy(name string, provenance ProvenancePolicy) error {
+	if t := provenance.GetType(); t != ProvenanceTypeSLSAv02 && t != ProvenanceTypeSLSAv1 {
+		return fmt.Errorf("invalid value (%s) of %s: provenance type of policy %s; allowed values (%s, %s)",
+			t, keyImageSignaturePolicies, name, ProvenanceTypeSLSAv02, ProvenanceTypeSLSAv1)
+	}
+	if provenance.MinSLSALevel < 0 || provenance.MinSLSALevel > 3 {
+		return fmt.Errorf("invalid value of %s: minimum SLSA level of policy %s must be between 0 and 3", keyImageSignaturePolicies, name)
+	}
+	for _, builder := range provenance.Builders {
+		if builder.ID == "" {
+			return fmt.Errorf("invalid value of %s: trusted builder of policy %s must specify id", keyImageSignaturePolicies, name)
+		}
+		if builder.SLSALevel < 1 || builder.SLSALevel > 3 {
+			return fmt.Errorf("invalid value of %s: SLSA level of trusted builder %s must be between 1 and 3", keyImageSignaturePolicies, builder.ID)
+		}
+	}
+	return nil
+}
+
 func (c ConfigData) GetKubeBenchImageRef() (string, error) {
 	return c.GetRequiredData(keyKubeBenchImageRef)
 }
@@ -190,6 +897,44 @@ func (c ConfigData) GetKubeHunterQuick() (bool, error) {
 	return val == "true", nil
 }
 
+// Validate returns problems with configuration settings, e.g. unsupported
+// scanners or values that cannot be parsed, or nil if the settings are valid.
+func (c ConfigData) Validate() []error {
+	var errs []error
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	_, err := c.GetVulnerabilityReportsScanner()
+	check(err)
+	_, err = c.GetConfigAuditReportsScanner()
+	check(err)
+	_, err = c.GetReportNameFormat()
+	check(err)
+	_, err = c.GetCommonMetadata()
+	check(err)
+	_, err = c.GetScanJobTolerations()
+	check(err)
+	_, err = c.GetScanJobAnnotations()
+	check(err)
+	_, err = c.GetScanJobPodTemplateLabels()
+	check(err)
+	_, err = c.GetScanJobCredentialProviders()
+	check(err)
+	_, err = c.GetScanJobScheduling()
+	check(err)
+	_, err = c.GetScanJobSandbox()
+	check(err)
+	_, err = c.GetScanJobImages()
+	check(err)
+	_, err = c.GetImageSignaturePolicies()
+	check(err)
+	_, err = c.GetKubeHunterQuick()
+	check(err)
+	return errs
+}
+
 func (c ConfigData) GetRequiredData(key string) (string, error) {
 	var ok bool
 	var value string
@@ -280,17 +1025,27 @@ func (c *configManager) Read(ctx context.Context) (ConfigData, error) {
 		return nil, err
 	}
 
+	return NewConfigData(cm, secret), nil
+}
+
+// NewConfigData returns ConfigData merged from the specified ConfigMap and
+// Secret, whose keys take precedence. Either of them may be nil.
+func NewConfigData(cm *corev1.ConfigMap, secret *corev1.Secret) ConfigData {
 	var data = make(map[string]string)
 
-	for k, v := range cm.Data {
-		data[k] = v
+	if cm != nil {
+		for k, v := range cm.Data {
+			data[k] = v
+		}
 	}
 
-	for k, v := range secret.Data {
-		data[k] = string(v)
+	if secret != nil {
+		for k, v := range secret.Data {
+			data[k] = string(v)
+		}
 	}
 
-	return data, nil
+	return data
 }
 
 func (c *configManager) Delete(ctx context.Context) error {
@@ -13,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 )
 
 func TestConfigData_GetVulnerabilityReportsScanner(t *testing.T) {
@@ -36,6 +37,27 @@ func TestConfigData_GetVulnerabilityReportsScanner(t *testing.T) {
 			},
 			expectedScanner: starboard.Aqua,
 		},
+		{
+			name: "Should return Harbor",
+			configData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": "Harbor",
+			},
+			expectedScanner: starboard.Harbor,
+		},
+		{
+			name: "Should return Quay",
+			configData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": "Quay",
+			},
+			expectedScanner: starboard.Quay,
+		},
+		{
+			name: "Should return External",
+			configData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": "External",
+			},
+			expectedScanner: starboard.External,
+		},
 		{
 			name:          "Should return error when value is not set",
 			configData:    starboard.ConfigData{},
@@ -46,7 +68,7 @@ func TestConfigData_GetVulnerabilityReportsScanner(t *testing.T) {
 			configData: starboard.ConfigData{
 				"vulnerabilityReports.scanner": "Clair",
 			},
-			expectedError: "invalid value (Clair) of vulnerabilityReports.scanner; allowed values (Trivy, Aqua)",
+			expectedError: "invalid value (Clair) of vulnerabilityReports.scanner; allowed values (Trivy, Aqua, Harbor, Quay, External)",
 		},
 	}
 	for _, tc := range testCases {
@@ -109,6 +131,72 @@ func TestConfigData_GetConfigAuditReportsScanner(t *testing.T) {
 	}
 }
 
+func TestConfigData_GetReportNameFormat(t *testing.T) {
+	testCases := []struct {
+		name           string
+		configData     starboard.ConfigData
+		expectedError  string
+		expectedFormat starboard.ReportNameFormat
+	}{
+		{
+			name:           "Should return Readable when value is not set",
+			configData:     starboard.ConfigData{},
+			expectedFormat: starboard.ReportNameReadable,
+		},
+		{
+			name: "Should return Hashed",
+			configData: starboard.ConfigData{
+				"reports.nameFormat": "Hashed",
+			},
+			expectedFormat: starboard.ReportNameHashed,
+		},
+		{
+			name: "Should return error when value is not allowed",
+			configData: starboard.ConfigData{
+				"reports.nameFormat": "Random",
+			},
+			expectedError: "invalid value (Random) of reports.nameFormat; allowed values (Readable, Hashed)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			format, err := tc.configData.GetReportNameFormat()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedFormat, format)
+			}
+		})
+	}
+}
+
+func TestConfigData_GetCommonMetadata(t *testing.T) {
+	metadata, err := starboard.ConfigData{}.GetCommonMetadata()
+	require.NoError(t, err)
+	assert.Empty(t, metadata.Labels)
+	assert.Empty(t, metadata.Annotations)
+
+	metadata, err = starboard.ConfigData{
+		"common.labels":      "asset-id=a-1234, example.com/env=prod",
+		"common.annotations": "example.com/owner=team a,note=a=b",
+	}.GetCommonMetadata()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"asset-id": "a-1234", "example.com/env": "prod"}, metadata.Labels)
+	assert.Equal(t, map[string]string{"example.com/owner": "team a", "note": "a=b"}, metadata.Annotations)
+
+	meta := metav1.ObjectMeta{Labels: map[string]string{"asset-id": "a-5678"}}
+	metadata.ApplyTo(&meta)
+	assert.Equal(t, map[string]string{"asset-id": "a-5678", "example.com/env": "prod"}, meta.Labels)
+	assert.Equal(t, map[string]string{"example.com/owner": "team a", "note": "a=b"}, meta.Annotations)
+
+	_, err = starboard.ConfigData{"common.labels": "asset-id"}.GetCommonMetadata()
+	assert.EqualError(t, err, "invalid value (asset-id) of common.labels; expected key=value pairs")
+
+	_, err = starboard.ConfigData{"common.labels": "owner=team a"}.GetCommonMetadata()
+	assert.Error(t, err)
+}
+
 func TestConfigData_GetScanJobTolerations(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -178,6 +266,415 @@ func TestConfigData_GetScanJobTolerations(t *testing.T) {
 	}
 }
 
+func TestConfigData_GetScanJobScheduling(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      starboard.ConfigData
+		expected    starboard.ScanJobScheduling
+		expectError string
+	}{
+		{
+			name:     "no scheduling constraints in ConfigData",
+			config:   starboard.ConfigData{},
+			expected: starboard.ScanJobScheduling{},
+		},
+		{
+			name: "all scheduling constraints",
+			config: starboard.ConfigData{
+				"scanJob.nodeSelector": `{"node-pool":"utility"}`,
+				"scanJob.affinity":     `{"podAntiAffinity":{"preferredDuringSchedulingIgnoredDuringExecution":[{"weight":100,"podAffinityTerm":{"topologyKey":"kubernetes.io/hostname"}}]}}`,
+				"scanJob.topologySpreadConstraints": `[{"maxSkew":1,"topologyKey":"topology.kubernetes.io/zone","whenUnsatisfiable":"ScheduleAnyway",
+					"labelSelector":{"matchLabels":{"app.kubernetes.io/managed-by":"starboard"}}}]`,
+				"scanJob.priorityClassName": "starboard-scan-job",
+			},
+			expected: starboard.ScanJobScheduling{
+				NodeSelector: map[string]string{"node-pool": "utility"},
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+							{
+								Weight:          100,
+								PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: "kubernetes.io/hostname"},
+							},
+						},
+					},
+				},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app.kubernetes.io/managed-by": "starboard"},
+						},
+					},
+				},
+				PriorityClassName: "starboard-scan-job",
+			},
+		},
+		{
+			name:        "scanJob.nodeSelector value is not json",
+			config:      starboard.ConfigData{"scanJob.nodeSelector": `node-pool=utility`},
+			expectError: "parsing scanJob.nodeSelector: invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			name:        "scanJob.affinity value is not json",
+			config:      starboard.ConfigData{"scanJob.affinity": `lolwut`},
+			expectError: "parsing scanJob.affinity: invalid character 'l' looking for beginning of value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.config.GetScanJobScheduling()
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestScanJobScheduling_ApplyTo(t *testing.T) {
+	scheduling := starboard.ScanJobScheduling{
+		NodeSelector: map[string]string{"node-pool": "utility"},
+		Affinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{
+						{
+							MatchExpressions: []corev1.NodeSelectorRequirement{
+								{Key: "node-pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"utility", "batch"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+			{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.ScheduleAnyway},
+		},
+		PriorityClassName: "starboard-scan-job",
+	}
+
+	t.Run("Should merge scheduling constraints with plugin defaults", func(t *testing.T) {
+		spec := corev1.PodSpec{
+			Affinity: starboard.LinuxNodeAffinity(),
+		}
+		scheduling.ApplyTo(&spec)
+		assert.Equal(t, map[string]string{"node-pool": "utility"}, spec.NodeSelector)
+		assert.Equal(t, &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{
+						{
+							MatchExpressions: []corev1.NodeSelectorRequirement{
+								{Key: "kubernetes.io/os", Operator: corev1.NodeSelectorOpIn, Values: []string{"linux"}},
+								{Key: "node-pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"utility", "batch"}},
+							},
+						},
+					},
+				},
+			},
+		}, spec.Affinity)
+		assert.Equal(t, scheduling.TopologySpreadConstraints, spec.TopologySpreadConstraints)
+		assert.Equal(t, "starboard-scan-job", spec.PriorityClassName)
+	})
+
+	t.Run("Should only set priority class of pod spec bound to node", func(t *testing.T) {
+		spec := corev1.PodSpec{
+			NodeName: "kind-control-plane",
+			Affinity: starboard.LinuxNodeAffinity(),
+		}
+		scheduling.ApplyTo(&spec)
+		assert.Equal(t, corev1.PodSpec{
+			NodeName:          "kind-control-plane",
+			Affinity:          starboard.LinuxNodeAffinity(),
+			PriorityClassName: "starboard-scan-job",
+		}, spec)
+	})
+}
+
+func TestConfigData_GetScanJobSandbox(t *testing.T) {
+	t.Run("Should return sandbox settings", func(t *testing.T) {
+		sandbox, err := starboard.ConfigData{
+			"scanJob.runtimeClassName":          "gvisor",
+			"scanJob.restrictedSecurityContext": "true",
+		}.GetScanJobSandbox()
+		require.NoError(t, err)
+		assert.Equal(t, starboard.ScanJobSandbox{RuntimeClassName: "gvisor", Restricted: true}, sandbox)
+	})
+
+	t.Run("Should return error when restricted security context is not boolean", func(t *testing.T) {
+		_, err := starboard.ConfigData{
+			"scanJob.restrictedSecurityContext": "yes please",
+		}.GetScanJobSandbox()
+		assert.EqualError(t, err, `parsing scanJob.restrictedSecurityContext: strconv.ParseBool: parsing "yes please": invalid syntax`)
+	})
+}
+
+func TestScanJobSandbox_ApplyTo(t *testing.T) {
+	sandbox := starboard.ScanJobSandbox{RuntimeClassName: "gvisor", Restricted: true}
+
+	t.Run("Should set runtime class and restricted security context", func(t *testing.T) {
+		spec := corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{},
+			Containers: []corev1.Container{
+				{
+					Name: "trivy",
+					SecurityContext: &corev1.SecurityContext{
+						Privileged:               pointer.BoolPtr(false),
+						AllowPrivilegeEscalation: pointer.BoolPtr(false),
+						Capabilities: &corev1.Capabilities{
+							Add:  []corev1.Capability{"NET_RAW", "NET_BIND_SERVICE"},
+							Drop: []corev1.Capability{"all"},
+						},
+						ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+					},
+				},
+			},
+		}
+		sandbox.ApplyTo(&spec)
+		assert.Equal(t, corev1.PodSpec{
+			RuntimeClassName: pointer.StringPtr("gvisor"),
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: pointer.BoolPtr(true),
+				RunAsUser:    pointer.Int64Ptr(65534),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: "trivy",
+					SecurityContext: &corev1.SecurityContext{
+						Privileged:               pointer.BoolPtr(false),
+						AllowPrivilegeEscalation: pointer.BoolPtr(false),
+						RunAsNonRoot:             pointer.BoolPtr(true),
+						Capabilities: &corev1.Capabilities{
+							Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+							Drop: []corev1.Capability{"ALL"},
+						},
+						ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+					},
+				},
+			},
+		}, spec)
+	})
+
+	t.Run("Should replace unconfined seccomp profiles", func(t *testing.T) {
+		spec := corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser: pointer.Int64Ptr(1000),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeUnconfined,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: "polaris",
+					SecurityContext: &corev1.SecurityContext{
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeUnconfined,
+						},
+					},
+				},
+			},
+		}
+		sandbox.ApplyTo(&spec)
+		assert.Equal(t, corev1.PodSpec{
+			RuntimeClassName: pointer.StringPtr("gvisor"),
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: pointer.BoolPtr(true),
+				RunAsUser:    pointer.Int64Ptr(1000),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: "polaris",
+					SecurityContext: &corev1.SecurityContext{
+						Privileged:               pointer.BoolPtr(false),
+						AllowPrivilegeEscalation: pointer.BoolPtr(false),
+						RunAsNonRoot:             pointer.BoolPtr(true),
+						Capabilities: &corev1.Capabilities{
+							Drop: []corev1.Capability{"ALL"},
+						},
+					},
+				},
+			},
+		}, spec)
+	})
+
+	t.Run("Should only set runtime class of pod spec mounting host path", func(t *testing.T) {
+		spec := corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: "trivy-db",
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: "/var/lib/starboard/trivy-db",
+						},
+					},
+				},
+			},
+		}
+		expected := spec.DeepCopy()
+		expected.RuntimeClassName = pointer.StringPtr("gvisor")
+		sandbox.ApplyTo(&spec)
+		assert.Equal(t, *expected, spec)
+	})
+
+	t.Run("Should not change pod spec running as root", func(t *testing.T) {
+		spec := corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "trivy",
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: pointer.Int64Ptr(0),
+					},
+				},
+			},
+		}
+		expected := spec.DeepCopy()
+		sandbox.ApplyTo(&spec)
+		assert.Equal(t, *expected, spec)
+	})
+
+	t.Run("Should not change pod spec using host namespaces", func(t *testing.T) {
+		spec := corev1.PodSpec{
+			HostPID: true,
+		}
+		sandbox.ApplyTo(&spec)
+		assert.Equal(t, corev1.PodSpec{HostPID: true}, spec)
+	})
+}
+
+func TestConfigData_GetScanJobImages(t *testing.T) {
+	t.Run("Should return images settings", func(t *testing.T) {
+		images, err := starboard.ConfigData{
+			"scanJob.imageRegistry":    "registry.acme.internal/mirror/",
+			"scanJob.imagePullSecrets": "mirror-credentials, backup-credentials",
+		}.GetScanJobImages()
+		require.NoError(t, err)
+		assert.Equal(t, starboard.ScanJobImages{
+			Registry:    "registry.acme.internal/mirror",
+			PullSecrets: []string{"mirror-credentials", "backup-credentials"},
+		}, images)
+	})
+
+	t.Run("Should return empty settings when not set", func(t *testing.T) {
+		images, err := starboard.ConfigData{}.GetScanJobImages()
+		require.NoError(t, err)
+		assert.Equal(t, starboard.ScanJobImages{}, images)
+	})
+
+	t.Run("Should return error when registry is invalid", func(t *testing.T) {
+		_, err := starboard.ConfigData{
+			"scanJob.imageRegistry": "registry.acme.internal/Mirror",
+		}.GetScanJobImages()
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error when pull secret name is invalid", func(t *testing.T) {
+		_, err := starboard.ConfigData{
+			"scanJob.imagePullSecrets": "mirror_credentials",
+		}.GetScanJobImages()
+		assert.Error(t, err)
+	})
+}
+
+func TestScanJobImages_MirrorImageRef(t *testing.T) {
+	images := starboard.ScanJobImages{Registry: "registry.acme.internal/mirror"}
+
+	testCases := []struct {
+		imageRef string
+		expected string
+	}{
+		{
+			imageRef: "docker.io/aquasec/trivy:0.25.2",
+			expected: "registry.acme.internal/mirror/aquasec/trivy:0.25.2",
+		},
+		{
+			imageRef: "quay.io/fairwinds/polaris:4.2",
+			expected: "registry.acme.internal/mirror/fairwinds/polaris:4.2",
+		},
+		{
+			imageRef: "alpine",
+			expected: "registry.acme.internal/mirror/library/alpine:latest",
+		},
+		{
+			imageRef: "gcr.io/projectsigstore/cosign@sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+			expected: "registry.acme.internal/mirror/projectsigstore/cosign@sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+		},
+		{
+			imageRef: "registry.acme.internal/mirror/aquasec/kube-bench:v0.6.5",
+			expected: "registry.acme.internal/mirror/aquasec/kube-bench:v0.6.5",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.imageRef, func(t *testing.T) {
+			imageRef, err := images.MirrorImageRef(tc.imageRef)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, imageRef)
+		})
+	}
+
+	t.Run("Should return image reference as is when registry is not set", func(t *testing.T) {
+		imageRef, err := starboard.ScanJobImages{}.MirrorImageRef("docker.io/aquasec/trivy:0.25.2")
+		require.NoError(t, err)
+		assert.Equal(t, "docker.io/aquasec/trivy:0.25.2", imageRef)
+	})
+}
+
+func TestScanJobImages_ApplyTo(t *testing.T) {
+	images := starboard.ScanJobImages{
+		Registry:    "registry.acme.internal/mirror",
+		PullSecrets: []string{"mirror-credentials"},
+	}
+
+	spec := corev1.PodSpec{
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "mirror-credentials"}},
+		InitContainers: []corev1.Container{
+			{Name: "trivy-get-binary", Image: "docker.io/aquasec/trivy:0.25.2"},
+		},
+		Containers: []corev1.Container{
+			{Name: "nginx", Image: "nginx:1.16"},
+		},
+	}
+	images.ApplyTo(&spec, "nginx:1.16")
+	assert.Equal(t, corev1.PodSpec{
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "mirror-credentials"}},
+		InitContainers: []corev1.Container{
+			{Name: "trivy-get-binary", Image: "registry.acme.internal/mirror/aquasec/trivy:0.25.2"},
+		},
+		Containers: []corev1.Container{
+			{Name: "nginx", Image: "nginx:1.16"},
+		},
+	}, spec)
+}
+
+func TestValidateImageRefs(t *testing.T) {
+	t.Run("Should accept valid image references", func(t *testing.T) {
+		err := starboard.ValidateImageRefs(map[string]string{
+			"trivy.imageRef":  "registry.acme.internal/aquasec/trivy:0.25.2",
+			"trivy.severity":  "CRITICAL",
+			"cosign.imageRef": "gcr.io/projectsigstore/cosign:v2.0.0",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return error when image reference is invalid", func(t *testing.T) {
+		err := starboard.ValidateImageRefs(map[string]string{
+			"kube-bench.imageRef": "docker.io/aquasec/kube-bench:v0.6.5:latest",
+		})
+		assert.Error(t, err)
+	})
+}
+
 func TestConfigData_GetScanJobAnnotations(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -284,6 +781,81 @@ func TestConfigData_GetScanJobPodTemplateLabels(t *testing.T) {
 	}
 }
 
+func TestConfigData_GetScanJobCredentialProviders(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      starboard.ConfigData
+		expected    []starboard.CredentialProvider
+		expectError string
+	}{
+		{
+			name:     "no scanJob.credentialProviders in ConfigData",
+			config:   starboard.ConfigData{},
+			expected: nil,
+		},
+		{
+			name: "scanJob.credentialProviders contains ECR",
+			config: starboard.ConfigData{
+				"scanJob.credentialProviders": "ECR",
+			},
+			expected: []starboard.CredentialProvider{starboard.ECR},
+		},
+		{
+			name: "scanJob.credentialProviders contains ECR, GCR and ACR",
+			config: starboard.ConfigData{
+				"scanJob.credentialProviders": "ECR, GCR,ACR",
+			},
+			expected: []starboard.CredentialProvider{starboard.ECR, starboard.GCR, starboard.ACR},
+		},
+		{
+			name: "raise an error on being provided with unknown provider",
+			config: starboard.ConfigData{
+				"scanJob.credentialProviders": "ECR,Quay",
+			},
+			expectError: "invalid value (Quay) of scanJob.credentialProviders; allowed values (ECR, GCR, ACR)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			providers, err := tc.config.GetScanJobCredentialProviders()
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError, tc.name)
+			} else {
+				assert.NoError(t, err, tc.name)
+				assert.Equal(t, tc.expected, providers, tc.name)
+			}
+		})
+	}
+}
+
+func TestConfigData_GetScanJobRegistryCredentialsSecret(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   starboard.ConfigData
+		expected string
+	}{
+		{
+			name:     "no scanJob.registryCredentialsSecret in ConfigData",
+			config:   starboard.ConfigData{},
+			expected: "",
+		},
+		{
+			name: "scanJob.registryCredentialsSecret set in ConfigData",
+			config: starboard.ConfigData{
+				"scanJob.registryCredentialsSecret": " starboard-registry-credentials ",
+			},
+			expected: "starboard-registry-credentials",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.config.GetScanJobRegistryCredentialsSecret())
+		})
+	}
+}
+
 func TestConfigData_GetKubeBenchImageRef(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -398,6 +970,142 @@ func TestConfigData_GetKubeHunterQuick(t *testing.T) {
 	}
 }
 
+func TestConfigData_GetImageSignaturePolicies(t *testing.T) {
+	testCases := []struct {
+		name             string
+		configData       starboard.ConfigData
+		expectedPolicies []starboard.ImageSignaturePolicy
+		expectedError    string
+	}{
+		{
+			name:       "Should return nil when policies are not set",
+			configData: starboard.ConfigData{},
+		},
+		{
+			name: "Should return policies",
+			configData: starboard.ConfigData{
+				"imageSignatures.policies": `[{"name":"acme","images":["ghcr.io/acme/*"],"keyless":{"issuer":"https://token.actions.githubusercontent.com","subject":"^https://github.com/acme/"}},{"name":"internal","images":["registry.internal/*"],"key":"PUBLIC KEY"}]`,
+			},
+			expectedPolicies: []starboard.ImageSignaturePolicy{
+				{
+					Name:   "acme",
+					Images: []string{"ghcr.io/acme/*"},
+					Keyless: &starboard.KeylessSignaturePolicy{
+						Issuer:  "https://token.actions.githubusercontent.com",
+						Subject: "^https://github.com/acme/",
+					},
+				},
+				{
+					Name:   "internal",
+					Images: []string{"registry.internal/*"},
+					Key:    "PUBLIC KEY",
+				},
+			},
+		},
+		{
+			name: "Should return error when value is not valid JSON",
+			configData: starboard.ConfigData{
+				"imageSignatures.policies": "[",
+			},
+			expectedError: "parsing imageSignatures.policies: unexpected end of JSON input",
+		},
+		{
+			name: "Should return error when policy name is duplicated",
+			configData: starboard.ConfigData{
+				"imageSignatures.policies": `[{"name":"acme","images":["a/*"],"key":"K"},{"name":"acme","images":["b/*"],"key":"K"}]`,
+			},
+			expectedError: "invalid value of imageSignatures.policies: duplicate policy name acme",
+		},
+		{
+			name: "Should return error when policy does not match any image",
+			configData: starboard.ConfigData{
+				"imageSignatures.policies": `[{"name":"acme","key":"K"}]`,
+			},
+			expectedError: "invalid value of imageSignatures.policies: policy acme must match at least one image",
+		},
+		{
+			name: "Should return error when policy specifies both key and keyless",
+			configData: starboard.ConfigData{
+				"imageSignatures.policies": `[{"name":"acme","images":["a/*"],"key":"K","keyless":{"issuer":"i","subject":"s"}}]`,
+			},
+			expectedError: "invalid value of imageSignatures.policies: policy acme must specify either key or keyless",
+		},
+		{
+			name: "Should return error when keyless policy does not specify subject",
+			configData: starboard.ConfigData{
+				"imageSignatures.policies": `[{"name":"acme","images":["a/*"],"keyless":{"issuer":"i"}}]`,
+			},
+			expectedError: "invalid value of imageSignatures.policies: keyless policy acme must specify issuer and subject",
+		},
+		{
+			name: "Should return policy with provenance",
+			configData: starboard.ConfigData{
+				"imageSignatures.policies": `[{"name":"acme","images":["a/*"],"key":"K","provenance":{"builders":[{"id":"https://github.com/slsa-framework/slsa-github-generator","slsaLevel":3}],"minSLSALevel":2}}]`,
+			},
+			expectedPolicies: []starboard.ImageSignaturePolicy{
+				{
+					Name:   "acme",
+					Images: []string{"a/*"},
+					Key:    "K",
+					Provenance: &starboard.ProvenancePolicy{
+						Builders: []starboard.TrustedBuilder{
+							{ID: "https://github.com/slsa-framework/slsa-github-generator", SLSALevel: 3},
+						},
+						MinSLSALevel: 2,
+					},
+				},
+			},
+		},
+		{
+			name: "Should return error when provenance type is not supported",
+			configData: starboard.ConfigData{
+				"imageSignatures.policies": `[{"name":"acme","images":["a/*"],"key":"K","provenance":{"type":"spdx"}}]`,
+			},
+			expectedError: "invalid value (spdx) of imageSignatures.policies: provenance type of policy acme; allowed values (slsaprovenance, slsaprovenance1)",
+		},
+		{
+			name: "Should return error when SLSA level of trusted builder is out of range",
+			configData: starboard.ConfigData{
+				"imageSignatures.policies": `[{"name":"acme","images":["a/*"],"key":"K","provenance":{"builders":[{"id":"b","slsaLevel":4}]}}]`,
+			},
+			expectedError: "invalid value of imageSignatures.policies: SLSA level of trusted builder b must be between 1 and 3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policies, err := tc.configData.GetImageSignaturePolicies()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedPolicies, policies)
+			}
+		})
+	}
+}
+
+func TestConfigData_Validate(t *testing.T) {
+	t.Run("Should return no errors for default settings", func(t *testing.T) {
+		assert.Empty(t, starboard.GetDefaultConfig().Validate())
+	})
+	t.Run("Should return errors for invalid settings", func(t *testing.T) {
+		config := starboard.ConfigData{
+			"vulnerabilityReports.scanner": "Clair",
+			"configAuditReports.scanner":   "Polaris",
+			"kube-hunter.quick":            "maybe",
+		}
+		var errs []string
+		for _, err := range config.Validate() {
+			errs = append(errs, err.Error())
+		}
+		assert.Equal(t, []string{
+			"invalid value (Clair) of vulnerabilityReports.scanner; allowed values (Trivy, Aqua, Harbor, Quay, External)",
+			"property kube-hunter.quick must be either \"false\" or \"true\", got \"maybe\"",
+		}, errs)
+	})
+}
+
 func TestGetVersionFromImageRef(t *testing.T) {
 	testCases := []struct {
 		imageRef        string
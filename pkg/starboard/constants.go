@@ -25,22 +25,72 @@ const (
 )
 
 const (
-	LabelResourceKind      = "starboard.resource.kind"
-	LabelResourceName      = "starboard.resource.name"
-	LabelResourceNameHash  = "starboard.resource.name-hash"
-	LabelResourceNamespace = "starboard.resource.namespace"
-	LabelContainerName     = "starboard.container.name"
-	LabelResourceSpecHash  = "resource-spec-hash"
-	LabelPluginConfigHash  = "plugin-config-hash"
-
-	LabelConfigAuditReportScanner   = "configAuditReport.scanner"
-	LabelVulnerabilityReportScanner = "vulnerabilityReport.scanner"
-	LabelKubeBenchReportScanner     = "kubeBenchReport.scanner"
+	LabelResourceKind       = "starboard.resource.kind"
+	LabelResourceName       = "starboard.resource.name"
+	LabelResourceNameHash   = "starboard.resource.name-hash"
+	LabelResourceNamespace  = "starboard.resource.namespace"
+	LabelContainerName      = "starboard.container.name"
+	LabelImageDigest        = "starboard.container.image-digest"
+	LabelResourceSpecHash   = "resource-spec-hash"
+	LabelPluginConfigHash   = "plugin-config-hash"
+	LabelScanJobSlot        = "starboard.scan-job-slot"
+	LabelScanJobGaveUp      = "starboard.scan-job-gave-up"
+	LabelReportExpired      = "starboard.report-expired"
+	LabelScanPolicyHash     = "starboard.scan-policy.hash"
+	LabelEcosystemsHash     = "starboard.ecosystems.hash"
+	LabelSLABreach          = "starboard.sla-breach"
+	LabelClusterName        = "starboard.cluster.name"
+	LabelClusterEnvironment = "starboard.cluster.environment"
+	LabelReportName         = "starboard.report.name"
+	LabelReportNamespace    = "starboard.report.namespace"
+	// LabelHubOriginPrefix is the prefix of labels of copies of reports in the
+	// hub cluster that identify the workload and image of the original report,
+	// e.g. starboard.origin.resource.kind.
+	LabelHubOriginPrefix = "starboard.origin."
+	// LabelRiskPriority is the priority, from P1 to P4, of the risk score of
+	// a workload, which is set on the workload and its reports.
+	LabelRiskPriority = "starboard.risk-priority"
+	// LabelSubmittedReport marks ClusterVulnerabilityReports submitted for
+	// image digests, e.g. by CI pipelines, which the operator may use instead
+	// of scanning the images.
+	LabelSubmittedReport = "starboard.report.submitted"
+
+	// LabelVulnerabilityIDPrefix is the prefix of labels of VulnerabilityReports
+	// that contain the vulnerability with the ID following the prefix, e.g.
+	// starboard.vulnerability.CVE-2021-44228.
+	LabelVulnerabilityIDPrefix = "starboard.vulnerability."
+
+	LabelConfigAuditReportScanner    = "configAuditReport.scanner"
+	LabelVulnerabilityReportScanner  = "vulnerabilityReport.scanner"
+	LabelKubeBenchReportScanner      = "kubeBenchReport.scanner"
+	LabelImageSignatureReportScanner = "imageSignatureReport.scanner"
 
 	LabelK8SAppManagedBy = "app.kubernetes.io/managed-by"
 	AppStarboard         = "starboard"
 )
 
 const (
-	AnnotationContainerImages = "starboard.container-images"
+	AnnotationContainerImages       = "starboard.container-images"
+	AnnotationContainerImageDigests = "starboard.container-image-digests"
+	AnnotationScanJobAttempt        = "starboard.scan-job-attempt"
+	AnnotationScanJobFailure        = "starboard.scan-job-failure"
+	// AnnotationScan opts a workload in, if set to "true", or out, if set to
+	// "false", of scanning and auditing by the operator.
+	AnnotationScan = "starboard.scan"
+	// AnnotationOrphanedAt is the time, in RFC 3339 format, when the operator
+	// found that the owner of a report without owner references was deleted.
+	AnnotationOrphanedAt = "starboard.orphaned-at"
+	// AnnotationImageVanishedAt is the time, in RFC 3339 format, when the
+	// operator found that the image digest of a VulnerabilityReport was not
+	// used by any pod in its namespace.
+	AnnotationImageVanishedAt = "starboard.image-vanished-at"
+	// AnnotationRiskScore is the risk score, from 0 to 100, of the workload of
+	// a report, which combines findings of all its reports and its exposure.
+	AnnotationRiskScore = "starboard.risk-score"
+)
+
+const (
+	// FinalizerReportExport prevents removing a report which is being deleted
+	// until it's exported by the operator.
+	FinalizerReportExport = "starboard.aquasecurity.github.io/report-export"
 )
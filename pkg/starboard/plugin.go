@@ -2,8 +2,11 @@ package starboard
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -28,6 +31,143 @@ func (c PluginConfig) GetRequiredData(key string) (string, error) {
 	return value, nil
 }
 
+const (
+	keyPluginScanJobTimeout            = "scanJob.timeout"
+	keyPluginScanJobNamespaceOverrides = "scanJob.namespaceOverrides"
+)
+
+// ScanJobOverride overrides the timeout and compute resources of the scan jobs
+// created by a plugin.
+type ScanJobOverride struct {
+	// Timeout overrides the timeout of scan jobs.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Resources overrides compute resources of containers of scan jobs.
+	// Resources which are not specified keep the values set by the plugin.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// GetScanJobOverride returns the ScanJobOverride of scan jobs of objects in
+// the specified namespace. The timeout is read from the scanJob.timeout key
+// and overridden by the value for the namespace in the JSON representation of
+// the scanJob.namespaceOverrides key, e.g.
+// `{"ml":{"timeout":"30m","resources":{"limits":{"memory":"8Gi"}}}}`.
+func (c PluginConfig) GetScanJobOverride(namespace string) (ScanJobOverride, error) {
+	var override ScanJobOverride
+	if value := strings.TrimSpace(c.Data[keyPluginScanJobTimeout]); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return ScanJobOverride{}, fmt.Errorf("parsing %s: %w", keyPluginScanJobTimeout, err)
+		}
+		override.Timeout = &metav1.Duration{Duration: timeout}
+	}
+
+	value := strings.TrimSpace(c.Data[keyPluginScanJobNamespaceOverrides])
+	if value == "" {
+		return override, nil
+	}
+	var namespaceOverrides map[string]ScanJobOverride
+	err := json.Unmarshal([]byte(value), &namespaceOverrides)
+	if err != nil {
+		return ScanJobOverride{}, fmt.Errorf("parsing %s: %w", keyPluginScanJobNamespaceOverrides, err)
+	}
+	if namespaceOverride, ok := namespaceOverrides[namespace]; ok {
+		if namespaceOverride.Timeout != nil {
+			override.Timeout = namespaceOverride.Timeout
+		}
+		override.Resources = namespaceOverride.Resources
+	}
+	return override, nil
+}
+
+// GetTimeout returns the overridden timeout or the specified default timeout.
+func (o ScanJobOverride) GetTimeout(defaultTimeout time.Duration) time.Duration {
+	if o.Timeout == nil {
+		return defaultTimeout
+	}
+	return o.Timeout.Duration
+}
+
+// ApplyTo overrides compute resources of containers of the specified pod spec
+// of a scan job.
+func (o ScanJobOverride) ApplyTo(spec *corev1.PodSpec) {
+	for i := range spec.Containers {
+		resources := &spec.Containers[i].Resources
+		if len(o.Resources.Requests) > 0 && resources.Requests == nil {
+			resources.Requests = make(corev1.ResourceList)
+		}
+		for name, quantity := range o.Resources.Requests {
+			resources.Requests[name] = quantity
+		}
+		if len(o.Resources.Limits) > 0 && resources.Limits == nil {
+			resources.Limits = make(corev1.ResourceList)
+		}
+		for name, quantity := range o.Resources.Limits {
+			resources.Limits[name] = quantity
+		}
+	}
+}
+
+const (
+	keyPluginEcosystemPrefix             = "ecosystem."
+	keyPluginEcosystemNamespaceOverrides = "ecosystem.namespaceOverrides"
+)
+
+// Ecosystems are toggles of package ecosystems, such as os, jar, npm, pip, or
+// gobinary, of which vulnerabilities are kept in VulnerabilityReports.
+// Ecosystems that are not toggled are enabled.
+type Ecosystems map[string]bool
+
+// IsEnabled checks whether vulnerabilities of the specified ecosystem are
+// kept. Vulnerabilities of unknown ecosystems are always kept.
+func (e Ecosystems) IsEnabled(ecosystem string) bool {
+	enabled, ok := e[ecosystem]
+	return ecosystem == "" || !ok || enabled
+}
+
+// IsFiltering returns true if any ecosystem is disabled.
+func (e Ecosystems) IsFiltering() bool {
+	for _, enabled := range e {
+		if !enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEcosystems returns toggles of ecosystems of vulnerabilities of objects in
+// the specified namespace. Toggles are read from ecosystem.<name> keys, e.g.
+// ecosystem.jar: "false", and overridden by the values for the namespace in
+// the JSON representation of the ecosystem.namespaceOverrides key, e.g.
+// `{"payments":{"jar":true,"os":false}}`.
+func (c PluginConfig) GetEcosystems(namespace string) (Ecosystems, error) {
+	ecosystems := make(Ecosystems)
+	for key, value := range c.Data {
+		if !strings.HasPrefix(key, keyPluginEcosystemPrefix) || key == keyPluginEcosystemNamespaceOverrides {
+			continue
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", key, err)
+		}
+		ecosystems[strings.TrimPrefix(key, keyPluginEcosystemPrefix)] = enabled
+	}
+
+	value := strings.TrimSpace(c.Data[keyPluginEcosystemNamespaceOverrides])
+	if value == "" {
+		return ecosystems, nil
+	}
+	var namespaceOverrides map[string]Ecosystems
+	err := json.Unmarshal([]byte(value), &namespaceOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", keyPluginEcosystemNamespaceOverrides, err)
+	}
+	for ecosystem, enabled := range namespaceOverrides[namespace] {
+		ecosystems[ecosystem] = enabled
+	}
+	return ecosystems, nil
+}
+
 // PluginContext is plugin's execution context within the Starboard toolkit.
 // The context is used to grant access to other methods so that this plugin
 // can interact with the toolkit.
@@ -46,6 +186,14 @@ type PluginContext interface {
 	GetServiceAccountName() string
 }
 
+// PluginConfigValidator is implemented by plugins that validate their
+// configuration settings, so that mistakes are reported before scan jobs fail.
+type PluginConfigValidator interface {
+	// ValidateConfig returns problems with the specified configuration
+	// settings, or nil if the settings are valid.
+	ValidateConfig(config PluginConfig) []error
+}
+
 // GetPluginConfigMapName returns the name of a ConfigMap used to configure a plugin
 // with the given name.
 // TODO Rename to GetPluginConfigObjectName as this method is used to determine the name of ConfigMaps and Secrets.
@@ -2,10 +2,12 @@ package starboard_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -95,3 +97,141 @@ func TestPluginContext_GetConfig(t *testing.T) {
 			}))
 	})
 }
+
+func TestPluginConfig_GetScanJobOverride(t *testing.T) {
+	config := starboard.PluginConfig{
+		Data: map[string]string{
+			"scanJob.timeout":            "10m",
+			"scanJob.namespaceOverrides": `{"ml":{"timeout":"30m","resources":{"limits":{"memory":"8Gi"}}},"web":{"resources":{"requests":{"cpu":"50m"}}}}`,
+		},
+	}
+
+	t.Run("Should return plugin timeout", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		override, err := config.GetScanJobOverride("default")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(override.GetTimeout(5 * time.Minute)).To(gomega.Equal(10 * time.Minute))
+		g.Expect(override.Resources).To(gomega.Equal(corev1.ResourceRequirements{}))
+	})
+
+	t.Run("Should return namespace override", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		override, err := config.GetScanJobOverride("ml")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(override.GetTimeout(5 * time.Minute)).To(gomega.Equal(30 * time.Minute))
+		g.Expect(override.Resources.Limits).To(gomega.Equal(corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("8Gi"),
+		}))
+
+		override, err = config.GetScanJobOverride("web")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(override.GetTimeout(5 * time.Minute)).To(gomega.Equal(10 * time.Minute))
+	})
+
+	t.Run("Should return default timeout", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		override, err := starboard.PluginConfig{}.GetScanJobOverride("default")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(override.GetTimeout(5 * time.Minute)).To(gomega.Equal(5 * time.Minute))
+	})
+
+	t.Run("Should return error when timeout is invalid", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		_, err := starboard.PluginConfig{Data: map[string]string{
+			"scanJob.timeout": "ten minutes",
+		}}.GetScanJobOverride("default")
+		g.Expect(err).To(gomega.MatchError(`parsing scanJob.timeout: time: invalid duration "ten minutes"`))
+	})
+}
+
+func TestPluginConfig_GetEcosystems(t *testing.T) {
+	config := starboard.PluginConfig{
+		Data: map[string]string{
+			"ecosystem.jar":                "false",
+			"ecosystem.npm":                "true",
+			"ecosystem.namespaceOverrides": `{"payments":{"jar":true,"os":false}}`,
+		},
+	}
+
+	t.Run("Should return plugin ecosystems", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		ecosystems, err := config.GetEcosystems("default")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(ecosystems).To(gomega.Equal(starboard.Ecosystems{"jar": false, "npm": true}))
+		g.Expect(ecosystems.IsFiltering()).To(gomega.BeTrue())
+		g.Expect(ecosystems.IsEnabled("jar")).To(gomega.BeFalse())
+		g.Expect(ecosystems.IsEnabled("os")).To(gomega.BeTrue())
+		g.Expect(ecosystems.IsEnabled("")).To(gomega.BeTrue())
+	})
+
+	t.Run("Should return namespace override", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		ecosystems, err := config.GetEcosystems("payments")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(ecosystems).To(gomega.Equal(starboard.Ecosystems{"jar": true, "npm": true, "os": false}))
+		g.Expect(ecosystems.IsEnabled("jar")).To(gomega.BeTrue())
+		g.Expect(ecosystems.IsEnabled("os")).To(gomega.BeFalse())
+	})
+
+	t.Run("Should return no toggles", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		ecosystems, err := starboard.PluginConfig{}.GetEcosystems("default")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(ecosystems.IsFiltering()).To(gomega.BeFalse())
+	})
+
+	t.Run("Should return error when toggle is invalid", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		_, err := starboard.PluginConfig{Data: map[string]string{
+			"ecosystem.pip": "off",
+		}}.GetEcosystems("default")
+		g.Expect(err).To(gomega.MatchError(`parsing ecosystem.pip: strconv.ParseBool: parsing "off": invalid syntax`))
+	})
+}
+
+func TestScanJobOverride_ApplyTo(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	override := starboard.ScanJobOverride{
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+	spec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "nginx",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("100M"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("500M"),
+					},
+				},
+			},
+			{
+				Name: "sidecar",
+			},
+		},
+	}
+	override.ApplyTo(&spec)
+	g.Expect(spec.Containers[0].Resources).To(gomega.Equal(corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("100M"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("8Gi"),
+		},
+	}))
+	g.Expect(spec.Containers[1].Resources).To(gomega.Equal(corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("8Gi"),
+		},
+	}))
+}
@@ -0,0 +1,146 @@
+package summaryapi
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	headerRemoteUser        = "X-Remote-User"
+	headerRemoteGroup       = "X-Remote-Group"
+	headerRemoteExtraPrefix = "X-Remote-Extra-"
+)
+
+// Auth authenticates and authorizes callers of the API.
+type Auth interface {
+	// Authenticate returns the user on behalf of whom the request was proxied
+	// by the Kubernetes API server, or false if the request wasn't sent by
+	// the API server.
+	Authenticate(req *http.Request) (authenticationv1.UserInfo, bool)
+	// Authorize returns true if the user is allowed to perform the verb on
+	// WorkloadVulnerabilitySummaries in the namespace, or in all namespaces if
+	// the namespace is empty.
+	Authorize(ctx context.Context, user authenticationv1.UserInfo, verb, namespace string) (bool, error)
+}
+
+// RequestHeaderConfig is the configuration of the front proxy of the
+// Kubernetes API server, which is published in the
+// kube-system/extension-apiserver-authentication ConfigMap.
+type RequestHeaderConfig struct {
+	// ClientCAs verify client certificates of the front proxy.
+	ClientCAs *x509.CertPool
+	// AllowedNames are common names of client certificates of the front
+	// proxy. If empty, any certificate verified by ClientCAs is allowed.
+	AllowedNames []string
+}
+
+// GetRequestHeaderConfig reads the RequestHeaderConfig of the cluster.
+func GetRequestHeaderConfig(ctx context.Context, clientset kubernetes.Interface) (RequestHeaderConfig, error) {
+	cm, err := clientset.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(ctx, "extension-apiserver-authentication", metav1.GetOptions{})
+	if err != nil {
+		return RequestHeaderConfig{}, fmt.Errorf("getting request header config: %w", err)
+	}
+	config := RequestHeaderConfig{ClientCAs: x509.NewCertPool()}
+	if !config.ClientCAs.AppendCertsFromPEM([]byte(cm.Data["requestheader-client-ca-file"])) {
+		return RequestHeaderConfig{}, fmt.Errorf("request header client CA not found")
+	}
+	if value := cm.Data["requestheader-allowed-names"]; value != "" {
+		err = json.Unmarshal([]byte(value), &config.AllowedNames)
+		if err != nil {
+			return RequestHeaderConfig{}, fmt.Errorf("parsing request header allowed names: %w", err)
+		}
+	}
+	return config, nil
+}
+
+type kubeAuth struct {
+	clientset    kubernetes.Interface
+	allowedNames []string
+}
+
+// NewKubeAuth constructs a new Auth, which authenticates requests proxied by
+// the front proxy with the specified allowed names, and checks whether users
+// are allowed to read summaries with SubjectAccessReviews.
+//
+// Client certificates must be verified by the TLS config of the server,
+// e.g. with RequestHeaderConfig.ClientCAs.
+func NewKubeAuth(clientset kubernetes.Interface, allowedNames []string) Auth {
+	return &kubeAuth{clientset: clientset, allowedNames: allowedNames}
+}
+
+func (a *kubeAuth) Authenticate(req *http.Request) (authenticationv1.UserInfo, bool) {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		return authenticationv1.UserInfo{}, false
+	}
+	if len(a.allowedNames) > 0 {
+		commonName := req.TLS.VerifiedChains[0][0].Subject.CommonName
+		allowed := false
+		for _, name := range a.allowedNames {
+			if name == commonName {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return authenticationv1.UserInfo{}, false
+		}
+	}
+	return getRemoteUser(req)
+}
+
+// getRemoteUser returns the user identified by the headers set by the front
+// proxy.
+func getRemoteUser(req *http.Request) (authenticationv1.UserInfo, bool) {
+	user := authenticationv1.UserInfo{
+		Username: req.Header.Get(headerRemoteUser),
+		Groups:   req.Header.Values(headerRemoteGroup),
+	}
+	if user.Username == "" {
+		return authenticationv1.UserInfo{}, false
+	}
+	for header, values := range req.Header {
+		if !strings.HasPrefix(header, headerRemoteExtraPrefix) {
+			continue
+		}
+		if user.Extra == nil {
+			user.Extra = make(map[string]authenticationv1.ExtraValue)
+		}
+		key := strings.ToLower(strings.TrimPrefix(header, headerRemoteExtraPrefix))
+		user.Extra[key] = append(user.Extra[key], values...)
+	}
+	return user, true
+}
+
+func (a *kubeAuth) Authorize(ctx context.Context, user authenticationv1.UserInfo, verb, namespace string) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review, err := a.clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     GroupName,
+				Version:   Version,
+				Resource:  WorkloadVulnerabilitySummaryResource,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("reviewing access: %w", err)
+	}
+	return review.Status.Allowed, nil
+}
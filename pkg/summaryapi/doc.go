@@ -0,0 +1,10 @@
+// Package summaryapi provides an aggregated API of virtual resources, such as
+// WorkloadVulnerabilitySummaries, which are computed on demand from reports
+// instead of being stored in etcd.
+//
+// The API is registered with the Kubernetes API server by an APIService,
+// hence kubectl and other clients read it the same way as built-in
+// resources. The API server authenticates clients and proxies their requests
+// with a client certificate of its front proxy and headers that identify the
+// user, and the API authorizes the user with SubjectAccessReviews.
+package summaryapi
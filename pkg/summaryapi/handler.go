@@ -0,0 +1,237 @@
+package summaryapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+type handler struct {
+	logger     logr.Logger
+	summarizer *Summarizer
+	auth       Auth
+	clock      ext.Clock
+}
+
+// NewHandler constructs a new http.Handler that serves the API group of
+// virtual resources to callers allowed by auth.
+//
+// Besides discovery documents of the group, it serves get and list requests
+// of WorkloadVulnerabilitySummaries computed by the summarizer. Lists are
+// returned as tables if clients, such as kubectl get, ask for them.
+func NewHandler(logger logr.Logger, summarizer *Summarizer, auth Auth, clock ext.Clock) http.Handler {
+	return &handler{logger: logger, summarizer: summarizer, auth: auth, clock: clock}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeStatus(w, http.StatusMethodNotAllowed, metav1.StatusReasonMethodNotAllowed, "method not allowed")
+		return
+	}
+	user, ok := h.auth.Authenticate(req)
+	if !ok {
+		writeStatus(w, http.StatusUnauthorized, metav1.StatusReasonUnauthorized, "unauthorized")
+		return
+	}
+
+	groupVersionPath := "/apis/" + SchemeGroupVersion.String()
+	switch path := strings.TrimSuffix(req.URL.Path, "/"); path {
+	case "/apis":
+		writeJSON(w, http.StatusOK, &metav1.APIGroupList{
+			TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"},
+			Groups:   []metav1.APIGroup{apiGroup()},
+		})
+	case "/apis/" + GroupName:
+		group := apiGroup()
+		group.TypeMeta = metav1.TypeMeta{Kind: "APIGroup", APIVersion: "v1"}
+		writeJSON(w, http.StatusOK, &group)
+	case groupVersionPath:
+		writeJSON(w, http.StatusOK, &metav1.APIResourceList{
+			TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+			GroupVersion: SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{
+					Name:       WorkloadVulnerabilitySummaryResource,
+					Namespaced: true,
+					Kind:       WorkloadVulnerabilitySummaryKind,
+					Verbs:      metav1.Verbs{"get", "list"},
+					ShortNames: []string{"vulnsummary"},
+				},
+			},
+		})
+	default:
+		namespace, name, ok := parseResourcePath(strings.TrimPrefix(path, groupVersionPath))
+		if !strings.HasPrefix(path, groupVersionPath+"/") || !ok {
+			writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, "the server could not find the requested resource")
+			return
+		}
+		h.serveSummaries(w, req, user, namespace, name)
+	}
+}
+
+// parseResourcePath returns the namespace and the name of the path of
+// WorkloadVulnerabilitySummaries relative to the group version, or false if
+// the path doesn't refer to them.
+func parseResourcePath(path string) (namespace, name string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		namespace = parts[1]
+		parts = parts[2:]
+	}
+	if parts[0] != WorkloadVulnerabilitySummaryResource || len(parts) > 2 {
+		return "", "", false
+	}
+	if len(parts) == 2 {
+		if namespace == "" {
+			return "", "", false
+		}
+		name = parts[1]
+	}
+	return namespace, name, true
+}
+
+func (h *handler) serveSummaries(w http.ResponseWriter, req *http.Request, user authenticationv1.UserInfo, namespace, name string) {
+	ctx := req.Context()
+	verb := "list"
+	if name != "" {
+		verb = "get"
+	}
+	allowed, err := h.auth.Authorize(ctx, user, verb, namespace)
+	if err != nil {
+		h.internalError(w, err)
+		return
+	}
+	if !allowed {
+		message := fmt.Sprintf("%s.%s is forbidden: User %q cannot %s resource %q in API group %q",
+			WorkloadVulnerabilitySummaryResource, GroupName, user.Username, verb, WorkloadVulnerabilitySummaryResource, GroupName)
+		if namespace != "" {
+			message += fmt.Sprintf(" in the namespace %q", namespace)
+		}
+		writeStatus(w, http.StatusForbidden, metav1.StatusReasonForbidden, message)
+		return
+	}
+
+	var items []WorkloadVulnerabilitySummary
+	if name != "" {
+		summary, found, err := h.summarizer.Get(ctx, namespace, name)
+		if err != nil {
+			h.internalError(w, err)
+			return
+		}
+		if !found {
+			writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound,
+				fmt.Sprintf("%s.%s %q not found", WorkloadVulnerabilitySummaryResource, GroupName, name))
+			return
+		}
+		if !acceptsTable(req) {
+			writeJSON(w, http.StatusOK, &summary)
+			return
+		}
+		items = []WorkloadVulnerabilitySummary{summary}
+	} else {
+		items, err = h.summarizer.List(ctx, namespace)
+		if err != nil {
+			h.internalError(w, err)
+			return
+		}
+	}
+
+	if acceptsTable(req) {
+		writeJSON(w, http.StatusOK, h.newTable(items))
+		return
+	}
+	writeJSON(w, http.StatusOK, &WorkloadVulnerabilitySummaryList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: SchemeGroupVersion.String(),
+			Kind:       WorkloadVulnerabilitySummaryListKind,
+		},
+		Items: items,
+	})
+}
+
+// acceptsTable returns true if the client asked for the response as a table,
+// e.g. with the application/json;as=Table;v=v1;g=meta.k8s.io media type.
+func acceptsTable(req *http.Request) bool {
+	return strings.Contains(req.Header.Get("Accept"), "as=Table")
+}
+
+func (h *handler) newTable(items []WorkloadVulnerabilitySummary) *metav1.Table {
+	table := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{Kind: "Table", APIVersion: "meta.k8s.io/v1"},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string", Format: "name"},
+			{Name: "Kind", Type: "string", Priority: 1},
+			{Name: "Workload", Type: "string", Priority: 1},
+			{Name: "Critical", Type: "integer"},
+			{Name: "High", Type: "integer"},
+			{Name: "Medium", Type: "integer"},
+			{Name: "Low", Type: "integer"},
+			{Name: "Unknown", Type: "integer"},
+			{Name: "Age", Type: "string"},
+		},
+		Rows: []metav1.TableRow{},
+	}
+	for _, item := range items {
+		// Rows include metadata of items, which kubectl uses to print their
+		// namespaces when listing summaries in all namespaces.
+		object, _ := json.Marshal(&metav1.PartialObjectMetadata{
+			TypeMeta:   metav1.TypeMeta{Kind: "PartialObjectMetadata", APIVersion: "meta.k8s.io/v1"},
+			ObjectMeta: item.ObjectMeta,
+		})
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []interface{}{
+				item.Name,
+				item.Workload.Kind,
+				item.Workload.Name,
+				item.Summary.CriticalCount,
+				item.Summary.HighCount,
+				item.Summary.MediumCount,
+				item.Summary.LowCount,
+				item.Summary.UnknownCount,
+				duration.HumanDuration(h.clock.Now().Sub(item.CreationTimestamp.Time)),
+			},
+			Object: runtime.RawExtension{Raw: object},
+		})
+	}
+	return table
+}
+
+func apiGroup() metav1.APIGroup {
+	version := metav1.GroupVersionForDiscovery{GroupVersion: SchemeGroupVersion.String(), Version: Version}
+	return metav1.APIGroup{
+		Name:             GroupName,
+		Versions:         []metav1.GroupVersionForDiscovery{version},
+		PreferredVersion: version,
+	}
+}
+
+func (h *handler) internalError(w http.ResponseWriter, err error) {
+	h.logger.Error(err, "Unable to serve summaries")
+	writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, "internal error")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeStatus writes the error as the Status object, which clients of the
+// Kubernetes API print as the message of the error.
+func writeStatus(w http.ResponseWriter, code int, reason metav1.StatusReason, message string) {
+	writeJSON(w, code, &metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  message,
+		Reason:   reason,
+		Code:     int32(code),
+	})
+}
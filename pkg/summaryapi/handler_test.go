@@ -0,0 +1,148 @@
+package summaryapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/summaryapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// fakeAuth authenticates the user of the X-Remote-User header, and allows
+// the "dev" user to read summaries in the default namespace.
+type fakeAuth struct{}
+
+func (a *fakeAuth) Authenticate(req *http.Request) (authenticationv1.UserInfo, bool) {
+	username := req.Header.Get("X-Remote-User")
+	return authenticationv1.UserInfo{Username: username}, username != ""
+}
+
+func (a *fakeAuth) Authorize(_ context.Context, user authenticationv1.UserInfo, _, namespace string) (bool, error) {
+	return user.Username == "dev" && namespace == "default", nil
+}
+
+func TestHandler(t *testing.T) {
+	now := time.Date(2022, 3, 10, 12, 0, 0, 0, time.UTC)
+	newReport := func(namespace, workload, container string, summary v1alpha1.VulnerabilitySummary) *v1alpha1.VulnerabilityReport {
+		return &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         namespace,
+				Name:              "replicaset-" + workload + "-" + container,
+				CreationTimestamp: metav1.NewTime(now.Add(-48 * time.Hour)),
+				Labels: map[string]string{
+					starboard.LabelResourceKind:  "ReplicaSet",
+					starboard.LabelResourceName:  workload,
+					starboard.LabelContainerName: container,
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Artifact: v1alpha1.Artifact{Repository: "library/" + container, Tag: "1.16"},
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Summary:  summary,
+			},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		newReport("default", "nginx-6d4cf56db6", "nginx", v1alpha1.VulnerabilitySummary{CriticalCount: 1, LowCount: 1}),
+		newReport("default", "nginx-6d4cf56db6", "sidecar", v1alpha1.VulnerabilitySummary{HighCount: 2}),
+		newReport("default", "app-5fbc9cc6b9", "app", v1alpha1.VulnerabilitySummary{LowCount: 3}),
+		newReport("kube-system", "coredns-7848d4b86f", "coredns", v1alpha1.VulnerabilitySummary{CriticalCount: 1}),
+	).Build()
+	handler := summaryapi.NewHandler(log.Log, summaryapi.NewSummarizer(c, c), &fakeAuth{}, ext.NewFixedClock(now))
+
+	get := func(t *testing.T, user, path, accept string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if user != "" {
+			req.Header.Set("X-Remote-User", user)
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	basePath := "/apis/summary.aquasecurity.github.io/v1alpha1"
+
+	t.Run("Should reject requests that weren't proxied by the API server", func(t *testing.T) {
+		rec := get(t, "", basePath+"/namespaces/default/workloadvulnerabilitysummaries", "")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("Should serve discovery of the API group", func(t *testing.T) {
+		rec := get(t, "dev", basePath, "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resources metav1.APIResourceList
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resources))
+		require.Len(t, resources.APIResources, 1)
+		assert.Equal(t, "workloadvulnerabilitysummaries", resources.APIResources[0].Name)
+		assert.True(t, resources.APIResources[0].Namespaced)
+	})
+
+	t.Run("Should reject requests for forbidden namespace", func(t *testing.T) {
+		rec := get(t, "dev", basePath+"/namespaces/kube-system/workloadvulnerabilitysummaries", "")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		rec = get(t, "dev", basePath+"/workloadvulnerabilitysummaries", "")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("Should list summaries of workloads", func(t *testing.T) {
+		rec := get(t, "dev", basePath+"/namespaces/default/workloadvulnerabilitysummaries", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var list summaryapi.WorkloadVulnerabilitySummaryList
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&list))
+		require.Len(t, list.Items, 2)
+		assert.Equal(t, "replicaset-app-5fbc9cc6b9", list.Items[0].Name)
+		assert.Equal(t, "replicaset-nginx-6d4cf56db6", list.Items[1].Name)
+		assert.Equal(t, summaryapi.Workload{Kind: "ReplicaSet", Name: "nginx-6d4cf56db6"}, list.Items[1].Workload)
+		assert.Equal(t, v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 2, LowCount: 1}, list.Items[1].Summary)
+		require.Len(t, list.Items[1].Containers, 2)
+		assert.Equal(t, "nginx", list.Items[1].Containers[0].Name)
+		assert.Equal(t, "index.docker.io/library/nginx:1.16", list.Items[1].Containers[0].Image)
+	})
+
+	t.Run("Should get summary by name", func(t *testing.T) {
+		rec := get(t, "dev", basePath+"/namespaces/default/workloadvulnerabilitysummaries/replicaset-app-5fbc9cc6b9", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var summary summaryapi.WorkloadVulnerabilitySummary
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&summary))
+		assert.Equal(t, summaryapi.WorkloadVulnerabilitySummaryKind, summary.Kind)
+		assert.Equal(t, v1alpha1.VulnerabilitySummary{LowCount: 3}, summary.Summary)
+
+		rec = get(t, "dev", basePath+"/namespaces/default/workloadvulnerabilitysummaries/replicaset-unknown", "")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Should return table if client asks for it", func(t *testing.T) {
+		rec := get(t, "dev", basePath+"/namespaces/default/workloadvulnerabilitysummaries",
+			"application/json;as=Table;v=v1;g=meta.k8s.io,application/json")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var table metav1.Table
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&table))
+		require.Len(t, table.Rows, 2)
+		assert.Equal(t, "replicaset-app-5fbc9cc6b9", table.Rows[0].Cells[0])
+		assert.Equal(t, "2d", table.Rows[0].Cells[len(table.Rows[0].Cells)-1])
+	})
+
+	t.Run("Should reject unsupported methods", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, basePath+"/namespaces/default/workloadvulnerabilitysummaries", strings.NewReader("{}"))
+		req.Header.Set("X-Remote-User", "dev")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
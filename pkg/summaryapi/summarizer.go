@@ -0,0 +1,197 @@
+package summaryapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Summarizer computes WorkloadVulnerabilitySummaries from VulnerabilityReports.
+//
+// Reports are listed as metadata from the informer cache, and only their
+// summaries are kept in memory. Full reports are read from the API server
+// when reports in the listed namespace were created or updated since the last
+// time they were read.
+type Summarizer struct {
+	cache  client.Reader
+	reader client.Reader
+
+	mu      sync.Mutex
+	reports map[types.NamespacedName]reportSummary
+}
+
+// reportSummary is the part of a VulnerabilityReport that is used by
+// summaries and not available in its metadata.
+type reportSummary struct {
+	resourceVersion string
+	image           string
+	updateTimestamp metav1.Time
+	summary         v1alpha1.VulnerabilitySummary
+}
+
+// NewSummarizer constructs a new Summarizer, which reads metadata of reports
+// from the specified cache and full reports with the specified reader.
+func NewSummarizer(cache, reader client.Reader) *Summarizer {
+	return &Summarizer{
+		cache:   cache,
+		reader:  reader,
+		reports: make(map[types.NamespacedName]reportSummary),
+	}
+}
+
+// List returns summaries of workloads in the namespace, or in all namespaces
+// if the namespace is empty, sorted by namespaces and names.
+func (s *Summarizer) List(ctx context.Context, namespace string) ([]WorkloadVulnerabilitySummary, error) {
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.VulnerabilityReportListKind))
+	err := s.cache.List(ctx, list, client.InNamespace(namespace))
+	if err != nil {
+		return nil, fmt.Errorf("listing report metadata: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err = s.refresh(ctx, namespace, list.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[types.NamespacedName]*WorkloadVulnerabilitySummary)
+	for _, metadata := range list.Items {
+		report, ok := s.reports[types.NamespacedName{Namespace: metadata.Namespace, Name: metadata.Name}]
+		kind := metadata.Labels[starboard.LabelResourceKind]
+		name := metadata.Labels[starboard.LabelResourceName]
+		if !ok || kind == "" || name == "" {
+			continue
+		}
+		key := types.NamespacedName{Namespace: metadata.Namespace, Name: GetSummaryName(kind, name)}
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &WorkloadVulnerabilitySummary{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: SchemeGroupVersion.String(),
+					Kind:       WorkloadVulnerabilitySummaryKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         key.Namespace,
+					Name:              key.Name,
+					CreationTimestamp: metadata.CreationTimestamp,
+				},
+				Workload:   Workload{Kind: kind, Name: name},
+				Containers: []ContainerVulnerabilitySummary{},
+			}
+			summaries[key] = summary
+		}
+		if metadata.CreationTimestamp.Before(&summary.CreationTimestamp) {
+			summary.CreationTimestamp = metadata.CreationTimestamp
+		}
+		if summary.UpdateTimestamp.Before(&report.updateTimestamp) {
+			summary.UpdateTimestamp = report.updateTimestamp
+		}
+		summary.Summary = addSummaries(summary.Summary, report.summary)
+		summary.Containers = append(summary.Containers, ContainerVulnerabilitySummary{
+			Name:            metadata.Labels[starboard.LabelContainerName],
+			Image:           report.image,
+			UpdateTimestamp: report.updateTimestamp,
+			Summary:         report.summary,
+		})
+	}
+
+	result := make([]WorkloadVulnerabilitySummary, 0, len(summaries))
+	for _, summary := range summaries {
+		sort.Slice(summary.Containers, func(i, j int) bool {
+			return summary.Containers[i].Name < summary.Containers[j].Name
+		})
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// Get returns the summary with the specified name in the namespace, or false
+// if the workload doesn't have any VulnerabilityReports.
+func (s *Summarizer) Get(ctx context.Context, namespace, name string) (WorkloadVulnerabilitySummary, bool, error) {
+	summaries, err := s.List(ctx, namespace)
+	if err != nil {
+		return WorkloadVulnerabilitySummary{}, false, err
+	}
+	for _, summary := range summaries {
+		if summary.Name == name {
+			return summary, true, nil
+		}
+	}
+	return WorkloadVulnerabilitySummary{}, false, nil
+}
+
+// refresh reads full reports in the namespace if summaries of any of the
+// listed reports are missing or outdated, and forgets summaries of reports
+// in the namespace that were deleted.
+func (s *Summarizer) refresh(ctx context.Context, namespace string, items []metav1.PartialObjectMetadata) error {
+	listed := make(map[types.NamespacedName]bool, len(items))
+	outdated := false
+	for _, metadata := range items {
+		key := types.NamespacedName{Namespace: metadata.Namespace, Name: metadata.Name}
+		listed[key] = true
+		if report, ok := s.reports[key]; !ok || report.resourceVersion != metadata.ResourceVersion {
+			outdated = true
+		}
+	}
+	for key := range s.reports {
+		if (namespace == "" || key.Namespace == namespace) && !listed[key] {
+			delete(s.reports, key)
+		}
+	}
+	if !outdated {
+		return nil
+	}
+
+	var list v1alpha1.VulnerabilityReportList
+	err := s.reader.List(ctx, &list, client.InNamespace(namespace))
+	if err != nil {
+		return fmt.Errorf("listing reports: %w", err)
+	}
+	for _, report := range list.Items {
+		key := types.NamespacedName{Namespace: report.Namespace, Name: report.Name}
+		if !listed[key] {
+			continue
+		}
+		s.reports[key] = reportSummary{
+			resourceVersion: report.ResourceVersion,
+			image:           vulnerabilityreport.GetImageRef(report.Report),
+			updateTimestamp: report.Report.UpdateTimestamp,
+			summary:         report.Report.Summary,
+		}
+	}
+	return nil
+}
+
+// GetSummaryName returns the name of the WorkloadVulnerabilitySummary of the
+// workload with the specified kind and name.
+func GetSummaryName(kind, name string) string {
+	return strings.ToLower(kind) + "-" + name
+}
+
+func addSummaries(a, b v1alpha1.VulnerabilitySummary) v1alpha1.VulnerabilitySummary {
+	return v1alpha1.VulnerabilitySummary{
+		CriticalCount: a.CriticalCount + b.CriticalCount,
+		HighCount:     a.HighCount + b.HighCount,
+		MediumCount:   a.MediumCount + b.MediumCount,
+		LowCount:      a.LowCount + b.LowCount,
+		UnknownCount:  a.UnknownCount + b.UnknownCount,
+		NoneCount:     a.NoneCount + b.NoneCount,
+	}
+}
@@ -0,0 +1,66 @@
+package summaryapi
+
+import (
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// GroupName is the name of the API group of virtual resources, which must
+	// differ from the group of custom resources of reports.
+	GroupName = "summary.aquasecurity.github.io"
+	// Version is the version of the API group.
+	Version = "v1alpha1"
+)
+
+// SchemeGroupVersion is the group version of virtual resources.
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: Version}
+
+const (
+	WorkloadVulnerabilitySummaryKind     = "WorkloadVulnerabilitySummary"
+	WorkloadVulnerabilitySummaryListKind = "WorkloadVulnerabilitySummaryList"
+	// WorkloadVulnerabilitySummaryResource is the plural resource name of
+	// WorkloadVulnerabilitySummaries, which is used in URLs and RBAC rules.
+	WorkloadVulnerabilitySummaryResource = "workloadvulnerabilitysummaries"
+)
+
+// Workload identifies the workload of a summary.
+type Workload struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// ContainerVulnerabilitySummary is the summary of the VulnerabilityReport of
+// a container of a workload.
+type ContainerVulnerabilitySummary struct {
+	Name            string                        `json:"name"`
+	Image           string                        `json:"image"`
+	UpdateTimestamp metav1.Time                   `json:"updateTimestamp"`
+	Summary         v1alpha1.VulnerabilitySummary `json:"summary"`
+}
+
+// WorkloadVulnerabilitySummary is a virtual resource that sums up
+// VulnerabilityReports of all containers of a workload. Its name is the
+// lowercase kind of the workload followed by a hyphen and the name of the
+// workload, e.g. replicaset-nginx-6d4cf56db6, and its creation timestamp is
+// the creation timestamp of the oldest report.
+type WorkloadVulnerabilitySummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Workload Workload `json:"workload"`
+	// UpdateTimestamp is the latest update timestamp of reports.
+	UpdateTimestamp metav1.Time                     `json:"updateTimestamp"`
+	Summary         v1alpha1.VulnerabilitySummary   `json:"summary"`
+	Containers      []ContainerVulnerabilitySummary `json:"containers"`
+}
+
+// WorkloadVulnerabilitySummaryList is a list of WorkloadVulnerabilitySummary
+// resources.
+type WorkloadVulnerabilitySummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []WorkloadVulnerabilitySummary `json:"items"`
+}
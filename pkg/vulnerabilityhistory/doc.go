@@ -0,0 +1,4 @@
+// Package vulnerabilityhistory provides primitives for tracking when
+// vulnerabilities appear in and disappear from VulnerabilityReports of a
+// workload, which are recorded as VulnerabilityHistory resources.
+package vulnerabilityhistory
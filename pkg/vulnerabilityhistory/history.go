@@ -0,0 +1,165 @@
+package vulnerabilityhistory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetName returns the name of the VulnerabilityHistory of the given workload.
+func GetName(workload kube.ObjectRef) string {
+	name := fmt.Sprintf("%s-%s", strings.ToLower(string(workload.Kind)), workload.Name)
+	if len(validation.IsValidLabelValue(name)) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s-%s", strings.ToLower(string(workload.Kind)), kube.ComputeHash(workload.Name))
+}
+
+// GetOwner returns the owner reference of the VulnerabilityHistory of the
+// given owner of VulnerabilityReports. Reports of a ReplicaSet controlled by
+// a Deployment are recorded in the history of the Deployment, so that the
+// history outlives revisions of the Deployment.
+func GetOwner(reportOwner client.Object) metav1.OwnerReference {
+	if _, ok := reportOwner.(*appsv1.ReplicaSet); ok {
+		if controller := metav1.GetControllerOf(reportOwner); controller != nil && controller.Kind == string(kube.KindDeployment) {
+			return *controller
+		}
+	}
+	gvk := reportOwner.GetObjectKind().GroupVersionKind()
+	return metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       reportOwner.GetName(),
+		UID:        reportOwner.GetUID(),
+		Controller: pointer.BoolPtr(true),
+	}
+}
+
+// NewHistory returns the empty VulnerabilityHistory of the workload referenced
+// by the given owner reference in the given namespace.
+func NewHistory(owner metav1.OwnerReference, namespace string) v1alpha1.VulnerabilityHistory {
+	workload := kube.ObjectRef{Kind: kube.Kind(owner.Kind), Name: owner.Name, Namespace: namespace}
+	// We set metadata.ownerReferences[x].blockOwnerDeletion to false so that
+	// additional RBAC permissions are not required when the
+	// OwnerReferencesPermissionsEnforcement admission controller is enabled.
+	owner.BlockOwnerDeletion = pointer.BoolPtr(false)
+	return v1alpha1.VulnerabilityHistory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            GetName(workload),
+			Namespace:       namespace,
+			Labels:          kube.ObjectRefToLabels(workload),
+			OwnerReferences: []metav1.OwnerReference{owner},
+		},
+		Report: v1alpha1.VulnerabilityHistoryData{
+			Open:     []v1alpha1.VulnerabilityRecord{},
+			Resolved: []v1alpha1.VulnerabilityRecord{},
+		},
+	}
+}
+
+type recordKey struct {
+	container       string
+	vulnerabilityID string
+	resource        string
+}
+
+// Update updates the given history with vulnerabilities currently reported
+// for containers of the workload, keyed by container names. Vulnerabilities
+// that appear for the first time are recorded as open. Open vulnerabilities
+// that are no longer reported are resolved, as are open vulnerabilities of
+// containers that are not in the given list of current containers of the
+// workload. Containers that are current but don't have reported
+// vulnerabilities are left intact.
+//
+// At most maxResolved of the most recently resolved vulnerabilities are kept.
+// Update returns the vulnerabilities resolved by this update.
+func Update(data *v1alpha1.VulnerabilityHistoryData, reported map[string][]v1alpha1.Vulnerability,
+	containers []string, now metav1.Time, maxResolved int) []v1alpha1.VulnerabilityRecord {
+	current := make(map[string]bool)
+	for _, container := range containers {
+		current[container] = true
+	}
+	found := make(map[recordKey]v1alpha1.Vulnerability)
+	for container, vulnerabilities := range reported {
+		for _, vulnerability := range vulnerabilities {
+			found[recordKey{container, vulnerability.VulnerabilityID, vulnerability.Resource}] = vulnerability
+		}
+	}
+
+	open := []v1alpha1.VulnerabilityRecord{}
+	var resolved []v1alpha1.VulnerabilityRecord
+	seen := make(map[recordKey]bool)
+	for _, record := range data.Open {
+		key := recordKey{record.Container, record.VulnerabilityID, record.Resource}
+		_, isReported := reported[record.Container]
+		vulnerability, isFound := found[key]
+		switch {
+		case isFound:
+			record.Severity = vulnerability.Severity
+			open = append(open, record)
+			seen[key] = true
+		case isReported || !current[record.Container]:
+			resolvedAt := now
+			record.ResolvedAt = &resolvedAt
+			resolved = append(resolved, record)
+		default:
+			open = append(open, record)
+		}
+	}
+	for key, vulnerability := range found {
+		if seen[key] {
+			continue
+		}
+		open = append(open, v1alpha1.VulnerabilityRecord{
+			Container:       key.container,
+			VulnerabilityID: key.vulnerabilityID,
+			Resource:        key.resource,
+			Severity:        vulnerability.Severity,
+			FirstSeen:       now,
+		})
+	}
+	sortRecords(open)
+	sortRecords(resolved)
+
+	data.UpdateTimestamp = now
+	data.Open = open
+	data.Resolved = append(resolved, data.Resolved...)
+	if len(data.Resolved) > maxResolved {
+		data.Resolved = data.Resolved[:maxResolved]
+	}
+	if data.Resolved == nil {
+		data.Resolved = []v1alpha1.VulnerabilityRecord{}
+	}
+	return resolved
+}
+
+func sortRecords(records []v1alpha1.VulnerabilityRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Container != b.Container {
+			return a.Container < b.Container
+		}
+		if a.VulnerabilityID != b.VulnerabilityID {
+			return a.VulnerabilityID < b.VulnerabilityID
+		}
+		return a.Resource < b.Resource
+	})
+}
+
+// RemediationDuration returns the time it took to resolve the given
+// vulnerability, or zero if it's still open.
+func RemediationDuration(record v1alpha1.VulnerabilityRecord) time.Duration {
+	if record.ResolvedAt == nil {
+		return 0
+	}
+	return record.ResolvedAt.Sub(record.FirstSeen.Time)
+}
@@ -0,0 +1,123 @@
+package vulnerabilityhistory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityhistory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestGetName(t *testing.T) {
+	assert.Equal(t, "deployment-nginx", vulnerabilityhistory.GetName(kube.ObjectRef{
+		Kind:      kube.KindDeployment,
+		Name:      "nginx",
+		Namespace: "default",
+	}))
+}
+
+func TestGetOwner(t *testing.T) {
+	t.Run("Should return Deployment of ReplicaSet", func(t *testing.T) {
+		rs := &appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nginx-6d4cf56db6",
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       "nginx",
+					UID:        "deploy-uid",
+					Controller: pointer.BoolPtr(true),
+				}},
+			},
+		}
+		owner := vulnerabilityhistory.GetOwner(rs)
+		assert.Equal(t, "Deployment", owner.Kind)
+		assert.Equal(t, "nginx", owner.Name)
+	})
+
+	t.Run("Should return StatefulSet", func(t *testing.T) {
+		sts := &appsv1.StatefulSet{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+			ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "default", UID: "sts-uid"},
+		}
+		owner := vulnerabilityhistory.GetOwner(sts)
+		assert.Equal(t, metav1.OwnerReference{
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+			Name:       "redis",
+			UID:        "sts-uid",
+			Controller: pointer.BoolPtr(true),
+		}, owner)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	day1 := metav1.NewTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	day3 := metav1.NewTime(time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC))
+
+	history := vulnerabilityhistory.NewHistory(metav1.OwnerReference{Kind: "Deployment", Name: "nginx"}, "default")
+	data := &history.Report
+
+	resolved := vulnerabilityhistory.Update(data, map[string][]v1alpha1.Vulnerability{
+		"nginx": {
+			{VulnerabilityID: "CVE-2022-0001", Resource: "openssl", Severity: v1alpha1.SeverityHigh},
+			{VulnerabilityID: "CVE-2022-0002", Resource: "curl", Severity: v1alpha1.SeverityLow},
+		},
+		"sidecar": {
+			{VulnerabilityID: "CVE-2022-0003", Resource: "zlib", Severity: v1alpha1.SeverityCritical},
+		},
+	}, []string{"nginx", "sidecar"}, day1, 1)
+	assert.Empty(t, resolved)
+	require.Len(t, data.Open, 3)
+	assert.Equal(t, "CVE-2022-0001", data.Open[0].VulnerabilityID)
+	assert.Equal(t, day1, data.Open[0].FirstSeen)
+
+	// The scan of nginx no longer reports CVE-2022-0002 and the sidecar was
+	// removed from the workload.
+	resolved = vulnerabilityhistory.Update(data, map[string][]v1alpha1.Vulnerability{
+		"nginx": {
+			{VulnerabilityID: "CVE-2022-0001", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+			{VulnerabilityID: "CVE-2022-0004", Resource: "libxml2", Severity: v1alpha1.SeverityMedium},
+		},
+	}, []string{"nginx"}, day3, 1)
+	require.Len(t, resolved, 2)
+	assert.Equal(t, "CVE-2022-0002", resolved[0].VulnerabilityID)
+	assert.Equal(t, "CVE-2022-0003", resolved[1].VulnerabilityID)
+	assert.Equal(t, 48*time.Hour, vulnerabilityhistory.RemediationDuration(resolved[0]))
+
+	require.Len(t, data.Open, 2)
+	assert.Equal(t, v1alpha1.SeverityCritical, data.Open[0].Severity)
+	assert.Equal(t, day1, data.Open[0].FirstSeen)
+	assert.Equal(t, "CVE-2022-0004", data.Open[1].VulnerabilityID)
+	assert.Equal(t, day3, data.Open[1].FirstSeen)
+	assert.Equal(t, day3, data.UpdateTimestamp)
+
+	require.Len(t, data.Resolved, 1, "Expected resolved records to be limited")
+	assert.Equal(t, "CVE-2022-0002", data.Resolved[0].VulnerabilityID)
+}
+
+func TestUpdate_ContainerWithoutReport(t *testing.T) {
+	day1 := metav1.NewTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	day2 := metav1.NewTime(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	data := &v1alpha1.VulnerabilityHistoryData{}
+	vulnerabilityhistory.Update(data, map[string][]v1alpha1.Vulnerability{
+		"nginx": {{VulnerabilityID: "CVE-2022-0001", Resource: "openssl"}},
+	}, []string{"nginx"}, day1, 10)
+
+	resolved := vulnerabilityhistory.Update(data, map[string][]v1alpha1.Vulnerability{
+		"sidecar": {},
+	}, []string{"nginx", "sidecar"}, day2, 10)
+	assert.Empty(t, resolved)
+	require.Len(t, data.Open, 1)
+	assert.Equal(t, day1, data.Open[0].FirstSeen)
+	assert.Empty(t, data.Resolved)
+}
@@ -0,0 +1,77 @@
+package vulnerabilityhistory
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Writer is the interface that wraps the Write method.
+//
+// Write creates or updates the given v1alpha1.VulnerabilityHistory.
+type Writer interface {
+	Write(context.Context, v1alpha1.VulnerabilityHistory) error
+}
+
+// Reader is the interface that wraps the Get method.
+//
+// Get returns the v1alpha1.VulnerabilityHistory with the given name and
+// namespace or nil if the history is not found.
+type Reader interface {
+	Get(context.Context, types.NamespacedName) (*v1alpha1.VulnerabilityHistory, error)
+}
+
+type ReadWriter interface {
+	Reader
+	Writer
+}
+
+type readWriter struct {
+	client.Client
+}
+
+// NewReadWriter constructs a new ReadWriter which is using the client package
+// provided by the controller-runtime libraries for interacting with the
+// Kubernetes API server.
+func NewReadWriter(client client.Client) ReadWriter {
+	return &readWriter{
+		Client: client,
+	}
+}
+
+func (r *readWriter) Write(ctx context.Context, history v1alpha1.VulnerabilityHistory) error {
+	var existing v1alpha1.VulnerabilityHistory
+	err := r.Client.Get(ctx, types.NamespacedName{
+		Name:      history.Name,
+		Namespace: history.Namespace,
+	}, &existing)
+
+	if err == nil {
+		copied := existing.DeepCopy()
+		copied.Labels = history.Labels
+		copied.Report = history.Report
+
+		return r.Update(ctx, copied)
+	}
+
+	if errors.IsNotFound(err) {
+		return r.Create(ctx, &history)
+	}
+
+	return err
+}
+
+func (r *readWriter) Get(ctx context.Context, name types.NamespacedName) (*v1alpha1.VulnerabilityHistory, error) {
+	var history v1alpha1.VulnerabilityHistory
+	err := r.Client.Get(ctx, name, &history)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
@@ -0,0 +1,62 @@
+package vulnerabilityhistory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityhistory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewReadWriter(t *testing.T) {
+
+	kubernetesScheme := starboard.NewScheme()
+	name := types.NamespacedName{Namespace: "qa", Name: "deployment-app1"}
+
+	newHistory := func(ids ...string) *v1alpha1.VulnerabilityHistory {
+		history := vulnerabilityhistory.NewHistory(metav1.OwnerReference{Kind: "Deployment", Name: "app1"}, "qa")
+		for _, id := range ids {
+			history.Report.Open = append(history.Report.Open, v1alpha1.VulnerabilityRecord{VulnerabilityID: id})
+		}
+		return &history
+	}
+
+	t.Run("Should return nil if VulnerabilityHistory does not exist", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		found, err := vulnerabilityhistory.NewReadWriter(client).Get(context.TODO(), name)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Should create VulnerabilityHistory", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		readWriter := vulnerabilityhistory.NewReadWriter(client)
+		err := readWriter.Write(context.TODO(), *newHistory("CVE-2022-0001"))
+		require.NoError(t, err)
+
+		found, err := readWriter.Get(context.TODO(), name)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Len(t, found.Report.Open, 1)
+	})
+
+	t.Run("Should update VulnerabilityHistory", func(t *testing.T) {
+		existing := newHistory("CVE-2022-0001")
+		existing.ResourceVersion = "0"
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(existing).Build()
+		readWriter := vulnerabilityhistory.NewReadWriter(client)
+		err := readWriter.Write(context.TODO(), *newHistory("CVE-2022-0001", "CVE-2022-0002"))
+		require.NoError(t, err)
+
+		found, err := readWriter.Get(context.TODO(), name)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Len(t, found.Report.Open, 2)
+	})
+}
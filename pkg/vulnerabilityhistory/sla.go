@@ -0,0 +1,84 @@
+package vulnerabilityhistory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+// day is the unit of durations of SLA, which are specified in days.
+const day = 24 * time.Hour
+
+// SLA is the maximum time that open vulnerabilities of each severity may remain
+// in workloads. Vulnerabilities of severities that are not set have no SLA.
+type SLA map[v1alpha1.Severity]time.Duration
+
+// ParseSLA parses the comma separated list of severity=days pairs, e.g.
+// CRITICAL=7,HIGH=30.
+func ParseSLA(value string) (SLA, error) {
+	sla := SLA{}
+	if strings.TrimSpace(value) == "" {
+		return sla, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 || !isSeverity(v1alpha1.Severity(parts[0])) {
+			return nil, fmt.Errorf("invalid SLA %q; expected severity=days", pair)
+		}
+		days, err := strconv.Atoi(parts[1])
+		if err != nil || days < 0 {
+			return nil, fmt.Errorf("invalid SLA %q; expected non-negative number of days", pair)
+		}
+		sla[v1alpha1.Severity(parts[0])] = time.Duration(days) * day
+	}
+	return sla, nil
+}
+
+func isSeverity(severity v1alpha1.Severity) bool {
+	switch severity {
+	case v1alpha1.SeverityCritical, v1alpha1.SeverityHigh, v1alpha1.SeverityMedium, v1alpha1.SeverityLow,
+		v1alpha1.SeverityUnknown:
+		return true
+	}
+	return false
+}
+
+// WithDays returns the copy of the SLA whose severities are overridden by the
+// given numbers of days, e.g. by the VulnerabilitySLA of a ScanPolicy.
+func (s SLA) WithDays(days map[v1alpha1.Severity]int) SLA {
+	sla := SLA{}
+	for severity, duration := range s {
+		sla[severity] = duration
+	}
+	for severity, n := range days {
+		sla[severity] = time.Duration(n) * day
+	}
+	return sla
+}
+
+// Breaches returns open vulnerabilities of the given history that have
+// remained in the workload longer than allowed at the given time. It also
+// returns the time until the next open vulnerability breaches the SLA, or zero
+// if no other open vulnerability will.
+func (s SLA) Breaches(data v1alpha1.VulnerabilityHistoryData, now time.Time) ([]v1alpha1.VulnerabilityRecord, time.Duration) {
+	var breaches []v1alpha1.VulnerabilityRecord
+	var next time.Duration
+	for _, record := range data.Open {
+		limit, ok := s[record.Severity]
+		if !ok {
+			continue
+		}
+		remaining := record.FirstSeen.Add(limit).Sub(now)
+		if remaining < 0 {
+			breaches = append(breaches, record)
+			continue
+		}
+		if next == 0 || remaining < next {
+			next = remaining
+		}
+	}
+	return breaches, next
+}
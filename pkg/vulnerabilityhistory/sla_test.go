@@ -0,0 +1,95 @@
+package vulnerabilityhistory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityhistory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseSLA(t *testing.T) {
+	testCases := []struct {
+		value         string
+		expectedSLA   vulnerabilityhistory.SLA
+		expectedError string
+	}{
+		{
+			value:       "",
+			expectedSLA: vulnerabilityhistory.SLA{},
+		},
+		{
+			value: "CRITICAL=7, HIGH=30",
+			expectedSLA: vulnerabilityhistory.SLA{
+				v1alpha1.SeverityCritical: 7 * 24 * time.Hour,
+				v1alpha1.SeverityHigh:     30 * 24 * time.Hour,
+			},
+		},
+		{
+			value:         "critical=7",
+			expectedError: `invalid SLA "critical=7"; expected severity=days`,
+		},
+		{
+			value:         "HIGH=7d",
+			expectedError: `invalid SLA "HIGH=7d"; expected non-negative number of days`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			sla, err := vulnerabilityhistory.ParseSLA(tc.value)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSLA, sla)
+		})
+	}
+}
+
+func TestSLA_WithDays(t *testing.T) {
+	sla := vulnerabilityhistory.SLA{
+		v1alpha1.SeverityCritical: 7 * 24 * time.Hour,
+		v1alpha1.SeverityHigh:     30 * 24 * time.Hour,
+	}
+	assert.Equal(t, vulnerabilityhistory.SLA{
+		v1alpha1.SeverityCritical: 2 * 24 * time.Hour,
+		v1alpha1.SeverityHigh:     30 * 24 * time.Hour,
+		v1alpha1.SeverityMedium:   90 * 24 * time.Hour,
+	}, sla.WithDays(map[v1alpha1.Severity]int{
+		v1alpha1.SeverityCritical: 2,
+		v1alpha1.SeverityMedium:   90,
+	}))
+	assert.Equal(t, 7*24*time.Hour, sla[v1alpha1.SeverityCritical], "Expected SLA not to be modified")
+}
+
+func TestSLA_Breaches(t *testing.T) {
+	now := time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) metav1.Time {
+		return metav1.NewTime(now.Add(-time.Duration(days) * 24 * time.Hour))
+	}
+	sla := vulnerabilityhistory.SLA{
+		v1alpha1.SeverityCritical: 7 * 24 * time.Hour,
+		v1alpha1.SeverityHigh:     30 * 24 * time.Hour,
+	}
+	data := v1alpha1.VulnerabilityHistoryData{
+		Open: []v1alpha1.VulnerabilityRecord{
+			{VulnerabilityID: "CVE-2022-0001", Severity: v1alpha1.SeverityCritical, FirstSeen: daysAgo(8)},
+			{VulnerabilityID: "CVE-2022-0002", Severity: v1alpha1.SeverityCritical, FirstSeen: daysAgo(5)},
+			{VulnerabilityID: "CVE-2022-0003", Severity: v1alpha1.SeverityHigh, FirstSeen: daysAgo(1)},
+			{VulnerabilityID: "CVE-2022-0004", Severity: v1alpha1.SeverityLow, FirstSeen: daysAgo(365)},
+		},
+	}
+
+	breaches, next := sla.Breaches(data, now)
+	require.Len(t, breaches, 1)
+	assert.Equal(t, "CVE-2022-0001", breaches[0].VulnerabilityID)
+	assert.Equal(t, 2*24*time.Hour, next)
+
+	breaches, next = vulnerabilityhistory.SLA{}.Breaches(data, now)
+	assert.Empty(t, breaches)
+	assert.Equal(t, time.Duration(0), next)
+}
@@ -2,6 +2,7 @@ package vulnerabilityreport
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -27,8 +28,14 @@ type ScanJobBuilder struct {
 	object            client.Object
 	credentials       map[string]docker.Auth
 	tolerations       []corev1.Toleration
+	scheduling        starboard.ScanJobScheduling
+	sandbox           starboard.ScanJobSandbox
+	images            starboard.ScanJobImages
+	override          starboard.ScanJobOverride
 	annotations       map[string]string
 	podTemplateLabels labels.Set
+	imageFilter       ImageFilter
+	commonMetadata    starboard.CommonMetadata
 }
 
 func NewScanJobBuilder() *ScanJobBuilder {
@@ -60,6 +67,26 @@ func (s *ScanJobBuilder) WithTolerations(tolerations []corev1.Toleration) *ScanJ
 	return s
 }
 
+func (s *ScanJobBuilder) WithScheduling(scheduling starboard.ScanJobScheduling) *ScanJobBuilder {
+	s.scheduling = scheduling
+	return s
+}
+
+func (s *ScanJobBuilder) WithOverride(override starboard.ScanJobOverride) *ScanJobBuilder {
+	s.override = override
+	return s
+}
+
+func (s *ScanJobBuilder) WithSandbox(sandbox starboard.ScanJobSandbox) *ScanJobBuilder {
+	s.sandbox = sandbox
+	return s
+}
+
+func (s *ScanJobBuilder) WithImages(images starboard.ScanJobImages) *ScanJobBuilder {
+	s.images = images
+	return s
+}
+
 func (s *ScanJobBuilder) WithAnnotations(annotations map[string]string) *ScanJobBuilder {
 	s.annotations = annotations
 	return s
@@ -70,11 +97,27 @@ func (s *ScanJobBuilder) WithPodTemplateLabels(podTemplateLabels labels.Set) *Sc
 	return s
 }
 
+// WithCommonMetadata sets labels and annotations that are added to the scan
+// job and to the template of its pods unless they are already set.
+func (s *ScanJobBuilder) WithCommonMetadata(metadata starboard.CommonMetadata) *ScanJobBuilder {
+	s.commonMetadata = metadata
+	return s
+}
+
 func (s *ScanJobBuilder) WithCredentials(credentials map[string]docker.Auth) *ScanJobBuilder {
 	s.credentials = credentials
 	return s
 }
 
+// WithImageFilter sets the ImageFilter of images that are scanned. Scan
+// containers of images that are not scanned are removed from the scan job,
+// which relies on plugins naming scan containers after containers of the
+// workload.
+func (s *ScanJobBuilder) WithImageFilter(filter ImageFilter) *ScanJobBuilder {
+	s.imageFilter = filter
+	return s
+}
+
 func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 	spec, err := kube.GetPodSpec(s.object)
 	if err != nil {
@@ -86,8 +129,30 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 		return nil, nil, err
 	}
 	templateSpec.Tolerations = append(templateSpec.Tolerations, s.tolerations...)
+	s.scheduling.ApplyTo(&templateSpec)
+	s.sandbox.ApplyTo(&templateSpec)
+	s.override.ApplyTo(&templateSpec)
+
+	allContainerImages := kube.GetContainerImagesFromPodSpec(spec)
+	containerImages := s.imageFilter.Apply(allContainerImages)
+	var scanContainers []corev1.Container
+	for _, container := range templateSpec.Containers {
+		_, workloadContainer := allContainerImages[container.Name]
+		_, scanned := containerImages[container.Name]
+		if workloadContainer && !scanned {
+			continue
+		}
+		scanContainers = append(scanContainers, container)
+	}
+	templateSpec.Containers = scanContainers
 
-	containerImagesAsJSON, err := kube.GetContainerImagesFromPodSpec(spec).AsJSON()
+	var workloadImages []string
+	for _, image := range containerImages {
+		workloadImages = append(workloadImages, image)
+	}
+	s.images.ApplyTo(&templateSpec, workloadImages...)
+
+	containerImagesAsJSON, err := containerImages.AsJSON()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -119,7 +184,7 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 		Spec: batchv1.JobSpec{
 			BackoffLimit:          pointer.Int32Ptr(0),
 			Completions:           pointer.Int32Ptr(1),
-			ActiveDeadlineSeconds: kube.GetActiveDeadlineSeconds(s.timeout),
+			ActiveDeadlineSeconds: kube.GetActiveDeadlineSeconds(s.override.GetTimeout(s.timeout)),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      podTemplateLabelsSet,
@@ -140,6 +205,8 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 		return nil, nil, err
 	}
 
+	s.commonMetadata.ApplyToJob(job)
+
 	return job, secrets, nil
 }
 
@@ -151,13 +218,71 @@ func GetScanJobName(obj client.Object) string {
 	}))
 }
 
+// GetImageDigestLabelValue returns the value of the starboard.LabelImageDigest
+// label for the specified image digest. The algorithm prefix is dropped and
+// the encoded hash is truncated to the maximum length of a label value.
+func GetImageDigestLabelValue(digest string) string {
+	if i := strings.Index(digest, ":"); i >= 0 {
+		digest = digest[i+1:]
+	}
+	if len(digest) > validation.LabelValueMaxLength {
+		digest = digest[:validation.LabelValueMaxLength]
+	}
+	return digest
+}
+
+// GetVulnerabilityIDLabel returns the label of VulnerabilityReports which
+// contain the vulnerability with the specified ID, or an empty string if the
+// ID cannot be encoded as a label name.
+func GetVulnerabilityIDLabel(id string) string {
+	label := starboard.LabelVulnerabilityIDPrefix + id
+	if len(validation.IsQualifiedName(label)) > 0 {
+		return ""
+	}
+	return label
+}
+
+// GetVulnerabilityIDs returns sorted unique IDs of vulnerabilities of the
+// report with the specified labels.
+func GetVulnerabilityIDs(labels map[string]string) []string {
+	var ids []string
+	for label := range labels {
+		if strings.HasPrefix(label, starboard.LabelVulnerabilityIDPrefix) {
+			ids = append(ids, strings.TrimPrefix(label, starboard.LabelVulnerabilityIDPrefix))
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// GetImageRef returns the reference of the image scanned for the specified
+// report data, e.g. index.docker.io/library/nginx:1.16.
+func GetImageRef(data v1alpha1.VulnerabilityReportData) string {
+	ref := data.Artifact.Repository
+	if data.Registry.Server != "" {
+		ref = data.Registry.Server + "/" + ref
+	}
+	if data.Artifact.Tag != "" {
+		ref += ":" + data.Artifact.Tag
+	}
+	return ref
+}
+
 type ReportBuilder struct {
-	scheme     *runtime.Scheme
-	controller client.Object
-	container  string
-	hash       string
-	data       v1alpha1.VulnerabilityReportData
-	reportTTL  *time.Duration
+	scheme      *runtime.Scheme
+	controller  client.Object
+	container   string
+	hash        string
+	digest      string
+	scanner     string
+	data        v1alpha1.VulnerabilityReportData
+	reportTTL   *time.Duration
+	labels      map[string]string
+	policy      *v1alpha1.ScanPolicySpec
+	ecosystems  starboard.Ecosystems
+	nameFormat  starboard.ReportNameFormat
+	annotations map[string]string
+	orphaned    bool
 }
 
 func NewReportBuilder(scheme *runtime.Scheme) *ReportBuilder {
@@ -171,6 +296,14 @@ func (b *ReportBuilder) Controller(controller client.Object) *ReportBuilder {
 	return b
 }
 
+// Orphaned sets whether the report is built without the owner reference to its
+// controller, so that it's not garbage collected by Kubernetes when the
+// controller is deleted.
+func (b *ReportBuilder) Orphaned(orphaned bool) *ReportBuilder {
+	b.orphaned = orphaned
+	return b
+}
+
 func (b *ReportBuilder) Container(name string) *ReportBuilder {
 	b.container = name
 	return b
@@ -181,6 +314,19 @@ func (b *ReportBuilder) PodSpecHash(hash string) *ReportBuilder {
 	return b
 }
 
+// ImageDigest sets the digest of the scanned image, which allows reusing the
+// report for other workloads that run the same image.
+func (b *ReportBuilder) ImageDigest(digest string) *ReportBuilder {
+	b.digest = digest
+	return b
+}
+
+// Scanner sets the name of the plugin that produced the report data.
+func (b *ReportBuilder) Scanner(name string) *ReportBuilder {
+	b.scanner = name
+	return b
+}
+
 func (b *ReportBuilder) Data(data v1alpha1.VulnerabilityReportData) *ReportBuilder {
 	b.data = data
 	return b
@@ -191,44 +337,119 @@ func (b *ReportBuilder) ReportTTL(ttl *time.Duration) *ReportBuilder {
 	return b
 }
 
+// Labels sets additional labels of the report, such as the labels that
+// identify the cluster. Labels set by the builder take precedence.
+func (b *ReportBuilder) Labels(labels map[string]string) *ReportBuilder {
+	b.labels = labels
+	return b
+}
+
+// ScanPolicy sets the ScanPolicySpec of the namespace of the controller, which
+// filters vulnerabilities of the report and overrides its TTL. Reports whose
+// vulnerabilities were filtered are labeled with the hash of the policy.
+func (b *ReportBuilder) ScanPolicy(policy *v1alpha1.ScanPolicySpec) *ReportBuilder {
+	b.policy = policy
+	return b
+}
+
+// Ecosystems sets toggles of ecosystems of vulnerabilities kept in the report.
+// Reports whose vulnerabilities were filtered are labeled with the hash of the
+// toggles.
+func (b *ReportBuilder) Ecosystems(ecosystems starboard.Ecosystems) *ReportBuilder {
+	b.ecosystems = ecosystems
+	return b
+}
+
+// Annotations sets additional annotations of the report, such as the
+// annotations copied from the workload. Annotations set by the builder take
+// precedence.
+func (b *ReportBuilder) Annotations(annotations map[string]string) *ReportBuilder {
+	b.annotations = annotations
+	return b
+}
+
+// NameFormat sets the starboard.ReportNameFormat of the name of the report,
+// which defaults to starboard.ReportNameReadable.
+func (b *ReportBuilder) NameFormat(format starboard.ReportNameFormat) *ReportBuilder {
+	b.nameFormat = format
+	return b
+}
+
 func (b *ReportBuilder) reportName() string {
 	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
-	name := b.controller.GetName()
-	reportName := fmt.Sprintf("%s-%s-%s", strings.ToLower(kind), name, b.container)
-	if len(validation.IsValidLabelValue(reportName)) == 0 {
-		return reportName
-	}
-
-	return fmt.Sprintf("%s-%s", strings.ToLower(kind), kube.ComputeHash(name+"-"+b.container))
+	return kube.GetReportName(b.nameFormat, kind, b.controller.GetName(), b.container)
 }
 
 func (b *ReportBuilder) Get() (v1alpha1.VulnerabilityReport, error) {
-	labels := map[string]string{
-		starboard.LabelContainerName: b.container,
+	data := b.data
+	reportTTL := b.reportTTL
+	labels := make(map[string]string)
+	for key, value := range b.labels {
+		labels[key] = value
 	}
+	labels[starboard.LabelContainerName] = b.container
 
 	if b.hash != "" {
 		labels[starboard.LabelResourceSpecHash] = b.hash
 	}
 
+	if b.digest != "" {
+		labels[starboard.LabelImageDigest] = GetImageDigestLabelValue(b.digest)
+	}
+
+	if b.scanner != "" {
+		labels[starboard.LabelVulnerabilityReportScanner] = b.scanner
+	}
+
+	if b.ecosystems.IsFiltering() {
+		data = ApplyEcosystems(data, b.ecosystems)
+		labels[starboard.LabelEcosystemsHash] = kube.ComputeHash(b.ecosystems)
+	}
+
+	if b.policy != nil {
+		if IsFiltering(*b.policy) {
+			data = ApplyScanPolicy(data, *b.policy)
+			labels[starboard.LabelScanPolicyHash] = kube.ComputeHash(b.policy)
+		}
+		if b.policy.VulnerabilityReportTTL != nil {
+			reportTTL = &b.policy.VulnerabilityReportTTL.Duration
+		}
+	}
+
+	for _, vulnerability := range data.Vulnerabilities {
+		if label := GetVulnerabilityIDLabel(vulnerability.VulnerabilityID); label != "" {
+			labels[label] = "true"
+		}
+	}
+
 	report := v1alpha1.VulnerabilityReport{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      b.reportName(),
 			Namespace: b.controller.GetNamespace(),
 			Labels:    labels,
 		},
-		Report: b.data,
+		Report: data,
 	}
 
-	if b.reportTTL != nil {
-		report.Annotations = map[string]string{
-			v1alpha1.TTLReportAnnotation: b.reportTTL.String(),
+	if len(b.annotations) > 0 {
+		report.Annotations = make(map[string]string)
+		for key, value := range b.annotations {
+			report.Annotations[key] = value
 		}
 	}
+	if reportTTL != nil {
+		if report.Annotations == nil {
+			report.Annotations = make(map[string]string)
+		}
+		report.Annotations[v1alpha1.TTLReportAnnotation] = reportTTL.String()
+	}
 	err := kube.ObjectToObjectMetadata(b.controller, &report.ObjectMeta)
 	if err != nil {
 		return v1alpha1.VulnerabilityReport{}, err
 	}
+	if b.orphaned {
+		return report, nil
+	}
 	err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
 	if err != nil {
 		return v1alpha1.VulnerabilityReport{}, fmt.Errorf("setting controller reference: %w", err)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/onsi/gomega"
@@ -63,6 +64,134 @@ func TestReportBuilder(t *testing.T) {
 	}))
 }
 
+func TestReportBuilder_ImageDigest(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-owner",
+				Namespace: "qa",
+			},
+		}).
+		Container("my-container").
+		PodSpecHash("xyz").
+		ImageDigest("sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767").
+		Scanner("Trivy").
+		Data(v1alpha1.VulnerabilityReportData{}).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report.Labels).To(gomega.Equal(map[string]string{
+		starboard.LabelResourceKind:               "ReplicaSet",
+		starboard.LabelResourceName:               "some-owner",
+		starboard.LabelResourceNamespace:          "qa",
+		starboard.LabelContainerName:              "my-container",
+		starboard.LabelResourceSpecHash:           "xyz",
+		starboard.LabelImageDigest:                "2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e776",
+		starboard.LabelVulnerabilityReportScanner: "Trivy",
+	}))
+}
+
+func TestReportBuilder_VulnerabilityIDs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-owner",
+				Namespace: "qa",
+			},
+		}).
+		Container("my-container").
+		Data(v1alpha1.VulnerabilityReportData{
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2021-44228", Resource: "log4j-core"},
+				{VulnerabilityID: "CVE-2021-44228", Resource: "log4j-api"},
+				{VulnerabilityID: "GHSA-jfh8-c2jp-5v3q", Resource: "log4j-core"},
+				{VulnerabilityID: "not a valid label", Resource: "openssl"},
+			},
+		}).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report.Labels).To(gomega.Equal(map[string]string{
+		starboard.LabelResourceKind:                   "ReplicaSet",
+		starboard.LabelResourceName:                   "some-owner",
+		starboard.LabelResourceNamespace:              "qa",
+		starboard.LabelContainerName:                  "my-container",
+		"starboard.vulnerability.CVE-2021-44228":      "true",
+		"starboard.vulnerability.GHSA-jfh8-c2jp-5v3q": "true",
+	}))
+	g.Expect(vulnerabilityreport.GetVulnerabilityIDs(report.Labels)).
+		To(gomega.Equal([]string{"CVE-2021-44228", "GHSA-jfh8-c2jp-5v3q"}))
+}
+
+func TestReportBuilder_ScanPolicy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	hour := time.Hour
+	report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-owner",
+				Namespace: "qa",
+			},
+		}).
+		Container("my-container").
+		Data(v1alpha1.VulnerabilityReportData{
+			Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1, LowCount: 1},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2021-44228", Severity: v1alpha1.SeverityCritical},
+				{VulnerabilityID: "CVE-2022-0778", Severity: v1alpha1.SeverityLow},
+			},
+		}).
+		ReportTTL(&hour).
+		ScanPolicy(&v1alpha1.ScanPolicySpec{
+			Severities:             []v1alpha1.Severity{v1alpha1.SeverityCritical},
+			VulnerabilityReportTTL: &metav1.Duration{Duration: 24 * time.Hour},
+		}).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report.Report.Summary).To(gomega.Equal(v1alpha1.VulnerabilitySummary{CriticalCount: 1}))
+	g.Expect(report.Report.Vulnerabilities).To(gomega.HaveLen(1))
+	g.Expect(report.Labels).To(gomega.HaveKey(starboard.LabelScanPolicyHash))
+	g.Expect(report.Labels).To(gomega.HaveKey("starboard.vulnerability.CVE-2021-44228"))
+	g.Expect(report.Labels).ToNot(gomega.HaveKey("starboard.vulnerability.CVE-2022-0778"))
+	g.Expect(report.Annotations).To(gomega.Equal(map[string]string{
+		v1alpha1.TTLReportAnnotation: "24h0m0s",
+	}))
+}
+
+func TestGetImageDigestLabelValue(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(vulnerabilityreport.GetImageDigestLabelValue("sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767")).
+		To(gomega.Equal("2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e776"))
+	g.Expect(vulnerabilityreport.GetImageDigestLabelValue("sha256:2834dc50")).
+		To(gomega.Equal("2834dc50"))
+}
+
+func TestGetImageRef(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(vulnerabilityreport.GetImageRef(v1alpha1.VulnerabilityReportData{
+		Registry: v1alpha1.Registry{Server: "index.docker.io"},
+		Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+	})).To(gomega.Equal("index.docker.io/library/nginx:1.16"))
+	g.Expect(vulnerabilityreport.GetImageRef(v1alpha1.VulnerabilityReportData{
+		Artifact: v1alpha1.Artifact{Repository: "nginx"},
+	})).To(gomega.Equal("nginx"))
+}
+
 func TestScanJobBuilder(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	job, _, err := vulnerabilityreport.NewScanJobBuilder().
@@ -136,6 +265,53 @@ func TestScanJobBuilder(t *testing.T) {
 	}))
 }
 
+func TestScanJobBuilder_ImageFilter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	job, _, err := vulnerabilityreport.NewScanJobBuilder().
+		WithPlugin(&containerPlugin{}).
+		WithPluginContext(starboard.NewPluginContext().
+			WithName("test-plugin").
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			Get()).
+		WithTimeout(3 * time.Second).
+		WithObject(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nginx-6799fc88d8",
+				Namespace: "prod-ns",
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "nginx",
+								Image: "nginx:1.16",
+							},
+							{
+								Name:  "pause",
+								Image: "k8s.gcr.io/pause:3.5",
+							},
+						},
+					},
+				},
+				Selector: &metav1.LabelSelector{},
+			},
+		}).
+		WithImageFilter(vulnerabilityreport.NewImageFilter(nil, []string{"k8s.gcr.io/pause:*"})).
+		Get()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(job.Annotations).To(gomega.Equal(map[string]string{
+		starboard.AnnotationContainerImages: `{"nginx":"nginx:1.16"}`,
+	}))
+	g.Expect(job.Spec.Template.Spec.Containers).To(gomega.HaveLen(1))
+	g.Expect(job.Spec.Template.Spec.Containers[0].Name).To(gomega.Equal("nginx"))
+}
+
 type testPlugin struct {
 }
 
@@ -147,6 +323,24 @@ func (p *testPlugin) GetScanJobSpec(_ starboard.PluginContext, _ client.Object,
 	return corev1.PodSpec{}, nil, nil
 }
 
+// containerPlugin is the testPlugin that names scan containers after
+// containers of the workload.
+type containerPlugin struct {
+	testPlugin
+}
+
+func (p *containerPlugin) GetScanJobSpec(_ starboard.PluginContext, obj client.Object, _ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	spec, err := kube.GetPodSpec(obj)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	var containers []corev1.Container
+	for _, container := range spec.Containers {
+		containers = append(containers, corev1.Container{Name: container.Name})
+	}
+	return corev1.PodSpec{Containers: containers}, nil, nil
+}
+
 func (p *testPlugin) ParseVulnerabilityReportData(_ starboard.PluginContext, _ string, _ io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
 	return v1alpha1.VulnerabilityReportData{}, nil
 }
@@ -0,0 +1,128 @@
+package vulnerabilityreport
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// CacheKey identifies a scan result of the image with the specified Digest
+// produced by the specified Scanner.
+type CacheKey struct {
+	Digest string
+	// Scanner identifies the scanner and its configuration. See
+	// GetScannerCacheKey.
+	Scanner string
+}
+
+// GetScannerCacheKey returns the value of CacheKey.Scanner for the specified
+// plugin and its configuration. The configuration includes the reference of
+// the scanner image, which determines the schema version of the
+// vulnerability database, as well as settings that affect scan results.
+// Therefore, cached results are not reused after the plugin is reconfigured.
+//
+// The key doesn't identify the build of the vulnerability database, which
+// scanners such as Trivy download independently of their image. Instead, the
+// ScanResultCache compares the builds recorded in scan results.
+func GetScannerCacheKey(pluginName string, config starboard.PluginConfig) string {
+	return pluginName + "/" + kube.ComputeHash(config.Data)
+}
+
+// ScanResultCache is the interface that wraps methods for caching scan
+// results of images, so that workloads running images that have already been
+// scanned do not require new scan jobs.
+//
+// Get returns the cached v1alpha1.VulnerabilityReportData for the given key
+// and true, or false if the result is not cached, has expired, or was
+// produced with an older build of the vulnerability database than another
+// result of the same scanner.
+//
+// Put caches the given v1alpha1.VulnerabilityReportData.
+type ScanResultCache interface {
+	Get(key CacheKey) (v1alpha1.VulnerabilityReportData, bool)
+	Put(key CacheKey, data v1alpha1.VulnerabilityReportData)
+}
+
+type cacheEntry struct {
+	data      v1alpha1.VulnerabilityReportData
+	expiresAt time.Time
+}
+
+type scanResultCache struct {
+	clock   ext.Clock
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[CacheKey]cacheEntry
+	// dbUpdatedAt is the build time of the latest vulnerability database
+	// recorded in scan results of each scanner.
+	dbUpdatedAt map[string]time.Time
+}
+
+// NewScanResultCache constructs a new in-memory ScanResultCache whose
+// entries expire after the specified TTL.
+func NewScanResultCache(clock ext.Clock, ttl time.Duration) ScanResultCache {
+	return &scanResultCache{
+		clock:       clock,
+		ttl:         ttl,
+		entries:     make(map[CacheKey]cacheEntry),
+		dbUpdatedAt: make(map[string]time.Time),
+	}
+}
+
+func (c *scanResultCache) Get(key CacheKey) (v1alpha1.VulnerabilityReportData, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return v1alpha1.VulnerabilityReportData{}, false
+	}
+	if !c.clock.Now().Before(entry.expiresAt) || c.isStale(key.Scanner, entry) {
+		delete(c.entries, key)
+		return v1alpha1.VulnerabilityReportData{}, false
+	}
+	return *entry.data.DeepCopy(), true
+}
+
+// isStale checks whether the specified entry was produced with an older build
+// of the vulnerability database than the latest one recorded for the
+// specified scanner. Entries without a recorded build are stale once any build
+// is recorded, whereas entries of scanners that don't record builds expire
+// only after the TTL.
+func (c *scanResultCache) isStale(scanner string, entry cacheEntry) bool {
+	latest, ok := c.dbUpdatedAt[scanner]
+	if !ok {
+		return false
+	}
+	return getDBUpdatedAt(entry.data).Before(latest)
+}
+
+func getDBUpdatedAt(data v1alpha1.VulnerabilityReportData) time.Time {
+	if data.Scan == nil || data.Scan.DB == nil {
+		return time.Time{}
+	}
+	return data.Scan.DB.UpdatedAt.Time
+}
+
+func (c *scanResultCache) Put(key CacheKey, data v1alpha1.VulnerabilityReportData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	if updatedAt := getDBUpdatedAt(data); updatedAt.After(c.dbUpdatedAt[key.Scanner]) {
+		c.dbUpdatedAt[key.Scanner] = updatedAt
+	}
+	// Evict expired entries, so that results of images which are no longer
+	// running do not pile up.
+	for k, entry := range c.entries {
+		if !now.Before(entry.expiresAt) || c.isStale(k.Scanner, entry) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{
+		data:      *data.DeepCopy(),
+		expiresAt: now.Add(c.ttl),
+	}
+}
@@ -0,0 +1,125 @@
+package vulnerabilityreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScanResultCache(t *testing.T) {
+	clock := ext.NewFakeClock(time.Date(2022, 1, 10, 8, 0, 0, 0, time.UTC))
+	cache := vulnerabilityreport.NewScanResultCache(clock, time.Hour)
+
+	key := vulnerabilityreport.CacheKey{
+		Digest:  "sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+		Scanner: "Trivy/xyz",
+	}
+	data := v1alpha1.VulnerabilityReportData{
+		Scanner: v1alpha1.Scanner{
+			Name:    "Trivy",
+			Vendor:  "Aqua Security",
+			Version: "0.22.0",
+		},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2020-1967", Severity: v1alpha1.SeverityHigh},
+		},
+	}
+
+	t.Run("Should return false when result is not cached", func(t *testing.T) {
+		_, ok := cache.Get(key)
+		assert.False(t, ok)
+	})
+
+	cache.Put(key, data)
+
+	t.Run("Should return cached result", func(t *testing.T) {
+		cached, ok := cache.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, data, cached)
+	})
+
+	t.Run("Should return false when scanner is different", func(t *testing.T) {
+		_, ok := cache.Get(vulnerabilityreport.CacheKey{Digest: key.Digest, Scanner: "Trivy/abc"})
+		assert.False(t, ok)
+	})
+
+	t.Run("Should return false when result has expired", func(t *testing.T) {
+		clock.Step(time.Hour)
+		_, ok := cache.Get(key)
+		assert.False(t, ok)
+	})
+}
+
+func TestScanResultCache_DBUpdate(t *testing.T) {
+	clock := ext.NewFakeClock(time.Date(2022, 1, 10, 8, 0, 0, 0, time.UTC))
+	cache := vulnerabilityreport.NewScanResultCache(clock, 24*time.Hour)
+
+	newData := func(dbUpdatedAt time.Time) v1alpha1.VulnerabilityReportData {
+		return v1alpha1.VulnerabilityReportData{
+			Scanner: v1alpha1.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.22.0"},
+			Scan: &v1alpha1.ScanMetadata{
+				DB: &v1alpha1.VulnerabilityDB{Version: 2, UpdatedAt: metav1.NewTime(dbUpdatedAt)},
+			},
+		}
+	}
+	nginx := vulnerabilityreport.CacheKey{
+		Digest:  "sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+		Scanner: "Trivy/xyz",
+	}
+	redis := vulnerabilityreport.CacheKey{
+		Digest:  "sha256:9d1e9c4e8a1bb9f7d4a5b2b3e4f9e2cbbd06e6b8f1b2e5c3a7d4f6e8a9b0c1d2",
+		Scanner: "Trivy/xyz",
+	}
+	other := vulnerabilityreport.CacheKey{
+		Digest:  nginx.Digest,
+		Scanner: "Trivy/abc",
+	}
+
+	cache.Put(nginx, newData(time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)))
+	cache.Put(other, newData(time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)))
+	cache.Put(redis, v1alpha1.VulnerabilityReportData{})
+
+	t.Run("Should return results produced with the latest database", func(t *testing.T) {
+		_, ok := cache.Get(nginx)
+		assert.True(t, ok)
+	})
+
+	t.Run("Should return false for results without database once it's known", func(t *testing.T) {
+		_, ok := cache.Get(redis)
+		assert.False(t, ok)
+	})
+
+	cache.Put(redis, newData(time.Date(2022, 1, 10, 6, 0, 0, 0, time.UTC)))
+
+	t.Run("Should return false for results produced with older database", func(t *testing.T) {
+		_, ok := cache.Get(nginx)
+		assert.False(t, ok)
+		_, ok = cache.Get(redis)
+		assert.True(t, ok)
+	})
+
+	t.Run("Should return results of other scanners", func(t *testing.T) {
+		_, ok := cache.Get(other)
+		assert.True(t, ok)
+	})
+}
+
+func TestGetScannerCacheKey(t *testing.T) {
+	config := starboard.PluginConfig{
+		Data: map[string]string{
+			"trivy.imageRef": "docker.io/aquasec/trivy:0.22.0",
+			"trivy.severity": "CRITICAL,HIGH",
+		},
+	}
+	key := vulnerabilityreport.GetScannerCacheKey("Trivy", config)
+	assert.Equal(t, key, vulnerabilityreport.GetScannerCacheKey("Trivy", config))
+
+	config.Data["trivy.imageRef"] = "docker.io/aquasec/trivy:0.23.0"
+	assert.NotEqual(t, key, vulnerabilityreport.GetScannerCacheKey("Trivy", config))
+}
@@ -0,0 +1,220 @@
+package vulnerabilityreport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// Column is a column of vulnerabilities printed in the table or CSV format.
+type Column string
+
+const (
+	ColumnNamespace Column = "namespace"
+	ColumnWorkload  Column = "workload"
+	ColumnContainer Column = "container"
+	ColumnImage     Column = "image"
+	ColumnCVE       Column = "cve"
+	ColumnSeverity  Column = "severity"
+	ColumnPackage   Column = "package"
+	ColumnInstalled Column = "installed"
+	ColumnFixed     Column = "fixed"
+	ColumnTitle     Column = "title"
+	ColumnLink      Column = "link"
+	ColumnLayer     Column = "layer"
+)
+
+// Columns are all columns in the default order.
+var Columns = []Column{
+	ColumnNamespace,
+	ColumnWorkload,
+	ColumnContainer,
+	ColumnImage,
+	ColumnCVE,
+	ColumnSeverity,
+	ColumnPackage,
+	ColumnInstalled,
+	ColumnFixed,
+	ColumnTitle,
+	ColumnLink,
+	ColumnLayer,
+}
+
+// TableColumns are columns printed in the table format by default.
+var TableColumns = []Column{
+	ColumnContainer,
+	ColumnCVE,
+	ColumnSeverity,
+	ColumnPackage,
+	ColumnInstalled,
+	ColumnFixed,
+}
+
+// WideTableColumns are columns printed in the wide table format by default.
+var WideTableColumns = []Column{
+	ColumnNamespace,
+	ColumnWorkload,
+	ColumnContainer,
+	ColumnImage,
+	ColumnCVE,
+	ColumnSeverity,
+	ColumnPackage,
+	ColumnInstalled,
+	ColumnFixed,
+	ColumnTitle,
+}
+
+// ParseColumns parses the comma-separated list of column names. An empty
+// list stands for the specified default columns.
+func ParseColumns(value string, defaults []Column) ([]Column, error) {
+	if strings.TrimSpace(value) == "" {
+		return defaults, nil
+	}
+	var columns []Column
+	for _, name := range strings.Split(value, ",") {
+		column := Column(strings.ToLower(strings.TrimSpace(name)))
+		if !column.valid() {
+			return nil, fmt.Errorf("invalid column %q, allowed columns are: %s", name, strings.Join(ColumnNames(), ","))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func (c Column) valid() bool {
+	for _, column := range Columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// ColumnNames returns names of all columns in the default order.
+func ColumnNames() []string {
+	names := make([]string, len(Columns))
+	for i, column := range Columns {
+		names[i] = string(column)
+	}
+	return names
+}
+
+// WriteCSV writes the header row with the specified columns followed by a row
+// for each vulnerability in the specified reports.
+func WriteCSV(reports []v1alpha1.VulnerabilityReport, columns []Column, out io.Writer) error {
+	w := csv.NewWriter(out)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = string(column)
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		for _, vulnerability := range report.Report.Vulnerabilities {
+			row := make([]string, len(columns))
+			for i, column := range columns {
+				row[i] = columnValue(report, vulnerability, column)
+			}
+			if err := w.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// WriteTable writes the table with the specified columns and a row for each
+// vulnerability in the specified reports. Rows are sorted by severity from
+// the highest to the lowest.
+func WriteTable(reports []v1alpha1.VulnerabilityReport, columns []Column, out io.Writer) error {
+	type row struct {
+		report        *v1alpha1.VulnerabilityReport
+		vulnerability v1alpha1.Vulnerability
+	}
+	var rows []row
+	for i := range reports {
+		for _, vulnerability := range reports[i].Report.Vulnerabilities {
+			rows = append(rows, row{report: &reports[i], vulnerability: vulnerability})
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return severityOrder[rows[i].vulnerability.Severity] < severityOrder[rows[j].vulnerability.Severity]
+	})
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToUpper(string(column))
+	}
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = columnValue(*r.report, r.vulnerability, column)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(values, "\t")); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func columnValue(report v1alpha1.VulnerabilityReport, vulnerability v1alpha1.Vulnerability, column Column) string {
+	switch column {
+	case ColumnNamespace:
+		return report.Namespace
+	case ColumnWorkload:
+		kind, name := report.Labels[starboard.LabelResourceKind], report.Labels[starboard.LabelResourceName]
+		if kind == "" {
+			return name
+		}
+		return strings.ToLower(kind) + "/" + name
+	case ColumnContainer:
+		return report.Labels[starboard.LabelContainerName]
+	case ColumnImage:
+		return GetImageRef(report.Report)
+	case ColumnCVE:
+		return vulnerability.VulnerabilityID
+	case ColumnSeverity:
+		return string(vulnerability.Severity)
+	case ColumnPackage:
+		return vulnerability.Resource
+	case ColumnInstalled:
+		return vulnerability.InstalledVersion
+	case ColumnFixed:
+		return vulnerability.FixedVersion
+	case ColumnTitle:
+		return vulnerability.Title
+	case ColumnLink:
+		return vulnerability.PrimaryLink
+	case ColumnLayer:
+		return layerValue(vulnerability.Layer)
+	default:
+		return ""
+	}
+}
+
+// layerValue returns the command which created the specified layer, or the
+// diff ID of the layer if the command is unknown.
+func layerValue(layer *v1alpha1.Layer) string {
+	switch {
+	case layer == nil:
+		return ""
+	case layer.CreatedBy != "":
+		return layer.CreatedBy
+	case layer.DiffID != "":
+		return layer.DiffID
+	default:
+		return layer.Digest
+	}
+}
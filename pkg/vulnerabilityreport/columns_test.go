@@ -0,0 +1,109 @@
+package vulnerabilityreport_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseColumns(t *testing.T) {
+	columns, err := vulnerabilityreport.ParseColumns("", vulnerabilityreport.Columns)
+	require.NoError(t, err)
+	assert.Equal(t, vulnerabilityreport.Columns, columns)
+
+	columns, err = vulnerabilityreport.ParseColumns("CVE, severity", vulnerabilityreport.TableColumns)
+	require.NoError(t, err)
+	assert.Equal(t, []vulnerabilityreport.Column{
+		vulnerabilityreport.ColumnCVE,
+		vulnerabilityreport.ColumnSeverity,
+	}, columns)
+
+	_, err = vulnerabilityreport.ParseColumns("cve,score", vulnerabilityreport.TableColumns)
+	assert.EqualError(t, err, `invalid column "score", allowed columns are: `+
+		"namespace,workload,container,image,cve,severity,package,installed,fixed,title,link,layer")
+}
+
+func TestWriteCSV(t *testing.T) {
+	reports := []v1alpha1.VulnerabilityReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:  "ReplicaSet",
+					starboard.LabelResourceName:  "nginx-6d4cf56db6",
+					starboard.LabelContainerName: "nginx",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{VulnerabilityID: "CVE-2020-27350", Severity: v1alpha1.SeverityMedium, Resource: "apt",
+						InstalledVersion: "1.8.2.1", FixedVersion: "1.8.2.2", Title: "apt: integer overflows, e.g. in \"ar\"",
+						Layer: &v1alpha1.Layer{DiffID: "sha256:c2adabaecedbda0af72b153c6499a0555f3a769d52370469d8f6bd6328af9b13",
+							CreatedBy: "ADD file:4903a19c327468b0e08e4f463cfc162c66b85b4618b5803d71365862f6302e0b in /"}},
+					{VulnerabilityID: "CVE-2021-3520", Severity: v1alpha1.SeverityCritical, Resource: "liblz4-1",
+						InstalledVersion: "1.8.3-1"},
+				},
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	err := vulnerabilityreport.WriteCSV(reports, []vulnerabilityreport.Column{
+		vulnerabilityreport.ColumnNamespace,
+		vulnerabilityreport.ColumnWorkload,
+		vulnerabilityreport.ColumnContainer,
+		vulnerabilityreport.ColumnCVE,
+		vulnerabilityreport.ColumnSeverity,
+		vulnerabilityreport.ColumnInstalled,
+		vulnerabilityreport.ColumnFixed,
+		vulnerabilityreport.ColumnTitle,
+		vulnerabilityreport.ColumnLayer,
+	}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, `namespace,workload,container,cve,severity,installed,fixed,title,layer
+default,replicaset/nginx-6d4cf56db6,nginx,CVE-2020-27350,MEDIUM,1.8.2.1,1.8.2.2,"apt: integer overflows, e.g. in ""ar""",ADD file:4903a19c327468b0e08e4f463cfc162c66b85b4618b5803d71365862f6302e0b in /
+default,replicaset/nginx-6d4cf56db6,nginx,CVE-2021-3520,CRITICAL,1.8.3-1,,,
+`, out.String())
+}
+
+func TestWriteTable(t *testing.T) {
+	reports := []v1alpha1.VulnerabilityReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{starboard.LabelContainerName: "nginx"}},
+			Report: v1alpha1.VulnerabilityReportData{
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{VulnerabilityID: "CVE-2020-27350", Severity: v1alpha1.SeverityMedium, Resource: "apt"},
+					{VulnerabilityID: "CVE-2021-3520", Severity: v1alpha1.SeverityCritical, Resource: "liblz4-1"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{starboard.LabelContainerName: "sidecar"}},
+			Report: v1alpha1.VulnerabilityReportData{
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{VulnerabilityID: "CVE-2021-33574", Severity: v1alpha1.SeverityCritical, Resource: "libc6"},
+				},
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	err := vulnerabilityreport.WriteTable(reports, []vulnerabilityreport.Column{
+		vulnerabilityreport.ColumnContainer,
+		vulnerabilityreport.ColumnCVE,
+		vulnerabilityreport.ColumnSeverity,
+		vulnerabilityreport.ColumnPackage,
+	}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, `CONTAINER  CVE             SEVERITY  PACKAGE
+nginx      CVE-2021-3520   CRITICAL  liblz4-1
+sidecar    CVE-2021-33574  CRITICAL  libc6
+nginx      CVE-2020-27350  MEDIUM    apt
+`, out.String())
+}
@@ -0,0 +1,139 @@
+package vulnerabilityreport
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// Finding is a vulnerability found in a container.
+type Finding struct {
+	Container     string                 `json:"container"`
+	Vulnerability v1alpha1.Vulnerability `json:"vulnerability"`
+}
+
+// Change is a vulnerability found in both compared scans of a container with
+// a different severity, installed version, or fixed version.
+type Change struct {
+	Container string                 `json:"container"`
+	From      v1alpha1.Vulnerability `json:"from"`
+	To        v1alpha1.Vulnerability `json:"to"`
+}
+
+// Diff holds findings added, removed, and changed between two scans.
+type Diff struct {
+	Added   []Finding `json:"added"`
+	Removed []Finding `json:"removed"`
+	Changed []Change  `json:"changed"`
+}
+
+// findingKey identifies the same vulnerability of the same package in the
+// same container across scans.
+type findingKey struct {
+	container, id, resource string
+}
+
+func findings(reports []v1alpha1.VulnerabilityReport) (map[findingKey]Finding, []findingKey) {
+	findings := make(map[findingKey]Finding)
+	var keys []findingKey
+	for _, report := range reports {
+		container := report.Labels[starboard.LabelContainerName]
+		for _, vulnerability := range report.Report.Vulnerabilities {
+			key := findingKey{container: container, id: vulnerability.VulnerabilityID, resource: vulnerability.Resource}
+			if _, ok := findings[key]; !ok {
+				keys = append(keys, key)
+			}
+			findings[key] = Finding{Container: container, Vulnerability: vulnerability}
+		}
+	}
+	return findings, keys
+}
+
+// Compare returns findings added, removed, and changed in the to reports since
+// the from reports, sorted by severity from the highest to the lowest.
+func Compare(from, to []v1alpha1.VulnerabilityReport) Diff {
+	fromFindings, fromKeys := findings(from)
+	toFindings, toKeys := findings(to)
+	diff := Diff{Added: []Finding{}, Removed: []Finding{}, Changed: []Change{}}
+	for _, key := range toKeys {
+		finding := toFindings[key]
+		previous, ok := fromFindings[key]
+		if !ok {
+			diff.Added = append(diff.Added, finding)
+			continue
+		}
+		if changed(previous.Vulnerability, finding.Vulnerability) {
+			diff.Changed = append(diff.Changed, Change{
+				Container: key.container,
+				From:      previous.Vulnerability,
+				To:        finding.Vulnerability,
+			})
+		}
+	}
+	for _, key := range fromKeys {
+		if _, ok := toFindings[key]; !ok {
+			diff.Removed = append(diff.Removed, fromFindings[key])
+		}
+	}
+	sortFindings(diff.Added)
+	sortFindings(diff.Removed)
+	sort.SliceStable(diff.Changed, func(i, j int) bool {
+		return severityOrder[diff.Changed[i].To.Severity] < severityOrder[diff.Changed[j].To.Severity]
+	})
+	return diff
+}
+
+func changed(from, to v1alpha1.Vulnerability) bool {
+	return from.Severity != to.Severity ||
+		from.InstalledVersion != to.InstalledVersion ||
+		from.FixedVersion != to.FixedVersion
+}
+
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityOrder[findings[i].Vulnerability.Severity] < severityOrder[findings[j].Vulnerability.Severity]
+	})
+}
+
+// Empty checks whether there are no differences.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// WriteTable writes the table with a row for each added (+), removed (-), and
+// changed (~) finding. Changed values are printed as old -> new.
+func (d Diff) WriteTable(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "\tCONTAINER\tCVE\tSEVERITY\tPACKAGE\tINSTALLED\tFIXED")
+	row := func(change, container string, columns ...string) {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", change, container, strings.Join(columns, "\t"))
+	}
+	for _, finding := range d.Added {
+		v := finding.Vulnerability
+		row("+", finding.Container, v.VulnerabilityID, string(v.Severity), v.Resource, v.InstalledVersion, v.FixedVersion)
+	}
+	for _, finding := range d.Removed {
+		v := finding.Vulnerability
+		row("-", finding.Container, v.VulnerabilityID, string(v.Severity), v.Resource, v.InstalledVersion, v.FixedVersion)
+	}
+	for _, change := range d.Changed {
+		row("~", change.Container, change.To.VulnerabilityID,
+			changedValue(string(change.From.Severity), string(change.To.Severity)),
+			change.To.Resource,
+			changedValue(change.From.InstalledVersion, change.To.InstalledVersion),
+			changedValue(change.From.FixedVersion, change.To.FixedVersion))
+	}
+	return w.Flush()
+}
+
+func changedValue(from, to string) string {
+	if from == to {
+		return to
+	}
+	return from + " -> " + to
+}
@@ -0,0 +1,53 @@
+package vulnerabilityreport_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCompare(t *testing.T) {
+	report := func(container string, vulnerabilities ...v1alpha1.Vulnerability) v1alpha1.VulnerabilityReport {
+		return v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{starboard.LabelContainerName: container}},
+			Report:     v1alpha1.VulnerabilityReportData{Vulnerabilities: vulnerabilities},
+		}
+	}
+	log4shell := v1alpha1.Vulnerability{VulnerabilityID: "CVE-2021-44228", Resource: "log4j-core",
+		InstalledVersion: "2.14.1", FixedVersion: "2.15.0", Severity: v1alpha1.SeverityCritical}
+	log4shellUpgraded := log4shell
+	log4shellUpgraded.InstalledVersion = "2.15.0"
+	log4shellUpgraded.FixedVersion = "2.16.0"
+	apt := v1alpha1.Vulnerability{VulnerabilityID: "CVE-2020-27350", Resource: "apt",
+		InstalledVersion: "1.8.2.1", Severity: v1alpha1.SeverityMedium}
+	openssl := v1alpha1.Vulnerability{VulnerabilityID: "CVE-2022-0778", Resource: "openssl",
+		InstalledVersion: "1.1.1k", Severity: v1alpha1.SeverityHigh}
+
+	diff := vulnerabilityreport.Compare(
+		[]v1alpha1.VulnerabilityReport{report("app", log4shell, apt), report("sidecar", apt)},
+		[]v1alpha1.VulnerabilityReport{report("app", log4shellUpgraded, openssl), report("sidecar", apt)},
+	)
+
+	assert.Equal(t, []vulnerabilityreport.Finding{{Container: "app", Vulnerability: openssl}}, diff.Added)
+	assert.Equal(t, []vulnerabilityreport.Finding{{Container: "app", Vulnerability: apt}}, diff.Removed)
+	assert.Equal(t, []vulnerabilityreport.Change{{Container: "app", From: log4shell, To: log4shellUpgraded}}, diff.Changed)
+	assert.False(t, diff.Empty())
+
+	var out bytes.Buffer
+	require.NoError(t, diff.WriteTable(&out))
+	assert.Equal(t, "   CONTAINER  CVE             SEVERITY  PACKAGE     INSTALLED         FIXED\n"+
+		"+  app        CVE-2022-0778   HIGH      openssl     1.1.1k            \n"+
+		"-  app        CVE-2020-27350  MEDIUM    apt         1.8.2.1           \n"+
+		"~  app        CVE-2021-44228  CRITICAL  log4j-core  2.14.1 -> 2.15.0  2.15.0 -> 2.16.0\n", out.String())
+
+	assert.True(t, vulnerabilityreport.Compare(
+		[]v1alpha1.VulnerabilityReport{report("app", apt)},
+		[]v1alpha1.VulnerabilityReport{report("app", apt)},
+	).Empty())
+}
@@ -0,0 +1,113 @@
+package vulnerabilityreport
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// Expiration is the time when the TTL of a VulnerabilityReport expires, after
+// which the report is deleted by the operator.
+type Expiration struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Kind      kube.Kind `json:"kind,omitempty"`
+	Workload  string    `json:"workload,omitempty"`
+	Container string    `json:"container,omitempty"`
+	TTL       string    `json:"ttl"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// GetExpiration returns the time when the TTL of the specified report
+// expires, i.e. the time when the report was updated plus its TTL, and false
+// if the report has no TTL. The TTL is read from the
+// v1alpha1.TTLReportAnnotation, unless the ttl argument is not nil, in which
+// case it's used for all reports to simulate a change of the TTL.
+func GetExpiration(report v1alpha1.VulnerabilityReport, ttl *time.Duration) (time.Time, time.Duration, bool, error) {
+	if ttl != nil {
+		return report.Report.UpdateTimestamp.Add(*ttl), *ttl, true, nil
+	}
+	value, ok := report.Annotations[v1alpha1.TTLReportAnnotation]
+	if !ok {
+		return time.Time{}, 0, false, nil
+	}
+	reportTTL, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, 0, false, fmt.Errorf("parsing %s of report %s/%s: %w", v1alpha1.TTLReportAnnotation,
+			report.Namespace, report.Name, err)
+	}
+	return report.Report.UpdateTimestamp.Add(reportTTL), reportTTL, true, nil
+}
+
+// PreviewExpirations returns expirations of the specified reports that expire
+// before the specified time, or of all reports with TTL if the time is zero,
+// sorted by the time of expiration. Reports whose TTL has already expired
+// are deleted by the next sweep of the operator.
+func PreviewExpirations(reports []v1alpha1.VulnerabilityReport, ttl *time.Duration, before time.Time) ([]Expiration, error) {
+	var expirations []Expiration
+	for _, report := range reports {
+		expiresAt, reportTTL, ok, err := GetExpiration(report, ttl)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || (!before.IsZero() && !expiresAt.Before(before)) {
+			continue
+		}
+		expiration := Expiration{
+			Namespace: report.Namespace,
+			Name:      report.Name,
+			Container: report.Labels[starboard.LabelContainerName],
+			TTL:       reportTTL.String(),
+			ExpiresAt: expiresAt,
+		}
+		if workload, err := kube.ObjectRefFromObjectMeta(report.ObjectMeta); err == nil {
+			expiration.Kind = workload.Kind
+			expiration.Workload = workload.Name
+		}
+		expirations = append(expirations, expiration)
+	}
+	sort.SliceStable(expirations, func(i, j int) bool {
+		a, b := expirations[i], expirations[j]
+		if !a.ExpiresAt.Equal(b.ExpiresAt) {
+			return a.ExpiresAt.Before(b.ExpiresAt)
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return expirations, nil
+}
+
+// WriteExpirationsTable writes the specified expirations as rows of the table
+// with the time left until each report expires, relative to now.
+func WriteExpirationsTable(expirations []Expiration, now time.Time, out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "NAMESPACE\tWORKLOAD\tCONTAINER\tTTL\tEXPIRES\tEXPIRES AT"); err != nil {
+		return err
+	}
+	for _, expiration := range expirations {
+		workload := "<unknown>"
+		if expiration.Workload != "" {
+			workload = strings.ToLower(string(expiration.Kind)) + "/" + expiration.Workload
+		}
+		expires := "expired"
+		if left := expiration.ExpiresAt.Sub(now); left > 0 {
+			expires = "in " + duration.HumanDuration(left)
+		}
+		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", expiration.Namespace, workload, expiration.Container,
+			expiration.TTL, expires, expiration.ExpiresAt.UTC().Format(time.RFC3339))
+		if err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
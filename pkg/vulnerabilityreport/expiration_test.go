@@ -0,0 +1,91 @@
+package vulnerabilityreport_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newExpiringReport(namespace, name, workload, ttl string, updated time.Time) v1alpha1.VulnerabilityReport {
+	report := v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      "ReplicaSet",
+				starboard.LabelResourceName:      workload,
+				starboard.LabelResourceNamespace: namespace,
+				starboard.LabelContainerName:     "app",
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{UpdateTimestamp: metav1.NewTime(updated)},
+	}
+	if ttl != "" {
+		report.Annotations = map[string]string{v1alpha1.TTLReportAnnotation: ttl}
+	}
+	return report
+}
+
+func TestPreviewExpirations(t *testing.T) {
+	now := time.Date(2022, 3, 10, 12, 0, 0, 0, time.UTC)
+	reports := []v1alpha1.VulnerabilityReport{
+		newExpiringReport("prod", "replicaset-web-app", "web", "168h", now.Add(-24*time.Hour)),
+		newExpiringReport("prod", "replicaset-api-app", "api", "24h", now.Add(-30*time.Hour)),
+		newExpiringReport("staging", "replicaset-web-app", "web", "24h", now.Add(-12*time.Hour)),
+		newExpiringReport("dev", "replicaset-web-app", "web", "", now.Add(-72*time.Hour)),
+	}
+
+	t.Run("Should preview reports with TTL sorted by expiration", func(t *testing.T) {
+		expirations, err := vulnerabilityreport.PreviewExpirations(reports, nil, time.Time{})
+		require.NoError(t, err)
+		assert.Equal(t, []vulnerabilityreport.Expiration{
+			{Namespace: "prod", Name: "replicaset-api-app", Kind: "ReplicaSet", Workload: "api", Container: "app",
+				TTL: "24h0m0s", ExpiresAt: now.Add(-6 * time.Hour)},
+			{Namespace: "staging", Name: "replicaset-web-app", Kind: "ReplicaSet", Workload: "web", Container: "app",
+				TTL: "24h0m0s", ExpiresAt: now.Add(12 * time.Hour)},
+			{Namespace: "prod", Name: "replicaset-web-app", Kind: "ReplicaSet", Workload: "web", Container: "app",
+				TTL: "168h0m0s", ExpiresAt: now.Add(144 * time.Hour)},
+		}, expirations)
+
+		var out bytes.Buffer
+		require.NoError(t, vulnerabilityreport.WriteExpirationsTable(expirations, now, &out))
+		assert.Equal(t, `NAMESPACE  WORKLOAD        CONTAINER  TTL       EXPIRES  EXPIRES AT
+prod       replicaset/api  app        24h0m0s   expired  2022-03-10T06:00:00Z
+staging    replicaset/web  app        24h0m0s   in 12h   2022-03-11T00:00:00Z
+prod       replicaset/web  app        168h0m0s  in 6d    2022-03-16T12:00:00Z
+`, out.String())
+	})
+
+	t.Run("Should preview reports that expire before the specified time", func(t *testing.T) {
+		expirations, err := vulnerabilityreport.PreviewExpirations(reports, nil, now.Add(24*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, expirations, 2)
+		assert.Equal(t, "prod", expirations[0].Namespace)
+		assert.Equal(t, "staging", expirations[1].Namespace)
+	})
+
+	t.Run("Should simulate the specified TTL of all reports", func(t *testing.T) {
+		ttl := 48 * time.Hour
+		expirations, err := vulnerabilityreport.PreviewExpirations(reports, &ttl, now)
+		require.NoError(t, err)
+		require.Len(t, expirations, 1)
+		assert.Equal(t, vulnerabilityreport.Expiration{
+			Namespace: "dev", Name: "replicaset-web-app", Kind: "ReplicaSet", Workload: "web", Container: "app",
+			TTL: "48h0m0s", ExpiresAt: now.Add(-24 * time.Hour),
+		}, expirations[0])
+	})
+
+	t.Run("Should return error when TTL is invalid", func(t *testing.T) {
+		_, err := vulnerabilityreport.PreviewExpirations([]v1alpha1.VulnerabilityReport{
+			newExpiringReport("prod", "replicaset-web-app", "web", "1w", now),
+		}, nil, time.Time{})
+		assert.EqualError(t, err, `parsing starboard.aquasecurity.github.io/report-ttl of report prod/replicaset-web-app: time: unknown unit "w" in duration "1w"`)
+	})
+}
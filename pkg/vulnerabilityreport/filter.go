@@ -0,0 +1,90 @@
+package vulnerabilityreport
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+)
+
+// ImageFilter determines which container images are scanned by glob patterns
+// of image references. The * wildcard matches any sequence of characters,
+// including the / separator, and the ? wildcard matches a single character.
+//
+// The zero value scans all images.
+type ImageFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// NewImageFilter constructs the ImageFilter that scans images matching any of
+// the include patterns, or all images if there are no include patterns, except
+// for images matching any of the exclude patterns.
+func NewImageFilter(include, exclude []string) ImageFilter {
+	return ImageFilter{
+		include: compileGlobs(include),
+		exclude: compileGlobs(exclude),
+	}
+}
+
+// Scans checks whether the specified image reference is scanned.
+func (f ImageFilter) Scans(image string) bool {
+	if len(f.include) > 0 && !matchesAny(f.include, image) {
+		return false
+	}
+	return !matchesAny(f.exclude, image)
+}
+
+// Apply returns containers of the specified mapping whose images are scanned.
+func (f ImageFilter) Apply(images kube.ContainerImages) kube.ContainerImages {
+	filtered := kube.ContainerImages{}
+	for container, image := range images {
+		if f.Scans(image) {
+			filtered[container] = image
+		}
+	}
+	return filtered
+}
+
+func compileGlobs(patterns []string) []*regexp.Regexp {
+	var globs []*regexp.Regexp
+	for _, pattern := range patterns {
+		expr := regexp.QuoteMeta(pattern)
+		expr = strings.ReplaceAll(expr, `\*`, `.*`)
+		expr = strings.ReplaceAll(expr, `\?`, `.`)
+		globs = append(globs, regexp.MustCompile("^"+expr+"$"))
+	}
+	return globs
+}
+
+func matchesAny(globs []*regexp.Regexp, image string) bool {
+	for _, glob := range globs {
+		if glob.MatchString(image) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByVulnerabilityID returns copies of the specified reports that list
+// only vulnerabilities with the specified ID, compared case-insensitively.
+// Reports without such vulnerabilities are skipped.
+func FilterByVulnerabilityID(reports []v1alpha1.VulnerabilityReport, id string) []v1alpha1.VulnerabilityReport {
+	var filtered []v1alpha1.VulnerabilityReport
+	for _, report := range reports {
+		var vulnerabilities []v1alpha1.Vulnerability
+		for _, vulnerability := range report.Report.Vulnerabilities {
+			if strings.EqualFold(vulnerability.VulnerabilityID, id) {
+				vulnerabilities = append(vulnerabilities, vulnerability)
+			}
+		}
+		if len(vulnerabilities) == 0 {
+			continue
+		}
+		copied := report.DeepCopy()
+		copied.Report.Vulnerabilities = vulnerabilities
+		filtered = append(filtered, *copied)
+	}
+	return filtered
+}
@@ -0,0 +1,115 @@
+package vulnerabilityreport_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestImageFilter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		image    string
+		expected bool
+	}{
+		{
+			name:     "Should scan all images by default",
+			image:    "nginx:1.16",
+			expected: true,
+		},
+		{
+			name:     "Should not scan excluded image",
+			exclude:  []string{"k8s.gcr.io/pause:*"},
+			image:    "k8s.gcr.io/pause:3.5",
+			expected: false,
+		},
+		{
+			name:     "Should match registry path with wildcard",
+			exclude:  []string{"*/internal/base-*"},
+			image:    "registry.example.com/team/internal/base-utils:1.0",
+			expected: false,
+		},
+		{
+			name:     "Should match single character with question mark",
+			exclude:  []string{"nginx:1.1?"},
+			image:    "nginx:1.16",
+			expected: false,
+		},
+		{
+			name:     "Should not scan image that is not included",
+			include:  []string{"registry.example.com/*"},
+			image:    "nginx:1.16",
+			expected: false,
+		},
+		{
+			name:     "Should scan included image",
+			include:  []string{"registry.example.com/*"},
+			image:    "registry.example.com/payments/api:2.3.1",
+			expected: true,
+		},
+		{
+			name:     "Should prefer exclude patterns over include patterns",
+			include:  []string{"registry.example.com/*"},
+			exclude:  []string{"*:debug"},
+			image:    "registry.example.com/payments/api:debug",
+			expected: false,
+		},
+		{
+			name:     "Should treat regular expression characters literally",
+			exclude:  []string{"nginx:1.16"},
+			image:    "nginx:1x16",
+			expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := vulnerabilityreport.NewImageFilter(tc.include, tc.exclude)
+			assert.Equal(t, tc.expected, filter.Scans(tc.image))
+		})
+	}
+}
+
+func TestImageFilter_Apply(t *testing.T) {
+	filter := vulnerabilityreport.NewImageFilter(nil, []string{"*/pause:*"})
+	assert.Equal(t, kube.ContainerImages{"nginx": "nginx:1.16"}, filter.Apply(kube.ContainerImages{
+		"nginx": "nginx:1.16",
+		"pause": "k8s.gcr.io/pause:3.5",
+	}))
+	assert.Equal(t, kube.ContainerImages{"nginx": "nginx:1.16"},
+		vulnerabilityreport.ImageFilter{}.Apply(kube.ContainerImages{"nginx": "nginx:1.16"}))
+}
+
+func TestFilterByVulnerabilityID(t *testing.T) {
+	log4j := v1alpha1.Vulnerability{VulnerabilityID: "CVE-2021-44228", Resource: "org.apache.logging.log4j:log4j-core"}
+	reports := []v1alpha1.VulnerabilityReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "replicaset-app-7b5d8f9c4-app"},
+			Report: v1alpha1.VulnerabilityReportData{
+				Vulnerabilities: []v1alpha1.Vulnerability{{VulnerabilityID: "CVE-2022-0778", Resource: "openssl"}, log4j},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "replicaset-nginx-6d4cf56db6-nginx"},
+			Report: v1alpha1.VulnerabilityReportData{
+				Vulnerabilities: []v1alpha1.Vulnerability{{VulnerabilityID: "CVE-2022-0778", Resource: "openssl"}},
+			},
+		},
+	}
+
+	filtered := vulnerabilityreport.FilterByVulnerabilityID(reports, "cve-2021-44228")
+	assert.Equal(t, []v1alpha1.VulnerabilityReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "replicaset-app-7b5d8f9c4-app"},
+			Report: v1alpha1.VulnerabilityReportData{
+				Vulnerabilities: []v1alpha1.Vulnerability{log4j},
+			},
+		},
+	}, filtered)
+	assert.Len(t, reports[0].Report.Vulnerabilities, 2, "reports must not be modified")
+}
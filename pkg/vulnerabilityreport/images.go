@@ -0,0 +1,238 @@
+package vulnerabilityreport
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// ImageRanking is the criterion by which images are ranked by SortImages.
+type ImageRanking string
+
+const (
+	// RankByCritical ranks images by the number of critical, and then high,
+	// medium, and low, vulnerabilities.
+	RankByCritical ImageRanking = "critical"
+	// RankByCVSS ranks images by the highest CVSS score of vulnerabilities.
+	RankByCVSS ImageRanking = "cvss"
+	// RankByCount ranks images by the total number of vulnerabilities.
+	RankByCount ImageRanking = "count"
+)
+
+// ImageRankings are all supported rankings.
+var ImageRankings = []ImageRanking{RankByCritical, RankByCVSS, RankByCount}
+
+// maxWorkloads is the maximum number of workloads of an image listed by
+// WriteImagesTable.
+const maxWorkloads = 3
+
+// ImageSummary is the summary of vulnerabilities of an image and containers
+// of workloads that run the image.
+type ImageSummary struct {
+	// Image is the reference of the image.
+	Image string `json:"image"`
+
+	// Digest is the digest of the image, if known.
+	Digest string `json:"digest,omitempty"`
+
+	// Summary are counts of vulnerabilities of the image.
+	Summary v1alpha1.VulnerabilitySummary `json:"summary"`
+
+	// MaxScore is the highest CVSS score of vulnerabilities of the image.
+	MaxScore float64 `json:"maxScore"`
+
+	// Containers are containers of workloads that run the image.
+	Containers []ImageContainer `json:"containers"`
+}
+
+// ImageContainer is a container of a workload.
+type ImageContainer struct {
+	Namespace string    `json:"namespace"`
+	Kind      kube.Kind `json:"kind"`
+	Name      string    `json:"name"`
+	Container string    `json:"container"`
+}
+
+// Workload returns the reference of the workload of the container.
+func (c ImageContainer) Workload() kube.ObjectRef {
+	return kube.ObjectRef{Kind: c.Kind, Name: c.Name, Namespace: c.Namespace}
+}
+
+// Total returns the total number of vulnerabilities of the image.
+func (s ImageSummary) Total() int {
+	return s.Summary.CriticalCount + s.Summary.HighCount + s.Summary.MediumCount + s.Summary.LowCount +
+		s.Summary.UnknownCount
+}
+
+// SummarizeImages groups the specified reports by the digest of scanned
+// images, or by the image reference if the digest is unknown, and returns
+// the summary of each image. Vulnerabilities of an image are counted once,
+// regardless of how many containers run the image, based on the newest
+// report of the image.
+func SummarizeImages(reports []v1alpha1.VulnerabilityReport) []ImageSummary {
+	var images []ImageSummary
+	for _, group := range groupByImage(reports) {
+		images = append(images, ImageSummary{
+			Image:      GetImageRef(group.newest),
+			Digest:     group.digest,
+			Summary:    group.newest.Summary,
+			MaxScore:   maxScore(group.newest.Vulnerabilities),
+			Containers: group.containers,
+		})
+	}
+	return images
+}
+
+// imageGroup is the newest report data of an image and containers of
+// workloads that run the image.
+type imageGroup struct {
+	digest     string
+	newest     v1alpha1.VulnerabilityReportData
+	containers []ImageContainer
+}
+
+// groupByImage groups the specified reports by the digest of scanned images,
+// or by the image reference if the digest is unknown, in the order of first
+// reports of each image.
+func groupByImage(reports []v1alpha1.VulnerabilityReport) []imageGroup {
+	var groups []imageGroup
+	indexes := make(map[string]int)
+	for _, report := range reports {
+		digest := report.Report.Artifact.Digest
+		if digest == "" {
+			digest = report.Labels[starboard.LabelImageDigest]
+		}
+		key := digest
+		if key == "" {
+			key = GetImageRef(report.Report)
+		}
+		index, ok := indexes[key]
+		if !ok {
+			index = len(groups)
+			indexes[key] = index
+			groups = append(groups, imageGroup{digest: digest, newest: report.Report})
+		}
+		group := &groups[index]
+		if group.newest.UpdateTimestamp.Before(&report.Report.UpdateTimestamp) {
+			group.newest = report.Report
+		}
+		if workload, err := kube.ObjectRefFromObjectMeta(report.ObjectMeta); err == nil {
+			group.containers = append(group.containers, ImageContainer{
+				Namespace: workload.Namespace,
+				Kind:      workload.Kind,
+				Name:      workload.Name,
+				Container: report.Labels[starboard.LabelContainerName],
+			})
+		}
+	}
+	return groups
+}
+
+func maxScore(vulnerabilities []v1alpha1.Vulnerability) float64 {
+	var max float64
+	for _, vulnerability := range vulnerabilities {
+		if vulnerability.Score != nil && *vulnerability.Score > max {
+			max = *vulnerability.Score
+		}
+	}
+	return max
+}
+
+// ParseImageRanking parses the specified ranking.
+func ParseImageRanking(value string) (ImageRanking, error) {
+	for _, ranking := range ImageRankings {
+		if strings.ToLower(value) == string(ranking) {
+			return ranking, nil
+		}
+	}
+	var names []string
+	for _, ranking := range ImageRankings {
+		names = append(names, string(ranking))
+	}
+	return "", fmt.Errorf("invalid ranking %q, allowed rankings are: %s", value, strings.Join(names, ","))
+}
+
+// SortImages sorts the specified images by the specified ranking, the most
+// vulnerable images first. Ties are broken by the number of critical and
+// high vulnerabilities, and then by image references.
+func SortImages(images []ImageSummary, ranking ImageRanking) {
+	sort.SliceStable(images, func(i, j int) bool {
+		a, b := images[i], images[j]
+		switch ranking {
+		case RankByCVSS:
+			if a.MaxScore != b.MaxScore {
+				return a.MaxScore > b.MaxScore
+			}
+		case RankByCount:
+			if a.Total() != b.Total() {
+				return a.Total() > b.Total()
+			}
+		}
+		for _, counts := range [][2]int{
+			{a.Summary.CriticalCount, b.Summary.CriticalCount},
+			{a.Summary.HighCount, b.Summary.HighCount},
+			{a.Summary.MediumCount, b.Summary.MediumCount},
+			{a.Summary.LowCount, b.Summary.LowCount},
+		} {
+			if counts[0] != counts[1] {
+				return counts[0] > counts[1]
+			}
+		}
+		return a.Image < b.Image
+	})
+}
+
+// WriteImagesTable writes the specified images as rows of the table with
+// counts of vulnerabilities and workloads that run each image. At most top
+// images are written, or all images if top is not positive.
+func WriteImagesTable(images []ImageSummary, top int, out io.Writer) error {
+	if top > 0 && len(images) > top {
+		images = images[:top]
+	}
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "IMAGE\tCRITICAL\tHIGH\tMEDIUM\tLOW\tUNKNOWN\tMAX CVSS\tWORKLOADS"); err != nil {
+		return err
+	}
+	for _, image := range images {
+		score := "-"
+		if image.MaxScore > 0 {
+			score = fmt.Sprintf("%.1f", image.MaxScore)
+		}
+		_, err := fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s\n", image.Image, image.Summary.CriticalCount,
+			image.Summary.HighCount, image.Summary.MediumCount, image.Summary.LowCount, image.Summary.UnknownCount,
+			score, formatWorkloads(image.Containers))
+		if err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// formatWorkloads returns the comma-separated list of unique workloads of
+// the specified containers, e.g. default/deployment/nginx.
+func formatWorkloads(containers []ImageContainer) string {
+	var workloads []string
+	unique := make(map[kube.ObjectRef]bool)
+	for _, container := range containers {
+		workload := container.Workload()
+		if unique[workload] {
+			continue
+		}
+		unique[workload] = true
+		workloads = append(workloads, workload.Namespace+"/"+strings.ToLower(string(workload.Kind))+"/"+workload.Name)
+	}
+	if len(workloads) == 0 {
+		return "<none>"
+	}
+	sort.Strings(workloads)
+	if len(workloads) > maxWorkloads {
+		return strings.Join(workloads[:maxWorkloads], ",") + fmt.Sprintf(" and %d more", len(workloads)-maxWorkloads)
+	}
+	return strings.Join(workloads, ",")
+}
@@ -0,0 +1,149 @@
+package vulnerabilityreport_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func newImageReport(namespace, kind, name, container, repository, tag, digest string, updated time.Time,
+	summary v1alpha1.VulnerabilitySummary, scores ...float64) v1alpha1.VulnerabilityReport {
+	var vulnerabilities []v1alpha1.Vulnerability
+	for _, score := range scores {
+		vulnerabilities = append(vulnerabilities, v1alpha1.Vulnerability{Score: pointer.Float64Ptr(score)})
+	}
+	return v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      kind,
+				starboard.LabelResourceName:      name,
+				starboard.LabelResourceNamespace: namespace,
+				starboard.LabelContainerName:     container,
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(updated),
+			Registry:        v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact:        v1alpha1.Artifact{Repository: repository, Tag: tag, Digest: digest},
+			Summary:         summary,
+			Vulnerabilities: vulnerabilities,
+		},
+	}
+}
+
+var (
+	scannedAt = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	imageReports = []v1alpha1.VulnerabilityReport{
+		newImageReport("default", "ReplicaSet", "nginx-6d4cf56db6", "nginx", "library/nginx", "1.16", "sha256:aaa",
+			scannedAt, v1alpha1.VulnerabilitySummary{CriticalCount: 2, HighCount: 10, MediumCount: 5}, 9.8, 7.5),
+		newImageReport("staging", "ReplicaSet", "web-7b5d8f9c4", "web", "library/nginx", "latest", "sha256:aaa",
+			scannedAt.Add(time.Hour), v1alpha1.VulnerabilitySummary{CriticalCount: 3, HighCount: 10, MediumCount: 5}, 9.8, 9.1),
+		newImageReport("default", "StatefulSet", "db", "postgres", "library/postgres", "13", "sha256:bbb",
+			scannedAt, v1alpha1.VulnerabilitySummary{HighCount: 4, MediumCount: 40, LowCount: 60}, 8.8),
+		newImageReport("default", "DaemonSet", "agent", "agent", "library/busybox", "1.35", "",
+			scannedAt, v1alpha1.VulnerabilitySummary{CriticalCount: 3, HighCount: 1}),
+	}
+)
+
+func TestSummarizeImages(t *testing.T) {
+	images := vulnerabilityreport.SummarizeImages(imageReports)
+	assert.Equal(t, []vulnerabilityreport.ImageSummary{
+		{
+			Image:    "index.docker.io/library/nginx:latest",
+			Digest:   "sha256:aaa",
+			Summary:  v1alpha1.VulnerabilitySummary{CriticalCount: 3, HighCount: 10, MediumCount: 5},
+			MaxScore: 9.8,
+			Containers: []vulnerabilityreport.ImageContainer{
+				{Namespace: "default", Kind: kube.KindReplicaSet, Name: "nginx-6d4cf56db6", Container: "nginx"},
+				{Namespace: "staging", Kind: kube.KindReplicaSet, Name: "web-7b5d8f9c4", Container: "web"},
+			},
+		},
+		{
+			Image:      "index.docker.io/library/postgres:13",
+			Digest:     "sha256:bbb",
+			Summary:    v1alpha1.VulnerabilitySummary{HighCount: 4, MediumCount: 40, LowCount: 60},
+			MaxScore:   8.8,
+			Containers: []vulnerabilityreport.ImageContainer{{Namespace: "default", Kind: kube.KindStatefulSet, Name: "db", Container: "postgres"}},
+		},
+		{
+			Image:      "index.docker.io/library/busybox:1.35",
+			Summary:    v1alpha1.VulnerabilitySummary{CriticalCount: 3, HighCount: 1},
+			Containers: []vulnerabilityreport.ImageContainer{{Namespace: "default", Kind: kube.KindDaemonSet, Name: "agent", Container: "agent"}},
+		},
+	}, images)
+}
+
+func TestSortImages(t *testing.T) {
+	testCases := []struct {
+		ranking        vulnerabilityreport.ImageRanking
+		expectedImages []string
+	}{
+		{
+			ranking: vulnerabilityreport.RankByCritical,
+			expectedImages: []string{
+				"index.docker.io/library/nginx:latest",
+				"index.docker.io/library/busybox:1.35",
+				"index.docker.io/library/postgres:13",
+			},
+		},
+		{
+			ranking: vulnerabilityreport.RankByCVSS,
+			expectedImages: []string{
+				"index.docker.io/library/nginx:latest",
+				"index.docker.io/library/postgres:13",
+				"index.docker.io/library/busybox:1.35",
+			},
+		},
+		{
+			ranking: vulnerabilityreport.RankByCount,
+			expectedImages: []string{
+				"index.docker.io/library/postgres:13",
+				"index.docker.io/library/nginx:latest",
+				"index.docker.io/library/busybox:1.35",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.ranking), func(t *testing.T) {
+			images := vulnerabilityreport.SummarizeImages(imageReports)
+			vulnerabilityreport.SortImages(images, tc.ranking)
+			var refs []string
+			for _, image := range images {
+				refs = append(refs, image.Image)
+			}
+			assert.Equal(t, tc.expectedImages, refs)
+		})
+	}
+}
+
+func TestParseImageRanking(t *testing.T) {
+	ranking, err := vulnerabilityreport.ParseImageRanking("CVSS")
+	require.NoError(t, err)
+	assert.Equal(t, vulnerabilityreport.RankByCVSS, ranking)
+
+	_, err = vulnerabilityreport.ParseImageRanking("epss")
+	assert.EqualError(t, err, `invalid ranking "epss", allowed rankings are: critical,cvss,count`)
+}
+
+func TestWriteImagesTable(t *testing.T) {
+	images := vulnerabilityreport.SummarizeImages(imageReports)
+	vulnerabilityreport.SortImages(images, vulnerabilityreport.RankByCritical)
+	var out bytes.Buffer
+	err := vulnerabilityreport.WriteImagesTable(images, 2, &out)
+	require.NoError(t, err)
+	assert.Equal(t, `IMAGE                                 CRITICAL  HIGH  MEDIUM  LOW  UNKNOWN  MAX CVSS  WORKLOADS
+index.docker.io/library/nginx:latest  3         10    5       0    0        9.8       default/replicaset/nginx-6d4cf56db6,staging/replicaset/web-7b5d8f9c4
+index.docker.io/library/busybox:1.35  3         1     0       0    0        -         default/daemonset/agent
+`, out.String())
+}
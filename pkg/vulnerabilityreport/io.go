@@ -6,7 +6,9 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -28,9 +30,20 @@ type Writer interface {
 // v1alpha1.VulnerabilityReport objects owned by related Kubernetes objects.
 // For example, if the given owner is a Deployment, but reports are owned by the
 // active ReplicaSet (current revision) this method will return the reports.
+//
+// FindByImageDigest returns the slice of v1alpha1.VulnerabilityReport
+// instances, in all namespaces, of containers that run the image with the
+// given digest or an empty slice if the reports are not found.
+//
+// FindByVulnerabilityID returns the slice of v1alpha1.VulnerabilityReport
+// instances in the given namespace, or in all namespaces if the namespace is
+// empty, that contain the vulnerability with the given ID, e.g. CVE-2021-44228,
+// or an empty slice if the reports are not found.
 type Reader interface {
 	FindByOwner(context.Context, kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error)
 	FindByOwnerInHierarchy(ctx context.Context, object kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error)
+	FindByImageDigest(ctx context.Context, digest string) ([]v1alpha1.VulnerabilityReport, error)
+	FindByVulnerabilityID(ctx context.Context, namespace, id string) ([]v1alpha1.VulnerabilityReport, error)
 }
 
 type ReadWriter interface {
@@ -68,21 +81,61 @@ func (r *readWriter) createOrUpdate(ctx context.Context, report v1alpha1.Vulnera
 		Namespace: report.Namespace,
 	}, &existing)
 
+	if err == nil && kube.IsNameCollision(&existing, &report) {
+		report.Name = kube.GetCollisionFreeName(&report)
+		err = r.Get(ctx, types.NamespacedName{
+			Name:      report.Name,
+			Namespace: report.Namespace,
+		}, &existing)
+	}
+
 	if err == nil {
 		copied := existing.DeepCopy()
 		copied.Labels = report.Labels
 		copied.Report = report.Report
+		for key, value := range report.Annotations {
+			metav1.SetMetaDataAnnotation(&copied.ObjectMeta, key, value)
+		}
 
 		return r.Update(ctx, copied)
 	}
 
 	if errors.IsNotFound(err) {
-		return r.Create(ctx, &report)
+		err = r.Create(ctx, &report)
+		if err != nil {
+			return err
+		}
+		return r.deleteRenamed(ctx, report)
 	}
 
 	return err
 }
 
+// deleteRenamed deletes reports of the workload and the container of the
+// specified report that have different names, e.g. because the format of
+// names of reports was changed.
+func (r *readWriter) deleteRenamed(ctx context.Context, report v1alpha1.VulnerabilityReport) error {
+	owner := kube.GetReportOwnerLabels(&report)
+	if len(owner) == 0 {
+		return nil
+	}
+	var list v1alpha1.VulnerabilityReportList
+	err := r.List(ctx, &list, client.InNamespace(report.Namespace), client.MatchingLabels(owner))
+	if err != nil {
+		return err
+	}
+	for i := range list.Items {
+		if list.Items[i].Name == report.Name {
+			continue
+		}
+		err = r.Delete(ctx, &list.Items[i])
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *readWriter) FindByOwner(ctx context.Context, owner kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error) {
 	var list v1alpha1.VulnerabilityReportList
 
@@ -96,6 +149,35 @@ func (r *readWriter) FindByOwner(ctx context.Context, owner kube.ObjectRef) ([]v
 	return list.DeepCopy().Items, nil
 }
 
+func (r *readWriter) FindByImageDigest(ctx context.Context, digest string) ([]v1alpha1.VulnerabilityReport, error) {
+	var list v1alpha1.VulnerabilityReportList
+
+	err := r.List(ctx, &list, client.MatchingLabels{
+		starboard.LabelImageDigest: GetImageDigestLabelValue(digest),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.DeepCopy().Items, nil
+}
+
+func (r *readWriter) FindByVulnerabilityID(ctx context.Context, namespace, id string) ([]v1alpha1.VulnerabilityReport, error) {
+	label := GetVulnerabilityIDLabel(id)
+	if label == "" {
+		return nil, fmt.Errorf("invalid vulnerability ID: %s", id)
+	}
+
+	var list v1alpha1.VulnerabilityReportList
+
+	err := r.List(ctx, &list, client.HasLabels{label}, client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	return list.DeepCopy().Items, nil
+}
+
 func (r *readWriter) FindByOwnerInHierarchy(ctx context.Context, owner kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error) {
 	reports, err := r.FindByOwner(ctx, owner)
 	if err != nil {
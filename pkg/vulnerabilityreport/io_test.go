@@ -201,6 +201,80 @@ func TestNewReadWriter(t *testing.T) {
 		}, found)
 	})
 
+	t.Run("Should rename VulnerabilityReport when name collides", func(t *testing.T) {
+		existing := &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deployment-nginx-nginx-app",
+				Namespace: "qa",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "Deployment",
+					starboard.LabelResourceName:      "nginx",
+					starboard.LabelResourceNamespace: "qa",
+					starboard.LabelContainerName:     "nginx-app",
+				},
+			},
+		}
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(existing).Build()
+		report := v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deployment-nginx-nginx-app",
+				Namespace: "qa",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "Deployment",
+					starboard.LabelResourceName:      "nginx-nginx",
+					starboard.LabelResourceNamespace: "qa",
+					starboard.LabelContainerName:     "app",
+				},
+			},
+		}
+		err := vulnerabilityreport.NewReadWriter(client).Write(context.TODO(), []v1alpha1.VulnerabilityReport{report})
+		require.NoError(t, err)
+
+		var list v1alpha1.VulnerabilityReportList
+		err = client.List(context.TODO(), &list)
+		require.NoError(t, err)
+		names := map[string]string{}
+		for _, item := range list.Items {
+			names[item.Name] = item.Labels[starboard.LabelContainerName]
+		}
+		assert.Equal(t, map[string]string{
+			"deployment-nginx-nginx-app":                  "nginx-app",
+			kube.GetCollisionFreeName(&report.ObjectMeta): "app",
+		}, names)
+	})
+
+	t.Run("Should delete renamed VulnerabilityReports", func(t *testing.T) {
+		labels := map[string]string{
+			starboard.LabelResourceKind:      "Deployment",
+			starboard.LabelResourceName:      "app1",
+			starboard.LabelResourceNamespace: "qa",
+			starboard.LabelContainerName:     "container1",
+		}
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deployment-5b4f8c9f7d",
+				Namespace: "qa",
+				Labels:    labels,
+			},
+		}).Build()
+		err := vulnerabilityreport.NewReadWriter(client).Write(context.TODO(), []v1alpha1.VulnerabilityReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app1-container1",
+					Namespace: "qa",
+					Labels:    labels,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var list v1alpha1.VulnerabilityReportList
+		err = client.List(context.TODO(), &list)
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "deployment-app1-container1", list.Items[0].Name)
+	})
+
 	t.Run("Should find VulnerabilityReports", func(t *testing.T) {
 		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(&v1alpha1.VulnerabilityReport{
 			ObjectMeta: metav1.ObjectMeta{
@@ -257,4 +331,95 @@ func TestNewReadWriter(t *testing.T) {
 		}, reports)
 	})
 
+	t.Run("Should find VulnerabilityReports by vulnerability ID", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-01",
+				Name:      "replicaset-app-6d4cf56db6-app",
+				Labels: map[string]string{
+					"starboard.vulnerability.CVE-2021-44228": "true",
+				},
+			},
+		}, &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-02",
+				Name:      "replicaset-app-7ff6d7c6d5-app",
+				Labels: map[string]string{
+					"starboard.vulnerability.CVE-2021-44228": "true",
+				},
+			},
+		}, &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-01",
+				Name:      "replicaset-nginx-6d4cf56db6-nginx",
+				Labels: map[string]string{
+					"starboard.vulnerability.CVE-2022-0778": "true",
+				},
+			},
+		}).Build()
+
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+		list, err := readWriter.FindByVulnerabilityID(context.TODO(), "", "CVE-2021-44228")
+		require.NoError(t, err)
+		reports := map[string]bool{}
+		for _, item := range list {
+			reports[item.Namespace+"/"+item.Name] = true
+		}
+		assert.Equal(t, map[string]bool{
+			"ns-01/replicaset-app-6d4cf56db6-app": true,
+			"ns-02/replicaset-app-7ff6d7c6d5-app": true,
+		}, reports)
+
+		list, err = readWriter.FindByVulnerabilityID(context.TODO(), "ns-01", "CVE-2021-44228")
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "replicaset-app-6d4cf56db6-app", list[0].Name)
+	})
+
+	t.Run("Should find VulnerabilityReports by image digest", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-01",
+				Name:      "replicaset-nginx-6d4cf56db6-nginx",
+				Labels: map[string]string{
+					starboard.LabelContainerName: "nginx",
+					starboard.LabelImageDigest:   "2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e776",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{},
+		}, &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-02",
+				Name:      "replicaset-nginx-7ff6d7c6d5-nginx",
+				Labels: map[string]string{
+					starboard.LabelContainerName: "nginx",
+					starboard.LabelImageDigest:   "2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e776",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{},
+		}, &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-02",
+				Name:      "replicaset-redis-5d8c5c97f9-redis",
+				Labels: map[string]string{
+					starboard.LabelContainerName: "redis",
+					starboard.LabelImageDigest:   "9b0cd132a5d40b1ac14ec4dc2d2b783c3eb618a1e2e4b1d3e5e8d1ad04d6fc3",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{},
+		}).Build()
+
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+		list, err := readWriter.FindByImageDigest(context.TODO(), "sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767")
+		require.NoError(t, err)
+		reports := map[string]bool{}
+		for _, item := range list {
+			reports[item.Namespace+"/"+item.Name] = true
+		}
+		assert.Equal(t, map[string]bool{
+			"ns-01/replicaset-nginx-6d4cf56db6-nginx": true,
+			"ns-02/replicaset-nginx-7ff6d7c6d5-nginx": true,
+		}, reports)
+	})
+
 }
@@ -0,0 +1,49 @@
+package vulnerabilityreport
+
+import (
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetDBMetadata returns the metadata of the vulnerability database used by
+// the scan job with the specified statuses of terminated containers, or nil
+// if the plugin does not implement DBMetadataParser or the metadata was not
+// recorded.
+func GetDBMetadata(plugin Plugin, ctx starboard.PluginContext, statuses map[string]*corev1.ContainerStateTerminated) (*DBMetadata, error) {
+	parser, ok := plugin.(DBMetadataParser)
+	if !ok {
+		return nil, nil
+	}
+	return parser.ParseDBMetadata(ctx, statuses)
+}
+
+// NewScanMetadata returns the metadata of the scan run by the scan job
+// container with the specified status, which used the vulnerability database
+// with the specified metadata. Either of them might be nil, in which case the
+// corresponding metadata is not recorded.
+func NewScanMetadata(status *corev1.ContainerStateTerminated, db *DBMetadata) *v1alpha1.ScanMetadata {
+	var metadata v1alpha1.ScanMetadata
+	if status != nil && !status.StartedAt.IsZero() {
+		startedAt := status.StartedAt
+		metadata.StartTimestamp = &startedAt
+		if status.FinishedAt.After(startedAt.Time) {
+			metadata.Duration = &metav1.Duration{Duration: status.FinishedAt.Sub(startedAt.Time)}
+		}
+	}
+	if db != nil && !db.UpdatedAt.IsZero() {
+		metadata.DB = &v1alpha1.VulnerabilityDB{
+			Version:   db.Version,
+			UpdatedAt: metav1.NewTime(db.UpdatedAt),
+		}
+		if !db.NextUpdate.IsZero() {
+			nextUpdate := metav1.NewTime(db.NextUpdate)
+			metadata.DB.NextUpdate = &nextUpdate
+		}
+	}
+	if metadata.StartTimestamp == nil && metadata.DB == nil {
+		return nil
+	}
+	return &metadata
+}
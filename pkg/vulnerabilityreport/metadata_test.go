@@ -0,0 +1,56 @@
+package vulnerabilityreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewScanMetadata(t *testing.T) {
+	startedAt := metav1.NewTime(time.Date(2022, 3, 1, 8, 0, 0, 0, time.UTC))
+	finishedAt := metav1.NewTime(time.Date(2022, 3, 1, 8, 1, 30, 0, time.UTC))
+	updatedAt := time.Date(2022, 3, 1, 6, 0, 0, 0, time.UTC)
+	nextUpdate := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should return metadata of scan and database", func(t *testing.T) {
+		metadata := vulnerabilityreport.NewScanMetadata(&corev1.ContainerStateTerminated{
+			StartedAt:  startedAt,
+			FinishedAt: finishedAt,
+		}, &vulnerabilityreport.DBMetadata{
+			Version:    2,
+			UpdatedAt:  updatedAt,
+			NextUpdate: nextUpdate,
+		})
+		expectedNextUpdate := metav1.NewTime(nextUpdate)
+		assert.Equal(t, &v1alpha1.ScanMetadata{
+			StartTimestamp: &startedAt,
+			Duration:       &metav1.Duration{Duration: 90 * time.Second},
+			DB: &v1alpha1.VulnerabilityDB{
+				Version:    2,
+				UpdatedAt:  metav1.NewTime(updatedAt),
+				NextUpdate: &expectedNextUpdate,
+			},
+		}, metadata)
+	})
+
+	t.Run("Should return metadata of scan without database", func(t *testing.T) {
+		metadata := vulnerabilityreport.NewScanMetadata(&corev1.ContainerStateTerminated{
+			StartedAt:  startedAt,
+			FinishedAt: finishedAt,
+		}, nil)
+		assert.Equal(t, &v1alpha1.ScanMetadata{
+			StartTimestamp: &startedAt,
+			Duration:       &metav1.Duration{Duration: 90 * time.Second},
+		}, metadata)
+	})
+
+	t.Run("Should return nil without metadata", func(t *testing.T) {
+		assert.Nil(t, vulnerabilityreport.NewScanMetadata(nil, nil))
+		assert.Nil(t, vulnerabilityreport.NewScanMetadata(&corev1.ContainerStateTerminated{}, &vulnerabilityreport.DBMetadata{}))
+	})
+}
@@ -0,0 +1,161 @@
+package vulnerabilityreport
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+)
+
+// PackageSummary is the summary of a vulnerable version of a package across
+// images run in the cluster.
+type PackageSummary struct {
+	// Package is the name of the package.
+	Package string `json:"package"`
+
+	// Version is the installed version of the package.
+	Version string `json:"version"`
+
+	// Ecosystem is the ecosystem of the package, if known.
+	Ecosystem string `json:"ecosystem,omitempty"`
+
+	// FixedVersion is the lowest version that fixes all vulnerabilities of
+	// the package, if any of them is fixed.
+	FixedVersion string `json:"fixedVersion,omitempty"`
+
+	// Summary are counts of unique vulnerabilities of the package.
+	Summary v1alpha1.VulnerabilitySummary `json:"summary"`
+
+	// VulnerabilityIDs are IDs of vulnerabilities of the package.
+	VulnerabilityIDs []string `json:"vulnerabilityIDs"`
+
+	// Images are references of images that contain the package.
+	Images []string `json:"images"`
+
+	// Workloads is the number of workloads that run images with the package.
+	Workloads int `json:"workloads"`
+}
+
+// SummarizePackages returns the summary of each vulnerable version of a
+// package in the specified reports. Reports are grouped by images as by
+// SummarizeImages, so each image is counted once based on its newest report.
+func SummarizePackages(reports []v1alpha1.VulnerabilityReport) []PackageSummary {
+	var packages []PackageSummary
+	indexes := make(map[string]int)
+	ids := make(map[string]map[string]bool)
+	images := make(map[string]map[string]bool)
+	workloads := make(map[string]map[kube.ObjectRef]bool)
+	for _, group := range groupByImage(reports) {
+		image := GetImageRef(group.newest)
+		for _, vulnerability := range group.newest.Vulnerabilities {
+			key := vulnerability.Resource + "@" + vulnerability.InstalledVersion
+			index, ok := indexes[key]
+			if !ok {
+				index = len(packages)
+				indexes[key] = index
+				packages = append(packages, PackageSummary{
+					Package:          vulnerability.Resource,
+					Version:          vulnerability.InstalledVersion,
+					Ecosystem:        vulnerability.Ecosystem,
+					VulnerabilityIDs: []string{},
+					Images:           []string{},
+				})
+				ids[key] = make(map[string]bool)
+				images[key] = make(map[string]bool)
+				workloads[key] = make(map[kube.ObjectRef]bool)
+			}
+			summary := &packages[index]
+			if !ids[key][vulnerability.VulnerabilityID] {
+				ids[key][vulnerability.VulnerabilityID] = true
+				summary.VulnerabilityIDs = append(summary.VulnerabilityIDs, vulnerability.VulnerabilityID)
+				countSeverity(&summary.Summary, vulnerability.Severity)
+				fixed := lowestFix(vulnerability.InstalledVersion, vulnerability.FixedVersion)
+				if fixed != "" && CompareVersions(fixed, summary.FixedVersion) > 0 {
+					summary.FixedVersion = fixed
+				}
+			}
+			if !images[key][image] {
+				images[key][image] = true
+				summary.Images = append(summary.Images, image)
+			}
+			for _, container := range group.containers {
+				if workload := container.Workload(); !workloads[key][workload] {
+					workloads[key][workload] = true
+					summary.Workloads++
+				}
+			}
+		}
+	}
+	for i := range packages {
+		sort.Strings(packages[i].Images)
+	}
+	return packages
+}
+
+func countSeverity(summary *v1alpha1.VulnerabilitySummary, severity v1alpha1.Severity) {
+	switch severity {
+	case v1alpha1.SeverityCritical:
+		summary.CriticalCount++
+	case v1alpha1.SeverityHigh:
+		summary.HighCount++
+	case v1alpha1.SeverityMedium:
+		summary.MediumCount++
+	case v1alpha1.SeverityLow:
+		summary.LowCount++
+	default:
+		summary.UnknownCount++
+	}
+}
+
+// SortPackages sorts the specified packages by the number of images that
+// contain them, the most widespread packages first. Ties are broken by the
+// number of critical and high vulnerabilities, and then by names and
+// versions.
+func SortPackages(packages []PackageSummary) {
+	sort.SliceStable(packages, func(i, j int) bool {
+		a, b := packages[i], packages[j]
+		for _, counts := range [][2]int{
+			{len(a.Images), len(b.Images)},
+			{a.Summary.CriticalCount, b.Summary.CriticalCount},
+			{a.Summary.HighCount, b.Summary.HighCount},
+			{a.Workloads, b.Workloads},
+		} {
+			if counts[0] != counts[1] {
+				return counts[0] > counts[1]
+			}
+		}
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		return a.Version < b.Version
+	})
+}
+
+// WritePackagesTable writes the specified packages as rows of the table with
+// counts of images, workloads, and vulnerabilities of each package. At most
+// top packages are written, or all packages if top is not positive.
+func WritePackagesTable(packages []PackageSummary, top int, out io.Writer) error {
+	if top > 0 && len(packages) > top {
+		packages = packages[:top]
+	}
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "PACKAGE\tVERSION\tIMAGES\tWORKLOADS\tCRITICAL\tHIGH\tMEDIUM\tLOW\tUNKNOWN\tFIXED"); err != nil {
+		return err
+	}
+	for _, p := range packages {
+		fixed := p.FixedVersion
+		if fixed == "" {
+			fixed = "-"
+		}
+		_, err := fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n", p.Package, p.Version, len(p.Images),
+			p.Workloads, p.Summary.CriticalCount, p.Summary.HighCount, p.Summary.MediumCount, p.Summary.LowCount,
+			p.Summary.UnknownCount, fixed)
+		if err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
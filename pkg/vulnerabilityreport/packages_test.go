@@ -0,0 +1,80 @@
+package vulnerabilityreport_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizePackages(t *testing.T) {
+	openssl := []v1alpha1.Vulnerability{
+		{VulnerabilityID: "CVE-2022-0778", Resource: "openssl", InstalledVersion: "1.1.1k-r0",
+			FixedVersion: "1.1.1n-r0", Severity: v1alpha1.SeverityHigh, Ecosystem: "os"},
+		{VulnerabilityID: "CVE-2021-3711", Resource: "openssl", InstalledVersion: "1.1.1k-r0",
+			FixedVersion: "1.1.1l-r0", Severity: v1alpha1.SeverityCritical, Ecosystem: "os"},
+	}
+	log4j := v1alpha1.Vulnerability{VulnerabilityID: "CVE-2021-44228", Resource: "log4j-core", InstalledVersion: "2.14.1",
+		FixedVersion: "2.15.0", Severity: v1alpha1.SeverityCritical, Ecosystem: "jar"}
+	curl := v1alpha1.Vulnerability{VulnerabilityID: "CVE-2022-32221", Resource: "curl", InstalledVersion: "7.79.1-r0",
+		Severity: v1alpha1.SeverityCritical, Ecosystem: "os"}
+
+	reports := []v1alpha1.VulnerabilityReport{
+		newImageReport("default", "ReplicaSet", "nginx-6d4cf56db6", "nginx", "library/nginx", "1.16", "sha256:aaa",
+			scannedAt, v1alpha1.VulnerabilitySummary{}),
+		newImageReport("staging", "ReplicaSet", "web-7b5d8f9c4", "web", "library/nginx", "1.16", "sha256:aaa",
+			scannedAt, v1alpha1.VulnerabilitySummary{}),
+		newImageReport("default", "StatefulSet", "solr", "solr", "library/solr", "8.11.0", "sha256:bbb",
+			scannedAt, v1alpha1.VulnerabilitySummary{}),
+		newImageReport("default", "DaemonSet", "agent", "agent", "library/agent", "1.0", "sha256:ccc",
+			scannedAt, v1alpha1.VulnerabilitySummary{}),
+	}
+	reports[0].Report.Vulnerabilities = openssl
+	reports[1].Report.Vulnerabilities = openssl
+	reports[2].Report.Vulnerabilities = append([]v1alpha1.Vulnerability{log4j}, openssl...)
+	reports[3].Report.Vulnerabilities = []v1alpha1.Vulnerability{curl}
+
+	packages := vulnerabilityreport.SummarizePackages(reports)
+	vulnerabilityreport.SortPackages(packages)
+	assert.Equal(t, []vulnerabilityreport.PackageSummary{
+		{
+			Package:          "openssl",
+			Version:          "1.1.1k-r0",
+			Ecosystem:        "os",
+			FixedVersion:     "1.1.1n-r0",
+			Summary:          v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 1},
+			VulnerabilityIDs: []string{"CVE-2022-0778", "CVE-2021-3711"},
+			Images:           []string{"index.docker.io/library/nginx:1.16", "index.docker.io/library/solr:8.11.0"},
+			Workloads:        3,
+		},
+		{
+			Package:          "curl",
+			Version:          "7.79.1-r0",
+			Ecosystem:        "os",
+			Summary:          v1alpha1.VulnerabilitySummary{CriticalCount: 1},
+			VulnerabilityIDs: []string{"CVE-2022-32221"},
+			Images:           []string{"index.docker.io/library/agent:1.0"},
+			Workloads:        1,
+		},
+		{
+			Package:          "log4j-core",
+			Version:          "2.14.1",
+			Ecosystem:        "jar",
+			FixedVersion:     "2.15.0",
+			Summary:          v1alpha1.VulnerabilitySummary{CriticalCount: 1},
+			VulnerabilityIDs: []string{"CVE-2021-44228"},
+			Images:           []string{"index.docker.io/library/solr:8.11.0"},
+			Workloads:        1,
+		},
+	}, packages)
+
+	var out bytes.Buffer
+	require.NoError(t, vulnerabilityreport.WritePackagesTable(packages, 2, &out))
+	assert.Equal(t, `PACKAGE  VERSION    IMAGES  WORKLOADS  CRITICAL  HIGH  MEDIUM  LOW  UNKNOWN  FIXED
+openssl  1.1.1k-r0  2       3          1         1     0       0    0        1.1.1n-r0
+curl     7.79.1-r0  1       1          1         0     0       0    0        -
+`, out.String())
+}
@@ -2,6 +2,7 @@ package vulnerabilityreport
 
 import (
 	"io"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/docker"
@@ -32,3 +33,26 @@ type Plugin interface {
 	ParseVulnerabilityReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (
 		v1alpha1.VulnerabilityReportData, error)
 }
+
+// DBMetadata describes the vulnerability database used by a scan job.
+type DBMetadata struct {
+	// Version is the schema version of the database.
+	Version int
+
+	// UpdatedAt is the time when the database was built.
+	UpdatedAt time.Time
+
+	// NextUpdate is the time when the next build of the database is expected.
+	NextUpdate time.Time
+}
+
+// DBMetadataParser is an optional interface of plugins whose scan jobs record
+// metadata of the vulnerability database in termination messages of their
+// containers, so that the freshness of the database can be monitored.
+type DBMetadataParser interface {
+
+	// ParseDBMetadata returns the metadata of the vulnerability database used
+	// by the scan job with the specified statuses of terminated containers, or
+	// nil if the metadata was not recorded.
+	ParseDBMetadata(ctx starboard.PluginContext, statuses map[string]*corev1.ContainerStateTerminated) (*DBMetadata, error)
+}
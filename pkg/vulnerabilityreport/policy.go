@@ -0,0 +1,73 @@
+package vulnerabilityreport
+
+import (
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// IsFiltering returns true if the specified ScanPolicySpec drops any
+// vulnerabilities from reports.
+func IsFiltering(policy v1alpha1.ScanPolicySpec) bool {
+	return len(policy.Severities) > 0 || len(policy.IgnoredVulnerabilities) > 0
+}
+
+// ApplyScanPolicy returns a copy of the specified report data without the
+// vulnerabilities that are dropped by the severity filter or ignored by the
+// specified ScanPolicySpec. Counts of the summary are recalculated from the
+// vulnerabilities that are kept.
+func ApplyScanPolicy(data v1alpha1.VulnerabilityReportData, policy v1alpha1.ScanPolicySpec) v1alpha1.VulnerabilityReportData {
+	filtered := *data.DeepCopy()
+	if !IsFiltering(policy) {
+		return filtered
+	}
+
+	severities := make(map[v1alpha1.Severity]bool)
+	for _, severity := range policy.Severities {
+		severities[severity] = true
+	}
+	ignored := make(map[string]bool)
+	for _, id := range policy.IgnoredVulnerabilities {
+		ignored[id] = true
+	}
+
+	filtered.Vulnerabilities = []v1alpha1.Vulnerability{}
+	for _, vulnerability := range data.Vulnerabilities {
+		if len(severities) > 0 && !severities[vulnerability.Severity] {
+			continue
+		}
+		if ignored[vulnerability.VulnerabilityID] {
+			continue
+		}
+		filtered.Vulnerabilities = append(filtered.Vulnerabilities, *vulnerability.DeepCopy())
+	}
+	filtered.Summary = summarize(filtered.Vulnerabilities, data.Summary.NoneCount)
+	return filtered
+}
+
+// ApplyEcosystems returns a copy of the specified report data without the
+// vulnerabilities of ecosystems disabled by the specified toggles. Counts of
+// the summary are recalculated from the vulnerabilities that are kept.
+func ApplyEcosystems(data v1alpha1.VulnerabilityReportData, ecosystems starboard.Ecosystems) v1alpha1.VulnerabilityReportData {
+	filtered := *data.DeepCopy()
+	if !ecosystems.IsFiltering() {
+		return filtered
+	}
+	filtered.Vulnerabilities = []v1alpha1.Vulnerability{}
+	for _, vulnerability := range data.Vulnerabilities {
+		if ecosystems.IsEnabled(vulnerability.Ecosystem) {
+			filtered.Vulnerabilities = append(filtered.Vulnerabilities, *vulnerability.DeepCopy())
+		}
+	}
+	filtered.Summary = summarize(filtered.Vulnerabilities, data.Summary.NoneCount)
+	return filtered
+}
+
+func summarize(vulnerabilities []v1alpha1.Vulnerability, noneCount int) v1alpha1.VulnerabilitySummary {
+	summary := v1alpha1.VulnerabilitySummary{
+		NoneCount: noneCount,
+	}
+	for _, vulnerability := range vulnerabilities {
+		countSeverity(&summary, vulnerability.Severity)
+	}
+	return summary
+}
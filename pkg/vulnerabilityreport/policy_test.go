@@ -0,0 +1,93 @@
+package vulnerabilityreport_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyScanPolicy(t *testing.T) {
+	data := v1alpha1.VulnerabilityReportData{
+		Summary: v1alpha1.VulnerabilitySummary{
+			CriticalCount: 1,
+			HighCount:     2,
+			LowCount:      1,
+			NoneCount:     7,
+		},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2021-44228", Severity: v1alpha1.SeverityCritical},
+			{VulnerabilityID: "CVE-2021-45046", Severity: v1alpha1.SeverityHigh},
+			{VulnerabilityID: "CVE-2021-45105", Severity: v1alpha1.SeverityHigh},
+			{VulnerabilityID: "CVE-2022-0778", Severity: v1alpha1.SeverityLow},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		policy          v1alpha1.ScanPolicySpec
+		expectedSummary v1alpha1.VulnerabilitySummary
+		expectedIDs     []string
+	}{
+		{
+			name:            "Should keep all vulnerabilities when policy does not filter",
+			policy:          v1alpha1.ScanPolicySpec{},
+			expectedSummary: data.Summary,
+			expectedIDs:     []string{"CVE-2021-44228", "CVE-2021-45046", "CVE-2021-45105", "CVE-2022-0778"},
+		},
+		{
+			name: "Should keep vulnerabilities with specified severities",
+			policy: v1alpha1.ScanPolicySpec{
+				Severities: []v1alpha1.Severity{v1alpha1.SeverityCritical, v1alpha1.SeverityHigh},
+			},
+			expectedSummary: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 2, NoneCount: 7},
+			expectedIDs:     []string{"CVE-2021-44228", "CVE-2021-45046", "CVE-2021-45105"},
+		},
+		{
+			name: "Should drop ignored vulnerabilities",
+			policy: v1alpha1.ScanPolicySpec{
+				Severities:             []v1alpha1.Severity{v1alpha1.SeverityHigh},
+				IgnoredVulnerabilities: []string{"CVE-2021-45105"},
+			},
+			expectedSummary: v1alpha1.VulnerabilitySummary{HighCount: 1, NoneCount: 7},
+			expectedIDs:     []string{"CVE-2021-45046"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filtered := vulnerabilityreport.ApplyScanPolicy(data, tc.policy)
+			assert.Equal(t, tc.expectedSummary, filtered.Summary)
+			var ids []string
+			for _, vulnerability := range filtered.Vulnerabilities {
+				ids = append(ids, vulnerability.VulnerabilityID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+	assert.Len(t, data.Vulnerabilities, 4, "data must not be modified")
+}
+
+func TestApplyEcosystems(t *testing.T) {
+	data := v1alpha1.VulnerabilityReportData{
+		Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 1, MediumCount: 1},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2021-44228", Severity: v1alpha1.SeverityCritical, Ecosystem: "jar"},
+			{VulnerabilityID: "CVE-2022-0778", Severity: v1alpha1.SeverityHigh, Ecosystem: "os"},
+			{VulnerabilityID: "CVE-2022-1000", Severity: v1alpha1.SeverityMedium},
+		},
+	}
+
+	filtered := vulnerabilityreport.ApplyEcosystems(data, starboard.Ecosystems{"jar": false, "npm": true})
+	assert.Equal(t, v1alpha1.VulnerabilitySummary{HighCount: 1, MediumCount: 1}, filtered.Summary)
+	var ids []string
+	for _, vulnerability := range filtered.Vulnerabilities {
+		ids = append(ids, vulnerability.VulnerabilityID)
+	}
+	assert.Equal(t, []string{"CVE-2022-0778", "CVE-2022-1000"}, ids)
+	assert.Len(t, data.Vulnerabilities, 3, "data must not be modified")
+
+	assert.Equal(t, data, vulnerabilityreport.ApplyEcosystems(data, starboard.Ecosystems{"os": true}))
+}
@@ -0,0 +1,259 @@
+package vulnerabilityreport
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// maxListedIDs is the maximum number of vulnerability IDs listed with each
+// upgrade by WriteRemediations.
+const maxListedIDs = 2
+
+// Upgrade is the upgrade of a package that fixes vulnerabilities.
+type Upgrade struct {
+	Package          string `json:"package"`
+	InstalledVersion string `json:"installedVersion"`
+	// FixedVersion is the lowest version that fixes all vulnerabilities.
+	FixedVersion string `json:"fixedVersion"`
+	// Severity is the highest severity of fixed vulnerabilities.
+	Severity         v1alpha1.Severity `json:"severity"`
+	VulnerabilityIDs []string          `json:"vulnerabilityIDs"`
+}
+
+// Remediation is the minimal set of package upgrades that fixes
+// vulnerabilities of a container.
+type Remediation struct {
+	Container string    `json:"container"`
+	Image     string    `json:"image"`
+	Upgrades  []Upgrade `json:"upgrades"`
+	// UnfixedIDs are IDs of vulnerabilities without fixed versions.
+	UnfixedIDs []string `json:"unfixedIDs"`
+	// BaseImage is the base image of the image if it's detected and a newer,
+	// patched base image probably exists.
+	BaseImage *v1alpha1.BaseImage `json:"baseImage,omitempty"`
+}
+
+// NewRemediation returns the remediation of vulnerabilities with the
+// specified severities in the specified report. Vulnerabilities of the same
+// package are fixed by a single upgrade to the highest of versions that fix
+// each of them.
+func NewRemediation(report v1alpha1.VulnerabilityReport, severities []v1alpha1.Severity) Remediation {
+	remediation := Remediation{
+		Container:  report.Labels[starboard.LabelContainerName],
+		Image:      GetImageRef(report.Report),
+		Upgrades:   []Upgrade{},
+		UnfixedIDs: []string{},
+	}
+	if baseImage := report.Report.BaseImage; baseImage != nil && baseImage.UpdateAvailable {
+		remediation.BaseImage = baseImage.DeepCopy()
+	}
+	included := make(map[v1alpha1.Severity]bool)
+	for _, severity := range severities {
+		included[severity] = true
+	}
+	indexes := make(map[string]int)
+	for _, vulnerability := range report.Report.Vulnerabilities {
+		if !included[vulnerability.Severity] {
+			continue
+		}
+		fixed := lowestFix(vulnerability.InstalledVersion, vulnerability.FixedVersion)
+		if fixed == "" {
+			remediation.UnfixedIDs = append(remediation.UnfixedIDs, vulnerability.VulnerabilityID)
+			continue
+		}
+		key := vulnerability.Resource + "@" + vulnerability.InstalledVersion
+		index, ok := indexes[key]
+		if !ok {
+			index = len(remediation.Upgrades)
+			indexes[key] = index
+			remediation.Upgrades = append(remediation.Upgrades, Upgrade{
+				Package:          vulnerability.Resource,
+				InstalledVersion: vulnerability.InstalledVersion,
+				FixedVersion:     fixed,
+				Severity:         vulnerability.Severity,
+			})
+		}
+		upgrade := &remediation.Upgrades[index]
+		if CompareVersions(fixed, upgrade.FixedVersion) > 0 {
+			upgrade.FixedVersion = fixed
+		}
+		if severityOrder[vulnerability.Severity] < severityOrder[upgrade.Severity] {
+			upgrade.Severity = vulnerability.Severity
+		}
+		upgrade.VulnerabilityIDs = append(upgrade.VulnerabilityIDs, vulnerability.VulnerabilityID)
+	}
+	sort.SliceStable(remediation.Upgrades, func(i, j int) bool {
+		a, b := remediation.Upgrades[i], remediation.Upgrades[j]
+		if severityOrder[a.Severity] != severityOrder[b.Severity] {
+			return severityOrder[a.Severity] < severityOrder[b.Severity]
+		}
+		if len(a.VulnerabilityIDs) != len(b.VulnerabilityIDs) {
+			return len(a.VulnerabilityIDs) > len(b.VulnerabilityIDs)
+		}
+		return a.Package < b.Package
+	})
+	return remediation
+}
+
+// lowestFix returns the lowest of the specified comma-separated fixed
+// versions that is higher than the installed version, e.g. the fix in the
+// release line of the installed version, or the highest fixed version if none
+// is higher than the installed version.
+func lowestFix(installed, fixedVersions string) string {
+	var lowest, highest string
+	for _, fixed := range strings.Split(fixedVersions, ",") {
+		fixed = strings.TrimSpace(fixed)
+		if fixed == "" {
+			continue
+		}
+		if highest == "" || CompareVersions(fixed, highest) > 0 {
+			highest = fixed
+		}
+		if CompareVersions(fixed, installed) > 0 && (lowest == "" || CompareVersions(fixed, lowest) < 0) {
+			lowest = fixed
+		}
+	}
+	if lowest == "" {
+		return highest
+	}
+	return lowest
+}
+
+// CompareVersions compares the specified versions of a package and returns
+// -1, 0, or 1 if the first version is lower than, equal to, or higher than
+// the second. Versions are compared segment by segment, where numeric
+// segments are compared as numbers and other segments as strings, which is a
+// best effort across versioning schemes of different ecosystems, e.g.
+// 1.1.1d-0+deb10u3 and 2.14.1.
+func CompareVersions(a, b string) int {
+	as, bs := versionSegments(a), versionSegments(b)
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareSegments(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func versionSegments(version string) []string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	var segments []string
+	var current []rune
+	digits := false
+	for _, char := range version {
+		if !unicode.IsDigit(char) && !unicode.IsLetter(char) {
+			if len(current) > 0 {
+				segments = append(segments, string(current))
+				current = nil
+			}
+			continue
+		}
+		if len(current) > 0 && unicode.IsDigit(char) != digits {
+			segments = append(segments, string(current))
+			current = nil
+		}
+		digits = unicode.IsDigit(char)
+		current = append(current, char)
+	}
+	if len(current) > 0 {
+		segments = append(segments, string(current))
+	}
+	return segments
+}
+
+func compareSegments(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		}
+		return 0
+	case aErr == nil:
+		// Numbers are higher than letters, e.g. 1.0.1 is higher than 1.0.rc1.
+		return 1
+	case bErr == nil:
+		return -1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// WriteRemediations writes upgrades of each of the specified remediations as
+// rows of a table preceded by the container and its image.
+func WriteRemediations(remediations []Remediation, out io.Writer) error {
+	for i, remediation := range remediations {
+		if i > 0 {
+			if _, err := fmt.Fprintln(out); err != nil {
+				return err
+			}
+		}
+		fixed := 0
+		for _, upgrade := range remediation.Upgrades {
+			fixed += len(upgrade.VulnerabilityIDs)
+		}
+		_, err := fmt.Fprintf(out, "Container %s (%s): %d upgrades fix %d of %d vulnerabilities\n", remediation.Container,
+			remediation.Image, len(remediation.Upgrades), fixed, fixed+len(remediation.UnfixedIDs))
+		if err != nil {
+			return err
+		}
+		if len(remediation.Upgrades) > 0 {
+			w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "PACKAGE\tINSTALLED\tFIXED\tSEVERITY\tVULNERABILITIES")
+			for _, upgrade := range remediation.Upgrades {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", upgrade.Package, upgrade.InstalledVersion,
+					upgrade.FixedVersion, upgrade.Severity, formatIDs(upgrade.VulnerabilityIDs))
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+		if len(remediation.UnfixedIDs) > 0 {
+			_, err := fmt.Fprintf(out, "Vulnerabilities without fixed versions (%d): %s\n", len(remediation.UnfixedIDs),
+				strings.Join(remediation.UnfixedIDs, ", "))
+			if err != nil {
+				return err
+			}
+		}
+		if baseImage := remediation.BaseImage; baseImage != nil {
+			if _, err := fmt.Fprintf(out, "Base image: %s\n", formatBaseImageHint(*baseImage)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func formatBaseImageHint(baseImage v1alpha1.BaseImage) string {
+	if baseImage.EndOfServiceLife {
+		return fmt.Sprintf("%s reached the end of service life, rebuild the image from a supported base image", baseImage.Name)
+	}
+	return fmt.Sprintf("rebuilding the image from the latest patch of %s may fix %d vulnerabilities of OS packages",
+		baseImage.Name, baseImage.FixableCount)
+}
+
+func formatIDs(ids []string) string {
+	if len(ids) > maxListedIDs {
+		return strings.Join(ids[:maxListedIDs], ", ") + fmt.Sprintf(" and %d more", len(ids)-maxListedIDs)
+	}
+	return strings.Join(ids, ", ")
+}
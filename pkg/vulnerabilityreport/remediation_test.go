@@ -0,0 +1,87 @@
+package vulnerabilityreport_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCompareVersions(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "2.14.1", b: "2.15.0", expected: -1},
+		{a: "2.15.0", b: "2.15.0", expected: 0},
+		{a: "v1.10.0", b: "1.9.3", expected: 1},
+		{a: "1.1.1d-0+deb10u3", b: "1.1.1n-0+deb10u1", expected: -1},
+		{a: "1.1.1n-0+deb10u1", b: "1.1.1n-0+deb10u1", expected: 0},
+		{a: "2.2.0", b: "2.2.0.1", expected: -1},
+		{a: "1.0.1", b: "1.0.rc1", expected: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			assert.Equal(t, tc.expected, vulnerabilityreport.CompareVersions(tc.a, tc.b))
+		})
+	}
+}
+
+func TestNewRemediation(t *testing.T) {
+	report := v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{starboard.LabelContainerName: "app"},
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			Registry: v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact: v1alpha1.Artifact{Repository: "library/app", Tag: "1.0"},
+			BaseImage: &v1alpha1.BaseImage{Name: "debian:10.10", Source: v1alpha1.BaseImageSourceOS, FixableCount: 3,
+				UpdateAvailable: true},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2021-44228", Resource: "log4j-core", InstalledVersion: "2.14.1",
+					FixedVersion: "2.15.0", Severity: v1alpha1.SeverityCritical},
+				{VulnerabilityID: "CVE-2021-45046", Resource: "log4j-core", InstalledVersion: "2.14.1",
+					FixedVersion: "2.12.2, 2.16.0", Severity: v1alpha1.SeverityCritical},
+				{VulnerabilityID: "CVE-2022-0778", Resource: "openssl", InstalledVersion: "1.1.1d-0+deb10u3",
+					FixedVersion: "1.1.1d-0+deb10u8", Severity: v1alpha1.SeverityHigh},
+				{VulnerabilityID: "CVE-2021-3711", Resource: "openssl", InstalledVersion: "1.1.1d-0+deb10u3",
+					FixedVersion: "1.1.1d-0+deb10u7", Severity: v1alpha1.SeverityCritical},
+				{VulnerabilityID: "CVE-2019-19603", Resource: "sqlite3", InstalledVersion: "3.27.2-3",
+					FixedVersion: "", Severity: v1alpha1.SeverityHigh},
+				{VulnerabilityID: "CVE-2022-1304", Resource: "e2fsprogs", InstalledVersion: "1.44.5-1",
+					FixedVersion: "1.44.5-1+deb10u4", Severity: v1alpha1.SeverityMedium},
+			},
+		},
+	}
+
+	remediation := vulnerabilityreport.NewRemediation(report, []v1alpha1.Severity{v1alpha1.SeverityCritical, v1alpha1.SeverityHigh})
+	assert.Equal(t, vulnerabilityreport.Remediation{
+		Container: "app",
+		Image:     "index.docker.io/library/app:1.0",
+		Upgrades: []vulnerabilityreport.Upgrade{
+			{Package: "log4j-core", InstalledVersion: "2.14.1", FixedVersion: "2.16.0", Severity: v1alpha1.SeverityCritical,
+				VulnerabilityIDs: []string{"CVE-2021-44228", "CVE-2021-45046"}},
+			{Package: "openssl", InstalledVersion: "1.1.1d-0+deb10u3", FixedVersion: "1.1.1d-0+deb10u8",
+				Severity: v1alpha1.SeverityCritical, VulnerabilityIDs: []string{"CVE-2022-0778", "CVE-2021-3711"}},
+		},
+		UnfixedIDs: []string{"CVE-2019-19603"},
+		BaseImage: &v1alpha1.BaseImage{Name: "debian:10.10", Source: v1alpha1.BaseImageSourceOS, FixableCount: 3,
+			UpdateAvailable: true},
+	}, remediation)
+
+	var out bytes.Buffer
+	err := vulnerabilityreport.WriteRemediations([]vulnerabilityreport.Remediation{remediation}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, `Container app (index.docker.io/library/app:1.0): 2 upgrades fix 4 of 5 vulnerabilities
+PACKAGE     INSTALLED         FIXED             SEVERITY  VULNERABILITIES
+log4j-core  2.14.1            2.16.0            CRITICAL  CVE-2021-44228, CVE-2021-45046
+openssl     1.1.1d-0+deb10u3  1.1.1d-0+deb10u8  CRITICAL  CVE-2022-0778, CVE-2021-3711
+Vulnerabilities without fixed versions (1): CVE-2019-19603
+Base image: rebuilding the image from the latest patch of debian:10.10 may fix 3 vulnerabilities of OS packages
+`, out.String())
+}
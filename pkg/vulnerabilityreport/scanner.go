@@ -9,6 +9,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/runner"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
@@ -16,10 +17,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ImageContainerName is the name of the container of reports returned by
+// Scanner.ScanImage.
+const ImageContainerName = "image"
+
 // Scanner is a template for running static vulnerability scanners that implement
 // the Plugin interface.
 type Scanner struct {
 	scheme         *runtime.Scheme
+	client         client.Client
 	clientset      kubernetes.Interface
 	plugin         Plugin
 	pluginContext  starboard.PluginContext
@@ -27,7 +33,6 @@ type Scanner struct {
 	logsReader     kube.LogsReader
 	config         starboard.ConfigData
 	opts           kube.ScannerOpts
-	secretsReader  kube.SecretsReader
 }
 
 // NewScanner constructs a new static vulnerability Scanner with the specified
@@ -44,6 +49,7 @@ func NewScanner(
 ) *Scanner {
 	return &Scanner{
 		scheme:         client.Scheme(),
+		client:         client,
 		clientset:      clientset,
 		opts:           opts,
 		plugin:         plugin,
@@ -51,7 +57,6 @@ func NewScanner(
 		objectResolver: &kube.ObjectResolver{Client: client},
 		logsReader:     kube.NewLogsReader(clientset),
 		config:         config,
-		secretsReader:  kube.NewSecretsReader(client),
 	}
 }
 
@@ -74,11 +79,78 @@ func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1
 		return nil, err
 	}
 
+	return s.scan(ctx, owner)
+}
+
+// ScanImage creates a Kubernetes job to scan the specified image reference,
+// which doesn't have to be deployed in the cluster. The image is scanned as
+// the only container of a Pod in the specified namespace, which is never
+// created but allows pulling the image with the specified image pull Secrets.
+//
+// Returned reports are not owned by any object, therefore they are meant to be
+// printed rather than stored.
+func (s *Scanner) ScanImage(ctx context.Context, image, namespace string, imagePullSecrets []string) ([]v1alpha1.VulnerabilityReport, error) {
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       string(kube.KindPod),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "image-" + kube.ComputeHash(image),
+			Namespace: namespace,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  ImageContainerName,
+				Image: image,
+			}},
+		},
+	}
+	for _, name := range imagePullSecrets {
+		pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+	}
+
+	reports, err := s.scan(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	for i := range reports {
+		reports[i].OwnerReferences = nil
+	}
+	return reports, nil
+}
+
+func (s *Scanner) scan(ctx context.Context, owner client.Object) ([]v1alpha1.VulnerabilityReport, error) {
 	scanJobTolerations, err := s.config.GetScanJobTolerations()
 	if err != nil {
 		return nil, fmt.Errorf("getting scan job tolerations: %w", err)
 	}
 
+	scanJobScheduling, err := s.config.GetScanJobScheduling()
+	if err != nil {
+		return nil, fmt.Errorf("getting scan job scheduling: %w", err)
+	}
+
+	scanJobSandbox, err := s.config.GetScanJobSandbox()
+	if err != nil {
+		return nil, fmt.Errorf("getting scan job sandbox: %w", err)
+	}
+
+	scanJobImages, err := s.config.GetScanJobImages()
+	if err != nil {
+		return nil, fmt.Errorf("getting scan job images: %w", err)
+	}
+
+	pluginConfig, err := s.pluginContext.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("getting plugin config: %w", err)
+	}
+
+	scanJobOverride, err := pluginConfig.GetScanJobOverride(owner.GetNamespace())
+	if err != nil {
+		return nil, fmt.Errorf("getting scan job override: %w", err)
+	}
+
 	scanJobAnnotations, err := s.config.GetScanJobAnnotations()
 	if err != nil {
 		return nil, fmt.Errorf("getting scan job annotations: %w", err)
@@ -89,9 +161,19 @@ func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1
 		return nil, fmt.Errorf("getting scan job template labels: %w", err)
 	}
 
+	commonMetadata, err := s.config.GetCommonMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("getting common metadata: %w", err)
+	}
+
 	klog.V(3).Infof("Scanning with options: %+v", s.opts)
 
-	credentials, err := s.secretsReader.CredentialsByWorkload(ctx, owner)
+	credentialsProviders, err := kube.NewCredentialsProviders(s.client, s.pluginContext.GetNamespace(), s.config)
+	if err != nil {
+		return nil, fmt.Errorf("constructing registry credentials providers: %w", err)
+	}
+
+	credentials, err := kube.NewSecretsReader(s.client, credentialsProviders...).CredentialsByWorkload(ctx, owner)
 	if err != nil {
 		return nil, err
 	}
@@ -103,8 +185,13 @@ func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1
 		WithObject(owner).
 		WithCredentials(credentials).
 		WithTolerations(scanJobTolerations).
+		WithScheduling(scanJobScheduling).
+		WithSandbox(scanJobSandbox).
+		WithImages(scanJobImages).
+		WithOverride(scanJobOverride).
 		WithAnnotations(scanJobAnnotations).
 		WithPodTemplateLabels(scanJobPodTemplateLabels).
+		WithCommonMetadata(commonMetadata).
 		Get()
 
 	if err != nil {
@@ -151,6 +238,35 @@ func (s *Scanner) getVulnerabilityReportsByScanJob(ctx context.Context, job *bat
 		return nil, fmt.Errorf("expected label %s not set", starboard.LabelResourceSpecHash)
 	}
 
+	pluginConfig, err := s.pluginContext.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("getting plugin config: %w", err)
+	}
+
+	ecosystems, err := pluginConfig.GetEcosystems(owner.GetNamespace())
+	if err != nil {
+		return nil, fmt.Errorf("getting ecosystems: %w", err)
+	}
+
+	nameFormat, err := s.config.GetReportNameFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	commonMetadata, err := s.config.GetCommonMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := s.logsReader.GetTerminatedContainersStatusesByJob(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("getting terminated containers statuses: %w", err)
+	}
+	dbMetadata, err := GetDBMetadata(s.plugin, s.pluginContext, statuses)
+	if err != nil {
+		return nil, fmt.Errorf("getting vulnerability database metadata: %w", err)
+	}
+
 	for containerName, containerImage := range containerImages {
 		klog.V(3).Infof("Getting logs for %s container in job: %s/%s", containerName, job.Namespace, job.Name)
 		logsStream, err := s.logsReader.GetLogsByJobAndContainerName(ctx, job, containerName)
@@ -163,12 +279,17 @@ func (s *Scanner) getVulnerabilityReportsByScanJob(ctx context.Context, job *bat
 		}
 
 		_ = logsStream.Close()
+		result.Scan = NewScanMetadata(statuses[containerName], dbMetadata)
 
 		report, err := NewReportBuilder(s.scheme).
 			Controller(owner).
 			Container(containerName).
 			Data(result).
 			PodSpecHash(podSpecHash).
+			Ecosystems(ecosystems).
+			NameFormat(nameFormat).
+			Labels(commonMetadata.Labels).
+			Annotations(commonMetadata.Annotations).
 			Get()
 		if err != nil {
 			return nil, err
@@ -0,0 +1,58 @@
+package vulnerabilityreport
+
+import (
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// IsSubmittedReport returns true if the ClusterVulnerabilityReport opts in to
+// be used instead of scanning the image, i.e. it's labeled with
+// starboard.LabelSubmittedReport set to "true".
+func IsSubmittedReport(report v1alpha1.ClusterVulnerabilityReport) bool {
+	return report.Labels[starboard.LabelSubmittedReport] == "true"
+}
+
+// ValidateSubmittedReport returns problems of the submitted report, or nil if
+// it satisfies the contract of submitted reports:
+//
+//   - report.artifact.digest is an image digest, e.g. sha256:4ed1d8ba4b1d...,
+//     and the starboard.LabelImageDigest label is set to its label value,
+//   - report.scanner.name and report.updateTimestamp are set,
+//   - each vulnerability has an ID and a known severity,
+//   - report.summary counts vulnerabilities by severity.
+//
+// Whether the report opts in with IsSubmittedReport and its age are checked
+// by the operator.
+func ValidateSubmittedReport(report v1alpha1.ClusterVulnerabilityReport) []error {
+	var problems []error
+	digest := report.Report.Artifact.Digest
+	if _, err := v1.NewHash(digest); err != nil {
+		problems = append(problems, fmt.Errorf("invalid report.artifact.digest (%s): %w", digest, err))
+	} else if value := GetImageDigestLabelValue(digest); report.Labels[starboard.LabelImageDigest] != value {
+		problems = append(problems, fmt.Errorf("label %s must be set to %s", starboard.LabelImageDigest, value))
+	}
+	if report.Report.Scanner.Name == "" {
+		problems = append(problems, fmt.Errorf("report.scanner.name is not set"))
+	}
+	if report.Report.UpdateTimestamp.IsZero() {
+		problems = append(problems, fmt.Errorf("report.updateTimestamp is not set"))
+	}
+	for i, vulnerability := range report.Report.Vulnerabilities {
+		if vulnerability.VulnerabilityID == "" {
+			problems = append(problems, fmt.Errorf("vulnerabilityID is not set at index %d of report.vulnerabilities", i))
+		}
+		switch vulnerability.Severity {
+		case v1alpha1.SeverityCritical, v1alpha1.SeverityHigh, v1alpha1.SeverityMedium, v1alpha1.SeverityLow,
+			v1alpha1.SeverityUnknown:
+		default:
+			problems = append(problems, fmt.Errorf("invalid severity (%s) at index %d of report.vulnerabilities", vulnerability.Severity, i))
+		}
+	}
+	if summary := summarize(report.Report.Vulnerabilities, report.Report.Summary.NoneCount); summary != report.Report.Summary {
+		problems = append(problems, fmt.Errorf("report.summary doesn't match report.vulnerabilities"))
+	}
+	return problems
+}
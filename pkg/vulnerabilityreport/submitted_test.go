@@ -0,0 +1,86 @@
+package vulnerabilityreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateSubmittedReport(t *testing.T) {
+	const digest = "sha256:4ed1d8ba4b1d1a2d4b1ad6e2b8a1b0fa5b2d6d5e5a1f8c0e3e3a1c1b0d4c2a9f"
+	newReport := func() v1alpha1.ClusterVulnerabilityReport {
+		return v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "acme-app-4ed1d8ba4b1d",
+				Labels: map[string]string{
+					starboard.LabelSubmittedReport: "true",
+					starboard.LabelImageDigest:     vulnerabilityreport.GetImageDigestLabelValue(digest),
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)),
+				Scanner:         v1alpha1.Scanner{Name: "Trivy"},
+				Artifact:        v1alpha1.Artifact{Repository: "acme/app", Digest: digest},
+				Summary:         v1alpha1.VulnerabilitySummary{HighCount: 1},
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{VulnerabilityID: "CVE-2022-0778", Severity: v1alpha1.SeverityHigh},
+				},
+			},
+		}
+	}
+
+	t.Run("Should accept valid report", func(t *testing.T) {
+		report := newReport()
+		assert.True(t, vulnerabilityreport.IsSubmittedReport(report))
+		assert.Empty(t, vulnerabilityreport.ValidateSubmittedReport(report))
+	})
+
+	t.Run("Should not treat unmarked report as submitted", func(t *testing.T) {
+		report := newReport()
+		delete(report.Labels, starboard.LabelSubmittedReport)
+		assert.False(t, vulnerabilityreport.IsSubmittedReport(report))
+	})
+
+	t.Run("Should reject malformed digest", func(t *testing.T) {
+		report := newReport()
+		report.Report.Artifact.Digest = "4ed1d8ba4b1d"
+		problems := vulnerabilityreport.ValidateSubmittedReport(report)
+		assert.Len(t, problems, 1)
+		assert.Contains(t, problems[0].Error(), "invalid report.artifact.digest (4ed1d8ba4b1d)")
+	})
+
+	t.Run("Should reject digest label of another digest", func(t *testing.T) {
+		report := newReport()
+		report.Labels[starboard.LabelImageDigest] = "2b6a"
+		assert.Equal(t, []string{
+			"label starboard.container.image-digest must be set to 4ed1d8ba4b1d1a2d4b1ad6e2b8a1b0fa5b2d6d5e5a1f8c0e3e3a1c1b0d4c2a9",
+		}, messages(vulnerabilityreport.ValidateSubmittedReport(report)))
+	})
+
+	t.Run("Should reject invalid vulnerabilities and summary", func(t *testing.T) {
+		report := newReport()
+		report.Report.Scanner.Name = ""
+		report.Report.UpdateTimestamp = metav1.Time{}
+		report.Report.Vulnerabilities = append(report.Report.Vulnerabilities, v1alpha1.Vulnerability{Severity: "SEVERE"})
+		assert.Equal(t, []string{
+			"report.scanner.name is not set",
+			"report.updateTimestamp is not set",
+			"vulnerabilityID is not set at index 1 of report.vulnerabilities",
+			"invalid severity (SEVERE) at index 1 of report.vulnerabilities",
+			"report.summary doesn't match report.vulnerabilities",
+		}, messages(vulnerabilityreport.ValidateSubmittedReport(report)))
+	})
+}
+
+func messages(problems []error) []string {
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.Error())
+	}
+	return messages
+}